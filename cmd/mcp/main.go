@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/cloudsql"
@@ -18,6 +19,7 @@ import (
 	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/logging"
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
 	_ "github.com/lib/pq"
 	"log/slog"
 )
@@ -70,6 +72,12 @@ func main() {
 
 	logger.Info("starting OSINTMCP MCP server")
 
+	// Needed to read the enricher's API key when it is stored encrypted
+	if err := secrets.Configure(cfg.Secrets.EncryptionKey); err != nil {
+		logger.Error("failed to configure secret encryption", "error", err)
+		os.Exit(1)
+	}
+
 	// Connect to database
 	dbURL, err := cloudsql.BuildDatabaseURL()
 	if err != nil {
@@ -107,12 +115,23 @@ func main() {
 	if err != nil {
 		logger.Warn("failed to initialize OpenAI enricher, using mock", "error", err)
 		enricher = enrichment.NewMockEnricher()
+		activityRepo.Log(context.Background(), models.ActivityLog{
+			ActivityType: models.ActivityTypeEnrichment,
+			Platform:     "ai",
+			Message:      "OpenAI enricher unavailable, falling back to mock enricher",
+			Details: map[string]interface{}{
+				"enricher": "mock",
+				"error":    err.Error(),
+			},
+		})
 	} else {
+		openaiEnricher.SetEmbeddingModel(cfg.Enrichment.EmbeddingModel)
 		enricher = openaiEnricher
 	}
 
 	// Create event manager
 	lifecycleConfig := eventmanager.DefaultLifecycleConfig()
+	lifecycleConfig.ApplyEventsConfig(cfg.Events)
 	eventManager := eventmanager.NewEventLifecycleManager(
 		sourceRepo,
 		eventRepo,
@@ -217,88 +236,7 @@ func (s *MCPServer) handleToolsList(w http.ResponseWriter, req MCPRequest) {
 		{
 			Name:        "get_events",
 			Description: "Query OSINT events with comprehensive filtering options including search, time range, magnitude/confidence thresholds, categories, source types, tags, entity types, and pagination.",
-			InputSchema: map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"search_query": map[string]interface{}{
-						"type":        "string",
-						"description": "Full-text search across event title and summary",
-					},
-					"since_timestamp": map[string]interface{}{
-						"type":        "string",
-						"format":      "date-time",
-						"description": "Start of time range (RFC3339 format)",
-					},
-					"until_timestamp": map[string]interface{}{
-						"type":        "string",
-						"format":      "date-time",
-						"description": "End of time range (RFC3339 format)",
-					},
-					"min_magnitude": map[string]interface{}{
-						"type":        "number",
-						"minimum":     0,
-						"maximum":     10,
-						"description": "Minimum event magnitude (0-10 scale)",
-					},
-					"min_confidence": map[string]interface{}{
-						"type":        "number",
-						"minimum":     0,
-						"maximum":     1,
-						"description": "Minimum confidence score (0-1 scale)",
-					},
-					"categories": map[string]interface{}{
-						"type": "array",
-						"items": map[string]interface{}{
-							"type": "string",
-							"enum": []string{"geopolitics", "military", "economic", "cyber", "disaster", "terrorism", "diplomacy", "intelligence", "humanitarian", "other"},
-						},
-						"description": "Filter by event categories",
-					},
-					"source_types": map[string]interface{}{
-						"type": "array",
-						"items": map[string]interface{}{
-							"type": "string",
-							"enum": []string{"twitter", "telegram", "reddit", "4chan", "glp", "government", "news_media", "blog", "other"},
-						},
-						"description": "Filter by source types",
-					},
-					"tags": map[string]interface{}{
-						"type":        "array",
-						"items":       map[string]interface{}{"type": "string"},
-						"description": "Filter by event tags",
-					},
-					"status": map[string]interface{}{
-						"type":        "string",
-						"enum":        []string{"pending", "enriched", "published", "archived", "rejected"},
-						"description": "Filter by event status (default: published)",
-					},
-					"page": map[string]interface{}{
-						"type":        "integer",
-						"minimum":     1,
-						"default":     1,
-						"description": "Page number for pagination (1-indexed)",
-					},
-					"limit": map[string]interface{}{
-						"type":        "integer",
-						"minimum":     1,
-						"maximum":     200,
-						"default":     20,
-						"description": "Number of results per page",
-					},
-					"sort_by": map[string]interface{}{
-						"type":        "string",
-						"enum":        []string{"timestamp", "magnitude", "confidence", "created_at", "updated_at"},
-						"default":     "timestamp",
-						"description": "Field to sort results by",
-					},
-					"sort_order": map[string]interface{}{
-						"type":        "string",
-						"enum":        []string{"asc", "desc"},
-						"default":     "desc",
-						"description": "Sort order (ascending or descending)",
-					},
-				},
-			},
+			InputSchema: getEventsInputSchema(),
 		},
 	}
 
@@ -309,6 +247,110 @@ func (s *MCPServer) handleToolsList(w http.ResponseWriter, req MCPRequest) {
 	s.sendResult(w, req.ID, result)
 }
 
+// getEventsInputSchema is the JSON schema advertised for get_events arguments and enforced by
+// validateArguments
+func getEventsInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"search_query": map[string]interface{}{
+				"type":        "string",
+				"description": "Full-text search across event title and summary",
+			},
+			"since_timestamp": map[string]interface{}{
+				"type":        "string",
+				"format":      "date-time",
+				"description": "Start of time range (RFC3339 format)",
+			},
+			"until_timestamp": map[string]interface{}{
+				"type":        "string",
+				"format":      "date-time",
+				"description": "End of time range (RFC3339 format)",
+			},
+			"window": map[string]interface{}{
+				"type":        "string",
+				"description": "Relative time range ending now, as a duration such as '90m' or '24h' or a number of days such as '7d'. Ignored when since_timestamp is given",
+			},
+			"min_magnitude": map[string]interface{}{
+				"type":        "number",
+				"minimum":     0,
+				"maximum":     10,
+				"description": "Minimum event magnitude (0-10 scale)",
+			},
+			"min_confidence": map[string]interface{}{
+				"type":        "number",
+				"minimum":     0,
+				"maximum":     1,
+				"description": "Minimum confidence score (0-1 scale)",
+			},
+			"breaking": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only return breaking events: high magnitude and recent, per the configured breaking thresholds",
+			},
+			"categories": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": models.Categories,
+				},
+				"description": "Filter by event categories",
+			},
+			"source_types": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": models.SourceTypes,
+				},
+				"description": "Filter by source types",
+			},
+			"tags": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Filter by event tags",
+			},
+			"status": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"pending", "enriched", "published", "archived", "rejected"},
+				"description": "Filter by event status (default: published)",
+			},
+			"page": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"default":     1,
+				"description": "Page number for pagination (1-indexed)",
+			},
+			"after_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Only events stored after this event ID (created_at order), oldest first; page and sort_order are ignored and sort_by must be unset or created_at. To poll for new events, pass the next_cursor of the previous response",
+			},
+			"before_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Only events stored before this event ID (created_at order), newest first; page and sort_order are ignored and sort_by must be unset or created_at",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     200,
+				"default":     20,
+				"description": "Number of results per page",
+			},
+			"sort_by": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"timestamp", "magnitude", "confidence", "created_at", "updated_at"},
+				"default":     "timestamp",
+				"description": "Field to sort results by",
+			},
+			"sort_order": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"asc", "desc"},
+				"default":     "desc",
+				"description": "Sort order (ascending or descending)",
+			},
+		},
+	}
+}
+
 // handleToolCall handles MCP tool execution
 func (s *MCPServer) handleToolCall(w http.ResponseWriter, req MCPRequest) {
 	var params struct {
@@ -333,8 +375,16 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, req MCPRequest) {
 		return
 	}
 
+	if errs := validateArguments(getEventsInputSchema(), queryArgs); len(errs) > 0 {
+		s.sendError(w, req.ID, -32602, "Invalid params: "+strings.Join(errs, "; "))
+		return
+	}
+
 	// Convert to EventQuery
 	query, err := s.parseEventQuery(queryArgs)
+	if err == nil {
+		err = query.Validate()
+	}
 	if err != nil {
 		s.sendError(w, req.ID, -32602, "Invalid query: "+err.Error())
 		return
@@ -347,8 +397,8 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, req MCPRequest) {
 		return
 	}
 
-	// Call MCP handler
-	ctx := context.Background()
+	// Call MCP handler; like the public API, MCP serves the default workspace
+	ctx := models.ContextWithWorkspace(context.Background(), models.DefaultWorkspace)
 	resultJSON, err := s.mcpHandler.GetEvents(ctx, string(queryJSON))
 	if err != nil {
 		s.sendError(w, req.ID, -32603, "Query failed: "+err.Error())
@@ -402,6 +452,10 @@ func (s *MCPServer) parseEventQuery(args map[string]interface{}) (*models.EventQ
 		query.UntilTimestamp = &t
 	}
 
+	if window, ok := args["window"].(string); ok {
+		query.Window = window
+	}
+
 	if minMag, ok := args["min_magnitude"].(float64); ok {
 		query.MinMagnitude = &minMag
 	}
@@ -410,6 +464,10 @@ func (s *MCPServer) parseEventQuery(args map[string]interface{}) (*models.EventQ
 		query.MinConfidence = &minConf
 	}
 
+	if breaking, ok := args["breaking"].(bool); ok {
+		query.Breaking = breaking
+	}
+
 	if page, ok := args["page"].(float64); ok {
 		query.Page = int(page)
 	}
@@ -418,6 +476,14 @@ func (s *MCPServer) parseEventQuery(args map[string]interface{}) (*models.EventQ
 		query.Limit = int(limit)
 	}
 
+	if afterID, ok := args["after_id"].(string); ok {
+		query.AfterID = afterID
+	}
+
+	if beforeID, ok := args["before_id"].(string); ok {
+		query.BeforeID = beforeID
+	}
+
 	if sortBy, ok := args["sort_by"].(string); ok {
 		query.SortBy = models.EventSortField(sortBy)
 	}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// validateArguments checks tool arguments against the subset of JSON schema used by the
+// advertised input schemas: property types, enums, numeric bounds, date-time formats and
+// additionalProperties. It returns one message per offending field, sorted by field name.
+// Integers may arrive as any JSON number with no fractional part, e.g. 2.0.
+func validateArguments(schema map[string]interface{}, args map[string]interface{}) []string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	closed := schema["additionalProperties"] == false
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			if closed {
+				errs = append(errs, fmt.Sprintf("%s: unknown argument", name))
+			}
+			continue
+		}
+		if msg := validateValue(property, args[name]); msg != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", name, msg))
+		}
+	}
+	return errs
+}
+
+// validateValue checks a single value against a property schema and describes the first
+// problem found, or returns "" if the value is valid
+func validateValue(property map[string]interface{}, value interface{}) string {
+	switch kind, _ := property["type"].(string); kind {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return "must be a string, got " + jsonType(value)
+		}
+		if property["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return fmt.Sprintf("must be an RFC3339 timestamp, got %q", str)
+			}
+		}
+		if allowed := enumValues(property); allowed != nil && !contains(allowed, str) {
+			return fmt.Sprintf("must be one of %s, got %q", strings.Join(allowed, ", "), str)
+		}
+	case "number", "integer":
+		num, ok := value.(float64)
+		if !ok {
+			return "must be a " + kind + ", got " + jsonType(value)
+		}
+		if kind == "integer" && num != math.Trunc(num) {
+			return fmt.Sprintf("must be an integer, got %v", num)
+		}
+		if min, ok := schemaNumber(property["minimum"]); ok && num < min {
+			return fmt.Sprintf("must be at least %v, got %v", min, num)
+		}
+		if max, ok := schemaNumber(property["maximum"]); ok && num > max {
+			return fmt.Sprintf("must be at most %v, got %v", max, num)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean, got " + jsonType(value)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return "must be an array, got " + jsonType(value)
+		}
+		itemSchema, _ := property["items"].(map[string]interface{})
+		if itemSchema == nil {
+			return ""
+		}
+		for i, item := range items {
+			if msg := validateValue(itemSchema, item); msg != "" {
+				return fmt.Sprintf("item %d %s", i, msg)
+			}
+		}
+	}
+	return ""
+}
+
+// jsonType names the JSON type of a value decoded by encoding/json
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// enumValues returns a property's allowed values, or nil if it has no enum. The enum may be
+// any slice, such as models.Categories.
+func enumValues(property map[string]interface{}) []string {
+	values := reflect.ValueOf(property["enum"])
+	if values.Kind() != reflect.Slice {
+		return nil
+	}
+	allowed := make([]string, values.Len())
+	for i := range allowed {
+		allowed[i] = fmt.Sprint(values.Index(i).Interface())
+	}
+	return allowed
+}
+
+// schemaNumber reads a numeric schema keyword such as minimum, which may be written as an int
+func schemaNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
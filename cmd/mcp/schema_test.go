@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateArguments(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string
+		wantErrs []string
+	}{
+		{
+			name: "valid arguments",
+			args: `{"search_query":"strike","min_magnitude":5,"breaking":true,"categories":["military"],"since_timestamp":"2024-01-01T00:00:00Z","page":2.0,"limit":50,"sort_order":"asc"}`,
+		},
+		{
+			name: "empty arguments",
+			args: `{}`,
+		},
+		{
+			name:     "number sent as string",
+			args:     `{"min_magnitude":"5"}`,
+			wantErrs: []string{"min_magnitude: must be a number, got string"},
+		},
+		{
+			name:     "fractional integer",
+			args:     `{"limit":10.5}`,
+			wantErrs: []string{"limit: must be an integer, got 10.5"},
+		},
+		{
+			name:     "out of range",
+			args:     `{"min_confidence":1.5,"limit":500}`,
+			wantErrs: []string{"limit: must be at most 200, got 500", "min_confidence: must be at most 1, got 1.5"},
+		},
+		{
+			name:     "unknown argument",
+			args:     `{"magnitude":5}`,
+			wantErrs: []string{"magnitude: unknown argument"},
+		},
+		{
+			name:     "semantic search needs an authenticated API",
+			args:     `{"semantic_query":"unrest near energy infrastructure"}`,
+			wantErrs: []string{"semantic_query: unknown argument"},
+		},
+		{
+			name:     "bad enum and timestamp",
+			args:     `{"sort_order":"up","until_timestamp":"yesterday"}`,
+			wantErrs: []string{`sort_order: must be one of asc, desc, got "up"`, `until_timestamp: must be an RFC3339 timestamp, got "yesterday"`},
+		},
+		{
+			name:     "bad array item",
+			args:     `{"categories":["military","weather"],"tags":"war"}`,
+			wantErrs: []string{`categories: item 1 must be one of geopolitics, military, economic, cyber, disaster, terrorism, diplomacy, intelligence, humanitarian, other, got "weather"`, "tags: must be an array, got string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.args), &args); err != nil {
+				t.Fatalf("bad test arguments: %v", err)
+			}
+			errs := validateArguments(getEventsInputSchema(), args)
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("validateArguments() = %q, want %q", errs, tt.wantErrs)
+			}
+		})
+	}
+}
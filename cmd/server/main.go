@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -19,12 +20,14 @@ import (
 	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/eventmanager"
 	"github.com/STRATINT/stratint/internal/forecaster"
+	"github.com/STRATINT/stratint/internal/httpclient"
 	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/ingestion"
 	"github.com/STRATINT/stratint/internal/logging"
 	"github.com/STRATINT/stratint/internal/metrics"
 	"github.com/STRATINT/stratint/internal/models"
 	"github.com/STRATINT/stratint/internal/scheduler"
+	"github.com/STRATINT/stratint/internal/secrets"
 	"github.com/STRATINT/stratint/internal/server"
 	"github.com/STRATINT/stratint/internal/social"
 	"github.com/STRATINT/stratint/internal/strategist"
@@ -47,6 +50,18 @@ func main() {
 
 	logger.Info("starting OSINTMCP")
 
+	// Timeouts, retries and User-Agent for requests to external services
+	httpclient.Configure(cfg.HTTPClient)
+
+	// Key for API keys and connector tokens stored in the database
+	if err := secrets.Configure(cfg.Secrets.EncryptionKey); err != nil {
+		logger.Error("failed to configure secret encryption", "error", err)
+		os.Exit(1)
+	}
+	if !secrets.Enabled() {
+		logger.Warn("SECRETS_ENCRYPTION_KEY not set; API keys and other credentials are stored in plaintext")
+	}
+
 	// Connect to database (supports both local DATABASE_URL and Cloud SQL)
 	dbURL, err := cloudsql.BuildDatabaseURL()
 	if err != nil {
@@ -77,8 +92,18 @@ func main() {
 		logger.Warn("failed to run migrations, continuing anyway", "error", err)
 	}
 
+	// Encrypt credentials saved before the encryption key was set. With a key configured, running
+	// on credentials left in plaintext is not an option.
+	if n, err := database.EncryptStoredSecrets(context.Background(), db); err != nil {
+		logger.Error("failed to encrypt stored secrets", "error", err)
+		os.Exit(1)
+	} else if n > 0 {
+		logger.Info("encrypted stored secrets", "count", n)
+	}
+
 	// Create repositories
 	sourceRepo := database.NewPostgresSourceRepository(db)
+	sourceRepo.SetDedupWindow(cfg.Ingestion.DedupWindow)
 	eventRepo := database.NewPostgresEventRepository(db)
 	trackedAccountRepo := database.NewPostgresTrackedAccountRepository(db)
 	errorRepo := database.NewPostgresIngestionErrorRepository(db)
@@ -92,20 +117,59 @@ func main() {
 
 	// Create inference logger
 	inferenceLogger := inference.NewLogger(inferenceLogRepo, logger)
+	inferenceLogger.SetBudget(inference.LoadBudgetFromEnv())
+	inferenceLogger.SetActivityLogger(activityLogRepo)
 
 	// Create enricher using database configuration
 	var enricher enrichment.Enricher
+	var fallbackEnricher *enrichment.FallbackEnricher
 	var credibilityCache *enrichment.CredibilityCache
-	openaiEnricher, err := enrichment.NewOpenAIClientFromDB(context.Background(), openaiConfigRepo, logger, inferenceLogger)
+	var translator *enrichment.Translator
+	loadEnricher := func(ctx context.Context) (*enrichment.OpenAIClient, error) {
+		client, err := enrichment.NewOpenAIClientFromDB(ctx, openaiConfigRepo, logger, inferenceLogger)
+		if err != nil {
+			return nil, err
+		}
+		// Shared by all enrichment workers, so this bounds OpenAI calls across them
+		client.SetMaxConcurrentCalls(cfg.Enrichment.MaxConcurrentCalls)
+		client.SetMinEntityConfidence(cfg.Enrichment.MinEntityConfidence)
+		client.SetEntityAliases(cfg.Enrichment.EntityAliases)
+		client.SetEmbeddingModel(cfg.Enrichment.EmbeddingModel)
+		return client, nil
+	}
+	openaiEnricher, err := loadEnricher(context.Background())
 	if err != nil {
 		logger.Warn("failed to initialize OpenAI enricher from database, using mock enricher", "error", err)
-		enricher = enrichment.NewMockEnricher()
+		// Switches to the OpenAI enricher when its credentials are reloaded from the admin panel
+		fallbackEnricher = enrichment.NewFallbackEnricher(loadEnricher, logger)
+		enricher = fallbackEnricher
+		// Mock events are meaningless, so leave a trace operators will see in the activity log
+		activityLogRepo.Log(context.Background(), models.ActivityLog{
+			ActivityType: models.ActivityTypeEnrichment,
+			Platform:     "ai",
+			Message:      "OpenAI enricher unavailable, falling back to mock enricher",
+			Details: map[string]interface{}{
+				"enricher": "mock",
+				"error":    err.Error(),
+			},
+		})
 	} else {
 		logger.Info("using OpenAI enricher from database config")
 		enricher = openaiEnricher
 		// Create credibility cache with 24h TTL
 		credibilityCache = enrichment.NewCredibilityCache(openaiEnricher, 24*time.Hour)
+		// Backed by Postgres so scores survive restarts and are shared across instances
+		credibilityCache.SetStore(database.NewCredibilityRepository(db), logger)
+		if warmed, err := credibilityCache.Warm(context.Background()); err != nil {
+			logger.Warn("failed to warm credibility cache", "error", err)
+		} else {
+			logger.Info("warmed credibility cache", "domains", warmed)
+		}
+		// Translate non-English sources for connectors that opt in
+		translator = enrichment.NewTranslator(openaiEnricher, connectorConfigRepo, sourceRepo, logger)
 	}
+	enrichmentWorkers := enrichment.NewWorkerStats(cfg.Enrichment.Workers)
+	retentionScheduler := scheduler.NewRetentionScheduler(database.NewRetentionRepository(db), cfg.Retention, activityLogRepo, logger)
 
 	// Create Twitter poster if OpenAI is available
 	var twitterPoster eventmanager.TwitterPoster
@@ -121,6 +185,7 @@ func main() {
 
 	// Create event manager
 	lifecycleConfig := eventmanager.DefaultLifecycleConfig()
+	lifecycleConfig.ApplyEventsConfig(cfg.Events)
 	eventManager := eventmanager.NewEventLifecycleManager(
 		sourceRepo,
 		eventRepo,
@@ -131,6 +196,7 @@ func main() {
 		logger,
 		lifecycleConfig,
 	)
+	eventManager.SetStatusHistoryRepository(database.NewEventStatusHistoryRepository(db))
 
 	// Scraping functionality removed - using RSS content only
 	logger.Info("application running with RSS-only ingestion (no web scraping)")
@@ -145,6 +211,32 @@ func main() {
 		_, _ = w.Write([]byte(`{"status":"ok"}`))
 	})
 
+	// Readiness endpoint: fails when the database is unreachable and reports the enricher mode
+	// so a mock fallback is visible to probes
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		status, code, dbStatus := "ready", http.StatusOK, "ok"
+		if err := db.PingContext(ctx); err != nil {
+			status, code, dbStatus = "not_ready", http.StatusServiceUnavailable, "unreachable"
+		}
+		mockEnricher := fallbackEnricher != nil && fallbackEnricher.Mock()
+		enricherMode := "openai"
+		if mockEnricher {
+			enricherMode = "mock"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":        status,
+			"database":      dbStatus,
+			"enricher":      enricherMode,
+			"enricher_mock": mockEnricher,
+		})
+	})
+
 	// Service info endpoint
 	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -157,6 +249,20 @@ func main() {
 		logger.Error("failed to init metrics", "error", err)
 		os.Exit(1)
 	}
+	collector.SetMockEnricher(fallbackEnricher != nil)
+	if fallbackEnricher != nil {
+		fallbackEnricher.SetOnLoad(func(*enrichment.OpenAIClient) {
+			collector.SetMockEnricher(false)
+			activityLogRepo.Log(context.Background(), models.ActivityLog{
+				ActivityType: models.ActivityTypeEnrichment,
+				Platform:     "ai",
+				Message:      "OpenAI enricher loaded, replacing mock enricher; restart to enable correlation, embeddings, credibility scoring and translation",
+				Details: map[string]interface{}{
+					"enricher": "openai",
+				},
+			})
+		})
+	}
 	mux.Handle("/metrics", collector.Handler())
 
 	// Load auth configuration
@@ -171,7 +277,7 @@ func main() {
 
 	// Add REST API routes
 	logger.Info("setting up REST API")
-	api.SetupRoutes(mux, db, eventManager, sourceRepo, eventRepo, trackedAccountRepo, errorRepo, thresholdRepo, activityLogRepo, openaiConfigRepo, connectorConfigRepo, twitterRepo, twitterPoster, credibilityCache, enricher, authConfig, fredAPIKey, logger)
+	api.SetupRoutes(mux, db, eventManager, sourceRepo, eventRepo, trackedAccountRepo, errorRepo, thresholdRepo, activityLogRepo, openaiConfigRepo, connectorConfigRepo, twitterRepo, twitterPoster, credibilityCache, enricher, inferenceLogger, authConfig, fredAPIKey, cfg.RateLimit, cfg.Forecasts, cfg.Enrichment, enrichmentWorkers, retentionScheduler, logger)
 
 	// MCP endpoint (Model Context Protocol)
 	mcpHandler := eventmanager.NewMCPHandler(eventManager)
@@ -215,6 +321,11 @@ func main() {
 			} else if len(accounts) > 0 {
 				logger.Debug("checking tracked RSS feeds", "count", len(accounts))
 
+				fetchArticles := false
+				if rssConfig, err := connectorConfigRepo.Get(context.Background(), "rss"); err == nil {
+					fetchArticles = rssConfig.Config[ingestion.FetchArticlesSetting] == "true"
+				}
+
 				for _, account := range accounts {
 					// Check if enough time has elapsed since last fetch
 					now := time.Now()
@@ -245,6 +356,7 @@ func main() {
 							"error", err)
 						continue
 					}
+					rssConnector.SetFeedAuth(account.AccountIdentifier, account.FetchAuth)
 
 					sources, err := rssConnector.Fetch()
 					if err != nil {
@@ -260,28 +372,35 @@ func main() {
 							"feed", account.AccountIdentifier,
 							"count", len(sources))
 
-						storedCount := 0
+						// Items belong to the feed's workspace and are deduplicated within it
+						workspaceCtx := models.ContextWithWorkspace(context.Background(), account.WorkspaceID)
+						storedCount, skippedCount := 0, 0
 						for _, source := range sources {
-							// Check if source already exists (deduplicate by title + URL)
-							existing, err := sourceRepo.GetByTitleAndURL(context.Background(), source.Title, source.URL)
-							if err != nil {
-								logger.Error("failed to check for duplicate source", "error", err)
-								continue
-							}
-							if existing != nil {
-								logger.Debug("skipping duplicate source", "title", source.Title)
-								continue
+							source.AssignToWorkspace(account.WorkspaceID)
+							if fetchArticles {
+								// Only fetch articles for items we don't have yet
+								if existing, err := sourceRepo.GetByURL(workspaceCtx, source.URL); err == nil && existing == nil {
+									if _, err := ingestion.ExpandSummary(context.Background(), &source); err != nil {
+										logger.Warn("failed to fetch article, keeping RSS summary",
+											"url", source.URL,
+											"error", err)
+									}
+								}
 							}
 
-							if err := sourceRepo.Store(context.Background(), source); err != nil {
+							// The repository skips items already stored under the same URL or content
+							stored, err := sourceRepo.Store(workspaceCtx, source)
+							if err != nil {
 								logger.Error("failed to store RSS source", "error", err)
-							} else {
+							} else if stored {
 								storedCount++
+							} else {
+								skippedCount++
 							}
 						}
 
-						if storedCount > 0 {
-							logger.Info("stored new sources", "count", storedCount)
+						if storedCount > 0 || skippedCount > 0 {
+							logger.Info("stored new sources", "count", storedCount, "duplicates_skipped", skippedCount)
 						}
 
 						// Update last fetched timestamp
@@ -334,26 +453,58 @@ func main() {
 			} else if len(accounts) > 0 {
 				logger.Debug("checking tracked Twitter accounts", "count", len(accounts))
 
-				for _, account := range accounts {
+				for i, account := range accounts {
 					sources, err := twitterConnector.FetchAccountTweets(account)
+					if rateLimitErr, ok := ingestion.IsRateLimited(err); ok {
+						// Every remaining request would be refused too; leave them for a later cycle
+						skipped := len(accounts) - i
+						logger.Warn("Twitter rate limited, skipping remaining accounts",
+							"skipped", skipped,
+							"reset_at", rateLimitErr.ResetAt)
+						activityLogRepo.Log(ctx, models.ActivityLog{
+							ActivityType: models.ActivityTypeTwitterRateLimit,
+							Platform:     "twitter",
+							Message:      fmt.Sprintf("Twitter rate limit reached; skipped %d accounts until %s", skipped, rateLimitErr.ResetAt.Format(time.RFC3339)),
+							Details: map[string]interface{}{
+								"skipped_accounts": skipped,
+								"reset_at":         rateLimitErr.ResetAt,
+							},
+						})
+						break
+					}
 					if err != nil {
 						logger.Error("failed to fetch tweets",
 							"account", account.AccountIdentifier,
 							"error", err)
+						ingestionErr := ingestion.NewIngestionError("twitter", models.ErrorTypeTwitterFetchFailed, "https://x.com/"+account.AccountIdentifier, err)
+						if err := errorRepo.Store(ctx, ingestionErr); err != nil {
+							logger.Error("failed to log twitter fetch error", "account", account.AccountIdentifier, "error", err)
+						}
 						continue
 					}
+					ingestion.ResolveTransientErrors(ctx, errorRepo, logger, "twitter", "https://x.com/"+account.AccountIdentifier)
 
 					if len(sources) > 0 {
 						logger.Info("fetched new tweets",
 							"account", account.AccountIdentifier,
 							"count", len(sources))
 
-						// Store sources
+						// Store sources; tweets repeating already stored text are skipped as duplicates
+						storedCount, skippedCount := 0, 0
 						for _, source := range sources {
-							if err := sourceRepo.Store(context.Background(), *source); err != nil {
+							stored, err := sourceRepo.Store(models.ContextWithWorkspace(context.Background(), account.WorkspaceID), *source)
+							if err != nil {
 								logger.Error("failed to store tweet source", "error", err)
+							} else if stored {
+								storedCount++
+							} else {
+								skippedCount++
 							}
 						}
+						logger.Info("stored new tweets",
+							"account", account.AccountIdentifier,
+							"count", storedCount,
+							"duplicates_skipped", skippedCount)
 
 						// Update last fetched ID
 						latestID := ingestion.GetLatestTweetID(sources)
@@ -372,12 +523,23 @@ func main() {
 	// Start forecast scheduler
 	logger.Info("starting forecast scheduler")
 	forecastRepo := database.NewForecastRepository(db)
+	scheduledForecaster := forecaster.NewForecaster(eventRepo, forecastRepo, logger, inferenceLogger)
+	scheduledForecaster.SetActivityLogger(activityLogRepo)
+	scheduledForecaster.SetMaxIterations(cfg.Forecasts.MaxIterations)
+	scheduledForecaster.SetStoreSamples(cfg.Forecasts.StoreSamples)
+	scheduledForecaster.SetMinHeadlines(cfg.Forecasts.MinHeadlines)
+	if openaiEnricher != nil {
+		scheduledForecaster.SetEmbedder(openaiEnricher)
+	}
 	forecastScheduler := scheduler.NewForecastScheduler(
 		forecastRepo,
-		forecaster.NewForecaster(eventRepo, forecastRepo, logger, inferenceLogger),
+		scheduledForecaster,
+		cfg.Forecasts,
 		logger,
 	)
-	go forecastScheduler.Start(context.Background())
+	// Background jobs work across workspaces; request contexts are scoped to one
+	allWorkspaces := models.ContextWithAllWorkspaces(context.Background())
+	go forecastScheduler.Start(allWorkspaces)
 
 	// Start summary scheduler
 	logger.Info("starting summary scheduler")
@@ -390,6 +552,10 @@ func main() {
 		}
 	}
 	summaryExecutor := api.NewSummaryExecutor(summaryRepo, eventRepo, forecastRepo, twitterRepo, summaryTwitterPoster, logger)
+	if cfg.SMTP.Enabled() {
+		summaryExecutor.SetEmailSender(social.NewEmailSender(cfg.SMTP))
+		logger.Info("summary email delivery enabled", "smtp_host", cfg.SMTP.Host, "tls", cfg.SMTP.TLS)
+	}
 	summaryScheduler := scheduler.NewSummaryScheduler(summaryRepo, summaryExecutor, logger)
 	go summaryScheduler.Start(context.Background())
 
@@ -400,171 +566,207 @@ func main() {
 	strategyScheduler := scheduler.NewStrategyScheduler(strategyRepo, strategistEngine, logger)
 	go strategyScheduler.Start(context.Background())
 
-	// Start background enrichment worker with database-level locking
-	logger.Info("starting enrichment worker with database-level locking")
-
-	go func() {
-		// Run continuously with minimal delay between batches
-		time.Sleep(5 * time.Second) // Initial delay
-
-		for {
-			enrichStart := time.Now()
-			ctx := context.Background()
-
-			// Atomically claim sources for enrichment (database-level locking)
-			// This prevents race conditions across multiple Cloud Run instances
-			// Stale claims (>15 min) are automatically reclaimed
-			claimedSources, err := sourceRepo.ClaimSourcesForEnrichment(ctx, 1, 15*time.Minute)
-			if err != nil {
-				logger.Error("failed to claim sources for enrichment", "error", err)
-				time.Sleep(5 * time.Second) // Brief pause on error
-				continue
-			}
-
-			if len(claimedSources) == 0 {
-				// No sources to process, pause before checking again
-				logger.Debug("no sources available for enrichment, pausing")
-				time.Sleep(10 * time.Second)
-				continue
-			}
-
-			logger.Info("claimed sources for enrichment", "count", len(claimedSources))
+	// Start retention scheduler (no-op unless a retention rule is configured)
+	go retentionScheduler.Start(allWorkspaces)
+
+	// Start archive scheduler (no-op unless ARCHIVE_PUBLISHED_EVENT_DAYS is set)
+	archiveScheduler := scheduler.NewArchiveScheduler(eventManager, cfg.Archive, activityLogRepo, logger)
+	go archiveScheduler.Start(allWorkspaces)
+
+	// Start reprocess scheduler, which feeds bulk reprocessing jobs to the enrichment workers in batches
+	reprocessScheduler := scheduler.NewReprocessScheduler(database.NewReprocessJobRepository(db), cfg.Reprocess, activityLogRepo, logger)
+	go reprocessScheduler.Start(allWorkspaces)
+
+	// Start background enrichment workers with database-level locking.
+	// Each worker claims and processes sources independently.
+	logger.Info("starting enrichment workers with database-level locking",
+		"workers", cfg.Enrichment.Workers,
+		"max_concurrent_calls", cfg.Enrichment.MaxConcurrentCalls,
+		"claim_batch_size", cfg.Enrichment.ClaimBatchSize)
+
+	// A batch must finish before its claims go stale and another worker takes them over; at the
+	// defaults that is 10 of the 15 minutes a single claimed source is given. While a batch runs
+	// its claims are refreshed, so only claims left by a crashed worker ever go stale.
+	claimWindow := cfg.Enrichment.ClaimStaleWindow()
+	batchTimeout := cfg.Enrichment.BatchTimeout()
+	heartbeatInterval := cfg.Enrichment.ClaimHeartbeatInterval()
+
+	for workerID := 1; workerID <= cfg.Enrichment.Workers; workerID++ {
+		logger := logger.With("enrichment_worker", workerID)
+		go func() {
+			// Run continuously with minimal delay between batches
+			time.Sleep(5 * time.Second) // Initial delay
+
+			for {
+				enrichStart := time.Now()
+				ctx := allWorkspaces
+
+				// Atomically claim sources for enrichment (database-level locking)
+				// This prevents race conditions across multiple Cloud Run instances
+				// Stale claims are automatically reclaimed
+				claimedSources, err := sourceRepo.ClaimSourcesForEnrichment(ctx, cfg.Enrichment.ClaimBatchSize, claimWindow)
+				if err != nil {
+					logger.Error("failed to claim sources for enrichment", "error", err)
+					time.Sleep(5 * time.Second) // Brief pause on error
+					continue
+				}
 
-			// Create a timeout context for the entire batch (10 minutes max)
-			batchCtx, batchCancel := context.WithTimeout(ctx, 10*time.Minute)
+				if len(claimedSources) == 0 {
+					// No sources to process, pause before checking again
+					logger.Debug("no sources available for enrichment, pausing")
+					time.Sleep(10 * time.Second)
+					continue
+				}
 
-			// Directly enrich the sources we claimed
-			logger.Info("enriching claimed sources", "num_sources", len(claimedSources))
-			events, enrichErr := enricher.EnrichBatch(batchCtx, claimedSources)
-			logger.Info("enrichment batch returned", "num_events", len(events), "has_error", enrichErr != nil)
+				logger.Info("claimed sources for enrichment", "count", len(claimedSources))
+				enrichmentWorkers.Start()
 
-			var eventsPublished, eventsRejected, errorCount int
+				// Create a timeout context for the entire batch
+				batchCtx, batchCancel := context.WithTimeout(ctx, batchTimeout)
 
-			// Track which sources successfully produced events
-			successfulSourceIDs := make(map[string]bool)
-			for _, event := range events {
-				// Each event has a Sources field with the source(s) it came from
-				for _, source := range event.Sources {
-					successfulSourceIDs[source.ID] = true
+				// Keep our claims fresh while enrichment runs
+				claimedIDs := make([]string, len(claimedSources))
+				for i, source := range claimedSources {
+					claimedIDs[i] = source.ID
+				}
+				stopHeartbeat := enrichment.KeepClaimsAlive(batchCtx, sourceRepo, claimedIDs, heartbeatInterval, logger)
+
+				// Directly enrich the sources we claimed
+				logger.Info("enriching claimed sources", "num_sources", len(claimedSources))
+				events, enrichErr := enricher.EnrichBatch(batchCtx, translator.Prepare(batchCtx, claimedSources))
+				stopHeartbeat()
+				logger.Info("enrichment batch returned", "num_events", len(events), "has_error", enrichErr != nil)
+
+				var eventsPublished, eventsRejected, errorCount int
+
+				// Track which sources successfully produced events, and the model that enriched them
+				successfulSourceIDs := make(map[string]bool)
+				enrichmentModels := make(map[string]string)
+				for _, event := range events {
+					// Each event has a Sources field with the source(s) it came from
+					for _, source := range event.Sources {
+						successfulSourceIDs[source.ID] = true
+						enrichmentModels[source.ID] = source.EnrichmentModel
+					}
 				}
-			}
 
-			// Identify and log failures for individual sources
-			for _, source := range claimedSources {
-				if !successfulSourceIDs[source.ID] {
-					// This source failed to produce an event
-					errorCount++
+				// Identify and log failures for individual sources
+				for _, source := range claimedSources {
+					if !successfulSourceIDs[source.ID] {
+						// This source failed to produce an event
+						errorCount++
 
-					// Determine error message
-					errorMsg := "enrichment failed"
-					if enrichErr != nil {
-						errorMsg = enrichErr.Error()
-					}
+						// Determine error message
+						failure := enrichErr
+						if failure == nil {
+							failure = errors.New("enrichment failed")
+						}
+						errorMsg := failure.Error()
 
-					// Update source status as failed
-					if err := sourceRepo.UpdateEnrichmentStatus(ctx, source.ID, models.EnrichmentStatusFailed, errorMsg); err != nil {
-						logger.Error("failed to update enrichment status", "source_id", source.ID, "error", err)
-					}
+						// Update source status as failed
+						if err := sourceRepo.UpdateEnrichmentStatus(ctx, source.ID, models.EnrichmentStatusFailed, errorMsg); err != nil {
+							logger.Error("failed to update enrichment status", "source_id", source.ID, "error", err)
+						}
 
-					// Log enrichment failure to ingestion_errors table
-					ingestionErr := models.IngestionError{
-						Platform:  "enrichment",
-						ErrorType: string(models.ErrorTypeEnrichmentFailed),
-						URL:       source.URL,
-						ErrorMsg:  errorMsg,
-						Metadata:  fmt.Sprintf(`{"source_id":"%s","title":"%s"}`, source.ID, source.Title),
-						CreatedAt: time.Now(),
-						Resolved:  false,
-					}
-					if err := errorRepo.Store(ctx, ingestionErr); err != nil {
-						logger.Error("failed to log enrichment error", "source_id", source.ID, "error", err)
-					} else {
-						logger.Debug("logged enrichment error for source", "source_id", source.ID, "url", source.URL)
+						// Log enrichment failure to ingestion_errors table
+						ingestionErr := ingestion.NewIngestionError("enrichment", models.ErrorTypeEnrichmentFailed, source.URL, failure)
+						ingestionErr.Metadata = fmt.Sprintf(`{"source_id":"%s","title":"%s"}`, source.ID, source.Title)
+						if err := errorRepo.Store(ctx, ingestionErr); err != nil {
+							logger.Error("failed to log enrichment error", "source_id", source.ID, "error", err)
+						} else {
+							logger.Debug("logged enrichment error for source", "source_id", source.ID, "url", source.URL)
+						}
 					}
 				}
-			}
 
-			// If no events were created at all, skip to next iteration
-			if len(events) == 0 {
-				logger.Warn("no events created from batch", "source_count", len(claimedSources))
-				batchCancel()
-				continue
-			}
+				// If no events were created at all, skip to next iteration
+				if len(events) == 0 {
+					logger.Warn("no events created from batch", "source_count", len(claimedSources))
+					batchCancel()
+					enrichmentWorkers.Done()
+					continue
+				}
 
-			// CRITICAL: Mark successful sources as completed IMMEDIATELY after enrichment, before ProcessEvent
-			// This prevents race conditions where another instance claims the same source
-			// while this instance is still processing the event (which can be slow)
-			for _, source := range claimedSources {
-				// Only mark as completed if it successfully produced an event
-				if successfulSourceIDs[source.ID] {
-					if err := sourceRepo.UpdateEnrichmentStatus(ctx, source.ID, models.EnrichmentStatusCompleted, ""); err != nil {
-						logger.Error("failed to mark source as enriched", "source_id", source.ID, "error", err)
-					} else {
-						logger.Debug("marked source as completed", "source_id", source.ID)
+				// CRITICAL: Mark successful sources as completed IMMEDIATELY after enrichment, before ProcessEvent
+				// This prevents race conditions where another instance claims the same source
+				// while this instance is still processing the event (which can be slow)
+				for _, source := range claimedSources {
+					// Only mark as completed if it successfully produced an event
+					if successfulSourceIDs[source.ID] {
+						if err := sourceRepo.UpdateEnrichmentStatus(ctx, source.ID, models.EnrichmentStatusCompleted, ""); err != nil {
+							logger.Error("failed to mark source as enriched", "source_id", source.ID, "error", err)
+						} else {
+							logger.Debug("marked source as completed", "source_id", source.ID)
+						}
+						if err := sourceRepo.SetEnrichmentModel(ctx, source.ID, enrichmentModels[source.ID]); err != nil {
+							logger.Error("failed to record enrichment model", "source_id", source.ID, "error", err)
+						}
 					}
 				}
-			}
 
-			// Process each enriched event through the lifecycle manager
-			for i := range events {
-				event := &events[i]
+				// Process each enriched event through the lifecycle manager
+				for i := range events {
+					event := &events[i]
 
-				// Process the event (this handles correlation, thresholds, and storage)
-				if err := eventManager.ProcessEvent(batchCtx, event); err != nil {
-					logger.Error("event processing failed",
-						"event_id", event.ID,
-						"error", err)
-					errorCount++
-					continue
-				}
+					// Process the event (this handles correlation, thresholds, and storage)
+					if err := eventManager.ProcessEvent(batchCtx, event); err != nil {
+						logger.Error("event processing failed",
+							"event_id", event.ID,
+							"error", err)
+						errorCount++
+						continue
+					}
 
-				// Count by status
-				switch event.Status {
-				case models.EventStatusPublished:
-					eventsPublished++
-				case models.EventStatusRejected:
-					eventsRejected++
+					// Count by status
+					switch event.Status {
+					case models.EventStatusPublished:
+						eventsPublished++
+					case models.EventStatusRejected:
+						eventsRejected++
+					}
 				}
-			}
-
-			// Cancel context after all processing is complete
-			batchCancel()
-
-			// Log completion
-			enrichDuration := int(time.Since(enrichStart).Milliseconds())
-			logger.Info("enrichment batch complete",
-				"sources_ingested", len(claimedSources),
-				"events_enriched", len(events),
-				"events_published", eventsPublished,
-				"events_rejected", eventsRejected,
-				"errors", errorCount,
-				"duration_ms", enrichDuration)
-
-			// Log enrichment activity
-			sourcesIngested := len(claimedSources)
-			activityLogRepo.Log(ctx, models.ActivityLog{
-				ActivityType: models.ActivityTypeEnrichment,
-				Message:      fmt.Sprintf("Enriched %d sources into %d events (%d published, %d rejected)", sourcesIngested, len(events), eventsPublished, eventsRejected),
-				Details: map[string]interface{}{
-					"sources_ingested": sourcesIngested,
-					"events_enriched":  len(events),
-					"events_published": eventsPublished,
-					"events_rejected":  eventsRejected,
-					"error_count":      errorCount,
-				},
-				SourceCount: &sourcesIngested,
-				DurationMs:  &enrichDuration,
-			})
 
-			// No delay if we processed sources, continue immediately
-		}
-	}()
+				// Cancel context after all processing is complete
+				batchCancel()
+				enrichmentWorkers.Done()
+
+				// Log completion
+				enrichDuration := int(time.Since(enrichStart).Milliseconds())
+				logger.Info("enrichment batch complete",
+					"sources_ingested", len(claimedSources),
+					"events_enriched", len(events),
+					"events_published", eventsPublished,
+					"events_rejected", eventsRejected,
+					"errors", errorCount,
+					"duration_ms", enrichDuration)
+
+				// Log enrichment activity
+				sourcesIngested := len(claimedSources)
+				activityLogRepo.Log(ctx, models.ActivityLog{
+					ActivityType: models.ActivityTypeEnrichment,
+					Message:      fmt.Sprintf("Enriched %d sources into %d events (%d published, %d rejected)", sourcesIngested, len(events), eventsPublished, eventsRejected),
+					Details: map[string]interface{}{
+						"sources_ingested": sourcesIngested,
+						"events_enriched":  len(events),
+						"events_published": eventsPublished,
+						"events_rejected":  eventsRejected,
+						"error_count":      errorCount,
+					},
+					SourceCount: &sourcesIngested,
+					DurationMs:  &enrichDuration,
+				})
+
+				// No delay if we processed sources, continue immediately
+			}
+		}()
+	}
 
 	// Scraper worker removed - no longer scraping articles
 
 	// Wrap with SPA middleware to serve frontend for non-API routes
 	logger.Info("setting up static file server for web UI")
-	handler := server.SPAMiddleware(collector.InstrumentHandler(mux), "./web/dist", "./web/dist/index.html")
+	// Requests without a token act in the default workspace; auth middleware rescopes the rest
+	handler := server.SPAMiddleware(collector.InstrumentHandler(auth.DefaultWorkspaceMiddleware(mux)), "./web/dist", "./web/dist/index.html")
 
 	// Start server
 	srv := server.New(cfg.Server, logger, handler)
@@ -1,13 +1,15 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
 )
 
 type ActivityLogHandlers struct {
@@ -23,26 +25,22 @@ func NewActivityLogHandlers(repo *database.ActivityLogRepository, logger *slog.L
 }
 
 // ListActivities handles GET /api/activity-logs
+// Filters: activity_type, platform, since/until (RFC3339) or window (e.g. "1h", "7d"),
+// and limit/offset for paging. The response includes the total number of matching logs.
 func (h *ActivityLogHandlers) ListActivities(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Parse query parameters
-	limitStr := r.URL.Query().Get("limit")
-	limit := 100
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
+	query, err := parseActivityLogQuery(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	activityType := r.URL.Query().Get("activity_type")
-	platform := r.URL.Query().Get("platform")
-
 	// Get activity logs
-	logs, err := h.repo.List(context.Background(), limit, activityType, platform)
+	logs, total, err := h.repo.List(r.Context(), query)
 	if err != nil {
 		h.logger.Error("failed to list activity logs", "error", err)
 		http.Error(w, "Failed to retrieve activity logs", http.StatusInternalServerError)
@@ -53,7 +51,57 @@ func (h *ActivityLogHandlers) ListActivities(w http.ResponseWriter, r *http.Requ
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"logs":  logs,
-		"count": len(logs),
+		"logs":   logs,
+		"count":  len(logs),
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
 	})
 }
+
+// parseActivityLogQuery reads activity log filters from the request. A window is measured back
+// from now and only applies when since is not given.
+func parseActivityLogQuery(r *http.Request, now time.Time) (models.ActivityLogQuery, error) {
+	params := r.URL.Query()
+	query := models.ActivityLogQuery{
+		ActivityType: models.ActivityType(params.Get("activity_type")),
+		Platform:     params.Get("platform"),
+		Limit:        100,
+	}
+
+	if limitStr := params.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			query.Limit = min(l, 1000)
+		}
+	}
+	if offsetStr := params.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			query.Offset = o
+		}
+	}
+
+	for param, dst := range map[string]**time.Time{"since": &query.Since, "until": &query.Until} {
+		if value := params.Get(param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s: must be RFC3339", param)
+			}
+			*dst = &t
+		}
+	}
+
+	if windowStr := params.Get("window"); windowStr != "" && query.Since == nil {
+		window, err := models.ParseWindow(windowStr)
+		if err != nil {
+			return query, fmt.Errorf("invalid window: %v", err)
+		}
+		since := now.Add(-window)
+		query.Since = &since
+	}
+
+	if query.Since != nil && query.Until != nil && !query.Since.Before(*query.Until) {
+		return query, fmt.Errorf("since must be before until")
+	}
+
+	return query, nil
+}
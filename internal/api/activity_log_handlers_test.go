@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestParseActivityLogQuery(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	r := httptest.NewRequest("GET", "/api/activity-logs?activity_type=enrichment&platform=rss&window=1h&limit=50&offset=100", nil)
+	q, err := parseActivityLogQuery(r, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.ActivityType != models.ActivityTypeEnrichment || q.Platform != "rss" {
+		t.Errorf("unexpected filters: %+v", q)
+	}
+	if q.Limit != 50 || q.Offset != 100 {
+		t.Errorf("expected limit 50 offset 100, got %d/%d", q.Limit, q.Offset)
+	}
+	if q.Since == nil || !q.Since.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected since one hour before now, got %v", q.Since)
+	}
+	if q.Until != nil {
+		t.Errorf("expected no until, got %v", q.Until)
+	}
+
+	// An explicit since wins over the window
+	r = httptest.NewRequest("GET", "/api/activity-logs?since=2026-02-01T00:00:00Z&until=2026-02-02T00:00:00Z&window=1h", nil)
+	q, err = parseActivityLogQuery(r, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !q.Since.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) || !q.Until.Equal(time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected range %v - %v", q.Since, q.Until)
+	}
+
+	// Defaults and clamping
+	r = httptest.NewRequest("GET", "/api/activity-logs?limit=5000&offset=-3", nil)
+	q, err = parseActivityLogQuery(r, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Limit != 1000 || q.Offset != 0 || q.Since != nil {
+		t.Errorf("unexpected defaults: %+v", q)
+	}
+
+	for _, bad := range []string{"since=yesterday", "window=soon", "since=2026-02-02T00:00:00Z&until=2026-02-01T00:00:00Z"} {
+		r = httptest.NewRequest("GET", "/api/activity-logs?"+bad, nil)
+		if _, err := parseActivityLogQuery(r, now); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
@@ -1,32 +1,82 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"log/slog"
+
+	"github.com/STRATINT/stratint/internal/auth"
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
 )
 
+// EventArchiver archives events and records who did it
+type EventArchiver interface {
+	ArchiveEvent(ctx context.Context, eventID, actor, reason string) error
+}
+
+// SourceCleaner counts and deletes sources by enrichment status and age
+type SourceCleaner interface {
+	CountByEnrichmentStatus(ctx context.Context, status models.EnrichmentStatus, before, claimedBefore time.Time) (int64, error)
+	DeleteByEnrichmentStatus(ctx context.Context, status models.EnrichmentStatus, before, claimedBefore time.Time) (int64, error)
+}
+
+// RawSourceReader loads the raw content a source was enriched from
+type RawSourceReader interface {
+	GetRawSource(ctx context.Context, id string) (*models.RawSource, error)
+}
+
 // AdminHandler handles admin-only operations
 type AdminHandler struct {
-	db     *sql.DB
-	logger *slog.Logger
+	db         *sql.DB
+	sources    SourceCleaner
+	rawSources RawSourceReader
+	archiver   EventArchiver
+	logger     *slog.Logger
+
+	claimStaleWindow time.Duration // How long enrichment claims stay live; cleanup leaves newer ones alone
 }
 
 // NewAdminHandler creates a new admin handler
 func NewAdminHandler(db *sql.DB, logger *slog.Logger) *AdminHandler {
+	sourceRepo := database.NewPostgresSourceRepository(db)
 	return &AdminHandler{
-		db:     db,
-		logger: logger,
+		db:         db,
+		sources:    sourceRepo,
+		rawSources: sourceRepo,
+		logger:     logger,
 	}
 }
 
-// DeleteAllData permanently deletes all events and sources from the database
+// SetClaimStaleWindow sets how long a source's enrichment claim may belong to a live worker, so
+// cleanup only deletes sources in enrichment whose claim is older
+func (h *AdminHandler) SetClaimStaleWindow(window time.Duration) {
+	h.claimStaleWindow = window
+}
+
+// SetEventArchiver sets the archiver used when reprocessing a source replaces its event
+func (h *AdminHandler) SetEventArchiver(archiver EventArchiver) {
+	h.archiver = archiver
+}
+
+// workspaceCondition limits rows to the workspace the admin's request is scoped to, bound to $n
+// by database.WorkspaceFilter
+func workspaceCondition(column string, n int) string {
+	return fmt.Sprintf("($%[2]d::text IS NULL OR %[1]s = $%[2]d)", column, n)
+}
+
+// DeleteAllData permanently deletes all events and sources in the admin's workspace
 func (h *AdminHandler) DeleteAllData(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -36,18 +86,21 @@ func (h *AdminHandler) DeleteAllData(w http.ResponseWriter, r *http.Request) {
 	h.logger.Warn("Admin initiated DELETE ALL DATA operation")
 
 	ctx := r.Context()
+	workspace := database.WorkspaceFilter(ctx)
+	eventsInWorkspace := "SELECT id FROM events WHERE " + workspaceCondition("workspace_id", 1)
+	sourcesInWorkspace := "SELECT id FROM sources WHERE " + workspaceCondition("workspace_id", 1)
 
 	// Get counts before deletion
 	var eventsCount, sourcesCount int64
 
-	err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM events").Scan(&eventsCount)
+	err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM events WHERE "+workspaceCondition("workspace_id", 1), workspace).Scan(&eventsCount)
 	if err != nil {
 		h.logger.Error("Failed to count events", "error", err)
 		http.Error(w, "Failed to count events", http.StatusInternalServerError)
 		return
 	}
 
-	err = h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources").Scan(&sourcesCount)
+	err = h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE "+workspaceCondition("workspace_id", 1), workspace).Scan(&sourcesCount)
 	if err != nil {
 		h.logger.Error("Failed to count sources", "error", err)
 		http.Error(w, "Failed to count sources", http.StatusInternalServerError)
@@ -64,26 +117,26 @@ func (h *AdminHandler) DeleteAllData(w http.ResponseWriter, r *http.Request) {
 	defer tx.Rollback()
 
 	// Delete all event-related data
-	if _, err := tx.ExecContext(ctx, "DELETE FROM event_entities"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM event_entities WHERE event_id IN ("+eventsInWorkspace+")", workspace); err != nil {
 		h.logger.Error("Failed to delete event_entities", "error", err)
 		http.Error(w, "Failed to delete event entities", http.StatusInternalServerError)
 		return
 	}
 
-	if _, err := tx.ExecContext(ctx, "DELETE FROM event_sources"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM event_sources WHERE event_id IN ("+eventsInWorkspace+") OR source_id IN ("+sourcesInWorkspace+")", workspace); err != nil {
 		h.logger.Error("Failed to delete event_sources", "error", err)
 		http.Error(w, "Failed to delete event sources", http.StatusInternalServerError)
 		return
 	}
 
-	if _, err := tx.ExecContext(ctx, "DELETE FROM events"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM events WHERE "+workspaceCondition("workspace_id", 1), workspace); err != nil {
 		h.logger.Error("Failed to delete events", "error", err)
 		http.Error(w, "Failed to delete events", http.StatusInternalServerError)
 		return
 	}
 
 	// Delete all sources
-	if _, err := tx.ExecContext(ctx, "DELETE FROM sources"); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM sources WHERE "+workspaceCondition("workspace_id", 1), workspace); err != nil {
 		h.logger.Error("Failed to delete sources", "error", err)
 		http.Error(w, "Failed to delete sources", http.StatusInternalServerError)
 		return
@@ -112,26 +165,67 @@ func (h *AdminHandler) DeleteAllData(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// RequeueFailedEnrichments resets failed enrichments back to pending for retry
+// RequeueFilter narrows which failed enrichments are requeued. Empty fields match everything.
+type RequeueFilter struct {
+	Platform      models.SourceType `json:"platform,omitempty"`       // Source type, e.g. "twitter"
+	FailedAfter   *time.Time        `json:"failed_after,omitempty"`   // Only sources that failed at or after this time
+	ErrorContains string            `json:"error_contains,omitempty"` // Case-insensitive substring of the enrichment error
+}
+
+// whereClause builds the WHERE clause and arguments selecting the failed sources that match the filter
+func (f RequeueFilter) whereClause() (string, []interface{}) {
+	conditions := []string{"enrichment_status = 'failed'"}
+	var args []interface{}
+
+	if f.Platform != "" {
+		args = append(args, f.Platform)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if f.FailedAfter != nil {
+		args = append(args, *f.FailedAfter)
+		conditions = append(conditions, fmt.Sprintf("enrichment_failed_at >= $%d", len(args)))
+	}
+	if f.ErrorContains != "" {
+		args = append(args, likeEscaper.Replace(f.ErrorContains))
+		conditions = append(conditions, fmt.Sprintf(`enrichment_error ILIKE '%%' || $%d || '%%' ESCAPE '\'`, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// RequeueFailedEnrichments resets failed enrichments back to pending for retry.
+// An optional JSON body (see RequeueFilter) limits which sources are requeued.
 func (h *AdminHandler) RequeueFailedEnrichments(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	h.logger.Info("Admin initiated requeue of failed enrichments")
+	var filter RequeueFilter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if filter.Platform != "" && !models.ValidSourceType(filter.Platform) {
+		http.Error(w, fmt.Sprintf("Invalid platform: %s", filter.Platform), http.StatusBadRequest)
+		return
+	}
 
-	ctx := r.Context()
+	h.logger.Info("Admin initiated requeue of failed enrichments",
+		"platform", filter.Platform,
+		"failed_after", filter.FailedAfter,
+		"error_contains", filter.ErrorContains,
+	)
 
-	// Get counts before update
-	var failedCount, pendingCount int64
+	ctx := r.Context()
+	where, args := filter.whereClause()
+	args = append(args, database.WorkspaceFilter(ctx))
+	where += " AND " + workspaceCondition("workspace_id", len(args))
 
-	err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE enrichment_status = 'failed'").Scan(&failedCount)
-	if err != nil {
-		h.logger.Error("Failed to count failed enrichments", "error", err)
-		http.Error(w, "Failed to count failed enrichments", http.StatusInternalServerError)
-		return
-	}
+	var pendingCount int64
 
 	// Begin transaction
 	tx, err := h.db.BeginTx(ctx, nil)
@@ -149,8 +243,7 @@ func (h *AdminHandler) RequeueFailedEnrichments(w http.ResponseWriter, r *http.R
 			enrichment_status = 'pending',
 			enrichment_error = NULL,
 			enrichment_claimed_at = NULL
-		WHERE enrichment_status = 'failed'
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		h.logger.Error("Failed to update enrichment status", "error", err)
 		http.Error(w, "Failed to update enrichment status", http.StatusInternalServerError)
@@ -165,7 +258,7 @@ func (h *AdminHandler) RequeueFailedEnrichments(w http.ResponseWriter, r *http.R
 	}
 
 	// Get new pending count
-	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE enrichment_status = 'pending'").Scan(&pendingCount)
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE enrichment_status = 'pending' AND "+workspaceCondition("workspace_id", 1), database.WorkspaceFilter(ctx)).Scan(&pendingCount)
 	if err != nil {
 		h.logger.Error("Failed to count pending enrichments", "error", err)
 		http.Error(w, "Failed to count pending enrichments", http.StatusInternalServerError)
@@ -189,12 +282,169 @@ func (h *AdminHandler) RequeueFailedEnrichments(w http.ResponseWriter, r *http.R
 		"message":        "Failed enrichments requeued successfully",
 		"requeued_count": rowsAffected,
 		"total_pending":  pendingCount,
+		"filter":         filter,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ReprocessSource resets a source to pending so the enrichment worker enriches it again,
+// e.g. to compare a prompt or model change against a known input.
+// With {"archive_event": true} the source's existing event is archived and detached from it.
+func (h *AdminHandler) ReprocessSource(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Path: /api/admin/sources/:id/reprocess
+	sourceID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/admin/sources/"), "/reprocess")
+	if sourceID == "" || strings.Contains(sourceID, "/") {
+		http.Error(w, "Source ID required", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		ArchiveEvent bool `json:"archive_event"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	var status models.EnrichmentStatus
+	var eventID sql.NullString
+	err := h.db.QueryRowContext(ctx, "SELECT enrichment_status, event_id FROM sources WHERE id = $1 AND "+workspaceCondition("workspace_id", 2), sourceID, database.WorkspaceFilter(ctx)).Scan(&status, &eventID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "Source not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get source", "source_id", sourceID, "error", err)
+		http.Error(w, "Failed to get source", http.StatusInternalServerError)
+		return
+	}
+
+	if status == models.EnrichmentStatusEnriching {
+		http.Error(w, "Source is currently being enriched", http.StatusConflict)
+		return
+	}
+
+	// The event is archived only once the reset has committed, so a failed reset never leaves
+	// the event archived with its source still attached
+	archivedEventID := ""
+	if request.ArchiveEvent && eventID.Valid && eventID.String != "" {
+		if h.archiver == nil {
+			http.Error(w, "Event archiving not configured", http.StatusServiceUnavailable)
+			return
+		}
+		archivedEventID = eventID.String
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		h.logger.Error("Failed to begin transaction", "error", err)
+		http.Error(w, "Failed to begin transaction", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if archivedEventID != "" {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM event_sources WHERE event_id = $1 AND source_id = $2", archivedEventID, sourceID); err != nil {
+			h.logger.Error("Failed to detach source from event", "source_id", sourceID, "error", err)
+			http.Error(w, "Failed to detach source from event", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Only clear event_id when the event was archived; otherwise the new enrichment
+	// goes through normal correlation and may update the existing event
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE sources
+		SET
+			enrichment_status = 'pending',
+			enrichment_error = NULL,
+			enriched_at = NULL,
+			enrichment_failed_at = NULL,
+			enrichment_claimed_at = NULL,
+			event_id = CASE WHEN $2 THEN NULL ELSE event_id END
+		WHERE id = $1 AND `+workspaceCondition("workspace_id", 3), sourceID, archivedEventID != "", database.WorkspaceFilter(ctx)); err != nil {
+		h.logger.Error("Failed to reset enrichment status", "source_id", sourceID, "error", err)
+		http.Error(w, "Failed to reset enrichment status", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.logger.Error("Failed to commit transaction", "error", err)
+		http.Error(w, "Failed to commit transaction", http.StatusInternalServerError)
+		return
+	}
+
+	if archivedEventID != "" {
+		actor, ok := auth.GetUserIDFromContext(ctx)
+		if !ok || actor == "" {
+			actor = "unknown"
+		}
+		reason := fmt.Sprintf("source %s reprocessed", sourceID)
+		if err := h.archiver.ArchiveEvent(ctx, archivedEventID, actor, reason); err != nil {
+			h.logger.Error("Failed to archive event", "event_id", archivedEventID, "source_id", sourceID, "error", err)
+			http.Error(w, fmt.Sprintf("Source queued for reprocessing, but failed to archive event %s", archivedEventID), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.logger.Info("Source queued for reprocessing",
+		"source_id", sourceID,
+		"previous_status", status,
+		"archived_event_id", archivedEventID,
+	)
+
+	response := map[string]interface{}{
+		"message":         "Source queued for reprocessing",
+		"source_id":       sourceID,
+		"previous_status": status,
+	}
+	if archivedEventID != "" {
+		response["archived_event_id"] = archivedEventID
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetSourceRaw returns a source's full raw content and enrichment state, for diagnosing feeds
+// that produce bad events
+// GET /api/admin/sources/:id/raw
+func (h *AdminHandler) GetSourceRaw(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sourceID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/admin/sources/"), "/raw")
+	if sourceID == "" || strings.Contains(sourceID, "/") {
+		http.Error(w, "Source ID required", http.StatusBadRequest)
+		return
+	}
+
+	source, err := h.rawSources.GetRawSource(r.Context(), sourceID)
+	if err != nil {
+		h.logger.Error("Failed to get source", "source_id", sourceID, "error", err)
+		http.Error(w, "Failed to get source", http.StatusInternalServerError)
+		return
+	}
+	if source == nil {
+		http.Error(w, "Source not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(source)
+}
+
 // DeleteFailedEnrichments permanently deletes sources with failed enrichment status
 func (h *AdminHandler) DeleteFailedEnrichments(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
@@ -209,7 +459,7 @@ func (h *AdminHandler) DeleteFailedEnrichments(w http.ResponseWriter, r *http.Re
 	// Get count before deletion
 	var failedCount int64
 
-	err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE enrichment_status = 'failed'").Scan(&failedCount)
+	err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE enrichment_status = 'failed' AND "+workspaceCondition("workspace_id", 1), database.WorkspaceFilter(ctx)).Scan(&failedCount)
 	if err != nil {
 		h.logger.Error("Failed to count failed enrichments", "error", err)
 		http.Error(w, "Failed to count failed enrichments", http.StatusInternalServerError)
@@ -238,8 +488,7 @@ func (h *AdminHandler) DeleteFailedEnrichments(w http.ResponseWriter, r *http.Re
 	// Delete sources with failed enrichment status
 	result, err := tx.ExecContext(ctx, `
 		DELETE FROM sources
-		WHERE enrichment_status = 'failed'
-	`)
+		WHERE enrichment_status = 'failed' AND `+workspaceCondition("workspace_id", 1), database.WorkspaceFilter(ctx))
 	if err != nil {
 		h.logger.Error("Failed to delete failed enrichments", "error", err)
 		http.Error(w, "Failed to delete failed enrichments", http.StatusInternalServerError)
@@ -274,21 +523,29 @@ func (h *AdminHandler) DeleteFailedEnrichments(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(response)
 }
 
-// DeletePendingSources permanently deletes sources with pending scrape status
+// DeletePendingSources permanently deletes sources with pending scrape status. An optional
+// older_than_days query parameter limits it to sources created at least that many days ago.
 func (h *AdminHandler) DeletePendingSources(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	h.logger.Info("Admin initiated delete of pending sources")
+	olderThanDays, err := parseOlderThanDays(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
+
+	h.logger.Info("Admin initiated delete of pending sources", "older_than_days", olderThanDays)
 
 	ctx := r.Context()
 
 	// Get count before deletion
 	var pendingCount int64
 
-	err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE scrape_status = 'pending'").Scan(&pendingCount)
+	err = h.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE scrape_status = 'pending' AND created_at < $1 AND "+workspaceCondition("workspace_id", 2), cutoff, database.WorkspaceFilter(ctx)).Scan(&pendingCount)
 	if err != nil {
 		h.logger.Error("Failed to count pending sources", "error", err)
 		http.Error(w, "Failed to count pending sources", http.StatusInternalServerError)
@@ -317,8 +574,7 @@ func (h *AdminHandler) DeletePendingSources(w http.ResponseWriter, r *http.Reque
 	// Delete sources with pending scrape status
 	result, err := tx.ExecContext(ctx, `
 		DELETE FROM sources
-		WHERE scrape_status = 'pending'
-	`)
+		WHERE scrape_status = 'pending' AND created_at < $1 AND `+workspaceCondition("workspace_id", 2), cutoff, database.WorkspaceFilter(ctx))
 	if err != nil {
 		h.logger.Error("Failed to delete pending sources", "error", err)
 		http.Error(w, "Failed to delete pending sources", http.StatusInternalServerError)
@@ -353,6 +609,94 @@ func (h *AdminHandler) DeletePendingSources(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(response)
 }
 
+// parseOlderThanDays reads the optional older_than_days query parameter; 0 (the default)
+// matches sources of any age
+func parseOlderThanDays(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("older_than_days")
+	if raw == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("older_than_days must be a non-negative integer")
+	}
+	return days, nil
+}
+
+// SourceCleanupResponse reports the sources matched by a cleanup and, once confirmed, how many were deleted
+type SourceCleanupResponse struct {
+	EnrichmentStatus models.EnrichmentStatus `json:"enrichment_status"`
+	OlderThanDays    int                     `json:"older_than_days"`
+	Cutoff           time.Time               `json:"cutoff"`
+	Count            int64                   `json:"count"`
+	DeletedCount     int64                   `json:"deleted_count"`
+	DryRun           bool                    `json:"dry_run"`
+}
+
+// CleanupSources deletes sources by enrichment status and age, e.g. pending sources older than
+// 7 days left behind by a misconfigured connector. GET reports how many sources match; DELETE
+// deletes them and requires confirm=true. Sources still backing an event are never deleted, nor
+// are sources in enrichment until their claim is stale.
+func (h *AdminHandler) CleanupSources(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := models.EnrichmentStatus(r.URL.Query().Get("enrichment_status"))
+	if !models.ValidEnrichmentStatus(status) {
+		http.Error(w, "enrichment_status must be one of pending, enriching, completed, failed", http.StatusBadRequest)
+		return
+	}
+	olderThanDays, err := parseOlderThanDays(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	response := SourceCleanupResponse{
+		EnrichmentStatus: status,
+		OlderThanDays:    olderThanDays,
+		Cutoff:           time.Now().AddDate(0, 0, -olderThanDays),
+		DryRun:           r.Method == http.MethodGet,
+	}
+
+	claimedBefore := time.Now().Add(-h.claimStaleWindow)
+	response.Count, err = h.sources.CountByEnrichmentStatus(ctx, status, response.Cutoff, claimedBefore)
+	if err != nil {
+		h.logger.Error("Failed to count sources for cleanup", "enrichment_status", status, "error", err)
+		http.Error(w, "Failed to count sources", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if r.URL.Query().Get("confirm") != "true" {
+			http.Error(w, fmt.Sprintf("%d sources match; add confirm=true to delete them", response.Count), http.StatusBadRequest)
+			return
+		}
+
+		h.logger.Warn("Admin initiated source cleanup",
+			"enrichment_status", status,
+			"older_than_days", olderThanDays,
+			"count", response.Count)
+
+		response.DeletedCount, err = h.sources.DeleteByEnrichmentStatus(ctx, status, response.Cutoff, claimedBefore)
+		if err != nil {
+			h.logger.Error("Failed to delete sources", "enrichment_status", status, "error", err)
+			http.Error(w, "Failed to delete sources", http.StatusInternalServerError)
+			return
+		}
+
+		h.logger.Info("Successfully cleaned up sources",
+			"enrichment_status", status,
+			"deleted_count", response.DeletedCount)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // ListCloudflareDebugFiles lists all Cloudflare debug HTML files in /tmp
 func (h *AdminHandler) ListCloudflareDebugFiles(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -474,11 +818,12 @@ func (h *AdminHandler) GetRecentEnrichments(w http.ResponseWriter, r *http.Reque
 		FROM sources s
 		LEFT JOIN events e ON s.event_id = e.id
 		WHERE s.enrichment_status != 'pending'
+		  AND ` + workspaceCondition("s.workspace_id", 2) + `
 		ORDER BY s.enriched_at DESC NULLS LAST, s.created_at DESC
 		LIMIT $1
 	`
 
-	rows, err := h.db.QueryContext(ctx, query, limit)
+	rows, err := h.db.QueryContext(ctx, query, limit, database.WorkspaceFilter(ctx))
 	if err != nil {
 		h.logger.Error("Failed to query recent enrichments", "error", err)
 		http.Error(w, "Failed to query recent enrichments", http.StatusInternalServerError)
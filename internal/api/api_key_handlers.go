@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/STRATINT/stratint/internal/auth"
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// Length of the key prefix stored for display: "stratint_" plus a few characters of the secret
+const apiKeyDisplayPrefixLen = 13
+
+// APIKeyHandler handles HTTP requests for API key management
+type APIKeyHandler struct {
+	repo   *database.APIKeyRepository
+	logger *slog.Logger
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(repo *database.APIKeyRepository, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ListAPIKeys handles GET /api/admin/api-keys
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	keys, err := h.repo.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list api keys", "error", err)
+		http.Error(w, "Failed to list API keys", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// CreateAPIKey handles POST /api/admin/api-keys
+// The key is returned only in this response.
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Scopes) == 0 {
+		req.Scopes = []string{models.APIKeyScopeRead}
+	}
+	for _, scope := range req.Scopes {
+		if scope != models.APIKeyScopeRead && scope != models.APIKeyScopeWrite {
+			http.Error(w, "scopes must be read or write", http.StatusBadRequest)
+			return
+		}
+	}
+
+	key, keyHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		h.logger.Error("failed to generate api key", "error", err)
+		http.Error(w, "Failed to create API key", http.StatusInternalServerError)
+		return
+	}
+
+	apiKey := models.APIKey{
+		Name:      req.Name,
+		KeyPrefix: key[:apiKeyDisplayPrefixLen],
+		KeyHash:   keyHash,
+		Scopes:    req.Scopes,
+	}
+	if err := h.repo.Create(r.Context(), &apiKey); err != nil {
+		h.logger.Error("failed to create api key", "error", err)
+		http.Error(w, "Failed to create API key", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("api key created", "id", apiKey.ID, "name", apiKey.Name, "scopes", apiKey.Scopes)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.CreateAPIKeyResponse{APIKey: apiKey, Key: key})
+}
+
+// RevokeAPIKey handles DELETE /api/admin/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/api-keys/")
+	if id == "" {
+		http.Error(w, "API key ID required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.repo.Revoke(r.Context(), id); err != nil {
+		if errors.Is(err, database.ErrAPIKeyNotFound) {
+			http.Error(w, "API key not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to revoke api key", "id", id, "error", err)
+		http.Error(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("api key revoked", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/database"
+)
+
+func TestRevokeAPIKey_InvalidIDNotFound(t *testing.T) {
+	// A non-UUID ID is rejected before the database is queried
+	h := NewAPIKeyHandler(database.NewAPIKeyRepository(nil), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	rec := httptest.NewRecorder()
+	h.RevokeAPIKey(rec, httptest.NewRequest(http.MethodDelete, "/api/admin/api-keys/not-a-uuid", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a non-UUID id, got %d", rec.Code)
+	}
+}
@@ -1,37 +1,53 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/auth"
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/google/uuid"
 	"log/slog"
 )
 
 // AuthHandler handles authentication requests
 type AuthHandler struct {
-	config auth.Config
-	logger *slog.Logger
+	config      auth.Config
+	refreshRepo *database.RefreshTokenRepository
+	logger      *slog.Logger
 }
 
 // NewAuthHandler creates a new authentication handler
-func NewAuthHandler(config auth.Config, logger *slog.Logger) *AuthHandler {
+func NewAuthHandler(config auth.Config, refreshRepo *database.RefreshTokenRepository, logger *slog.Logger) *AuthHandler {
 	return &AuthHandler{
-		config: config,
-		logger: logger,
+		config:      config,
+		refreshRepo: refreshRepo,
+		logger:      logger,
 	}
 }
 
 // LoginRequest represents a login request
 type LoginRequest struct {
-	Password string `json:"password"`
+	Password    string `json:"password"`
+	WorkspaceID string `json:"workspace_id,omitempty"` // Workspace the session acts in; defaults to "default"
 }
 
 // LoginResponse represents a login response
 type LoginResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
+	Token                 string    `json:"token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	Role                  string    `json:"role"`
+	WorkspaceID           string    `json:"workspace_id"`
+}
+
+// RefreshRequest represents a token refresh or logout request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // Login handles POST /api/auth/login
@@ -39,7 +55,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers for all responses
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Handle CORS preflight
 	if r.Method == http.MethodOptions {
@@ -57,45 +73,205 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	workspaceID := models.WorkspaceOrDefault(req.WorkspaceID)
+	if !models.ValidWorkspaceID(workspaceID) {
+		http.Error(w, "Invalid workspace_id", http.StatusBadRequest)
+		return
+	}
 
-	// Validate password
-	if req.Password != h.config.AdminPassword {
+	// The password determines the role
+	role := h.config.RoleForPassword(req.Password)
+	if role == "" {
 		h.logger.Warn("failed login attempt", "ip", r.RemoteAddr)
 		// Use a generic error message to prevent username enumeration
 		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
 
-	// Generate JWT token
-	token, err := auth.GenerateToken("admin", h.config.JWTSecret, h.config.TokenDuration)
+	// Each login starts a new refresh token family
+	response, err := h.issueTokens(r.Context(), role, role, workspaceID, uuid.New().String())
 	if err != nil {
 		h.logger.Error("failed to generate token", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	h.logger.Info("successful login", "ip", r.RemoteAddr)
+	// Opportunistically prune expired refresh tokens
+	if _, err := h.refreshRepo.DeleteExpired(r.Context(), time.Now()); err != nil {
+		h.logger.Warn("failed to delete expired refresh tokens", "error", err)
+	}
+
+	h.logger.Info("successful login", "role", role, "workspace_id", workspaceID, "ip", r.RemoteAddr)
 
-	// Return token
+	// Return tokens
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	response := LoginResponse{
-		Token:     token,
-		ExpiresAt: time.Now().Add(h.config.TokenDuration),
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// Refresh handles POST /api/auth/refresh
+// Exchanges a valid refresh token for a new access token and a rotated refresh token.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers for all responses
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+
+	// Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	stored, err := h.refreshRepo.GetByHash(ctx, auth.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		h.logger.Error("failed to look up refresh token", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if stored == nil || time.Now().After(stored.ExpiresAt) {
+		http.Error(w, "Invalid or expired refresh token", http.StatusUnauthorized)
+		return
+	}
+
+	// Refresh tokens are single use. Presenting one that was already rotated means it leaked,
+	// so the whole family is revoked and the user has to log in again.
+	rotated := false
+	if stored.RevokedAt == nil {
+		rotated, err = h.refreshRepo.Revoke(ctx, stored.ID)
+		if err != nil {
+			h.logger.Error("failed to revoke refresh token", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+	if !rotated {
+		h.logger.Warn("reuse of revoked refresh token, revoking family", "family_id", stored.FamilyID, "ip", r.RemoteAddr)
+		if err := h.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			h.logger.Error("failed to revoke refresh token family", "error", err)
+		}
+		http.Error(w, "Invalid or expired refresh token", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := h.issueTokens(ctx, stored.UserID, stored.Role, stored.WorkspaceID, stored.FamilyID)
+	if err != nil {
+		h.logger.Error("failed to generate token", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.logger.Error("failed to encode response", "error", err)
 	}
 }
 
+// Logout handles POST /api/auth/logout
+// Revokes the presented refresh token along with every token rotated from the same login.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers for all responses
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+
+	// Handle CORS preflight
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	stored, err := h.refreshRepo.GetByHash(ctx, auth.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		h.logger.Error("failed to look up refresh token", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Unknown tokens are treated as already logged out
+	if stored != nil {
+		if err := h.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			h.logger.Error("failed to revoke refresh token family", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// issueTokens creates an access token and a new refresh token in the given family
+func (h *AuthHandler) issueTokens(ctx context.Context, userID, role, workspaceID, familyID string) (*LoginResponse, error) {
+	now := time.Now()
+	workspaceID = models.WorkspaceOrDefault(workspaceID)
+
+	token, err := auth.GenerateToken(userID, role, workspaceID, h.config.JWTSecret, h.config.TokenDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshHash, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.RefreshToken{
+		UserID:      userID,
+		Role:        role,
+		FamilyID:    familyID,
+		WorkspaceID: workspaceID,
+		TokenHash:   refreshHash,
+		ExpiresAt:   now.Add(h.config.RefreshTokenDuration),
+	}
+	if err := h.refreshRepo.Create(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		Token:                 token,
+		ExpiresAt:             now.Add(h.config.TokenDuration),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+		Role:                  role,
+		WorkspaceID:           workspaceID,
+	}, nil
+}
+
 // ValidateToken handles GET /api/auth/validate
 func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers for all responses
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Handle CORS preflight
 	if r.Method == http.MethodOptions {
@@ -113,9 +289,14 @@ func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
+	userID, _ := auth.GetUserIDFromContext(r.Context())
+	role, _ := auth.GetRoleFromContext(r.Context())
+	workspaceID, _ := models.WorkspaceFromContext(r.Context())
 	response := map[string]interface{}{
-		"valid":  true,
-		"userID": "admin",
+		"valid":        true,
+		"userID":       userID,
+		"role":         role,
+		"workspace_id": workspaceID,
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -9,19 +9,22 @@ import (
 	"strings"
 
 	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
 )
 
 // ConnectorConfigHandlers manages connector configuration endpoints
 type ConnectorConfigHandlers struct {
-	repo   *database.ConnectorConfigRepository
-	logger *slog.Logger
+	repo               *database.ConnectorConfigRepository
+	trackedAccountRepo models.TrackedAccountRepository
+	logger             *slog.Logger
 }
 
 // NewConnectorConfigHandlers creates connector config handlers
-func NewConnectorConfigHandlers(repo *database.ConnectorConfigRepository, logger *slog.Logger) *ConnectorConfigHandlers {
+func NewConnectorConfigHandlers(repo *database.ConnectorConfigRepository, trackedAccountRepo models.TrackedAccountRepository, logger *slog.Logger) *ConnectorConfigHandlers {
 	return &ConnectorConfigHandlers{
-		repo:   repo,
-		logger: logger,
+		repo:               repo,
+		trackedAccountRepo: trackedAccountRepo,
+		logger:             logger,
 	}
 }
 
@@ -45,7 +48,7 @@ func (h *ConnectorConfigHandlers) ListConnectors(w http.ResponseWriter, r *http.
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Get all connectors from database
 	ctx := context.Background()
@@ -103,7 +106,7 @@ func (h *ConnectorConfigHandlers) ToggleConnector(w http.ResponseWriter, r *http
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Get current config
 	ctx := context.Background()
@@ -114,8 +117,26 @@ func (h *ConnectorConfigHandlers) ToggleConnector(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Toggle enabled status
+	// Toggle enabled status, refusing to enable a connector that would fail every poll
 	newEnabled := !config.Enabled
+	if newEnabled {
+		if err := ValidateConnectorConfig(connectorID, config.Config); err != nil {
+			http.Error(w, "Cannot enable connector until its configuration is fixed:\n"+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if connectorID == "rss" {
+			feeds, err := h.trackedAccountRepo.ListByPlatform("rss", true)
+			if err != nil {
+				h.logger.Error("failed to list RSS feeds", "error", err)
+				http.Error(w, "Failed to check RSS feeds", http.StatusInternalServerError)
+				return
+			}
+			if len(feeds) == 0 {
+				http.Error(w, "Cannot enable RSS connector: add at least one enabled feed first", http.StatusBadRequest)
+				return
+			}
+		}
+	}
 	err = h.repo.SetEnabled(ctx, connectorID, newEnabled)
 	if err != nil {
 		h.logger.Error("failed to toggle connector", "error", err)
@@ -148,7 +169,7 @@ func (h *ConnectorConfigHandlers) GetConnectorConfig(w http.ResponseWriter, r *h
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Get config from database
 	ctx := context.Background()
@@ -162,14 +183,8 @@ func (h *ConnectorConfigHandlers) GetConnectorConfig(w http.ResponseWriter, r *h
 	// Mask sensitive values for security
 	maskedConfig := make(map[string]string)
 	for key, value := range dbConfig.Config {
-		if strings.Contains(strings.ToLower(key), "token") || strings.Contains(strings.ToLower(key), "key") || strings.Contains(strings.ToLower(key), "secret") {
-			if value != "" && len(value) > 4 {
-				maskedConfig[key] = "***" + value[len(value)-4:]
-			} else if value != "" {
-				maskedConfig[key] = "***"
-			} else {
-				maskedConfig[key] = ""
-			}
+		if models.IsSecretSetting(key) {
+			maskedConfig[key] = maskSetting(key, value)
 		} else {
 			maskedConfig[key] = value
 		}
@@ -195,7 +210,7 @@ func (h *ConnectorConfigHandlers) UpdateConnectorConfig(w http.ResponseWriter, r
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Parse request
 	body, err := io.ReadAll(r.Body)
@@ -212,8 +227,23 @@ func (h *ConnectorConfigHandlers) UpdateConnectorConfig(w http.ResponseWriter, r
 		return
 	}
 
-	// Update config in database
 	ctx := context.Background()
+
+	// The UI posts back the masked credentials it was sent; keep the stored value for those
+	if existing, err := h.repo.Get(ctx, connectorID); err == nil {
+		for key, value := range request.Config {
+			if models.IsSecretSetting(key) {
+				request.Config[key] = unmaskSetting(key, value, existing.Config[key])
+			}
+		}
+	}
+
+	if err := ValidateConnectorConfig(connectorID, request.Config); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Update config in database
 	_, err = h.repo.Update(ctx, connectorID, nil, request.Config)
 	if err != nil {
 		h.logger.Error("failed to update connector config", "error", err)
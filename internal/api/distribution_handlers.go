@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+const (
+	defaultMagnitudeWidth   = 1.0
+	defaultConfidenceWidth  = 0.1
+	defaultDistributionDays = 30
+	maxDistributionDays     = 365
+)
+
+// DistributionRepository computes magnitude and confidence histograms.
+type DistributionRepository interface {
+	GetEventDistribution(ctx context.Context, q models.DistributionQuery) (*models.EventDistribution, error)
+}
+
+// DistributionHandler reports how events spread across magnitude and confidence.
+type DistributionHandler struct {
+	repo   DistributionRepository
+	logger *slog.Logger
+}
+
+// NewDistributionHandler creates a new distribution handler.
+func NewDistributionHandler(repo DistributionRepository, logger *slog.Logger) *DistributionHandler {
+	return &DistributionHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// DistributionResponse is an event distribution with the parameters that produced it.
+type DistributionResponse struct {
+	*models.EventDistribution
+	MagnitudeWidth  float64             `json:"magnitude_width"`
+	ConfidenceWidth float64             `json:"confidence_width"`
+	Days            int                 `json:"days"`
+	Status          *models.EventStatus `json:"status,omitempty"`
+}
+
+// GetDistributionHandler returns histograms of event magnitude and confidence, optionally per
+// category, for picking publication thresholds. Archived events are left out unless status
+// asks for them.
+// GET /api/admin/events/distribution?magnitude_width=1&confidence_width=0.1&days=30&status=published&by_category=true
+func (h *DistributionHandler) GetDistributionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	q := models.DistributionQuery{
+		MagnitudeWidth:  defaultMagnitudeWidth,
+		ConfidenceWidth: defaultConfidenceWidth,
+	}
+
+	if v := params.Get("magnitude_width"); v != "" {
+		width, err := strconv.ParseFloat(v, 64)
+		if err != nil || width < 0.1 || width > 5 {
+			http.Error(w, "magnitude_width must be between 0.1 and 5", http.StatusBadRequest)
+			return
+		}
+		q.MagnitudeWidth = width
+	}
+
+	if v := params.Get("confidence_width"); v != "" {
+		width, err := strconv.ParseFloat(v, 64)
+		if err != nil || width < 0.01 || width > 0.5 {
+			http.Error(w, "confidence_width must be between 0.01 and 0.5", http.StatusBadRequest)
+			return
+		}
+		q.ConfidenceWidth = width
+	}
+
+	days := defaultDistributionDays
+	if v := params.Get("days"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 1 || d > maxDistributionDays {
+			http.Error(w, fmt.Sprintf("days must be between 1 and %d", maxDistributionDays), http.StatusBadRequest)
+			return
+		}
+		days = d
+	}
+	q.Since = time.Now().AddDate(0, 0, -days)
+
+	if v := params.Get("status"); v != "" {
+		status := models.EventStatus(v)
+		switch status {
+		case models.EventStatusPending, models.EventStatusEnriched, models.EventStatusPublished,
+			models.EventStatusArchived, models.EventStatusRejected:
+		default:
+			http.Error(w, "status must be pending, enriched, published, archived or rejected", http.StatusBadRequest)
+			return
+		}
+		q.Status = &status
+	}
+
+	q.ByCategory, _ = strconv.ParseBool(params.Get("by_category"))
+
+	dist, err := h.repo.GetEventDistribution(r.Context(), q)
+	if err != nil {
+		h.logger.Error("failed to get event distribution", "error", err)
+		http.Error(w, "Failed to get event distribution", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DistributionResponse{
+		EventDistribution: dist,
+		MagnitudeWidth:    q.MagnitudeWidth,
+		ConfidenceWidth:   q.ConfidenceWidth,
+		Days:              days,
+		Status:            q.Status,
+	})
+}
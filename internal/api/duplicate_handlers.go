@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+const (
+	defaultDuplicateThreshold = 0.6
+	defaultDuplicateDays      = 7
+	maxDuplicateDays          = 30
+	defaultDuplicateLimit     = 20
+	maxDuplicateLimit         = 100
+)
+
+// DuplicateRepository finds likely duplicate events.
+type DuplicateRepository interface {
+	FindDuplicatePairs(ctx context.Context, since time.Time, minSimilarity float64) ([]models.DuplicatePair, error)
+	GetDuplicateEvents(ctx context.Context, ids []string) (map[string]models.DuplicateEvent, error)
+}
+
+// DuplicateHandler handles duplicate event reporting.
+type DuplicateHandler struct {
+	repo   DuplicateRepository
+	logger *slog.Logger
+}
+
+// NewDuplicateHandler creates a new duplicate handler.
+func NewDuplicateHandler(repo DuplicateRepository, logger *slog.Logger) *DuplicateHandler {
+	return &DuplicateHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// DuplicateReportResponse is a page of likely duplicate clusters.
+type DuplicateReportResponse struct {
+	Clusters      []models.DuplicateCluster `json:"clusters"`
+	TotalClusters int                       `json:"total_clusters"`
+	// Events that would disappear if every cluster were merged into one event
+	ExcessEvents int     `json:"excess_events"`
+	Threshold    float64 `json:"threshold"`
+	Days         int     `json:"days"`
+	Page         int     `json:"page"`
+	Limit        int     `json:"limit"`
+}
+
+// GetDuplicatesHandler returns clusters of recent events that are likely duplicates, by title
+// similarity or shared source URLs.
+// GET /api/admin/events/duplicates?threshold=0.6&days=7&page=1&limit=20
+func (h *DuplicateHandler) GetDuplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	threshold := defaultDuplicateThreshold
+	if v := params.Get("threshold"); v != "" {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil || t <= 0 || t > 1 {
+			http.Error(w, "threshold must be between 0 and 1", http.StatusBadRequest)
+			return
+		}
+		threshold = t
+	}
+
+	days := defaultDuplicateDays
+	if v := params.Get("days"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 1 || d > maxDuplicateDays {
+			http.Error(w, "days must be between 1 and 30", http.StatusBadRequest)
+			return
+		}
+		days = d
+	}
+
+	page := 1
+	if v, err := strconv.Atoi(params.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	limit := defaultDuplicateLimit
+	if v, err := strconv.Atoi(params.Get("limit")); err == nil && v > 0 {
+		limit = min(v, maxDuplicateLimit)
+	}
+
+	ctx := r.Context()
+	since := time.Now().AddDate(0, 0, -days)
+
+	pairs, err := h.repo.FindDuplicatePairs(ctx, since, threshold)
+	if err != nil {
+		h.logger.Error("failed to find duplicate events", "error", err)
+		http.Error(w, "Failed to find duplicate events", http.StatusInternalServerError)
+		return
+	}
+
+	clusters := models.ClusterDuplicates(pairs)
+	excess := 0
+	for _, c := range clusters {
+		excess += len(c.EventIDs) - 1
+	}
+
+	start := min((page-1)*limit, len(clusters))
+	end := min(start+limit, len(clusters))
+	pageClusters := clusters[start:end]
+
+	var ids []string
+	for _, c := range pageClusters {
+		ids = append(ids, c.EventIDs...)
+	}
+	if len(ids) > 0 {
+		events, err := h.repo.GetDuplicateEvents(ctx, ids)
+		if err != nil {
+			h.logger.Error("failed to load duplicate events", "error", err)
+			http.Error(w, "Failed to load duplicate events", http.StatusInternalServerError)
+			return
+		}
+		for i := range pageClusters {
+			for _, id := range pageClusters[i].EventIDs {
+				if e, ok := events[id]; ok {
+					pageClusters[i].Events = append(pageClusters[i].Events, e)
+				}
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DuplicateReportResponse{
+		Clusters:      pageClusters,
+		TotalClusters: len(clusters),
+		ExcessEvents:  excess,
+		Threshold:     threshold,
+		Days:          days,
+		Page:          page,
+		Limit:         limit,
+	})
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// EntityTimelineRepository provides time-bucketed event counts for an entity.
+type EntityTimelineRepository interface {
+	GetEntityTimeline(ctx context.Context, q models.EntityTimelineQuery) ([]models.EntityTimelineBucket, error)
+}
+
+// EntityHandler handles entity endpoints.
+type EntityHandler struct {
+	repo   EntityTimelineRepository
+	logger *slog.Logger
+}
+
+// NewEntityHandler creates a new entity handler.
+func NewEntityHandler(repo EntityTimelineRepository, logger *slog.Logger) *EntityHandler {
+	return &EntityHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// EntityTimelineResponse is the response for an entity timeline.
+type EntityTimelineResponse struct {
+	Query   models.EntityTimelineQuery    `json:"query"`
+	Total   int                           `json:"total"`
+	Buckets []models.EntityTimelineBucket `json:"buckets"`
+}
+
+// GetEntityTimelineHandler returns how many published events mentioned an entity per hour or day.
+// GET /api/entities/:name/timeline?granularity=hour|day&category=military&since=...&until=...&weighted=true
+func (h *EntityHandler) GetEntityTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/entities/"), "/timeline")
+	params := r.URL.Query()
+
+	query := models.EntityTimelineQuery{
+		Name:        strings.TrimSpace(name),
+		Granularity: models.TimelineGranularity(params.Get("granularity")),
+		Weighted:    params.Get("weighted") == "true",
+	}
+	if category := params.Get("category"); category != "" {
+		c := models.Category(category)
+		query.Category = &c
+	}
+	for param, dst := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+		if value := params.Get(param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				http.Error(w, "Invalid "+param+": must be RFC3339", http.StatusBadRequest)
+				return
+			}
+			*dst = t
+		}
+	}
+
+	if err := query.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	buckets, err := h.repo.GetEntityTimeline(r.Context(), query)
+	if err != nil {
+		h.logger.Error("failed to get entity timeline", "entity", query.Name, "error", err)
+		http.Error(w, "Failed to get entity timeline", http.StatusInternalServerError)
+		return
+	}
+
+	total := 0
+	for _, b := range buckets {
+		total += b.Count
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(EntityTimelineResponse{
+		Query:   query,
+		Total:   total,
+		Buckets: query.FillGaps(buckets),
+	})
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// Atom 1.0 feed structures
+type AtomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Author  AtomPerson   `xml:"author"`
+	Links   []AtomLink   `xml:"link"`
+	Entries []*AtomEntry `xml:"entry"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []AtomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary"`
+	Categories []AtomCategory `xml:"category"`
+}
+
+// JSON Feed 1.1 structures (https://jsonfeed.org/version/1.1)
+type JSONFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url"`
+	FeedURL     string          `json:"feed_url"`
+	NextURL     string          `json:"next_url,omitempty"`
+	Description string          `json:"description"`
+	Language    string          `json:"language"`
+	Items       []*JSONFeedItem `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            string        `json:"id"`
+	URL           string        `json:"url"`
+	Title         string        `json:"title"`
+	ContentText   string        `json:"content_text"`
+	DatePublished string        `json:"date_published"`
+	DateModified  string        `json:"date_modified,omitempty"`
+	Tags          []string      `json:"tags,omitempty"`
+	Stratint      JSONFeedEvent `json:"_stratint"`
+}
+
+// JSONFeedEvent is the _stratint extension on JSON Feed items: event metadata that has no
+// standard JSON Feed field
+type JSONFeedEvent struct {
+	Category   models.Category  `json:"category"`
+	Magnitude  float64          `json:"magnitude"`
+	Confidence float64          `json:"confidence"`
+	IsBreaking bool             `json:"is_breaking"`
+	Entities   []JSONFeedEntity `json:"entities"`
+}
+
+type JSONFeedEntity struct {
+	Name string            `json:"name"`
+	Type models.EntityType `json:"type"`
+}
+
+// GetAtomFeedHandler returns the events of the RSS feed, with the same filters, as Atom 1.0.
+// GET /api/feed.atom
+func (h *RSSHandler) GetAtomFeedHandler(w http.ResponseWriter, r *http.Request) {
+	query, events, ok := h.feedEvents(w, r)
+	if !ok {
+		return
+	}
+	baseURL := feedBaseURL(r)
+
+	feed := &AtomFeed{
+		ID:      feedPageURL(r, 1),
+		Title:   "OSINTMCP Intelligence Feed" + feedTitleSuffix(query),
+		Updated: feedUpdated(events).Format(time.RFC3339),
+		Author:  AtomPerson{Name: "OSINTMCP"},
+		Links: []AtomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: feedPageURL(r, query.Page)},
+			{Rel: "alternate", Type: "text/html", Href: baseURL},
+		},
+		Entries: make([]*AtomEntry, 0, len(events)),
+	}
+	if next := feedNextURL(r, query, events); next != "" {
+		feed.Links = append(feed.Links, AtomLink{Rel: "next", Type: "application/atom+xml", Href: next})
+	}
+
+	for _, event := range events {
+		feed.Entries = append(feed.Entries, &AtomEntry{
+			ID:         eventURN(event),
+			Title:      event.Title,
+			Links:      []AtomLink{{Rel: "alternate", Href: baseURL + "/api/events/" + event.ID}},
+			Published:  eventPublished(event).Format(time.RFC3339),
+			Updated:    eventModified(event).Format(time.RFC3339),
+			Summary:    event.Summary,
+			Categories: []AtomCategory{{Term: string(event.Category)}},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+
+	w.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		h.logger.Error("failed to encode Atom feed", "error", err)
+	}
+}
+
+// GetJSONFeedHandler returns the events of the RSS feed, with the same filters, as JSON Feed
+// 1.1. Magnitude, confidence and entities are carried in the _stratint item extension.
+// GET /api/feed.json
+func (h *RSSHandler) GetJSONFeedHandler(w http.ResponseWriter, r *http.Request) {
+	query, events, ok := h.feedEvents(w, r)
+	if !ok {
+		return
+	}
+	baseURL := feedBaseURL(r)
+
+	feed := &JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       "OSINTMCP Intelligence Feed" + feedTitleSuffix(query),
+		HomePageURL: baseURL,
+		FeedURL:     feedPageURL(r, query.Page),
+		NextURL:     feedNextURL(r, query, events),
+		Description: "Real-time OSINT intelligence events from OSINTMCP",
+		Language:    "en-US",
+		Items:       make([]*JSONFeedItem, 0, len(events)),
+	}
+
+	for _, event := range events {
+		entities := make([]JSONFeedEntity, 0, len(event.Entities))
+		for _, entity := range event.Entities {
+			entities = append(entities, JSONFeedEntity{Name: entity.Name, Type: entity.Type})
+		}
+		feed.Items = append(feed.Items, &JSONFeedItem{
+			ID:            eventURN(event),
+			URL:           baseURL + "/api/events/" + event.ID,
+			Title:         event.Title,
+			ContentText:   event.Summary,
+			DatePublished: eventPublished(event).Format(time.RFC3339),
+			DateModified:  eventModified(event).Format(time.RFC3339),
+			Tags:          event.Tags,
+			Stratint: JSONFeedEvent{
+				Category:   event.Category,
+				Magnitude:  event.Magnitude,
+				Confidence: event.Confidence.Score,
+				IsBreaking: event.IsBreaking,
+				Entities:   entities,
+			},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(feed); err != nil {
+		h.logger.Error("failed to encode JSON feed", "error", err)
+	}
+}
+
+// eventURN is an event's stable feed identifier, independent of the host serving the feed
+func eventURN(event models.Event) string {
+	return "urn:stratint:event:" + event.ID
+}
+
+// eventPublished is when an event happened, or when it was created for events without a timestamp
+func eventPublished(event models.Event) time.Time {
+	if event.Timestamp.IsZero() {
+		return event.CreatedAt.UTC()
+	}
+	return event.Timestamp.UTC()
+}
+
+// eventModified is when an event last changed, never earlier than when it was published
+func eventModified(event models.Event) time.Time {
+	published := eventPublished(event)
+	if event.UpdatedAt.After(published) {
+		return event.UpdatedAt.UTC()
+	}
+	return published
+}
+
+// feedUpdated is when the newest event in a feed last changed, or now for an empty feed
+func feedUpdated(events []models.Event) time.Time {
+	var updated time.Time
+	for _, event := range events {
+		if modified := eventModified(event); modified.After(updated) {
+			updated = modified
+		}
+	}
+	if updated.IsZero() {
+		return time.Now().UTC()
+	}
+	return updated
+}
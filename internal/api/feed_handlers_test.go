@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func feedTestEvents() []models.Event {
+	timestamp := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	return []models.Event{
+		{
+			ID: "evt-1", Title: "Ransomware hits grid operator", Summary: "Outages reported.",
+			Category: models.CategoryCyber, Magnitude: 7, Confidence: models.Confidence{Score: 0.8},
+			Entities:  []models.Entity{{Name: "GridCo", Type: models.EntityTypeOrganization}},
+			Tags:      []string{"ransomware"},
+			Timestamp: timestamp, UpdatedAt: timestamp.Add(time.Hour),
+		},
+		{ID: "evt-2", Title: "Phishing wave", Category: models.CategoryCyber, Timestamp: timestamp.Add(-time.Hour)},
+	}
+}
+
+func TestGetAtomFeedHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	querier := &stubEventQuerier{events: feedTestEvents()}
+	h := NewRSSHandler(querier, logger)
+
+	rec := httptest.NewRecorder()
+	h.GetAtomFeedHandler(rec, httptest.NewRequest(http.MethodGet, "http://stratint.example/api/feed.atom?categories=cyber&limit=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/atom+xml; charset=utf-8" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	var feed AtomFeed
+	if err := xml.Unmarshal(rec.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	links := make(map[string]string)
+	for _, link := range feed.Links {
+		links[link.Rel] = link.Href
+	}
+	if links["self"] != "http://stratint.example/api/feed.atom?categories=cyber&limit=2" {
+		t.Errorf("unexpected self link %q", links["self"])
+	}
+	if links["next"] != "http://stratint.example/api/feed.atom?categories=cyber&limit=2&page=2" {
+		t.Errorf("unexpected next link %q", links["next"])
+	}
+	if feed.Updated != "2024-03-01T11:30:00Z" {
+		t.Errorf("expected feed updated at the newest change, got %q", feed.Updated)
+	}
+	entry := feed.Entries[0]
+	if entry.ID != "urn:stratint:event:evt-1" || entry.Published != "2024-03-01T10:30:00Z" || entry.Updated != "2024-03-01T11:30:00Z" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	// A short page is the last one
+	rec = httptest.NewRecorder()
+	h.GetAtomFeedHandler(rec, httptest.NewRequest(http.MethodGet, "/api/feed.atom?page=3", nil))
+	if querier.query.Page != 3 {
+		t.Errorf("expected page 3 to be queried, got %d", querier.query.Page)
+	}
+	var lastPage AtomFeed
+	if err := xml.Unmarshal(rec.Body.Bytes(), &lastPage); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	for _, link := range lastPage.Links {
+		if link.Rel == "next" {
+			t.Errorf("unexpected next link on the last page: %q", link.Href)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	h.GetAtomFeedHandler(rec, httptest.NewRequest(http.MethodGet, "/api/feed.atom?page=0", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for page 0, got %d", rec.Code)
+	}
+}
+
+func TestGetJSONFeedHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := NewRSSHandler(&stubEventQuerier{events: feedTestEvents()}, logger)
+
+	rec := httptest.NewRecorder()
+	h.GetJSONFeedHandler(rec, httptest.NewRequest(http.MethodGet, "http://stratint.example/api/feed.json?min_magnitude=6", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/feed+json; charset=utf-8" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	var feed JSONFeed
+	if err := json.NewDecoder(rec.Body).Decode(&feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	if feed.Version != "https://jsonfeed.org/version/1.1" || feed.FeedURL != "http://stratint.example/api/feed.json?min_magnitude=6" {
+		t.Errorf("unexpected feed header %+v", feed)
+	}
+	if feed.NextURL != "" {
+		t.Errorf("expected no next page after a short page, got %q", feed.NextURL)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Items))
+	}
+	item := feed.Items[0]
+	if item.ID != "urn:stratint:event:evt-1" || item.URL != "http://stratint.example/api/events/evt-1" || item.DatePublished != "2024-03-01T10:30:00Z" {
+		t.Errorf("unexpected item %+v", item)
+	}
+	ext := item.Stratint
+	if ext.Magnitude != 7 || ext.Confidence != 0.8 || ext.Category != models.CategoryCyber {
+		t.Errorf("unexpected event extension %+v", ext)
+	}
+	if len(ext.Entities) != 1 || ext.Entities[0].Name != "GridCo" || ext.Entities[0].Type != models.EntityTypeOrganization {
+		t.Errorf("unexpected entities %+v", ext.Entities)
+	}
+}
@@ -1,11 +1,18 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/STRATINT/stratint/internal/database"
 	"github.com/STRATINT/stratint/internal/forecaster"
@@ -17,17 +24,20 @@ import (
 type ForecastHandler struct {
 	forecastRepo *database.ForecastRepository
 	forecaster   *forecaster.Forecaster
+	options      *OptionsAnalysisHandler
 	logger       *slog.Logger
 }
 
 // NewForecastHandler creates a new forecast handler
-func NewForecastHandler(db *sql.DB, eventRepo *database.PostgresEventRepository, logger *slog.Logger, inferenceLogger *inference.Logger) *ForecastHandler {
+func NewForecastHandler(db *sql.DB, eventRepo *database.PostgresEventRepository, activityLogRepo *database.ActivityLogRepository, logger *slog.Logger, inferenceLogger *inference.Logger) *ForecastHandler {
 	forecastRepo := database.NewForecastRepository(db)
 	forecasterInstance := forecaster.NewForecaster(eventRepo, forecastRepo, logger, inferenceLogger)
+	forecasterInstance.SetActivityLogger(activityLogRepo)
 
 	return &ForecastHandler{
 		forecastRepo: forecastRepo,
 		forecaster:   forecasterInstance,
+		options:      NewOptionsAnalysisHandler(logger),
 		logger:       logger,
 	}
 }
@@ -59,27 +69,97 @@ func (h *ForecastHandler) CreateForecast(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "At least one model is required", http.StatusBadRequest)
 		return
 	}
+	if err := ValidateForecastModelWeights(req.Models); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, model := range req.Models {
+		if err := ValidateOpenAIEndpoint(model.Endpoint()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if model.Provider == models.ProviderOpenAICompatible && model.BaseURL == "" {
+			http.Error(w, "Base URL is required for openai_compatible models", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.PredictionType == "" {
+		req.PredictionType = models.PredictionTypePercentile // Default
+	}
+	if !models.ValidPredictionType(req.PredictionType) {
+		http.Error(w, "Prediction type must be one of: percentile, point_estimate, probability", http.StatusBadRequest)
+		return
+	}
+	if req.DisagreementThreshold != nil && *req.DisagreementThreshold <= 0 {
+		http.Error(w, "Disagreement threshold must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.TimeoutMinutes < 0 {
+		http.Error(w, "Timeout minutes cannot be negative", http.StatusBadRequest)
+		return
+	}
+	if err := ValidateForecastCompletionWebhook(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := ValidateForecastMarketSymbol(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := ValidateForecastFixedHeadlines(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.HeadlineSelection == "" {
+		req.HeadlineSelection = models.HeadlineSelectionRecent // Default
+	}
+	if !models.ValidHeadlineSelection(req.HeadlineSelection) {
+		http.Error(w, "Headline selection must be one of: recent, relevant", http.StatusBadRequest)
+		return
+	}
 	if req.HeadlineCount <= 0 {
 		req.HeadlineCount = 500 // Default
 	}
 	if req.Iterations <= 0 {
 		req.Iterations = 1 // Default
 	}
+	if maxIterations := h.forecaster.MaxIterations(); req.Iterations > maxIterations {
+		http.Error(w, fmt.Sprintf("Iterations must be at most %d", maxIterations), http.StatusBadRequest)
+		return
+	}
 
 	ctx := r.Context()
+
+	// A duplicate posts back the masked secrets of the forecast it copies
+	var source *models.Forecast
+	var sourceModels []models.ForecastModel
+	if req.DuplicateOf != "" {
+		var err error
+		if source, sourceModels, err = h.forecastWithModels(ctx, req.DuplicateOf); err != nil {
+			h.logger.Error("Failed to get source forecast", "error", err, "forecast_id", req.DuplicateOf)
+			http.Error(w, "Failed to get source forecast", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := restoreMaskedForecastSecrets(&req, source, sourceModels); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	forecast, err := h.forecastRepo.CreateForecast(ctx, req)
 	if err != nil {
 		h.logger.Error("Failed to create forecast", "error", err)
 		http.Error(w, "Failed to create forecast", http.StatusInternalServerError)
 		return
 	}
+	maskForecastSecrets(forecast)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(forecast)
 }
 
-// ListForecasts handles GET /api/admin/forecasts
+// ListForecasts handles GET /api/admin/forecasts?include_archived=true
 func (h *ForecastHandler) ListForecasts(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -87,12 +167,16 @@ func (h *ForecastHandler) ListForecasts(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := r.Context()
-	forecasts, err := h.forecastRepo.ListForecasts(ctx)
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	forecasts, err := h.forecastRepo.ListForecasts(ctx, includeArchived)
 	if err != nil {
 		h.logger.Error("Failed to list forecasts", "error", err)
 		http.Error(w, "Failed to list forecasts", http.StatusInternalServerError)
 		return
 	}
+	for i := range forecasts {
+		maskForecastSecrets(&forecasts[i])
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -137,20 +221,86 @@ func (h *ForecastHandler) UpdateForecast(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "At least one model is required", http.StatusBadRequest)
 		return
 	}
+	if err := ValidateForecastModelWeights(req.Models); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, model := range req.Models {
+		if err := ValidateOpenAIEndpoint(model.Endpoint()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if model.Provider == models.ProviderOpenAICompatible && model.BaseURL == "" {
+			http.Error(w, "Base URL is required for openai_compatible models", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.PredictionType == "" {
+		req.PredictionType = models.PredictionTypePercentile // Default
+	}
+	if !models.ValidPredictionType(req.PredictionType) {
+		http.Error(w, "Prediction type must be one of: percentile, point_estimate, probability", http.StatusBadRequest)
+		return
+	}
+	if req.DisagreementThreshold != nil && *req.DisagreementThreshold <= 0 {
+		http.Error(w, "Disagreement threshold must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.TimeoutMinutes < 0 {
+		http.Error(w, "Timeout minutes cannot be negative", http.StatusBadRequest)
+		return
+	}
+	if err := ValidateForecastCompletionWebhook(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := ValidateForecastMarketSymbol(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := ValidateForecastFixedHeadlines(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.HeadlineSelection == "" {
+		req.HeadlineSelection = models.HeadlineSelectionRecent // Default
+	}
+	if !models.ValidHeadlineSelection(req.HeadlineSelection) {
+		http.Error(w, "Headline selection must be one of: recent, relevant", http.StatusBadRequest)
+		return
+	}
 	if req.HeadlineCount <= 0 {
 		req.HeadlineCount = 500 // Default
 	}
 	if req.Iterations <= 0 {
 		req.Iterations = 1 // Default
 	}
+	if maxIterations := h.forecaster.MaxIterations(); req.Iterations > maxIterations {
+		http.Error(w, fmt.Sprintf("Iterations must be at most %d", maxIterations), http.StatusBadRequest)
+		return
+	}
 
 	ctx := r.Context()
+
+	// Secrets posted back from GetForecast are masked; keep the stored ones
+	existing, existingModels, err := h.forecastWithModels(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast", "error", err)
+		http.Error(w, "Failed to get forecast", http.StatusInternalServerError)
+		return
+	}
+	if err := restoreMaskedForecastSecrets(&req, existing, existingModels); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	forecast, err := h.forecastRepo.UpdateForecast(ctx, forecastID, req)
 	if err != nil {
 		h.logger.Error("Failed to update forecast", "error", err)
 		http.Error(w, "Failed to update forecast", http.StatusInternalServerError)
 		return
 	}
+	maskForecastSecrets(forecast)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -192,6 +342,8 @@ func (h *ForecastHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to get forecast models", http.StatusInternalServerError)
 		return
 	}
+	maskForecastSecrets(forecast)
+	maskModelKeys(forecastModelKeys(models))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -201,7 +353,41 @@ func (h *ForecastHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// ExecuteForecast handles POST /api/admin/forecasts/:id/execute
+// forecastWithModels returns a forecast, or nil if it doesn't exist, with its models
+func (h *ForecastHandler) forecastWithModels(ctx context.Context, id string) (*models.Forecast, []models.ForecastModel, error) {
+	forecast, err := h.forecastRepo.GetForecast(ctx, id)
+	if err != nil || forecast == nil {
+		return nil, nil, err
+	}
+	forecastModels, err := h.forecastRepo.GetForecastModels(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return forecast, forecastModels, nil
+}
+
+// maskForecastSecrets masks the completion webhook secret of a forecast returned by the admin API
+func maskForecastSecrets(forecast *models.Forecast) {
+	forecast.CompletionWebhookSecret = hideSecret(forecast.CompletionWebhookSecret)
+}
+
+// restoreMaskedForecastSecrets puts back the model API keys and completion webhook secret of
+// stored, the forecast being updated or duplicated, wherever req posts back their masked values
+func restoreMaskedForecastSecrets(req *models.CreateForecastRequest, stored *models.Forecast, storedModels []models.ForecastModel) error {
+	if stored != nil {
+		req.CompletionWebhookSecret = unhideSecret(req.CompletionWebhookSecret, stored.CompletionWebhookSecret)
+	}
+	if isMaskedSecret(req.CompletionWebhookSecret) {
+		return ValidationError{Field: "completion_webhook_secret", Message: "Completion webhook secret is masked; re-enter it"}
+	}
+	return restoreMaskedAPIKeys(forecastModelKeys(req.Models), forecastModelKeys(storedModels))
+}
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header accepted by ExecuteForecast
+const maxIdempotencyKeyLength = 255
+
+// ExecuteForecast handles POST /api/admin/forecasts/:id/execute. An optional Idempotency-Key
+// header makes retries within forecaster.IdempotencyKeyWindow return the run already started.
 func (h *ForecastHandler) ExecuteForecast(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -217,18 +403,39 @@ func (h *ForecastHandler) ExecuteForecast(w http.ResponseWriter, r *http.Request
 	}
 	forecastID := path
 
+	// Retries carrying the same key get the run the first request started
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if len(key) > maxIdempotencyKeyLength {
+		http.Error(w, fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength), http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
-	runID, err := h.forecaster.ExecuteForecast(ctx, forecastID)
+	runID, existing, err := h.forecaster.ExecuteForecastIdempotent(ctx, forecastID, key)
+	if errors.Is(err, forecaster.ErrRunInProgress) || errors.Is(err, forecaster.ErrForecastArchived) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if errors.Is(err, forecaster.ErrTooManyIterations) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to execute forecast", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	message := "Forecast execution started"
+	if existing {
+		message = "Forecast execution already started for this idempotency key"
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Forecast execution started",
+		"message": message,
 		"run_id":  runID,
 	})
 }
@@ -265,7 +472,298 @@ func (h *ForecastHandler) GetForecastRun(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(runDetail)
 }
 
-// ListForecastRuns handles GET /api/admin/forecasts/:id/runs
+// GetForecastSamples handles GET /api/admin/forecasts/runs/:runId/samples, every stored sample of
+// a run. Samples are only stored while FORECAST_STORE_SAMPLES is on.
+func (h *ForecastHandler) GetForecastSamples(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract run ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/runs/")
+	runID := strings.TrimSuffix(path, "/samples")
+	if runID == "" || strings.Contains(runID, "/") {
+		http.Error(w, "Run ID required", http.StatusBadRequest)
+		return
+	}
+
+	samples, err := h.forecastRepo.GetForecastSamples(r.Context(), runID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast samples", "error", err)
+		http.Error(w, "Failed to get forecast samples", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"samples": samples,
+		"count":   len(samples),
+	})
+}
+
+// CancelForecastRun handles POST /api/admin/forecasts/runs/:runId/cancel
+func (h *ForecastHandler) CancelForecastRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract run ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/runs/")
+	path = strings.TrimSuffix(path, "/cancel")
+	if path == "" {
+		http.Error(w, "Run ID required", http.StatusBadRequest)
+		return
+	}
+	runID := path
+
+	ctx := r.Context()
+	runDetail, err := h.forecastRepo.GetForecastRun(ctx, runID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast run", "error", err)
+		http.Error(w, "Failed to get forecast run", http.StatusInternalServerError)
+		return
+	}
+	if runDetail == nil {
+		http.Error(w, "Forecast run not found", http.StatusNotFound)
+		return
+	}
+	if runDetail.Run.Status != "pending" && runDetail.Run.Status != "running" {
+		http.Error(w, "Forecast run is not in progress", http.StatusConflict)
+		return
+	}
+
+	// The run finishes asynchronously once its in-flight calls return. A run that isn't executing
+	// here is only marked as cancelled once its heartbeat shows it was abandoned, e.g. by a
+	// restart; one still executing on another instance must be cancelled there.
+	if !forecaster.CancelRun(runID) {
+		failed, err := h.forecastRepo.FailStaleForecastRun(ctx, runID, "cancelled", time.Now().Add(-forecaster.RunStaleAfter))
+		if err != nil {
+			h.logger.Error("Failed to cancel forecast run", "error", err)
+			http.Error(w, "Failed to cancel forecast run", http.StatusInternalServerError)
+			return
+		}
+		if !failed {
+			http.Error(w, "Forecast run is executing on another instance", http.StatusConflict)
+			return
+		}
+	}
+
+	h.logger.Info("forecast run cancelled", "run_id", runID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Forecast run cancelled",
+		"run_id":  runID,
+	})
+}
+
+// streamPollInterval is how often a run executing outside this process is re-read while streaming
+const streamPollInterval = 5 * time.Second
+
+// streamKeepaliveInterval is how often an idle stream sends a comment to keep proxies from closing it
+const streamKeepaliveInterval = 15 * time.Second
+
+// StreamForecastRun handles GET /api/admin/forecasts/runs/:runId/stream
+// It sends server-sent events as samples and models complete, ending with a "done" event.
+func (h *ForecastHandler) StreamForecastRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract run ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/runs/")
+	path = strings.TrimSuffix(path, "/stream")
+	if path == "" {
+		http.Error(w, "Run ID required", http.StatusBadRequest)
+		return
+	}
+	runID := path
+
+	ctx := r.Context()
+	runDetail, err := h.forecastRepo.GetForecastRun(ctx, runID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast run", "error", err)
+		http.Error(w, "Failed to get forecast run", http.StatusInternalServerError)
+		return
+	}
+	if runDetail == nil {
+		http.Error(w, "Forecast run not found", http.StatusNotFound)
+		return
+	}
+
+	// The stream lasts as long as the run, well past the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("failed to clear write deadline for forecast stream", "run_id", runID, "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+
+	keepalive := time.NewTicker(streamKeepaliveInterval)
+	defer keepalive.Stop()
+
+	events, unsubscribe, ok := forecaster.SubscribeProgress(runID)
+	if !ok {
+		// The run has finished or is executing in another process; follow its stored status
+		h.pollForecastRun(ctx, w, rc, runDetail)
+		return
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive.C:
+			if err := writeKeepalive(w, rc); err != nil {
+				return
+			}
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			if err := writeProgressEvent(w, rc, event); err != nil {
+				return
+			}
+			if event.Type == forecaster.ProgressEventDone {
+				return
+			}
+		}
+	}
+}
+
+// pollForecastRun streams a run from the database, reporting completed models, until it finishes
+func (h *ForecastHandler) pollForecastRun(ctx context.Context, w http.ResponseWriter, rc *http.ResponseController, runDetail *models.ForecastRunDetail) {
+	modelsTotal := 0
+	if forecastModels, err := h.forecastRepo.GetForecastModels(ctx, runDetail.Run.ForecastID); err == nil {
+		modelsTotal = len(forecastModels)
+	}
+
+	poll := time.NewTicker(streamPollInterval)
+	defer poll.Stop()
+
+	for {
+		event := storedRunEvent(runDetail, modelsTotal)
+		if err := writeProgressEvent(w, rc, event); err != nil || event.Type == forecaster.ProgressEventDone {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+
+		detail, err := h.forecastRepo.GetForecastRun(ctx, runDetail.Run.ID)
+		if err != nil {
+			h.logger.Error("Failed to poll forecast run", "run_id", runDetail.Run.ID, "error", err)
+			continue
+		}
+		if detail == nil {
+			// Deleted while streaming
+			return
+		}
+		runDetail = detail
+	}
+}
+
+// storedRunEvent describes a run from its stored state. Sample counts aren't stored, so only
+// model progress is reported.
+func storedRunEvent(runDetail *models.ForecastRunDetail, modelsTotal int) forecaster.ProgressEvent {
+	event := forecaster.ProgressEvent{
+		Type:            forecaster.ProgressEventModel,
+		RunID:           runDetail.Run.ID,
+		ModelsCompleted: len(runDetail.Responses),
+		ModelsTotal:     modelsTotal,
+		Status:          runDetail.Run.Status,
+		Error:           runDetail.Run.ErrorMessage,
+	}
+	if runDetail.Run.Status == "completed" || runDetail.Run.Status == "failed" {
+		event.Type = forecaster.ProgressEventDone
+	}
+	return event
+}
+
+// writeProgressEvent writes a progress event as a server-sent event and flushes it
+func writeProgressEvent(w http.ResponseWriter, rc *http.ResponseController, event forecaster.ProgressEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// writeKeepalive writes a server-sent event comment and flushes it
+func writeKeepalive(w http.ResponseWriter, rc *http.ResponseController) error {
+	if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// CompareForecastRuns handles GET /api/admin/forecasts/:id/compare?run_a=...&run_b=...
+func (h *ForecastHandler) CompareForecastRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract forecast ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/compare")
+	if forecastID == "" {
+		http.Error(w, "Forecast ID required", http.StatusBadRequest)
+		return
+	}
+
+	runAID := r.URL.Query().Get("run_a")
+	runBID := r.URL.Query().Get("run_b")
+	if runAID == "" || runBID == "" {
+		http.Error(w, "run_a and run_b are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	runs := make([]*models.ForecastRunDetail, 0, 2)
+	for _, runID := range []string{runAID, runBID} {
+		runDetail, err := h.forecastRepo.GetForecastRun(ctx, runID)
+		if err != nil {
+			h.logger.Error("Failed to get forecast run", "run_id", runID, "error", err)
+			http.Error(w, "Failed to get forecast run", http.StatusInternalServerError)
+			return
+		}
+		if runDetail == nil || runDetail.Run.ForecastID != forecastID {
+			http.Error(w, "Forecast run not found: "+runID, http.StatusNotFound)
+			return
+		}
+		runs = append(runs, runDetail)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(forecaster.CompareRuns(runs[0], runs[1]))
+}
+
+// Page sizes for a forecast's run history
+const (
+	defaultRunPageSize = 50
+	maxRunPageSize     = 500
+)
+
+// ListForecastRuns handles GET /api/admin/forecasts/:id/runs?limit=50&offset=0, a page of the
+// forecast's runs, newest first, with the total run count
 func (h *ForecastHandler) ListForecastRuns(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -281,8 +779,14 @@ func (h *ForecastHandler) ListForecastRuns(w http.ResponseWriter, r *http.Reques
 	}
 	forecastID := path
 
+	limit, offset, err := runPage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
-	runs, err := h.forecastRepo.ListForecastRuns(ctx, forecastID, 50)
+	runs, total, err := h.forecastRepo.ListForecastRunsPage(ctx, forecastID, limit, offset)
 	if err != nil {
 		h.logger.Error("Failed to list forecast runs", "error", err)
 		http.Error(w, "Failed to list forecast runs", http.StatusInternalServerError)
@@ -292,11 +796,33 @@ func (h *ForecastHandler) ListForecastRuns(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"runs":  runs,
-		"count": len(runs),
+		"runs":     runs,
+		"count":    len(runs),
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"has_more": offset+len(runs) < total,
 	})
 }
 
+// runPage reads ?limit= (1-500, default 50) and ?offset= (default 0) for paging through runs
+func runPage(r *http.Request) (limit, offset int, err error) {
+	limit = defaultRunPageSize
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxRunPageSize {
+			return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxRunPageSize)
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("offset must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
 // GetForecastHistory handles GET /api/admin/forecasts/:id/history
 func (h *ForecastHandler) GetForecastHistory(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -329,6 +855,167 @@ func (h *ForecastHandler) GetForecastHistory(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+// forecastExportFlushEvery is how many points a history export writes between flushes
+const forecastExportFlushEvery = 500
+
+// forecastHistoryCSVHeader is the header row of CSV forecast history exports
+var forecastHistoryCSVHeader = []string{
+	"run_id", "run_at", "completed_at", "headline_count",
+	"p10", "p25", "p50", "p75", "p90", "point_estimate", "probability",
+	"model_count", "consensus_level",
+}
+
+// ExportForecastHistory handles GET /api/admin/forecasts/:id/history/export?format=csv|json
+// and streams every completed run's aggregated result, oldest first. CSV is the default.
+func (h *ForecastHandler) ExportForecastHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/history/export")
+	if forecastID == "" || strings.Contains(forecastID, "/") {
+		http.Error(w, "Forecast ID required", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		http.Error(w, "Format must be csv or json", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	forecast, err := h.forecastRepo.GetForecast(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast", "error", err)
+		http.Error(w, "Failed to get forecast", http.StatusInternalServerError)
+		return
+	}
+	if forecast == nil {
+		http.Error(w, "Forecast not found", http.StatusNotFound)
+		return
+	}
+
+	contentType := "text/csv"
+	if format == "json" {
+		contentType = "application/json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="forecast-%s-history.%s"`, forecastID, format))
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	stream := func(fn func(models.ForecastHistoryPoint) error) error {
+		return h.forecastRepo.StreamForecastHistory(ctx, forecastID, fn)
+	}
+	// Headers are already sent, so a failure part way through can only truncate the file
+	if err := writeForecastHistoryExport(w, format, stream); err != nil {
+		h.logger.Error("Failed to export forecast history", "forecast_id", forecastID, "error", err)
+	}
+}
+
+// writeForecastHistoryExport writes each point stream yields to w as CSV rows or as elements of
+// a JSON array, flushing periodically so large histories aren't buffered
+func writeForecastHistoryExport(w io.Writer, format string, stream func(func(models.ForecastHistoryPoint) error) error) error {
+	flusher, _ := w.(http.Flusher)
+	written := 0
+	// flush counts a written point and periodically pushes buffered output to the client
+	flush := func(buffered func()) {
+		written++
+		if written%forecastExportFlushEvery == 0 {
+			if buffered != nil {
+				buffered()
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	if format == "json" {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		err := stream(func(point models.ForecastHistoryPoint) error {
+			if written > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			data, err := json.Marshal(point)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			flush(nil)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "]\n")
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(forecastHistoryCSVHeader); err != nil {
+		return err
+	}
+	err := stream(func(point models.ForecastHistoryPoint) error {
+		completedAt := ""
+		if point.CompletedAt != nil {
+			completedAt = point.CompletedAt.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			point.RunID,
+			point.RunAt.UTC().Format(time.RFC3339),
+			completedAt,
+			strconv.Itoa(point.HeadlineCount),
+			csvFloat(point.P10), csvFloat(point.P25), csvFloat(point.P50), csvFloat(point.P75), csvFloat(point.P90),
+			csvFloat(point.PointEstimate),
+			csvFloat(point.Probability),
+			strconv.Itoa(point.ModelCount),
+			csvFloat(point.ConsensusLevel),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		flush(cw.Flush)
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvFloat formats an optional value for CSV, leaving the cell empty when it is unset
+func csvFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// ohlcMetric returns the metric an OHLC history request charts, from ?metric= (default p50)
+func ohlcMetric(r *http.Request) (string, error) {
+	metric := r.URL.Query().Get("metric")
+	if metric == "" {
+		return database.DefaultForecastMetric, nil
+	}
+	if !database.ValidForecastMetric(metric) {
+		return "", fmt.Errorf("metric must be one of: %s", strings.Join(database.ForecastMetricNames(), ", "))
+	}
+	return metric, nil
+}
+
 // GetForecastHistoryDaily handles GET /api/admin/forecasts/:id/history/daily
 func (h *ForecastHandler) GetForecastHistoryDaily(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -346,7 +1033,13 @@ func (h *ForecastHandler) GetForecastHistoryDaily(w http.ResponseWriter, r *http
 	forecastID := path
 
 	ctx := r.Context()
-	ohlcData, err := h.forecastRepo.GetForecastHistoryDaily(ctx, forecastID)
+	metric, err := ohlcMetric(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ohlcData, err := h.forecastRepo.GetForecastOHLC(ctx, forecastID, metric, database.OHLCDaily)
 	if err != nil {
 		h.logger.Error("Failed to get daily OHLC data", "error", err)
 		http.Error(w, "Failed to get daily OHLC data", http.StatusInternalServerError)
@@ -378,7 +1071,13 @@ func (h *ForecastHandler) GetForecastHistory4Hour(w http.ResponseWriter, r *http
 	forecastID := path
 
 	ctx := r.Context()
-	ohlcData, err := h.forecastRepo.GetForecastHistory4Hour(ctx, forecastID)
+	metric, err := ohlcMetric(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ohlcData, err := h.forecastRepo.GetForecastOHLC(ctx, forecastID, metric, database.OHLCFourHour)
 	if err != nil {
 		h.logger.Error("Failed to get 4-hour OHLC data", "error", err)
 		http.Error(w, "Failed to get 4-hour OHLC data", http.StatusInternalServerError)
@@ -393,6 +1092,72 @@ func (h *ForecastHandler) GetForecastHistory4Hour(w http.ResponseWriter, r *http
 	})
 }
 
+// GetForecastHistoryBars handles GET /api/admin/forecasts/:id/history/bars
+func (h *ForecastHandler) GetForecastHistoryBars(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/history/bars")
+	if forecastID == "" {
+		http.Error(w, "Forecast ID required", http.StatusBadRequest)
+		return
+	}
+
+	h.writeHistoryBars(w, r, forecastID)
+}
+
+// writeHistoryBars responds with the OHLC bars a /history/bars request asks for: bars of a named
+// interval (hourly, 4h, daily or weekly; default daily), or of interval_seconds when given
+func (h *ForecastHandler) writeHistoryBars(w http.ResponseWriter, r *http.Request, forecastID string) {
+	metric, err := ohlcMetric(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	var ohlcData []database.DailyOHLC
+	if v := r.URL.Query().Get("interval_seconds"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < database.MinBucketSeconds || seconds > database.MaxBucketSeconds {
+			http.Error(w, fmt.Sprintf("interval_seconds must be between %d and %d", database.MinBucketSeconds, database.MaxBucketSeconds), http.StatusBadRequest)
+			return
+		}
+		ohlcData, err = h.forecastRepo.GetForecastHistoryBucketed(ctx, forecastID, metric, seconds)
+		if err != nil {
+			h.logger.Error("Failed to get OHLC data", "interval_seconds", seconds, "error", err)
+			http.Error(w, "Failed to get OHLC data", http.StatusInternalServerError)
+			return
+		}
+	} else {
+		interval := database.OHLCInterval(r.URL.Query().Get("interval"))
+		if interval == "" {
+			interval = database.OHLCDaily
+		}
+		if !database.ValidOHLCInterval(interval) {
+			http.Error(w, "interval must be one of: hourly, 4h, daily, weekly", http.StatusBadRequest)
+			return
+		}
+		ohlcData, err = h.forecastRepo.GetForecastOHLC(ctx, forecastID, metric, interval)
+		if err != nil {
+			h.logger.Error("Failed to get OHLC data", "interval", interval, "error", err)
+			http.Error(w, "Failed to get OHLC data", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":  ohlcData,
+		"count": len(ohlcData),
+	})
+}
+
 // UpdateForecastSchedule handles PUT /api/admin/forecasts/:id/schedule
 func (h *ForecastHandler) UpdateForecastSchedule(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
@@ -589,7 +1354,8 @@ func (h *ForecastHandler) UpdateForecastDisplayOrder(w http.ResponseWriter, r *h
 	})
 }
 
-// DeleteForecast handles DELETE /api/admin/forecasts/:id
+// DeleteForecast handles DELETE /api/admin/forecasts/:id. The forecast is archived rather than
+// deleted: it disappears from lists and stops running, but keeps its history and can be restored.
 func (h *ForecastHandler) DeleteForecast(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -605,7 +1371,92 @@ func (h *ForecastHandler) DeleteForecast(w http.ResponseWriter, r *http.Request)
 	forecastID := path
 
 	ctx := r.Context()
-	err := h.forecastRepo.DeleteForecast(ctx, forecastID)
+	err := h.forecastRepo.ArchiveForecast(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to archive forecast", "error", err)
+		if err.Error() == "forecast not found" {
+			http.Error(w, "Forecast not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to archive forecast", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Forecast archived successfully",
+	})
+}
+
+// RestoreForecast handles POST /api/admin/forecasts/:id/restore
+func (h *ForecastHandler) RestoreForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract ID from URL path like /api/admin/forecasts/:id/restore
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/restore")
+	if forecastID == "" || strings.Contains(forecastID, "/") {
+		http.Error(w, "Invalid forecast ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	err := h.forecastRepo.RestoreForecast(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to restore forecast", "error", err)
+		if err.Error() == "forecast not found" {
+			http.Error(w, "Forecast not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to restore forecast", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Forecast restored successfully",
+	})
+}
+
+// PermanentlyDeleteForecast handles DELETE /api/admin/forecasts/:id/permanent, removing a
+// forecast with all its runs and results. Only archived forecasts can be deleted, so a forecast
+// is never lost to a single click.
+func (h *ForecastHandler) PermanentlyDeleteForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract ID from URL path like /api/admin/forecasts/:id/permanent
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/permanent")
+	if forecastID == "" || strings.Contains(forecastID, "/") {
+		http.Error(w, "Invalid forecast ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	forecast, err := h.forecastRepo.GetForecast(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast", "error", err)
+		http.Error(w, "Failed to delete forecast", http.StatusInternalServerError)
+		return
+	}
+	if forecast == nil {
+		http.Error(w, "Forecast not found", http.StatusNotFound)
+		return
+	}
+	if forecast.ArchivedAt == nil {
+		http.Error(w, "Forecast must be archived before it is permanently deleted", http.StatusConflict)
+		return
+	}
+
+	err = h.forecastRepo.DeleteForecast(ctx, forecastID)
 	if err != nil {
 		h.logger.Error("Failed to delete forecast", "error", err)
 		if err.Error() == "forecast not found" {
@@ -619,10 +1470,55 @@ func (h *ForecastHandler) DeleteForecast(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Forecast deleted successfully",
+		"message": "Forecast permanently deleted",
 	})
 }
 
+// HandleForecastConsistency reports forecast runs, responses, results and model configs left
+// orphaned or incomplete (GET, a dry run) or repairs them (POST).
+// GET/POST /api/admin/forecasts/consistency
+func (h *ForecastHandler) HandleForecastConsistency(w http.ResponseWriter, r *http.Request) {
+	var (
+		report *models.ForecastConsistencyReport
+		err    error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		report, err = h.forecastRepo.CheckForecastConsistency(r.Context())
+	case http.MethodPost:
+		report, err = h.forecastRepo.RepairForecastConsistency(r.Context())
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Forecast consistency check failed", "repair", r.Method == http.MethodPost, "error", err)
+		http.Error(w, "Forecast consistency check failed", http.StatusInternalServerError)
+		return
+	}
+
+	if !report.DryRun {
+		h.logger.Info("Repaired forecast data",
+			"orphaned_runs", report.OrphanedRuns,
+			"orphaned_model_responses", report.OrphanedModelResponses,
+			"orphaned_results", report.OrphanedResults,
+			"orphaned_models", report.OrphanedModels,
+			"completed_runs_without_result", report.CompletedRunsWithoutResult)
+		for _, repaired := range report.Repaired {
+			h.logger.Info("Repaired forecast row", "table", repaired.Table, "id", repaired.ID, "workspace", repaired.WorkspaceID)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(report)
+}
+
+// publiclyVisible reports whether a forecast may be shown on public endpoints
+func publiclyVisible(forecast *models.Forecast) bool {
+	return forecast != nil && forecast.Public && forecast.ArchivedAt == nil
+}
+
 // ListPublicForecasts handles GET /api/forecasts
 func (h *ForecastHandler) ListPublicForecasts(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -638,6 +1534,17 @@ func (h *ForecastHandler) ListPublicForecasts(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Never expose alert destinations or webhook secrets publicly
+	for i := range forecasts {
+		forecasts[i].AlertWebhookURL = ""
+		forecasts[i].CompletionWebhookURL = ""
+		forecasts[i].CompletionWebhookSecret = ""
+		forecasts[i].CompletionWebhookAbove = nil
+		forecasts[i].CompletionWebhookBelow = nil
+		// Each run's snapshot already shows the headlines it used
+		forecasts[i].FixedHeadlines = nil
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -672,7 +1579,7 @@ func (h *ForecastHandler) GetPublicForecastHistory(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if !forecast.Public {
+	if !publiclyVisible(forecast) {
 		http.Error(w, "Forecast not found", http.StatusNotFound)
 		return
 	}
@@ -684,6 +1591,17 @@ func (h *ForecastHandler) GetPublicForecastHistory(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// ?include_models=true adds each run's per-model predictions, anonymized
+	if r.URL.Query().Get("include_models") == "true" {
+		responses, err := h.forecastRepo.GetCompletedModelResponses(ctx, forecastID)
+		if err != nil {
+			h.logger.Error("Failed to get model responses", "error", err)
+			http.Error(w, "Failed to get forecast history", http.StatusInternalServerError)
+			return
+		}
+		anonymizeModelEstimates(history, responses)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -692,6 +1610,69 @@ func (h *ForecastHandler) GetPublicForecastHistory(w http.ResponseWriter, r *htt
 	})
 }
 
+// anonymizeModelEstimates sets each run's ModelEstimates from its model responses. Models are
+// labeled "Model A", "Model B", ... in the order they first appear across the history, so a
+// label refers to the same model in every run of the response.
+func anonymizeModelEstimates(history []models.ForecastRunDetail, responses map[string][]models.ForecastModelResponse) {
+	labels := make(map[string]string)
+	for i := range history {
+		for _, resp := range responses[history[i].Run.ID] {
+			label, ok := labels[resp.ModelID]
+			if !ok {
+				label = modelLabel(len(labels))
+				labels[resp.ModelID] = label
+			}
+			history[i].ModelEstimates = append(history[i].ModelEstimates, models.AnonymousModelEstimate{
+				Label:                 label,
+				PercentilePredictions: resp.PercentilePredictions,
+				PointEstimate:         resp.PointEstimate,
+				Probability:           resp.Probability,
+			})
+		}
+	}
+}
+
+// modelLabel returns the anonymous label of the i-th model: "Model A" to "Model Z", then
+// "Model AA", "Model AB", ...
+func modelLabel(i int) string {
+	suffix := ""
+	for i >= 0 {
+		suffix = string(rune('A'+i%26)) + suffix
+		i = i/26 - 1
+	}
+	return "Model " + suffix
+}
+
+// GetPublicForecastHistoryBars handles GET /api/forecasts/:id/history/bars (public)
+func (h *ForecastHandler) GetPublicForecastHistoryBars(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/history/bars")
+	if forecastID == "" {
+		http.Error(w, "Forecast ID required", http.StatusBadRequest)
+		return
+	}
+
+	// First verify the forecast is public
+	forecast, err := h.forecastRepo.GetForecast(r.Context(), forecastID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast", "error", err)
+		http.Error(w, "Forecast not found", http.StatusNotFound)
+		return
+	}
+	if !publiclyVisible(forecast) {
+		http.Error(w, "Forecast not found", http.StatusNotFound)
+		return
+	}
+
+	h.writeHistoryBars(w, r, forecastID)
+}
+
 // GetPublicForecastHistoryDaily handles GET /api/forecasts/:id/history/daily (public)
 func (h *ForecastHandler) GetPublicForecastHistoryDaily(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -718,12 +1699,18 @@ func (h *ForecastHandler) GetPublicForecastHistoryDaily(w http.ResponseWriter, r
 		return
 	}
 
-	if !forecast.Public {
+	if !publiclyVisible(forecast) {
 		http.Error(w, "Forecast not found", http.StatusNotFound)
 		return
 	}
 
-	ohlcData, err := h.forecastRepo.GetForecastHistoryDaily(ctx, forecastID)
+	metric, err := ohlcMetric(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ohlcData, err := h.forecastRepo.GetForecastOHLC(ctx, forecastID, metric, database.OHLCDaily)
 	if err != nil {
 		h.logger.Error("Failed to get daily OHLC data", "error", err)
 		http.Error(w, "Failed to get daily OHLC data", http.StatusInternalServerError)
@@ -764,12 +1751,18 @@ func (h *ForecastHandler) GetPublicForecastHistory4Hour(w http.ResponseWriter, r
 		return
 	}
 
-	if !forecast.Public {
+	if !publiclyVisible(forecast) {
 		http.Error(w, "Forecast not found", http.StatusNotFound)
 		return
 	}
 
-	ohlcData, err := h.forecastRepo.GetForecastHistory4Hour(ctx, forecastID)
+	metric, err := ohlcMetric(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ohlcData, err := h.forecastRepo.GetForecastOHLC(ctx, forecastID, metric, database.OHLCFourHour)
 	if err != nil {
 		h.logger.Error("Failed to get 4-hour OHLC data", "error", err)
 		http.Error(w, "Failed to get 4-hour OHLC data", http.StatusInternalServerError)
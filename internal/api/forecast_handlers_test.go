@@ -0,0 +1,256 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func historyStream(points []models.ForecastHistoryPoint) func(func(models.ForecastHistoryPoint) error) error {
+	return func(fn func(models.ForecastHistoryPoint) error) error {
+		for _, point := range points {
+			if err := fn(point); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestWriteForecastHistoryExport(t *testing.T) {
+	runAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	completedAt := runAt.Add(5 * time.Minute)
+	p10, p25, p50, p75, p90, consensus, estimate := -5.0, -1.0, 2.5, 6.0, 11.0, 1.25, 42.0
+	points := []models.ForecastHistoryPoint{
+		{RunID: "run1", RunAt: runAt, CompletedAt: &completedAt, HeadlineCount: 300, P10: &p10, P25: &p25, P50: &p50, P75: &p75, P90: &p90, ModelCount: 3, ConsensusLevel: &consensus},
+		{RunID: "run2", RunAt: runAt.Add(time.Hour), HeadlineCount: 250, PointEstimate: &estimate, ModelCount: 1},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeForecastHistoryExport(&buf, "csv", historyStream(points)); err != nil {
+			t.Fatalf("export failed: %v", err)
+		}
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("invalid CSV: %v", err)
+		}
+		if len(records) != 3 {
+			t.Fatalf("expected header and 2 rows, got %d records", len(records))
+		}
+		want := []string{"run1", "2026-03-01T12:00:00Z", "2026-03-01T12:05:00Z", "300", "-5", "-1", "2.5", "6", "11", "", "", "3", "1.25"}
+		for i, cell := range want {
+			if records[1][i] != cell {
+				t.Errorf("row 1 %s = %q, want %q", records[0][i], records[1][i], cell)
+			}
+		}
+		if records[2][2] != "" || records[2][6] != "" || records[2][9] != "42" {
+			t.Errorf("unexpected point estimate row: %v", records[2])
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeForecastHistoryExport(&buf, "json", historyStream(points)); err != nil {
+			t.Fatalf("export failed: %v", err)
+		}
+
+		var decoded []models.ForecastHistoryPoint
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if len(decoded) != 2 || decoded[0].P50 == nil || *decoded[0].P50 != 2.5 || decoded[1].PointEstimate == nil || decoded[1].HeadlineCount != 250 {
+			t.Errorf("unexpected export: %+v", decoded)
+		}
+	})
+
+	t.Run("empty json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeForecastHistoryExport(&buf, "json", historyStream(nil)); err != nil {
+			t.Fatalf("export failed: %v", err)
+		}
+		if buf.String() != "[]\n" {
+			t.Errorf("expected an empty array, got %q", buf.String())
+		}
+	})
+}
+
+func TestAnonymizeModelEstimates(t *testing.T) {
+	v1, v2, v3 := 10.0, 12.0, 30.0
+	history := []models.ForecastRunDetail{
+		{Run: models.ForecastRun{ID: "run1"}},
+		{Run: models.ForecastRun{ID: "run2"}},
+		{Run: models.ForecastRun{ID: "run3"}},
+	}
+	responses := map[string][]models.ForecastModelResponse{
+		"run1": {
+			{RunID: "run1", ModelID: "claude", PointEstimate: &v1},
+			{RunID: "run1", ModelID: "gpt", PointEstimate: &v2},
+		},
+		"run2": {
+			{RunID: "run2", ModelID: "gpt", PointEstimate: &v2},
+			{RunID: "run2", ModelID: "llama", PointEstimate: &v3},
+		},
+	}
+
+	anonymizeModelEstimates(history, responses)
+
+	got := func(run int) []string {
+		var labels []string
+		for _, estimate := range history[run].ModelEstimates {
+			labels = append(labels, estimate.Label)
+		}
+		return labels
+	}
+	if labels := got(0); len(labels) != 2 || labels[0] != "Model A" || labels[1] != "Model B" {
+		t.Errorf("run1 labels = %v", labels)
+	}
+	// The same model keeps its label across runs
+	if labels := got(1); len(labels) != 2 || labels[0] != "Model B" || labels[1] != "Model C" {
+		t.Errorf("run2 labels = %v", labels)
+	}
+	if len(history[2].ModelEstimates) != 0 {
+		t.Errorf("expected no estimates for a run without responses, got %v", history[2].ModelEstimates)
+	}
+	if *history[1].ModelEstimates[1].PointEstimate != 30 {
+		t.Errorf("unexpected estimate: %+v", history[1].ModelEstimates[1])
+	}
+
+	// Provider and model names never appear in the output
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"claude", "gpt", "llama"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("anonymized history leaks %q: %s", secret, data)
+		}
+	}
+}
+
+func TestModelLabel(t *testing.T) {
+	for i, want := range map[int]string{0: "Model A", 25: "Model Z", 26: "Model AA", 27: "Model AB", 51: "Model AZ", 52: "Model BA"} {
+		if got := modelLabel(i); got != want {
+			t.Errorf("modelLabel(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestWriteHistoryBars_RejectsBadParameters(t *testing.T) {
+	h := &ForecastHandler{}
+
+	for _, query := range []string{
+		"metric=median",
+		"interval=monthly",
+		"interval_seconds=60",
+		"interval_seconds=hour",
+	} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/forecasts/fc-1/history/bars?"+query, nil)
+			rec := httptest.NewRecorder()
+
+			h.writeHistoryBars(rec, req, "fc-1")
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestPubliclyVisible(t *testing.T) {
+	archivedAt := time.Now()
+	tests := []struct {
+		name     string
+		forecast *models.Forecast
+		want     bool
+	}{
+		{"missing", nil, false},
+		{"private", &models.Forecast{}, false},
+		{"public", &models.Forecast{Public: true}, true},
+		{"public but archived", &models.Forecast{Public: true, ArchivedAt: &archivedAt}, false},
+	}
+	for _, tt := range tests {
+		if got := publiclyVisible(tt.forecast); got != tt.want {
+			t.Errorf("%s: publiclyVisible() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRunPage(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantLimit  int
+		wantOffset int
+		wantErr    bool
+	}{
+		{query: "", wantLimit: defaultRunPageSize},
+		{query: "limit=10&offset=20", wantLimit: 10, wantOffset: 20},
+		{query: "limit=500", wantLimit: 500},
+		{query: "limit=0", wantErr: true},
+		{query: "limit=501", wantErr: true},
+		{query: "limit=ten", wantErr: true},
+		{query: "offset=-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/forecasts/f1/runs?"+tt.query, nil)
+		limit, offset, err := runPage(req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (limit != tt.wantLimit || offset != tt.wantOffset) {
+			t.Errorf("%q: got limit %d offset %d, want %d and %d", tt.query, limit, offset, tt.wantLimit, tt.wantOffset)
+		}
+	}
+}
+
+func TestRestoreMaskedForecastSecrets(t *testing.T) {
+	stored := &models.Forecast{CompletionWebhookSecret: "whsec-stored-5678"}
+	existing := []models.ForecastModel{
+		{ID: "m1", Provider: "openai", ModelName: "gpt-4o", APIKey: "sk-first-1234"},
+		{ID: "m2", Provider: "openai", ModelName: "gpt-4o-mini", APIKey: "sk-other-1234"},
+		{ID: "m3", Provider: "anthropic", ModelName: "claude", APIKey: "sk-ant-9999"},
+	}
+
+	req := models.CreateForecastRequest{
+		CompletionWebhookSecret: "***",
+		Models: []models.ForecastModel{
+			{ID: "m2", Provider: "openai", APIKey: "***1234"},    // same mask as m1; the ID picks m2
+			{Provider: "anthropic", APIKey: "***9999"},           // duplicate without IDs; matched by provider
+			{ID: "m1", Provider: "openai", APIKey: "sk-new-key"}, // a new key is kept
+		},
+	}
+	if err := restoreMaskedForecastSecrets(&req, stored, existing); err != nil {
+		t.Fatalf("restoreMaskedForecastSecrets: %v", err)
+	}
+	if req.CompletionWebhookSecret != "whsec-stored-5678" {
+		t.Errorf("webhook secret = %q, want the stored one", req.CompletionWebhookSecret)
+	}
+	for i, want := range []string{"sk-other-1234", "sk-ant-9999", "sk-new-key"} {
+		if req.Models[i].APIKey != want {
+			t.Errorf("model %d key = %q, want %q", i, req.Models[i].APIKey, want)
+		}
+	}
+
+	// A masked value without a stored value behind it can't be saved
+	unknown := models.CreateForecastRequest{Models: []models.ForecastModel{{Provider: "anthropic", ModelName: "claude", APIKey: "***1234"}}}
+	if err := restoreMaskedForecastSecrets(&unknown, stored, existing); err == nil {
+		t.Error("expected a masked key matching no stored key to be rejected")
+	}
+	fresh := models.CreateForecastRequest{Models: []models.ForecastModel{{Provider: "openai", APIKey: "***1234"}}}
+	if err := restoreMaskedForecastSecrets(&fresh, nil, nil); err == nil {
+		t.Error("expected a masked key to be rejected on a new forecast")
+	}
+	webhook := models.CreateForecastRequest{CompletionWebhookSecret: "***0000"}
+	if err := restoreMaskedForecastSecrets(&webhook, stored, existing); err == nil {
+		t.Error("expected a masked webhook secret matching no stored secret to be rejected")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"log/slog"
+
+	"github.com/STRATINT/stratint/internal/httpclient"
 )
 
 // Cache entry for FRED API responses
@@ -376,7 +378,7 @@ func (h *FREDHandler) fetchSeriesMetadata(seriesID string) (*seriesMetadata, err
 	url := fmt.Sprintf("https://api.stlouisfed.org/fred/series?series_id=%s&api_key=%s&file_type=json",
 		seriesID, h.apiKey)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("http request failed: %w", err)
@@ -418,7 +420,7 @@ func (h *FREDHandler) fetchObservations(seriesID, observationStart string) ([]st
 	url := fmt.Sprintf("https://api.stlouisfed.org/fred/series/observations?series_id=%s&api_key=%s&file_type=json&observation_start=%s",
 		seriesID, h.apiKey, observationStart)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("http request failed: %w", err)
@@ -453,7 +455,7 @@ func (h *FREDHandler) fetchObservationsRaw(seriesID, observationStart string) ([
 	url := fmt.Sprintf("https://api.stlouisfed.org/fred/series/observations?series_id=%s&api_key=%s&file_type=json&observation_start=%s",
 		seriesID, h.apiKey, observationStart)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("http request failed: %w", err)
@@ -1,14 +1,16 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/auth"
 	"github.com/STRATINT/stratint/internal/eventmanager"
 	"github.com/STRATINT/stratint/internal/ingestion"
 	"github.com/STRATINT/stratint/internal/models"
@@ -21,6 +23,7 @@ type Handler struct {
 	sourceRepo         ingestion.SourceRepository
 	trackedAccountRepo models.TrackedAccountRepository
 	startTime          time.Time
+	streamClients      atomic.Int64 // Open event stream connections
 }
 
 func NewHandler(manager *eventmanager.EventLifecycleManager, sourceRepo ingestion.SourceRepository, trackedAccountRepo models.TrackedAccountRepository, logger *slog.Logger) *Handler {
@@ -41,10 +44,22 @@ func (h *Handler) GetEventsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse query parameters into EventQuery
-	query := h.parseQueryParams(r)
+	query := parseEventQuery(r)
+	if err := query.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Get events from manager
 	events, err := h.manager.GetEvents(query)
+	if errors.Is(err, eventmanager.ErrSemanticSearchUnavailable) {
+		http.Error(w, "Semantic search is not available", http.StatusServiceUnavailable)
+		return
+	}
+	if errors.Is(err, models.ErrUnknownCursor) {
+		http.Error(w, "Unknown after_id or before_id", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to get events", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -56,10 +71,13 @@ func (h *Handler) GetEventsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*") // CORS for dev
 	w.WriteHeader(http.StatusOK)
 
+	page := models.EventResponse{Events: events}
+	page.SetNextCursor(query)
 	response := EventsResponse{
-		Events: events,
-		Count:  len(events),
-		Query:  query,
+		Events:     events,
+		Count:      len(events),
+		Query:      query,
+		NextCursor: page.NextCursor,
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -102,6 +120,45 @@ func (h *Handler) GetEventByIDHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(event)
 }
 
+// GetEventStatusHistoryHandler handles GET /api/events/:id/history
+func (h *Handler) GetEventStatusHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract ID from path
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 5 {
+		http.Error(w, "Event ID required", http.StatusBadRequest)
+		return
+	}
+	eventID := parts[3]
+
+	ctx := r.Context()
+	event, err := h.manager.GetEventByID(ctx, eventID)
+	if err != nil || event == nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	history, err := h.manager.GetStatusHistory(ctx, eventID)
+	if err != nil {
+		h.logger.Error("failed to get event status history", "id", eventID, "error", err)
+		http.Error(w, "Failed to get event status history", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"event_id": eventID,
+		"status":   event.Status,
+		"history":  history,
+	})
+}
+
 // GetStatsHandler handles GET /api/stats
 func (h *Handler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -110,7 +167,8 @@ func (h *Handler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get total event count efficiently
-	totalEvents, err := h.manager.GetEventCount(models.EventQuery{})
+	workspaceID := requestWorkspace(r)
+	totalEvents, err := h.manager.GetEventCount(models.EventQuery{WorkspaceID: workspaceID})
 	if err != nil {
 		h.logger.Error("failed to get event count for stats", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -119,7 +177,8 @@ func (h *Handler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Get a sample of events for calculating averages
 	query := models.EventQuery{
-		Limit: 200, // Sample for stats calculation
+		Limit:       200, // Sample for stats calculation
+		WorkspaceID: workspaceID,
 	}
 	events, err := h.manager.GetEvents(query)
 	if err != nil {
@@ -130,14 +189,13 @@ func (h *Handler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Get source count
 	sourceCount := 0
-	ctx := context.Background()
-	if count, err := h.sourceRepo.Count(ctx); err == nil {
+	if count, err := h.sourceRepo.Count(r.Context()); err == nil {
 		sourceCount = count
 	}
 
 	// Get tracked account count
 	trackedCount := 0
-	if accounts, err := h.trackedAccountRepo.ListAll(false); err == nil {
+	if accounts, err := h.trackedAccountRepo.ListByWorkspace(workspaceID, "", false); err == nil {
 		trackedCount = len(accounts)
 	}
 
@@ -171,15 +229,23 @@ func (h *Handler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(stats)
 }
 
-// parseQueryParams converts URL query parameters to EventQuery
-func (h *Handler) parseQueryParams(r *http.Request) models.EventQuery {
+// requestWorkspace returns the workspace a request acts in: the one its token or API key is
+// scoped to, or the default workspace for public requests
+func requestWorkspace(r *http.Request) string {
+	workspaceID, _ := models.WorkspaceFromContext(r.Context())
+	return models.WorkspaceOrDefault(workspaceID)
+}
+
+// parseEventQuery converts URL query parameters to EventQuery, scoped to the request's workspace
+func parseEventQuery(r *http.Request) models.EventQuery {
 	q := r.URL.Query()
-	query := models.EventQuery{}
+	query := models.EventQuery{WorkspaceID: requestWorkspace(r)}
 
 	// Text search
 	if search := q.Get("search"); search != "" {
 		query.Search = &search
 	}
+	query.SemanticQuery = q.Get("semantic_query")
 
 	// Time range
 	if since := q.Get("since"); since != "" {
@@ -193,6 +259,9 @@ func (h *Handler) parseQueryParams(r *http.Request) models.EventQuery {
 		}
 	}
 
+	// Relative window such as 24h or 7d, resolved by EventQuery.Validate
+	query.Window = q.Get("window")
+
 	// Time range shortcuts
 	if timeRange := q.Get("time_range"); timeRange != "" {
 		now := time.Now()
@@ -248,11 +317,23 @@ func (h *Handler) parseQueryParams(r *http.Request) models.EventQuery {
 		query.Categories = modelCats
 	}
 
+	// Source types
+	if sourceTypes := q.Get("source_types"); sourceTypes != "" {
+		for _, st := range strings.Split(sourceTypes, ",") {
+			query.SourceTypes = append(query.SourceTypes, models.SourceType(strings.TrimSpace(st)))
+		}
+	}
+
 	// Tags
 	if tags := q.Get("tags"); tags != "" {
 		query.Tags = strings.Split(tags, ",")
 	}
 
+	// Breaking events only
+	if breaking := q.Get("breaking"); breaking != "" {
+		query.Breaking, _ = strconv.ParseBool(breaking)
+	}
+
 	// Status
 	if status := q.Get("status"); status != "" {
 		s := models.EventStatus(status)
@@ -278,6 +359,8 @@ func (h *Handler) parseQueryParams(r *http.Request) models.EventQuery {
 			query.Offset = val
 		}
 	}
+	query.AfterID = q.Get("after_id")
+	query.BeforeID = q.Get("before_id")
 
 	return query
 }
@@ -355,7 +438,7 @@ func (h *Handler) getSourcesHandler(w http.ResponseWriter, r *http.Request) {
 	if trackedAccountID != "" {
 		// Get the tracked account to know its identifier (feed URL, username, etc.)
 		account, err := h.trackedAccountRepo.GetByID(trackedAccountID)
-		if err != nil || account == nil {
+		if err != nil || account == nil || account.WorkspaceID != requestWorkspace(r) {
 			h.logger.Error("failed to get tracked account", "id", trackedAccountID, "error", err)
 			http.Error(w, "Tracked account not found", http.StatusNotFound)
 			return
@@ -413,31 +496,23 @@ func (h *Handler) createSourceHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for duplicates by title + URL
-	if source.Title != "" && source.URL != "" {
-		existing, err := h.sourceRepo.GetByTitleAndURL(r.Context(), source.Title, source.URL)
-		if err != nil {
-			h.logger.Error("failed to check for duplicate source", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
-		if existing != nil {
-			h.logger.Warn("attempted to create duplicate source", "title", source.Title, "url", source.URL)
-			http.Error(w, "Source with same title and URL already exists", http.StatusConflict)
-			return
-		}
-	}
-
 	// Set timestamps
 	if source.PublishedAt.IsZero() {
 		source.PublishedAt = time.Now()
 	}
+	source.WorkspaceID = requestWorkspace(r)
 
-	if err := h.manager.CreateSource(r.Context(), &source); err != nil {
+	stored, err := h.manager.CreateSource(r.Context(), &source)
+	if err != nil {
 		h.logger.Error("failed to create source", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if !stored {
+		h.logger.Warn("attempted to create duplicate source", "title", source.Title, "url", source.URL)
+		http.Error(w, "Source with same URL or content already exists", http.StatusConflict)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -488,6 +563,7 @@ func (h *Handler) updateSourceHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	source.ID = sourceID // Ensure ID matches URL
+	source.WorkspaceID = requestWorkspace(r)
 
 	if err := h.manager.UpdateSource(r.Context(), &source); err != nil {
 		h.logger.Error("failed to update source", "error", err)
@@ -537,20 +613,31 @@ func (h *Handler) UpdateEventStatusHandler(w http.ResponseWriter, r *http.Reques
 
 	var request struct {
 		Status string `json:"status"`
+		Reason string `json:"reason"` // Optional, recorded in the status history
 	}
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
+	// Record who made the change; the auth middleware has already authenticated the caller
+	actor, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok || actor == "" {
+		actor = "unknown"
+	}
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		reason = "manual status update"
+	}
+
 	var err error
 	switch request.Status {
 	case "published":
-		err = h.manager.PublishEvent(r.Context(), eventID)
+		err = h.manager.PublishEvent(r.Context(), eventID, actor, reason)
 	case "rejected":
-		err = h.manager.RejectEvent(r.Context(), eventID)
+		err = h.manager.RejectEvent(r.Context(), eventID, actor, reason)
 	case "archived":
-		err = h.manager.ArchiveEvent(r.Context(), eventID)
+		err = h.manager.ArchiveEvent(r.Context(), eventID, actor, reason)
 	default:
 		http.Error(w, "Invalid status. Must be: published, rejected, or archived", http.StatusBadRequest)
 		return
@@ -562,7 +649,7 @@ func (h *Handler) UpdateEventStatusHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	h.logger.Info("event status updated", "event_id", eventID, "status", request.Status)
+	h.logger.Info("event status updated", "event_id", eventID, "status", request.Status, "actor", actor)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -577,9 +664,10 @@ func (h *Handler) UpdateEventStatusHandler(w http.ResponseWriter, r *http.Reques
 
 // Response types
 type EventsResponse struct {
-	Events []models.Event    `json:"events"`
-	Count  int               `json:"count"`
-	Query  models.EventQuery `json:"query,omitempty"`
+	Events     []models.Event    `json:"events"`
+	Count      int               `json:"count"`
+	Query      models.EventQuery `json:"query,omitempty"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Set for after_id/before_id queries
 }
 
 type StatsResponse struct {
@@ -9,20 +9,23 @@ import (
 	"time"
 
 	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/models"
 )
 
 // InferenceLogHandler handles HTTP requests for inference log management
 type InferenceLogHandler struct {
-	repo   *database.InferenceLogRepository
-	logger *slog.Logger
+	repo            *database.InferenceLogRepository
+	inferenceLogger *inference.Logger
+	logger          *slog.Logger
 }
 
 // NewInferenceLogHandler creates a new handler
-func NewInferenceLogHandler(repo *database.InferenceLogRepository, logger *slog.Logger) *InferenceLogHandler {
+func NewInferenceLogHandler(repo *database.InferenceLogRepository, inferenceLogger *inference.Logger, logger *slog.Logger) *InferenceLogHandler {
 	return &InferenceLogHandler{
-		repo:   repo,
-		logger: logger,
+		repo:            repo,
+		inferenceLogger: inferenceLogger,
+		logger:          logger,
 	}
 }
 
@@ -117,3 +120,79 @@ func (h *InferenceLogHandler) GetInferenceStats(w http.ResponseWriter, r *http.R
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
 }
+
+// GetInferenceBreakdown handles GET /api/admin/inference-logs/breakdown
+// Groups calls by provider, model and operation over a time window. The window is either
+// start_date/end_date (RFC3339) or window (e.g. "24h", "7d"); it defaults to the last 24 hours.
+func (h *InferenceLogHandler) GetInferenceBreakdown(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var startDate, endDate *time.Time
+
+	if startDateStr := r.URL.Query().Get("start_date"); startDateStr != "" {
+		parsed, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			http.Error(w, "Invalid start_date: must be an RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		startDate = &parsed
+	}
+
+	if endDateStr := r.URL.Query().Get("end_date"); endDateStr != "" {
+		parsed, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			http.Error(w, "Invalid end_date: must be an RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		endDate = &parsed
+	}
+
+	if startDate == nil {
+		window := 24 * time.Hour
+		if windowStr := r.URL.Query().Get("window"); windowStr != "" {
+			parsed, err := models.ParseWindow(windowStr)
+			if err != nil {
+				http.Error(w, "Invalid window: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			window = parsed
+		}
+		start := time.Now().Add(-window)
+		startDate = &start
+	}
+
+	breakdown, err := h.repo.GetUsageBreakdown(r.Context(), startDate, endDate)
+	if err != nil {
+		h.logger.Error("failed to get inference breakdown", "error", err)
+		http.Error(w, "Failed to get inference breakdown: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"start_date": startDate,
+		"end_date":   endDate,
+		"breakdown":  breakdown,
+	})
+}
+
+// GetInferenceBudget handles GET /api/admin/inference-logs/budget
+func (h *InferenceLogHandler) GetInferenceBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status, err := h.inferenceLogger.BudgetStatus(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get inference budget status", "error", err)
+		http.Error(w, "Failed to get inference budget status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
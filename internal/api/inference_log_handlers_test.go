@@ -0,0 +1,25 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetInferenceBreakdown_RejectsBadRange(t *testing.T) {
+	h := NewInferenceLogHandler(nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for _, query := range []string{
+		"start_date=yesterday",
+		"start_date=2024-01-01T00:00:00Z&end_date=2024-02-30",
+		"window=forever",
+	} {
+		rec := httptest.NewRecorder()
+		h.GetInferenceBreakdown(rec, httptest.NewRequest(http.MethodGet, "/api/admin/inference-logs/breakdown?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
 )
 
 type IngestionErrorHandler struct {
@@ -24,12 +25,12 @@ func NewIngestionErrorHandler(repo database.IngestionErrorRepository, logger *sl
 }
 
 // ListErrors returns ingestion errors with optional filtering
-// GET /api/ingestion-errors?limit=100&unresolved_only=true
+// GET /api/ingestion-errors?limit=100&unresolved_only=true&category=timeout&platform=rss
 func (h *IngestionErrorHandler) ListErrors(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Parse query parameters
 	limitStr := r.URL.Query().Get("limit")
@@ -40,10 +41,15 @@ func (h *IngestionErrorHandler) ListErrors(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	unresolvedOnly := r.URL.Query().Get("unresolved_only") == "true"
+	filter := models.IngestionErrorFilter{
+		Limit:          limit,
+		UnresolvedOnly: r.URL.Query().Get("unresolved_only") == "true",
+		Category:       models.ErrorCategory(r.URL.Query().Get("category")),
+		Platform:       r.URL.Query().Get("platform"),
+	}
 
 	ctx := context.Background()
-	errors, err := h.repo.List(ctx, limit, unresolvedOnly)
+	errors, err := h.repo.List(ctx, filter)
 	if err != nil {
 		h.logger.Error("failed to list ingestion errors", "error", err)
 		http.Error(w, "Failed to list errors", http.StatusInternalServerError)
@@ -65,13 +71,51 @@ func (h *IngestionErrorHandler) ListErrors(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// ErrorStats returns error counts grouped by category and platform
+// GET /api/ingestion-errors/stats?unresolved_only=true
+func (h *IngestionErrorHandler) ErrorStats(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+
+	unresolvedOnly := r.URL.Query().Get("unresolved_only") == "true"
+
+	stats, err := h.repo.Stats(r.Context(), unresolvedOnly)
+	if err != nil {
+		h.logger.Error("failed to get ingestion error stats", "error", err)
+		http.Error(w, "Failed to get error stats", http.StatusInternalServerError)
+		return
+	}
+	if stats == nil {
+		stats = []models.IngestionErrorStat{}
+	}
+
+	byCategory := make(map[models.ErrorCategory]int)
+	byPlatform := make(map[string]int)
+	total := 0
+	for _, s := range stats {
+		byCategory[s.Category] += s.Count
+		byPlatform[s.Platform] += s.Count
+		total += s.Count
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"stats":       stats,
+		"by_category": byCategory,
+		"by_platform": byPlatform,
+		"total":       total,
+	})
+}
+
 // ResolveError marks an error as resolved
 // POST /api/ingestion-errors/:id/resolve
 func (h *IngestionErrorHandler) ResolveError(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Extract ID from path
 	path := strings.TrimPrefix(r.URL.Path, "/api/ingestion-errors/")
@@ -99,7 +143,7 @@ func (h *IngestionErrorHandler) DeleteError(w http.ResponseWriter, r *http.Reque
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Extract ID from path
 	id := strings.TrimPrefix(r.URL.Path, "/api/ingestion-errors/")
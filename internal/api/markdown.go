@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// The markdown renderer covers what summary models write: headings, bullet and numbered lists,
+// paragraphs, bold, italics, inline code and http(s) links. Everything else is escaped text.
+var (
+	mdHeadingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdBulletRe   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	mdNumberedRe = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	mdCodeRe     = regexp.MustCompile("`([^`]+)`")
+	mdLinkRe     = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^\s)]+)\)`)
+	mdBoldRe     = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdItalicRe   = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	mdMarkerRe   = regexp.MustCompile(`\*\*|\*|` + "`")
+	mdHashRe     = regexp.MustCompile(`^#{1,6}\s+`)
+	mdListRe     = regexp.MustCompile(`^[*+]\s+`)
+)
+
+// renderMarkdown converts summary markdown to HTML. The input is escaped first, so model output
+// can't inject markup.
+func renderMarkdown(markdown string) string {
+	var b strings.Builder
+	var paragraph []string
+	list := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			fmt.Fprintf(&b, "<p>%s</p>\n", strings.Join(paragraph, "<br>"))
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if list != "" {
+			fmt.Fprintf(&b, "</%s>\n", list)
+			list = ""
+		}
+	}
+	listItem := func(tag, text string) {
+		flushParagraph()
+		if list != tag {
+			closeList()
+			fmt.Fprintf(&b, "<%s>\n", tag)
+			list = tag
+		}
+		fmt.Fprintf(&b, "<li>%s</li>\n", renderInline(text))
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			flushParagraph()
+			closeList()
+			continue
+		}
+
+		if m := mdHeadingRe.FindStringSubmatch(line); m != nil {
+			flushParagraph()
+			closeList()
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", len(m[1]), renderInline(m[2]), len(m[1]))
+		} else if m := mdBulletRe.FindStringSubmatch(line); m != nil {
+			listItem("ul", m[1])
+		} else if m := mdNumberedRe.FindStringSubmatch(line); m != nil {
+			listItem("ol", m[1])
+		} else {
+			closeList()
+			paragraph = append(paragraph, renderInline(line))
+		}
+	}
+	flushParagraph()
+	closeList()
+
+	return b.String()
+}
+
+// renderInline escapes a line and converts its inline markdown. Code spans are rendered last so
+// their contents stay literal.
+func renderInline(text string) string {
+	var codes []string
+	text = mdCodeRe.ReplaceAllStringFunc(text, func(m string) string {
+		codes = append(codes, "<code>"+html.EscapeString(m[1:len(m)-1])+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+
+	text = html.EscapeString(text)
+	text = mdLinkRe.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdBoldRe.ReplaceAllString(text, "<strong>$1</strong>")
+	text = mdItalicRe.ReplaceAllString(text, "<em>$1</em>")
+
+	for i, code := range codes {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), code, 1)
+	}
+	return text
+}
+
+// markdownToText strips markdown for plain-text destinations such as Twitter. Links become
+// "text (url)" and emphasis markers are dropped.
+func markdownToText(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		line = mdHashRe.ReplaceAllString(strings.TrimSpace(line), "")
+		line = mdListRe.ReplaceAllString(line, "- ")
+		line = mdLinkRe.ReplaceAllString(line, "$1 ($2)")
+		lines[i] = mdMarkerRe.ReplaceAllString(line, "")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
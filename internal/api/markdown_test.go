@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"heading and paragraph": {
+			input: "## Overview\nMarkets fell.\nOil rose.",
+			want:  "<h2>Overview</h2>\n<p>Markets fell.<br>Oil rose.</p>\n",
+		},
+		"lists switch type": {
+			input: "- one\n* two\n1. first\n2) second",
+			want:  "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n",
+		},
+		"inline formatting": {
+			input: "**Strike** on *port* per [report](https://stratint.ai/events/e1?a=1&b=2), see `a*b*c`",
+			want:  "<p><strong>Strike</strong> on <em>port</em> per <a href=\"https://stratint.ai/events/e1?a=1&amp;b=2\">report</a>, see <code>a*b*c</code></p>\n",
+		},
+		"markup is escaped": {
+			input: "<script>alert(1)</script> [x](javascript:alert(1))",
+			want:  "<p>&lt;script&gt;alert(1)&lt;/script&gt; [x](javascript:alert(1))</p>\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := renderMarkdown(tc.input); got != tc.want {
+				t.Errorf("renderMarkdown(%q)\n got: %q\nwant: %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToText(t *testing.T) {
+	input := "## Overview\n* **Strike** on [port](https://stratint.ai/events/e1) (magnitude 7.5)\n- Oil `rose`"
+	want := "Overview\n- Strike on port (https://stratint.ai/events/e1) (magnitude 7.5)\n- Oil rose"
+	if got := markdownToText(input); got != want {
+		t.Errorf("markdownToText()\n got: %q\nwant: %q", got, want)
+	}
+}
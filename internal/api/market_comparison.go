@@ -0,0 +1,351 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/httpclient"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// optionChain identifies the Nasdaq option chain analyzed for a symbol
+type optionChain struct {
+	assetClass string
+	months     []time.Month // Months whose long-dated expiries are listed with enough liquidity
+}
+
+// Long-dated expiry cycles: quarterly for the most liquid chains, January LEAPS for the rest
+var (
+	quarterlyExpiries = []time.Month{time.March, time.June, time.September, time.December}
+	januaryExpiries   = []time.Month{time.January}
+)
+
+// optionChains are the symbols with options analysis, shared by the risk analysis endpoints and
+// forecast market comparisons
+var optionChains = map[string]optionChain{
+	"SPY":  {assetClass: "etf", months: quarterlyExpiries},
+	"IBIT": {assetClass: "stocks", months: januaryExpiries},
+	"GLD":  {assetClass: "etf", months: quarterlyExpiries},
+	"TLT":  {assetClass: "etf", months: quarterlyExpiries},
+	"VNQ":  {assetClass: "etf", months: januaryExpiries},
+	"USO":  {assetClass: "etf", months: januaryExpiries},
+}
+
+// minExpiryDays is how far out the analyzed expiry must be, so it prices roughly a year ahead
+const minExpiryDays = 270
+
+// expiry returns the expiry analyzed at now, as YYYY-MM-DD: the first standard monthly expiry
+// (the third Friday) in one of the chain's months at least minExpiryDays away. It moves forward
+// as time passes, so the analysis never points at an expired chain.
+func (c optionChain) expiry(now time.Time) string {
+	earliest := now.AddDate(0, 0, minExpiryDays)
+	month := time.Date(earliest.Year(), earliest.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for {
+		if slices.Contains(c.months, month.Month()) {
+			if friday := thirdFriday(month); !friday.Before(earliest) {
+				return friday.Format("2006-01-02")
+			}
+		}
+		month = month.AddDate(0, 1, 0)
+	}
+}
+
+// thirdFriday returns the third Friday of the month starting at first
+func thirdFriday(first time.Time) time.Time {
+	offset := (int(time.Friday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+14)
+}
+
+// marketSymbols returns the symbols with options analysis in alphabetical order
+func marketSymbols() []string {
+	symbols := make([]string, 0, len(optionChains))
+	for symbol := range optionChains {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Rates used to derive risk-neutral return distributions (same as the risk analysis)
+const (
+	impliedRiskFreeRate  = 0.04
+	impliedDividendYield = 0.012
+)
+
+// Standard normal quantiles of the forecast percentiles
+var percentileZ = models.PercentilePredictions{
+	P10: -1.2815515655446004,
+	P25: -0.6744897501960817,
+	P50: 0,
+	P75: 0.6744897501960817,
+	P90: 1.2815515655446004,
+}
+
+// MarketDistribution is the percent return distribution priced by a symbol's options over a
+// horizon: log-normal with the at-the-money implied volatility
+type MarketDistribution struct {
+	Symbol            string                       `json:"symbol"`
+	SpotPrice         float64                      `json:"spot_price"`
+	OptionsExpiry     string                       `json:"options_expiry"`
+	HorizonDays       int                          `json:"horizon_days"`
+	ImpliedVolatility float64                      `json:"implied_volatility_percent"` // Annualized ATM IV
+	Percentiles       models.PercentilePredictions `json:"percentiles"`                // Percent return at each percentile
+	ProbGain          float64                      `json:"prob_gain_percent"`          // Probability the return is positive (0-100)
+}
+
+// impliedReturnPercentiles returns the percent return percentiles of a log-normal price with
+// annualized volatility iv over years
+func impliedReturnPercentiles(iv, years float64) models.PercentilePredictions {
+	drift := (impliedRiskFreeRate - impliedDividendYield - 0.5*iv*iv) * years
+	spread := iv * math.Sqrt(years)
+	ret := func(z float64) float64 {
+		return (math.Exp(drift+spread*z) - 1) * 100
+	}
+	return models.PercentilePredictions{
+		P10: ret(percentileZ.P10),
+		P25: ret(percentileZ.P25),
+		P50: ret(percentileZ.P50),
+		P75: ret(percentileZ.P75),
+		P90: ret(percentileZ.P90),
+	}
+}
+
+// impliedReturnCDF returns the probability (0-1) that the percent return over years is below
+// returnPct, under the same log-normal distribution as impliedReturnPercentiles
+func impliedReturnCDF(returnPct, iv, years float64) float64 {
+	if returnPct <= -100 {
+		return 0
+	}
+	drift := (impliedRiskFreeRate - impliedDividendYield - 0.5*iv*iv) * years
+	spread := iv * math.Sqrt(years)
+	return normCDF((math.Log(1+returnPct/100) - drift) / spread)
+}
+
+// fetchOptionChain fetches a symbol's option chain for an expiry from Nasdaq
+func (h *OptionsAnalysisHandler) fetchOptionChain(ctx context.Context, symbol string, chain optionChain, expiry string) (NasdaqOptionChain, error) {
+	var chainData NasdaqOptionChain
+
+	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/%s/option-chain?assetclass=%s&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
+		symbol, chain.assetClass, expiry, expiry)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nasdaqURL, nil)
+	if err != nil {
+		return chainData, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "application/json, text/plain, */*")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Referer", "https://www.nasdaq.com/")
+	req.Header.Set("Origin", "https://www.nasdaq.com")
+
+	resp, err := httpclient.New().Do(req)
+	if err != nil {
+		return chainData, fmt.Errorf("failed to fetch option chain: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return chainData, fmt.Errorf("nasdaq returned HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return chainData, fmt.Errorf("failed to read option chain: %w", err)
+	}
+	if err := json.Unmarshal(body, &chainData); err != nil {
+		return chainData, fmt.Errorf("failed to decode option chain: %w", err)
+	}
+	if chainData.Status.RCode != 200 {
+		return chainData, fmt.Errorf("nasdaq api error: %s", chainData.Status.BCodeMessage)
+	}
+
+	return chainData, nil
+}
+
+// ImpliedReturnDistribution derives the return distribution a symbol's options price over
+// horizonDays, or over the time to the options expiry when horizonDays is 0
+func (h *OptionsAnalysisHandler) ImpliedReturnDistribution(ctx context.Context, symbol string, horizonDays int) (*MarketDistribution, error) {
+	chain, ok := optionChains[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no options analysis for %s", symbol)
+	}
+
+	now := time.Now()
+	expiry := chain.expiry(now)
+	expiryTime, err := time.Parse("2006-01-02", expiry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options expiry %s: %w", expiry, err)
+	}
+	daysToExpiry := int(expiryTime.Sub(now).Hours() / 24)
+
+	chainData, err := h.fetchOptionChain(ctx, symbol, chain, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	options, spotPrice, err := h.parseOptionsDataV2(chainData, daysToExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	atm := findATMOption(options, spotPrice)
+	var iv float64
+	switch {
+	case atm.HasCallIV && atm.HasPutIV:
+		iv = (atm.CallIV + atm.PutIV) / 2
+	case atm.HasCallIV:
+		iv = atm.CallIV
+	case atm.HasPutIV:
+		iv = atm.PutIV
+	default:
+		return nil, fmt.Errorf("no at-the-money implied volatility for %s", symbol)
+	}
+
+	if horizonDays <= 0 {
+		horizonDays = daysToExpiry
+	}
+	years := float64(horizonDays) / 365
+
+	return &MarketDistribution{
+		Symbol:            symbol,
+		SpotPrice:         spotPrice,
+		OptionsExpiry:     expiry,
+		HorizonDays:       horizonDays,
+		ImpliedVolatility: iv * 100,
+		Percentiles:       impliedReturnPercentiles(iv, years),
+		ProbGain:          (1 - impliedReturnCDF(0, iv, years)) * 100,
+	}, nil
+}
+
+// ForecastMarketComparison sets a forecast's latest result next to the distribution the options
+// market prices for its symbol. Deltas are the ensemble minus the market and are nil without a
+// completed run.
+type ForecastMarketComparison struct {
+	ForecastID string             `json:"forecast_id"`
+	Market     MarketDistribution `json:"market"`
+
+	RunID                 string                        `json:"run_id,omitempty"` // Latest completed run
+	RunAt                 *time.Time                    `json:"run_at,omitempty"`
+	EnsemblePercentiles   *models.PercentilePredictions `json:"ensemble_percentiles,omitempty"`
+	EnsemblePointEstimate *float64                      `json:"ensemble_point_estimate,omitempty"`
+	PercentileDeltas      *models.PercentilePredictions `json:"percentile_deltas,omitempty"`
+	MedianDelta           *float64                      `json:"median_delta,omitempty"` // Ensemble P50 or point estimate minus the market P50
+
+	// MarketRank is where the ensemble's median falls in the market distribution (0-100): 50
+	// agrees with the market, values near 0 or 100 are far out in its tails
+	MarketRank *float64 `json:"market_rank,omitempty"`
+}
+
+// forecastHorizonDays returns the days from now to the forecast's target date, or 0 without a
+// future target date
+func forecastHorizonDays(forecast *models.Forecast, now time.Time) int {
+	if forecast.TargetDate == nil || !forecast.TargetDate.After(now) {
+		return 0
+	}
+	return int(math.Ceil(forecast.TargetDate.Sub(now).Hours() / 24))
+}
+
+// compareWithMarket builds the comparison of a forecast's latest run with the market distribution
+func compareWithMarket(forecastID string, market MarketDistribution, run *models.ForecastRunDetail) ForecastMarketComparison {
+	comparison := ForecastMarketComparison{
+		ForecastID: forecastID,
+		Market:     market,
+	}
+	if run == nil || run.Result == nil {
+		return comparison
+	}
+
+	comparison.RunID = run.Run.ID
+	comparison.RunAt = &run.Run.RunAt
+
+	var median *float64
+	if p := run.Result.AggregatedPercentiles; p != nil {
+		m := market.Percentiles
+		comparison.EnsemblePercentiles = p
+		comparison.PercentileDeltas = &models.PercentilePredictions{
+			P10: p.P10 - m.P10,
+			P25: p.P25 - m.P25,
+			P50: p.P50 - m.P50,
+			P75: p.P75 - m.P75,
+			P90: p.P90 - m.P90,
+		}
+		median = &p.P50
+	} else if run.Result.AggregatedPointEstimate != nil {
+		comparison.EnsemblePointEstimate = run.Result.AggregatedPointEstimate
+		median = run.Result.AggregatedPointEstimate
+	}
+
+	if median != nil {
+		delta := *median - market.Percentiles.P50
+		comparison.MedianDelta = &delta
+
+		iv := market.ImpliedVolatility / 100
+		rank := impliedReturnCDF(*median, iv, float64(market.HorizonDays)/365) * 100
+		comparison.MarketRank = &rank
+	}
+
+	return comparison
+}
+
+// GetMarketComparison handles GET /api/admin/forecasts/:id/market-comparison. It compares the
+// forecast's latest completed run with the return distribution implied by options on the
+// forecast's market_symbol, over the time to its target date (or the options expiry).
+func (h *ForecastHandler) GetMarketComparison(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/forecasts/")
+	forecastID := strings.TrimSuffix(path, "/market-comparison")
+	if forecastID == "" {
+		http.Error(w, "Forecast ID required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	forecast, err := h.forecastRepo.GetForecast(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to get forecast", "error", err)
+		http.Error(w, "Failed to get forecast", http.StatusInternalServerError)
+		return
+	}
+	if forecast == nil {
+		http.Error(w, "Forecast not found", http.StatusNotFound)
+		return
+	}
+	if forecast.MarketSymbol == "" {
+		http.Error(w, "Forecast has no market_symbol", http.StatusBadRequest)
+		return
+	}
+	if forecast.PredictionType == models.PredictionTypeProbability {
+		http.Error(w, "Market comparison needs a percentile or point_estimate forecast", http.StatusBadRequest)
+		return
+	}
+
+	run, err := h.forecastRepo.GetLatestCompletedForecastRun(ctx, forecastID)
+	if err != nil {
+		h.logger.Error("Failed to get latest forecast run", "error", err)
+		http.Error(w, "Failed to get latest forecast run", http.StatusInternalServerError)
+		return
+	}
+
+	market, err := h.options.ImpliedReturnDistribution(ctx, forecast.MarketSymbol, forecastHorizonDays(forecast, time.Now()))
+	if err != nil {
+		h.logger.Error("Failed to get market distribution", "symbol", forecast.MarketSymbol, "error", err)
+		http.Error(w, "Market data unavailable: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(compareWithMarket(forecastID, *market, run))
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestImpliedReturnPercentiles(t *testing.T) {
+	iv, years := 0.2, 1.0
+	p := impliedReturnPercentiles(iv, years)
+
+	// The median return is the log-normal drift
+	wantP50 := (math.Exp(impliedRiskFreeRate-impliedDividendYield-0.5*iv*iv) - 1) * 100
+	if math.Abs(p.P50-wantP50) > 1e-9 {
+		t.Errorf("P50 = %v, want %v", p.P50, wantP50)
+	}
+	if !(p.P10 < p.P25 && p.P25 < p.P50 && p.P50 < p.P75 && p.P75 < p.P90) {
+		t.Errorf("percentiles not increasing: %+v", p)
+	}
+
+	// The CDF inverts the percentiles
+	for _, tt := range []struct {
+		value float64
+		want  float64
+	}{{p.P10, 0.10}, {p.P25, 0.25}, {p.P50, 0.50}, {p.P75, 0.75}, {p.P90, 0.90}} {
+		if got := impliedReturnCDF(tt.value, iv, years); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("impliedReturnCDF(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if got := impliedReturnCDF(-100, iv, years); got != 0 {
+		t.Errorf("impliedReturnCDF(-100) = %v, want 0", got)
+	}
+
+	// A shorter horizon narrows the distribution
+	short := impliedReturnPercentiles(iv, 0.25)
+	if short.P90-short.P10 >= p.P90-p.P10 {
+		t.Errorf("3-month spread %v should be narrower than 1-year spread %v", short.P90-short.P10, p.P90-p.P10)
+	}
+}
+
+func TestForecastHorizonDays(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(90*24*time.Hour + time.Hour)
+	past := now.Add(-24 * time.Hour)
+
+	if got := forecastHorizonDays(&models.Forecast{TargetDate: &future}, now); got != 91 {
+		t.Errorf("future target = %d days, want 91", got)
+	}
+	if got := forecastHorizonDays(&models.Forecast{TargetDate: &past}, now); got != 0 {
+		t.Errorf("past target = %d days, want 0", got)
+	}
+	if got := forecastHorizonDays(&models.Forecast{}, now); got != 0 {
+		t.Errorf("no target = %d days, want 0", got)
+	}
+}
+
+func TestCompareWithMarket(t *testing.T) {
+	market := MarketDistribution{
+		Symbol:            "SPY",
+		HorizonDays:       365,
+		ImpliedVolatility: 20,
+		Percentiles:       impliedReturnPercentiles(0.2, 1),
+	}
+
+	t.Run("no completed run", func(t *testing.T) {
+		got := compareWithMarket("fc-1", market, nil)
+		if got.RunID != "" || got.MedianDelta != nil || got.MarketRank != nil {
+			t.Errorf("expected market only, got %+v", got)
+		}
+	})
+
+	t.Run("percentile run", func(t *testing.T) {
+		ensemble := models.PercentilePredictions{P10: -5, P25: 0, P50: market.Percentiles.P50 + 4, P75: 12, P90: 20}
+		run := &models.ForecastRunDetail{
+			Run:    models.ForecastRun{ID: "run-1"},
+			Result: &models.ForecastResult{AggregatedPercentiles: &ensemble},
+		}
+
+		got := compareWithMarket("fc-1", market, run)
+		if got.RunID != "run-1" || got.PercentileDeltas == nil || got.MedianDelta == nil || got.MarketRank == nil {
+			t.Fatalf("missing comparison fields: %+v", got)
+		}
+		if math.Abs(*got.MedianDelta-4) > 1e-9 || math.Abs(got.PercentileDeltas.P50-4) > 1e-9 {
+			t.Errorf("median delta = %v, P50 delta = %v, want 4", *got.MedianDelta, got.PercentileDeltas.P50)
+		}
+		if got.PercentileDeltas.P10 != -5-market.Percentiles.P10 {
+			t.Errorf("P10 delta = %v, want %v", got.PercentileDeltas.P10, -5-market.Percentiles.P10)
+		}
+		if *got.MarketRank <= 50 || *got.MarketRank >= 100 {
+			t.Errorf("market rank = %v, want above the market median", *got.MarketRank)
+		}
+	})
+
+	t.Run("point estimate run", func(t *testing.T) {
+		estimate := market.Percentiles.P50
+		run := &models.ForecastRunDetail{
+			Run:    models.ForecastRun{ID: "run-2"},
+			Result: &models.ForecastResult{AggregatedPointEstimate: &estimate},
+		}
+
+		got := compareWithMarket("fc-1", market, run)
+		if got.PercentileDeltas != nil || got.EnsemblePointEstimate == nil {
+			t.Fatalf("unexpected fields: %+v", got)
+		}
+		if math.Abs(*got.MedianDelta) > 1e-9 || math.Abs(*got.MarketRank-50) > 1e-6 {
+			t.Errorf("median delta = %v, rank = %v, want 0 and 50", *got.MedianDelta, *got.MarketRank)
+		}
+	})
+}
+
+func TestOptionChainExpiry(t *testing.T) {
+	tests := []struct {
+		name   string
+		now    string
+		symbol string
+		want   string
+	}{
+		{"quarterly", "2026-10-17", "SPY", "2027-09-17"},
+		{"january", "2026-10-17", "VNQ", "2028-01-21"},
+		{"quarterly near year end", "2026-03-01", "GLD", "2026-12-18"},
+		{"january near year end", "2026-03-01", "USO", "2027-01-15"},
+		{"third friday too close skips to next cycle month", "2026-12-24", "TLT", "2027-12-17"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, _ := time.Parse("2006-01-02", tt.now)
+			if got := optionChains[tt.symbol].expiry(now); got != tt.want {
+				t.Errorf("%s expiry at %s = %s, want %s", tt.symbol, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionChainExpiry_NeverExpired(t *testing.T) {
+	// The chains once hardcoded expiries that then passed; every day must get a live one
+	start := time.Date(2025, time.November, 22, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 3*365; day++ {
+		now := start.AddDate(0, 0, day)
+		for symbol, chain := range optionChains {
+			expiry, err := time.Parse("2006-01-02", chain.expiry(now))
+			if err != nil {
+				t.Fatalf("%s expiry at %s: %v", symbol, now.Format("2006-01-02"), err)
+			}
+			if expiry.Before(now.AddDate(0, 0, minExpiryDays)) || expiry.Weekday() != time.Friday || expiry.Day() < 15 || expiry.Day() > 21 {
+				t.Fatalf("%s expiry at %s = %s, want a third Friday at least %d days out", symbol, now.Format("2006-01-02"), expiry.Format("2006-01-02"), minExpiryDays)
+			}
+		}
+	}
+}
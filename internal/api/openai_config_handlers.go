@@ -3,25 +3,43 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/models"
 )
 
+// CredentialReloader swaps the running enricher's API credentials for the stored ones after
+// checking the provider accepts them
+type CredentialReloader interface {
+	ReloadCredentials(ctx context.Context) error
+}
+
 type OpenAIConfigHandlers struct {
-	repo   *database.OpenAIConfigRepository
-	logger *slog.Logger
+	repo     *database.OpenAIConfigRepository
+	logger   *slog.Logger
+	reloader CredentialReloader // nil when the enricher isn't running from the database config
+
+	// checkCredentials confirms the provider accepts new credentials before they are saved
+	checkCredentials func(ctx context.Context, config *models.OpenAIConfig) error
 }
 
 func NewOpenAIConfigHandlers(repo *database.OpenAIConfigRepository, logger *slog.Logger) *OpenAIConfigHandlers {
 	return &OpenAIConfigHandlers{
-		repo:   repo,
-		logger: logger,
+		repo:             repo,
+		logger:           logger,
+		checkCredentials: enrichment.CheckCredentials,
 	}
 }
 
+// SetCredentialReloader lets credential changes reach the running enricher without a restart.
+func (h *OpenAIConfigHandlers) SetCredentialReloader(reloader CredentialReloader) {
+	h.reloader = reloader
+}
+
 // GetOpenAIConfig handles GET /api/openai-config
 func (h *OpenAIConfigHandlers) GetOpenAIConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -35,6 +53,7 @@ func (h *OpenAIConfigHandlers) GetOpenAIConfig(w http.ResponseWriter, r *http.Re
 		http.Error(w, "Failed to get OpenAI configuration", http.StatusInternalServerError)
 		return
 	}
+	config.APIKey = maskSecret(config.APIKey)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -63,6 +82,11 @@ func (h *OpenAIConfigHandlers) UpdateOpenAIConfig(w http.ResponseWriter, r *http
 		return
 	}
 
+	// The masked key from GET posted back unchanged keeps the stored key
+	if update.APIKey != nil && *update.APIKey != "" && *update.APIKey == maskSecret(currentConfig.APIKey) {
+		update.APIKey = nil
+	}
+
 	// Apply updates to a copy for validation
 	testConfig := *currentConfig
 	if update.APIKey != nil {
@@ -71,6 +95,9 @@ func (h *OpenAIConfigHandlers) UpdateOpenAIConfig(w http.ResponseWriter, r *http
 	if update.Model != nil {
 		testConfig.Model = *update.Model
 	}
+	if update.FallbackModels != nil {
+		testConfig.FallbackModels = update.FallbackModels
+	}
 	if update.Temperature != nil {
 		testConfig.Temperature = *update.Temperature
 	}
@@ -80,12 +107,57 @@ func (h *OpenAIConfigHandlers) UpdateOpenAIConfig(w http.ResponseWriter, r *http
 	if update.TimeoutSeconds != nil {
 		testConfig.TimeoutSeconds = *update.TimeoutSeconds
 	}
+	if update.Provider != nil {
+		testConfig.Provider = *update.Provider
+	}
+	if update.BaseURL != nil {
+		testConfig.BaseURL = *update.BaseURL
+	}
+	if update.AzureDeployment != nil {
+		testConfig.AzureDeployment = *update.AzureDeployment
+	}
+	if update.AzureAPIVersion != nil {
+		testConfig.AzureAPIVersion = *update.AzureAPIVersion
+	}
+	if update.CategoryMapping != nil {
+		testConfig.CategoryMapping = update.CategoryMapping
+	}
+	if update.SystemPrompt != nil {
+		testConfig.SystemPrompt = *update.SystemPrompt
+	}
+	if update.AnalysisTemplate != nil {
+		testConfig.AnalysisTemplate = *update.AnalysisTemplate
+	}
+	if update.PromptBSystemPrompt != nil {
+		testConfig.PromptBSystemPrompt = *update.PromptBSystemPrompt
+	}
+	if update.PromptBAnalysisTemplate != nil {
+		testConfig.PromptBAnalysisTemplate = *update.PromptBAnalysisTemplate
+	}
+	if update.PromptBFraction != nil {
+		testConfig.PromptBFraction = *update.PromptBFraction
+	}
 
 	// Validate the config
 	if err := ValidateOpenAIConfig(&testConfig); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := ValidatePromptTemplates(&update); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// New credentials are saved only once the provider accepts them, so a restart never loads a
+	// key that was rejected
+	changedCredentials := credentialsChanged(currentConfig, update)
+	if changedCredentials {
+		if err := h.checkCredentials(r.Context(), &testConfig); err != nil {
+			h.logger.Warn("rejected openai config update; provider refused the new credentials", "error", err)
+			http.Error(w, "OpenAI configuration not saved: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
 	// Update configuration in database
 	config, err := h.repo.Update(context.Background(), update)
@@ -97,17 +169,74 @@ func (h *OpenAIConfigHandlers) UpdateOpenAIConfig(w http.ResponseWriter, r *http
 
 	h.logger.Info("openai config updated",
 		"model", config.Model,
+		"fallback_models", config.FallbackModels,
 		"temperature", config.Temperature,
 		"enabled", config.Enabled,
 	)
+	config.APIKey = maskSecret(config.APIKey)
+
+	response := map[string]interface{}{
+		"success": true,
+		"message": "OpenAI configuration updated successfully. Changes will apply to new sources.",
+		"config":  config,
+	}
+
+	// The saved credentials go live at once; the enricher keeps the previous ones when the
+	// reload fails, e.g. after a provider switch that still needs a restart
+	if changedCredentials && h.reloader != nil {
+		if err := h.reloader.ReloadCredentials(r.Context()); err != nil {
+			h.logger.Warn("failed to reload openai credentials after config update", "error", err)
+			response["credentials_reloaded"] = false
+			response["message"] = "OpenAI configuration saved, but the enricher kept its previous credentials: " + err.Error()
+		} else {
+			response["credentials_reloaded"] = true
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// credentialsChanged reports whether an update changes the API key or where calls are sent
+func credentialsChanged(current *models.OpenAIConfig, update models.OpenAIConfigUpdate) bool {
+	changed := func(value *string, was string) bool { return value != nil && *value != was }
+	return changed(update.APIKey, current.APIKey) || changed(update.Provider, current.Provider) ||
+		changed(update.BaseURL, current.BaseURL) || changed(update.AzureDeployment, current.AzureDeployment) ||
+		changed(update.AzureAPIVersion, current.AzureAPIVersion)
+}
+
+// ReloadCredentials handles POST /api/openai-config/reload
+func (h *OpenAIConfigHandlers) ReloadCredentials(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.reloader == nil {
+		http.Error(w, "The enricher is not running from the database configuration; restart to load it", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.reloader.ReloadCredentials(r.Context()); err != nil {
+		h.logger.Error("failed to reload openai credentials", "error", err)
+		switch {
+		case errors.Is(err, enrichment.ErrCredentialsRejected):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, enrichment.ErrProviderChanged):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Failed to reload OpenAI credentials", http.StatusInternalServerError)
+		}
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
-		"message": "OpenAI configuration updated successfully. Changes will apply to new sources.",
-		"config":  config,
+		"message": "OpenAI credentials reloaded",
 	})
 }
 
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/enrichment"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+type stubCredentialReloader struct {
+	err   error
+	calls int
+}
+
+func (s *stubCredentialReloader) ReloadCredentials(ctx context.Context) error {
+	s.calls++
+	return s.err
+}
+
+func TestReloadCredentials(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		name     string
+		reloader *stubCredentialReloader
+		want     int
+	}{
+		{"no enricher", nil, http.StatusServiceUnavailable},
+		{"reloaded", &stubCredentialReloader{}, http.StatusOK},
+		{"rejected", &stubCredentialReloader{err: fmt.Errorf("%w: 401", enrichment.ErrCredentialsRejected)}, http.StatusBadRequest},
+		{"provider changed", &stubCredentialReloader{err: enrichment.ErrProviderChanged}, http.StatusConflict},
+		{"database down", &stubCredentialReloader{err: fmt.Errorf("connection refused")}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOpenAIConfigHandlers(nil, logger)
+			if tt.reloader != nil {
+				h.SetCredentialReloader(tt.reloader)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ReloadCredentials(rec, httptest.NewRequest(http.MethodPost, "/api/openai-config/reload", nil))
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+			if tt.reloader != nil && tt.reloader.calls != 1 {
+				t.Errorf("expected one reload, got %d", tt.reloader.calls)
+			}
+		})
+	}
+}
+
+func TestCredentialsChanged(t *testing.T) {
+	current := &models.OpenAIConfig{APIKey: "sk-old", Model: "gpt-4o-mini"}
+	oldKey, newKey, model := "sk-old", "sk-new", "gpt-4o"
+	if !credentialsChanged(current, models.OpenAIConfigUpdate{APIKey: &newKey}) {
+		t.Error("expected a new API key to count as a credential change")
+	}
+	if credentialsChanged(current, models.OpenAIConfigUpdate{APIKey: &oldKey, Model: &model}) {
+		t.Error("expected resending the same key with a new model not to reload credentials")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"log/slog"
+
+	"github.com/STRATINT/stratint/internal/httpclient"
 )
 
 // OptionsAnalysisHandler handles GET /api/market/spy-risk-analysis
@@ -136,9 +138,8 @@ func (h *OptionsAnalysisHandler) HandleSPYRiskAnalysis(w http.ResponseWriter, r
 
 	h.logger.Info("fetching SPY options chain for risk analysis")
 
-	// Use December 2026 third Friday (standard monthly expiration)
-	// This is a known date with high liquidity
-	expiryDate := "2026-12-18"
+	// Use the quarterly expiry roughly a year out (standard monthly expiration, high liquidity)
+	expiryDate := optionChains["SPY"].expiry(time.Now())
 
 	h.logger.Info("using expiry date", "date", expiryDate)
 
@@ -146,7 +147,7 @@ func (h *OptionsAnalysisHandler) HandleSPYRiskAnalysis(w http.ResponseWriter, r
 	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/SPY/option-chain?assetclass=etf&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
 		expiryDate, expiryDate)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	req, err := http.NewRequest("GET", nasdaqURL, nil)
 	if err != nil {
 		h.logger.Error("failed to create nasdaq request", "error", err)
@@ -242,9 +243,8 @@ func (h *OptionsAnalysisHandler) HandleIBITRiskAnalysis(w http.ResponseWriter, r
 
 	h.logger.Info("fetching IBIT options chain for risk analysis")
 
-	// Use January 15, 2027 for IBIT options (furthest available ~15 months out)
-	// Note: IBIT has leap options available for longer-term analysis
-	expiryDate := "2027-01-15"
+	// Use the January LEAPS expiry roughly a year or more out for IBIT options
+	expiryDate := optionChains["IBIT"].expiry(time.Now())
 
 	h.logger.Info("using expiry date", "date", expiryDate)
 
@@ -253,7 +253,7 @@ func (h *OptionsAnalysisHandler) HandleIBITRiskAnalysis(w http.ResponseWriter, r
 	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/IBIT/option-chain?assetclass=stocks&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
 		expiryDate, expiryDate)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	req, err := http.NewRequest("GET", nasdaqURL, nil)
 	if err != nil {
 		h.logger.Error("failed to create nasdaq request", "error", err)
@@ -349,9 +349,8 @@ func (h *OptionsAnalysisHandler) HandleGLDRiskAnalysis(w http.ResponseWriter, r
 
 	h.logger.Info("fetching GLD options chain for risk analysis")
 
-	// Use September 2026 for GLD options (~1 year out, 335 days)
-	// Closest available to 1-year horizon with adequate liquidity
-	expiryDate := "2026-09-18"
+	// Use the quarterly expiry closest to a 1-year horizon with adequate liquidity
+	expiryDate := optionChains["GLD"].expiry(time.Now())
 
 	h.logger.Info("using expiry date", "date", expiryDate)
 
@@ -360,7 +359,7 @@ func (h *OptionsAnalysisHandler) HandleGLDRiskAnalysis(w http.ResponseWriter, r
 	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/GLD/option-chain?assetclass=etf&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
 		expiryDate, expiryDate)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	req, err := http.NewRequest("GET", nasdaqURL, nil)
 	if err != nil {
 		h.logger.Error("failed to create nasdaq request", "error", err)
@@ -639,13 +638,13 @@ func (h *OptionsAnalysisHandler) HandleTLTRiskAnalysis(w http.ResponseWriter, r
 	}
 
 	h.logger.Info("fetching TLT options chain for risk analysis")
-	expiryDate := "2026-09-18"
+	expiryDate := optionChains["TLT"].expiry(time.Now())
 	h.logger.Info("using expiry date", "date", expiryDate)
 
 	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/TLT/option-chain?assetclass=etf&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
 		expiryDate, expiryDate)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	req, err := http.NewRequest("GET", nasdaqURL, nil)
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -713,15 +712,15 @@ func (h *OptionsAnalysisHandler) HandleVNQRiskAnalysis(w http.ResponseWriter, r
 
 	h.logger.Info("fetching VNQ options chain for risk analysis")
 
-	// Use November 21, 2025 for VNQ options (only available expiry as of Oct 2025)
-	expiryDate := "2025-11-21"
+	// Use the January LEAPS expiry for VNQ options, which list few long-dated expirations
+	expiryDate := optionChains["VNQ"].expiry(time.Now())
 	h.logger.Info("using expiry date", "date", expiryDate)
 
 	// VNQ is an ETF, so use assetclass=etf
 	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/VNQ/option-chain?assetclass=etf&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
 		expiryDate, expiryDate)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	req, err := http.NewRequest("GET", nasdaqURL, nil)
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -789,16 +788,16 @@ func (h *OptionsAnalysisHandler) HandleUSORiskAnalysis(w http.ResponseWriter, r
 
 	h.logger.Info("fetching USO options chain for risk analysis")
 
-	// Use January 15, 2027 for USO options (LEAPS, ~15 months out - closest available to 1 year)
-	// Note: USO has limited option expirations, this is the furthest available
-	expiryDate := "2027-01-15"
+	// Use the January LEAPS expiry for USO options, closest available to 1 year
+	// Note: USO has limited option expirations
+	expiryDate := optionChains["USO"].expiry(time.Now())
 	h.logger.Info("using expiry date", "date", expiryDate)
 
 	// USO is an ETF, so use assetclass=etf
 	nasdaqURL := fmt.Sprintf("https://api.nasdaq.com/api/quote/USO/option-chain?assetclass=etf&limit=200&fromdate=%s&todate=%s&excode=oprac&callput=callput&money=all&type=all",
 		expiryDate, expiryDate)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := httpclient.New()
 	req, err := http.NewRequest("GET", nasdaqURL, nil)
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -10,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/ingestion"
 	"github.com/STRATINT/stratint/internal/models"
 	"log/slog"
@@ -31,6 +33,9 @@ type PipelineHandler struct {
 	db         *sql.DB
 	logger     *slog.Logger
 	noise      *noiseCache
+	workers    *enrichment.WorkerStats
+	errorRepo  database.IngestionErrorRepository
+	enricher   enrichment.Enricher
 }
 
 // NewPipelineHandler creates a new pipeline handler.
@@ -51,6 +56,50 @@ func NewPipelineHandler(
 	}
 }
 
+// SetWorkerStats reports the enrichment worker counts in pipeline metrics.
+func (h *PipelineHandler) SetWorkerStats(stats *enrichment.WorkerStats) {
+	h.workers = stats
+}
+
+// SetIngestionErrors reports open ingestion errors in pipeline status.
+func (h *PipelineHandler) SetIngestionErrors(repo database.IngestionErrorRepository) {
+	h.errorRepo = repo
+}
+
+// SetEnricher reports whether the running enricher is real or the mock in pipeline status.
+func (h *PipelineHandler) SetEnricher(enricher enrichment.Enricher) {
+	h.enricher = enricher
+}
+
+// statusThroughputWindow is how far back pipeline status measures enrichment throughput.
+const statusThroughputWindow = 15 * time.Minute
+
+// PipelineStatusResponse is an at-a-glance view of pipeline health.
+type PipelineStatusResponse struct {
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// Sources by enrichment status, and how long the oldest pending source has waited
+	SourcesByEnrichmentStatus map[string]int `json:"sources_by_enrichment_status"`
+	OldestPendingSourceAt     *time.Time     `json:"oldest_pending_source_at,omitempty"`
+	OldestPendingAgeSeconds   float64        `json:"oldest_pending_age_seconds"`
+
+	// Events by status, and events created per minute over the throughput window
+	EventsByStatus          map[string]int `json:"events_by_status"`
+	EventsCreatedRecent     int            `json:"events_created_recent"`
+	ThroughputWindowMinutes int            `json:"throughput_window_minutes"`
+	EventsPerMinute         float64        `json:"events_per_minute"`
+
+	// Unresolved ingestion errors by failure category
+	OpenErrorsByCategory map[models.ErrorCategory]int `json:"open_errors_by_category"`
+	OpenErrorsTotal      int                          `json:"open_errors_total"`
+
+	// Enricher in use ("openai" or "mock") and the workers on this instance
+	Enricher                string `json:"enricher"`
+	EnricherMock            bool   `json:"enricher_mock"`
+	EnrichmentWorkers       int    `json:"enrichment_workers"`
+	EnrichmentWorkersActive int    `json:"enrichment_workers_active"`
+}
+
 // PipelineMetricsResponse represents the processing pipeline metrics.
 type PipelineMetricsResponse struct {
 	// Source stage
@@ -61,6 +110,10 @@ type PipelineMetricsResponse struct {
 	// Enrichment stage (sources waiting for AI enrichment)
 	EnrichmentByStatus map[string]int `json:"enrichment_by_status"` // pending, enriching, completed, failed
 
+	// Enrichment workers on the instance serving this request
+	EnrichmentWorkers       int `json:"enrichment_workers"`
+	EnrichmentWorkersActive int `json:"enrichment_workers_active"`
+
 	// Event stage
 	EventsTotal       int            `json:"events_total"`
 	EventsByStatus    map[string]int `json:"events_by_status"`
@@ -145,6 +198,141 @@ func (h *PipelineHandler) GetPipelineMetricsHandler(w http.ResponseWriter, r *ht
 	}
 }
 
+// GetPipelineStatusHandler returns a single-payload summary of pipeline health.
+// GET /api/pipeline/status
+func (h *PipelineHandler) GetPipelineStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status, err := h.calculatePipelineStatus(r.Context(), time.Now())
+	if err != nil {
+		h.logger.Error("failed to calculate pipeline status", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// calculatePipelineStatus gathers pipeline status with one grouped query per table.
+func (h *PipelineHandler) calculatePipelineStatus(ctx context.Context, now time.Time) (*PipelineStatusResponse, error) {
+	status := &PipelineStatusResponse{
+		GeneratedAt: now,
+		SourcesByEnrichmentStatus: map[string]int{
+			"pending":   0,
+			"enriching": 0,
+			"completed": 0,
+			"failed":    0,
+		},
+		EventsByStatus: map[string]int{
+			"pending":   0,
+			"published": 0,
+			"rejected":  0,
+			"enriched":  0,
+			"archived":  0,
+		},
+		OpenErrorsByCategory:    map[models.ErrorCategory]int{},
+		ThroughputWindowMinutes: int(statusThroughputWindow.Minutes()),
+		EnrichmentWorkers:       h.workers.Configured(),
+		EnrichmentWorkersActive: h.workers.Active(),
+	}
+	status.Enricher, status.EnricherMock = enricherMode(h.enricher)
+
+	// Sources: counts per enrichment status; the pending group's MIN(created_at) is the oldest waiting source
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT enrichment_status, COUNT(*), MIN(created_at)
+		FROM sources
+		WHERE `+workspaceCondition("workspace_id", 1)+`
+		GROUP BY enrichment_status
+	`, database.WorkspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sources by enrichment status: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var enrichmentStatus string
+		var count int
+		var oldest sql.NullTime
+		if err := rows.Scan(&enrichmentStatus, &count, &oldest); err != nil {
+			return nil, fmt.Errorf("failed to scan enrichment status: %w", err)
+		}
+		status.SourcesByEnrichmentStatus[enrichmentStatus] = count
+		if enrichmentStatus == string(models.EnrichmentStatusPending) && oldest.Valid {
+			status.OldestPendingSourceAt = &oldest.Time
+			status.OldestPendingAgeSeconds = max(now.Sub(oldest.Time).Seconds(), 0)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating enrichment rows: %w", err)
+	}
+
+	// Events: counts per status and how many were created inside the throughput window
+	eventRows, err := h.db.QueryContext(ctx, `
+		SELECT status, COUNT(*), COUNT(*) FILTER (WHERE created_at >= $1)
+		FROM events
+		WHERE `+workspaceCondition("workspace_id", 2)+`
+		GROUP BY status
+	`, now.Add(-statusThroughputWindow), database.WorkspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events by status: %w", err)
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var eventStatus string
+		var count, recent int
+		if err := eventRows.Scan(&eventStatus, &count, &recent); err != nil {
+			return nil, fmt.Errorf("failed to scan event status: %w", err)
+		}
+		status.EventsByStatus[eventStatus] = count
+		status.EventsCreatedRecent += recent
+	}
+	if err := eventRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event rows: %w", err)
+	}
+	status.EventsPerMinute = float64(status.EventsCreatedRecent) / statusThroughputWindow.Minutes()
+
+	// Open ingestion errors by category
+	if h.errorRepo != nil {
+		stats, err := h.errorRepo.Stats(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stats {
+			status.OpenErrorsByCategory[s.Category] += s.Count
+			status.OpenErrorsTotal += s.Count
+		}
+	}
+
+	return status, nil
+}
+
+// enricherMode names the enricher in use and whether it is the mock.
+func enricherMode(enricher enrichment.Enricher) (string, bool) {
+	switch e := enricher.(type) {
+	case nil:
+		return "none", false
+	case *enrichment.MockEnricher:
+		return "mock", true
+	case *enrichment.FallbackEnricher:
+		if e.Mock() {
+			return "mock", true
+		}
+		return "openai", false
+	default:
+		return "openai", false
+	}
+}
+
 // calculatePipelineMetrics computes the pipeline metrics.
 func (h *PipelineHandler) calculatePipelineMetrics(ctx context.Context) (*PipelineMetricsResponse, error) {
 	// Initialize with all expected keys to avoid empty/sparse maps
@@ -168,6 +356,8 @@ func (h *PipelineHandler) calculatePipelineMetrics(ctx context.Context) (*Pipeli
 			"rejected":  0,
 		},
 	}
+	metrics.EnrichmentWorkers = h.workers.Configured()
+	metrics.EnrichmentWorkersActive = h.workers.Active()
 
 	// Get source counts by status
 	statuses := []models.ScrapeStatus{
@@ -194,8 +384,9 @@ func (h *PipelineHandler) calculatePipelineMetrics(ctx context.Context) (*Pipeli
 	rows, err := h.db.QueryContext(ctx, `
 		SELECT enrichment_status, COUNT(*)
 		FROM sources
+		WHERE `+workspaceCondition("workspace_id", 1)+`
 		GROUP BY enrichment_status
-	`)
+	`, database.WorkspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query enrichment status: %w", err)
 	}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/enrichment"
+)
+
+func TestEnricherMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		enricher enrichment.Enricher
+		wantMode string
+		wantMock bool
+	}{
+		{"none", nil, "none", false},
+		{"mock", enrichment.NewMockEnricher(), "mock", true},
+		{"real", &enrichment.OpenAIClient{}, "openai", false},
+		{"fallback", enrichment.NewFallbackEnricher(nil, nil), "mock", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, mock := enricherMode(tt.enricher)
+			if mode != tt.wantMode || mock != tt.wantMock {
+				t.Errorf("enricherMode() = %q, %v; want %q, %v", mode, mock, tt.wantMode, tt.wantMock)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+const (
+	// defaultPromptVariantDays is how far back prompt A/B results look by default
+	defaultPromptVariantDays = 30
+	// maxPromptVariantDays is the furthest back prompt A/B results may look
+	maxPromptVariantDays = 365
+)
+
+// PromptVariantRepository aggregates events per enrichment prompt variant.
+type PromptVariantRepository interface {
+	GetPromptVariantStats(ctx context.Context, since time.Time) ([]models.PromptVariantStats, error)
+}
+
+// PromptVariantHandler reports how the prompts in an enrichment A/B test compare.
+type PromptVariantHandler struct {
+	repo   PromptVariantRepository
+	logger *slog.Logger
+}
+
+// NewPromptVariantHandler creates a new prompt variant handler.
+func NewPromptVariantHandler(repo PromptVariantRepository, logger *slog.Logger) *PromptVariantHandler {
+	return &PromptVariantHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// PromptVariantResponse compares the prompt variants over the requested period.
+type PromptVariantResponse struct {
+	Days     int                         `json:"days"`
+	Variants []models.PromptVariantStats `json:"variants"`
+}
+
+// GetPromptVariantsHandler returns average confidence, average magnitude and rejection rate of
+// the events created by each prompt variant, for comparing prompts in an A/B test.
+// GET /api/admin/events/prompt-variants?days=30
+func (h *PromptVariantHandler) GetPromptVariantsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days := defaultPromptVariantDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 1 || d > maxPromptVariantDays {
+			http.Error(w, fmt.Sprintf("days must be between 1 and %d", maxPromptVariantDays), http.StatusBadRequest)
+			return
+		}
+		days = d
+	}
+
+	stats, err := h.repo.GetPromptVariantStats(r.Context(), time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		h.logger.Error("failed to get prompt variant stats", "error", err)
+		http.Error(w, "Failed to get prompt variant stats", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PromptVariantResponse{
+		Days:     days,
+		Variants: stats,
+	})
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// RelatedEventsRepository finds events overlapping with a given event.
+type RelatedEventsRepository interface {
+	GetRelatedEvents(ctx context.Context, q models.RelatedEventsQuery) ([]models.RelatedEvent, int, error)
+}
+
+// RelatedEventsHandler serves the events related to an event.
+type RelatedEventsHandler struct {
+	repo   RelatedEventsRepository
+	logger *slog.Logger
+}
+
+// NewRelatedEventsHandler creates a new related events handler.
+func NewRelatedEventsHandler(repo RelatedEventsRepository, logger *slog.Logger) *RelatedEventsHandler {
+	return &RelatedEventsHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// RelatedEventsResponse is a page of events related to one event.
+type RelatedEventsResponse struct {
+	Query   models.RelatedEventsQuery `json:"query"`
+	Total   int                       `json:"total"`
+	HasMore bool                      `json:"has_more"`
+	Related []models.RelatedEvent     `json:"related"`
+}
+
+// GetRelatedEventsHandler returns recent published events sharing entities, tags or source URLs
+// with an event, highest overlap first. Works without correlation or any model calls.
+// GET /api/events/:id/related?days=7&limit=10&offset=0
+func (h *RelatedEventsHandler) GetRelatedEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/events/"), "/related")
+	params := r.URL.Query()
+
+	query := models.RelatedEventsQuery{EventID: strings.TrimSpace(id)}
+	for param, dst := range map[string]*int{"days": &query.Days, "limit": &query.Limit, "offset": &query.Offset} {
+		if value := params.Get(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w, "Invalid "+param+": must be an integer", http.StatusBadRequest)
+				return
+			}
+			*dst = n
+		}
+	}
+
+	if err := query.Validate(time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	related, total, err := h.repo.GetRelatedEvents(r.Context(), query)
+	if err != nil {
+		h.logger.Error("failed to get related events", "id", query.EventID, "error", err)
+		http.Error(w, "Failed to get related events", http.StatusInternalServerError)
+		return
+	}
+	if related == nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RelatedEventsResponse{
+		Query:   query,
+		Total:   total,
+		HasMore: query.Offset+len(related) < total,
+		Related: related,
+	})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+type stubRelatedRepo struct {
+	query   models.RelatedEventsQuery
+	related []models.RelatedEvent
+	total   int
+}
+
+func (s *stubRelatedRepo) GetRelatedEvents(ctx context.Context, q models.RelatedEventsQuery) ([]models.RelatedEvent, int, error) {
+	s.query = q
+	return s.related, s.total, nil
+}
+
+func TestGetRelatedEventsHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	repo := &stubRelatedRepo{
+		related: []models.RelatedEvent{{ID: "evt-2", SharedEntities: []string{"kyiv"}, Score: 1}},
+		total:   3,
+	}
+	h := NewRelatedEventsHandler(repo, logger)
+
+	rec := httptest.NewRecorder()
+	h.GetRelatedEventsHandler(rec, httptest.NewRequest(http.MethodGet, "/api/events/evt-1/related?days=3&limit=1&offset=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if repo.query.EventID != "evt-1" || repo.query.Days != 3 || repo.query.Limit != 1 || repo.query.Offset != 1 {
+		t.Errorf("unexpected query passed to repository: %+v", repo.query)
+	}
+
+	var resp RelatedEventsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Total != 3 || !resp.HasMore || len(resp.Related) != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	// Unknown or unpublished events
+	rec = httptest.NewRecorder()
+	NewRelatedEventsHandler(&stubRelatedRepo{}, logger).GetRelatedEventsHandler(rec, httptest.NewRequest(http.MethodGet, "/api/events/missing/related", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for missing event, got %d", rec.Code)
+	}
+
+	for _, bad := range []string{"days=0x", "days=365", "limit=ten"} {
+		rec = httptest.NewRecorder()
+		h.GetRelatedEventsHandler(rec, httptest.NewRequest(http.MethodGet, "/api/events/evt-1/related?"+bad, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", bad, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/STRATINT/stratint/internal/auth"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// reprocessJobListLimit is how many recent jobs the list endpoint returns
+const reprocessJobListLimit = 20
+
+// ReprocessJobRepository creates, lists and cancels bulk reprocessing jobs.
+type ReprocessJobRepository interface {
+	Create(ctx context.Context, req models.ReprocessJobRequest, createdBy string) (*models.ReprocessJob, error)
+	Get(ctx context.Context, id string) (*models.ReprocessJob, error)
+	List(ctx context.Context, limit int) ([]models.ReprocessJob, error)
+	Cancel(ctx context.Context, id string) (bool, error)
+}
+
+// ReprocessHandler serves the bulk reprocessing endpoints.
+type ReprocessHandler struct {
+	repo   ReprocessJobRepository
+	logger *slog.Logger
+}
+
+// NewReprocessHandler creates a new reprocess handler.
+func NewReprocessHandler(repo ReprocessJobRepository, logger *slog.Logger) *ReprocessHandler {
+	return &ReprocessHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ReprocessJobResponse is a job with its progress.
+type ReprocessJobResponse struct {
+	models.ReprocessJob
+	Progress float64 `json:"progress"` // Fraction of matching sources processed, 0-1
+}
+
+func newReprocessJobResponse(job models.ReprocessJob) ReprocessJobResponse {
+	return ReprocessJobResponse{ReprocessJob: job, Progress: job.Progress()}
+}
+
+// HandleReprocessAll starts a job (POST) or lists recent jobs (GET).
+// POST /api/admin/reprocess-all with an optional JSON body (see models.ReprocessJobRequest)
+// re-enriches every matching source in batches and returns the job with 202 Accepted.
+func (h *ReprocessHandler) HandleReprocessAll(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listJobs(w, r)
+	case http.MethodPost:
+		h.createJob(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ReprocessHandler) createJob(w http.ResponseWriter, r *http.Request) {
+	var req models.ReprocessJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	actor, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok || actor == "" {
+		actor = "unknown"
+	}
+
+	job, err := h.repo.Create(r.Context(), req, actor)
+	if err != nil {
+		h.logger.Error("failed to create reprocess job", "error", err)
+		http.Error(w, "Failed to create reprocess job", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("Admin started reprocess job",
+		"job_id", job.ID,
+		"actor", actor,
+		"platform", job.Platform,
+		"since", job.Since,
+		"until", job.Until,
+		"batch_size", job.BatchSize,
+		"total", job.Total)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(newReprocessJobResponse(*job))
+}
+
+func (h *ReprocessHandler) listJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.repo.List(r.Context(), reprocessJobListLimit)
+	if err != nil {
+		h.logger.Error("failed to list reprocess jobs", "error", err)
+		http.Error(w, "Failed to list reprocess jobs", http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]ReprocessJobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		responses = append(responses, newReprocessJobResponse(job))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jobs": responses,
+	})
+}
+
+// HandleReprocessJob returns a job's progress (GET /api/admin/reprocess-all/:id) or cancels it
+// (POST /api/admin/reprocess-all/:id/cancel). Cancelling stops further batches; sources already
+// queued are still enriched.
+func (h *ReprocessHandler) HandleReprocessJob(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/reprocess-all/")
+	id, action, _ := strings.Cut(path, "/")
+	if id == "" {
+		http.Error(w, "Job ID required", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.getJob(w, r, id)
+	case action == "cancel" && r.Method == http.MethodPost:
+		h.cancelJob(w, r, id)
+	case action == "" || action == "cancel":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+func (h *ReprocessHandler) getJob(w http.ResponseWriter, r *http.Request, id string) {
+	job, err := h.repo.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get reprocess job", "job_id", id, "error", err)
+		http.Error(w, "Failed to get reprocess job", http.StatusInternalServerError)
+		return
+	}
+	if job == nil {
+		http.Error(w, "Reprocess job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newReprocessJobResponse(*job))
+}
+
+func (h *ReprocessHandler) cancelJob(w http.ResponseWriter, r *http.Request, id string) {
+	cancelled, err := h.repo.Cancel(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to cancel reprocess job", "job_id", id, "error", err)
+		http.Error(w, "Failed to cancel reprocess job", http.StatusInternalServerError)
+		return
+	}
+
+	job, err := h.repo.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get reprocess job", "job_id", id, "error", err)
+		http.Error(w, "Failed to get reprocess job", http.StatusInternalServerError)
+		return
+	}
+	if job == nil {
+		http.Error(w, "Reprocess job not found", http.StatusNotFound)
+		return
+	}
+	if !cancelled {
+		http.Error(w, "Reprocess job is not running", http.StatusConflict)
+		return
+	}
+
+	h.logger.Info("Admin cancelled reprocess job", "job_id", id, "processed", job.Processed, "total", job.Total)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newReprocessJobResponse(*job))
+}
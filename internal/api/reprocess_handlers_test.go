@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+type stubReprocessRepo struct {
+	jobs      map[string]*models.ReprocessJob
+	createReq models.ReprocessJobRequest
+}
+
+func (s *stubReprocessRepo) Create(ctx context.Context, req models.ReprocessJobRequest, createdBy string) (*models.ReprocessJob, error) {
+	s.createReq = req
+	job := &models.ReprocessJob{ID: "job-1", Status: models.ReprocessJobRunning, Platform: req.Platform, BatchSize: req.BatchSize, Total: 250, CreatedBy: createdBy}
+	s.jobs[job.ID] = job
+	return job, nil
+}
+
+func (s *stubReprocessRepo) Get(ctx context.Context, id string) (*models.ReprocessJob, error) {
+	return s.jobs[id], nil
+}
+
+func (s *stubReprocessRepo) List(ctx context.Context, limit int) ([]models.ReprocessJob, error) {
+	var jobs []models.ReprocessJob
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+func (s *stubReprocessRepo) Cancel(ctx context.Context, id string) (bool, error) {
+	job, ok := s.jobs[id]
+	if !ok || job.Status != models.ReprocessJobRunning {
+		return false, nil
+	}
+	job.Status = models.ReprocessJobCancelled
+	return true, nil
+}
+
+func TestReprocessHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &stubReprocessRepo{jobs: map[string]*models.ReprocessJob{}}
+	h := NewReprocessHandler(repo, logger)
+
+	rec := httptest.NewRecorder()
+	h.HandleReprocessAll(rec, httptest.NewRequest(http.MethodPost, "/api/admin/reprocess-all",
+		strings.NewReader(`{"platform":"twitter","since":"2025-01-01T00:00:00Z"}`)))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if repo.createReq.Platform != models.SourceTypeTwitter || repo.createReq.Since == nil || repo.createReq.BatchSize != models.DefaultReprocessBatchSize {
+		t.Errorf("unexpected request passed to repository: %+v", repo.createReq)
+	}
+	var created ReprocessJobResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "job-1" || created.Status != models.ReprocessJobRunning || created.Total != 250 {
+		t.Errorf("unexpected job: %+v", created)
+	}
+
+	for _, bad := range []string{`{"platform":"fax"}`, `{"batch_size":5000}`, `{"since":"2025-02-01T00:00:00Z","until":"2025-01-01T00:00:00Z"}`, `not json`} {
+		rec = httptest.NewRecorder()
+		h.HandleReprocessAll(rec, httptest.NewRequest(http.MethodPost, "/api/admin/reprocess-all", strings.NewReader(bad)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %s: expected 400, got %d", bad, rec.Code)
+		}
+	}
+
+	repo.jobs["job-1"].Processed = 100
+	rec = httptest.NewRecorder()
+	h.HandleReprocessJob(rec, httptest.NewRequest(http.MethodGet, "/api/admin/reprocess-all/job-1", nil))
+	var got ReprocessJobResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.Progress != 0.4 {
+		t.Errorf("Progress = %v, want 0.4", got.Progress)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleReprocessJob(rec, httptest.NewRequest(http.MethodPost, "/api/admin/reprocess-all/job-1/cancel", nil))
+	if rec.Code != http.StatusOK || repo.jobs["job-1"].Status != models.ReprocessJobCancelled {
+		t.Fatalf("expected job cancelled, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleReprocessJob(rec, httptest.NewRequest(http.MethodPost, "/api/admin/reprocess-all/job-1/cancel", nil))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 cancelling a stopped job, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleReprocessJob(rec, httptest.NewRequest(http.MethodGet, "/api/admin/reprocess-all/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown job, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// RetentionRunner applies the data retention policy.
+type RetentionRunner interface {
+	Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error)
+}
+
+// RetentionHandler handles data retention endpoints.
+type RetentionHandler struct {
+	runner RetentionRunner
+	logger *slog.Logger
+}
+
+// NewRetentionHandler creates a new retention handler.
+func NewRetentionHandler(runner RetentionRunner, logger *slog.Logger) *RetentionHandler {
+	return &RetentionHandler{
+		runner: runner,
+		logger: logger,
+	}
+}
+
+// HandleRetention reports what the retention policy would delete (GET, a dry run)
+// or applies it immediately (POST).
+func (h *RetentionHandler) HandleRetention(w http.ResponseWriter, r *http.Request) {
+	var dryRun bool
+	switch r.Method {
+	case http.MethodGet:
+		dryRun = true
+	case http.MethodPost:
+		dryRun = false
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.runner == nil {
+		http.Error(w, "Retention not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	report, err := h.runner.Run(r.Context(), dryRun)
+	if err != nil {
+		h.logger.Error("retention run failed", "dry_run", dryRun, "error", err)
+		http.Error(w, "Retention run failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -6,26 +6,31 @@ import (
 	"strings"
 
 	"github.com/STRATINT/stratint/internal/auth"
+	"github.com/STRATINT/stratint/internal/config"
 	"github.com/STRATINT/stratint/internal/database"
 	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/eventmanager"
 	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/ingestion"
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/server"
 	"github.com/STRATINT/stratint/internal/social"
 	"github.com/STRATINT/stratint/internal/strategist"
 	"log/slog"
 )
 
 // SetupRoutes configures all API routes
-func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLifecycleManager, sourceRepo ingestion.SourceRepository, eventRepo ingestion.EventRepository, trackedAccountRepo models.TrackedAccountRepository, errorRepo database.IngestionErrorRepository, thresholdRepo *database.ThresholdRepository, activityLogRepo *database.ActivityLogRepository, openaiConfigRepo *database.OpenAIConfigRepository, connectorConfigRepo *database.ConnectorConfigRepository, twitterRepo *database.TwitterRepository, twitterPoster eventmanager.TwitterPoster, credibilityCache *enrichment.CredibilityCache, enricher enrichment.Enricher, authConfig auth.Config, fredAPIKey string, logger *slog.Logger) {
+func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLifecycleManager, sourceRepo ingestion.SourceRepository, eventRepo ingestion.EventRepository, trackedAccountRepo models.TrackedAccountRepository, errorRepo database.IngestionErrorRepository, thresholdRepo *database.ThresholdRepository, activityLogRepo *database.ActivityLogRepository, openaiConfigRepo *database.OpenAIConfigRepository, connectorConfigRepo *database.ConnectorConfigRepository, twitterRepo *database.TwitterRepository, twitterPoster eventmanager.TwitterPoster, credibilityCache *enrichment.CredibilityCache, enricher enrichment.Enricher, inferenceLogger *inference.Logger, authConfig auth.Config, fredAPIKey string, rateLimits config.RateLimitConfig, forecastConfig config.ForecastScheduleConfig, enrichmentConfig config.EnrichmentConfig, enrichmentWorkers *enrichment.WorkerStats, retention RetentionRunner, logger *slog.Logger) {
 	handler := NewHandler(manager, sourceRepo, trackedAccountRepo, logger)
 	trackedAccountsHandler := NewTrackedAccountsHandler(trackedAccountRepo, sourceRepo, errorRepo, activityLogRepo, connectorConfigRepo, credibilityCache, enricher, logger)
-	connectorConfigHandler := NewConnectorConfigHandlers(connectorConfigRepo, logger)
+	connectorConfigHandler := NewConnectorConfigHandlers(connectorConfigRepo, trackedAccountRepo, logger)
 	thresholdHandler := NewThresholdHandlers(thresholdRepo, logger)
 	errorHandler := NewIngestionErrorHandler(errorRepo, logger)
 	activityHandler := NewActivityLogHandlers(activityLogRepo, logger)
 	openaiConfigHandler := NewOpenAIConfigHandlers(openaiConfigRepo, logger)
+	if reloader, ok := enricher.(CredentialReloader); ok {
+		openaiConfigHandler.SetCredentialReloader(reloader)
+	}
 	twitterConfigHandler := NewTwitterConfigHandlers(twitterRepo, logger)
 	// Inject dependencies for Twitter posting
 	if twitterPoster != nil {
@@ -35,16 +40,37 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 	}
 	twitterConfigHandler.SetEventRepo(eventRepo)
 	pipelineHandler := NewPipelineHandler(sourceRepo, eventRepo, db, logger)
+	pipelineHandler.SetWorkerStats(enrichmentWorkers)
+	pipelineHandler.SetIngestionErrors(errorRepo)
+	pipelineHandler.SetEnricher(enricher)
 	rssHandler := NewRSSHandler(manager, logger)
-	authHandler := NewAuthHandler(authConfig, logger)
+	authHandler := NewAuthHandler(authConfig, database.NewRefreshTokenRepository(db), logger)
+	apiKeyRepo := database.NewAPIKeyRepository(db)
+	apiKeyHandler := NewAPIKeyHandler(apiKeyRepo, logger)
+	authConfig.APIKeys = apiKeyRepo
 	adminHandler := NewAdminHandler(db, logger)
+	adminHandler.SetEventArchiver(manager)
+	adminHandler.SetClaimStaleWindow(enrichmentConfig.ClaimStaleWindow())
+	retentionHandler := NewRetentionHandler(retention, logger)
+	reprocessHandler := NewReprocessHandler(database.NewReprocessJobRepository(db), logger)
+	entityHandler := NewEntityHandler(eventRepo.(*database.PostgresEventRepository), logger)
+	duplicateHandler := NewDuplicateHandler(eventRepo.(*database.PostgresEventRepository), logger)
+	distributionHandler := NewDistributionHandler(eventRepo.(*database.PostgresEventRepository), logger)
+	promptVariantHandler := NewPromptVariantHandler(eventRepo.(*database.PostgresEventRepository), logger)
+	relatedHandler := NewRelatedEventsHandler(eventRepo.(*database.PostgresEventRepository), logger)
 
 	// Initialize inference log components
 	inferenceLogRepo := database.NewInferenceLogRepository(db)
-	inferenceLogger := inference.NewLogger(inferenceLogRepo, logger)
-	inferenceLogHandler := NewInferenceLogHandler(inferenceLogRepo, logger)
+	// Shares the enricher's logger so budget status and enforcement see the same usage
+	inferenceLogHandler := NewInferenceLogHandler(inferenceLogRepo, inferenceLogger, logger)
 
-	forecastHandler := NewForecastHandler(db, eventRepo.(*database.PostgresEventRepository), logger, inferenceLogger)
+	forecastHandler := NewForecastHandler(db, eventRepo.(*database.PostgresEventRepository), activityLogRepo, logger, inferenceLogger)
+	if embedder, ok := enricher.(enrichment.Embedder); ok {
+		forecastHandler.forecaster.SetEmbedder(embedder)
+	}
+	forecastHandler.forecaster.SetMaxIterations(forecastConfig.MaxIterations)
+	forecastHandler.forecaster.SetStoreSamples(forecastConfig.StoreSamples)
+	forecastHandler.forecaster.SetMinHeadlines(forecastConfig.MinHeadlines)
 
 	// Initialize strategy components
 	strategyRepo := database.NewStrategyRepository(db)
@@ -67,17 +93,33 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 	optionsHandler := NewOptionsAnalysisHandler(logger)
 	fredHandler := NewFREDHandler(logger, fredAPIKey)
 
-	// Auth middleware
+	// Rate limiting for public routes, keyed by client IP. Admin routes are not limited.
+	publicLimiter := server.NewRateLimiter(rateLimits.Public, rateLimits.TrustedProxies)
+	marketLimiter := server.NewRateLimiter(rateLimits.Market, rateLimits.TrustedProxies)
+
+	// Auth middleware. Most admin routes require the admin role; forecast, strategy and summary
+	// routes also let analysts read.
 	authMiddleware := auth.AuthMiddleware(authConfig)
+	readOnlyMiddleware := auth.ReadOnlyMiddleware(authConfig)
 
 	// Authentication routes (public)
 	mux.HandleFunc("/api/auth/login", authHandler.Login)
+	mux.HandleFunc("/api/auth/refresh", authHandler.Refresh)
+	mux.HandleFunc("/api/auth/logout", authHandler.Logout)
 	mux.HandleFunc("/api/auth/validate", func(w http.ResponseWriter, r *http.Request) {
-		authMiddleware(http.HandlerFunc(authHandler.ValidateToken)).ServeHTTP(w, r)
+		auth.AnyRoleMiddleware(authConfig)(http.HandlerFunc(authHandler.ValidateToken)).ServeHTTP(w, r)
 	})
 
 	// Event routes (public for reading)
-	mux.HandleFunc("/api/events", handler.GetEventsHandler)
+	mux.HandleFunc("/api/events", func(w http.ResponseWriter, r *http.Request) {
+		// Semantic search pays for an embedding call per request, so it requires auth; analysts may use it
+		if r.URL.Query().Get("semantic_query") != "" {
+			readOnlyMiddleware(http.HandlerFunc(handler.GetEventsHandler)).ServeHTTP(w, r)
+			return
+		}
+		publicLimiter.Middleware(http.HandlerFunc(handler.GetEventsHandler)).ServeHTTP(w, r)
+	})
+	mux.Handle("/api/events/stream", publicLimiter.Middleware(http.HandlerFunc(handler.StreamEventsHandler)))
 	mux.HandleFunc("/api/events/", func(w http.ResponseWriter, r *http.Request) {
 		// Handle POST /api/events/:id/post-to-twitter (requires auth)
 		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/post-to-twitter") {
@@ -89,14 +131,33 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 			authMiddleware(http.HandlerFunc(handler.UpdateEventStatusHandler)).ServeHTTP(w, r)
 			return
 		}
+		// Handle GET /api/events/:id/history (requires auth; analysts may read)
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/history") {
+			readOnlyMiddleware(http.HandlerFunc(handler.GetEventStatusHistoryHandler)).ServeHTTP(w, r)
+			return
+		}
+		// Handle GET /api/events/:id/related (public)
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/related") {
+			publicLimiter.Middleware(http.HandlerFunc(relatedHandler.GetRelatedEventsHandler)).ServeHTTP(w, r)
+			return
+		}
 		// Otherwise handle as get by ID (public)
-		handler.GetEventByIDHandler(w, r)
+		publicLimiter.Middleware(http.HandlerFunc(handler.GetEventByIDHandler)).ServeHTTP(w, r)
+	})
+	mux.Handle("/api/stats", publicLimiter.Middleware(http.HandlerFunc(handler.GetStatsHandler)))
+
+	// Entity timeline (public)
+	mux.HandleFunc("/api/entities/", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/timeline") {
+			http.NotFound(w, r)
+			return
+		}
+		publicLimiter.Middleware(http.HandlerFunc(entityHandler.GetEntityTimelineHandler)).ServeHTTP(w, r)
 	})
-	mux.HandleFunc("/api/stats", handler.GetStatsHandler)
 
 	// Public forecast routes
-	mux.HandleFunc("/api/forecasts", forecastHandler.ListPublicForecasts)
-	mux.HandleFunc("/api/forecasts/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/api/forecasts", publicLimiter.Middleware(http.HandlerFunc(forecastHandler.ListPublicForecasts)))
+	mux.Handle("/api/forecasts/", publicLimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasSuffix(r.URL.Path, "/history/daily") {
 			forecastHandler.GetPublicForecastHistoryDaily(w, r)
 			return
@@ -105,33 +166,37 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 			forecastHandler.GetPublicForecastHistory4Hour(w, r)
 			return
 		}
+		if strings.HasSuffix(r.URL.Path, "/history/bars") {
+			forecastHandler.GetPublicForecastHistoryBars(w, r)
+			return
+		}
 		if strings.HasSuffix(r.URL.Path, "/history") {
 			forecastHandler.GetPublicForecastHistory(w, r)
 			return
 		}
 		http.Error(w, "Not found", http.StatusNotFound)
-	})
+	})))
 
 	// Public strategy routes
-	mux.HandleFunc("/api/strategies", strategyHandler.ListPublicStrategies)
-	mux.HandleFunc("/api/strategies/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/api/strategies", publicLimiter.Middleware(http.HandlerFunc(strategyHandler.ListPublicStrategies)))
+	mux.Handle("/api/strategies/", publicLimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasSuffix(r.URL.Path, "/latest") {
 			strategyHandler.GetLatestStrategyResult(w, r)
 			return
 		}
 		strategyHandler.GetPublicStrategy(w, r)
-	})
+	})))
 
 	// Market analysis routes (public)
-	mux.HandleFunc("/api/market/spy-risk-analysis", optionsHandler.HandleSPYRiskAnalysis)
-	mux.HandleFunc("/api/market/ibit-risk-analysis", optionsHandler.HandleIBITRiskAnalysis)
-	mux.HandleFunc("/api/market/gld-risk-analysis", optionsHandler.HandleGLDRiskAnalysis)
-	mux.HandleFunc("/api/market/tlt-risk-analysis", optionsHandler.HandleTLTRiskAnalysis)
-	mux.HandleFunc("/api/market/vnq-risk-analysis", optionsHandler.HandleVNQRiskAnalysis)
-	mux.HandleFunc("/api/market/uso-risk-analysis", optionsHandler.HandleUSORiskAnalysis)
+	mux.Handle("/api/market/spy-risk-analysis", marketLimiter.Middleware(http.HandlerFunc(optionsHandler.HandleSPYRiskAnalysis)))
+	mux.Handle("/api/market/ibit-risk-analysis", marketLimiter.Middleware(http.HandlerFunc(optionsHandler.HandleIBITRiskAnalysis)))
+	mux.Handle("/api/market/gld-risk-analysis", marketLimiter.Middleware(http.HandlerFunc(optionsHandler.HandleGLDRiskAnalysis)))
+	mux.Handle("/api/market/tlt-risk-analysis", marketLimiter.Middleware(http.HandlerFunc(optionsHandler.HandleTLTRiskAnalysis)))
+	mux.Handle("/api/market/vnq-risk-analysis", marketLimiter.Middleware(http.HandlerFunc(optionsHandler.HandleVNQRiskAnalysis)))
+	mux.Handle("/api/market/uso-risk-analysis", marketLimiter.Middleware(http.HandlerFunc(optionsHandler.HandleUSORiskAnalysis)))
 
 	// FRED economic data routes (public)
-	mux.HandleFunc("/api/market/fred/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/api/market/fred/", marketLimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if this is a multi-series request (has ?series query param)
 		if r.URL.Query().Get("series") != "" {
 			fredHandler.HandleFREDMultiSeries(w, r)
@@ -139,7 +204,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		}
 		// Otherwise handle as single series
 		fredHandler.HandleFREDSeries(w, r)
-	})
+	})))
 
 	// Source management routes
 	mux.HandleFunc("/api/sources", handler.HandleSources)
@@ -151,7 +216,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -179,13 +244,23 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 
 		// Require authentication for all subroutes
 		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Handle /api/tracked-accounts/bulk-toggle
+			if r.URL.Path == "/api/tracked-accounts/bulk-toggle" {
+				if r.Method != http.MethodPost {
+					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					return
+				}
+				trackedAccountsHandler.BulkToggleTrackedAccounts(w, r)
+				return
+			}
+
 			// Handle /api/tracked-accounts/:id/toggle
 			if r.Method == http.MethodPost && len(r.URL.Path) > 7 && r.URL.Path[len(r.URL.Path)-7:] == "/toggle" {
 				trackedAccountsHandler.ToggleTrackedAccount(w, r)
@@ -218,7 +293,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -243,7 +318,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -276,7 +351,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -299,7 +374,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -316,11 +391,19 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/ingestion-errors/stats" {
+				if r.Method != http.MethodGet {
+					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					return
+				}
+				errorHandler.ErrorStats(w, r)
+				return
+			}
 			if r.Method == http.MethodPost && len(r.URL.Path) > 8 && r.URL.Path[len(r.URL.Path)-8:] == "/resolve" {
 				errorHandler.ResolveError(w, r)
 				return
@@ -338,7 +421,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -356,7 +439,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -376,7 +459,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -389,6 +472,17 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
+	mux.HandleFunc("/api/openai-config/reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(openaiConfigHandler.ReloadCredentials)).ServeHTTP(w, r)
+	})
+
 	// Scraping functionality removed - now using RSS content only
 
 	// Twitter configuration routes (admin only)
@@ -396,7 +490,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -417,7 +511,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -429,7 +523,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -441,19 +535,110 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 		authMiddleware(http.HandlerFunc(adminHandler.RequeueFailedEnrichments)).ServeHTTP(w, r)
 	})
 
+	// Bulk reprocessing: POST starts a job re-enriching sources in batches, GET lists jobs (admin only)
+	mux.HandleFunc("/api/admin/reprocess-all", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(reprocessHandler.HandleReprocessAll)).ServeHTTP(w, r)
+	})
+
+	// Bulk reprocessing job progress (GET /:id) and cancellation (POST /:id/cancel) (admin only)
+	mux.HandleFunc("/api/admin/reprocess-all/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(reprocessHandler.HandleReprocessJob)).ServeHTTP(w, r)
+	})
+
+	// Data retention: GET previews what would be deleted, POST runs it now (admin only)
+	mux.HandleFunc("/api/admin/retention", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(retentionHandler.HandleRetention)).ServeHTTP(w, r)
+	})
+
+	// Likely duplicate events report (admin; analysts read-only)
+	mux.HandleFunc("/api/admin/events/duplicates", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		readOnlyMiddleware(http.HandlerFunc(duplicateHandler.GetDuplicatesHandler)).ServeHTTP(w, r)
+	})
+
+	// Magnitude and confidence histograms for threshold tuning (admin; analysts read-only)
+	mux.HandleFunc("/api/admin/events/distribution", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		readOnlyMiddleware(http.HandlerFunc(distributionHandler.GetDistributionHandler)).ServeHTTP(w, r)
+	})
+
+	// Enrichment prompt A/B test results (admin; analysts read-only)
+	mux.HandleFunc("/api/admin/events/prompt-variants", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		readOnlyMiddleware(http.HandlerFunc(promptVariantHandler.GetPromptVariantsHandler)).ServeHTTP(w, r)
+	})
+
+	// Reprocess a single source (POST /:id/reprocess) or view the raw content it was enriched from (GET /:id/raw) (admin only)
+	mux.HandleFunc("/api/admin/sources/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/reprocess"):
+			authMiddleware(http.HandlerFunc(adminHandler.ReprocessSource)).ServeHTTP(w, r)
+		case strings.HasSuffix(r.URL.Path, "/raw"):
+			authMiddleware(http.HandlerFunc(adminHandler.GetSourceRaw)).ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
 	// Delete failed enrichments route (admin only)
 	mux.HandleFunc("/api/admin/delete-failed-enrichments", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -465,19 +650,31 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 		authMiddleware(http.HandlerFunc(adminHandler.DeletePendingSources)).ServeHTTP(w, r)
 	})
 
+	// Source cleanup by enrichment status and age: GET counts, DELETE with confirm=true deletes (admin only)
+	mux.HandleFunc("/api/admin/sources/cleanup", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(adminHandler.CleanupSources)).ServeHTTP(w, r)
+	})
+
 	// List Cloudflare debug HTML files (admin only)
 	mux.HandleFunc("/api/admin/cloudflare-debug-files", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -489,7 +686,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -501,23 +698,23 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 		authMiddleware(http.HandlerFunc(adminHandler.GetRecentEnrichments)).ServeHTTP(w, r)
 	})
 
-	// Forecast routes (admin only)
+	// Forecast routes (admin; analysts read-only)
 	mux.HandleFunc("/api/admin/forecasts", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.Method {
 			case http.MethodGet:
 				forecastHandler.ListForecasts(w, r)
@@ -529,18 +726,36 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
+	// Forecast data consistency: GET reports orphaned or incomplete rows, POST repairs them (admin only)
+	mux.HandleFunc("/api/admin/forecasts/consistency", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(forecastHandler.HandleForecastConsistency)).ServeHTTP(w, r)
+	})
+
 	mux.HandleFunc("/api/admin/forecasts/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, Idempotency-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Handle /api/admin/forecasts/runs/:runId
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Handle /api/admin/forecasts/runs/:runId, /runs/:runId/stream, /runs/:runId/cancel and /runs/:runId/samples
 			if strings.HasPrefix(r.URL.Path, "/api/admin/forecasts/runs/") {
-				if r.Method == http.MethodDelete {
+				if strings.HasSuffix(r.URL.Path, "/stream") {
+					forecastHandler.StreamForecastRun(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/samples") {
+					forecastHandler.GetForecastSamples(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/cancel") {
+					forecastHandler.CancelForecastRun(w, r)
+				} else if r.Method == http.MethodDelete {
 					forecastHandler.DeleteForecastRun(w, r)
 				} else {
 					forecastHandler.GetForecastRun(w, r)
@@ -560,12 +775,36 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 				return
 			}
 
+			// Handle /api/admin/forecasts/:id/compare
+			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/compare") {
+				forecastHandler.CompareForecastRuns(w, r)
+				return
+			}
+
+			// Handle /api/admin/forecasts/:id/market-comparison
+			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/market-comparison") {
+				forecastHandler.GetMarketComparison(w, r)
+				return
+			}
+
+			// Handle /api/admin/forecasts/:id/history/export
+			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/history/export") {
+				forecastHandler.ExportForecastHistory(w, r)
+				return
+			}
+
 			// Handle /api/admin/forecasts/:id/history/daily
 			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/history/daily") {
 				forecastHandler.GetForecastHistoryDaily(w, r)
 				return
 			}
 
+			// Handle /api/admin/forecasts/:id/history/bars
+			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/history/bars") {
+				forecastHandler.GetForecastHistoryBars(w, r)
+				return
+			}
+
 			// Handle /api/admin/forecasts/:id/history/4h
 			if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/history/4h") {
 				forecastHandler.GetForecastHistory4Hour(w, r)
@@ -578,6 +817,18 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 				return
 			}
 
+			// Handle /api/admin/forecasts/:id/restore (POST)
+			if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/restore") {
+				forecastHandler.RestoreForecast(w, r)
+				return
+			}
+
+			// Handle /api/admin/forecasts/:id/permanent (DELETE)
+			if r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/permanent") {
+				forecastHandler.PermanentlyDeleteForecast(w, r)
+				return
+			}
+
 			// Handle /api/admin/forecasts/:id/public (PUT)
 			if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/public") {
 				forecastHandler.ToggleForecastPublic(w, r)
@@ -614,7 +865,7 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 				return
 			}
 
-			// Handle /api/admin/forecasts/:id (DELETE)
+			// Handle /api/admin/forecasts/:id (DELETE - archive)
 			if r.Method == http.MethodDelete {
 				forecastHandler.DeleteForecast(w, r)
 				return
@@ -624,16 +875,16 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
-	// Strategy routes (admin only)
+	// Strategy routes (admin; analysts read-only)
 	mux.HandleFunc("/api/admin/strategies", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.Method {
 			case http.MethodGet:
 				strategyHandler.ListStrategies(w, r)
@@ -649,11 +900,11 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Handle /api/admin/strategies/runs/:runId
 			if strings.HasPrefix(r.URL.Path, "/api/admin/strategies/runs/") {
 				strategyHandler.GetStrategyRun(w, r)
@@ -666,6 +917,12 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 				return
 			}
 
+			// Handle /api/admin/strategies/:id/simulate
+			if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/simulate") {
+				strategyHandler.SimulateStrategy(w, r)
+				return
+			}
+
 			// Handle /api/admin/strategies/:id/schedule
 			if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/schedule") {
 				strategyHandler.UpdateStrategySchedule(w, r)
@@ -712,16 +969,16 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
-	// Summary routes (admin only)
+	// Summary routes (admin; analysts read-only)
 	mux.HandleFunc("/api/admin/summaries", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.Method {
 			case http.MethodGet:
 				summaryHandler.List(w, r)
@@ -737,11 +994,11 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Handle /api/admin/summaries/:id/execute
 			if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/execute") {
 				summaryHandler.Execute(w, r)
@@ -788,16 +1045,16 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
-	// Summary run detail route (admin only)
+	// Summary run detail route (admin; analysts read-only)
 	mux.HandleFunc("/api/admin/summaries/runs/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Handle POST /api/admin/summaries/runs/:runId/tweet
 			if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/tweet") {
 				summaryHandler.PostToTwitter(w, r)
@@ -808,12 +1065,44 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
+	// API key routes (admin only)
+	mux.HandleFunc("/api/admin/api-keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				apiKeyHandler.ListAPIKeys(w, r)
+			case http.MethodPost:
+				apiKeyHandler.CreateAPIKey(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		})).ServeHTTP(w, r)
+	})
+
+	mux.HandleFunc("/api/admin/api-keys/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(apiKeyHandler.RevokeAPIKey)).ServeHTTP(w, r)
+	})
+
 	// Inference log routes (admin only)
 	mux.HandleFunc("/api/admin/inference-logs", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -824,19 +1113,41 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 		authMiddleware(http.HandlerFunc(inferenceLogHandler.GetInferenceStats)).ServeHTTP(w, r)
 	})
 
+	mux.HandleFunc("/api/admin/inference-logs/breakdown", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(inferenceLogHandler.GetInferenceBreakdown)).ServeHTTP(w, r)
+	})
+
+	mux.HandleFunc("/api/admin/inference-logs/budget", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(inferenceLogHandler.GetInferenceBudget)).ServeHTTP(w, r)
+	})
+
 	// Pipeline metrics routes (admin only)
 	mux.HandleFunc("/api/pipeline/metrics", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -849,15 +1160,34 @@ func SetupRoutes(mux *http.ServeMux, db *sql.DB, manager *eventmanager.EventLife
 		})).ServeHTTP(w, r)
 	})
 
-	// RSS feed route
-	mux.HandleFunc("/api/feed.rss", rssHandler.GetRSSFeedHandler)
+	mux.HandleFunc("/api/pipeline/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				pipelineHandler.GetPipelineStatusHandler(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		})).ServeHTTP(w, r)
+	})
+
+	// Feed routes
+	mux.Handle("/api/feed.rss", publicLimiter.Middleware(http.HandlerFunc(rssHandler.GetRSSFeedHandler)))
+	mux.Handle("/api/feed.atom", publicLimiter.Middleware(http.HandlerFunc(rssHandler.GetAtomFeedHandler)))
+	mux.Handle("/api/feed.json", publicLimiter.Middleware(http.HandlerFunc(rssHandler.GetJSONFeedHandler)))
 
 	// CORS preflight
 	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 			w.WriteHeader(http.StatusOK)
 			return
 		}
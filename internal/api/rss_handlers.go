@@ -2,8 +2,11 @@ package api
 
 import (
 	"encoding/xml"
+	"fmt"
 	"html"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
@@ -50,45 +53,38 @@ type Item struct {
 	Link        string `xml:"link"`
 	Description string `xml:"description"`
 	PubDate     string `xml:"pubDate"`
-	GUID        string `xml:"guid"`
+	GUID        GUID   `xml:"guid"`
 	Category    string `xml:"category,omitempty"`
 }
 
-// GetRSSFeedHandler returns an RSS feed of the 20 most recent published events.
-// GET /api/feed.rss
-func (h *RSSHandler) GetRSSFeedHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+// GUID identifies a feed item. Event IDs are not URLs, so they are marked as not permalinks.
+type GUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
 
-	// Query for the 20 most recent published events
-	published := models.EventStatusPublished
-	query := models.EventQuery{
-		Status: &published,
-		Limit:  20,
-		Page:   1,
-	}
+// Feed size bounds
+const (
+	defaultFeedItems = 20
+	maxFeedItems     = 100
+)
 
-	events, err := h.eventManager.GetEvents(query)
-	if err != nil {
-		h.logger.Error("failed to get events for RSS feed", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+// GetRSSFeedHandler returns an RSS feed of the most recent published events, 20 by default.
+// Accepts the /api/events filters (categories, min_magnitude, tags, window, ...) so readers
+// can subscribe to a slice of the stream, e.g. ?categories=cyber&min_magnitude=6.
+// GET /api/feed.rss
+func (h *RSSHandler) GetRSSFeedHandler(w http.ResponseWriter, r *http.Request) {
+	query, events, ok := h.feedEvents(w, r)
+	if !ok {
 		return
 	}
-
-	// Determine base URL from request
-	scheme := "http"
-	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
-		scheme = "https"
-	}
-	baseURL := scheme + "://" + r.Host
+	baseURL := feedBaseURL(r)
 
 	// Build RSS feed
 	feed := &RSS{
 		Version: "2.0",
 		Channel: &Channel{
-			Title:       "OSINTMCP Intelligence Feed",
+			Title:       "OSINTMCP Intelligence Feed" + feedTitleSuffix(query),
 			Link:        baseURL,
 			Description: "Real-time OSINT intelligence events from OSINTMCP",
 			Language:    "en-us",
@@ -97,7 +93,7 @@ func (h *RSSHandler) GetRSSFeedHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Set last build date to now
-	feed.Channel.LastBuildDate = time.Now().Format(time.RFC1123Z)
+	feed.Channel.LastBuildDate = time.Now().UTC().Format(time.RFC1123Z)
 
 	// Convert events to RSS items
 	for _, event := range events {
@@ -105,8 +101,8 @@ func (h *RSSHandler) GetRSSFeedHandler(w http.ResponseWriter, r *http.Request) {
 			Title:       event.Title,
 			Link:        baseURL + "/api/events/" + event.ID,
 			Description: html.EscapeString(event.Summary),
-			PubDate:     event.Timestamp.Format(time.RFC1123Z),
-			GUID:        event.ID,
+			PubDate:     eventPublished(event).Format(time.RFC1123Z),
+			GUID:        GUID{Value: event.ID},
 			Category:    string(event.Category),
 		}
 		feed.Channel.Items = append(feed.Channel.Items, item)
@@ -125,3 +121,110 @@ func (h *RSSHandler) GetRSSFeedHandler(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("failed to encode RSS feed", "error", err)
 	}
 }
+
+// feedQuery builds the event query shared by all feed formats from the request's /api/events
+// filters. Feeds always list published events newest first; page selects older pages.
+// semantic_query is rejected: feeds are public and it would spend an embedding call per request.
+func feedQuery(r *http.Request) (models.EventQuery, error) {
+	query := parseEventQuery(r)
+	if query.SemanticQuery != "" {
+		return query, fmt.Errorf("semantic_query is not supported by feeds")
+	}
+	published := models.EventStatusPublished
+	query.Status = &published
+	query.SortBy = models.SortByTimestamp
+	query.SortOrder = models.SortOrderDesc
+	query.Page = 1
+	query.Offset = 0
+	query.AfterID = ""
+	query.BeforeID = ""
+	if page := r.URL.Query().Get("page"); page != "" {
+		val, err := strconv.Atoi(page)
+		if err != nil || val < 1 {
+			return query, fmt.Errorf("invalid page %q: must be a positive integer", page)
+		}
+		query.Page = val
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultFeedItems
+	}
+	if query.Limit > maxFeedItems {
+		query.Limit = maxFeedItems
+	}
+	if err := query.Validate(); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// feedEvents runs the feed query for a request, writing an error response and returning false
+// if the request is invalid or the query fails
+func (h *RSSHandler) feedEvents(w http.ResponseWriter, r *http.Request) (models.EventQuery, []models.Event, bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return models.EventQuery{}, nil, false
+	}
+
+	query, err := feedQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return query, nil, false
+	}
+
+	events, err := h.eventManager.GetEvents(query)
+	if err != nil {
+		h.logger.Error("failed to get events for feed", "path", r.URL.Path, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return query, nil, false
+	}
+	return query, events, true
+}
+
+// feedBaseURL determines the site's base URL from the request
+func feedBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// feedPageURL links to the given page of the requested feed, keeping its filters
+func feedPageURL(r *http.Request, page int) string {
+	params := r.URL.Query()
+	params.Del("page")
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	link := feedBaseURL(r) + r.URL.Path
+	if encoded := params.Encode(); encoded != "" {
+		link += "?" + encoded
+	}
+	return link
+}
+
+// feedNextURL links to the next page of the feed, or returns "" when this page is the last.
+// A full page may be followed by an empty one.
+func feedNextURL(r *http.Request, query models.EventQuery, events []models.Event) string {
+	if len(events) < query.Limit {
+		return ""
+	}
+	return feedPageURL(r, query.Page+1)
+}
+
+// feedTitleSuffix describes a filtered feed in its title, e.g. " (cyber, magnitude 6+)", so
+// subscriptions to different slices are told apart in readers
+func feedTitleSuffix(query models.EventQuery) string {
+	var parts []string
+	for _, category := range query.Categories {
+		parts = append(parts, string(category))
+	}
+	parts = append(parts, query.Tags...)
+	if query.MinMagnitude != nil {
+		parts = append(parts, "magnitude "+strconv.FormatFloat(*query.MinMagnitude, 'f', -1, 64)+"+")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+type stubEventQuerier struct {
+	query  models.EventQuery
+	events []models.Event
+}
+
+func (s *stubEventQuerier) GetEvents(query models.EventQuery) ([]models.Event, error) {
+	s.query = query
+	return s.events, nil
+}
+
+func TestGetRSSFeedHandler_Filters(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	timestamp := time.Date(2024, 3, 1, 12, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+	querier := &stubEventQuerier{events: []models.Event{
+		{ID: "evt-1", Title: "Ransomware hits grid operator", Category: models.CategoryCyber, Timestamp: timestamp},
+	}}
+	h := NewRSSHandler(querier, logger)
+
+	rec := httptest.NewRecorder()
+	h.GetRSSFeedHandler(rec, httptest.NewRequest(http.MethodGet, "/api/feed.rss?categories=cyber&min_magnitude=6&tags=ransomware&status=rejected&limit=500", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	query := querier.query
+	if !reflect.DeepEqual(query.Categories, []models.Category{models.CategoryCyber}) || !reflect.DeepEqual(query.Tags, []string{"ransomware"}) {
+		t.Errorf("filters not passed through: %+v", query)
+	}
+	if query.MinMagnitude == nil || *query.MinMagnitude != 6 {
+		t.Errorf("expected min magnitude 6, got %v", query.MinMagnitude)
+	}
+	if query.Status == nil || *query.Status != models.EventStatusPublished {
+		t.Errorf("feeds must only list published events, got status %v", query.Status)
+	}
+	if query.Limit != maxFeedItems {
+		t.Errorf("expected limit capped at %d, got %d", maxFeedItems, query.Limit)
+	}
+
+	var feed RSS
+	if err := xml.Unmarshal(rec.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	if feed.Channel.Title != "OSINTMCP Intelligence Feed (cyber, ransomware, magnitude 6+)" {
+		t.Errorf("unexpected feed title %q", feed.Channel.Title)
+	}
+	item := feed.Channel.Items[0]
+	if item.GUID.Value != "evt-1" || item.GUID.IsPermaLink {
+		t.Errorf("unexpected guid %+v", item.GUID)
+	}
+	if item.PubDate != "Fri, 01 Mar 2024 10:30:00 +0000" {
+		t.Errorf("unexpected pubDate %q", item.PubDate)
+	}
+
+	rec = httptest.NewRecorder()
+	h.GetRSSFeedHandler(rec, httptest.NewRequest(http.MethodGet, "/api/feed.rss?categories=weather", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown category, got %d", rec.Code)
+	}
+
+	// Feeds are public, so they never run a paid semantic search
+	querier.query = models.EventQuery{}
+	rec = httptest.NewRecorder()
+	h.GetRSSFeedHandler(rec, httptest.NewRequest(http.MethodGet, "/api/feed.rss?semantic_query=grid+attacks", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for semantic_query, got %d", rec.Code)
+	}
+	if querier.query.SemanticQuery != "" {
+		t.Errorf("semantic_query reached the event query: %+v", querier.query)
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// maskSecret hides all but the last four characters of a credential
+func maskSecret(value string) string {
+	if value != "" && len(value) > 4 {
+		return "***" + value[len(value)-4:]
+	} else if value != "" {
+		return "***"
+	}
+	return ""
+}
+
+// hideSecret masks a secret that is not an API key, such as a password or signing secret,
+// without revealing any of it
+func hideSecret(value string) string {
+	if value != "" {
+		return "***"
+	}
+	return ""
+}
+
+// isMaskedSecret reports whether value is a credential masked by maskSecret or hideSecret
+func isMaskedSecret(value string) bool {
+	return strings.HasPrefix(value, "***")
+}
+
+// unmaskSecret returns stored when value is its masked form posted back unchanged, and value
+// otherwise
+func unmaskSecret(value, stored string) string {
+	if value != "" && value == maskSecret(stored) {
+		return stored
+	}
+	return value
+}
+
+// unhideSecret is unmaskSecret for secrets masked by hideSecret
+func unhideSecret(value, stored string) string {
+	if value != "" && value == hideSecret(stored) {
+		return stored
+	}
+	return value
+}
+
+// maskSetting masks a secret connector setting, hiding secrets and passwords entirely
+func maskSetting(key, value string) string {
+	if hiddenSetting(key) {
+		return hideSecret(value)
+	}
+	return maskSecret(value)
+}
+
+// unmaskSetting is unmaskSecret for a connector setting masked by maskSetting
+func unmaskSetting(key, value, stored string) string {
+	if hiddenSetting(key) {
+		return unhideSecret(value, stored)
+	}
+	return unmaskSecret(value, stored)
+}
+
+// hiddenSetting reports whether a connector setting holds a secret or password rather than a key
+// or token
+func hiddenSetting(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "secret") || strings.Contains(key, "password")
+}
+
+// modelKey points at the API key of one model of a forecast, strategy or summary
+type modelKey struct {
+	id       string
+	provider string
+	name     string
+	key      *string
+}
+
+func forecastModelKeys(forecastModels []models.ForecastModel) []modelKey {
+	keys := make([]modelKey, len(forecastModels))
+	for i := range forecastModels {
+		m := &forecastModels[i]
+		keys[i] = modelKey{id: m.ID, provider: m.Provider, name: m.ModelName, key: &m.APIKey}
+	}
+	return keys
+}
+
+func strategyModelKeys(strategyModels []models.StrategyModel) []modelKey {
+	keys := make([]modelKey, len(strategyModels))
+	for i := range strategyModels {
+		m := &strategyModels[i]
+		keys[i] = modelKey{id: m.ID, provider: m.Provider, name: m.ModelName, key: &m.APIKey}
+	}
+	return keys
+}
+
+func summaryModelKeys(summaryModels []models.SummaryModel) []modelKey {
+	keys := make([]modelKey, len(summaryModels))
+	for i := range summaryModels {
+		m := &summaryModels[i]
+		keys[i] = modelKey{provider: m.Provider, name: m.ModelName, key: &m.APIKey}
+	}
+	return keys
+}
+
+// maskModelKeys masks the API keys of models about to be returned by the API
+func maskModelKeys(keys []modelKey) {
+	for _, k := range keys {
+		*k.key = maskSecret(*k.key)
+	}
+}
+
+// restoreMaskedAPIKeys replaces the masked API keys a client posted back unchanged with the
+// stored keys they stand for in existing. A masked key that stands for no stored key is rejected.
+func restoreMaskedAPIKeys(requested, existing []modelKey) error {
+	for _, model := range requested {
+		if !isMaskedSecret(*model.key) {
+			continue
+		}
+		key, ok := storedAPIKey(model, existing)
+		if !ok {
+			return ValidationError{Field: "models", Message: fmt.Sprintf("Model %s has a masked API key; re-enter the API key", model.name)}
+		}
+		*model.key = key
+	}
+	return nil
+}
+
+// storedAPIKey finds the stored key behind model's masked key, preferring the model with the same
+// ID over one with the same provider
+func storedAPIKey(model modelKey, existing []modelKey) (string, bool) {
+	key, found := "", false
+	for _, stored := range existing {
+		if maskSecret(*stored.key) != *model.key {
+			continue
+		}
+		if model.id != "" && stored.id == model.id {
+			return *stored.key, true
+		}
+		if stored.provider == model.provider && !found {
+			key, found = *stored.key, true
+		}
+	}
+	return key, found
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestUnmaskSecret(t *testing.T) {
+	tests := []struct {
+		value, stored, want string
+	}{
+		{"***oken", "bearer-token", "bearer-token"}, // masked value posted back
+		{"new-token", "bearer-token", "new-token"},  // replaced
+		{"", "bearer-token", ""},                    // cleared
+		{"***", "abc", "abc"},                       // short secrets mask to ***
+		{"***1234", "bearer-token", "***1234"},      // not this secret's mask
+	}
+	for _, tt := range tests {
+		if got := unmaskSecret(tt.value, tt.stored); got != tt.want {
+			t.Errorf("unmaskSecret(%q, %q) = %q, want %q", tt.value, tt.stored, got, tt.want)
+		}
+	}
+}
+
+func TestMaskTwitterConfig(t *testing.T) {
+	config := &models.TwitterConfig{
+		APIKey:            "consumer-key-1111",
+		APISecret:         "consumer-secret-2222",
+		AccessToken:       "access-token-3333",
+		AccessTokenSecret: "access-secret-4444",
+		BearerToken:       "bearer-token-5555",
+	}
+	maskTwitterConfig(config)
+	for _, got := range []string{config.APIKey, config.AccessToken, config.BearerToken} {
+		if !isMaskedSecret(got) || len(got) != len("***1111") {
+			t.Errorf("expected a masked credential, got %q", got)
+		}
+	}
+	for _, got := range []string{config.APISecret, config.AccessTokenSecret} {
+		if got != "***" {
+			t.Errorf("expected a hidden secret, got %q", got)
+		}
+	}
+}
+
+func TestMaskSetting(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"api_key", "sk-live-1234", "***1234"},
+		{"bot_token", "token-abcd", "***abcd"},
+		{"client_secret", "s3cr3t-value", "***"},
+		{"webhook_password", "hunter2", "***"},
+		{"client_secret", "", ""},
+	}
+	for _, tt := range tests {
+		got := maskSetting(tt.key, tt.value)
+		if got != tt.want {
+			t.Errorf("maskSetting(%q, %q) = %q, want %q", tt.key, tt.value, got, tt.want)
+		}
+		if tt.value != "" && unmaskSetting(tt.key, got, tt.value) != tt.value {
+			t.Errorf("unmaskSetting(%q, %q) did not restore the stored value", tt.key, got)
+		}
+	}
+}
+
+func TestRestoreMaskedSummaryKeys(t *testing.T) {
+	existing := []models.SummaryModel{
+		{Provider: "openai", ModelName: "gpt-4o", APIKey: "sk-openai-1234"},
+		{Provider: "anthropic", ModelName: "claude", APIKey: "sk-ant-1234"},
+	}
+	requested := []models.SummaryModel{
+		{Provider: "anthropic", ModelName: "claude", APIKey: "***1234"},
+		{Provider: "openai", ModelName: "gpt-4o", APIKey: "***1234"},
+	}
+	if err := restoreMaskedAPIKeys(summaryModelKeys(requested), summaryModelKeys(existing)); err != nil {
+		t.Fatalf("restoreMaskedAPIKeys: %v", err)
+	}
+	if requested[0].APIKey != "sk-ant-1234" || requested[1].APIKey != "sk-openai-1234" {
+		t.Errorf("expected keys restored by provider, got %q and %q", requested[0].APIKey, requested[1].APIKey)
+	}
+
+	maskModelKeys(summaryModelKeys(existing))
+	if existing[0].APIKey != "***1234" {
+		t.Errorf("expected masked key, got %q", existing[0].APIKey)
+	}
+}
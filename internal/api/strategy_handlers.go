@@ -70,6 +70,22 @@ func (h *StrategyHandler) CreateStrategy(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := context.Background()
+
+	// A duplicate posts back the masked keys of the strategy it copies
+	var sourceModels []models.StrategyModel
+	if req.DuplicateOf != "" {
+		var err error
+		if sourceModels, err = h.repo.GetStrategyModels(ctx, req.DuplicateOf); err != nil {
+			h.logger.Error("failed to get source strategy models", "id", req.DuplicateOf, "error", err)
+			http.Error(w, "Failed to get source strategy models", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := restoreMaskedAPIKeys(strategyModelKeys(req.Models), strategyModelKeys(sourceModels)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	strategy, err := h.repo.CreateStrategy(ctx, req)
 	if err != nil {
 		h.logger.Error("failed to create strategy", "error", err)
@@ -132,6 +148,7 @@ func (h *StrategyHandler) GetStrategy(w http.ResponseWriter, r *http.Request) {
 		// Don't fail the request, just log the error and return strategy without models
 		strategyModels = []models.StrategyModel{}
 	}
+	maskModelKeys(strategyModelKeys(strategyModels))
 	strategy.Models = strategyModels
 
 	w.Header().Set("Content-Type", "application/json")
@@ -174,6 +191,19 @@ func (h *StrategyHandler) UpdateStrategy(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := context.Background()
+
+	// Models posted back from GetStrategy carry masked keys; keep the stored ones
+	existingModels, err := h.repo.GetStrategyModels(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get strategy models", "id", id, "error", err)
+		http.Error(w, "Failed to get strategy models", http.StatusInternalServerError)
+		return
+	}
+	if err := restoreMaskedAPIKeys(strategyModelKeys(req.Models), strategyModelKeys(existingModels)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	strategy, err := h.repo.UpdateStrategy(ctx, id, req)
 	if err != nil {
 		h.logger.Error("failed to update strategy", "id", id, "error", err)
@@ -260,6 +290,71 @@ func (h *StrategyHandler) ExecuteStrategy(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// SimulateStrategy handles POST /api/admin/strategies/{id}/simulate
+func (h *StrategyHandler) SimulateStrategy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract ID from URL
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/strategies/")
+	id := strings.TrimSuffix(path, "/simulate")
+	if id == "" {
+		http.Error(w, "Strategy ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SimulateStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode simulate strategy request", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Validate hypothetical events
+	if len(req.Events) == 0 {
+		http.Error(w, "At least one hypothetical event is required", http.StatusBadRequest)
+		return
+	}
+	for i, event := range req.Events {
+		if strings.TrimSpace(event.Title) == "" {
+			http.Error(w, fmt.Sprintf("Event %d: title is required", i+1), http.StatusBadRequest)
+			return
+		}
+		if event.Category == "" {
+			req.Events[i].Category = string(models.CategoryOther)
+		} else if !models.ValidCategory(models.Category(event.Category)) {
+			http.Error(w, fmt.Sprintf("Event %d: unknown category %q", i+1, event.Category), http.StatusBadRequest)
+			return
+		}
+		if event.Magnitude < 0 || event.Magnitude > 10 {
+			http.Error(w, fmt.Sprintf("Event %d: magnitude must be between 0 and 10", i+1), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := r.Context()
+
+	// Verify strategy exists
+	if _, err := h.repo.GetStrategy(ctx, id); err != nil {
+		h.logger.Error("failed to get strategy for simulation", "id", id, "error", err)
+		http.Error(w, "Strategy not found", http.StatusNotFound)
+		return
+	}
+
+	// Runs synchronously; the result is returned but not stored
+	simulation, err := h.strategist.SimulateStrategy(ctx, id, req.Events)
+	if err != nil {
+		h.logger.Error("failed to simulate strategy", "id", id, "error", err)
+		http.Error(w, "Failed to simulate strategy: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(simulation)
+}
+
 // GetStrategyRuns handles GET /api/admin/strategies/{id}/runs
 func (h *StrategyHandler) GetStrategyRuns(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
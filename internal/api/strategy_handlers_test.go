@@ -0,0 +1,26 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSimulateStrategy_RejectsUnknownCategory(t *testing.T) {
+	// Validation runs before the strategy is loaded, so no repository is needed
+	h := NewStrategyHandler(nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	body := `{"events": [{"title": "Coup attempt", "category": "politics", "magnitude": 6}]}`
+	rec := httptest.NewRecorder()
+	h.SimulateStrategy(rec, httptest.NewRequest(http.MethodPost, "/api/admin/strategies/s-1/simulate", strings.NewReader(body)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `unknown category "politics"`) {
+		t.Errorf("unexpected error message: %q", rec.Body.String())
+	}
+}
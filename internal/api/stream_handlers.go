@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/eventmanager"
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/server"
+)
+
+// maxStreamClients caps concurrent event stream connections
+const maxStreamClients = 1000
+
+// streamPingInterval is how often an idle event stream pings the client to keep proxies from
+// closing it
+const streamPingInterval = 30 * time.Second
+
+// streamPongTimeout is how long an event stream waits for a pong before dropping the client
+const streamPongTimeout = 2 * streamPingInterval
+
+// StreamMessage is a message sent over the event stream
+type StreamMessage struct {
+	Type  string       `json:"type"`
+	Event models.Event `json:"event"`
+}
+
+// StreamEventsHandler handles GET /api/events/stream, a WebSocket that pushes events as they are
+// published. Filters are fixed at connection time:
+//
+//	categories=military,cyber  only these categories
+//	min_magnitude=6            only events of at least this magnitude
+//
+// Clients that fall behind are disconnected with close code 1013 and may reconnect, using
+// /api/events?after_id= to catch up.
+func (h *Handler) StreamEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseStreamFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.streamClients.Add(1) > maxStreamClients {
+		h.streamClients.Add(-1)
+		http.Error(w, "Too many stream clients", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.streamClients.Add(-1)
+
+	conn, err := server.UpgradeWebSocket(w, r)
+	if err != nil {
+		h.logger.Debug("event stream upgrade failed", "error", err)
+		return
+	}
+	defer conn.Close()
+	if err := conn.SetPongTimeout(streamPongTimeout); err != nil {
+		h.logger.Debug("failed to set event stream pong timeout", "error", err)
+		return
+	}
+
+	events, unsubscribe := h.manager.EventHub().Subscribe(filter)
+	defer unsubscribe()
+
+	// The client only sends control frames; reading them answers pings and notices disconnects,
+	// including a client that stops answering pings
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+		case <-ping.C:
+			if err := conn.WritePing(); err != nil {
+				return
+			}
+		case event, open := <-events:
+			if !open {
+				h.logger.Info("dropped slow event stream client", "remote_addr", r.RemoteAddr)
+				conn.WriteClose(server.CloseTryAgainLater, "client too slow")
+				return
+			}
+			data, err := json.Marshal(StreamMessage{Type: "event", Event: event})
+			if err != nil {
+				h.logger.Error("failed to encode streamed event", "event_id", event.ID, "error", err)
+				continue
+			}
+			if err := conn.WriteText(data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseStreamFilter reads the stream's filters from the query string, scoped to the request's
+// workspace
+func parseStreamFilter(r *http.Request) (eventmanager.StreamFilter, error) {
+	q := r.URL.Query()
+	filter := eventmanager.StreamFilter{WorkspaceID: requestWorkspace(r)}
+
+	if categories := q.Get("categories"); categories != "" {
+		for _, c := range strings.Split(categories, ",") {
+			category := models.Category(strings.TrimSpace(c))
+			if !models.ValidCategory(category) {
+				return filter, fmt.Errorf("invalid category %q", category)
+			}
+			filter.Categories = append(filter.Categories, category)
+		}
+	}
+
+	if minMag := q.Get("min_magnitude"); minMag != "" {
+		val, err := strconv.ParseFloat(minMag, 64)
+		if err != nil || val < 0 || val > 10 {
+			return filter, fmt.Errorf("min_magnitude must be a number between 0 and 10")
+		}
+		filter.MinMagnitude = val
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/eventmanager"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestParseStreamFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    eventmanager.StreamFilter
+		wantErr bool
+	}{
+		{
+			name:  "no filters",
+			query: "",
+			want:  eventmanager.StreamFilter{WorkspaceID: models.DefaultWorkspace},
+		},
+		{
+			name:  "categories and magnitude",
+			query: "categories=military,%20cyber&min_magnitude=6.5",
+			want: eventmanager.StreamFilter{
+				WorkspaceID:  models.DefaultWorkspace,
+				Categories:   []models.Category{models.CategoryMilitary, models.CategoryCyber},
+				MinMagnitude: 6.5,
+			},
+		},
+		{name: "unknown category", query: "categories=weather", wantErr: true},
+		{name: "magnitude not a number", query: "min_magnitude=high", wantErr: true},
+		{name: "magnitude out of range", query: "min_magnitude=11", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/events/stream?"+tt.query, nil)
+			got, err := parseStreamFilter(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStreamFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStreamFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamEventsHandler_RejectsBadFilterBeforeUpgrade(t *testing.T) {
+	h := &Handler{}
+	req := httptest.NewRequest(http.MethodGet, "/api/events/stream?min_magnitude=-1", nil)
+	rec := httptest.NewRecorder()
+
+	h.StreamEventsHandler(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"fmt"
+	"html"
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +21,11 @@ type TwitterPoster interface {
 	PostTweet(text string) (tweetID string, err error)
 }
 
+// SummaryEmailSender delivers an HTML email to a list of recipients
+type SummaryEmailSender interface {
+	SendHTML(ctx context.Context, to []string, subject, htmlBody string) error
+}
+
 // SummaryExecutor handles executing summaries
 type SummaryExecutor struct {
 	repo          *database.SummaryRepository
@@ -26,6 +33,7 @@ type SummaryExecutor struct {
 	forecastRepo  *database.ForecastRepository
 	TwitterRepo   *database.TwitterRepository // Exported for handler access
 	TwitterClient TwitterPoster               // Exported for handler access
+	emailSender   SummaryEmailSender
 	logger        *slog.Logger
 }
 
@@ -48,8 +56,23 @@ func NewSummaryExecutor(
 	}
 }
 
+// SetEmailSender enables email delivery of scheduled summaries
+func (e *SummaryExecutor) SetEmailSender(sender SummaryEmailSender) {
+	e.emailSender = sender
+}
+
 // Execute starts a summary execution and returns the run ID
 func (e *SummaryExecutor) Execute(ctx context.Context, summaryID string) (string, error) {
+	return e.execute(ctx, summaryID, false)
+}
+
+// ExecuteScheduled starts a scheduled summary execution, which is also emailed to the
+// summary's recipients when email delivery is enabled, and returns the run ID
+func (e *SummaryExecutor) ExecuteScheduled(ctx context.Context, summaryID string) (string, error) {
+	return e.execute(ctx, summaryID, true)
+}
+
+func (e *SummaryExecutor) execute(ctx context.Context, summaryID string, scheduled bool) (string, error) {
 	summary, err := e.repo.Get(ctx, summaryID)
 	if err != nil {
 		return "", fmt.Errorf("summary not found: %w", err)
@@ -66,60 +89,80 @@ func (e *SummaryExecutor) Execute(ctx context.Context, summaryID string) (string
 	}
 
 	// Start execution asynchronously
-	go e.executeSummary(summary, runID, startTime, endTime)
+	go e.executeSummary(summary, runID, startTime, endTime, scheduled)
 
 	return runID, nil
 }
 
-func (e *SummaryExecutor) executeSummary(summary *models.Summary, runID string, startTime, endTime time.Time) {
+func (e *SummaryExecutor) executeSummary(summary *models.Summary, runID string, startTime, endTime time.Time, scheduled bool) {
 	ctx := context.Background()
 
-	// Fetch headlines
-	headlines, err := e.eventRepo.GetEventsBetween(ctx, startTime, endTime, summary.Categories, summary.HeadlineCount)
-	if err != nil {
-		errMsg := err.Error()
-		e.repo.CompleteRun(ctx, runID, "failed", &errMsg)
-		return
+	// Optionally include forecasts
+	forecastsText := ""
+	if summary.IncludeForecasts {
+		forecastsText = e.forecastsText(ctx)
 	}
 
-	if len(headlines) == 0 {
-		errMsg := "no headlines found in time range"
-		e.repo.CompleteRun(ctx, runID, "failed", &errMsg)
-		return
-	}
+	var fullPrompt string
+	var promptEvents []models.Event
+	if summary.Mode == models.SummaryModeDigest {
+		perCategory := summary.DigestPerCategory
+		if perCategory <= 0 {
+			perCategory = models.DefaultDigestPerCategory
+		}
 
-	// Build prompt with headlines
-	headlinesText := ""
-	for _, h := range headlines {
-		headlinesText += fmt.Sprintf("- [%s] %s\n", h.Timestamp.Format("2006-01-02 15:04"), h.Title)
-	}
+		events, err := e.eventRepo.GetTopEventsByCategory(ctx, startTime, endTime, summary.Categories, perCategory)
+		if err != nil {
+			errMsg := err.Error()
+			e.repo.CompleteRun(ctx, runID, "failed", &errMsg)
+			return
+		}
 
-	// Optionally include forecasts
-	forecastsText := ""
-	if summary.IncludeForecasts {
-		forecasts, err := e.forecastRepo.ListForecasts(ctx)
+		sections := digestSections(events, summary.Categories)
+		if len(sections) == 0 {
+			errMsg := "no events found in time range"
+			e.repo.CompleteRun(ctx, runID, "failed", &errMsg)
+			return
+		}
+
+		for _, section := range sections {
+			promptEvents = append(promptEvents, section.Events...)
+		}
+		fullPrompt = buildDigestPrompt(summary.Prompt, summary.LookbackHours, sections, forecastsText, summary.Format)
+	} else {
+		// Fetch headlines
+		headlines, err := e.eventRepo.GetEventsBetween(ctx, startTime, endTime, summary.Categories, summary.HeadlineCount)
 		if err != nil {
-			e.logger.Warn("failed to fetch forecasts for summary", "error", err)
-		} else {
-			forecastsText = "\n\nCurrent Forecasts:\n"
-			for _, f := range forecasts {
-				if !f.Active {
-					continue
-				}
-				// Get latest run to get probability
-				latestRun, err := e.forecastRepo.GetLatestCompletedForecastRun(ctx, f.ID)
-				if err == nil && latestRun != nil && latestRun.Result != nil && latestRun.Result.AggregatedPercentiles != nil {
-					// Use the median (P50) as the probability
-					forecastsText += fmt.Sprintf("- %s: %.1f%%\n", f.Name, latestRun.Result.AggregatedPercentiles.P50*100)
-				} else {
-					// No run yet, just show the forecast name
-					forecastsText += fmt.Sprintf("- %s: (no recent forecast available)\n", f.Name)
-				}
-			}
+			errMsg := err.Error()
+			e.repo.CompleteRun(ctx, runID, "failed", &errMsg)
+			return
+		}
+
+		if len(headlines) == 0 {
+			errMsg := "no headlines found in time range"
+			e.repo.CompleteRun(ctx, runID, "failed", &errMsg)
+			return
+		}
+
+		// Build prompt with headlines
+		headlinesText := ""
+		for _, h := range headlines {
+			headlinesText += summaryEventLine(h, summary.Format)
 		}
+
+		promptEvents = headlines
+		fullPrompt = fmt.Sprintf("%s\n\nHeadlines from the last %d hours:\n%s%s%s", summary.Prompt, summary.LookbackHours, headlinesText, forecastsText, formatInstructions(summary.Format))
 	}
 
-	fullPrompt := fmt.Sprintf("%s\n\nHeadlines from the last %d hours:\n%s%s", summary.Prompt, summary.LookbackHours, headlinesText, forecastsText)
+	// Record the events behind the summary so it can cite them
+	citations := summaryCitations(promptEvents)
+	eventIDs := make([]string, len(citations))
+	for i, c := range citations {
+		eventIDs[i] = c.EventID
+	}
+	if err := e.repo.SetRunEventIDs(ctx, runID, eventIDs); err != nil {
+		e.logger.Error("failed to record summary citations", "run_id", runID, "error", err)
+	}
 
 	// Execute with each model and track first result
 	var firstSummaryText string
@@ -130,7 +173,7 @@ func (e *SummaryExecutor) executeSummary(summary *models.Summary, runID string,
 			continue
 		}
 
-		if err := e.repo.SaveResult(ctx, runID, summaryText, model.Provider, model.ModelName); err != nil {
+		if err := e.repo.SaveResult(ctx, runID, summaryText, summaryFormat(summary), model.Provider, model.ModelName); err != nil {
 			e.logger.Error("failed to save result", "error", err)
 		}
 
@@ -142,9 +185,14 @@ func (e *SummaryExecutor) executeSummary(summary *models.Summary, runID string,
 
 	e.repo.CompleteRun(ctx, runID, "completed", nil)
 
+	if scheduled && summary.EmailEnabled && firstSummaryText != "" {
+		e.emailSummary(ctx, summary, runID, endTime, firstSummaryText, citations)
+	}
+
 	// Auto-post to Twitter if enabled
 	if summary.AutoPostToTwitter && firstSummaryText != "" && e.TwitterClient != nil {
-		tweetID, err := e.TwitterClient.PostTweet(firstSummaryText)
+		tweetText := plainSummaryText(firstSummaryText, summaryFormat(summary))
+		tweetID, err := e.TwitterClient.PostTweet(tweetText)
 		if err != nil {
 			e.logger.Error("failed to auto-post to twitter", "run_id", runID, "error", err)
 		} else {
@@ -152,7 +200,7 @@ func (e *SummaryExecutor) executeSummary(summary *models.Summary, runID string,
 			// Record the tweet in database
 			config, err := e.TwitterRepo.Get(ctx)
 			if err == nil && config != nil {
-				if err := e.TwitterRepo.RecordPostedTweet(ctx, "", tweetID, firstSummaryText); err != nil {
+				if err := e.TwitterRepo.RecordPostedTweet(ctx, "", tweetID, tweetText); err != nil {
 					e.logger.Error("failed to record auto-posted tweet", "error", err)
 				}
 			}
@@ -160,6 +208,220 @@ func (e *SummaryExecutor) executeSummary(summary *models.Summary, runID string,
 	}
 }
 
+// emailSummary sends a run's summary to the summary's recipients and records the outcome on the run
+func (e *SummaryExecutor) emailSummary(ctx context.Context, summary *models.Summary, runID string, endTime time.Time, summaryText string, citations []models.SummaryCitation) {
+	var err error
+	switch {
+	case e.emailSender == nil:
+		err = fmt.Errorf("SMTP is not configured")
+	case len(summary.EmailRecipients) == 0:
+		err = fmt.Errorf("no email recipients")
+	default:
+		subject := fmt.Sprintf("%s - %s", summary.Name, endTime.UTC().Format("2006-01-02"))
+		err = e.emailSender.SendHTML(ctx, summary.EmailRecipients, subject, renderSummaryEmail(summary.Name, endTime, summaryText, citations))
+	}
+
+	if err != nil {
+		e.logger.Error("failed to email summary", "run_id", runID, "error", err)
+		errMsg := err.Error()
+		if err := e.repo.RecordEmailDelivery(ctx, runID, models.SummaryEmailFailed, &errMsg); err != nil {
+			e.logger.Error("failed to record email delivery", "run_id", runID, "error", err)
+		}
+		return
+	}
+
+	e.logger.Info("emailed summary", "run_id", runID, "recipients", len(summary.EmailRecipients))
+	if err := e.repo.RecordEmailDelivery(ctx, runID, models.SummaryEmailSent, nil); err != nil {
+		e.logger.Error("failed to record email delivery", "run_id", runID, "error", err)
+	}
+}
+
+// renderSummaryEmail renders a summary as an HTML email. Plain-text summaries go through the
+// markdown renderer too, so the headings and bullet lists models tend to write still render.
+// The events the summary drew from are listed at the end as sources.
+func renderSummaryEmail(name string, endTime time.Time, summaryText string, citations []models.SummaryCitation) string {
+	var b strings.Builder
+	b.WriteString(`<!DOCTYPE html><html><body style="font-family: Arial, sans-serif; line-height: 1.5; color: #1a1a1a; max-width: 640px;">`)
+	fmt.Fprintf(&b, "<h1 style=\"font-size: 20px;\">%s</h1>", html.EscapeString(name))
+	fmt.Fprintf(&b, "<p style=\"color: #666; font-size: 13px;\">%s</p>\n", endTime.UTC().Format("Monday, January 2, 2006 15:04 MST"))
+	b.WriteString(renderMarkdown(summaryText))
+	b.WriteString(renderCitations(citations))
+	b.WriteString("</body></html>")
+	return b.String()
+}
+
+// eventURLBase is the public page of an event, linked from markdown summaries
+const eventURLBase = "https://stratint.ai/events/"
+
+// summaryCitations lists the events a summary was built from, linked to their public pages
+func summaryCitations(events []models.Event) []models.SummaryCitation {
+	citations := make([]models.SummaryCitation, 0, len(events))
+	for _, event := range events {
+		citations = append(citations, models.SummaryCitation{
+			EventID:   event.ID,
+			Title:     event.Title,
+			Category:  string(event.Category),
+			Magnitude: event.Magnitude,
+			Timestamp: event.Timestamp,
+			URL:       eventURLBase + event.ID,
+		})
+	}
+	return citations
+}
+
+// renderCitations renders a "Sources cited" list linking each event to its page
+func renderCitations(citations []models.SummaryCitation) string {
+	if len(citations) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("<h2>Sources cited</h2>\n<ol>\n")
+	for _, c := range citations {
+		fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a> (%s, magnitude %.1f, %s)</li>\n",
+			html.EscapeString(c.URL), html.EscapeString(c.Title), html.EscapeString(c.Category),
+			c.Magnitude, c.Timestamp.UTC().Format("2006-01-02 15:04"))
+	}
+	b.WriteString("</ol>\n")
+	return b.String()
+}
+
+// summaryFormat is the format a summary's results are generated in
+func summaryFormat(summary *models.Summary) string {
+	if summary.Format == models.SummaryFormatMarkdown {
+		return models.SummaryFormatMarkdown
+	}
+	return models.SummaryFormatText
+}
+
+// plainSummaryText returns a result as plain text, stripping markdown for destinations like Twitter
+func plainSummaryText(summaryText, format string) string {
+	if format == models.SummaryFormatMarkdown {
+		return markdownToText(summaryText)
+	}
+	return summaryText
+}
+
+// summaryEventLine lists a headline in the prompt. Markdown summaries also get its magnitude and
+// link so the model can cite them.
+func summaryEventLine(event models.Event, format string) string {
+	line := fmt.Sprintf("- [%s] %s", event.Timestamp.Format("2006-01-02 15:04"), event.Title)
+	if format == models.SummaryFormatMarkdown {
+		line += fmt.Sprintf(" (magnitude %.1f, %s%s)", event.Magnitude, eventURLBase, event.ID)
+	}
+	return line + "\n"
+}
+
+// formatInstructions tells the model how to format its output; plain text needs no instructions
+func formatInstructions(format string) string {
+	if format != models.SummaryFormatMarkdown {
+		return ""
+	}
+	return "\n\nFormat the summary in Markdown. Use headings and bullet lists where they help. " +
+		"Link each event you mention to its URL as [short title](url) and give its magnitude (0-10)."
+}
+
+// forecastsText lists the active forecasts with their latest median probability
+func (e *SummaryExecutor) forecastsText(ctx context.Context) string {
+	forecasts, err := e.forecastRepo.ListForecasts(ctx, false)
+	if err != nil {
+		e.logger.Warn("failed to fetch forecasts for summary", "error", err)
+		return ""
+	}
+
+	text := "\n\nCurrent Forecasts:\n"
+	for _, f := range forecasts {
+		if !f.Active {
+			continue
+		}
+		// Get latest run to get probability
+		latestRun, err := e.forecastRepo.GetLatestCompletedForecastRun(ctx, f.ID)
+		if err == nil && latestRun != nil && latestRun.Result != nil && latestRun.Result.AggregatedPercentiles != nil {
+			// Use the median (P50) as the probability
+			text += fmt.Sprintf("- %s: %.1f%%\n", f.Name, latestRun.Result.AggregatedPercentiles.P50*100)
+		} else {
+			// No run yet, just show the forecast name
+			text += fmt.Sprintf("- %s: (no recent forecast available)\n", f.Name)
+		}
+	}
+	return text
+}
+
+// digestSection is one category of a digest with its top events, highest magnitude first
+type digestSection struct {
+	Category string
+	Events   []models.Event
+}
+
+// digestSections groups ranked events by category. Sections follow the configured category order;
+// without one, the category with the most significant event comes first. Event order is kept.
+func digestSections(events []models.Event, categories []string) []digestSection {
+	byCategory := make(map[string][]models.Event)
+	var seen []string
+	for _, event := range events {
+		category := string(event.Category)
+		if _, ok := byCategory[category]; !ok {
+			seen = append(seen, category)
+		}
+		byCategory[category] = append(byCategory[category], event)
+	}
+
+	order := categories
+	if len(order) == 0 {
+		order = seen
+		sort.SliceStable(order, func(i, j int) bool {
+			return topMagnitude(byCategory[order[i]]) > topMagnitude(byCategory[order[j]])
+		})
+	}
+
+	var sections []digestSection
+	for _, category := range order {
+		if len(byCategory[category]) == 0 {
+			continue
+		}
+		sections = append(sections, digestSection{Category: category, Events: byCategory[category]})
+	}
+	return sections
+}
+
+func topMagnitude(events []models.Event) float64 {
+	top := 0.0
+	for _, event := range events {
+		if event.Magnitude > top {
+			top = event.Magnitude
+		}
+	}
+	return top
+}
+
+// buildDigestPrompt asks for a per-category rollup with a one-line takeaway for each event
+func buildDigestPrompt(prompt string, lookbackHours int, sections []digestSection, forecastsText, format string) string {
+	var b strings.Builder
+	b.WriteString(prompt)
+	fmt.Fprintf(&b, "\n\nWrite a digest of the last %d hours. For each category below, in the order given, "+
+		"write the category name as a heading followed by one line per event: a single-sentence takeaway "+
+		"saying what happened and why it matters. Keep the events in the order given and do not add events "+
+		"that are not listed.\n", lookbackHours)
+
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n%s:\n", strings.ToUpper(section.Category))
+		for _, event := range section.Events {
+			fmt.Fprintf(&b, "- [%s] (magnitude %.1f) %s", event.Timestamp.Format("2006-01-02 15:04"), event.Magnitude, event.Title)
+			if event.Summary != "" {
+				fmt.Fprintf(&b, " — %s", event.Summary)
+			}
+			if format == models.SummaryFormatMarkdown {
+				fmt.Fprintf(&b, " (%s%s)", eventURLBase, event.ID)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(forecastsText)
+	b.WriteString(formatInstructions(format))
+	return b.String()
+}
+
 func (e *SummaryExecutor) callLLM(model models.SummaryModel, prompt string) (string, error) {
 	ctx := context.Background()
 
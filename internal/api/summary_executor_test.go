@@ -0,0 +1,182 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestDigestSections(t *testing.T) {
+	events := []models.Event{
+		{Title: "Cyber A", Category: models.CategoryCyber, Magnitude: 6},
+		{Title: "Cyber B", Category: models.CategoryCyber, Magnitude: 4},
+		{Title: "Military A", Category: models.CategoryMilitary, Magnitude: 8.5},
+		{Title: "Economic A", Category: models.CategoryEconomic, Magnitude: 3},
+	}
+
+	t.Run("ordered by top magnitude without categories", func(t *testing.T) {
+		sections := digestSections(events, nil)
+		var order []string
+		for _, s := range sections {
+			order = append(order, s.Category)
+		}
+		if got := strings.Join(order, ","); got != "military,cyber,economic" {
+			t.Fatalf("unexpected section order %s", got)
+		}
+		if len(sections[1].Events) != 2 || sections[1].Events[0].Title != "Cyber A" {
+			t.Errorf("expected cyber events kept in rank order, got %+v", sections[1].Events)
+		}
+	})
+
+	t.Run("configured order skips empty categories", func(t *testing.T) {
+		sections := digestSections(events, []string{"economic", "disaster", "cyber"})
+		if len(sections) != 2 || sections[0].Category != "economic" || sections[1].Category != "cyber" {
+			t.Fatalf("unexpected sections %+v", sections)
+		}
+	})
+}
+
+func TestBuildDigestPrompt(t *testing.T) {
+	sections := []digestSection{{
+		Category: "military",
+		Events: []models.Event{{
+			Title:     "Troops mobilize",
+			Summary:   "Units moved to the border",
+			Magnitude: 7.5,
+			Timestamp: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+		}},
+	}}
+
+	prompt := buildDigestPrompt("Brief the analyst.", 24, sections, "\n\nCurrent Forecasts:\n- X: 40.0%\n", models.SummaryFormatText)
+
+	for _, want := range []string{
+		"Brief the analyst.",
+		"last 24 hours",
+		"one line per event",
+		"MILITARY:",
+		"- [2025-03-01 09:30] (magnitude 7.5) Troops mobilize — Units moved to the border",
+		"Current Forecasts:",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "Markdown") || strings.Contains(prompt, eventURLBase) {
+		t.Errorf("plain-text prompt should not ask for markdown or links:\n%s", prompt)
+	}
+
+	sections[0].Events[0].ID = "evt-1"
+	prompt = buildDigestPrompt("Brief the analyst.", 24, sections, "", models.SummaryFormatMarkdown)
+	for _, want := range []string{eventURLBase + "evt-1", "Format the summary in Markdown"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("markdown prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestSummaryEventLine(t *testing.T) {
+	event := models.Event{
+		ID:        "evt-1",
+		Title:     "Troops mobilize",
+		Magnitude: 7.5,
+		Timestamp: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+
+	if got := summaryEventLine(event, models.SummaryFormatText); got != "- [2025-03-01 09:30] Troops mobilize\n" {
+		t.Errorf("unexpected text line %q", got)
+	}
+	want := "- [2025-03-01 09:30] Troops mobilize (magnitude 7.5, " + eventURLBase + "evt-1)\n"
+	if got := summaryEventLine(event, models.SummaryFormatMarkdown); got != want {
+		t.Errorf("unexpected markdown line %q", got)
+	}
+}
+
+func TestNormalizeSummaryMode(t *testing.T) {
+	summary := models.Summary{}
+	if err := normalizeSummaryMode(&summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Mode != models.SummaryModeNarrative || summary.DigestPerCategory != models.DefaultDigestPerCategory {
+		t.Errorf("expected defaults, got mode %q per category %d", summary.Mode, summary.DigestPerCategory)
+	}
+
+	for _, bad := range []models.Summary{
+		{Mode: "weekly"},
+		{Mode: models.SummaryModeDigest, DigestPerCategory: -1},
+		{Mode: models.SummaryModeDigest, DigestPerCategory: maxDigestPerCategory + 1},
+	} {
+		if err := normalizeSummaryMode(&bad); err == nil {
+			t.Errorf("expected %+v to be rejected", bad)
+		}
+	}
+}
+
+func TestRenderSummaryEmail(t *testing.T) {
+	text := "## Military\n- Troops <mobilize>\n- Ships sail\n\nMarkets fell.\nOil rose."
+	citations := summaryCitations([]models.Event{{
+		ID:        "evt-1",
+		Title:     "Troops <mobilize>",
+		Category:  models.CategoryMilitary,
+		Magnitude: 7.5,
+		Timestamp: time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC),
+	}})
+	body := renderSummaryEmail("Daily & Brief", time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC), text, citations)
+
+	for _, want := range []string{
+		"<h1 style=\"font-size: 20px;\">Daily &amp; Brief</h1>",
+		"Saturday, March 1, 2025 09:30 UTC",
+		"<h2>Military</h2>",
+		"<ul>\n<li>Troops &lt;mobilize&gt;</li>\n<li>Ships sail</li>\n</ul>",
+		"<p>Markets fell.<br>Oil rose.</p>",
+		"<h2>Sources cited</h2>",
+		"<li><a href=\"" + eventURLBase + "evt-1\">Troops &lt;mobilize&gt;</a> (military, magnitude 7.5, 2025-03-01 08:00)</li>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("email missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestNormalizeSummaryEmail(t *testing.T) {
+	summary := models.Summary{EmailEnabled: true, EmailRecipients: []string{" a@example.com ", "", "b@example.com"}}
+	if err := normalizeSummaryEmail(&summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(summary.EmailRecipients, ",") != "a@example.com,b@example.com" {
+		t.Errorf("unexpected recipients %v", summary.EmailRecipients)
+	}
+
+	for _, bad := range []models.Summary{
+		{EmailEnabled: true},
+		{EmailRecipients: []string{"not an address"}},
+		{EmailRecipients: []string{"Alice <a@example.com>"}},
+	} {
+		if err := normalizeSummaryEmail(&bad); err == nil {
+			t.Errorf("expected %+v to be rejected", bad)
+		}
+	}
+}
+
+func TestRenderRunDetail(t *testing.T) {
+	detail := &models.SummaryRunDetail{
+		Results: []models.SummaryResult{
+			{SummaryText: "**Strike**", Format: models.SummaryFormatMarkdown},
+			{SummaryText: "**Strike**", Format: models.SummaryFormatText},
+		},
+		Citations: []models.SummaryCitation{{EventID: "evt-1"}},
+	}
+
+	renderRunDetail(detail)
+
+	if detail.Results[0].HTML != "<p><strong>Strike</strong></p>\n" {
+		t.Errorf("unexpected markdown HTML %q", detail.Results[0].HTML)
+	}
+	if detail.Results[1].HTML != "" {
+		t.Errorf("expected no HTML for plain text, got %q", detail.Results[1].HTML)
+	}
+	if detail.Citations[0].URL != eventURLBase+"evt-1" {
+		t.Errorf("unexpected citation URL %q", detail.Citations[0].URL)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/mail"
 	"strings"
 	"time"
 
@@ -31,6 +32,86 @@ func NewSummaryHandler(repo *database.SummaryRepository, executor *SummaryExecut
 	}
 }
 
+// maxDigestPerCategory bounds how many events per category a digest may include
+const maxDigestPerCategory = 20
+
+// normalizeSummary validates a summary from a request and fills in defaults
+func normalizeSummary(summary *models.Summary) error {
+	if err := normalizeSummaryMode(summary); err != nil {
+		return err
+	}
+	if err := normalizeSummaryFormat(summary); err != nil {
+		return err
+	}
+	return normalizeSummaryEmail(summary)
+}
+
+// normalizeSummaryFormat defaults an unset format to plain text and rejects unknown formats
+func normalizeSummaryFormat(summary *models.Summary) error {
+	switch summary.Format {
+	case "":
+		summary.Format = models.SummaryFormatText
+	case models.SummaryFormatText, models.SummaryFormatMarkdown:
+	default:
+		return fmt.Errorf("format must be %q or %q", models.SummaryFormatText, models.SummaryFormatMarkdown)
+	}
+	return nil
+}
+
+// renderRunDetail fills in the HTML of markdown results and the links of the run's citations
+func renderRunDetail(detail *models.SummaryRunDetail) {
+	for i, result := range detail.Results {
+		if result.Format == models.SummaryFormatMarkdown {
+			detail.Results[i].HTML = renderMarkdown(result.SummaryText)
+		}
+	}
+	for i := range detail.Citations {
+		detail.Citations[i].URL = eventURLBase + detail.Citations[i].EventID
+	}
+}
+
+// normalizeSummaryEmail trims and checks the email recipients. Recipients are required
+// when email delivery is enabled.
+func normalizeSummaryEmail(summary *models.Summary) error {
+	recipients := make([]string, 0, len(summary.EmailRecipients))
+	for _, recipient := range summary.EmailRecipients {
+		recipient = strings.TrimSpace(recipient)
+		if recipient == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(recipient)
+		if err != nil || addr.Address != recipient {
+			return fmt.Errorf("invalid email recipient %q", recipient)
+		}
+		recipients = append(recipients, recipient)
+	}
+	summary.EmailRecipients = recipients
+
+	if summary.EmailEnabled && len(recipients) == 0 {
+		return fmt.Errorf("email_recipients is required when email_enabled is set")
+	}
+	return nil
+}
+
+// normalizeSummaryMode defaults an unset mode and digest size and rejects values out of range
+func normalizeSummaryMode(summary *models.Summary) error {
+	switch summary.Mode {
+	case "":
+		summary.Mode = models.SummaryModeNarrative
+	case models.SummaryModeNarrative, models.SummaryModeDigest:
+	default:
+		return fmt.Errorf("mode must be %q or %q", models.SummaryModeNarrative, models.SummaryModeDigest)
+	}
+
+	if summary.DigestPerCategory == 0 {
+		summary.DigestPerCategory = models.DefaultDigestPerCategory
+	}
+	if summary.DigestPerCategory < 1 || summary.DigestPerCategory > maxDigestPerCategory {
+		return fmt.Errorf("digest_per_category must be between 1 and %d", maxDigestPerCategory)
+	}
+	return nil
+}
+
 // List summaries
 func (h *SummaryHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -44,6 +125,9 @@ func (h *SummaryHandler) List(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to list summaries", http.StatusInternalServerError)
 		return
 	}
+	for i := range summaries {
+		maskModelKeys(summaryModelKeys(summaries[i].Models))
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(summaries)
@@ -67,6 +151,7 @@ func (h *SummaryHandler) Get(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Summary not found", http.StatusNotFound)
 		return
 	}
+	maskModelKeys(summaryModelKeys(summary.Models))
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(summary)
@@ -85,11 +170,21 @@ func (h *SummaryHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := normalizeSummary(&summary); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := restoreMaskedAPIKeys(summaryModelKeys(summary.Models), nil); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	if err := h.repo.Create(context.Background(), &summary); err != nil {
 		h.logger.Error("failed to create summary", "error", err)
 		http.Error(w, "Failed to create summary", http.StatusInternalServerError)
 		return
 	}
+	maskModelKeys(summaryModelKeys(summary.Models))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -115,12 +210,29 @@ func (h *SummaryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := normalizeSummary(&summary); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Models posted back from Get carry masked keys; keep the stored ones
+	existing, err := h.repo.Get(context.Background(), id)
+	if err != nil {
+		http.Error(w, "Summary not found", http.StatusNotFound)
+		return
+	}
+	if err := restoreMaskedAPIKeys(summaryModelKeys(summary.Models), summaryModelKeys(existing.Models)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	summary.ID = id
 	if err := h.repo.Update(context.Background(), &summary); err != nil {
 		h.logger.Error("failed to update summary", "error", err)
 		http.Error(w, "Failed to update summary", http.StatusInternalServerError)
 		return
 	}
+	maskModelKeys(summaryModelKeys(summary.Models))
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(summary)
@@ -207,6 +319,11 @@ func (h *SummaryHandler) Clone(w http.ResponseWriter, r *http.Request) {
 		ScheduleInterval:  original.ScheduleInterval,
 		AutoPostToTwitter: original.AutoPostToTwitter,
 		IncludeForecasts:  original.IncludeForecasts,
+		Mode:              original.Mode,
+		DigestPerCategory: original.DigestPerCategory,
+		EmailEnabled:      original.EmailEnabled,
+		EmailRecipients:   original.EmailRecipients,
+		Format:            original.Format,
 	}
 
 	if err := h.repo.Create(context.Background(), clone); err != nil {
@@ -214,6 +331,7 @@ func (h *SummaryHandler) Clone(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to clone summary", http.StatusInternalServerError)
 		return
 	}
+	maskModelKeys(summaryModelKeys(clone.Models))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -255,6 +373,7 @@ func (h *SummaryHandler) GetLatestRun(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "No runs found", http.StatusNotFound)
 		return
 	}
+	renderRunDetail(runDetail)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(runDetail)
@@ -298,6 +417,7 @@ func (h *SummaryHandler) GetRun(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Run not found", http.StatusNotFound)
 		return
 	}
+	renderRunDetail(runDetail)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(runDetail)
@@ -340,7 +460,7 @@ func (h *SummaryHandler) PostToTwitter(w http.ResponseWriter, r *http.Request) {
 	var modelName string
 	for _, result := range runDetail.Results {
 		if result.ID == req.ResultID {
-			summaryText = result.SummaryText
+			summaryText = plainSummaryText(result.SummaryText, result.Format)
 			modelName = result.ModelName
 			break
 		}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -29,7 +28,7 @@ func (h *ThresholdHandlers) GetThresholds(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	config, err := h.repo.Get(context.Background())
+	config, err := h.repo.Get(r.Context())
 	if err != nil {
 		h.logger.Error("failed to get thresholds", "error", err)
 		http.Error(w, "Failed to get thresholds", http.StatusInternalServerError)
@@ -62,7 +61,7 @@ func (h *ThresholdHandlers) UpdateThresholds(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Update thresholds in database
-	if err := h.repo.Update(context.Background(), &config); err != nil {
+	if err := h.repo.Update(r.Context(), &config); err != nil {
 		h.logger.Error("failed to update thresholds", "error", err)
 		http.Error(w, "Failed to update thresholds", http.StatusInternalServerError)
 		return
@@ -72,6 +71,11 @@ func (h *ThresholdHandlers) UpdateThresholds(w http.ResponseWriter, r *http.Requ
 		"min_confidence", config.MinConfidence,
 		"min_magnitude", config.MinMagnitude,
 		"max_source_age_hours", config.MaxSourceAgeHours,
+		"min_sources", config.MinSources,
+		"min_source_types", config.MinSourceTypes,
+		"min_source_domains", config.MinSourceDomains,
+		"breaking_min_magnitude", config.BreakingMinMagnitude,
+		"breaking_window_hours", config.BreakingWindowHours,
 	)
 
 	w.Header().Set("Content-Type", "application/json")
@@ -3,11 +3,14 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/auth"
 	"github.com/STRATINT/stratint/internal/database"
 	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/ingestion"
@@ -44,30 +47,27 @@ func (h *TrackedAccountsHandler) ListTrackedAccounts(w http.ResponseWriter, r *h
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	platform := r.URL.Query().Get("platform")
 	enabledOnly := r.URL.Query().Get("enabled_only") == "true"
 
-	var accounts []*models.TrackedAccount
-	var err error
-
-	if platform != "" {
-		accounts, err = h.repo.ListByPlatform(platform, enabledOnly)
-	} else {
-		accounts, err = h.repo.ListAll(enabledOnly)
-	}
-
+	accounts, err := h.repo.ListByWorkspace(requestWorkspace(r), platform, enabledOnly)
 	if err != nil {
 		h.logger.Error("failed to list tracked accounts", "error", err)
 		http.Error(w, "Failed to list accounts", http.StatusInternalServerError)
 		return
 	}
 
+	masked := make([]*models.TrackedAccount, len(accounts))
+	for i, account := range accounts {
+		masked[i] = maskFeedAuth(account)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"accounts": accounts,
-		"count":    len(accounts),
+		"accounts": masked,
+		"count":    len(masked),
 	})
 }
 
@@ -78,7 +78,7 @@ func (h *TrackedAccountsHandler) AddTrackedAccount(w http.ResponseWriter, r *htt
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	var account models.TrackedAccount
 	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
@@ -96,13 +96,22 @@ func (h *TrackedAccountsHandler) AddTrackedAccount(w http.ResponseWriter, r *htt
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := ValidateFeedAuth(account.Platform, account.FetchAuth); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if account.FetchAuth.IsEmpty() {
+		account.FetchAuth = nil
+	}
 
 	// Normalize account identifier
 	account.AccountIdentifier = normalizeAccountIdentifier(account.Platform, account.AccountIdentifier)
 	if account.Metadata == nil {
 		account.Metadata = make(map[string]interface{})
 	}
+	account.Tags = normalizeTags(account.Tags)
 	account.Enabled = true
+	account.WorkspaceID = requestWorkspace(r)
 
 	if err := h.repo.Store(&account); err != nil {
 		h.logger.Error("failed to store tracked account", "error", err)
@@ -117,7 +126,7 @@ func (h *TrackedAccountsHandler) AddTrackedAccount(w http.ResponseWriter, r *htt
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(account)
+	json.NewEncoder(w).Encode(maskFeedAuth(&account))
 }
 
 // GetTrackedAccount returns a specific tracked account
@@ -132,13 +141,13 @@ func (h *TrackedAccountsHandler) GetTrackedAccount(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if account == nil {
+	if account == nil || account.WorkspaceID != requestWorkspace(r) {
 		http.Error(w, "Account not found", http.StatusNotFound)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(account)
+	json.NewEncoder(w).Encode(maskFeedAuth(account))
 }
 
 // UpdateTrackedAccount updates an existing account
@@ -160,7 +169,7 @@ func (h *TrackedAccountsHandler) UpdateTrackedAccount(w http.ResponseWriter, r *
 		return
 	}
 
-	if existing == nil {
+	if existing == nil || existing.WorkspaceID != requestWorkspace(r) {
 		http.Error(w, "Account not found", http.StatusNotFound)
 		return
 	}
@@ -171,6 +180,21 @@ func (h *TrackedAccountsHandler) UpdateTrackedAccount(w http.ResponseWriter, r *
 	if updates.Metadata != nil {
 		existing.Metadata = updates.Metadata
 	}
+	if updates.Tags != nil {
+		existing.Tags = normalizeTags(updates.Tags)
+	}
+	if updates.FetchAuth != nil {
+		// Send "fetch_auth": {} to remove stored credentials
+		restoreMaskedFeedAuth(updates.FetchAuth, existing.FetchAuth)
+		if err := ValidateFeedAuth(existing.Platform, updates.FetchAuth); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		existing.FetchAuth = updates.FetchAuth
+		if existing.FetchAuth.IsEmpty() {
+			existing.FetchAuth = nil
+		}
+	}
 
 	if err := h.repo.Store(existing); err != nil {
 		h.logger.Error("failed to update tracked account", "error", err)
@@ -181,7 +205,7 @@ func (h *TrackedAccountsHandler) UpdateTrackedAccount(w http.ResponseWriter, r *
 	h.logger.Info("updated tracked account", "id", id)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(existing)
+	json.NewEncoder(w).Encode(maskFeedAuth(existing))
 }
 
 // DeleteTrackedAccount removes an account from tracking
@@ -189,6 +213,10 @@ func (h *TrackedAccountsHandler) UpdateTrackedAccount(w http.ResponseWriter, r *
 func (h *TrackedAccountsHandler) DeleteTrackedAccount(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/api/tracked-accounts/")
 
+	if !h.ownsAccount(w, r, id) {
+		return
+	}
+
 	if err := h.repo.Delete(id); err != nil {
 		h.logger.Error("failed to delete tracked account", "error", err)
 		http.Error(w, "Failed to delete account", http.StatusInternalServerError)
@@ -215,6 +243,10 @@ func (h *TrackedAccountsHandler) ToggleTrackedAccount(w http.ResponseWriter, r *
 		return
 	}
 
+	if !h.ownsAccount(w, r, id) {
+		return
+	}
+
 	if err := h.repo.SetEnabled(id, body.Enabled); err != nil {
 		h.logger.Error("failed to toggle tracked account", "error", err)
 		http.Error(w, "Failed to toggle account", http.StatusInternalServerError)
@@ -230,13 +262,92 @@ func (h *TrackedAccountsHandler) ToggleTrackedAccount(w http.ResponseWriter, r *
 	})
 }
 
+// BulkToggleTrackedAccounts enables/disables every account on a platform and/or with a tag
+// POST /api/tracked-accounts/bulk-toggle
+// Body: {"platform": "twitter", "tag": "wires", "enabled": false}
+func (h *TrackedAccountsHandler) BulkToggleTrackedAccounts(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Platform string `json:"platform"`
+		Tag      string `json:"tag"`
+		Enabled  *bool  `json:"enabled"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.Enabled == nil {
+		http.Error(w, "enabled is required", http.StatusBadRequest)
+		return
+	}
+
+	// An empty filter would toggle every account, which is never what a bulk pause means
+	tag := strings.ToLower(strings.TrimSpace(body.Tag))
+	if body.Platform == "" && tag == "" {
+		http.Error(w, "platform or tag is required", http.StatusBadRequest)
+		return
+	}
+	if body.Platform != "" && !validTrackedAccountPlatform(body.Platform) {
+		http.Error(w, "Invalid platform (must be twitter, rss, or reddit)", http.StatusBadRequest)
+		return
+	}
+
+	workspaceID := requestWorkspace(r)
+	affected, err := h.repo.SetEnabledMatching(workspaceID, body.Platform, tag, *body.Enabled)
+	if err != nil {
+		h.logger.Error("failed to bulk toggle tracked accounts", "error", err)
+		http.Error(w, "Failed to toggle accounts", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("bulk toggled tracked accounts",
+		"platform", body.Platform,
+		"tag", tag,
+		"enabled", *body.Enabled,
+		"affected", affected)
+
+	if h.activityLogRepo != nil {
+		action := "Disabled"
+		if *body.Enabled {
+			action = "Enabled"
+		}
+		details := map[string]interface{}{
+			"enabled":      *body.Enabled,
+			"workspace_id": workspaceID,
+		}
+		if tag != "" {
+			details["tag"] = tag
+		}
+		if userID, ok := auth.GetUserIDFromContext(r.Context()); ok {
+			details["user_id"] = userID
+		}
+		if err := h.activityLogRepo.Log(r.Context(), models.ActivityLog{
+			ActivityType: models.ActivityTypeTrackedAccounts,
+			Platform:     body.Platform,
+			Message:      fmt.Sprintf("%s %d tracked accounts in bulk", action, affected),
+			Details:      details,
+			SourceCount:  &affected,
+		}); err != nil {
+			h.logger.Warn("failed to log bulk toggle", "error", err)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"platform": body.Platform,
+		"tag":      tag,
+		"enabled":  *body.Enabled,
+		"affected": affected,
+	})
+}
+
 // FetchNow triggers an immediate fetch for a tracked account
 // POST /api/tracked-accounts/:id/fetch
 func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	id := strings.TrimPrefix(r.URL.Path, "/api/tracked-accounts/")
 	id = strings.TrimSuffix(id, "/fetch")
@@ -249,14 +360,15 @@ func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if account == nil {
+	if account == nil || account.WorkspaceID != requestWorkspace(r) {
 		http.Error(w, "Account not found", http.StatusNotFound)
 		return
 	}
 
 	// Fetch based on platform
 	var sources []*models.Source
-	ctx := context.Background()
+	fetchArticles := false
+	ctx := models.ContextWithWorkspace(context.Background(), account.WorkspaceID)
 
 	switch account.Platform {
 	case "twitter":
@@ -277,6 +389,11 @@ func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request
 		h.logger.Info("manual fetch triggered", "platform", "twitter", "account", account.AccountIdentifier)
 		twitterConnector := ingestion.NewTwitterConnector(bearerToken, h.logger, h.credibilityCache)
 		sources, err = twitterConnector.FetchAccountTweets(account)
+		if rateLimitErr, ok := ingestion.IsRateLimited(err); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(rateLimitErr.ResetAt).Seconds())+1))
+			http.Error(w, rateLimitErr.Error(), http.StatusTooManyRequests)
+			return
+		}
 		if err != nil {
 			h.logger.Error("failed to fetch tweets", "account", account.AccountIdentifier, "error", err)
 			http.Error(w, "Failed to fetch tweets: "+err.Error(), http.StatusInternalServerError)
@@ -292,6 +409,11 @@ func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request
 			return
 		}
 		defer rssConnector.Close()
+		rssConnector.SetFeedAuth(account.AccountIdentifier, account.FetchAuth)
+
+		if rssConfig, err := h.connectorConfigRepo.Get(ctx, "rss"); err == nil {
+			fetchArticles = rssConfig.Config[ingestion.FetchArticlesSetting] == "true"
+		}
 
 		rssSources, err := rssConnector.Fetch()
 		if err != nil {
@@ -301,6 +423,7 @@ func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request
 		}
 		// Convert []models.Source to []*models.Source
 		for i := range rssSources {
+			rssSources[i].AssignToWorkspace(account.WorkspaceID)
 			sources = append(sources, &rssSources[i])
 		}
 
@@ -309,45 +432,30 @@ func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Store sources (check for duplicates first)
+	// Store sources; the repository skips duplicates
 	storedCount := 0
 	skippedCount := 0
-	for i, source := range sources {
-		h.logger.Info("processing source",
-			"index", i,
-			"title", source.Title,
-			"url", source.URL,
-			"has_title", source.Title != "",
-			"has_url", source.URL != "")
-
-		// Check for duplicates by title + URL
-		if source.Title != "" && source.URL != "" {
-			existing, err := h.sourceRepo.GetByTitleAndURL(ctx, source.Title, source.URL)
-			if err != nil {
-				h.logger.Error("failed to check for duplicate source", "error", err, "title", source.Title)
-				continue
+	for _, source := range sources {
+		if account.Platform == "rss" && fetchArticles {
+			// Only fetch articles for items we don't have yet
+			if existing, err := h.sourceRepo.GetByURL(ctx, source.URL); err == nil && existing == nil {
+				if _, err := ingestion.ExpandSummary(ctx, source); err != nil {
+					h.logger.Warn("failed to fetch article, keeping RSS summary", "url", source.URL, "error", err)
+				}
 			}
-			if existing != nil {
-				h.logger.Info("skipping duplicate source",
-					"title", source.Title,
-					"url", source.URL,
-					"existing_id", existing.ID)
-				skippedCount++
-				continue
-			}
-			h.logger.Info("no duplicate found, storing source", "title", source.Title)
-		} else {
-			h.logger.Warn("source missing title or URL, storing anyway",
-				"title", source.Title,
-				"url", source.URL)
 		}
 
-		if err := h.sourceRepo.StoreRaw(ctx, *source); err != nil {
+		stored, err := h.sourceRepo.StoreRaw(ctx, *source)
+		if err != nil {
 			h.logger.Error("failed to store source", "error", err, "title", source.Title)
-		} else {
-			storedCount++
-			h.logger.Info("successfully stored source", "title", source.Title, "url", source.URL)
+			continue
 		}
+		if !stored {
+			h.logger.Debug("skipping duplicate source", "title", source.Title, "url", source.URL)
+			skippedCount++
+			continue
+		}
+		storedCount++
 	}
 
 	// Update last fetched timestamp
@@ -384,6 +492,69 @@ func (h *TrackedAccountsHandler) FetchNow(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// ownsAccount reports whether the account exists in the request's workspace, writing an error
+// response if not. Accounts in other workspaces are reported as not found.
+func (h *TrackedAccountsHandler) ownsAccount(w http.ResponseWriter, r *http.Request, id string) bool {
+	account, err := h.repo.GetByID(id)
+	if err != nil {
+		h.logger.Error("failed to get tracked account", "error", err)
+		http.Error(w, "Failed to get account", http.StatusInternalServerError)
+		return false
+	}
+	if account == nil || account.WorkspaceID != requestWorkspace(r) {
+		http.Error(w, "Account not found", http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// maskFeedAuth returns a copy of account with its feed header values masked, since headers on
+// private feeds are nearly always credentials, and its password hidden
+func maskFeedAuth(account *models.TrackedAccount) *models.TrackedAccount {
+	if account.FetchAuth == nil {
+		return account
+	}
+	masked := *account
+	masked.FetchAuth = &models.FeedAuth{
+		Headers:  make(map[string]string, len(account.FetchAuth.Headers)),
+		Username: account.FetchAuth.Username,
+		Password: hideSecret(account.FetchAuth.Password),
+	}
+	for name, value := range account.FetchAuth.Headers {
+		masked.FetchAuth.Headers[name] = maskSecret(value)
+	}
+	return &masked
+}
+
+// restoreMaskedFeedAuth puts back the stored header values and password wherever an update posts
+// back the masked value it was sent
+func restoreMaskedFeedAuth(updated, stored *models.FeedAuth) {
+	if stored == nil {
+		return
+	}
+	for name, value := range updated.Headers {
+		if storedValue, ok := stored.Headers[name]; ok && value != "" && value == maskSecret(storedValue) {
+			updated.Headers[name] = storedValue
+		}
+	}
+	updated.Password = unhideSecret(updated.Password, stored.Password)
+}
+
+// normalizeTags lowercases and trims tags, dropping empty and repeated ones
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
 // normalizeAccountIdentifier standardizes account identifiers
 func normalizeAccountIdentifier(platform, identifier string) string {
 	switch platform {
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// stubTrackedAccountRepo implements the bulk toggle over an in-memory account list
+type stubTrackedAccountRepo struct {
+	models.TrackedAccountRepository
+	accounts []*models.TrackedAccount
+}
+
+func (s *stubTrackedAccountRepo) SetEnabledMatching(workspaceID, platform, tag string, enabled bool) (int, error) {
+	affected := 0
+	for _, account := range s.accounts {
+		if account.WorkspaceID != workspaceID || (platform != "" && account.Platform != platform) {
+			continue
+		}
+		if tag != "" && !contains(account.Tags, tag) {
+			continue
+		}
+		if account.Enabled != enabled {
+			account.Enabled = enabled
+			affected++
+		}
+	}
+	return affected, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBulkToggleTrackedAccounts(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := &stubTrackedAccountRepo{accounts: []*models.TrackedAccount{
+		{ID: "1", Platform: "twitter", Enabled: true, WorkspaceID: models.DefaultWorkspace, Tags: []string{"wires"}},
+		{ID: "2", Platform: "twitter", Enabled: true, WorkspaceID: models.DefaultWorkspace},
+		{ID: "3", Platform: "rss", Enabled: true, WorkspaceID: models.DefaultWorkspace, Tags: []string{"wires"}},
+		{ID: "4", Platform: "twitter", Enabled: true, WorkspaceID: "acme"},
+	}}
+	h := NewTrackedAccountsHandler(repo, nil, nil, nil, nil, nil, nil, logger)
+
+	toggle := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/tracked-accounts/bulk-toggle", strings.NewReader(body))
+		req = req.WithContext(models.ContextWithWorkspace(req.Context(), models.DefaultWorkspace))
+		h.BulkToggleTrackedAccounts(rec, req)
+		return rec
+	}
+	enabled := func() []bool {
+		var states []bool
+		for _, account := range repo.accounts {
+			states = append(states, account.Enabled)
+		}
+		return states
+	}
+
+	rec := toggle(`{"platform":"twitter","enabled":false}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Affected int `json:"affected"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Affected != 2 {
+		t.Errorf("expected 2 accounts affected, got %d", resp.Affected)
+	}
+	if got, want := enabled(), []bool{false, false, true, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after disabling twitter, enabled = %v, want %v (other workspaces untouched)", got, want)
+	}
+
+	if rec := toggle(`{"tag":" Wires ","enabled":true}`); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got, want := enabled(), []bool{true, false, true, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after enabling tag wires, enabled = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{`{"enabled":false}`, `{"platform":"twitter"}`, `{"platform":"fax","enabled":false}`, `not json`} {
+		if rec := toggle(bad); rec.Code != http.StatusBadRequest {
+			t.Errorf("body %s: expected 400, got %d", bad, rec.Code)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := normalizeTags([]string{" Wires", "ukraine", "", "wires", "UKRAINE "})
+	if want := []string{"wires", "ukraine"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeTags() = %v, want %v", got, want)
+	}
+}
+
+func TestMaskFeedAuth(t *testing.T) {
+	stored := &models.FeedAuth{
+		Headers:  map[string]string{"X-API-Key": "key-0123456789"},
+		Username: "analyst",
+		Password: "hunter2-secret",
+	}
+	account := &models.TrackedAccount{ID: "1", Platform: "rss", FetchAuth: stored}
+
+	masked := maskFeedAuth(account)
+	if masked.FetchAuth.Headers["X-API-Key"] != "***6789" || masked.FetchAuth.Password != "***" || masked.FetchAuth.Username != "analyst" {
+		t.Errorf("unexpected masked auth: %+v", masked.FetchAuth)
+	}
+	if account.FetchAuth.Password != "hunter2-secret" {
+		t.Error("masking changed the stored account")
+	}
+
+	// Posting the masked values back keeps the stored ones; changed values replace them
+	updated := &models.FeedAuth{
+		Headers:  map[string]string{"X-API-Key": "***6789", "X-Org": "acme"},
+		Username: "analyst",
+		Password: "new-password",
+	}
+	restoreMaskedFeedAuth(updated, stored)
+	want := &models.FeedAuth{
+		Headers:  map[string]string{"X-API-Key": "key-0123456789", "X-Org": "acme"},
+		Username: "analyst",
+		Password: "new-password",
+	}
+	if !reflect.DeepEqual(updated, want) {
+		t.Errorf("restoreMaskedFeedAuth() = %+v, want %+v", updated, want)
+	}
+}
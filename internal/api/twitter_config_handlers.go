@@ -51,6 +51,7 @@ func (h *TwitterConfigHandlers) GetTwitterConfig(w http.ResponseWriter, r *http.
 		http.Error(w, "Failed to get Twitter configuration", http.StatusInternalServerError)
 		return
 	}
+	maskTwitterConfig(config)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -71,6 +72,19 @@ func (h *TwitterConfigHandlers) UpdateTwitterConfig(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Credentials posted back from GetTwitterConfig are masked; keep the stored ones
+	current, err := h.repo.Get(context.Background())
+	if err != nil {
+		h.logger.Error("failed to get current twitter config", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	update.APIKey = unmaskSecret(update.APIKey, current.APIKey)
+	update.APISecret = unhideSecret(update.APISecret, current.APISecret)
+	update.AccessToken = unmaskSecret(update.AccessToken, current.AccessToken)
+	update.AccessTokenSecret = unhideSecret(update.AccessTokenSecret, current.AccessTokenSecret)
+	update.BearerToken = unmaskSecret(update.BearerToken, current.BearerToken)
+
 	// Validate config
 	if err := ValidateTwitterConfig(&update); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -92,6 +106,8 @@ func (h *TwitterConfigHandlers) UpdateTwitterConfig(w http.ResponseWriter, r *ht
 		return
 	}
 
+	maskTwitterConfig(config)
+
 	h.logger.Info("twitter config updated",
 		"min_magnitude", update.MinMagnitudeForTweet,
 		"min_confidence", update.MinConfidenceForTweet,
@@ -108,6 +124,16 @@ func (h *TwitterConfigHandlers) UpdateTwitterConfig(w http.ResponseWriter, r *ht
 	})
 }
 
+// maskTwitterConfig masks the credentials of a Twitter config returned by the API, hiding the
+// secrets entirely
+func maskTwitterConfig(config *models.TwitterConfig) {
+	config.APIKey = maskSecret(config.APIKey)
+	config.APISecret = hideSecret(config.APISecret)
+	config.AccessToken = maskSecret(config.AccessToken)
+	config.AccessTokenSecret = hideSecret(config.AccessTokenSecret)
+	config.BearerToken = maskSecret(config.BearerToken)
+}
+
 // PostEventToTwitter handles POST /api/events/:id/post-to-twitter
 func (h *TwitterConfigHandlers) PostEventToTwitter(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
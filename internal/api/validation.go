@@ -2,10 +2,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"unicode"
 
+	"github.com/STRATINT/stratint/internal/enrichment"
+	"github.com/STRATINT/stratint/internal/ingestion"
 	"github.com/STRATINT/stratint/internal/models"
 )
 
@@ -19,8 +26,123 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// maxLocalTimeoutSeconds bounds per-call timeouts for OpenAI-compatible servers, which are often
+// local models running far slower than the hosted API
+const maxLocalTimeoutSeconds = 1800
+
+// maxFallbackModels bounds the enrichment fallback chain
+const maxFallbackModels = 5
+
+// validModels are the chat models offered by the public OpenAI API, from
+// https://platform.openai.com/docs/pricing
+var validModels = []string{
+	// GPT-4o models
+	"gpt-4o",
+	"gpt-4o-mini",
+	"gpt-4o-2024-11-20",
+	"gpt-4o-2024-08-06",
+	"gpt-4o-2024-05-13",
+	"gpt-4o-mini-2024-07-18",
+	// GPT-4 Turbo models
+	"gpt-4-turbo",
+	"gpt-4-turbo-2024-04-09",
+	"gpt-4-turbo-preview",
+	"gpt-4-0125-preview",
+	"gpt-4-1106-preview",
+	// GPT-4 models
+	"gpt-4",
+	"gpt-4-0613",
+	"gpt-4-0314",
+	// GPT-3.5 Turbo models
+	"gpt-3.5-turbo",
+	"gpt-3.5-turbo-0125",
+	"gpt-3.5-turbo-1106",
+	"gpt-3.5-turbo-16k",
+	// o1 models
+	"o1-preview",
+	"o1-preview-2024-09-12",
+	"o1-mini",
+	"o1-mini-2024-09-12",
+	// o4 models
+	"o4-mini",
+	// gpt-5 models
+	"gpt-5",
+	"gpt-5-mini",
+}
+
 // ValidateOpenAIConfig validates OpenAI configuration
 func ValidateOpenAIConfig(config *models.OpenAIConfig) error {
+	maxTimeout := 300
+	switch config.Provider {
+	case "", models.ProviderOpenAI:
+		if err := validateHostedOpenAI(config); err != nil {
+			return err
+		}
+	case models.ProviderOpenAICompatible:
+		if config.BaseURL == "" {
+			return ValidationError{Field: "base_url", Message: "Base URL is required for OpenAI-compatible servers (e.g. http://localhost:11434/v1)"}
+		}
+		if config.AzureDeployment != "" {
+			return ValidationError{Field: "azure_deployment", Message: "Azure deployments use the openai provider"}
+		}
+		if err := ValidateOpenAIEndpoint(config.Endpoint()); err != nil {
+			return err
+		}
+		// Local servers serve whatever models are installed and usually ignore the API key
+		if config.Model == "" {
+			return ValidationError{Field: "model", Message: "Model is required"}
+		}
+		maxTimeout = maxLocalTimeoutSeconds
+	default:
+		return ValidationError{Field: "provider", Message: "Provider must be openai or openai_compatible"}
+	}
+
+	if err := validateFallbackModels(config); err != nil {
+		return err
+	}
+	if err := config.CategoryMapping.Validate(); err != nil {
+		return ValidationError{Field: "category_mapping", Message: "Invalid category mapping: " + err.Error()}
+	}
+	if err := validatePromptABTest(config); err != nil {
+		return err
+	}
+
+	// Validate temperature (0.0 - 2.0)
+	if config.Temperature < 0.0 || config.Temperature > 2.0 {
+		return ValidationError{Field: "temperature", Message: "Temperature must be between 0.0 and 2.0"}
+	}
+
+	// Validate max tokens (1 - 128000)
+	if config.MaxTokens < 1 || config.MaxTokens > 128000 {
+		return ValidationError{Field: "max_tokens", Message: "Max tokens must be between 1 and 128000"}
+	}
+
+	// Validate timeout (1 - 300 seconds, longer for local servers)
+	if config.TimeoutSeconds < 1 || config.TimeoutSeconds > maxTimeout {
+		return ValidationError{Field: "timeout_seconds", Message: fmt.Sprintf("Timeout must be between 1 and %d seconds", maxTimeout)}
+	}
+
+	return nil
+}
+
+// validatePromptABTest validates the prompt A/B test settings. A running test needs a B prompt
+// that differs from A, or both variants would be the same.
+func validatePromptABTest(config *models.OpenAIConfig) error {
+	if config.PromptBFraction < 0 || config.PromptBFraction > 1 {
+		return ValidationError{Field: "prompt_b_fraction", Message: "Prompt B fraction must be between 0.0 and 1.0"}
+	}
+	if config.PromptBFraction == 0 {
+		return nil
+	}
+	if (config.PromptBSystemPrompt == "" || config.PromptBSystemPrompt == config.SystemPrompt) &&
+		(config.PromptBAnalysisTemplate == "" || config.PromptBAnalysisTemplate == config.AnalysisTemplate) {
+		return ValidationError{Field: "prompt_b_fraction", Message: "Set a prompt B system prompt or analysis template that differs from prompt A to run an A/B test"}
+	}
+	return nil
+}
+
+// validateHostedOpenAI validates the API key and model for the public OpenAI API or Azure
+func validateHostedOpenAI(config *models.OpenAIConfig) error {
 	if config.APIKey == "" {
 		return ValidationError{Field: "api_key", Message: "API key is required"}
 	}
@@ -29,71 +151,162 @@ func ValidateOpenAIConfig(config *models.OpenAIConfig) error {
 		return ValidationError{Field: "api_key", Message: "API key appears to be invalid (too short)"}
 	}
 
-	if !strings.HasPrefix(config.APIKey, "sk-") {
+	if err := ValidateOpenAIEndpoint(config.Endpoint()); err != nil {
+		return err
+	}
+
+	// Only keys for the public API have the sk- prefix; Azure keys don't
+	if config.BaseURL == "" && !strings.HasPrefix(config.APIKey, "sk-") {
 		return ValidationError{Field: "api_key", Message: "API key must start with 'sk-'"}
 	}
 
-	// Validate model - all models from https://platform.openai.com/docs/pricing
-	validModels := []string{
-		// GPT-4o models
-		"gpt-4o",
-		"gpt-4o-mini",
-		"gpt-4o-2024-11-20",
-		"gpt-4o-2024-08-06",
-		"gpt-4o-2024-05-13",
-		"gpt-4o-mini-2024-07-18",
-		// GPT-4 Turbo models
-		"gpt-4-turbo",
-		"gpt-4-turbo-2024-04-09",
-		"gpt-4-turbo-preview",
-		"gpt-4-0125-preview",
-		"gpt-4-1106-preview",
-		// GPT-4 models
-		"gpt-4",
-		"gpt-4-0613",
-		"gpt-4-0314",
-		// GPT-3.5 Turbo models
-		"gpt-3.5-turbo",
-		"gpt-3.5-turbo-0125",
-		"gpt-3.5-turbo-1106",
-		"gpt-3.5-turbo-16k",
-		// o1 models
-		"o1-preview",
-		"o1-preview-2024-09-12",
-		"o1-mini",
-		"o1-mini-2024-09-12",
-		// o4 models
-		"o4-mini",
-		// gpt-5 models
-		"gpt-5",
-		"gpt-5-mini",
-	}
-
-	modelValid := false
+	if !validOpenAIModel(config.Model) {
+		return ValidationError{Field: "model", Message: "Invalid model name"}
+	}
+
+	return nil
+}
+
+// validOpenAIModel reports whether model is one of the public OpenAI API's chat models
+func validOpenAIModel(model string) bool {
 	for _, validModel := range validModels {
-		if config.Model == validModel {
-			modelValid = true
-			break
+		if model == validModel {
+			return true
 		}
 	}
+	return false
+}
 
-	if !modelValid {
-		return ValidationError{Field: "model", Message: "Invalid model name"}
+// validateFallbackModels checks the enrichment fallback chain. Fallbacks are served by the same
+// endpoint as the primary model, so on the public API they must be valid OpenAI models.
+func validateFallbackModels(config *models.OpenAIConfig) error {
+	if len(config.FallbackModels) > maxFallbackModels {
+		return ValidationError{Field: "fallback_models", Message: fmt.Sprintf("At most %d fallback models are allowed", maxFallbackModels)}
 	}
 
-	// Validate temperature (0.0 - 2.0)
-	if config.Temperature < 0.0 || config.Temperature > 2.0 {
-		return ValidationError{Field: "temperature", Message: "Temperature must be between 0.0 and 2.0"}
+	seen := map[string]bool{config.Model: true}
+	hosted := config.Provider != models.ProviderOpenAICompatible && config.BaseURL == ""
+	for _, model := range config.FallbackModels {
+		if strings.TrimSpace(model) == "" {
+			return ValidationError{Field: "fallback_models", Message: "Fallback model names cannot be empty"}
+		}
+		if seen[model] {
+			return ValidationError{Field: "fallback_models", Message: fmt.Sprintf("Fallback model %s is listed more than once or is the primary model", model)}
+		}
+		if hosted && !validOpenAIModel(model) {
+			return ValidationError{Field: "fallback_models", Message: fmt.Sprintf("Invalid fallback model name: %s", model)}
+		}
+		seen[model] = true
 	}
+	return nil
+}
 
-	// Validate max tokens (1 - 128000)
-	if config.MaxTokens < 1 || config.MaxTokens > 128000 {
-		return ValidationError{Field: "max_tokens", Message: "Max tokens must be between 1 and 128000"}
+// ValidateOpenAIEndpoint validates a custom OpenAI base URL or Azure deployment
+func ValidateOpenAIEndpoint(endpoint models.OpenAIEndpoint) error {
+	if endpoint.BaseURL != "" {
+		if err := ValidateURL(endpoint.BaseURL); err != nil {
+			return ValidationError{Field: "base_url", Message: "Base URL must be an http or https URL"}
+		}
+	}
+
+	if endpoint.IsAzure() && endpoint.BaseURL == "" {
+		return ValidationError{Field: "base_url", Message: "Base URL (the Azure resource endpoint) is required for Azure deployments"}
 	}
 
-	// Validate timeout (1 - 300 seconds)
-	if config.TimeoutSeconds < 1 || config.TimeoutSeconds > 300 {
-		return ValidationError{Field: "timeout_seconds", Message: "Timeout must be between 1 and 300 seconds"}
+	if endpoint.AzureAPIVersion != "" && !endpoint.IsAzure() {
+		return ValidationError{Field: "azure_api_version", Message: "Azure API version requires an Azure deployment"}
+	}
+
+	return nil
+}
+
+// ValidateForecastModelWeights checks that every model has a positive, finite weight, since
+// the aggregated result is a weighted mean
+func ValidateForecastModelWeights(forecastModels []models.ForecastModel) error {
+	for _, model := range forecastModels {
+		if !(model.Weight > 0) || math.IsInf(model.Weight, 0) {
+			return ValidationError{Field: "weight", Message: fmt.Sprintf("Weight for model %s must be a positive number", model.ModelName)}
+		}
+	}
+	return nil
+}
+
+// ValidateForecastCompletionWebhook validates a forecast's completion webhook settings
+func ValidateForecastCompletionWebhook(req *models.CreateForecastRequest) error {
+	if req.CompletionWebhookURL == "" {
+		if req.CompletionWebhookSecret != "" || req.CompletionWebhookAbove != nil || req.CompletionWebhookBelow != nil {
+			return ValidationError{Field: "completion_webhook_url", Message: "Completion webhook URL is required when a secret or threshold is set"}
+		}
+		return nil
+	}
+
+	if err := ValidateURL(req.CompletionWebhookURL); err != nil {
+		return ValidationError{Field: "completion_webhook_url", Message: "Completion webhook URL must be an http or https URL"}
+	}
+
+	// With below >= above every value would cross a threshold
+	if req.CompletionWebhookAbove != nil && req.CompletionWebhookBelow != nil && *req.CompletionWebhookBelow >= *req.CompletionWebhookAbove {
+		return ValidationError{Field: "completion_webhook_below", Message: "Completion webhook below threshold must be less than the above threshold"}
+	}
+
+	return nil
+}
+
+// ValidateForecastMarketSymbol normalizes a forecast's market symbol and checks it has options
+// analysis and a prediction type that can be compared with the market
+func ValidateForecastMarketSymbol(req *models.CreateForecastRequest) error {
+	req.MarketSymbol = strings.ToUpper(strings.TrimSpace(req.MarketSymbol))
+	if req.MarketSymbol == "" {
+		return nil
+	}
+	if _, ok := optionChains[req.MarketSymbol]; !ok {
+		return ValidationError{Field: "market_symbol", Message: "Market symbol must be one of: " + strings.Join(marketSymbols(), ", ")}
+	}
+	if req.PredictionType == models.PredictionTypeProbability {
+		return ValidationError{Field: "market_symbol", Message: "Market symbol needs a percentile or point_estimate forecast"}
+	}
+	return nil
+}
+
+// maxFixedHeadlines caps the headlines a forecast can be pinned to
+const maxFixedHeadlines = 1000
+
+// ValidateForecastFixedHeadlines trims a forecast's fixed headlines and checks each has a title
+func ValidateForecastFixedHeadlines(req *models.CreateForecastRequest) error {
+	if len(req.FixedHeadlines) > maxFixedHeadlines {
+		return ValidationError{Field: "fixed_headlines", Message: fmt.Sprintf("At most %d fixed headlines are allowed", maxFixedHeadlines)}
+	}
+	for i := range req.FixedHeadlines {
+		headline := &req.FixedHeadlines[i]
+		headline.Title = strings.TrimSpace(headline.Title)
+		headline.Summary = strings.TrimSpace(headline.Summary)
+		if headline.Title == "" {
+			return ValidationError{Field: "fixed_headlines", Message: fmt.Sprintf("Fixed headline %d needs a title", i+1)}
+		}
+	}
+	return nil
+}
+
+// ValidatePromptTemplates checks that updated prompt templates keep their required placeholders
+func ValidatePromptTemplates(update *models.OpenAIConfigUpdate) error {
+	templates := []struct {
+		field    string
+		template *string
+	}{
+		{"analysis_template", update.AnalysisTemplate},
+		{"prompt_b_analysis_template", update.PromptBAnalysisTemplate},
+		{"entity_extraction_prompt", update.EntityExtractionPrompt},
+		{"correlation_template", update.CorrelationTemplate},
+		{"credibility_template", update.CredibilityTemplate},
+	}
+
+	for _, t := range templates {
+		if t.template == nil {
+			continue
+		}
+		if err := enrichment.ValidatePromptTemplate(t.field, *t.template); err != nil {
+			return ValidationError{Field: t.field, Message: err.Error()}
+		}
 	}
 
 	return nil
@@ -116,9 +329,92 @@ func ValidateThresholdConfig(config *models.ThresholdConfig) error {
 		return ValidationError{Field: "max_source_age_hours", Message: "Max age hours cannot be negative"}
 	}
 
+	// Validate minimum sources (0 = use the lifecycle default)
+	if config.MinSources < 0 || config.MinSources > 20 {
+		return ValidationError{Field: "min_sources", Message: "Min sources must be between 0 and 20 (0 uses the default of 1)"}
+	}
+
+	// Validate source diversity (0 = disabled)
+	if config.MinSourceTypes < 0 || config.MinSourceTypes > 10 {
+		return ValidationError{Field: "min_source_types", Message: "Min source types must be between 0 and 10"}
+	}
+	if config.MinSourceDomains < 0 || config.MinSourceDomains > 20 {
+		return ValidationError{Field: "min_source_domains", Message: "Min source domains must be between 0 and 20"}
+	}
+
+	// Validate breaking criteria (0 = use the default)
+	if config.BreakingMinMagnitude < 0.0 || config.BreakingMinMagnitude > 10.0 {
+		return ValidationError{Field: "breaking_min_magnitude", Message: "Breaking magnitude must be between 0.0 and 10.0"}
+	}
+	if config.BreakingWindowHours < 0 || config.BreakingWindowHours > 168 {
+		return ValidationError{Field: "breaking_window_hours", Message: "Breaking window must be between 1 and 168 hours"}
+	}
+
 	return nil
 }
 
+// connectorSetting describes a key a connector reads from its config
+type connectorSetting struct {
+	Key      string
+	Required bool
+	Bool     bool // Value must be "true" or "false"
+}
+
+// connectorSchemas lists the settings each connector accepts. RSS feeds are tracked accounts,
+// not config, so RSS only has optional settings.
+var connectorSchemas = map[string][]connectorSetting{
+	"twitter": {
+		{Key: "bearer_token", Required: true},
+		{Key: enrichment.TranslateSetting, Bool: true},
+	},
+	"telegram": {
+		{Key: "bot_token", Required: true},
+		{Key: enrichment.TranslateSetting, Bool: true},
+	},
+	"rss": {
+		{Key: enrichment.TranslateSetting, Bool: true},
+		{Key: ingestion.FetchArticlesSetting, Bool: true},
+	},
+}
+
+// ValidateConnectorConfig checks a connector's config against its schema. Every problem found
+// is reported, one per line.
+func ValidateConnectorConfig(connectorID string, config map[string]string) error {
+	schema, ok := connectorSchemas[connectorID]
+	if !ok {
+		return ValidationError{Field: "connector", Message: fmt.Sprintf("Unknown connector %q", connectorID)}
+	}
+
+	var errs []error
+	known := make(map[string]bool, len(schema))
+	for _, setting := range schema {
+		known[setting.Key] = true
+		value := strings.TrimSpace(config[setting.Key])
+		if value == "" {
+			if setting.Required {
+				errs = append(errs, ValidationError{Field: setting.Key, Message: "Required"})
+			}
+			continue
+		}
+		if setting.Bool && value != "true" && value != "false" {
+			errs = append(errs, ValidationError{Field: setting.Key, Message: "Must be true or false"})
+		}
+	}
+
+	var unknown []string
+	for key := range config {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, ValidationError{Field: key, Message: fmt.Sprintf("Unknown setting for the %s connector", connectorID)})
+	}
+
+	return errors.Join(errs...)
+}
+
 // ValidateURL validates a URL string
 func ValidateURL(urlStr string) error {
 	if urlStr == "" {
@@ -141,22 +437,23 @@ func ValidateURL(urlStr string) error {
 	return nil
 }
 
+// validTrackedAccountPlatform reports whether accounts can be tracked on platform
+func validTrackedAccountPlatform(platform string) bool {
+	for _, validPlatform := range []string{"twitter", "rss", "reddit"} {
+		if platform == validPlatform {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateTrackedAccount validates tracked account data
 func ValidateTrackedAccount(platform, identifier string, fetchInterval int) error {
 	if platform == "" {
 		return ValidationError{Field: "platform", Message: "Platform is required"}
 	}
 
-	validPlatforms := []string{"twitter", "rss", "reddit"}
-	platformValid := false
-	for _, validPlatform := range validPlatforms {
-		if platform == validPlatform {
-			platformValid = true
-			break
-		}
-	}
-
-	if !platformValid {
+	if !validTrackedAccountPlatform(platform) {
 		return ValidationError{Field: "platform", Message: "Invalid platform (must be twitter, rss, or reddit)"}
 	}
 
@@ -179,6 +476,84 @@ func ValidateTrackedAccount(platform, identifier string, fetchInterval int) erro
 	return nil
 }
 
+// maxFeedAuthHeaders bounds the extra headers sent with a feed request
+const maxFeedAuthHeaders = 20
+
+// reservedFeedHeaders are managed by the HTTP client and can't be set per feed
+var reservedFeedHeaders = map[string]bool{
+	"Host":              true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+}
+
+// validHeaderName reports whether name is an HTTP header field name (an RFC 7230 token)
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateFeedAuth validates the headers and basic auth credentials sent when fetching a feed
+func ValidateFeedAuth(platform string, feedAuth *models.FeedAuth) error {
+	if feedAuth.IsEmpty() {
+		return nil
+	}
+	if platform != "rss" {
+		return ValidationError{Field: "fetch_auth", Message: "Fetch headers and credentials are only supported for RSS feeds"}
+	}
+	if len(feedAuth.Headers) > maxFeedAuthHeaders {
+		return ValidationError{Field: "fetch_auth.headers", Message: fmt.Sprintf("At most %d headers are allowed", maxFeedAuthHeaders)}
+	}
+
+	names := make([]string, 0, len(feedAuth.Headers))
+	for name := range feedAuth.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !validHeaderName(name) {
+			return ValidationError{Field: "fetch_auth.headers", Message: fmt.Sprintf("Invalid header name %q", name)}
+		}
+		canonical := http.CanonicalHeaderKey(name)
+		if reservedFeedHeaders[canonical] {
+			return ValidationError{Field: "fetch_auth.headers", Message: fmt.Sprintf("Header %q can't be set", name)}
+		}
+		if seen[canonical] {
+			return ValidationError{Field: "fetch_auth.headers", Message: fmt.Sprintf("Header %q is set more than once", canonical)}
+		}
+		seen[canonical] = true
+		if canonical == "Authorization" && feedAuth.Username != "" {
+			return ValidationError{Field: "fetch_auth.headers", Message: "An Authorization header can't be combined with a username and password"}
+		}
+
+		value := feedAuth.Headers[name]
+		if strings.TrimSpace(value) == "" {
+			return ValidationError{Field: "fetch_auth.headers", Message: fmt.Sprintf("Header %q has no value", name)}
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return ValidationError{Field: "fetch_auth.headers", Message: fmt.Sprintf("Header %q has an invalid value", name)}
+		}
+	}
+
+	if feedAuth.Password != "" && feedAuth.Username == "" {
+		return ValidationError{Field: "fetch_auth.username", Message: "Username is required with a password"}
+	}
+	if strings.Contains(feedAuth.Username, ":") {
+		return ValidationError{Field: "fetch_auth.username", Message: "Username can't contain ':'"}
+	}
+
+	return nil
+}
+
 // Scraper configuration removed - using RSS content only
 
 // ValidateTwitterConfig validates Twitter configuration
@@ -0,0 +1,340 @@
+package api
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestValidateConnectorConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		connectorID string
+		config      map[string]string
+		wantErrs    []string
+	}{
+		{
+			name:        "valid twitter config",
+			connectorID: "twitter",
+			config:      map[string]string{"bearer_token": "AAAA1234", "translate_non_english": "true"},
+		},
+		{
+			name:        "twitter missing bearer token",
+			connectorID: "twitter",
+			config:      map[string]string{"bearer_token": "  "},
+			wantErrs:    []string{"bearer_token: Required"},
+		},
+		{
+			name:        "rss needs no settings",
+			connectorID: "rss",
+			config:      map[string]string{},
+		},
+		{
+			name:        "every problem is reported",
+			connectorID: "telegram",
+			config:      map[string]string{"translate_non_english": "yes", "bot-token": "123"},
+			wantErrs: []string{
+				"bot_token: Required",
+				"translate_non_english: Must be true or false",
+				"bot-token: Unknown setting for the telegram connector",
+			},
+		},
+		{
+			name:        "unknown connector",
+			connectorID: "mastodon",
+			config:      map[string]string{},
+			wantErrs:    []string{`Unknown connector "mastodon"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConnectorConfig(tt.connectorID, tt.config)
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %v, got nil", tt.wantErrs)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.wantErrs) {
+				t.Fatalf("expected %d errors, got %q", len(tt.wantErrs), err.Error())
+			}
+			for i, want := range tt.wantErrs {
+				if !strings.Contains(lines[i], want) {
+					t.Errorf("error %d: expected %q, got %q", i, want, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateOpenAIConfig_FallbackModels(t *testing.T) {
+	base := models.OpenAIConfig{
+		APIKey:         "sk-test-0123456789abcdefghij",
+		Model:          "gpt-4o",
+		Temperature:    0.3,
+		MaxTokens:      2000,
+		TimeoutSeconds: 60,
+	}
+
+	tests := []struct {
+		name      string
+		provider  string
+		fallbacks []string
+		wantErr   string
+	}{
+		{name: "no fallbacks"},
+		{name: "valid chain", fallbacks: []string{"gpt-4o-mini", "gpt-3.5-turbo"}},
+		{name: "unknown hosted model", fallbacks: []string{"llama3"}, wantErr: "Invalid fallback model name: llama3"},
+		{name: "local server serves any model", provider: models.ProviderOpenAICompatible, fallbacks: []string{"llama3", "mistral"}},
+		{name: "primary repeated", fallbacks: []string{"gpt-4o"}, wantErr: "listed more than once"},
+		{name: "duplicate", fallbacks: []string{"gpt-4o-mini", "gpt-4o-mini"}, wantErr: "listed more than once"},
+		{name: "blank", fallbacks: []string{" "}, wantErr: "cannot be empty"},
+		{name: "too many", fallbacks: []string{"gpt-4o-mini", "gpt-4", "gpt-4-turbo", "gpt-3.5-turbo", "gpt-5", "gpt-5-mini"}, wantErr: "At most 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			config.Provider = tt.provider
+			config.FallbackModels = tt.fallbacks
+			if tt.provider == models.ProviderOpenAICompatible {
+				config.BaseURL = "http://localhost:11434/v1"
+				config.Model = "qwen2"
+			}
+
+			err := ValidateOpenAIConfig(&config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOpenAIConfig_PromptABTest(t *testing.T) {
+	base := models.OpenAIConfig{
+		APIKey:         "sk-test-0123456789abcdefghij",
+		Model:          "gpt-4o",
+		Temperature:    0.3,
+		MaxTokens:      2000,
+		TimeoutSeconds: 60,
+		SystemPrompt:   "You are an analyst.",
+	}
+
+	tests := []struct {
+		name     string
+		fraction float64
+		system   string
+		template string
+		wantErr  string
+	}{
+		{name: "off"},
+		{name: "off with prompts kept", system: "You are a terse analyst."},
+		{name: "different system prompt", fraction: 0.2, system: "You are a terse analyst."},
+		{name: "different template", fraction: 0.5, template: "Analyze {{.RawContent}}"},
+		{name: "no prompt B", fraction: 0.2, wantErr: "differs from prompt A"},
+		{name: "same as prompt A", fraction: 0.2, system: "You are an analyst.", wantErr: "differs from prompt A"},
+		{name: "negative fraction", fraction: -0.1, system: "You are a terse analyst.", wantErr: "between 0.0 and 1.0"},
+		{name: "fraction above one", fraction: 1.5, system: "You are a terse analyst.", wantErr: "between 0.0 and 1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			config.PromptBFraction = tt.fraction
+			config.PromptBSystemPrompt = tt.system
+			config.PromptBAnalysisTemplate = tt.template
+
+			err := ValidateOpenAIConfig(&config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForecastCompletionWebhook(t *testing.T) {
+	low, high := 1.0, 5.0
+
+	tests := []struct {
+		name    string
+		req     models.CreateForecastRequest
+		wantErr string
+	}{
+		{name: "disabled"},
+		{name: "url only", req: models.CreateForecastRequest{CompletionWebhookURL: "https://example.com/hook"}},
+		{name: "signed with thresholds", req: models.CreateForecastRequest{CompletionWebhookURL: "https://example.com/hook", CompletionWebhookSecret: "s3cret", CompletionWebhookAbove: &high, CompletionWebhookBelow: &low}},
+		{name: "invalid url", req: models.CreateForecastRequest{CompletionWebhookURL: "ftp://example.com"}, wantErr: "must be an http or https URL"},
+		{name: "secret without url", req: models.CreateForecastRequest{CompletionWebhookSecret: "s3cret"}, wantErr: "URL is required"},
+		{name: "threshold without url", req: models.CreateForecastRequest{CompletionWebhookAbove: &high}, wantErr: "URL is required"},
+		{name: "overlapping thresholds", req: models.CreateForecastRequest{CompletionWebhookURL: "https://example.com/hook", CompletionWebhookAbove: &low, CompletionWebhookBelow: &high}, wantErr: "must be less than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateForecastCompletionWebhook(&tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForecastMarketSymbol(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        models.CreateForecastRequest
+		wantSymbol string
+		wantErr    string
+	}{
+		{name: "none"},
+		{name: "normalized", req: models.CreateForecastRequest{MarketSymbol: " spy ", PredictionType: models.PredictionTypePercentile}, wantSymbol: "SPY"},
+		{name: "point estimate", req: models.CreateForecastRequest{MarketSymbol: "GLD", PredictionType: models.PredictionTypePointEstimate}, wantSymbol: "GLD"},
+		{name: "unknown symbol", req: models.CreateForecastRequest{MarketSymbol: "AAPL", PredictionType: models.PredictionTypePercentile}, wantErr: "must be one of: GLD, IBIT, SPY, TLT, USO, VNQ"},
+		{name: "probability forecast", req: models.CreateForecastRequest{MarketSymbol: "SPY", PredictionType: models.PredictionTypeProbability}, wantErr: "percentile or point_estimate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateForecastMarketSymbol(&tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if tt.req.MarketSymbol != tt.wantSymbol {
+					t.Errorf("MarketSymbol = %q, want %q", tt.req.MarketSymbol, tt.wantSymbol)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForecastFixedHeadlines(t *testing.T) {
+	req := models.CreateForecastRequest{FixedHeadlines: []models.ForecastHeadline{{Title: "  Strait closed  ", Summary: " Shipping halted "}}}
+	if err := ValidateForecastFixedHeadlines(&req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := req.FixedHeadlines[0]; got.Title != "Strait closed" || got.Summary != "Shipping halted" {
+		t.Errorf("expected trimmed headline, got %+v", got)
+	}
+
+	req = models.CreateForecastRequest{FixedHeadlines: []models.ForecastHeadline{{Title: "Strait closed"}, {Title: " "}}}
+	if err := ValidateForecastFixedHeadlines(&req); err == nil || !strings.Contains(err.Error(), "Fixed headline 2 needs a title") {
+		t.Errorf("expected an untitled headline error, got %v", err)
+	}
+
+	req = models.CreateForecastRequest{FixedHeadlines: make([]models.ForecastHeadline, maxFixedHeadlines+1)}
+	if err := ValidateForecastFixedHeadlines(&req); err == nil || !strings.Contains(err.Error(), "At most") {
+		t.Errorf("expected a too many headlines error, got %v", err)
+	}
+}
+
+func TestValidateFeedAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		auth     *models.FeedAuth
+		wantErr  string
+	}{
+		{name: "none", platform: "twitter"},
+		{name: "empty", platform: "twitter", auth: &models.FeedAuth{}},
+		{name: "api key header", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"X-API-Key": "abc123"}}},
+		{name: "basic auth", platform: "rss", auth: &models.FeedAuth{Username: "analyst", Password: "hunter2"}},
+		{name: "not rss", platform: "twitter", auth: &models.FeedAuth{Username: "analyst"}, wantErr: "only supported for RSS"},
+		{name: "bad header name", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"X API Key": "abc"}}, wantErr: "Invalid header name"},
+		{name: "reserved header", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"host": "example.com"}}, wantErr: "can't be set"},
+		{name: "duplicate header", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"x-api-key": "a", "X-Api-Key": "b"}}, wantErr: "more than once"},
+		{name: "header injection", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"X-API-Key": "abc\r\nX-Evil: 1"}}, wantErr: "invalid value"},
+		{name: "empty value", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"X-API-Key": " "}}, wantErr: "has no value"},
+		{name: "authorization with username", platform: "rss", auth: &models.FeedAuth{Headers: map[string]string{"Authorization": "Bearer x"}, Username: "analyst"}, wantErr: "can't be combined"},
+		{name: "password without username", platform: "rss", auth: &models.FeedAuth{Password: "hunter2"}, wantErr: "Username is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFeedAuth(tt.platform, tt.auth)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForecastModelWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+		wantErr bool
+	}{
+		{name: "positive", weights: []float64{1, 0.5}},
+		{name: "zero", weights: []float64{1, 0}, wantErr: true},
+		{name: "negative", weights: []float64{-1}, wantErr: true},
+		{name: "nan", weights: []float64{math.NaN()}, wantErr: true},
+		{name: "infinite", weights: []float64{math.Inf(1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forecastModels []models.ForecastModel
+			for _, weight := range tt.weights {
+				forecastModels = append(forecastModels, models.ForecastModel{ModelName: "gpt-4o", Weight: weight})
+			}
+
+			err := ValidateForecastModelWeights(forecastModels)
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), "must be a positive number")) {
+				t.Fatalf("expected a weight error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateThresholdConfig_MinSources(t *testing.T) {
+	for _, n := range []int{0, 1, 20} {
+		if err := ValidateThresholdConfig(&models.ThresholdConfig{MinSources: n}); err != nil {
+			t.Errorf("min_sources %d: unexpected error %v", n, err)
+		}
+	}
+	for _, n := range []int{-1, 21} {
+		err := ValidateThresholdConfig(&models.ThresholdConfig{MinSources: n})
+		if err == nil || !strings.Contains(err.Error(), "between 0 and 20") {
+			t.Errorf("min_sources %d: expected range error, got %v", n, err)
+		}
+	}
+}
@@ -2,12 +2,17 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/models"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -15,13 +20,37 @@ import (
 // contextKey is a custom type for context keys to avoid collisions
 type contextKey string
 
-const userIDContextKey contextKey = "userID"
+const (
+	userIDContextKey contextKey = "userID"
+	roleContextKey   contextKey = "role"
+)
+
+// Roles carried in access tokens
+const (
+	RoleAdmin   = "admin"   // Full access to every admin route
+	RoleAnalyst = "analyst" // Read-only access to routes wrapped in ReadOnlyMiddleware
+)
 
 // Config holds authentication configuration
 type Config struct {
-	JWTSecret     string
-	AdminPassword string
-	TokenDuration time.Duration
+	JWTSecret            string
+	AdminPassword        string
+	AnalystPassword      string              // Empty disables analyst login
+	TokenDuration        time.Duration       // Lifetime of access tokens
+	RefreshTokenDuration time.Duration       // Lifetime of refresh tokens
+	APIKeys              APIKeyAuthenticator // Enables X-API-Key auth when set
+}
+
+// APIKeyHeader carries an API key as an alternative to a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyPrefix marks STRATINT API keys so they are recognisable in configs and secret scanners
+const apiKeyPrefix = "stratint_"
+
+// APIKeyAuthenticator resolves the hash of an API key to the active key it belongs to.
+// It returns nil for unknown or revoked keys.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, keyHash string) (*models.APIKey, error)
 }
 
 // LoadConfigFromEnv loads auth config from environment variables
@@ -37,22 +66,39 @@ func LoadConfigFromEnv() Config {
 	}
 
 	return Config{
-		JWTSecret:     secret,
-		AdminPassword: password,
-		TokenDuration: 24 * time.Hour, // Tokens valid for 24 hours
+		JWTSecret:            secret,
+		AdminPassword:        password,
+		AnalystPassword:      os.Getenv("ANALYST_PASSWORD"),
+		TokenDuration:        1 * time.Hour,       // Access tokens valid for 1 hour
+		RefreshTokenDuration: 30 * 24 * time.Hour, // Refresh tokens valid for 30 days
 	}
 }
 
 // Claims represents the JWT claims
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID      string `json:"user_id"`
+	Role        string `json:"role,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a new JWT token
-func GenerateToken(userID string, secret string, duration time.Duration) (string, error) {
+// RoleForPassword returns the role a login password grants, or "" if it matches none
+func (c Config) RoleForPassword(password string) string {
+	if password == c.AdminPassword {
+		return RoleAdmin
+	}
+	if c.AnalystPassword != "" && password == c.AnalystPassword {
+		return RoleAnalyst
+	}
+	return ""
+}
+
+// GenerateToken creates a new JWT token scoped to a workspace
+func GenerateToken(userID, role, workspaceID string, secret string, duration time.Duration) (string, error) {
 	claims := Claims{
-		UserID: userID,
+		UserID:      userID,
+		Role:        role,
+		WorkspaceID: models.WorkspaceOrDefault(workspaceID),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -66,6 +112,17 @@ func GenerateToken(userID string, secret string, duration time.Duration) (string
 
 // ValidateToken validates a JWT token and returns the user ID
 func ValidateToken(tokenString string, secret string) (string, error) {
+	claims, err := ParseToken(tokenString, secret)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
+
+// ParseToken validates a JWT token and returns its claims.
+// Tokens issued before roles existed carry no role and are treated as admin; tokens issued
+// before workspaces existed are scoped to the default workspace.
+func ParseToken(tokenString string, secret string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -74,14 +131,73 @@ func ValidateToken(tokenString string, secret string) (string, error) {
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if claims.Role == "" {
+		claims.Role = RoleAdmin
+	}
+	claims.WorkspaceID = models.WorkspaceOrDefault(claims.WorkspaceID)
+
+	return claims, nil
+}
+
+// GenerateRefreshToken creates a random opaque refresh token and returns it with its hash
+func GenerateRefreshToken() (string, string, error) {
+	token, err := randomSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hash under which a refresh token is stored
+func HashRefreshToken(token string) string {
+	return hashSecret(token)
+}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims.UserID, nil
+// GenerateAPIKey creates a new API key and returns it with its hash
+func GenerateAPIKey() (string, string, error) {
+	secret, err := randomSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
 	}
+	key := apiKeyPrefix + secret
+	return key, HashAPIKey(key), nil
+}
 
-	return "", fmt.Errorf("invalid token")
+// HashAPIKey returns the hash under which an API key is stored
+func HashAPIKey(key string) string {
+	return hashSecret(key)
+}
+
+// RoleForScopes maps API key scopes onto the role the key acts with
+func RoleForScopes(scopes []string) string {
+	for _, scope := range scopes {
+		if scope == models.APIKeyScopeWrite {
+			return RoleAdmin
+		}
+	}
+	return RoleAnalyst
+}
+
+// randomSecret returns 32 random bytes, base64url encoded
+func randomSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret returns the hex SHA-256 of a high-entropy secret. Secrets are random, so no salt is needed.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
 }
 
 // HashPassword hashes a password using bcrypt
@@ -96,40 +212,91 @@ func CheckPassword(password, hash string) bool {
 	return err == nil
 }
 
-// AuthMiddleware is a middleware that validates JWT tokens
+// AuthMiddleware is a middleware that validates JWT tokens or API keys and only admits admins
 func AuthMiddleware(config Config) func(http.Handler) http.Handler {
+	return roleMiddleware(config, func(role, method string) bool {
+		return role == RoleAdmin
+	})
+}
+
+// ReadOnlyMiddleware validates JWT tokens like AuthMiddleware but also admits analysts
+// for read-only requests. POST, PUT, DELETE and other mutating methods still require admin.
+func ReadOnlyMiddleware(config Config) func(http.Handler) http.Handler {
+	return roleMiddleware(config, func(role, method string) bool {
+		return role == RoleAdmin || (role == RoleAnalyst && isReadOnlyMethod(method))
+	})
+}
+
+// AnyRoleMiddleware validates JWT tokens and admits every role for every method.
+// Use it only for routes that report on the caller's own session.
+func AnyRoleMiddleware(config Config) func(http.Handler) http.Handler {
+	return roleMiddleware(config, func(role, method string) bool {
+		return true
+	})
+}
+
+// isReadOnlyMethod reports whether an HTTP method never mutates state
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// roleMiddleware authenticates the request and checks that its role may use the request method.
+// An X-API-Key header is checked when present; otherwise a bearer token is required.
+func roleMiddleware(config Config, allowed func(role, method string) bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Set CORS headers first, before any auth checks
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
-			// Extract token from Authorization header
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				http.Error(w, "Authorization header required", http.StatusUnauthorized)
-				return
-			}
+			var claims *Claims
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" && config.APIKeys != nil {
+				key, err := config.APIKeys.Authenticate(r.Context(), HashAPIKey(apiKey))
+				if err != nil {
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+					return
+				}
+				if key == nil {
+					http.Error(w, "Invalid or revoked API key", http.StatusUnauthorized)
+					return
+				}
+				claims = &Claims{UserID: "api_key:" + key.ID, Role: RoleForScopes(key.Scopes), WorkspaceID: models.WorkspaceOrDefault(key.WorkspaceID)}
+			} else {
+				// Extract token from Authorization header
+				authHeader := r.Header.Get("Authorization")
+				if authHeader == "" {
+					http.Error(w, "Authorization header required", http.StatusUnauthorized)
+					return
+				}
 
-			// Check for Bearer token format
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
-				return
-			}
+				// Check for Bearer token format
+				parts := strings.Split(authHeader, " ")
+				if len(parts) != 2 || parts[0] != "Bearer" {
+					http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+					return
+				}
 
-			tokenString := parts[1]
+				tokenString := parts[1]
 
-			// Validate token
-			userID, err := ValidateToken(tokenString, config.JWTSecret)
-			if err != nil {
-				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				// Validate token
+				var err error
+				claims, err = ParseToken(tokenString, config.JWTSecret)
+				if err != nil {
+					http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+					return
+				}
+			}
+
+			if !allowed(claims.Role, r.Method) {
+				http.Error(w, "Insufficient permissions", http.StatusForbidden)
 				return
 			}
 
-			// Add user ID to request context
-			ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+			// Add user ID, role and workspace to request context
+			ctx := context.WithValue(r.Context(), userIDContextKey, claims.UserID)
+			ctx = context.WithValue(ctx, roleContextKey, claims.Role)
+			ctx = models.ContextWithWorkspace(ctx, claims.WorkspaceID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -140,3 +307,20 @@ func GetUserIDFromContext(ctx context.Context) (string, bool) {
 	userID, ok := ctx.Value(userIDContextKey).(string)
 	return userID, ok
 }
+
+// GetRoleFromContext extracts the caller's role from the request context
+func GetRoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(roleContextKey).(string)
+	return role, ok
+}
+
+// DefaultWorkspaceMiddleware scopes requests that are not already scoped, such as public
+// requests that carry no token, to the default workspace
+func DefaultWorkspaceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := models.WorkspaceFromContext(r.Context()); !ok {
+			r = r.WithContext(models.ContextWithWorkspace(r.Context(), models.DefaultWorkspace))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
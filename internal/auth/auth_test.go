@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestGenerateRefreshToken(t *testing.T) {
+	token1, hash1, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	token2, hash2, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+
+	if token1 == token2 || hash1 == hash2 {
+		t.Error("expected distinct refresh tokens")
+	}
+	if HashRefreshToken(token1) != hash1 {
+		t.Error("HashRefreshToken does not match the hash returned with the token")
+	}
+	if hash1 == token1 {
+		t.Error("refresh token hash must not equal the token")
+	}
+}
+
+func TestValidateToken_RejectsRefreshToken(t *testing.T) {
+	token, _, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	if _, err := ValidateToken(token, "secret"); err == nil {
+		t.Error("expected refresh token to be rejected as an access token")
+	}
+
+	access, err := GenerateToken("admin", RoleAdmin, "", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if userID, err := ValidateToken(access, "secret"); err != nil || userID != "admin" {
+		t.Errorf("ValidateToken = %q, %v; want admin, nil", userID, err)
+	}
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	config := Config{JWTSecret: "secret"}
+	adminToken, _ := GenerateToken(RoleAdmin, RoleAdmin, "", config.JWTSecret, time.Hour)
+	analystToken, _ := GenerateToken(RoleAnalyst, RoleAnalyst, "", config.JWTSecret, time.Hour)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		middleware func(http.Handler) http.Handler
+		token      string
+		method     string
+		want       int
+	}{
+		{"analyst read", ReadOnlyMiddleware(config), analystToken, http.MethodGet, http.StatusOK},
+		{"analyst write", ReadOnlyMiddleware(config), analystToken, http.MethodPost, http.StatusForbidden},
+		{"analyst delete", ReadOnlyMiddleware(config), analystToken, http.MethodDelete, http.StatusForbidden},
+		{"admin write", ReadOnlyMiddleware(config), adminToken, http.MethodPut, http.StatusOK},
+		{"analyst on admin route", AuthMiddleware(config), analystToken, http.MethodGet, http.StatusForbidden},
+		{"admin on admin route", AuthMiddleware(config), adminToken, http.MethodPost, http.StatusOK},
+		{"missing token", ReadOnlyMiddleware(config), "", http.MethodGet, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/admin/forecasts", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			tt.middleware(ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseToken_LegacyTokenIsAdmin(t *testing.T) {
+	token, err := GenerateToken("admin", "", "", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	claims, err := ParseToken(token, "secret")
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if claims.Role != RoleAdmin {
+		t.Errorf("Role = %q, want %q", claims.Role, RoleAdmin)
+	}
+}
+
+func TestAuthMiddleware_ScopesWorkspace(t *testing.T) {
+	config := Config{JWTSecret: "secret"}
+	scopedToken, _ := GenerateToken(RoleAdmin, RoleAdmin, "acme", config.JWTSecret, time.Hour)
+	legacyToken, _ := GenerateToken(RoleAdmin, RoleAdmin, "", config.JWTSecret, time.Hour)
+
+	var gotWorkspace string
+	handler := AuthMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotWorkspace, _ = models.WorkspaceFromContext(r.Context())
+	}))
+
+	for token, want := range map[string]string{scopedToken: "acme", legacyToken: models.DefaultWorkspace} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/forecasts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		gotWorkspace = ""
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		if gotWorkspace != want {
+			t.Errorf("workspace = %q, want %q", gotWorkspace, want)
+		}
+	}
+
+	// Public requests without a token are scoped to the default workspace
+	DefaultWorkspaceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotWorkspace, _ = models.WorkspaceFromContext(r.Context())
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/events", nil))
+	if gotWorkspace != models.DefaultWorkspace {
+		t.Errorf("public workspace = %q, want %q", gotWorkspace, models.DefaultWorkspace)
+	}
+}
+
+type fakeAPIKeys map[string]*models.APIKey
+
+func (f fakeAPIKeys) Authenticate(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	return f[keyHash], nil
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	readKey, readHash, _ := GenerateAPIKey()
+	writeKey, writeHash, _ := GenerateAPIKey()
+	config := Config{
+		JWTSecret: "secret",
+		APIKeys: fakeAPIKeys{
+			readHash:  {ID: "k1", Scopes: []string{models.APIKeyScopeRead}},
+			writeHash: {ID: "k2", Scopes: []string{models.APIKeyScopeRead, models.APIKeyScopeWrite}},
+		},
+	}
+
+	var gotUserID string
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, _ = GetUserIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		middleware func(http.Handler) http.Handler
+		key        string
+		method     string
+		want       int
+	}{
+		{"read key reads", ReadOnlyMiddleware(config), readKey, http.MethodGet, http.StatusOK},
+		{"read key writes", ReadOnlyMiddleware(config), readKey, http.MethodPost, http.StatusForbidden},
+		{"write key writes", AuthMiddleware(config), writeKey, http.MethodPost, http.StatusOK},
+		{"unknown key", AuthMiddleware(config), "stratint_unknown", http.MethodGet, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/admin/forecasts", nil)
+			req.Header.Set(APIKeyHeader, tt.key)
+			rec := httptest.NewRecorder()
+			tt.middleware(ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	if gotUserID != "api_key:k2" {
+		t.Errorf("user ID = %q, want api_key:k2", gotUserID)
+	}
+}
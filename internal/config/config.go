@@ -1,17 +1,165 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/STRATINT/stratint/internal/secrets"
 )
 
 // Config represents runtime configuration derived from environment variables.
 type Config struct {
-	Server  ServerConfig
-	Logging LoggingConfig
+	Server     ServerConfig
+	Logging    LoggingConfig
+	RateLimit  RateLimitConfig
+	Enrichment EnrichmentConfig
+	Retention  RetentionConfig
+	Archive    ArchiveConfig
+	Ingestion  IngestionConfig
+	Events     EventsConfig
+	Reprocess  ReprocessConfig
+	Forecasts  ForecastScheduleConfig
+	SMTP       SMTPConfig
+	HTTPClient HTTPClientConfig
+	Secrets    SecretsConfig
+}
+
+// SecretsConfig holds the key that API keys and connector tokens are encrypted with in the
+// database. They are stored in plaintext when EncryptionKey is empty.
+type SecretsConfig struct {
+	EncryptionKey []byte // secrets.KeySize bytes for AES-256
+}
+
+// HTTPClientConfig sets the defaults for clients making requests to external services: feeds,
+// articles, market data, webhooks and the Twitter API.
+type HTTPClientConfig struct {
+	Timeout    time.Duration // Limit on a request including its retries, unless a caller overrides it
+	MaxRetries int           // Retries of GET and HEAD requests after network errors and 5xx responses
+	UserAgent  string        // Sent when a request sets none; empty uses the built-in default
+}
+
+// SMTP TLS modes
+const (
+	SMTPTLSStartTLS = "starttls" // Upgrade a plain connection with STARTTLS, usually on port 587
+	SMTPTLSImplicit = "tls"      // Connect over TLS from the start, usually on port 465
+	SMTPTLSNone     = "none"     // No encryption; only for local relays
+)
+
+// SMTPConfig is the mail server used to deliver summaries by email. Email is disabled when Host is empty.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string // Sender address; defaults to Username
+	TLS      string // SMTPTLSStartTLS, SMTPTLSImplicit or SMTPTLSNone
+}
+
+// Enabled reports whether a mail server is configured.
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// ForecastScheduleConfig limits how many scheduled forecasts start at once, so a backlog built up
+// during downtime doesn't fire all together, and caps the cost of any one run.
+type ForecastScheduleConfig struct {
+	MaxPerTick    int           // Scheduled forecasts claimed per check; 0 claims every due forecast
+	StaleAfter    time.Duration // Skip runs overdue by more than this and reschedule them; 0 runs every overdue forecast
+	MaxIterations int           // Most samples per model a forecast may request
+	StoreSamples  bool          // Store each model's individual samples, not just their average
+	MinHeadlines  int           // Headlines below which a run is widened beyond its categories and flagged sparse; 0 disables
+}
+
+// RetentionConfig controls the scheduled cleanup of old data. Zero days disables a rule;
+// all rules are off by default.
+type RetentionConfig struct {
+	RejectedEventDays  int // Delete rejected events older than this
+	OrphanedSourceDays int // Delete enriched or failed sources linked to no event, older than this
+	ResolvedErrorDays  int // Delete ingestion errors resolved longer ago than this
+	Interval           time.Duration
+}
+
+// ArchiveConfig controls the scheduled archival of old published events, so the feed and
+// forecasts stop surfacing stale items. Archival is off while PublishedEventDays is zero.
+type ArchiveConfig struct {
+	PublishedEventDays     int     // Archive published events older than this
+	HighMagnitudeDays      int     // Keep events at or above HighMagnitudeThreshold this long instead; 0 keeps no longer
+	HighMagnitudeThreshold float64 // Magnitude at which HighMagnitudeDays applies
+	Interval               time.Duration
+}
+
+// IngestionConfig controls how fetched items are stored.
+type IngestionConfig struct {
+	// Only sources stored within this window count as duplicates of a new item with the same
+	// content, so recycled text from long ago doesn't suppress it; 0 checks all history
+	DedupWindow time.Duration
+}
+
+// EventsConfig controls how new events are merged into recent ones, and how closely semantic
+// search results must match.
+type EventsConfig struct {
+	TitleSimilarity  float64       // Merge into a recent event whose title is at least this similar (pg_trgm, 0-1); 0 disables
+	TitleDedupWindow time.Duration // How far back to look for near-duplicate titles
+
+	EmbeddingCandidates      int           // Nearest recent events compared with each new event; 0 disables
+	EmbeddingSimilarity      float64       // Minimum cosine similarity for a merge candidate
+	EmbeddingMergeSimilarity float64       // Without a correlator, merge into the nearest event at or above this
+	EmbeddingWindow          time.Duration // How far back to look for similar events
+	SemanticSearchSimilarity float64       // Minimum cosine similarity for semantic_query results
+}
+
+// DefaultEventsConfig returns the event merging and semantic search settings used when the
+// environment does not override them.
+func DefaultEventsConfig() EventsConfig {
+	return EventsConfig{
+		TitleDedupWindow:         defaultTitleDedupWindowHours * time.Hour,
+		EmbeddingCandidates:      defaultEmbeddingCandidates,
+		EmbeddingSimilarity:      defaultEmbeddingSimilarity,
+		EmbeddingMergeSimilarity: defaultEmbeddingMergeSimilarity,
+		EmbeddingWindow:          defaultEmbeddingWindowHours * time.Hour,
+		SemanticSearchSimilarity: defaultSemanticSearchSimilarity,
+	}
+}
+
+// ReprocessConfig controls how fast bulk reprocessing jobs reset sources to pending. Each check
+// queues one batch per running job, and only while fewer than MaxPending sources await enrichment.
+type ReprocessConfig struct {
+	Interval   time.Duration
+	MaxPending int
+}
+
+// EnrichmentConfig controls the background enrichment workers.
+type EnrichmentConfig struct {
+	Workers             int               // Workers each claiming and enriching sources independently
+	MaxConcurrentCalls  int               // Limit on in-flight OpenAI calls shared by all workers
+	MinEntityConfidence float64           // Extracted entities below this confidence (0-1) are dropped; 0 keeps all
+	EntityAliases       map[string]string // Extra entity name aliases mapped to canonical names
+	EmbeddingModel      string            // Embeds events and semantic queries; must return 1536 dimensions
+	ClaimBatchSize      int               // Sources each worker claims and enriches at a time
+	ClaimStaleAfter     time.Duration     // Per claimed source; a batch's claims are reclaimed after this times the batch size
+}
+
+// ClaimStaleWindow is how long a batch of claimed sources may stay in enrichment before another
+// worker may reclaim them. It grows with the batch size, since a batch is enriched in one go.
+func (c EnrichmentConfig) ClaimStaleWindow() time.Duration {
+	return c.ClaimStaleAfter * time.Duration(max(c.ClaimBatchSize, 1))
+}
+
+// BatchTimeout bounds how long a worker spends on one claimed batch. It is derived from the
+// claim window so a batch always gives up well before its claims could be reclaimed.
+func (c EnrichmentConfig) BatchTimeout() time.Duration {
+	return c.ClaimStaleWindow() * 2 / 3
+}
+
+// ClaimHeartbeatInterval is how often a worker refreshes its claims while enriching a batch,
+// often enough that a live worker's claims never go stale.
+func (c EnrichmentConfig) ClaimHeartbeatInterval() time.Duration {
+	return c.ClaimStaleAfter / 3
 }
 
 // ServerConfig holds HTTP server runtime parameters.
@@ -22,6 +170,23 @@ type ServerConfig struct {
 	ShutdownTimeout time.Duration
 }
 
+// RateLimitConfig holds per-client request limits for public route groups.
+// Authenticated admin routes are not rate limited.
+type RateLimitConfig struct {
+	Public RateLimit // Events, stats, public forecasts and strategies, RSS
+	Market RateLimit // Market analysis and FRED routes, which call upstream APIs
+	// Number of proxies in front of the server that append to X-Forwarded-For.
+	// Cloud Run's front end adds one; put a load balancer in front and it is two.
+	TrustedProxies int
+}
+
+// RateLimit is a token bucket: PerMinute requests refill steadily, up to Burst at once.
+// A PerMinute of zero disables the limit.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
 // LoggingConfig represents structured logging configuration.
 type LoggingConfig struct {
 	Level  slog.Level
@@ -35,6 +200,45 @@ const (
 	defaultShutdownTimeout = 5 * time.Second
 
 	defaultLogFormat = "json"
+
+	defaultPublicRatePerMinute = 120
+	defaultPublicRateBurst     = 60
+	defaultMarketRatePerMinute = 20
+	defaultMarketRateBurst     = 10
+	defaultTrustedProxies      = 1
+
+	defaultEnrichmentWorkers            = 1
+	defaultEnrichmentMaxConcurrentCalls = 4
+	defaultEnrichmentClaimBatchSize     = 1
+	defaultEnrichmentClaimStaleAfter    = 15 * time.Minute
+	defaultMinEntityConfidence          = 0 // Off, so upgrading keeps every entity extracted before
+	defaultEmbeddingModel               = "text-embedding-3-small"
+
+	defaultRetentionInterval = 24 * time.Hour
+
+	defaultArchiveInterval               = 6 * time.Hour
+	defaultArchiveHighMagnitudeThreshold = 8.0
+
+	defaultSourceDedupWindowDays = 30
+
+	defaultTitleDedupWindowHours    = 48
+	defaultEmbeddingCandidates      = 5
+	defaultEmbeddingSimilarity      = 0.8
+	defaultEmbeddingMergeSimilarity = 0.92
+	defaultEmbeddingWindowHours     = 7 * 24
+	defaultSemanticSearchSimilarity = 0.3
+
+	defaultReprocessInterval   = time.Minute
+	defaultReprocessMaxPending = 200
+
+	defaultForecastMaxPerTick    = 5
+	defaultForecastMaxIterations = 50
+	defaultForecastMinHeadlines  = 5
+
+	defaultSMTPPort = 587
+
+	defaultHTTPClientTimeout    = 30 * time.Second
+	defaultHTTPClientMaxRetries = 2
 )
 
 // Load reads configuration from environment variables, applying defaults when
@@ -57,6 +261,52 @@ func Load() (Config, error) {
 			Level:  slog.LevelInfo,
 			Format: defaultLogFormat,
 		},
+		RateLimit: RateLimitConfig{
+			Public:         RateLimit{PerMinute: defaultPublicRatePerMinute, Burst: defaultPublicRateBurst},
+			Market:         RateLimit{PerMinute: defaultMarketRatePerMinute, Burst: defaultMarketRateBurst},
+			TrustedProxies: defaultTrustedProxies,
+		},
+		Enrichment: EnrichmentConfig{
+			Workers:             defaultEnrichmentWorkers,
+			MaxConcurrentCalls:  defaultEnrichmentMaxConcurrentCalls,
+			MinEntityConfidence: defaultMinEntityConfidence,
+			EmbeddingModel:      getEnv("OPENAI_EMBEDDING_MODEL", defaultEmbeddingModel),
+			ClaimBatchSize:      defaultEnrichmentClaimBatchSize,
+			ClaimStaleAfter:     defaultEnrichmentClaimStaleAfter,
+		},
+		Retention: RetentionConfig{
+			Interval: defaultRetentionInterval,
+		},
+		Archive: ArchiveConfig{
+			HighMagnitudeThreshold: defaultArchiveHighMagnitudeThreshold,
+			Interval:               defaultArchiveInterval,
+		},
+		Ingestion: IngestionConfig{
+			DedupWindow: defaultSourceDedupWindowDays * 24 * time.Hour,
+		},
+		Events: DefaultEventsConfig(),
+		Reprocess: ReprocessConfig{
+			Interval:   defaultReprocessInterval,
+			MaxPending: defaultReprocessMaxPending,
+		},
+		Forecasts: ForecastScheduleConfig{
+			MaxPerTick:    defaultForecastMaxPerTick,
+			MaxIterations: defaultForecastMaxIterations,
+			MinHeadlines:  defaultForecastMinHeadlines,
+		},
+		SMTP: SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     defaultSMTPPort,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+			TLS:      SMTPTLSStartTLS,
+		},
+		HTTPClient: HTTPClientConfig{
+			Timeout:    defaultHTTPClientTimeout,
+			MaxRetries: defaultHTTPClientMaxRetries,
+			UserAgent:  os.Getenv("HTTP_CLIENT_USER_AGENT"),
+		},
 	}
 
 	if v := os.Getenv("SERVER_READ_TIMEOUT_SECONDS"); v != "" {
@@ -100,9 +350,337 @@ func Load() (Config, error) {
 		}
 	}
 
+	rateLimitVars := []struct {
+		key    string
+		target *int
+	}{
+		{"RATE_LIMIT_PUBLIC_PER_MINUTE", &cfg.RateLimit.Public.PerMinute},
+		{"RATE_LIMIT_PUBLIC_BURST", &cfg.RateLimit.Public.Burst},
+		{"RATE_LIMIT_MARKET_PER_MINUTE", &cfg.RateLimit.Market.PerMinute},
+		{"RATE_LIMIT_MARKET_BURST", &cfg.RateLimit.Market.Burst},
+		{"RATE_LIMIT_TRUSTED_PROXIES", &cfg.RateLimit.TrustedProxies},
+	}
+	for _, v := range rateLimitVars {
+		raw := os.Getenv(v.key)
+		if raw == "" {
+			continue
+		}
+		n, err := parseNonNegativeInt(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", v.key, err)
+		}
+		*v.target = n
+	}
+
+	enrichmentVars := []struct {
+		key    string
+		target *int
+	}{
+		{"ENRICHMENT_WORKERS", &cfg.Enrichment.Workers},
+		{"ENRICHMENT_MAX_CONCURRENT_CALLS", &cfg.Enrichment.MaxConcurrentCalls},
+		{"ENRICHMENT_CLAIM_BATCH_SIZE", &cfg.Enrichment.ClaimBatchSize},
+	}
+	for _, v := range enrichmentVars {
+		raw := os.Getenv(v.key)
+		if raw == "" {
+			continue
+		}
+		n, err := parsePositiveInt(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", v.key, err)
+		}
+		*v.target = n
+	}
+
+	if v := os.Getenv("ENRICHMENT_CLAIM_STALE_MINUTES"); v != "" {
+		minutes, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid ENRICHMENT_CLAIM_STALE_MINUTES: %w", err)
+		}
+		cfg.Enrichment.ClaimStaleAfter = time.Duration(minutes) * time.Minute
+	}
+
+	if v := os.Getenv("ENRICHMENT_MIN_ENTITY_CONFIDENCE"); v != "" {
+		confidence, err := strconv.ParseFloat(v, 64)
+		if err != nil || confidence < 0 || confidence > 1 {
+			return Config{}, fmt.Errorf("invalid ENRICHMENT_MIN_ENTITY_CONFIDENCE: must be between 0 and 1")
+		}
+		cfg.Enrichment.MinEntityConfidence = confidence
+	}
+
+	if v := os.Getenv("ENRICHMENT_ENTITY_ALIASES"); v != "" {
+		aliases, err := parseAliases(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid ENRICHMENT_ENTITY_ALIASES: %w", err)
+		}
+		cfg.Enrichment.EntityAliases = aliases
+	}
+
+	retentionVars := []struct {
+		key    string
+		target *int
+	}{
+		{"RETENTION_REJECTED_EVENT_DAYS", &cfg.Retention.RejectedEventDays},
+		{"RETENTION_ORPHANED_SOURCE_DAYS", &cfg.Retention.OrphanedSourceDays},
+		{"RETENTION_RESOLVED_ERROR_DAYS", &cfg.Retention.ResolvedErrorDays},
+	}
+	for _, v := range retentionVars {
+		raw := os.Getenv(v.key)
+		if raw == "" {
+			continue
+		}
+		n, err := parseNonNegativeInt(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", v.key, err)
+		}
+		*v.target = n
+	}
+
+	if v := os.Getenv("RETENTION_INTERVAL_HOURS"); v != "" {
+		hours, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid RETENTION_INTERVAL_HOURS: %w", err)
+		}
+		cfg.Retention.Interval = time.Duration(hours) * time.Hour
+	}
+
+	archiveVars := []struct {
+		key    string
+		target *int
+	}{
+		{"ARCHIVE_PUBLISHED_EVENT_DAYS", &cfg.Archive.PublishedEventDays},
+		{"ARCHIVE_HIGH_MAGNITUDE_DAYS", &cfg.Archive.HighMagnitudeDays},
+	}
+	for _, v := range archiveVars {
+		raw := os.Getenv(v.key)
+		if raw == "" {
+			continue
+		}
+		n, err := parseNonNegativeInt(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", v.key, err)
+		}
+		*v.target = n
+	}
+
+	if v := os.Getenv("ARCHIVE_HIGH_MAGNITUDE_THRESHOLD"); v != "" {
+		magnitude, err := strconv.ParseFloat(v, 64)
+		if err != nil || magnitude < 0 || magnitude > 10 {
+			return Config{}, fmt.Errorf("invalid ARCHIVE_HIGH_MAGNITUDE_THRESHOLD: must be between 0 and 10")
+		}
+		cfg.Archive.HighMagnitudeThreshold = magnitude
+	}
+
+	if v := os.Getenv("ARCHIVE_INTERVAL_HOURS"); v != "" {
+		hours, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid ARCHIVE_INTERVAL_HOURS: %w", err)
+		}
+		cfg.Archive.Interval = time.Duration(hours) * time.Hour
+	}
+
+	if v := os.Getenv("SOURCE_DEDUP_WINDOW_DAYS"); v != "" {
+		days, err := parseNonNegativeInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid SOURCE_DEDUP_WINDOW_DAYS: %w", err)
+		}
+		cfg.Ingestion.DedupWindow = time.Duration(days) * 24 * time.Hour
+	}
+
+	for _, v := range []struct {
+		key  string
+		dest *float64
+	}{
+		{"TITLE_DEDUP_SIMILARITY", &cfg.Events.TitleSimilarity},
+		{"EMBEDDING_SIMILARITY", &cfg.Events.EmbeddingSimilarity},
+		{"EMBEDDING_MERGE_SIMILARITY", &cfg.Events.EmbeddingMergeSimilarity},
+		{"SEMANTIC_SEARCH_SIMILARITY", &cfg.Events.SemanticSearchSimilarity},
+	} {
+		raw := os.Getenv(v.key)
+		if raw == "" {
+			continue
+		}
+		similarity, err := parseFraction(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", v.key, err)
+		}
+		*v.dest = similarity
+	}
+
+	for _, v := range []struct {
+		key  string
+		dest *time.Duration
+	}{
+		{"TITLE_DEDUP_WINDOW_HOURS", &cfg.Events.TitleDedupWindow},
+		{"EMBEDDING_WINDOW_HOURS", &cfg.Events.EmbeddingWindow},
+	} {
+		raw := os.Getenv(v.key)
+		if raw == "" {
+			continue
+		}
+		hours, err := parsePositiveInt(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", v.key, err)
+		}
+		*v.dest = time.Duration(hours) * time.Hour
+	}
+
+	if v := os.Getenv("EMBEDDING_CANDIDATES"); v != "" {
+		n, err := parseNonNegativeInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid EMBEDDING_CANDIDATES: %w", err)
+		}
+		cfg.Events.EmbeddingCandidates = n
+	}
+
+	if v := os.Getenv("REPROCESS_INTERVAL_SECONDS"); v != "" {
+		seconds, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid REPROCESS_INTERVAL_SECONDS: %w", err)
+		}
+		cfg.Reprocess.Interval = time.Duration(seconds) * time.Second
+	}
+
+	if v := os.Getenv("REPROCESS_MAX_PENDING"); v != "" {
+		n, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid REPROCESS_MAX_PENDING: %w", err)
+		}
+		cfg.Reprocess.MaxPending = n
+	}
+
+	if v := os.Getenv("FORECAST_SCHEDULE_MAX_PER_TICK"); v != "" {
+		n, err := parseNonNegativeInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid FORECAST_SCHEDULE_MAX_PER_TICK: %w", err)
+		}
+		cfg.Forecasts.MaxPerTick = n
+	}
+
+	if v := os.Getenv("FORECAST_SCHEDULE_STALE_MINUTES"); v != "" {
+		minutes, err := parseNonNegativeInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid FORECAST_SCHEDULE_STALE_MINUTES: %w", err)
+		}
+		cfg.Forecasts.StaleAfter = time.Duration(minutes) * time.Minute
+	}
+
+	if v := os.Getenv("FORECAST_MAX_ITERATIONS"); v != "" {
+		n, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid FORECAST_MAX_ITERATIONS: %w", err)
+		}
+		cfg.Forecasts.MaxIterations = n
+	}
+
+	if v := os.Getenv("FORECAST_MIN_HEADLINES"); v != "" {
+		n, err := parseNonNegativeInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid FORECAST_MIN_HEADLINES: %w", err)
+		}
+		cfg.Forecasts.MinHeadlines = n
+	}
+
+	if v := os.Getenv("FORECAST_STORE_SAMPLES"); v != "" {
+		store, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid FORECAST_STORE_SAMPLES: %w", err)
+		}
+		cfg.Forecasts.StoreSamples = store
+	}
+
+	if v := os.Getenv("SMTP_PORT"); v != "" {
+		n, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid SMTP_PORT: %w", err)
+		}
+		cfg.SMTP.Port = n
+	}
+
+	if v := os.Getenv("SMTP_TLS"); v != "" {
+		switch v {
+		case SMTPTLSStartTLS, SMTPTLSImplicit, SMTPTLSNone:
+			cfg.SMTP.TLS = v
+		default:
+			return Config{}, fmt.Errorf("invalid SMTP_TLS: must be 'starttls', 'tls' or 'none'")
+		}
+	}
+
+	if v := os.Getenv("HTTP_CLIENT_TIMEOUT_SECONDS"); v != "" {
+		seconds, err := parsePositiveInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid HTTP_CLIENT_TIMEOUT_SECONDS: %w", err)
+		}
+		cfg.HTTPClient.Timeout = time.Duration(seconds) * time.Second
+	}
+
+	if v := os.Getenv("HTTP_CLIENT_MAX_RETRIES"); v != "" {
+		n, err := parseNonNegativeInt(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid HTTP_CLIENT_MAX_RETRIES: %w", err)
+		}
+		cfg.HTTPClient.MaxRetries = n
+	}
+
+	if v := os.Getenv("SECRETS_ENCRYPTION_KEY"); v != "" {
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(key) != secrets.KeySize {
+			return Config{}, fmt.Errorf("invalid SECRETS_ENCRYPTION_KEY: must be %d bytes, base64-encoded", secrets.KeySize)
+		}
+		cfg.Secrets.EncryptionKey = key
+	}
+
+	if cfg.SMTP.From == "" {
+		cfg.SMTP.From = cfg.SMTP.Username
+	}
+	if cfg.SMTP.Enabled() && cfg.SMTP.From == "" {
+		return Config{}, fmt.Errorf("SMTP_FROM is required when SMTP_HOST is set without SMTP_USERNAME")
+	}
+
 	return cfg, nil
 }
 
+func parsePositiveInt(raw string) (int, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("must be a positive integer")
+	}
+	return n, nil
+}
+
+func parseFraction(raw string) (float64, error) {
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f < 0 || f > 1 {
+		return 0, fmt.Errorf("must be between 0 and 1")
+	}
+	return f, nil
+}
+
+func parseNonNegativeInt(raw string) (int, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("must be a non-negative integer")
+	}
+	return n, nil
+}
+
+// parseAliases reads comma-separated alias=Canonical pairs
+func parseAliases(raw string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		alias, canonical, ok := strings.Cut(pair, "=")
+		alias, canonical = strings.TrimSpace(alias), strings.TrimSpace(canonical)
+		if !ok || alias == "" || canonical == "" {
+			return nil, fmt.Errorf("expected alias=Canonical, got %q", strings.TrimSpace(pair))
+		}
+		aliases[alias] = canonical
+	}
+	return aliases, nil
+}
+
 func parseSeconds(raw string) (time.Duration, error) {
 	seconds, err := strconv.Atoi(raw)
 	if err != nil || seconds < 0 {
@@ -1,11 +1,16 @@
 package config
 
 import (
+	"bytes"
+	"encoding/base64"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
 	"log/slog"
+
+	"github.com/STRATINT/stratint/internal/secrets"
 )
 
 func TestLoadDefaults(t *testing.T) {
@@ -100,6 +105,14 @@ func TestLoadWithInvalidValues(t *testing.T) {
 		"SERVER_SHUTDOWN_TIMEOUT_SECONDS": "3.5",
 		"LOG_LEVEL":                       "verbose",
 		"LOG_FORMAT":                      "xml",
+		"RATE_LIMIT_PUBLIC_PER_MINUTE":    "-5",
+		"RATE_LIMIT_MARKET_BURST":         "many",
+		"ENRICHMENT_WORKERS":              "0",
+		"ENRICHMENT_MAX_CONCURRENT_CALLS": "-2",
+		"ENRICHMENT_CLAIM_BATCH_SIZE":     "0",
+		"ENRICHMENT_CLAIM_STALE_MINUTES":  "half",
+		"RETENTION_REJECTED_EVENT_DAYS":   "-30",
+		"RETENTION_INTERVAL_HOURS":        "0",
 	}
 
 	for key, value := range tests {
@@ -114,6 +127,481 @@ func TestLoadWithInvalidValues(t *testing.T) {
 	}
 }
 
+func TestLoadRateLimitOverrides(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("RATE_LIMIT_PUBLIC_PER_MINUTE", "0")
+	t.Setenv("RATE_LIMIT_MARKET_PER_MINUTE", "5")
+	t.Setenv("RATE_LIMIT_TRUSTED_PROXIES", "2")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.RateLimit.Public.PerMinute != 0 {
+		t.Errorf("expected public rate limit disabled, got %d/min", cfg.RateLimit.Public.PerMinute)
+	}
+	if cfg.RateLimit.Market.PerMinute != 5 {
+		t.Errorf("expected market rate 5/min, got %d/min", cfg.RateLimit.Market.PerMinute)
+	}
+	if cfg.RateLimit.Market.Burst != defaultMarketRateBurst {
+		t.Errorf("expected default market burst %d, got %d", defaultMarketRateBurst, cfg.RateLimit.Market.Burst)
+	}
+	if cfg.RateLimit.TrustedProxies != 2 {
+		t.Errorf("expected 2 trusted proxies, got %d", cfg.RateLimit.TrustedProxies)
+	}
+}
+
+func TestLoadEnrichmentOverrides(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Enrichment.Workers != defaultEnrichmentWorkers {
+		t.Errorf("expected default %d workers, got %d", defaultEnrichmentWorkers, cfg.Enrichment.Workers)
+	}
+	if cfg.Enrichment.ClaimStaleWindow() != 15*time.Minute {
+		t.Errorf("expected default 15m claim window, got %v", cfg.Enrichment.ClaimStaleWindow())
+	}
+
+	t.Setenv("ENRICHMENT_WORKERS", "3")
+	t.Setenv("ENRICHMENT_MAX_CONCURRENT_CALLS", "6")
+	t.Setenv("ENRICHMENT_CLAIM_BATCH_SIZE", "4")
+	t.Setenv("ENRICHMENT_CLAIM_STALE_MINUTES", "10")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Enrichment.Workers != 3 {
+		t.Errorf("expected 3 workers, got %d", cfg.Enrichment.Workers)
+	}
+	if cfg.Enrichment.MaxConcurrentCalls != 6 {
+		t.Errorf("expected 6 concurrent calls, got %d", cfg.Enrichment.MaxConcurrentCalls)
+	}
+	if cfg.Enrichment.ClaimBatchSize != 4 {
+		t.Errorf("expected batches of 4, got %d", cfg.Enrichment.ClaimBatchSize)
+	}
+	if cfg.Enrichment.ClaimStaleWindow() != 40*time.Minute {
+		t.Errorf("expected the claim window to scale to 40m, got %v", cfg.Enrichment.ClaimStaleWindow())
+	}
+	if timeout := cfg.Enrichment.BatchTimeout(); timeout >= cfg.Enrichment.ClaimStaleWindow() {
+		t.Errorf("expected the batch timeout %v to be shorter than the claim window", timeout)
+	}
+	if interval := cfg.Enrichment.ClaimHeartbeatInterval(); interval <= 0 || interval >= cfg.Enrichment.ClaimStaleAfter {
+		t.Errorf("expected claims to be refreshed more often than they go stale, got every %v", interval)
+	}
+}
+
+func TestLoadMinEntityConfidence(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Enrichment.MinEntityConfidence != 0 {
+		t.Errorf("expected the threshold off by default, got %v", cfg.Enrichment.MinEntityConfidence)
+	}
+
+	t.Setenv("ENRICHMENT_MIN_ENTITY_CONFIDENCE", "0.5")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Enrichment.MinEntityConfidence != 0.5 {
+		t.Errorf("expected configured threshold 0.5, got %v", cfg.Enrichment.MinEntityConfidence)
+	}
+
+	t.Setenv("ENRICHMENT_MIN_ENTITY_CONFIDENCE", "1.5")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for confidence above 1")
+	}
+}
+
+func TestLoadTitleDedup(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Events.TitleSimilarity != 0 {
+		t.Errorf("expected title deduplication off by default, got %v", cfg.Events.TitleSimilarity)
+	}
+	if cfg.Events.TitleDedupWindow != 48*time.Hour {
+		t.Errorf("expected default window of 48h, got %v", cfg.Events.TitleDedupWindow)
+	}
+
+	t.Setenv("TITLE_DEDUP_SIMILARITY", "0.6")
+	t.Setenv("TITLE_DEDUP_WINDOW_HOURS", "24")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Events.TitleSimilarity != 0.6 || cfg.Events.TitleDedupWindow != 24*time.Hour {
+		t.Errorf("unexpected title dedup config: %+v", cfg.Events)
+	}
+
+	t.Setenv("TITLE_DEDUP_SIMILARITY", "60")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for similarity above 1")
+	}
+
+	t.Setenv("TITLE_DEDUP_SIMILARITY", "0.6")
+	t.Setenv("TITLE_DEDUP_WINDOW_HOURS", "0")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a zero window")
+	}
+}
+
+func TestLoadEmbeddingSettings(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Enrichment.EmbeddingModel != defaultEmbeddingModel {
+		t.Errorf("expected default embedding model %q, got %q", defaultEmbeddingModel, cfg.Enrichment.EmbeddingModel)
+	}
+	if cfg.Events.EmbeddingCandidates != defaultEmbeddingCandidates || cfg.Events.EmbeddingWindow != 7*24*time.Hour {
+		t.Errorf("unexpected embedding defaults: %+v", cfg.Events)
+	}
+
+	t.Setenv("OPENAI_EMBEDDING_MODEL", "text-embedding-ada-002")
+	t.Setenv("EMBEDDING_CANDIDATES", "0")
+	t.Setenv("EMBEDDING_SIMILARITY", "0.75")
+	t.Setenv("EMBEDDING_MERGE_SIMILARITY", "0.95")
+	t.Setenv("EMBEDDING_WINDOW_HOURS", "72")
+	t.Setenv("SEMANTIC_SEARCH_SIMILARITY", "0.4")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	want := EventsConfig{
+		TitleDedupWindow:         48 * time.Hour,
+		EmbeddingCandidates:      0,
+		EmbeddingSimilarity:      0.75,
+		EmbeddingMergeSimilarity: 0.95,
+		EmbeddingWindow:          72 * time.Hour,
+		SemanticSearchSimilarity: 0.4,
+	}
+	if cfg.Events != want {
+		t.Errorf("expected %+v, got %+v", want, cfg.Events)
+	}
+	if cfg.Enrichment.EmbeddingModel != "text-embedding-ada-002" {
+		t.Errorf("expected configured embedding model, got %q", cfg.Enrichment.EmbeddingModel)
+	}
+
+	for key, value := range map[string]string{
+		"EMBEDDING_CANDIDATES":       "-1",
+		"EMBEDDING_SIMILARITY":       "high",
+		"EMBEDDING_MERGE_SIMILARITY": "1.2",
+		"EMBEDDING_WINDOW_HOURS":     "0",
+		"SEMANTIC_SEARCH_SIMILARITY": "-0.1",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := Load(); err == nil {
+				t.Errorf("expected error for %s=%s", key, value)
+			}
+		})
+	}
+}
+
+func TestLoadEntityAliases(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("ENRICHMENT_ENTITY_ALIASES", "Kremlin = Russian Federation, POTUS=President of the United States,")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	want := map[string]string{"Kremlin": "Russian Federation", "POTUS": "President of the United States"}
+	if !reflect.DeepEqual(cfg.Enrichment.EntityAliases, want) {
+		t.Errorf("expected aliases %v, got %v", want, cfg.Enrichment.EntityAliases)
+	}
+
+	t.Setenv("ENRICHMENT_ENTITY_ALIASES", "Kremlin")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for alias without a canonical name")
+	}
+}
+
+func TestLoadRetentionOverrides(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("RETENTION_REJECTED_EVENT_DAYS", "30")
+	t.Setenv("RETENTION_RESOLVED_ERROR_DAYS", "7")
+	t.Setenv("RETENTION_INTERVAL_HOURS", "6")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.Retention.RejectedEventDays != 30 {
+		t.Errorf("expected 30 rejected event days, got %d", cfg.Retention.RejectedEventDays)
+	}
+	if cfg.Retention.OrphanedSourceDays != 0 {
+		t.Errorf("expected orphaned source retention disabled, got %d days", cfg.Retention.OrphanedSourceDays)
+	}
+	if cfg.Retention.ResolvedErrorDays != 7 {
+		t.Errorf("expected 7 resolved error days, got %d", cfg.Retention.ResolvedErrorDays)
+	}
+	if cfg.Retention.Interval != 6*time.Hour {
+		t.Errorf("expected 6h interval, got %v", cfg.Retention.Interval)
+	}
+}
+
+func TestLoadArchive(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Archive.PublishedEventDays != 0 {
+		t.Errorf("expected archival disabled by default, got %d days", cfg.Archive.PublishedEventDays)
+	}
+	if cfg.Archive.HighMagnitudeThreshold != defaultArchiveHighMagnitudeThreshold || cfg.Archive.Interval != defaultArchiveInterval {
+		t.Errorf("unexpected archive defaults: %+v", cfg.Archive)
+	}
+
+	t.Setenv("ARCHIVE_PUBLISHED_EVENT_DAYS", "14")
+	t.Setenv("ARCHIVE_HIGH_MAGNITUDE_DAYS", "60")
+	t.Setenv("ARCHIVE_HIGH_MAGNITUDE_THRESHOLD", "7.5")
+	t.Setenv("ARCHIVE_INTERVAL_HOURS", "1")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Archive.PublishedEventDays != 14 || cfg.Archive.HighMagnitudeDays != 60 {
+		t.Errorf("unexpected archive days: %+v", cfg.Archive)
+	}
+	if cfg.Archive.HighMagnitudeThreshold != 7.5 || cfg.Archive.Interval != time.Hour {
+		t.Errorf("unexpected archive threshold or interval: %+v", cfg.Archive)
+	}
+
+	t.Setenv("ARCHIVE_HIGH_MAGNITUDE_THRESHOLD", "11")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for magnitude threshold above 10")
+	}
+}
+
+func TestLoadSourceDedupWindow(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Ingestion.DedupWindow != 30*24*time.Hour {
+		t.Errorf("expected 30 day dedup window by default, got %v", cfg.Ingestion.DedupWindow)
+	}
+
+	t.Setenv("SOURCE_DEDUP_WINDOW_DAYS", "0")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Ingestion.DedupWindow != 0 {
+		t.Errorf("expected unbounded dedup window, got %v", cfg.Ingestion.DedupWindow)
+	}
+
+	t.Setenv("SOURCE_DEDUP_WINDOW_DAYS", "-1")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for negative dedup window")
+	}
+}
+
+func TestLoadReprocess(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Reprocess.Interval != defaultReprocessInterval || cfg.Reprocess.MaxPending != defaultReprocessMaxPending {
+		t.Errorf("unexpected reprocess defaults: %+v", cfg.Reprocess)
+	}
+
+	t.Setenv("REPROCESS_INTERVAL_SECONDS", "30")
+	t.Setenv("REPROCESS_MAX_PENDING", "50")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Reprocess.Interval != 30*time.Second || cfg.Reprocess.MaxPending != 50 {
+		t.Errorf("unexpected reprocess config: %+v", cfg.Reprocess)
+	}
+
+	t.Setenv("REPROCESS_MAX_PENDING", "0")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for zero max pending")
+	}
+}
+
+func TestLoadForecastScheduleOverrides(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Forecasts.MaxPerTick != defaultForecastMaxPerTick || cfg.Forecasts.StaleAfter != 0 || cfg.Forecasts.MaxIterations != defaultForecastMaxIterations || cfg.Forecasts.StoreSamples || cfg.Forecasts.MinHeadlines != defaultForecastMinHeadlines {
+		t.Errorf("unexpected forecast schedule defaults: %+v", cfg.Forecasts)
+	}
+
+	t.Setenv("FORECAST_SCHEDULE_MAX_PER_TICK", "0")
+	t.Setenv("FORECAST_SCHEDULE_STALE_MINUTES", "90")
+	t.Setenv("FORECAST_MAX_ITERATIONS", "20")
+	t.Setenv("FORECAST_STORE_SAMPLES", "true")
+	t.Setenv("FORECAST_MIN_HEADLINES", "0")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Forecasts.MaxPerTick != 0 {
+		t.Errorf("expected uncapped forecasts per tick, got %d", cfg.Forecasts.MaxPerTick)
+	}
+	if cfg.Forecasts.StaleAfter != 90*time.Minute {
+		t.Errorf("expected 90m staleness window, got %v", cfg.Forecasts.StaleAfter)
+	}
+	if cfg.Forecasts.MaxIterations != 20 {
+		t.Errorf("expected max iterations 20, got %d", cfg.Forecasts.MaxIterations)
+	}
+	if !cfg.Forecasts.StoreSamples {
+		t.Error("expected samples to be stored")
+	}
+	if cfg.Forecasts.MinHeadlines != 0 {
+		t.Errorf("expected the headline minimum to be off, got %d", cfg.Forecasts.MinHeadlines)
+	}
+
+	t.Setenv("FORECAST_MIN_HEADLINES", "-2")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for negative FORECAST_MIN_HEADLINES")
+	}
+	t.Setenv("FORECAST_MIN_HEADLINES", "")
+
+	t.Setenv("FORECAST_STORE_SAMPLES", "sometimes")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for invalid FORECAST_STORE_SAMPLES")
+	}
+	t.Setenv("FORECAST_STORE_SAMPLES", "")
+
+	t.Setenv("FORECAST_MAX_ITERATIONS", "0")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for zero FORECAST_MAX_ITERATIONS")
+	}
+	t.Setenv("FORECAST_MAX_ITERATIONS", "")
+
+	t.Setenv("FORECAST_SCHEDULE_MAX_PER_TICK", "-1")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for negative FORECAST_SCHEDULE_MAX_PER_TICK")
+	}
+}
+
+func TestLoadSMTP(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.SMTP.Enabled() || cfg.SMTP.Port != defaultSMTPPort || cfg.SMTP.TLS != SMTPTLSStartTLS {
+		t.Errorf("unexpected SMTP defaults: %+v", cfg.SMTP)
+	}
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "465")
+	t.Setenv("SMTP_USERNAME", "alerts@example.com")
+	t.Setenv("SMTP_TLS", "tls")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.SMTP.Enabled() || cfg.SMTP.Port != 465 || cfg.SMTP.TLS != SMTPTLSImplicit {
+		t.Errorf("unexpected SMTP config: %+v", cfg.SMTP)
+	}
+	if cfg.SMTP.From != "alerts@example.com" {
+		t.Errorf("expected sender to default to the username, got %q", cfg.SMTP.From)
+	}
+
+	t.Setenv("SMTP_TLS", "ssl")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for unknown SMTP_TLS")
+	}
+
+	t.Setenv("SMTP_TLS", "")
+	t.Setenv("SMTP_USERNAME", "")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for SMTP_HOST without a sender address")
+	}
+}
+
+func TestLoadHTTPClient(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.HTTPClient.Timeout != defaultHTTPClientTimeout || cfg.HTTPClient.MaxRetries != defaultHTTPClientMaxRetries || cfg.HTTPClient.UserAgent != "" {
+		t.Errorf("unexpected HTTP client defaults: %+v", cfg.HTTPClient)
+	}
+
+	t.Setenv("HTTP_CLIENT_TIMEOUT_SECONDS", "45")
+	t.Setenv("HTTP_CLIENT_MAX_RETRIES", "0")
+	t.Setenv("HTTP_CLIENT_USER_AGENT", "stratint-test")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.HTTPClient.Timeout != 45*time.Second || cfg.HTTPClient.MaxRetries != 0 || cfg.HTTPClient.UserAgent != "stratint-test" {
+		t.Errorf("unexpected HTTP client config: %+v", cfg.HTTPClient)
+	}
+
+	t.Setenv("HTTP_CLIENT_TIMEOUT_SECONDS", "0")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for zero HTTP_CLIENT_TIMEOUT_SECONDS")
+	}
+}
+
+func TestLoadSecrets(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Secrets.EncryptionKey != nil {
+		t.Errorf("expected no encryption key by default, got %v", cfg.Secrets.EncryptionKey)
+	}
+
+	t.Setenv("SECRETS_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, secrets.KeySize)))
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Secrets.EncryptionKey) != secrets.KeySize || cfg.Secrets.EncryptionKey[0] != 1 {
+		t.Errorf("unexpected encryption key: %v", cfg.Secrets.EncryptionKey)
+	}
+
+	for _, bad := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("sixteen byte key"))} {
+		t.Setenv("SECRETS_ENCRYPTION_KEY", bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("expected error for SECRETS_ENCRYPTION_KEY %q", bad)
+		}
+	}
+}
+
 func TestParseLogLevelAliases(t *testing.T) {
 	tests := map[string]slog.Level{
 		"warn":    slog.LevelWarn,
@@ -173,6 +661,51 @@ func clearConfigEnv(t *testing.T) {
 		"SERVER_SHUTDOWN_TIMEOUT_SECONDS",
 		"LOG_LEVEL",
 		"LOG_FORMAT",
+		"RATE_LIMIT_PUBLIC_PER_MINUTE",
+		"RATE_LIMIT_PUBLIC_BURST",
+		"RATE_LIMIT_MARKET_PER_MINUTE",
+		"RATE_LIMIT_MARKET_BURST",
+		"RATE_LIMIT_TRUSTED_PROXIES",
+		"ENRICHMENT_WORKERS",
+		"ENRICHMENT_MAX_CONCURRENT_CALLS",
+		"ENRICHMENT_CLAIM_BATCH_SIZE",
+		"ENRICHMENT_CLAIM_STALE_MINUTES",
+		"ENRICHMENT_MIN_ENTITY_CONFIDENCE",
+		"ENRICHMENT_ENTITY_ALIASES",
+		"RETENTION_REJECTED_EVENT_DAYS",
+		"RETENTION_ORPHANED_SOURCE_DAYS",
+		"RETENTION_RESOLVED_ERROR_DAYS",
+		"RETENTION_INTERVAL_HOURS",
+		"ARCHIVE_PUBLISHED_EVENT_DAYS",
+		"ARCHIVE_HIGH_MAGNITUDE_DAYS",
+		"ARCHIVE_HIGH_MAGNITUDE_THRESHOLD",
+		"ARCHIVE_INTERVAL_HOURS",
+		"SOURCE_DEDUP_WINDOW_DAYS",
+		"TITLE_DEDUP_SIMILARITY",
+		"TITLE_DEDUP_WINDOW_HOURS",
+		"EMBEDDING_CANDIDATES",
+		"EMBEDDING_SIMILARITY",
+		"EMBEDDING_MERGE_SIMILARITY",
+		"EMBEDDING_WINDOW_HOURS",
+		"SEMANTIC_SEARCH_SIMILARITY",
+		"OPENAI_EMBEDDING_MODEL",
+		"REPROCESS_INTERVAL_SECONDS",
+		"REPROCESS_MAX_PENDING",
+		"FORECAST_SCHEDULE_MAX_PER_TICK",
+		"FORECAST_SCHEDULE_STALE_MINUTES",
+		"FORECAST_MAX_ITERATIONS",
+		"FORECAST_STORE_SAMPLES",
+		"FORECAST_MIN_HEADLINES",
+		"SMTP_HOST",
+		"SMTP_PORT",
+		"SMTP_USERNAME",
+		"SMTP_PASSWORD",
+		"SMTP_FROM",
+		"SMTP_TLS",
+		"HTTP_CLIENT_TIMEOUT_SECONDS",
+		"HTTP_CLIENT_MAX_RETRIES",
+		"HTTP_CLIENT_USER_AGENT",
+		"SECRETS_ENCRYPTION_KEY",
 	}
 
 	for _, key := range keys {
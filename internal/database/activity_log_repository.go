@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
@@ -58,42 +59,37 @@ func (r *ActivityLogRepository) Log(ctx context.Context, log models.ActivityLog)
 	return err
 }
 
-// List retrieves activity logs with optional filtering.
-func (r *ActivityLogRepository) List(ctx context.Context, limit int, activityType string, platform string) ([]models.ActivityLog, error) {
+// List retrieves a page of activity logs matching the query, newest first, along with the
+// total number of matching logs.
+func (r *ActivityLogRepository) List(ctx context.Context, q models.ActivityLogQuery) ([]models.ActivityLog, int, error) {
+	limit := q.Limit
 	if limit <= 0 {
 		limit = 100
 	}
 	if limit > 1000 {
 		limit = 1000
 	}
-
-	query := `
-		SELECT id, timestamp, activity_type, platform, message, details, source_count, duration_ms
-		FROM activity_logs
-		WHERE 1=1
-	`
-	args := []interface{}{}
-	argPos := 1
-
-	if activityType != "" {
-		query += fmt.Sprintf(" AND activity_type = $%d", argPos)
-		args = append(args, activityType)
-		argPos++
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
 	}
 
-	if platform != "" {
-		query += fmt.Sprintf(" AND platform = $%d", argPos)
-		args = append(args, platform)
-		argPos++
+	where, args := activityLogConditions(q)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM activity_logs"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count activity logs: %w", err)
 	}
 
-	query += " ORDER BY timestamp DESC"
-	query += fmt.Sprintf(" LIMIT $%d", argPos)
-	args = append(args, limit)
+	query := `
+		SELECT id, timestamp, activity_type, platform, message, details, source_count, duration_ms
+		FROM activity_logs` + where
+	query += fmt.Sprintf(" ORDER BY timestamp DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, fmt.Errorf("failed to query activity logs: %w", err)
 	}
 	defer rows.Close()
 
@@ -113,19 +109,51 @@ func (r *ActivityLogRepository) List(ctx context.Context, limit int, activityTyp
 			&log.DurationMs,
 		)
 		if err != nil {
-			return nil, err
+			return nil, 0, fmt.Errorf("failed to scan activity log: %w", err)
 		}
 
 		if len(detailsJSON) > 0 {
 			if err := json.Unmarshal(detailsJSON, &log.Details); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal details: %w", err)
+				return nil, 0, fmt.Errorf("failed to unmarshal details: %w", err)
 			}
 		}
 
 		logs = append(logs, log)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
 
-	return logs, rows.Err()
+	return logs, total, nil
+}
+
+// activityLogConditions builds the WHERE clause shared by the count and page queries. Each
+// condition compares a bare indexed column so the type/platform + timestamp indexes apply.
+func activityLogConditions(q models.ActivityLogQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if q.ActivityType != "" {
+		args = append(args, string(q.ActivityType))
+		conditions = append(conditions, fmt.Sprintf("activity_type = $%d", len(args)))
+	}
+	if q.Platform != "" {
+		args = append(args, q.Platform)
+		conditions = append(conditions, fmt.Sprintf("platform = $%d", len(args)))
+	}
+	if q.Since != nil {
+		args = append(args, *q.Since)
+		conditions = append(conditions, fmt.Sprintf("timestamp >= $%d", len(args)))
+	}
+	if q.Until != nil {
+		args = append(args, *q.Until)
+		conditions = append(conditions, fmt.Sprintf("timestamp < $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
 }
 
 // DeleteOlderThan deletes activity logs older than the specified duration.
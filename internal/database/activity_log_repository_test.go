@@ -0,0 +1,32 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestActivityLogConditions(t *testing.T) {
+	where, args := activityLogConditions(models.ActivityLogQuery{})
+	if where != "" || len(args) != 0 {
+		t.Errorf("expected no conditions, got %q %v", where, args)
+	}
+
+	since := time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+	where, args = activityLogConditions(models.ActivityLogQuery{
+		ActivityType: models.ActivityTypeEnrichment,
+		Platform:     "rss",
+		Since:        &since,
+		Until:        &until,
+	})
+
+	want := " WHERE activity_type = $1 AND platform = $2 AND timestamp >= $3 AND timestamp < $4"
+	if where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if len(args) != 4 || args[0] != "enrichment" || args[1] != "rss" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
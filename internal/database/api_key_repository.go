@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrAPIKeyNotFound is returned when revoking a key that doesn't exist, is already revoked or
+// belongs to another workspace
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKeyRepository handles API key storage
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, name, key_prefix, key_hash, scopes, workspace_id, last_used_at, revoked_at, created_at`
+
+// scanAPIKey scans a row selected with apiKeyColumns
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	var lastUsedAt, revokedAt sql.NullTime
+	if err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.KeyPrefix,
+		&key.KeyHash,
+		pq.Array(&key.Scopes),
+		&key.WorkspaceID,
+		&lastUsedAt,
+		&revokedAt,
+		&key.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
+
+// Create stores an API key, filling in its ID and creation time. Keys without a workspace
+// belong to the one ctx is scoped to.
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	key.ID = uuid.New().String()
+	key.WorkspaceID = writeWorkspace(ctx, key.WorkspaceID)
+	key.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO api_keys (id, name, key_prefix, key_hash, scopes, workspace_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, key.ID, key.Name, key.KeyPrefix, key.KeyHash, pq.Array(key.Scopes), key.WorkspaceID, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the API keys of the workspace ctx is scoped to, including revoked ones, newest first
+func (r *APIKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE ($1::text IS NULL OR workspace_id = $1)
+		ORDER BY created_at DESC`, workspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// apiKeyUsageInterval is how old a key's last_used_at must be before authenticating with it
+// records the use again, so a busy key doesn't cost a write per request
+const apiKeyUsageInterval = time.Minute
+
+// Authenticate looks up an active key by hash and records that it was used, at most once per
+// apiKeyUsageInterval. It returns nil if no unrevoked key has that hash.
+func (r *APIKeyRepository) Authenticate(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `
+		WITH touched AS (
+			UPDATE api_keys SET last_used_at = $1
+			WHERE key_hash = $2 AND revoked_at IS NULL
+			  AND (last_used_at IS NULL OR last_used_at < $3)
+		)
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE key_hash = $2 AND revoked_at IS NULL`
+
+	now := time.Now()
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, now, keyHash, now.Add(-apiKeyUsageInterval)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Revoke marks an API key in the workspace ctx is scoped to as revoked
+func (r *APIKeyRepository) Revoke(ctx context.Context, id string) error {
+	// IDs are UUIDs; anything else can't name a key
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrAPIKeyNotFound
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL AND ($3::text IS NULL OR workspace_id = $3)`,
+		time.Now(), id, workspaceFilter(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestAuthenticate_ThrottlesLastUsed(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAPIKeyRepository(db)
+	key := &models.APIKey{Name: "throttle test", KeyPrefix: "sk_test", KeyHash: "throttle-test-hash", Scopes: []string{"read"}}
+	if err := repo.Create(ctx, key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	defer db.Exec("DELETE FROM api_keys WHERE id = $1", key.ID)
+
+	lastUsed := func() time.Time {
+		var at time.Time
+		if err := db.QueryRow("SELECT last_used_at FROM api_keys WHERE id = $1", key.ID).Scan(&at); err != nil {
+			t.Fatalf("failed to read last_used_at: %v", err)
+		}
+		return at
+	}
+
+	if got, err := repo.Authenticate(ctx, key.KeyHash); err != nil || got == nil {
+		t.Fatalf("Authenticate = %v, %v", got, err)
+	}
+	first := lastUsed()
+
+	// A second use within the interval doesn't write again
+	if _, err := repo.Authenticate(ctx, key.KeyHash); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if again := lastUsed(); !again.Equal(first) {
+		t.Errorf("last_used_at moved from %v to %v within the interval", first, again)
+	}
+
+	// Once the recorded use is old enough it is updated
+	stale := time.Now().Add(-2 * apiKeyUsageInterval)
+	if _, err := db.Exec("UPDATE api_keys SET last_used_at = $1 WHERE id = $2", stale, key.ID); err != nil {
+		t.Fatalf("failed to age last_used_at: %v", err)
+	}
+	if _, err := repo.Authenticate(ctx, key.KeyHash); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if got := lastUsed(); !got.After(stale.Add(time.Second)) {
+		t.Errorf("expected a stale last_used_at to be refreshed, got %v", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
 )
 
 // ConnectorConfigRepository manages connector configuration in the database.
@@ -50,6 +51,9 @@ func (r *ConnectorConfigRepository) Get(ctx context.Context, connectorID string)
 	if err := json.Unmarshal(configJSON, &config.Config); err != nil {
 		return nil, fmt.Errorf("failed to parse connector config: %w", err)
 	}
+	if err := decryptSettings(config.Config); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
@@ -87,6 +91,9 @@ func (r *ConnectorConfigRepository) GetAll(ctx context.Context) ([]models.Connec
 		if err := json.Unmarshal(configJSON, &config.Config); err != nil {
 			return nil, fmt.Errorf("failed to parse connector config: %w", err)
 		}
+		if err := decryptSettings(config.Config); err != nil {
+			return nil, err
+		}
 
 		configs = append(configs, config)
 	}
@@ -108,8 +115,12 @@ func (r *ConnectorConfigRepository) Update(ctx context.Context, connectorID stri
 	}
 
 	if config != nil {
+		stored, err := encryptSettings(config)
+		if err != nil {
+			return nil, err
+		}
 		argCount++
-		configJSON, err := json.Marshal(config)
+		configJSON, err := json.Marshal(stored)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal config: %w", err)
 		}
@@ -142,10 +153,44 @@ func (r *ConnectorConfigRepository) Update(ctx context.Context, connectorID stri
 	if err := json.Unmarshal(configJSON, &result.Config); err != nil {
 		return nil, fmt.Errorf("failed to parse connector config: %w", err)
 	}
+	if err := decryptSettings(result.Config); err != nil {
+		return nil, err
+	}
 
 	return result, nil
 }
 
+// encryptSettings returns a copy of config with its secret settings encrypted for storage
+func encryptSettings(config map[string]string) (map[string]string, error) {
+	stored := make(map[string]string, len(config))
+	for key, value := range config {
+		if models.IsSecretSetting(key) {
+			encrypted, err := secrets.Encrypt(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt connector setting %s: %w", key, err)
+			}
+			value = encrypted
+		}
+		stored[key] = value
+	}
+	return stored, nil
+}
+
+// decryptSettings decrypts the secret settings of a stored config in place
+func decryptSettings(config map[string]string) error {
+	for key, value := range config {
+		if !models.IsSecretSetting(key) {
+			continue
+		}
+		plaintext, err := secrets.Decrypt(value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt connector setting %s: %w", key, err)
+		}
+		config[key] = plaintext
+	}
+	return nil
+}
+
 // SetEnabled enables or disables a connector.
 func (r *ConnectorConfigRepository) SetEnabled(ctx context.Context, connectorID string, enabled bool) error {
 	query := `
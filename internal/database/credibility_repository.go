@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// CredibilityRepository stores domain credibility scores for the enrichment credibility cache.
+type CredibilityRepository struct {
+	db *sql.DB
+}
+
+// NewCredibilityRepository creates a new credibility repository.
+func NewCredibilityRepository(db *sql.DB) *CredibilityRepository {
+	return &CredibilityRepository{db: db}
+}
+
+// GetCredibility returns a domain's score if it was computed after since, or nil.
+func (r *CredibilityRepository) GetCredibility(ctx context.Context, domain string, since time.Time) (*models.DomainCredibility, error) {
+	query := `
+		SELECT domain, score, computed_at
+		FROM domain_credibility
+		WHERE domain = $1 AND computed_at > $2
+	`
+
+	var c models.DomainCredibility
+	err := r.db.QueryRowContext(ctx, query, domain, since).Scan(&c.Domain, &c.Score, &c.ComputedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain credibility: %w", err)
+	}
+
+	return &c, nil
+}
+
+// SaveCredibility stores a domain's score, replacing any earlier one.
+func (r *CredibilityRepository) SaveCredibility(ctx context.Context, c models.DomainCredibility) error {
+	query := `
+		INSERT INTO domain_credibility (domain, score, computed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (domain) DO UPDATE
+		SET score = EXCLUDED.score,
+		    computed_at = EXCLUDED.computed_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, c.Domain, c.Score, c.ComputedAt); err != nil {
+		return fmt.Errorf("failed to save domain credibility: %w", err)
+	}
+
+	return nil
+}
+
+// ListCredibility returns every score computed after since.
+func (r *CredibilityRepository) ListCredibility(ctx context.Context, since time.Time) ([]models.DomainCredibility, error) {
+	query := `
+		SELECT domain, score, computed_at
+		FROM domain_credibility
+		WHERE computed_at > $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domain credibility: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.DomainCredibility
+	for rows.Next() {
+		var c models.DomainCredibility
+		if err := rows.Scan(&c.Domain, &c.Score, &c.ComputedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan domain credibility: %w", err)
+		}
+		scores = append(scores, c)
+	}
+
+	return scores, rows.Err()
+}
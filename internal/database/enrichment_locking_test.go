@@ -111,7 +111,7 @@ func TestClaimSourcesForEnrichment_StaleReclaim(t *testing.T) {
 		CreatedAt:           time.Now(),
 	}
 
-	if err := repo.Store(ctx, source); err != nil {
+	if _, err := repo.Store(ctx, source); err != nil {
 		t.Fatalf("Failed to create stale source: %v", err)
 	}
 
@@ -131,6 +131,45 @@ func TestClaimSourcesForEnrichment_StaleReclaim(t *testing.T) {
 	}
 }
 
+// TestDeleteByEnrichmentStatus_KeepsLiveClaims verifies cleanup of stuck sources leaves ones a
+// worker claimed recently
+func TestDeleteByEnrichmentStatus_KeepsLiveClaims(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewPostgresSourceRepository(db)
+	ctx := context.Background()
+
+	// Both created a month ago; one claimed by a worker that died, one claimed just now
+	for i, claimedAt := range []time.Time{time.Now().Add(-20 * time.Minute), time.Now()} {
+		_, err := db.Exec(`
+			INSERT INTO sources (
+				id, type, url, published_at, retrieved_at, raw_content, content_hash,
+				credibility, scrape_status, enrichment_status, enrichment_claimed_at, created_at
+			) VALUES ($1, $2, $3, NOW(), NOW(), 'Test content for cleanup', $4, 0.8, $5, $6, $7, NOW() - INTERVAL '30 days')
+		`, fmt.Sprintf("cleanup-source-%d", i), models.SourceTypeNewsMedia, fmt.Sprintf("https://test.com/cleanup-%d", i),
+			fmt.Sprintf("cleanup%d", i), models.ScrapeStatusCompleted, models.EnrichmentStatusEnriching, claimedAt)
+		if err != nil {
+			t.Fatalf("Failed to create source: %v", err)
+		}
+	}
+
+	deleted, err := repo.DeleteByEnrichmentStatus(ctx, models.EnrichmentStatusEnriching, time.Now().Add(-7*24*time.Hour), time.Now().Add(-15*time.Minute))
+	if err != nil {
+		t.Fatalf("DeleteByEnrichmentStatus: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("Expected only the stale claim to be deleted, got %d", deleted)
+	}
+	if source, err := repo.GetByID(ctx, "cleanup-source-1"); err != nil || source == nil {
+		t.Errorf("Expected the freshly claimed source to be kept, got %v, %v", source, err)
+	}
+}
+
 // TestEnrichmentFlow_NoDuplicates tests full enrichment flow for duplicates
 func TestEnrichmentFlow_NoDuplicates(t *testing.T) {
 	if testing.Short() {
@@ -258,7 +297,7 @@ func TestUpdateEnrichmentStatus(t *testing.T) {
 		CreatedAt:        time.Now(),
 	}
 
-	if err := repo.Store(ctx, source); err != nil {
+	if _, err := repo.Store(ctx, source); err != nil {
 		t.Fatalf("Failed to create source: %v", err)
 	}
 
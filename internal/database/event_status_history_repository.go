@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// EventStatusHistoryRepository handles the event status audit trail
+type EventStatusHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewEventStatusHistoryRepository creates a new event status history repository
+func NewEventStatusHistoryRepository(db *sql.DB) *EventStatusHistoryRepository {
+	return &EventStatusHistoryRepository{db: db}
+}
+
+// Record stores a status transition
+func (r *EventStatusHistoryRepository) Record(ctx context.Context, change models.EventStatusChange) error {
+	query := `
+		INSERT INTO event_status_history (event_id, old_status, new_status, actor, reason)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`
+
+	var oldStatus sql.NullString
+	if change.OldStatus != nil {
+		oldStatus = sql.NullString{String: string(*change.OldStatus), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query, change.EventID, oldStatus, change.NewStatus, change.Actor, change.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to record event status change: %w", err)
+	}
+
+	return nil
+}
+
+// ListByEvent returns an event's status transitions, oldest first
+func (r *EventStatusHistoryRepository) ListByEvent(ctx context.Context, eventID string) ([]models.EventStatusChange, error) {
+	query := `
+		SELECT id, event_id, old_status, new_status, actor, reason, created_at
+		FROM event_status_history
+		WHERE event_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event status history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []models.EventStatusChange{}
+	for rows.Next() {
+		var change models.EventStatusChange
+		var oldStatus, reason sql.NullString
+		if err := rows.Scan(&change.ID, &change.EventID, &oldStatus, &change.NewStatus, &change.Actor, &reason, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event status change: %w", err)
+		}
+		if oldStatus.Valid {
+			status := models.EventStatus(oldStatus.String)
+			change.OldStatus = &status
+		}
+		change.Reason = reason.String
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
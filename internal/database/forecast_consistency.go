@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// incompleteRunError is recorded on completed runs found without a result
+const incompleteRunError = "run completed without a result"
+
+// consistencyRule selects the rows one forecast consistency check flags in a table, and the
+// statement that repairs them ("DELETE" or an UPDATE's SET clause).
+type consistencyRule struct {
+	table     string // Table with alias, e.g. "forecast_runs fr"
+	where     string
+	set       string // Empty to delete the rows
+	workspace string // Expression for the row's workspace; empty for orphans, whose forecast is gone
+	result    func(*models.ForecastConsistencyReport) *int64
+}
+
+// liveRun matches a run, referenced as run_id on the rule's alias, that still belongs to a forecast
+func liveRun(alias string) string {
+	return `EXISTS (
+		SELECT 1 FROM forecast_runs fr
+		JOIN forecasts f ON f.id = fr.forecast_id
+		WHERE fr.id = ` + alias + `.run_id
+	)`
+}
+
+// forecastConsistencyRules are applied in order on repair: children go before their parents so
+// the deletes don't trip foreign keys, and completed runs are only checked once orphans are gone.
+var forecastConsistencyRules = []consistencyRule{
+	{
+		table:  "forecast_samples fs",
+		where:  "NOT EXISTS (SELECT 1 FROM forecast_model_responses mr WHERE mr.id = fs.response_id AND " + liveRun("mr") + ")",
+		result: func(r *models.ForecastConsistencyReport) *int64 { return &r.OrphanedSamples },
+	},
+	{
+		table:  "forecast_model_responses mr",
+		where:  "NOT " + liveRun("mr"),
+		result: func(r *models.ForecastConsistencyReport) *int64 { return &r.OrphanedModelResponses },
+	},
+	{
+		table:  "forecast_results res",
+		where:  "NOT " + liveRun("res"),
+		result: func(r *models.ForecastConsistencyReport) *int64 { return &r.OrphanedResults },
+	},
+	{
+		table:  "forecast_runs fr",
+		where:  "NOT EXISTS (SELECT 1 FROM forecasts f WHERE f.id = fr.forecast_id)",
+		result: func(r *models.ForecastConsistencyReport) *int64 { return &r.OrphanedRuns },
+	},
+	{
+		table: "forecast_models fm",
+		where: `NOT EXISTS (SELECT 1 FROM forecasts f WHERE f.id = fm.forecast_id)
+			AND NOT EXISTS (SELECT 1 FROM forecast_model_responses mr WHERE mr.model_id = fm.id AND ` + liveRun("mr") + `)`,
+		result: func(r *models.ForecastConsistencyReport) *int64 { return &r.OrphanedModels },
+	},
+	{
+		table: "forecast_runs fr",
+		where: `fr.status = 'completed'
+			AND EXISTS (SELECT 1 FROM forecasts f WHERE f.id = fr.forecast_id)
+			AND NOT EXISTS (SELECT 1 FROM forecast_results res WHERE res.run_id = fr.id)`,
+		set:       "status = 'failed', error_message = '" + incompleteRunError + "'",
+		workspace: "(SELECT f.workspace_id FROM forecasts f WHERE f.id = fr.forecast_id)",
+		result:    func(r *models.ForecastConsistencyReport) *int64 { return &r.CompletedRunsWithoutResult },
+	},
+}
+
+// scopedWhere returns the rule's condition, limited to the workspace bound to $1 by
+// workspaceFilter for rows that still belong to one. Orphans have no workspace left and are
+// matched in every scope.
+func (rule consistencyRule) scopedWhere() string {
+	if rule.workspace == "" {
+		return rule.where
+	}
+	return fmt.Sprintf("%s AND ($1::text IS NULL OR %s = $1)", rule.where, rule.workspace)
+}
+
+// repairStatement returns the statement that fixes the rows a consistency rule flags, returning
+// each row's ID and workspace
+func (rule consistencyRule) repairStatement() string {
+	alias := strings.Fields(rule.table)[1]
+	workspace := rule.workspace
+	if workspace == "" {
+		workspace = "''"
+	}
+	returning := fmt.Sprintf("RETURNING %s.id, %s", alias, workspace)
+	if rule.set == "" {
+		return fmt.Sprintf("DELETE FROM %s WHERE %s %s", rule.table, rule.scopedWhere(), returning)
+	}
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s %s", rule.table, rule.set, rule.scopedWhere(), returning)
+}
+
+// CheckForecastConsistency counts forecast rows left inconsistent by partial failures or manual
+// deletes, without changing anything. Rows that belong to a forecast are only counted in the
+// workspace ctx is scoped to; orphans, whose forecast is gone, are counted in every workspace.
+func (r *ForecastRepository) CheckForecastConsistency(ctx context.Context) (*models.ForecastConsistencyReport, error) {
+	report := &models.ForecastConsistencyReport{DryRun: true, CheckedAt: time.Now()}
+
+	for _, rule := range forecastConsistencyRules {
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", rule.table, rule.scopedWhere())
+		var args []interface{}
+		if rule.workspace != "" {
+			args = append(args, workspaceFilter(ctx))
+		}
+		if err := r.db.QueryRowContext(ctx, query, args...).Scan(rule.result(report)); err != nil {
+			return nil, fmt.Errorf("failed to check %s consistency: %w", rule.table, err)
+		}
+	}
+
+	return report, nil
+}
+
+// RepairForecastConsistency deletes orphaned forecast rows and marks completed runs without a
+// result in the workspace ctx is scoped to as failed, in a single transaction, reporting how many
+// rows each check fixed and which rows they were.
+func (r *ForecastRepository) RepairForecastConsistency(ctx context.Context) (*models.ForecastConsistencyReport, error) {
+	report := &models.ForecastConsistencyReport{CheckedAt: time.Now()}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, rule := range forecastConsistencyRules {
+		var args []interface{}
+		if rule.workspace != "" {
+			args = append(args, workspaceFilter(ctx))
+		}
+		if err := repairRule(ctx, tx, rule, args, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit forecast repair: %w", err)
+	}
+
+	return report, nil
+}
+
+// repairRule applies one rule's repair, counting and recording the rows it changed
+func repairRule(ctx context.Context, tx *sql.Tx, rule consistencyRule, args []interface{}, report *models.ForecastConsistencyReport) error {
+	rows, err := tx.QueryContext(ctx, rule.repairStatement(), args...)
+	if err != nil {
+		return fmt.Errorf("failed to repair %s: %w", rule.table, err)
+	}
+	defer rows.Close()
+
+	table := strings.Fields(rule.table)[0]
+	count := rule.result(report)
+	for rows.Next() {
+		repaired := models.ForecastConsistencyRepair{Table: table}
+		if err := rows.Scan(&repaired.ID, &repaired.WorkspaceID); err != nil {
+			return fmt.Errorf("failed to scan repaired %s row: %w", table, err)
+		}
+		report.Repaired = append(report.Repaired, repaired)
+		*count++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to repair %s: %w", rule.table, err)
+	}
+	return nil
+}
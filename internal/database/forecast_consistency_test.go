@@ -0,0 +1,58 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestForecastConsistencyRules_RepairOrder(t *testing.T) {
+	// A child table must be repaired before its parent, so deletes never leave rows pointing at
+	// removed parents or trip the foreign keys
+	parents := map[string][]string{
+		"forecast_model_responses": {"forecast_samples"},
+		"forecast_runs":            {"forecast_samples", "forecast_model_responses", "forecast_results"},
+		"forecast_models":          {"forecast_model_responses", "forecast_runs"},
+	}
+	position := make(map[string]int)
+	for i, rule := range forecastConsistencyRules {
+		table := strings.Fields(rule.table)[0]
+		if _, seen := position[table]; !seen {
+			position[table] = i
+		}
+	}
+	for parent, children := range parents {
+		for _, child := range children {
+			if position[child] >= position[parent] {
+				t.Errorf("%s is repaired before %s", parent, child)
+			}
+		}
+	}
+}
+
+func TestForecastConsistencyRules_Statements(t *testing.T) {
+	var report models.ForecastConsistencyReport
+	counted := make(map[*int64]bool)
+	for _, rule := range forecastConsistencyRules {
+		counted[rule.result(&report)] = true
+
+		statement := rule.repairStatement()
+		if rule.set == "" && !strings.HasPrefix(statement, "DELETE FROM "+rule.table+" WHERE ") {
+			t.Errorf("delete rule on %s builds %q", rule.table, statement)
+		}
+		if rule.set != "" && !strings.HasPrefix(statement, "UPDATE "+rule.table+" SET ") {
+			t.Errorf("update rule on %s builds %q", rule.table, statement)
+		}
+		if !strings.Contains(statement, " RETURNING ") {
+			t.Errorf("rule on %s does not return the rows it repairs: %q", rule.table, statement)
+		}
+		// Rows that still belong to a forecast are only repaired in the caller's workspace
+		if scoped := strings.Contains(statement, "$1"); scoped != (rule.workspace != "") {
+			t.Errorf("rule on %s: workspace scoped %v, want %v", rule.table, scoped, rule.workspace != "")
+		}
+	}
+	if len(counted) != len(forecastConsistencyRules) {
+		t.Errorf("%d rules write to %d report fields; each rule needs its own", len(forecastConsistencyRules), len(counted))
+	}
+}
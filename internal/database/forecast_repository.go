@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
@@ -22,7 +24,76 @@ func NewForecastRepository(db *sql.DB) *ForecastRepository {
 	return &ForecastRepository{db: db}
 }
 
-// CreateForecast creates a new forecast with its models
+// forecastColumns is the column list scanned by scanForecast
+const forecastColumns = `id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, disagreement_threshold, alert_webhook_url, timeout_minutes, created_at, updated_at, completion_webhook_url, completion_webhook_secret, completion_webhook_above, completion_webhook_below, headline_selection, include_summaries, explain_result, market_symbol, archived_at, workspace_id, fixed_headlines`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanForecast scans a row selected with forecastColumns into a forecast
+func scanForecast(row rowScanner) (*models.Forecast, error) {
+	var forecast models.Forecast
+	var units, alertWebhookURL, completionWebhookURL, completionWebhookSecret, marketSymbol sql.NullString
+	var fixedHeadlinesJSON []byte
+
+	err := row.Scan(
+		&forecast.ID,
+		&forecast.Name,
+		&forecast.Proposition,
+		&forecast.PredictionType,
+		&units,
+		&forecast.TargetDate,
+		pq.Array(&forecast.Categories),
+		&forecast.HeadlineCount,
+		&forecast.Iterations,
+		pq.Array(&forecast.ContextURLs),
+		&forecast.Active,
+		&forecast.Public,
+		&forecast.DisplayOrder,
+		&forecast.ScheduleEnabled,
+		&forecast.ScheduleInterval,
+		&forecast.LastRunAt,
+		&forecast.NextRunAt,
+		&forecast.DisagreementThreshold,
+		&alertWebhookURL,
+		&forecast.TimeoutMinutes,
+		&forecast.CreatedAt,
+		&forecast.UpdatedAt,
+		&completionWebhookURL,
+		&completionWebhookSecret,
+		&forecast.CompletionWebhookAbove,
+		&forecast.CompletionWebhookBelow,
+		&forecast.HeadlineSelection,
+		&forecast.IncludeSummaries,
+		&forecast.ExplainResult,
+		&marketSymbol,
+		&forecast.ArchivedAt,
+		&forecast.WorkspaceID,
+		&fixedHeadlinesJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fixedHeadlinesJSON != nil {
+		if err := json.Unmarshal(fixedHeadlinesJSON, &forecast.FixedHeadlines); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fixed headlines: %w", err)
+		}
+	}
+
+	forecast.Units = units.String
+	forecast.AlertWebhookURL = alertWebhookURL.String
+	forecast.CompletionWebhookURL = completionWebhookURL.String
+	if forecast.CompletionWebhookSecret, err = secrets.Decrypt(completionWebhookSecret.String); err != nil {
+		return nil, fmt.Errorf("failed to decrypt completion webhook secret of forecast %s: %w", forecast.ID, err)
+	}
+	forecast.MarketSymbol = marketSymbol.String
+
+	return &forecast, nil
+}
+
+// CreateForecast creates a new forecast with its models in the workspace ctx is scoped to
 func (r *ForecastRepository) CreateForecast(ctx context.Context, req models.CreateForecastRequest) (*models.Forecast, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -30,13 +101,22 @@ func (r *ForecastRepository) CreateForecast(ctx context.Context, req models.Crea
 	}
 	defer tx.Rollback()
 
+	fixedHeadlinesJSON, err := marshalFixedHeadlines(req.FixedHeadlines)
+	if err != nil {
+		return nil, err
+	}
+	webhookSecret, err := secrets.Encrypt(req.CompletionWebhookSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt completion webhook secret: %w", err)
+	}
+
 	// Create forecast
 	forecastID := uuid.New().String()
 	now := time.Now()
 
 	query := `
-		INSERT INTO forecasts (id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		INSERT INTO forecasts (id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, schedule_enabled, schedule_interval, last_run_at, next_run_at, disagreement_threshold, alert_webhook_url, timeout_minutes, created_at, updated_at, completion_webhook_url, completion_webhook_secret, completion_webhook_above, completion_webhook_below, headline_selection, include_summaries, explain_result, market_symbol, workspace_id, fixed_headlines)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, NULLIF($21, ''), NULLIF($22, ''), $23, $24, $25, $26, $27, NULLIF($28, ''), $29, $30)
 	`
 
 	iterations := req.Iterations
@@ -44,19 +124,23 @@ func (r *ForecastRepository) CreateForecast(ctx context.Context, req models.Crea
 		iterations = 1
 	}
 
-	_, err = tx.ExecContext(ctx, query, forecastID, req.Name, req.Proposition, req.PredictionType, req.Units, req.TargetDate, pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ContextURLs), true, false, 0, nil, nil, now, now)
+	_, err = tx.ExecContext(ctx, query, forecastID, req.Name, req.Proposition, req.PredictionType, req.Units, req.TargetDate, pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ContextURLs), true, false, 0, nil, nil, req.DisagreementThreshold, req.AlertWebhookURL, req.TimeoutMinutes, now, now, req.CompletionWebhookURL, webhookSecret, req.CompletionWebhookAbove, req.CompletionWebhookBelow, req.HeadlineSelection, req.IncludeSummaries, req.ExplainResult, req.MarketSymbol, writeWorkspace(ctx, ""), fixedHeadlinesJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create forecast: %w", err)
 	}
 
 	// Create forecast models
 	for _, model := range req.Models {
+		apiKey, err := secrets.Encrypt(model.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt api key: %w", err)
+		}
 		modelID := uuid.New().String()
 		modelQuery := `
-			INSERT INTO forecast_models (id, forecast_id, provider, model_name, api_key, weight, active, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			INSERT INTO forecast_models (id, forecast_id, provider, model_name, api_key, weight, active, created_at, base_url, azure_deployment, azure_api_version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		`
-		_, err = tx.ExecContext(ctx, modelQuery, modelID, forecastID, model.Provider, model.ModelName, model.APIKey, model.Weight, true, now)
+		_, err = tx.ExecContext(ctx, modelQuery, modelID, forecastID, model.Provider, model.ModelName, apiKey, model.Weight, true, now, model.BaseURL, model.AzureDeployment, model.AzureAPIVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create forecast model: %w", err)
 		}
@@ -70,7 +154,7 @@ func (r *ForecastRepository) CreateForecast(ctx context.Context, req models.Crea
 	return r.GetForecast(ctx, forecastID)
 }
 
-// UpdateForecast updates an existing forecast
+// UpdateForecast updates an existing forecast in the workspace ctx is scoped to
 func (r *ForecastRepository) UpdateForecast(ctx context.Context, id string, req models.CreateForecastRequest) (*models.Forecast, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -78,13 +162,24 @@ func (r *ForecastRepository) UpdateForecast(ctx context.Context, id string, req
 	}
 	defer tx.Rollback()
 
+	fixedHeadlinesJSON, err := marshalFixedHeadlines(req.FixedHeadlines)
+	if err != nil {
+		return nil, err
+	}
+	webhookSecret, err := secrets.Encrypt(req.CompletionWebhookSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt completion webhook secret: %w", err)
+	}
+
 	now := time.Now()
 
 	// Update forecast (preserve existing schedule settings)
 	query := `
 		UPDATE forecasts
-		SET name = $1, proposition = $2, prediction_type = $3, units = $4, target_date = $5, categories = $6, headline_count = $7, iterations = $8, context_urls = $9, updated_at = $10
-		WHERE id = $11
+		SET name = $1, proposition = $2, prediction_type = $3, units = $4, target_date = $5, categories = $6, headline_count = $7, iterations = $8, context_urls = $9, disagreement_threshold = $10, alert_webhook_url = $11, timeout_minutes = $12, updated_at = $13,
+			completion_webhook_url = NULLIF($15, ''), completion_webhook_secret = NULLIF($16, ''), completion_webhook_above = $17, completion_webhook_below = $18,
+			headline_selection = $19, include_summaries = $20, explain_result = $21, market_symbol = NULLIF($22, ''), fixed_headlines = $24
+		WHERE id = $14 AND ($23::text IS NULL OR workspace_id = $23)
 	`
 
 	iterations := req.Iterations
@@ -92,10 +187,15 @@ func (r *ForecastRepository) UpdateForecast(ctx context.Context, id string, req
 		iterations = 1
 	}
 
-	_, err = tx.ExecContext(ctx, query, req.Name, req.Proposition, req.PredictionType, req.Units, req.TargetDate, pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ContextURLs), now, id)
+	result, err := tx.ExecContext(ctx, query, req.Name, req.Proposition, req.PredictionType, req.Units, req.TargetDate, pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ContextURLs), req.DisagreementThreshold, req.AlertWebhookURL, req.TimeoutMinutes, now, id, req.CompletionWebhookURL, webhookSecret, req.CompletionWebhookAbove, req.CompletionWebhookBelow, req.HeadlineSelection, req.IncludeSummaries, req.ExplainResult, req.MarketSymbol, workspaceFilter(ctx), fixedHeadlinesJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update forecast: %w", err)
 	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rowsAffected == 0 {
+		return nil, fmt.Errorf("forecast not found")
+	}
 
 	// Deactivate existing models
 	_, err = tx.ExecContext(ctx, "UPDATE forecast_models SET active = false WHERE forecast_id = $1", id)
@@ -105,12 +205,16 @@ func (r *ForecastRepository) UpdateForecast(ctx context.Context, id string, req
 
 	// Create new models
 	for _, model := range req.Models {
+		apiKey, err := secrets.Encrypt(model.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt api key: %w", err)
+		}
 		modelID := uuid.New().String()
 		modelQuery := `
-			INSERT INTO forecast_models (id, forecast_id, provider, model_name, api_key, weight, active, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			INSERT INTO forecast_models (id, forecast_id, provider, model_name, api_key, weight, active, created_at, base_url, azure_deployment, azure_api_version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		`
-		_, err = tx.ExecContext(ctx, modelQuery, modelID, id, model.Provider, model.ModelName, model.APIKey, model.Weight, true, now)
+		_, err = tx.ExecContext(ctx, modelQuery, modelID, id, model.Provider, model.ModelName, apiKey, model.Weight, true, now, model.BaseURL, model.AzureDeployment, model.AzureAPIVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create forecast model: %w", err)
 		}
@@ -124,38 +228,26 @@ func (r *ForecastRepository) UpdateForecast(ctx context.Context, id string, req
 	return r.GetForecast(ctx, id)
 }
 
-// GetForecast retrieves a forecast by ID
+// marshalFixedHeadlines encodes a forecast's fixed headlines for storage, as NULL when there are none
+func marshalFixedHeadlines(headlines []models.ForecastHeadline) ([]byte, error) {
+	if len(headlines) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(headlines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fixed headlines: %w", err)
+	}
+	return data, nil
+}
+
+// GetForecast retrieves a forecast by ID from the workspace ctx is scoped to
 func (r *ForecastRepository) GetForecast(ctx context.Context, id string) (*models.Forecast, error) {
-	query := `
-		SELECT id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+	query := `SELECT ` + forecastColumns + `
 		FROM forecasts
-		WHERE id = $1
+		WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2)
 	`
 
-	var forecast models.Forecast
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&forecast.ID,
-		&forecast.Name,
-		&forecast.Proposition,
-		&forecast.PredictionType,
-		&forecast.Units,
-		&forecast.TargetDate,
-		pq.Array(&forecast.Categories),
-		&forecast.HeadlineCount,
-		&forecast.Iterations,
-		pq.Array(&forecast.ContextURLs),
-		&forecast.Active,
-		&forecast.Public,
-		&forecast.DisplayOrder,
-		&forecast.ScheduleEnabled,
-		&forecast.ScheduleInterval,
-		&forecast.LastRunAt,
-		&forecast.NextRunAt,
-		&forecast.CreatedAt,
-		&forecast.UpdatedAt,
-	)
-
+	forecast, err := scanForecast(r.db.QueryRowContext(ctx, query, id, workspaceFilter(ctx)))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -163,18 +255,20 @@ func (r *ForecastRepository) GetForecast(ctx context.Context, id string) (*model
 		return nil, fmt.Errorf("failed to get forecast: %w", err)
 	}
 
-	return &forecast, nil
+	return forecast, nil
 }
 
-// ListForecasts retrieves all forecasts
-func (r *ForecastRepository) ListForecasts(ctx context.Context) ([]models.Forecast, error) {
-	query := `
-		SELECT id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+// ListForecasts retrieves the forecasts in the workspace ctx is scoped to, leaving out archived
+// ones unless includeArchived is set
+func (r *ForecastRepository) ListForecasts(ctx context.Context, includeArchived bool) ([]models.Forecast, error) {
+	query := `SELECT ` + forecastColumns + `
 		FROM forecasts
+		WHERE ($1::text IS NULL OR workspace_id = $1)
+		  AND ($2 OR archived_at IS NULL)
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, workspaceFilter(ctx), includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list forecasts: %w", err)
 	}
@@ -182,40 +276,54 @@ func (r *ForecastRepository) ListForecasts(ctx context.Context) ([]models.Foreca
 
 	var forecasts []models.Forecast
 	for rows.Next() {
-		var forecast models.Forecast
-
-		err := rows.Scan(
-			&forecast.ID,
-			&forecast.Name,
-			&forecast.Proposition,
-			&forecast.PredictionType,
-			&forecast.Units,
-			&forecast.TargetDate,
-			pq.Array(&forecast.Categories),
-			&forecast.HeadlineCount,
-			&forecast.Iterations,
-			pq.Array(&forecast.ContextURLs),
-			&forecast.Active,
-			&forecast.Public,
-			&forecast.DisplayOrder,
-			&forecast.ScheduleEnabled,
-			&forecast.ScheduleInterval,
-			&forecast.LastRunAt,
-			&forecast.NextRunAt,
-			&forecast.CreatedAt,
-			&forecast.UpdatedAt,
-		)
+		forecast, err := scanForecast(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan forecast: %w", err)
 		}
 
-		forecasts = append(forecasts, forecast)
+		forecasts = append(forecasts, *forecast)
 	}
 
 	return forecasts, nil
 }
 
-// DeleteForecast deletes a forecast by ID (manually deletes related records in correct order)
+// ArchiveForecast hides a forecast in the workspace ctx is scoped to from lists, public pages and
+// the scheduler, keeping its history. Archiving an archived forecast is a no-op.
+func (r *ForecastRepository) ArchiveForecast(ctx context.Context, id string) error {
+	return r.setArchived(ctx, id, "COALESCE(archived_at, NOW())")
+}
+
+// RestoreForecast brings back an archived forecast in the workspace ctx is scoped to
+func (r *ForecastRepository) RestoreForecast(ctx context.Context, id string) error {
+	return r.setArchived(ctx, id, "NULL")
+}
+
+// setArchived sets a forecast's archived_at to the given SQL expression
+func (r *ForecastRepository) setArchived(ctx context.Context, id, archivedAt string) error {
+	query := `
+		UPDATE forecasts
+		SET archived_at = ` + archivedAt + `, updated_at = NOW()
+		WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, workspaceFilter(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update forecast archive state: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("forecast not found")
+	}
+
+	return nil
+}
+
+// DeleteForecast permanently deletes a forecast by ID from the workspace ctx is scoped to
+// (manually deletes related records in correct order)
 func (r *ForecastRepository) DeleteForecast(ctx context.Context, id string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -223,6 +331,18 @@ func (r *ForecastRepository) DeleteForecast(ctx context.Context, id string) erro
 	}
 	defer tx.Rollback()
 
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM forecasts WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2))`,
+		id, workspaceFilter(ctx),
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check forecast: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("forecast not found")
+	}
+
 	// Delete forecast_model_responses (references both runs and models)
 	_, err = tx.ExecContext(ctx, `
 		DELETE FROM forecast_model_responses
@@ -275,16 +395,17 @@ func (r *ForecastRepository) DeleteForecast(ctx context.Context, id string) erro
 	return nil
 }
 
-// GetForecastModels retrieves all models for a forecast
+// GetForecastModels retrieves all models for a forecast in the workspace ctx is scoped to
 func (r *ForecastRepository) GetForecastModels(ctx context.Context, forecastID string) ([]models.ForecastModel, error) {
 	query := `
-		SELECT id, forecast_id, provider, model_name, api_key, weight, active, created_at
+		SELECT id, forecast_id, provider, model_name, api_key, weight, active, created_at,
+		       base_url, azure_deployment, azure_api_version
 		FROM forecast_models
-		WHERE forecast_id = $1 AND active = true
+		WHERE forecast_id = $1 AND active = true AND ` + forecastInWorkspace("forecast_id", 2) + `
 		ORDER BY created_at
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, forecastID)
+	rows, err := r.db.QueryContext(ctx, query, forecastID, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get forecast models: %w", err)
 	}
@@ -302,17 +423,24 @@ func (r *ForecastRepository) GetForecastModels(ctx context.Context, forecastID s
 			&model.Weight,
 			&model.Active,
 			&model.CreatedAt,
+			&model.BaseURL,
+			&model.AzureDeployment,
+			&model.AzureAPIVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan forecast model: %w", err)
 		}
+		if model.APIKey, err = secrets.Decrypt(model.APIKey); err != nil {
+			return nil, fmt.Errorf("failed to decrypt api key of forecast model %s: %w", model.ID, err)
+		}
 		forecastModels = append(forecastModels, model)
 	}
 
 	return forecastModels, nil
 }
 
-// CreateForecastRun creates a new forecast run
+// CreateForecastRun creates a new forecast run. It returns models.ErrForecastRunActive if the
+// forecast already has a run in progress.
 func (r *ForecastRepository) CreateForecastRun(ctx context.Context, forecastID string, headlines []models.ForecastHeadline) (string, error) {
 	runID := uuid.New().String()
 	now := time.Now()
@@ -328,6 +456,9 @@ func (r *ForecastRepository) CreateForecastRun(ctx context.Context, forecastID s
 	`
 
 	_, err = r.db.ExecContext(ctx, query, runID, forecastID, now, len(headlines), headlinesJSON, "pending")
+	if isUniqueViolation(err, "idx_forecast_runs_one_in_progress") {
+		return "", fmt.Errorf("%w: forecast %s", models.ErrForecastRunActive, forecastID)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to create forecast run: %w", err)
 	}
@@ -353,6 +484,66 @@ func (r *ForecastRepository) UpdateForecastRunStatus(ctx context.Context, runID,
 	return err
 }
 
+// TouchForecastRun records that the run is still executing. Finished runs are left alone.
+func (r *ForecastRepository) TouchForecastRun(ctx context.Context, runID string) error {
+	query := `
+		UPDATE forecast_runs
+		SET heartbeat_at = $1
+		WHERE id = $2 AND status IN ('pending', 'running')
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), runID); err != nil {
+		return fmt.Errorf("failed to touch forecast run: %w", err)
+	}
+	return nil
+}
+
+// FailStaleForecastRun marks an in-progress run failed with errorMsg, but only if its last
+// heartbeat (or its start, if it never sent one) is before staleBefore. It returns false when the
+// run is still alive or no longer in progress, so a run executing on another instance is left alone.
+func (r *ForecastRepository) FailStaleForecastRun(ctx context.Context, runID, errorMsg string, staleBefore time.Time) (bool, error) {
+	query := `
+		UPDATE forecast_runs
+		SET status = 'failed', error_message = $1, completed_at = $2
+		WHERE id = $3
+		  AND status IN ('pending', 'running')
+		  AND COALESCE(heartbeat_at, run_at) < $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, errorMsg, time.Now(), runID, staleBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to fail stale forecast run: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to fail stale forecast run: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// FailStaleForecastRuns marks a forecast's in-progress runs failed with errorMsg if their last
+// heartbeat (or start) is before staleBefore, so a run abandoned by a restart doesn't keep the
+// forecast from starting another. It returns the number of runs failed.
+func (r *ForecastRepository) FailStaleForecastRuns(ctx context.Context, forecastID, errorMsg string, staleBefore time.Time) (int64, error) {
+	query := `
+		UPDATE forecast_runs
+		SET status = 'failed', error_message = $1, completed_at = $2
+		WHERE forecast_id = $3
+		  AND status IN ('pending', 'running')
+		  AND COALESCE(heartbeat_at, run_at) < $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, errorMsg, time.Now(), forecastID, staleBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale forecast runs: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale forecast runs: %w", err)
+	}
+	return affected, nil
+}
+
 // CreateModelResponse creates a model response
 func (r *ForecastRepository) CreateModelResponse(ctx context.Context, response models.ForecastModelResponse) error {
 	if response.ID == "" {
@@ -377,20 +568,100 @@ func (r *ForecastRepository) CreateModelResponse(ctx context.Context, response m
 
 	query := `
 		INSERT INTO forecast_model_responses (
-			id, run_id, model_id, provider, model_name, percentile_predictions, reasoning,
+			id, run_id, model_id, provider, model_name, percentile_predictions, probability, reasoning,
 			raw_response, tokens_used, response_time_ms, status, error_message, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
 		response.ID, response.RunID, response.ModelID, response.Provider, response.ModelName,
-		percentilesJSON, response.Reasoning, rawResponseJSON, response.TokensUsed,
+		percentilesJSON, response.Probability, response.Reasoning, rawResponseJSON, response.TokensUsed,
 		response.ResponseTimeMs, response.Status, response.ErrorMessage, response.CreatedAt,
 	)
 
 	return err
 }
 
+// CreateForecastSamples stores the individual samples behind a model response in one transaction
+func (r *ForecastRepository) CreateForecastSamples(ctx context.Context, samples []models.ForecastSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO forecast_samples (response_id, run_id, model_id, sample_index, p10, p25, p50, p75, p90, value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare forecast sample insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, s := range samples {
+		var p10, p25, p50, p75, p90 *float64
+		if s.Percentiles != nil {
+			p10, p25, p50, p75, p90 = &s.Percentiles.P10, &s.Percentiles.P25, &s.Percentiles.P50, &s.Percentiles.P75, &s.Percentiles.P90
+		}
+		_, err := stmt.ExecContext(ctx, s.ResponseID, s.RunID, s.ModelID, s.SampleIndex, p10, p25, p50, p75, p90, s.Value)
+		if err != nil {
+			return fmt.Errorf("failed to insert forecast sample: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit forecast samples: %w", err)
+	}
+
+	return nil
+}
+
+// GetForecastSamples returns the stored samples of a run, by model then sample order
+func (r *ForecastRepository) GetForecastSamples(ctx context.Context, runID string) ([]models.ForecastSample, error) {
+	query := `
+		SELECT response_id, run_id, model_id, sample_index, p10, p25, p50, p75, p90, value, created_at
+		FROM forecast_samples
+		WHERE run_id = $1
+		ORDER BY model_id, sample_index
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecast samples: %w", err)
+	}
+	defer rows.Close()
+
+	samples := []models.ForecastSample{}
+	for rows.Next() {
+		var s models.ForecastSample
+		var p10, p25, p50, p75, p90, value sql.NullFloat64
+		err := rows.Scan(&s.ResponseID, &s.RunID, &s.ModelID, &s.SampleIndex, &p10, &p25, &p50, &p75, &p90, &value, &s.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan forecast sample: %w", err)
+		}
+		if p50.Valid {
+			s.Percentiles = &models.PercentilePredictions{
+				P10: p10.Float64, P25: p25.Float64, P50: p50.Float64, P75: p75.Float64, P90: p90.Float64,
+			}
+		}
+		if value.Valid {
+			s.Value = &value.Float64
+		}
+		samples = append(samples, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating forecast samples: %w", err)
+	}
+
+	return samples, nil
+}
+
 // CreateForecastResult creates a forecast result
 func (r *ForecastRepository) CreateForecastResult(ctx context.Context, result models.ForecastResult) error {
 	if result.ID == "" {
@@ -411,14 +682,15 @@ func (r *ForecastRepository) CreateForecastResult(ctx context.Context, result mo
 
 	query := `
 		INSERT INTO forecast_results (
-			id, run_id, aggregated_percentiles, aggregated_point_estimate,
-			model_count, consensus_level, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			id, run_id, aggregated_percentiles, aggregated_point_estimate, aggregated_probability,
+			model_count, consensus_level, explanation, created_at, headlines_widened, sparse_headlines
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11)
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
-		result.ID, result.RunID, percentilesJSON, result.AggregatedPointEstimate,
-		result.ModelCount, result.ConsensusLevel, result.CreatedAt,
+		result.ID, result.RunID, percentilesJSON, result.AggregatedPointEstimate, result.AggregatedProbability,
+		result.ModelCount, result.ConsensusLevel, result.Explanation, result.CreatedAt,
+		result.HeadlinesWidened, result.SparseHeadlines,
 	)
 
 	return err
@@ -426,11 +698,11 @@ func (r *ForecastRepository) CreateForecastResult(ctx context.Context, result mo
 
 // GetForecastRun retrieves a forecast run with all details
 func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (*models.ForecastRunDetail, error) {
-	// Get run
+	// Get run; runs of forecasts in other workspaces are not found
 	runQuery := `
 		SELECT id, forecast_id, run_at, headline_count, headlines_snapshot, status, error_message, completed_at
 		FROM forecast_runs
-		WHERE id = $1
+		WHERE id = $1 AND ` + forecastInWorkspace("forecast_id", 2) + `
 	`
 
 	var run models.ForecastRun
@@ -438,7 +710,7 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 	var errorMsg sql.NullString
 	var completedAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, runQuery, runID).Scan(
+	err := r.db.QueryRowContext(ctx, runQuery, runID, workspaceFilter(ctx)).Scan(
 		&run.ID, &run.ForecastID, &run.RunAt, &run.HeadlineCount,
 		&headlinesJSON, &run.Status, &errorMsg, &completedAt,
 	)
@@ -463,7 +735,7 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 
 	// Get responses
 	responsesQuery := `
-		SELECT id, run_id, model_id, provider, model_name, percentile_predictions, point_estimate,
+		SELECT id, run_id, model_id, provider, model_name, percentile_predictions, point_estimate, probability,
 		       reasoning, raw_response, tokens_used, response_time_ms, status, error_message, created_at
 		FROM forecast_model_responses
 		WHERE run_id = $1
@@ -480,14 +752,14 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 	for rows.Next() {
 		var resp models.ForecastModelResponse
 		var percentilesJSON []byte
-		var pointEstimate sql.NullFloat64
+		var pointEstimate, probability sql.NullFloat64
 		var tokensUsed, responseTime sql.NullInt64
 		var rawResponseJSON []byte
 		var errMsg sql.NullString
 
 		err := rows.Scan(
 			&resp.ID, &resp.RunID, &resp.ModelID, &resp.Provider, &resp.ModelName,
-			&percentilesJSON, &pointEstimate, &resp.Reasoning, &rawResponseJSON,
+			&percentilesJSON, &pointEstimate, &probability, &resp.Reasoning, &rawResponseJSON,
 			&tokensUsed, &responseTime, &resp.Status, &errMsg, &resp.CreatedAt,
 		)
 		if err != nil {
@@ -504,6 +776,9 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 		if pointEstimate.Valid {
 			resp.PointEstimate = &pointEstimate.Float64
 		}
+		if probability.Valid {
+			resp.Probability = &probability.Float64
+		}
 		if tokensUsed.Valid {
 			tokens := int(tokensUsed.Int64)
 			resp.TokensUsed = &tokens
@@ -527,8 +802,9 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 
 	// Get result
 	resultQuery := `
-		SELECT id, run_id, aggregated_percentiles, aggregated_point_estimate,
-		       model_count, consensus_level, created_at
+		SELECT id, run_id, aggregated_percentiles, aggregated_point_estimate, aggregated_probability,
+		       model_count, consensus_level, COALESCE(explanation, ''), created_at,
+		       headlines_widened, sparse_headlines
 		FROM forecast_results
 		WHERE run_id = $1
 	`
@@ -536,11 +812,13 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 	var result models.ForecastResult
 	var percentilesJSON []byte
 	var pointEstimate sql.NullFloat64
+	var probability sql.NullFloat64
 	var consensus sql.NullFloat64
 
 	err = r.db.QueryRowContext(ctx, resultQuery, runID).Scan(
-		&result.ID, &result.RunID, &percentilesJSON, &pointEstimate,
-		&result.ModelCount, &consensus, &result.CreatedAt,
+		&result.ID, &result.RunID, &percentilesJSON, &pointEstimate, &probability,
+		&result.ModelCount, &consensus, &result.Explanation, &result.CreatedAt,
+		&result.HeadlinesWidened, &result.SparseHeadlines,
 	)
 
 	if err != nil && err != sql.ErrNoRows {
@@ -559,6 +837,9 @@ func (r *ForecastRepository) GetForecastRun(ctx context.Context, runID string) (
 		if pointEstimate.Valid {
 			result.AggregatedPointEstimate = &pointEstimate.Float64
 		}
+		if probability.Valid {
+			result.AggregatedProbability = &probability.Float64
+		}
 		if consensus.Valid {
 			result.ConsensusLevel = &consensus.Float64
 		}
@@ -579,13 +860,13 @@ func (r *ForecastRepository) GetLatestCompletedForecastRun(ctx context.Context,
 		SELECT fr.id
 		FROM forecast_runs fr
 		INNER JOIN forecast_results res ON res.run_id = fr.id
-		WHERE fr.forecast_id = $1 AND fr.status = 'completed'
+		WHERE fr.forecast_id = $1 AND fr.status = 'completed' AND ` + forecastInWorkspace("fr.forecast_id", 2) + `
 		ORDER BY fr.run_at DESC
 		LIMIT 1
 	`
 
 	var runID string
-	err := r.db.QueryRowContext(ctx, runQuery, forecastID).Scan(&runID)
+	err := r.db.QueryRowContext(ctx, runQuery, forecastID, workspaceFilter(ctx)).Scan(&runID)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -608,12 +889,12 @@ func (r *ForecastRepository) GetLatestNCompletedForecastRuns(ctx context.Context
 		SELECT fr.id
 		FROM forecast_runs fr
 		INNER JOIN forecast_results res ON res.run_id = fr.id
-		WHERE fr.forecast_id = $1 AND fr.status = 'completed'
+		WHERE fr.forecast_id = $1 AND fr.status = 'completed' AND ` + forecastInWorkspace("fr.forecast_id", 3) + `
 		ORDER BY fr.run_at DESC
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, runQuery, forecastID, n)
+	rows, err := r.db.QueryContext(ctx, runQuery, forecastID, n, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest completed runs: %w", err)
 	}
@@ -647,19 +928,35 @@ func (r *ForecastRepository) GetLatestNCompletedForecastRuns(ctx context.Context
 	return runs, nil
 }
 
-// ListForecastRuns lists all runs for a forecast
+// ListForecastRuns lists the newest limit runs for a forecast
 func (r *ForecastRepository) ListForecastRuns(ctx context.Context, forecastID string, limit int) ([]models.ForecastRun, error) {
+	runs, _, err := r.ListForecastRunsPage(ctx, forecastID, limit, 0)
+	return runs, err
+}
+
+// ListForecastRunsPage lists a page of a forecast's runs, newest first, skipping the newest
+// offset runs, and returns the forecast's total number of runs
+func (r *ForecastRepository) ListForecastRunsPage(ctx context.Context, forecastID string, limit, offset int) ([]models.ForecastRun, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM forecast_runs WHERE forecast_id = $1 AND `+forecastInWorkspace("forecast_id", 2),
+		forecastID, workspaceFilter(ctx),
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count forecast runs: %w", err)
+	}
+
 	query := `
 		SELECT id, forecast_id, run_at, headline_count, status, error_message, completed_at
 		FROM forecast_runs
-		WHERE forecast_id = $1
-		ORDER BY run_at DESC
-		LIMIT $2
+		WHERE forecast_id = $1 AND ` + forecastInWorkspace("forecast_id", 4) + `
+		ORDER BY run_at DESC, id
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, forecastID, limit)
+	rows, err := r.db.QueryContext(ctx, query, forecastID, limit, offset, workspaceFilter(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list forecast runs: %w", err)
+		return nil, 0, fmt.Errorf("failed to list forecast runs: %w", err)
 	}
 	defer rows.Close()
 
@@ -674,7 +971,7 @@ func (r *ForecastRepository) ListForecastRuns(ctx context.Context, forecastID st
 			&run.Status, &errorMsg, &completedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan forecast run: %w", err)
+			return nil, 0, fmt.Errorf("failed to scan forecast run: %w", err)
 		}
 
 		if errorMsg.Valid {
@@ -687,7 +984,102 @@ func (r *ForecastRepository) ListForecastRuns(ctx context.Context, forecastID st
 		runs = append(runs, run)
 	}
 
-	return runs, nil
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating forecast runs: %w", err)
+	}
+
+	return runs, total, nil
+}
+
+// GetActiveForecastRun returns the latest pending or running run of a forecast started at or
+// after since, or nil if there is none. Older unfinished runs are assumed abandoned.
+func (r *ForecastRepository) GetActiveForecastRun(ctx context.Context, forecastID string, since time.Time) (*models.ForecastRun, error) {
+	query := `
+		SELECT id, forecast_id, run_at, headline_count, status
+		FROM forecast_runs
+		WHERE forecast_id = $1
+		  AND status IN ('pending', 'running')
+		  AND run_at >= $2
+		ORDER BY run_at DESC
+		LIMIT 1
+	`
+
+	var run models.ForecastRun
+	err := r.db.QueryRowContext(ctx, query, forecastID, since).Scan(
+		&run.ID, &run.ForecastID, &run.RunAt, &run.HeadlineCount, &run.Status,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active forecast run: %w", err)
+	}
+
+	return &run, nil
+}
+
+// ReserveIdempotencyKey claims an idempotency key for a forecast before its run starts, after
+// deleting keys created before expiredBefore. It returns reserved if this call now holds the key;
+// the caller must then record the run with SetIdempotentRun or free the key with
+// ReleaseIdempotencyKey. Otherwise it returns the run the key started, or "" while the request
+// holding the key has not started it yet. A reservation left without a run since before
+// staleBefore, e.g. by a restart, is taken over.
+func (r *ForecastRepository) ReserveIdempotencyKey(ctx context.Context, forecastID, key string, expiredBefore, staleBefore time.Time) (runID string, reserved bool, err error) {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM forecast_idempotency_keys WHERE created_at < $1`, expiredBefore); err != nil {
+		return "", false, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO forecast_idempotency_keys (forecast_id, idempotency_key, run_id, created_at)
+		VALUES ($1, $2, NULL, NOW())
+		ON CONFLICT (forecast_id, idempotency_key) DO UPDATE SET created_at = NOW()
+		WHERE forecast_idempotency_keys.run_id IS NULL AND forecast_idempotency_keys.created_at < $3
+	`, forecastID, key, staleBefore)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows > 0 {
+		return "", true, nil
+	}
+
+	var existing sql.NullString
+	err = r.db.QueryRowContext(ctx, `
+		SELECT run_id FROM forecast_idempotency_keys
+		WHERE forecast_id = $1 AND idempotency_key = $2
+	`, forecastID, key).Scan(&existing)
+	if err == sql.ErrNoRows {
+		// Released since the insert; the next attempt can reserve it
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get idempotent run: %w", err)
+	}
+	return existing.String, false, nil
+}
+
+// SetIdempotentRun records the run started with a reserved idempotency key
+func (r *ForecastRepository) SetIdempotentRun(ctx context.Context, forecastID, key, runID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE forecast_idempotency_keys SET run_id = $3
+		WHERE forecast_id = $1 AND idempotency_key = $2
+	`, forecastID, key, runID)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey frees a reserved idempotency key whose run did not start, so a retry can
+// start it
+func (r *ForecastRepository) ReleaseIdempotencyKey(ctx context.Context, forecastID, key string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM forecast_idempotency_keys
+		WHERE forecast_id = $1 AND idempotency_key = $2 AND run_id IS NULL
+	`, forecastID, key)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
 }
 
 // GetForecastHistory retrieves completed runs with results for a forecast (optimized for charting)
@@ -696,14 +1088,14 @@ func (r *ForecastRepository) GetForecastHistory(ctx context.Context, forecastID
 	query := `
 		SELECT
 			fr.id, fr.forecast_id, fr.run_at, fr.headline_count, fr.status, fr.error_message, fr.completed_at,
-			fres.id, fres.aggregated_percentiles, fres.aggregated_point_estimate, fres.model_count, fres.consensus_level
+			fres.id, fres.aggregated_percentiles, fres.aggregated_point_estimate, fres.aggregated_probability, fres.model_count, fres.consensus_level
 		FROM forecast_runs fr
 		LEFT JOIN forecast_results fres ON fr.id = fres.run_id
-		WHERE fr.forecast_id = $1 AND fr.status = 'completed'
+		WHERE fr.forecast_id = $1 AND fr.status = 'completed' AND ` + forecastInWorkspace("fr.forecast_id", 2) + `
 		ORDER BY fr.run_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, forecastID)
+	rows, err := r.db.QueryContext(ctx, query, forecastID, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get forecast history: %w", err)
 	}
@@ -718,13 +1110,14 @@ func (r *ForecastRepository) GetForecastHistory(ctx context.Context, forecastID
 		var resultID sql.NullString
 		var percentilesJSON []byte
 		var pointEstimate sql.NullFloat64
+		var probability sql.NullFloat64
 		var modelCount sql.NullInt64
 		var consensus sql.NullFloat64
 
 		err := rows.Scan(
 			&run.ID, &run.ForecastID, &run.RunAt, &run.HeadlineCount,
 			&run.Status, &errorMsg, &completedAt,
-			&resultID, &percentilesJSON, &pointEstimate, &modelCount, &consensus,
+			&resultID, &percentilesJSON, &pointEstimate, &probability, &modelCount, &consensus,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan forecast history: %w", err)
@@ -754,6 +1147,9 @@ func (r *ForecastRepository) GetForecastHistory(ctx context.Context, forecastID
 			if pointEstimate.Valid {
 				result.AggregatedPointEstimate = &pointEstimate.Float64
 			}
+			if probability.Valid {
+				result.AggregatedProbability = &probability.Float64
+			}
 			if modelCount.Valid {
 				result.ModelCount = int(modelCount.Int64)
 			}
@@ -774,105 +1170,263 @@ func (r *ForecastRepository) GetForecastHistory(ctx context.Context, forecastID
 	return history, nil
 }
 
-// DailyOHLC represents OHLC data for a single day
-type DailyOHLC struct {
-	Date  string  `json:"date"`
-	Open  float64 `json:"open"`
-	High  float64 `json:"high"`
-	Low   float64 `json:"low"`
-	Close float64 `json:"close"`
-}
-
-// GetForecastHistoryDaily returns P50 values aggregated into daily OHLC bars
-func (r *ForecastRepository) GetForecastHistoryDaily(ctx context.Context, forecastID string) ([]DailyOHLC, error) {
-	// Aggregate P50 values by day using window functions
+// GetCompletedModelResponses returns the completed model responses of a forecast's completed
+// runs, keyed by run ID and ordered by when each response was stored. Only the model ID and
+// predictions are loaded.
+func (r *ForecastRepository) GetCompletedModelResponses(ctx context.Context, forecastID string) (map[string][]models.ForecastModelResponse, error) {
 	query := `
-		WITH daily_p50 AS (
-			SELECT
-				DATE(fr.run_at) as date,
-				(fres.aggregated_percentiles->>'p50')::float as p50,
-				fr.run_at,
-				ROW_NUMBER() OVER (PARTITION BY DATE(fr.run_at) ORDER BY fr.run_at ASC) as first_run,
-				ROW_NUMBER() OVER (PARTITION BY DATE(fr.run_at) ORDER BY fr.run_at DESC) as last_run
-			FROM forecast_runs fr
-			INNER JOIN forecast_results fres ON fr.id = fres.run_id
-			WHERE fr.forecast_id = $1
-				AND fr.status = 'completed'
-				AND fres.aggregated_percentiles IS NOT NULL
-		)
-		SELECT
-			date::text,
-			MAX(CASE WHEN first_run = 1 THEN p50 END) as open,
-			MAX(p50) as high,
-			MIN(p50) as low,
-			MAX(CASE WHEN last_run = 1 THEN p50 END) as close
-		FROM daily_p50
-		GROUP BY date
-		ORDER BY date ASC
+		SELECT mr.run_id, mr.model_id, mr.percentile_predictions, mr.point_estimate, mr.probability
+		FROM forecast_model_responses mr
+		INNER JOIN forecast_runs fr ON fr.id = mr.run_id
+		WHERE fr.forecast_id = $1 AND fr.status = 'completed' AND mr.status = 'completed'
+		  AND ` + forecastInWorkspace("fr.forecast_id", 2) + `
+		ORDER BY fr.run_at ASC, mr.created_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, forecastID)
+	rows, err := r.db.QueryContext(ctx, query, forecastID, workspaceFilter(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get daily OHLC: %w", err)
+		return nil, fmt.Errorf("failed to get model responses: %w", err)
 	}
 	defer rows.Close()
 
-	var ohlcData []DailyOHLC
+	responses := make(map[string][]models.ForecastModelResponse)
 	for rows.Next() {
-		var ohlc DailyOHLC
-		var open, close sql.NullFloat64
+		var resp models.ForecastModelResponse
+		var percentilesJSON []byte
+		var pointEstimate, probability sql.NullFloat64
 
-		err := rows.Scan(&ohlc.Date, &open, &ohlc.High, &ohlc.Low, &close)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan OHLC data: %w", err)
+		if err := rows.Scan(&resp.RunID, &resp.ModelID, &percentilesJSON, &pointEstimate, &probability); err != nil {
+			return nil, fmt.Errorf("failed to scan model response: %w", err)
 		}
 
-		// Handle NULL for open/close (shouldn't happen but be safe)
-		if open.Valid {
-			ohlc.Open = open.Float64
+		if len(percentilesJSON) > 0 {
+			var percentiles models.PercentilePredictions
+			if err := json.Unmarshal(percentilesJSON, &percentiles); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal percentile predictions: %w", err)
+			}
+			resp.PercentilePredictions = &percentiles
 		}
-		if close.Valid {
-			ohlc.Close = close.Float64
+		if pointEstimate.Valid {
+			resp.PointEstimate = &pointEstimate.Float64
+		}
+		if probability.Valid {
+			resp.Probability = &probability.Float64
 		}
+		resp.Status = "completed"
 
-		ohlcData = append(ohlcData, ohlc)
+		responses[resp.RunID] = append(responses[resp.RunID], resp)
 	}
 
-	return ohlcData, nil
+	return responses, rows.Err()
 }
 
-// GetForecastHistory4Hour returns P50 values aggregated into 4-hour OHLC bars
-func (r *ForecastRepository) GetForecastHistory4Hour(ctx context.Context, forecastID string) ([]DailyOHLC, error) {
-	// Aggregate P50 values by 4-hour intervals using window functions
-	// 14400 seconds = 4 hours
+// StreamForecastHistory calls fn for every completed run of a forecast that has a result,
+// oldest first, without loading the whole history into memory. It stops at the first error
+// fn returns.
+func (r *ForecastRepository) StreamForecastHistory(ctx context.Context, forecastID string, fn func(models.ForecastHistoryPoint) error) error {
 	query := `
-		WITH bucketed_p50 AS (
+		SELECT
+			fr.id, fr.run_at, fr.completed_at, fr.headline_count,
+			(fres.aggregated_percentiles->>'p10')::float,
+			(fres.aggregated_percentiles->>'p25')::float,
+			(fres.aggregated_percentiles->>'p50')::float,
+			(fres.aggregated_percentiles->>'p75')::float,
+			(fres.aggregated_percentiles->>'p90')::float,
+			fres.aggregated_point_estimate, fres.aggregated_probability, fres.model_count, fres.consensus_level
+		FROM forecast_runs fr
+		INNER JOIN forecast_results fres ON fr.id = fres.run_id
+		WHERE fr.forecast_id = $1 AND fr.status = 'completed' AND ` + forecastInWorkspace("fr.forecast_id", 2) + `
+		ORDER BY fr.run_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, forecastID, workspaceFilter(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to query forecast history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var point models.ForecastHistoryPoint
+		var completedAt sql.NullTime
+		if err := rows.Scan(
+			&point.RunID, &point.RunAt, &completedAt, &point.HeadlineCount,
+			&point.P10, &point.P25, &point.P50, &point.P75, &point.P90,
+			&point.PointEstimate, &point.Probability, &point.ModelCount, &point.ConsensusLevel,
+		); err != nil {
+			return fmt.Errorf("failed to scan forecast history: %w", err)
+		}
+		if completedAt.Valid {
+			point.CompletedAt = &completedAt.Time
+		}
+		if err := fn(point); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// epochBucket returns a SQL expression that floors a timestamp column to fixed-length buckets
+func epochBucket(column string, seconds int) string {
+	return fmt.Sprintf("to_timestamp(floor(extract(epoch from %s) / %d) * %d)", column, seconds, seconds)
+}
+
+// DailyOHLC represents OHLC data for a single bar (a day or a 4-hour interval)
+type DailyOHLC struct {
+	Date  string  `json:"date"`
+	Open  float64 `json:"open"`
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+}
+
+// OHLCInterval is the length of the bars forecast history is aggregated into
+type OHLCInterval string
+
+// Supported OHLC bar lengths
+const (
+	OHLCHourly   OHLCInterval = "hourly"
+	OHLCFourHour OHLCInterval = "4h"
+	OHLCDaily    OHLCInterval = "daily"
+	OHLCWeekly   OHLCInterval = "weekly"
+)
+
+// Bounds of arbitrary bar lengths for GetForecastHistoryBucketed, and the most bars any OHLC
+// query returns; longer histories keep their most recent bars
+const (
+	MinBucketSeconds = 300
+	MaxBucketSeconds = 90 * 24 * 3600
+	MaxOHLCBuckets   = 1000
+)
+
+// ohlcBucket groups completed runs into bars. expr assigns a run to its bar; label renders the
+// bar (aliased bucket) as DailyOHLC.Date.
+type ohlcBucket struct {
+	expr  string
+	label string
+}
+
+// fixedBucket groups runs into bars of the given length, labeled with their start in Unix seconds
+func fixedBucket(seconds int) ohlcBucket {
+	return ohlcBucket{expr: epochBucket("fr.run_at", seconds), label: "EXTRACT(EPOCH FROM bucket)::bigint::text"}
+}
+
+// ohlcIntervals are the named bar lengths. Daily and weekly bars follow the calendar (weeks start
+// on Monday) and are labeled with their first day as YYYY-MM-DD.
+var ohlcIntervals = map[OHLCInterval]ohlcBucket{
+	OHLCHourly:   fixedBucket(3600),
+	OHLCFourHour: fixedBucket(14400),
+	OHLCDaily:    {expr: "DATE(fr.run_at)", label: "bucket::text"},
+	OHLCWeekly:   {expr: "DATE(date_trunc('week', fr.run_at))", label: "bucket::text"},
+}
+
+// ValidOHLCInterval reports whether interval is a named bar length
+func ValidOHLCInterval(interval OHLCInterval) bool {
+	_, ok := ohlcIntervals[interval]
+	return ok
+}
+
+// DefaultForecastMetric is the series charted when none is requested
+const DefaultForecastMetric = "p50"
+
+// forecastMetrics are the numeric series of forecast results that can be aggregated into OHLC
+// bars, as SQL expressions over forecast_results (aliased fres). Runs where the expression is
+// NULL, e.g. percentiles of a probability forecast, are left out of the bars.
+var forecastMetrics = map[string]string{
+	"p10":            "(fres.aggregated_percentiles->>'p10')::float",
+	"p25":            "(fres.aggregated_percentiles->>'p25')::float",
+	"p50":            "(fres.aggregated_percentiles->>'p50')::float",
+	"p75":            "(fres.aggregated_percentiles->>'p75')::float",
+	"p90":            "(fres.aggregated_percentiles->>'p90')::float",
+	"point_estimate": "fres.aggregated_point_estimate",
+	"probability":    "fres.aggregated_probability",
+	"consensus":      "fres.consensus_level",
+	// Width of the 80% interval
+	"spread": "(fres.aggregated_percentiles->>'p90')::float - (fres.aggregated_percentiles->>'p10')::float",
+	// Headline value: P50, point estimate or probability depending on prediction type
+	"value": "COALESCE((fres.aggregated_percentiles->>'p50')::float, fres.aggregated_point_estimate, fres.aggregated_probability)",
+}
+
+// ForecastMetricNames returns the metrics GetForecastOHLC accepts, sorted
+func ForecastMetricNames() []string {
+	names := make([]string, 0, len(forecastMetrics))
+	for name := range forecastMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidForecastMetric reports whether metric can be aggregated by GetForecastOHLC
+func ValidForecastMetric(metric string) bool {
+	_, ok := forecastMetrics[metric]
+	return ok
+}
+
+// forecastOHLCQuery builds the query aggregating a metric of a forecast's completed runs into
+// bars: open and close are the first and last run's values in the bar, high and low the extremes.
+// It returns the newest $2 bars, newest first, if the forecast is in the workspace bound to $3.
+func forecastOHLCQuery(metric string, bucket ohlcBucket) (string, error) {
+	value, ok := forecastMetrics[metric]
+	if !ok {
+		return "", fmt.Errorf("unknown forecast metric: %s", metric)
+	}
+
+	return `
+		WITH metric_values AS (
 			SELECT
-				to_timestamp(floor((extract(epoch from fr.run_at) / 14400)) * 14400) as bucket,
-				(fres.aggregated_percentiles->>'p50')::float as p50,
-				fr.run_at,
-				ROW_NUMBER() OVER (PARTITION BY floor((extract(epoch from fr.run_at) / 14400)) ORDER BY fr.run_at ASC) as first_run,
-				ROW_NUMBER() OVER (PARTITION BY floor((extract(epoch from fr.run_at) / 14400)) ORDER BY fr.run_at DESC) as last_run
+				` + bucket.expr + ` as bucket,
+				` + value + ` as value,
+				ROW_NUMBER() OVER (PARTITION BY ` + bucket.expr + ` ORDER BY fr.run_at ASC) as first_run,
+				ROW_NUMBER() OVER (PARTITION BY ` + bucket.expr + ` ORDER BY fr.run_at DESC) as last_run
 			FROM forecast_runs fr
 			INNER JOIN forecast_results fres ON fr.id = fres.run_id
-			WHERE fr.forecast_id = $1
+			WHERE fr.forecast_id = $1 AND ` + forecastInWorkspace("fr.forecast_id", 3) + `
 				AND fr.status = 'completed'
-				AND fres.aggregated_percentiles IS NOT NULL
+				AND ` + value + ` IS NOT NULL
 		)
 		SELECT
-			EXTRACT(EPOCH FROM bucket)::bigint as time,
-			MAX(CASE WHEN first_run = 1 THEN p50 END) as open,
-			MAX(p50) as high,
-			MIN(p50) as low,
-			MAX(CASE WHEN last_run = 1 THEN p50 END) as close
-		FROM bucketed_p50
+			` + bucket.label + `,
+			MAX(CASE WHEN first_run = 1 THEN value END) as open,
+			MAX(value) as high,
+			MIN(value) as low,
+			MAX(CASE WHEN last_run = 1 THEN value END) as close
+		FROM metric_values
 		GROUP BY bucket
-		ORDER BY bucket ASC
-	`
+		ORDER BY bucket DESC
+		LIMIT $2
+	`, nil
+}
+
+// GetForecastOHLC returns a metric of a forecast's completed runs (see ForecastMetricNames)
+// aggregated into OHLC bars of a named interval, oldest first
+func (r *ForecastRepository) GetForecastOHLC(ctx context.Context, forecastID, metric string, interval OHLCInterval) ([]DailyOHLC, error) {
+	bucket, ok := ohlcIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("unknown OHLC interval: %s", interval)
+	}
+	return r.queryOHLC(ctx, forecastID, metric, bucket)
+}
+
+// GetForecastHistoryBucketed returns a metric of a forecast's completed runs aggregated into OHLC
+// bars of intervalSeconds (between MinBucketSeconds and MaxBucketSeconds), oldest first
+func (r *ForecastRepository) GetForecastHistoryBucketed(ctx context.Context, forecastID, metric string, intervalSeconds int) ([]DailyOHLC, error) {
+	if intervalSeconds < MinBucketSeconds || intervalSeconds > MaxBucketSeconds {
+		return nil, fmt.Errorf("bucket interval must be between %d and %d seconds", MinBucketSeconds, MaxBucketSeconds)
+	}
+	return r.queryOHLC(ctx, forecastID, metric, fixedBucket(intervalSeconds))
+}
+
+// queryOHLC runs forecastOHLCQuery, keeping the newest MaxOHLCBuckets bars in ascending order
+func (r *ForecastRepository) queryOHLC(ctx context.Context, forecastID, metric string, bucket ohlcBucket) ([]DailyOHLC, error) {
+	query, err := forecastOHLCQuery(metric, bucket)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, forecastID)
+	rows, err := r.db.QueryContext(ctx, query, forecastID, MaxOHLCBuckets, workspaceFilter(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get 4-hour OHLC: %w", err)
+		return nil, fmt.Errorf("failed to get OHLC: %w", err)
 	}
 	defer rows.Close()
 
@@ -880,16 +1434,12 @@ func (r *ForecastRepository) GetForecastHistory4Hour(ctx context.Context, foreca
 	for rows.Next() {
 		var ohlc DailyOHLC
 		var open, close sql.NullFloat64
-		var timestamp int64
 
-		err := rows.Scan(&timestamp, &open, &ohlc.High, &ohlc.Low, &close)
+		err := rows.Scan(&ohlc.Date, &open, &ohlc.High, &ohlc.Low, &close)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan OHLC data: %w", err)
 		}
 
-		// Convert Unix timestamp to string for JSON (frontend will parse as number)
-		ohlc.Date = fmt.Sprintf("%d", timestamp)
-
 		// Handle NULL for open/close (shouldn't happen but be safe)
 		if open.Valid {
 			ohlc.Open = open.Float64
@@ -900,11 +1450,20 @@ func (r *ForecastRepository) GetForecastHistory4Hour(ctx context.Context, foreca
 
 		ohlcData = append(ohlcData, ohlc)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating OHLC data: %w", err)
+	}
+
+	// Newest first from the query; charts want them in time order
+	for i, j := 0, len(ohlcData)-1; i < j; i, j = i+1, j-1 {
+		ohlcData[i], ohlcData[j] = ohlcData[j], ohlcData[i]
+	}
 
 	return ohlcData, nil
 }
 
-// UpdateForecastSchedule updates the schedule settings for a forecast
+// UpdateForecastSchedule updates the schedule settings for a forecast in the workspace ctx is
+// scoped to
 func (r *ForecastRepository) UpdateForecastSchedule(ctx context.Context, forecastID string, enabled bool, intervalMinutes int) error {
 	var nextRunAt *time.Time
 	if enabled && intervalMinutes > 0 {
@@ -915,10 +1474,10 @@ func (r *ForecastRepository) UpdateForecastSchedule(ctx context.Context, forecas
 	query := `
 		UPDATE forecasts
 		SET schedule_enabled = $1, schedule_interval = $2, next_run_at = $3, updated_at = $4
-		WHERE id = $5
+		WHERE id = $5 AND ($6::text IS NULL OR workspace_id = $6)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, enabled, intervalMinutes, nextRunAt, time.Now(), forecastID)
+	_, err := r.db.ExecContext(ctx, query, enabled, intervalMinutes, nextRunAt, time.Now(), forecastID, workspaceFilter(ctx))
 	return err
 }
 
@@ -939,9 +1498,51 @@ func (r *ForecastRepository) UpdateForecastLastRun(ctx context.Context, forecast
 	return err
 }
 
-// GetScheduledForecasts retrieves all forecasts that are due to run
+// SkipStaleScheduledForecasts reschedules forecasts overdue by more than staleAfter to their next
+// slot after now without running them, so runs missed during downtime are dropped rather than
+// all fired at once. Slots stay on the forecast's original cadence: a daily 09:00 forecast skipped
+// at 14:00 next runs at 09:00 the following day. It returns the skipped forecasts.
+func (r *ForecastRepository) SkipStaleScheduledForecasts(ctx context.Context, staleAfter time.Duration) ([]models.Forecast, error) {
+	query := `
+		UPDATE forecasts
+		SET next_run_at = next_run_at + (schedule_interval || ' minutes')::interval *
+		    (floor(extract(epoch FROM $1::timestamp - next_run_at) / (schedule_interval * 60)) + 1)
+		WHERE id IN (
+			SELECT id
+			FROM forecasts
+			WHERE schedule_enabled = TRUE
+			  AND active = TRUE
+			  AND archived_at IS NULL
+			  AND schedule_interval > 0
+			  AND next_run_at < $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + forecastColumns + `
+	`
+
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(-staleAfter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to skip stale scheduled forecasts: %w", err)
+	}
+	defer rows.Close()
+
+	var forecasts []models.Forecast
+	for rows.Next() {
+		forecast, err := scanForecast(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan skipped forecast: %w", err)
+		}
+		forecasts = append(forecasts, *forecast)
+	}
+
+	return forecasts, nil
+}
+
+// GetScheduledForecasts claims up to limit forecasts that are due to run, most overdue first.
+// A limit of 0 claims every due forecast.
 // Uses atomic UPDATE with SKIP LOCKED to prevent duplicate execution across multiple instances
-func (r *ForecastRepository) GetScheduledForecasts(ctx context.Context) ([]models.Forecast, error) {
+func (r *ForecastRepository) GetScheduledForecasts(ctx context.Context, limit int) ([]models.Forecast, error) {
 	// Use UPDATE with SKIP LOCKED to atomically claim forecasts and prevent duplicates
 	// This ensures only ONE instance can claim each forecast, even across multiple Cloud Run instances
 	query := `
@@ -953,16 +1554,24 @@ func (r *ForecastRepository) GetScheduledForecasts(ctx context.Context) ([]model
 			FROM forecasts
 			WHERE schedule_enabled = TRUE
 			  AND active = TRUE
+			  AND archived_at IS NULL
 			  AND schedule_interval > 0
 			  AND (next_run_at IS NULL OR next_run_at <= $1)
 			ORDER BY next_run_at ASC NULLS FIRST
+			LIMIT $2
 			FOR UPDATE SKIP LOCKED
 		)
-		RETURNING id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+		RETURNING ` + forecastColumns + `
 	`
 
+	// LIMIT NULL claims every due forecast
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
 	now := time.Now()
-	rows, err := r.db.QueryContext(ctx, query, now)
+	rows, err := r.db.QueryContext(ctx, query, now, limitArg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get scheduled forecasts: %w", err)
 	}
@@ -970,51 +1579,17 @@ func (r *ForecastRepository) GetScheduledForecasts(ctx context.Context) ([]model
 
 	var forecasts []models.Forecast
 	for rows.Next() {
-		var forecast models.Forecast
-		var targetDate sql.NullTime
-		var lastRunAt sql.NullTime
-		var nextRunAt sql.NullTime
-		err := rows.Scan(
-			&forecast.ID,
-			&forecast.Name,
-			&forecast.Proposition,
-			&forecast.PredictionType,
-			&forecast.Units,
-			&targetDate,
-			pq.Array(&forecast.Categories),
-			&forecast.HeadlineCount,
-			&forecast.Iterations,
-			pq.Array(&forecast.ContextURLs),
-			&forecast.Active,
-			&forecast.Public,
-			&forecast.DisplayOrder,
-			&forecast.ScheduleEnabled,
-			&forecast.ScheduleInterval,
-			&lastRunAt,
-			&nextRunAt,
-			&forecast.CreatedAt,
-			&forecast.UpdatedAt,
-		)
+		forecast, err := scanForecast(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan scheduled forecast: %w", err)
 		}
-
-		if targetDate.Valid {
-			forecast.TargetDate = &targetDate.Time
-		}
-		if lastRunAt.Valid {
-			forecast.LastRunAt = &lastRunAt.Time
-		}
-		if nextRunAt.Valid {
-			forecast.NextRunAt = &nextRunAt.Time
-		}
-		forecasts = append(forecasts, forecast)
+		forecasts = append(forecasts, *forecast)
 	}
 
 	return forecasts, nil
 }
 
-// DeleteForecastRun deletes a forecast run by ID
+// DeleteForecastRun deletes a run of a forecast in the workspace ctx is scoped to
 func (r *ForecastRepository) DeleteForecastRun(ctx context.Context, runID string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -1022,6 +1597,18 @@ func (r *ForecastRepository) DeleteForecastRun(ctx context.Context, runID string
 	}
 	defer tx.Rollback()
 
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM forecast_runs WHERE id = $1 AND `+forecastInWorkspace("forecast_id", 2)+`)`,
+		runID, workspaceFilter(ctx),
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check forecast run: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("forecast run not found")
+	}
+
 	// Delete model responses for this run
 	_, err = tx.ExecContext(ctx, `DELETE FROM forecast_model_responses WHERE run_id = $1`, runID)
 	if err != nil {
@@ -1056,7 +1643,7 @@ func (r *ForecastRepository) DeleteForecastRun(ctx context.Context, runID string
 	return nil
 }
 
-// DeleteAllRunsForForecast deletes all runs for a forecast
+// DeleteAllRunsForForecast deletes all runs for a forecast in the workspace ctx is scoped to
 func (r *ForecastRepository) DeleteAllRunsForForecast(ctx context.Context, forecastID string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -1064,8 +1651,11 @@ func (r *ForecastRepository) DeleteAllRunsForForecast(ctx context.Context, forec
 	}
 	defer tx.Rollback()
 
-	// Get all run IDs for this forecast
-	rows, err := tx.QueryContext(ctx, `SELECT id FROM forecast_runs WHERE forecast_id = $1`, forecastID)
+	// Get all run IDs for this forecast; forecasts in other workspaces have none
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id FROM forecast_runs WHERE forecast_id = $1 AND `+forecastInWorkspace("forecast_id", 2),
+		forecastID, workspaceFilter(ctx),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to get run IDs: %w", err)
 	}
@@ -1097,7 +1687,7 @@ func (r *ForecastRepository) DeleteAllRunsForForecast(ctx context.Context, forec
 	}
 
 	// Delete all runs
-	_, err = tx.ExecContext(ctx, `DELETE FROM forecast_runs WHERE forecast_id = $1`, forecastID)
+	_, err = tx.ExecContext(ctx, `DELETE FROM forecast_runs WHERE id = ANY($1)`, pq.Array(runIDs))
 	if err != nil {
 		return fmt.Errorf("failed to delete forecast runs: %w", err)
 	}
@@ -1109,10 +1699,10 @@ func (r *ForecastRepository) DeleteAllRunsForForecast(ctx context.Context, forec
 	return nil
 }
 
-// UpdateForecastPublic updates the public status of a forecast
+// UpdateForecastPublic updates the public status of a forecast in the workspace ctx is scoped to
 func (r *ForecastRepository) UpdateForecastPublic(ctx context.Context, forecastID string, public bool) error {
-	query := `UPDATE forecasts SET public = $1, updated_at = $2 WHERE id = $3`
-	result, err := r.db.ExecContext(ctx, query, public, time.Now(), forecastID)
+	query := `UPDATE forecasts SET public = $1, updated_at = $2 WHERE id = $3 AND ($4::text IS NULL OR workspace_id = $4)`
+	result, err := r.db.ExecContext(ctx, query, public, time.Now(), forecastID, workspaceFilter(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to update forecast public status: %w", err)
 	}
@@ -1129,10 +1719,11 @@ func (r *ForecastRepository) UpdateForecastPublic(ctx context.Context, forecastI
 	return nil
 }
 
-// UpdateForecastDisplayOrder updates the display order of a forecast
+// UpdateForecastDisplayOrder updates the display order of a forecast in the workspace ctx is
+// scoped to
 func (r *ForecastRepository) UpdateForecastDisplayOrder(ctx context.Context, forecastID string, displayOrder int) error {
-	query := `UPDATE forecasts SET display_order = $1, updated_at = $2 WHERE id = $3`
-	result, err := r.db.ExecContext(ctx, query, displayOrder, time.Now(), forecastID)
+	query := `UPDATE forecasts SET display_order = $1, updated_at = $2 WHERE id = $3 AND ($4::text IS NULL OR workspace_id = $4)`
+	result, err := r.db.ExecContext(ctx, query, displayOrder, time.Now(), forecastID, workspaceFilter(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to update forecast display order: %w", err)
 	}
@@ -1149,17 +1740,16 @@ func (r *ForecastRepository) UpdateForecastDisplayOrder(ctx context.Context, for
 	return nil
 }
 
-// ListPublicForecasts returns all public forecasts with their latest runs
+// ListPublicForecasts returns all public forecasts in the workspace ctx is scoped to with their latest runs
 func (r *ForecastRepository) ListPublicForecasts(ctx context.Context) ([]models.Forecast, error) {
-	query := `
-		SELECT
-			id, name, proposition, prediction_type, units, target_date, categories, headline_count, iterations, context_urls, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+	query := `SELECT ` + forecastColumns + `
 		FROM forecasts
-		WHERE public = true AND active = true
+		WHERE public = true AND active = true AND archived_at IS NULL
+		  AND ($1::text IS NULL OR workspace_id = $1)
 		ORDER BY display_order DESC, updated_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query public forecasts: %w", err)
 	}
@@ -1167,28 +1757,12 @@ func (r *ForecastRepository) ListPublicForecasts(ctx context.Context) ([]models.
 
 	var forecasts []models.Forecast
 	for rows.Next() {
-		var f models.Forecast
-		var targetDate sql.NullTime
-		var lastRunAt sql.NullTime
-		var nextRunAt sql.NullTime
-		err := rows.Scan(
-			&f.ID, &f.Name, &f.Proposition, &f.PredictionType, &f.Units, &targetDate, pq.Array(&f.Categories), &f.HeadlineCount, &f.Iterations, pq.Array(&f.ContextURLs), &f.Active, &f.Public, &f.DisplayOrder, &f.ScheduleEnabled, &f.ScheduleInterval, &lastRunAt, &nextRunAt, &f.CreatedAt, &f.UpdatedAt,
-		)
+		f, err := scanForecast(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan forecast: %w", err)
 		}
 
-		if targetDate.Valid {
-			f.TargetDate = &targetDate.Time
-		}
-		if lastRunAt.Valid {
-			f.LastRunAt = &lastRunAt.Time
-		}
-		if nextRunAt.Valid {
-			f.NextRunAt = &nextRunAt.Time
-		}
-
-		forecasts = append(forecasts, f)
+		forecasts = append(forecasts, *f)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -0,0 +1,288 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestForecastOHLCQuery(t *testing.T) {
+	daily, err := forecastOHLCQuery(DefaultForecastMetric, ohlcIntervals[OHLCDaily])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"DATE(fr.run_at) as bucket",
+		"(fres.aggregated_percentiles->>'p50')::float as value",
+		"PARTITION BY DATE(fr.run_at) ORDER BY fr.run_at ASC",
+		"AND (fres.aggregated_percentiles->>'p50')::float IS NOT NULL",
+		"bucket::text,",
+	} {
+		if !strings.Contains(daily, want) {
+			t.Errorf("daily p50 query missing %q:\n%s", want, daily)
+		}
+	}
+
+	fourHour, err := forecastOHLCQuery("consensus", ohlcIntervals[OHLCFourHour])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		epochBucket("fr.run_at", 14400) + " as bucket",
+		"fres.consensus_level as value",
+		"AND fres.consensus_level IS NOT NULL",
+		"EXTRACT(EPOCH FROM bucket)::bigint::text,",
+		"ORDER BY bucket DESC\n\t\tLIMIT $2",
+	} {
+		if !strings.Contains(fourHour, want) {
+			t.Errorf("4-hour consensus query missing %q:\n%s", want, fourHour)
+		}
+	}
+
+	weekly, err := forecastOHLCQuery("value", ohlcIntervals[OHLCWeekly])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(weekly, "DATE(date_trunc('week', fr.run_at)) as bucket") {
+		t.Errorf("weekly bars should start on the calendar week:\n%s", weekly)
+	}
+
+	if _, err := forecastOHLCQuery("p50; DROP TABLE forecasts", ohlcIntervals[OHLCDaily]); err == nil {
+		t.Error("expected an error for an unknown metric")
+	}
+	if ValidOHLCInterval("monthly") {
+		t.Error("monthly should not be a named interval")
+	}
+}
+
+func TestGetForecastHistoryBucketed_RejectsInterval(t *testing.T) {
+	r := &ForecastRepository{}
+	for _, seconds := range []int{0, MinBucketSeconds - 1, MaxBucketSeconds + 1} {
+		if _, err := r.GetForecastHistoryBucketed(context.Background(), "fc-1", DefaultForecastMetric, seconds); err == nil {
+			t.Errorf("expected an error for %d seconds", seconds)
+		}
+	}
+}
+
+func TestForecastMetricNames(t *testing.T) {
+	names := ForecastMetricNames()
+	if len(names) != len(forecastMetrics) {
+		t.Fatalf("got %d names, want %d", len(names), len(forecastMetrics))
+	}
+	for i, name := range names {
+		if !ValidForecastMetric(name) {
+			t.Errorf("%s should be valid", name)
+		}
+		if i > 0 && names[i-1] >= name {
+			t.Errorf("names not sorted: %v", names)
+		}
+	}
+	if ValidForecastMetric("median") {
+		t.Error("median should not be a valid metric")
+	}
+}
+
+func TestSkipStaleScheduledForecasts_KeepsCadence(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewForecastRepository(db)
+	forecast, err := repo.CreateForecast(ctx, models.CreateForecastRequest{
+		Name:           "Skip stale test",
+		Proposition:    "What will the S&P 500 do?",
+		PredictionType: "point_estimate",
+	})
+	if err != nil {
+		t.Fatalf("CreateForecast: %v", err)
+	}
+	defer db.Exec("DELETE FROM forecasts WHERE id = $1", forecast.ID)
+
+	// Hourly, and five and a half slots overdue
+	var due time.Time
+	if err := db.QueryRow(`
+		UPDATE forecasts
+		SET schedule_enabled = TRUE, active = TRUE, schedule_interval = 60,
+		    next_run_at = $2::timestamp
+		WHERE id = $1
+		RETURNING next_run_at`, forecast.ID, time.Now().Add(-330*time.Minute)).Scan(&due); err != nil {
+		t.Fatalf("failed to schedule forecast: %v", err)
+	}
+
+	skipped, err := repo.SkipStaleScheduledForecasts(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("SkipStaleScheduledForecasts: %v", err)
+	}
+
+	var found *models.Forecast
+	for i := range skipped {
+		if skipped[i].ID == forecast.ID {
+			found = &skipped[i]
+		}
+	}
+	if found == nil || found.NextRunAt == nil {
+		t.Fatal("expected the overdue forecast to be skipped")
+	}
+	if got := found.NextRunAt.Sub(due); got != 6*time.Hour {
+		t.Errorf("expected the next slot on the original cadence (+6h), got +%v", got)
+	}
+}
+
+func TestFailStaleForecastRun_LeavesLiveRuns(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewForecastRepository(db)
+	forecast, err := repo.CreateForecast(ctx, models.CreateForecastRequest{
+		Name:           "Stale run test",
+		Proposition:    "What will the S&P 500 do?",
+		PredictionType: "point_estimate",
+	})
+	if err != nil {
+		t.Fatalf("CreateForecast: %v", err)
+	}
+	defer db.Exec("DELETE FROM forecasts WHERE id = $1", forecast.ID)
+
+	runID, err := repo.CreateForecastRun(ctx, forecast.ID, []models.ForecastHeadline{})
+	if err != nil {
+		t.Fatalf("CreateForecastRun: %v", err)
+	}
+	if err := repo.UpdateForecastRunStatus(ctx, runID, "running", ""); err != nil {
+		t.Fatalf("UpdateForecastRunStatus: %v", err)
+	}
+
+	// Started an hour ago, but another instance has just reported it alive
+	if _, err := db.Exec(`UPDATE forecast_runs SET run_at = $2 WHERE id = $1`, runID, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to backdate run: %v", err)
+	}
+	if err := repo.TouchForecastRun(ctx, runID); err != nil {
+		t.Fatalf("TouchForecastRun: %v", err)
+	}
+	failed, err := repo.FailStaleForecastRun(ctx, runID, "cancelled", time.Now().Add(-2*time.Minute))
+	if err != nil {
+		t.Fatalf("FailStaleForecastRun: %v", err)
+	}
+	if failed {
+		t.Fatal("expected a run with a recent heartbeat to be left alone")
+	}
+
+	// Once the heartbeat stops the run is presumed abandoned
+	if _, err := db.Exec(`UPDATE forecast_runs SET heartbeat_at = $2 WHERE id = $1`, runID, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to age heartbeat: %v", err)
+	}
+	failed, err = repo.FailStaleForecastRun(ctx, runID, "cancelled", time.Now().Add(-2*time.Minute))
+	if err != nil {
+		t.Fatalf("FailStaleForecastRun: %v", err)
+	}
+	if !failed {
+		t.Fatal("expected a run without a recent heartbeat to be failed")
+	}
+	detail, err := repo.GetForecastRun(models.ContextWithAllWorkspaces(ctx), runID)
+	if err != nil || detail == nil {
+		t.Fatalf("GetForecastRun: %v", err)
+	}
+	if detail.Run.Status != "failed" || detail.Run.ErrorMessage != "cancelled" {
+		t.Errorf("expected run failed with reason cancelled, got %+v", detail.Run)
+	}
+}
+
+func TestCreateForecastRun_OneInProgress(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewForecastRepository(db)
+	forecast, err := repo.CreateForecast(ctx, models.CreateForecastRequest{
+		Name:           "Concurrent run test",
+		Proposition:    "What will the S&P 500 do?",
+		PredictionType: "point_estimate",
+	})
+	if err != nil {
+		t.Fatalf("CreateForecast: %v", err)
+	}
+	defer db.Exec("DELETE FROM forecasts WHERE id = $1", forecast.ID)
+
+	runID, err := repo.CreateForecastRun(ctx, forecast.ID, []models.ForecastHeadline{})
+	if err != nil {
+		t.Fatalf("CreateForecastRun: %v", err)
+	}
+	if _, err := repo.CreateForecastRun(ctx, forecast.ID, []models.ForecastHeadline{}); !errors.Is(err, models.ErrForecastRunActive) {
+		t.Fatalf("expected ErrForecastRunActive for a second run, got %v", err)
+	}
+
+	// An abandoned run no longer blocks the forecast once it is failed
+	if _, err := db.Exec(`UPDATE forecast_runs SET run_at = $2, heartbeat_at = $2 WHERE id = $1`, runID, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to age run: %v", err)
+	}
+	failed, err := repo.FailStaleForecastRuns(ctx, forecast.ID, "abandoned: no heartbeat", time.Now().Add(-2*time.Minute))
+	if err != nil {
+		t.Fatalf("FailStaleForecastRuns: %v", err)
+	}
+	if failed != 1 {
+		t.Fatalf("expected 1 stale run failed, got %d", failed)
+	}
+	if _, err := repo.CreateForecastRun(ctx, forecast.ID, []models.ForecastHeadline{}); err != nil {
+		t.Fatalf("expected a new run after the stale one was failed, got %v", err)
+	}
+}
+
+func TestReserveIdempotencyKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewForecastRepository(db)
+	forecast, err := repo.CreateForecast(ctx, models.CreateForecastRequest{
+		Name:           "Idempotency key test",
+		Proposition:    "What will the S&P 500 do?",
+		PredictionType: "point_estimate",
+	})
+	if err != nil {
+		t.Fatalf("CreateForecast: %v", err)
+	}
+	defer db.Exec("DELETE FROM forecasts WHERE id = $1", forecast.ID)
+
+	expired, stale := time.Now().Add(-24*time.Hour), time.Now().Add(-time.Minute)
+	reserve := func() (string, bool) {
+		t.Helper()
+		runID, reserved, err := repo.ReserveIdempotencyKey(ctx, forecast.ID, "key", expired, stale)
+		if err != nil {
+			t.Fatalf("ReserveIdempotencyKey: %v", err)
+		}
+		return runID, reserved
+	}
+
+	if _, reserved := reserve(); !reserved {
+		t.Fatal("expected the first request to reserve the key")
+	}
+	if runID, reserved := reserve(); reserved || runID != "" {
+		t.Fatalf("expected a concurrent request to wait for the run, got %q (reserved %v)", runID, reserved)
+	}
+
+	// A reservation whose run never started is taken over once stale
+	if _, err := db.Exec(`UPDATE forecast_idempotency_keys SET created_at = NOW() - INTERVAL '5 minutes' WHERE forecast_id = $1`, forecast.ID); err != nil {
+		t.Fatalf("failed to age reservation: %v", err)
+	}
+	if _, reserved := reserve(); !reserved {
+		t.Fatal("expected a stale reservation to be taken over")
+	}
+
+	runID, err := repo.CreateForecastRun(ctx, forecast.ID, []models.ForecastHeadline{})
+	if err != nil {
+		t.Fatalf("CreateForecastRun: %v", err)
+	}
+	if err := repo.SetIdempotentRun(ctx, forecast.ID, "key", runID); err != nil {
+		t.Fatalf("SetIdempotentRun: %v", err)
+	}
+	if err := repo.ReleaseIdempotencyKey(ctx, forecast.ID, "key"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	if got, reserved := reserve(); reserved || got != runID {
+		t.Fatalf("expected retries to get run %s, got %q (reserved %v)", runID, got, reserved)
+	}
+}
@@ -188,3 +188,86 @@ func (r *InferenceLogRepository) GetStats(ctx context.Context, startDate, endDat
 
 	return &stats, nil
 }
+
+// GetUsageBreakdown aggregates calls by provider, model and operation, most expensive first
+func (r *InferenceLogRepository) GetUsageBreakdown(ctx context.Context, startDate, endDate *time.Time) ([]models.InferenceUsageBreakdown, error) {
+	query := `
+		SELECT
+			provider,
+			model,
+			operation,
+			COUNT(*) as call_count,
+			COALESCE(SUM(tokens_used), 0) as total_tokens,
+			COALESCE(SUM(cost_usd), 0) as total_cost_usd,
+			SUM(CASE WHEN status = 'error' THEN 1 ELSE 0 END) as error_count,
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms), 0) as p50_latency_ms,
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ms), 0) as p95_latency_ms
+		FROM inference_logs
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	argPos := 1
+
+	if startDate != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argPos)
+		args = append(args, startDate)
+		argPos++
+	}
+
+	if endDate != nil {
+		query += fmt.Sprintf(" AND created_at <= $%d", argPos)
+		args = append(args, endDate)
+	}
+
+	query += " GROUP BY provider, model, operation ORDER BY total_cost_usd DESC, call_count DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inference usage breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	breakdown := []models.InferenceUsageBreakdown{}
+	for rows.Next() {
+		var b models.InferenceUsageBreakdown
+		if err := rows.Scan(
+			&b.Provider,
+			&b.Model,
+			&b.Operation,
+			&b.CallCount,
+			&b.TotalTokens,
+			&b.TotalCostUSD,
+			&b.ErrorCount,
+			&b.P50LatencyMs,
+			&b.P95LatencyMs,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan inference usage breakdown: %w", err)
+		}
+
+		if b.CallCount > 0 {
+			b.ErrorRate = float64(b.ErrorCount) / float64(b.CallCount)
+		}
+
+		breakdown = append(breakdown, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inference usage breakdown: %w", err)
+	}
+
+	return breakdown, nil
+}
+
+// GetTokenUsageSince returns the total tokens used by inference calls since the given time
+func (r *InferenceLogRepository) GetTokenUsageSince(ctx context.Context, since time.Time) (int64, error) {
+	var total int64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(tokens_used), 0) FROM inference_logs WHERE created_at >= $1`,
+		since,
+	).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token usage: %w", err)
+	}
+
+	return total, nil
+}
@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // IngestionErrorRepository defines the interface for storing and retrieving ingestion errors.
@@ -17,7 +19,10 @@ type IngestionErrorRepository interface {
 	Store(ctx context.Context, err models.IngestionError) error
 
 	// List retrieves ingestion errors with optional filtering.
-	List(ctx context.Context, limit int, unresolvedOnly bool) ([]models.IngestionError, error)
+	List(ctx context.Context, filter models.IngestionErrorFilter) ([]models.IngestionError, error)
+
+	// Stats counts ingestion errors grouped by category and platform.
+	Stats(ctx context.Context, unresolvedOnly bool) ([]models.IngestionErrorStat, error)
 
 	// GetByID retrieves an error by its ID.
 	GetByID(ctx context.Context, id string) (*models.IngestionError, error)
@@ -25,6 +30,9 @@ type IngestionErrorRepository interface {
 	// MarkResolved marks an error as resolved.
 	MarkResolved(ctx context.Context, id string) error
 
+	// ResolveTransient resolves open transient errors for a platform and URL, returning how many were resolved.
+	ResolveTransient(ctx context.Context, platform, url, note string) (int64, error)
+
 	// Delete removes an error from the repository.
 	Delete(ctx context.Context, id string) error
 
@@ -54,10 +62,15 @@ func (r *PostgresIngestionErrorRepository) Store(ctx context.Context, err models
 		err.CreatedAt = time.Now()
 	}
 
+	if err.Category == "" {
+		err.Category = models.ErrorCategoryUnknown
+	}
+
 	query := `
-		INSERT INTO ingestion_errors (id, platform, error_type, url, error_msg, metadata, created_at, resolved, resolved_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO ingestion_errors (id, platform, error_type, category, url, error_msg, metadata, created_at, resolved, resolved_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (id) DO UPDATE SET
+			category = EXCLUDED.category,
 			error_msg = EXCLUDED.error_msg,
 			metadata = EXCLUDED.metadata,
 			created_at = EXCLUDED.created_at
@@ -67,6 +80,7 @@ func (r *PostgresIngestionErrorRepository) Store(ctx context.Context, err models
 		err.ID,
 		err.Platform,
 		err.ErrorType,
+		err.Category,
 		err.URL,
 		err.ErrorMsg,
 		err.Metadata,
@@ -79,19 +93,39 @@ func (r *PostgresIngestionErrorRepository) Store(ctx context.Context, err models
 }
 
 // List retrieves ingestion errors with optional filtering.
-func (r *PostgresIngestionErrorRepository) List(ctx context.Context, limit int, unresolvedOnly bool) ([]models.IngestionError, error) {
+func (r *PostgresIngestionErrorRepository) List(ctx context.Context, filter models.IngestionErrorFilter) ([]models.IngestionError, error) {
 	query := `
-		SELECT id, platform, error_type, url, error_msg, metadata, created_at, resolved, resolved_at
+		SELECT id, platform, error_type, category, url, error_msg, metadata, created_at, resolved, resolved_at, resolution_note
 		FROM ingestion_errors
 	`
 
-	if unresolvedOnly {
-		query += " WHERE resolved = FALSE"
+	var conditions []string
+	var args []interface{}
+
+	if filter.UnresolvedOnly {
+		conditions = append(conditions, "resolved = FALSE")
+	}
+	if filter.Category != "" {
+		args = append(args, string(filter.Category))
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if filter.Platform != "" {
+		args = append(args, filter.Platform)
+		conditions = append(conditions, fmt.Sprintf("platform = $%d", len(args)))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY created_at DESC LIMIT $1"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query ingestion errors: %w", err)
 	}
@@ -102,17 +136,20 @@ func (r *PostgresIngestionErrorRepository) List(ctx context.Context, limit int,
 		var e models.IngestionError
 		var metadata sql.NullString
 		var resolvedAt sql.NullTime
+		var resolutionNote sql.NullString
 
 		if err := rows.Scan(
 			&e.ID,
 			&e.Platform,
 			&e.ErrorType,
+			&e.Category,
 			&e.URL,
 			&e.ErrorMsg,
 			&metadata,
 			&e.CreatedAt,
 			&e.Resolved,
 			&resolvedAt,
+			&resolutionNote,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan ingestion error: %w", err)
 		}
@@ -123,6 +160,9 @@ func (r *PostgresIngestionErrorRepository) List(ctx context.Context, limit int,
 		if resolvedAt.Valid {
 			e.ResolvedAt = &resolvedAt.Time
 		}
+		if resolutionNote.Valid {
+			e.ResolutionNote = resolutionNote.String
+		}
 
 		errors = append(errors, e)
 	}
@@ -130,10 +170,38 @@ func (r *PostgresIngestionErrorRepository) List(ctx context.Context, limit int,
 	return errors, rows.Err()
 }
 
+// Stats counts ingestion errors grouped by category and platform, largest groups first.
+func (r *PostgresIngestionErrorRepository) Stats(ctx context.Context, unresolvedOnly bool) ([]models.IngestionErrorStat, error) {
+	query := `SELECT category, platform, COUNT(*) FROM ingestion_errors`
+
+	if unresolvedOnly {
+		query += " WHERE resolved = FALSE"
+	}
+
+	query += " GROUP BY category, platform ORDER BY COUNT(*) DESC, category, platform"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ingestion error stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.IngestionErrorStat
+	for rows.Next() {
+		var s models.IngestionErrorStat
+		if err := rows.Scan(&s.Category, &s.Platform, &s.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan ingestion error stat: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
 // GetByID retrieves an error by its ID.
 func (r *PostgresIngestionErrorRepository) GetByID(ctx context.Context, id string) (*models.IngestionError, error) {
 	query := `
-		SELECT id, platform, error_type, url, error_msg, metadata, created_at, resolved, resolved_at
+		SELECT id, platform, error_type, category, url, error_msg, metadata, created_at, resolved, resolved_at, resolution_note
 		FROM ingestion_errors
 		WHERE id = $1
 	`
@@ -141,17 +209,20 @@ func (r *PostgresIngestionErrorRepository) GetByID(ctx context.Context, id strin
 	var e models.IngestionError
 	var metadata sql.NullString
 	var resolvedAt sql.NullTime
+	var resolutionNote sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&e.ID,
 		&e.Platform,
 		&e.ErrorType,
+		&e.Category,
 		&e.URL,
 		&e.ErrorMsg,
 		&metadata,
 		&e.CreatedAt,
 		&e.Resolved,
 		&resolvedAt,
+		&resolutionNote,
 	)
 
 	if err == sql.ErrNoRows {
@@ -167,6 +238,9 @@ func (r *PostgresIngestionErrorRepository) GetByID(ctx context.Context, id strin
 	if resolvedAt.Valid {
 		e.ResolvedAt = &resolvedAt.Time
 	}
+	if resolutionNote.Valid {
+		e.ResolutionNote = resolutionNote.String
+	}
 
 	return &e, nil
 }
@@ -183,6 +257,33 @@ func (r *PostgresIngestionErrorRepository) MarkResolved(ctx context.Context, id
 	return err
 }
 
+// ResolveTransient resolves open errors for platform and url whose category is transient,
+// recording note as the reason. Permanent failures are left for manual handling.
+func (r *PostgresIngestionErrorRepository) ResolveTransient(ctx context.Context, platform, url, note string) (int64, error) {
+	categories := make([]string, len(models.TransientErrorCategories))
+	for i, c := range models.TransientErrorCategories {
+		categories[i] = string(c)
+	}
+
+	query := `
+		UPDATE ingestion_errors
+		SET resolved = TRUE, resolved_at = NOW(), resolution_note = $3
+		WHERE platform = $1 AND url = $2 AND resolved = FALSE AND category = ANY($4)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, platform, url, note, pq.Array(categories))
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve transient ingestion errors: %w", err)
+	}
+
+	resolved, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count resolved ingestion errors: %w", err)
+	}
+
+	return resolved, nil
+}
+
 // Delete removes an error from the repository.
 func (r *PostgresIngestionErrorRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM ingestion_errors WHERE id = $1`
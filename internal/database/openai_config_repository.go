@@ -3,10 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
+	"github.com/lib/pq"
 )
 
 // OpenAIConfigRepository manages OpenAI configuration in the database.
@@ -22,18 +25,23 @@ func NewOpenAIConfigRepository(db *sql.DB) *OpenAIConfigRepository {
 // Get retrieves the OpenAI configuration.
 func (r *OpenAIConfigRepository) Get(ctx context.Context) (*models.OpenAIConfig, error) {
 	query := `
-		SELECT id, api_key, model, temperature, max_tokens, timeout_seconds,
-		       system_prompt, analysis_template, entity_extraction_prompt, correlation_system_prompt,
+		SELECT id, api_key, model, fallback_models, temperature, max_tokens, timeout_seconds,
+		       system_prompt, analysis_template, entity_extraction_prompt, correlation_system_prompt, correlation_template,
+		       credibility_system_prompt, credibility_template,
+		       provider, base_url, azure_deployment, azure_api_version, category_mapping,
+		       prompt_b_system_prompt, prompt_b_analysis_template, prompt_b_fraction,
 		       enabled, updated_at, created_at
 		FROM openai_config
 		LIMIT 1
 	`
 
 	config := &models.OpenAIConfig{}
+	var categoryMapping []byte
 	err := r.db.QueryRowContext(ctx, query).Scan(
 		&config.ID,
 		&config.APIKey,
 		&config.Model,
+		pq.Array(&config.FallbackModels),
 		&config.Temperature,
 		&config.MaxTokens,
 		&config.TimeoutSeconds,
@@ -41,6 +49,17 @@ func (r *OpenAIConfigRepository) Get(ctx context.Context) (*models.OpenAIConfig,
 		&config.AnalysisTemplate,
 		&config.EntityExtractionPrompt,
 		&config.CorrelationSystemPrompt,
+		&config.CorrelationTemplate,
+		&config.CredibilitySystemPrompt,
+		&config.CredibilityTemplate,
+		&config.Provider,
+		&config.BaseURL,
+		&config.AzureDeployment,
+		&config.AzureAPIVersion,
+		&categoryMapping,
+		&config.PromptBSystemPrompt,
+		&config.PromptBAnalysisTemplate,
+		&config.PromptBFraction,
 		&config.Enabled,
 		&config.UpdatedAt,
 		&config.CreatedAt,
@@ -52,6 +71,12 @@ func (r *OpenAIConfigRepository) Get(ctx context.Context) (*models.OpenAIConfig,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get openai configuration: %w", err)
 	}
+	if err := json.Unmarshal(categoryMapping, &config.CategoryMapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal category mapping: %w", err)
+	}
+	if config.APIKey, err = secrets.Decrypt(config.APIKey); err != nil {
+		return nil, fmt.Errorf("failed to decrypt api key: %w", err)
+	}
 
 	return config, nil
 }
@@ -64,15 +89,24 @@ func (r *OpenAIConfigRepository) Update(ctx context.Context, update models.OpenA
 	argCount := 1
 
 	if update.APIKey != nil {
+		apiKey, err := secrets.Encrypt(*update.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt api key: %w", err)
+		}
 		argCount++
 		query += fmt.Sprintf(", api_key = $%d", argCount)
-		args = append(args, *update.APIKey)
+		args = append(args, apiKey)
 	}
 	if update.Model != nil {
 		argCount++
 		query += fmt.Sprintf(", model = $%d", argCount)
 		args = append(args, *update.Model)
 	}
+	if update.FallbackModels != nil {
+		argCount++
+		query += fmt.Sprintf(", fallback_models = $%d", argCount)
+		args = append(args, pq.Array(update.FallbackModels))
+	}
 	if update.Temperature != nil {
 		argCount++
 		query += fmt.Sprintf(", temperature = $%d", argCount)
@@ -108,21 +142,85 @@ func (r *OpenAIConfigRepository) Update(ctx context.Context, update models.OpenA
 		query += fmt.Sprintf(", correlation_system_prompt = $%d", argCount)
 		args = append(args, *update.CorrelationSystemPrompt)
 	}
+	if update.CorrelationTemplate != nil {
+		argCount++
+		query += fmt.Sprintf(", correlation_template = $%d", argCount)
+		args = append(args, *update.CorrelationTemplate)
+	}
+	if update.CredibilitySystemPrompt != nil {
+		argCount++
+		query += fmt.Sprintf(", credibility_system_prompt = $%d", argCount)
+		args = append(args, *update.CredibilitySystemPrompt)
+	}
+	if update.CredibilityTemplate != nil {
+		argCount++
+		query += fmt.Sprintf(", credibility_template = $%d", argCount)
+		args = append(args, *update.CredibilityTemplate)
+	}
+	if update.Provider != nil {
+		argCount++
+		query += fmt.Sprintf(", provider = $%d", argCount)
+		args = append(args, *update.Provider)
+	}
+	if update.BaseURL != nil {
+		argCount++
+		query += fmt.Sprintf(", base_url = $%d", argCount)
+		args = append(args, *update.BaseURL)
+	}
+	if update.AzureDeployment != nil {
+		argCount++
+		query += fmt.Sprintf(", azure_deployment = $%d", argCount)
+		args = append(args, *update.AzureDeployment)
+	}
+	if update.AzureAPIVersion != nil {
+		argCount++
+		query += fmt.Sprintf(", azure_api_version = $%d", argCount)
+		args = append(args, *update.AzureAPIVersion)
+	}
+	if update.CategoryMapping != nil {
+		mapping, err := json.Marshal(update.CategoryMapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal category mapping: %w", err)
+		}
+		argCount++
+		query += fmt.Sprintf(", category_mapping = $%d", argCount)
+		args = append(args, mapping)
+	}
+	if update.PromptBSystemPrompt != nil {
+		argCount++
+		query += fmt.Sprintf(", prompt_b_system_prompt = $%d", argCount)
+		args = append(args, *update.PromptBSystemPrompt)
+	}
+	if update.PromptBAnalysisTemplate != nil {
+		argCount++
+		query += fmt.Sprintf(", prompt_b_analysis_template = $%d", argCount)
+		args = append(args, *update.PromptBAnalysisTemplate)
+	}
+	if update.PromptBFraction != nil {
+		argCount++
+		query += fmt.Sprintf(", prompt_b_fraction = $%d", argCount)
+		args = append(args, *update.PromptBFraction)
+	}
 	if update.Enabled != nil {
 		argCount++
 		query += fmt.Sprintf(", enabled = $%d", argCount)
 		args = append(args, *update.Enabled)
 	}
 
-	query += ` RETURNING id, api_key, model, temperature, max_tokens, timeout_seconds,
-	                     system_prompt, analysis_template, entity_extraction_prompt, correlation_system_prompt,
+	query += ` RETURNING id, api_key, model, fallback_models, temperature, max_tokens, timeout_seconds,
+	                     system_prompt, analysis_template, entity_extraction_prompt, correlation_system_prompt, correlation_template,
+	                     credibility_system_prompt, credibility_template,
+	                     provider, base_url, azure_deployment, azure_api_version, category_mapping,
+	                     prompt_b_system_prompt, prompt_b_analysis_template, prompt_b_fraction,
 	                     enabled, updated_at, created_at`
 
 	config := &models.OpenAIConfig{}
+	var categoryMapping []byte
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&config.ID,
 		&config.APIKey,
 		&config.Model,
+		pq.Array(&config.FallbackModels),
 		&config.Temperature,
 		&config.MaxTokens,
 		&config.TimeoutSeconds,
@@ -130,6 +228,17 @@ func (r *OpenAIConfigRepository) Update(ctx context.Context, update models.OpenA
 		&config.AnalysisTemplate,
 		&config.EntityExtractionPrompt,
 		&config.CorrelationSystemPrompt,
+		&config.CorrelationTemplate,
+		&config.CredibilitySystemPrompt,
+		&config.CredibilityTemplate,
+		&config.Provider,
+		&config.BaseURL,
+		&config.AzureDeployment,
+		&config.AzureAPIVersion,
+		&categoryMapping,
+		&config.PromptBSystemPrompt,
+		&config.PromptBAnalysisTemplate,
+		&config.PromptBFraction,
 		&config.Enabled,
 		&config.UpdatedAt,
 		&config.CreatedAt,
@@ -138,6 +247,12 @@ func (r *OpenAIConfigRepository) Update(ctx context.Context, update models.OpenA
 	if err != nil {
 		return nil, fmt.Errorf("failed to update openai configuration: %w", err)
 	}
+	if err := json.Unmarshal(categoryMapping, &config.CategoryMapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal category mapping: %w", err)
+	}
+	if config.APIKey, err = secrets.Decrypt(config.APIKey); err != nil {
+		return nil, fmt.Errorf("failed to decrypt api key: %w", err)
+	}
 
 	return config, nil
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,13 +38,20 @@ func (r *PostgresEventRepository) Create(ctx context.Context, event models.Event
 		return fmt.Errorf("failed to marshal confidence: %w", err)
 	}
 
+	rejectionThresholdsJSON, err := marshalRejectionThresholds(event.RejectionThresholds)
+	if err != nil {
+		return err
+	}
+
 	// Insert event with location fields
 	query := `
 		INSERT INTO events (
 			id, timestamp, title, summary, raw_content, magnitude, confidence,
 			category, status, tags, location, location_country, location_city, location_region,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, ST_SetSRID(ST_MakePoint($11, $12), 4326), $13, $14, $15, $16, $17)
+			created_at, updated_at, rejection_reason, rejection_thresholds, enrichment_model, workspace_id,
+			prompt_variant
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, ST_SetSRID(ST_MakePoint($11, $12), 4326), $13, $14, $15, $16, $17, NULLIF($18, ''), $19, NULLIF($20, ''), $21,
+			NULLIF($22, ''))
 	`
 
 	var lon, lat *float64
@@ -79,6 +88,11 @@ func (r *PostgresEventRepository) Create(ctx context.Context, event models.Event
 		region,
 		event.CreatedAt,
 		event.UpdatedAt,
+		event.RejectionReason,
+		rejectionThresholdsJSON,
+		event.EnrichmentModel,
+		writeWorkspace(ctx, event.WorkspaceID),
+		event.PromptVariant,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert event: %w", err)
@@ -104,9 +118,11 @@ func (r *PostgresEventRepository) GetByID(ctx context.Context, id string) (*mode
 		SELECT id, timestamp, title, summary, raw_content, magnitude, confidence,
 		       category, status, tags, ST_X(location::geometry), ST_Y(location::geometry),
 		       location_country, location_city, location_region,
-		       created_at, updated_at
+		       created_at, updated_at, rejection_reason, rejection_thresholds,
+		       COALESCE(enrichment_model, ''), workspace_id, COALESCE(prompt_variant, '')
 		FROM events
 		WHERE id = $1
+		  AND ($2::text IS NULL OR workspace_id = $2)
 	`
 
 	var event models.Event
@@ -114,8 +130,10 @@ func (r *PostgresEventRepository) GetByID(ctx context.Context, id string) (*mode
 	var lon, lat sql.NullFloat64
 	var locationCountry, locationCity, locationRegion sql.NullString
 	var tags pq.StringArray
+	var rejectionReason sql.NullString
+	var rejectionThresholdsJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, workspaceFilter(ctx)).Scan(
 		&event.ID,
 		&event.Timestamp,
 		&event.Title,
@@ -133,6 +151,11 @@ func (r *PostgresEventRepository) GetByID(ctx context.Context, id string) (*mode
 		&locationRegion,
 		&event.CreatedAt,
 		&event.UpdatedAt,
+		&rejectionReason,
+		&rejectionThresholdsJSON,
+		&event.EnrichmentModel,
+		&event.WorkspaceID,
+		&event.PromptVariant,
 	)
 
 	if err == sql.ErrNoRows {
@@ -148,6 +171,9 @@ func (r *PostgresEventRepository) GetByID(ctx context.Context, id string) (*mode
 	}
 
 	event.Tags = tags
+	if err := setRejection(&event, rejectionReason, rejectionThresholdsJSON); err != nil {
+		return nil, err
+	}
 
 	// Set location if any location data is present
 	if lon.Valid || lat.Valid || locationCountry.Valid || locationCity.Valid || locationRegion.Valid {
@@ -177,7 +203,7 @@ func (r *PostgresEventRepository) GetByID(ctx context.Context, id string) (*mode
 	return &event, nil
 }
 
-// Update updates an existing event.
+// Update updates an existing event in the workspace ctx is scoped to.
 func (r *PostgresEventRepository) Update(ctx context.Context, event models.Event) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -190,13 +216,18 @@ func (r *PostgresEventRepository) Update(ctx context.Context, event models.Event
 		return fmt.Errorf("failed to marshal confidence: %w", err)
 	}
 
+	rejectionThresholdsJSON, err := marshalRejectionThresholds(event.RejectionThresholds)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE events SET
 			timestamp = $2, title = $3, summary = $4, raw_content = $5,
 			magnitude = $6, confidence = $7, category = $8, status = $9,
 			tags = $10, location = ST_SetSRID(ST_MakePoint($11, $12), 4326),
-			updated_at = $13
-		WHERE id = $1
+			updated_at = $13, rejection_reason = NULLIF($14, ''), rejection_thresholds = $15
+		WHERE id = $1 AND ($16::text IS NULL OR workspace_id = $16)
 	`
 
 	var lon, lat *float64
@@ -219,6 +250,9 @@ func (r *PostgresEventRepository) Update(ctx context.Context, event models.Event
 		lon,
 		lat,
 		time.Now(),
+		event.RejectionReason,
+		rejectionThresholdsJSON,
+		workspaceFilter(ctx),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update event: %w", err)
@@ -247,9 +281,12 @@ func (r *PostgresEventRepository) Update(ctx context.Context, event models.Event
 	return tx.Commit()
 }
 
-// Delete removes an event from the database.
+// Delete removes an event in the workspace ctx is scoped to from the database.
 func (r *PostgresEventRepository) Delete(ctx context.Context, id string) error {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM events WHERE id = $1", id)
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM events WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2)",
+		id, workspaceFilter(ctx),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
@@ -262,10 +299,17 @@ func (r *PostgresEventRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// UpdateStatus updates only the status of an event.
+// UpdateStatus updates only the status of an event in the workspace ctx is scoped to. The
+// rejection reason is kept only if the event is being rejected, since it no longer applies once
+// the event moves to another status.
 func (r *PostgresEventRepository) UpdateStatus(ctx context.Context, id string, status models.EventStatus) error {
-	query := "UPDATE events SET status = $1, updated_at = $2 WHERE id = $3"
-	result, err := r.db.ExecContext(ctx, query, status, time.Now(), id)
+	query := `
+		UPDATE events SET status = $1, updated_at = $2,
+			rejection_reason = CASE WHEN $1 = 'rejected' THEN rejection_reason END,
+			rejection_thresholds = CASE WHEN $1 = 'rejected' THEN rejection_thresholds END
+		WHERE id = $3 AND ($4::text IS NULL OR workspace_id = $4)
+	`
+	result, err := r.db.ExecContext(ctx, query, status, time.Now(), id, workspaceFilter(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
@@ -278,12 +322,62 @@ func (r *PostgresEventRepository) UpdateStatus(ctx context.Context, id string, s
 	return nil
 }
 
-// Query retrieves events based on filter criteria.
+// Reject marks an event in the workspace ctx is scoped to as rejected with the given reason. Only
+// the status columns are written, so sources or corroboration added concurrently are kept.
+func (r *PostgresEventRepository) Reject(ctx context.Context, id string, reason string) error {
+	query := `
+		UPDATE events SET status = $1, rejection_reason = $2, rejection_thresholds = NULL, updated_at = $3
+		WHERE id = $4 AND ($5::text IS NULL OR workspace_id = $5)
+	`
+	result, err := r.db.ExecContext(ctx, query, models.EventStatusRejected, reason, time.Now(), id, workspaceFilter(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to reject event: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("event not found: %s", id)
+	}
+
+	return nil
+}
+
+// marshalRejectionThresholds serializes a threshold snapshot, returning nil for a nil snapshot
+func marshalRejectionThresholds(thresholds *models.RejectionThresholds) ([]byte, error) {
+	if thresholds == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rejection thresholds: %w", err)
+	}
+	return data, nil
+}
+
+// setRejection fills an event's rejection fields from their scanned columns
+func setRejection(event *models.Event, reason sql.NullString, thresholdsJSON []byte) error {
+	event.RejectionReason = reason.String
+	if len(thresholdsJSON) == 0 {
+		return nil
+	}
+	event.RejectionThresholds = &models.RejectionThresholds{}
+	if err := json.Unmarshal(thresholdsJSON, event.RejectionThresholds); err != nil {
+		return fmt.Errorf("failed to unmarshal rejection thresholds: %w", err)
+	}
+	return nil
+}
+
+// Query retrieves events based on filter criteria. Queries without a workspace are limited to
+// the workspace ctx is scoped to, if any.
 func (r *PostgresEventRepository) Query(ctx context.Context, query models.EventQuery) (*models.EventResponse, error) {
 	// Validate query
 	if err := query.Validate(); err != nil {
 		return nil, err
 	}
+	query.WorkspaceID = queryWorkspace(ctx, query.WorkspaceID)
+	if err := r.checkCursors(ctx, query); err != nil {
+		return nil, err
+	}
 
 	// Build SQL query
 	sqlQuery, args := r.buildQuery(query)
@@ -292,13 +386,13 @@ func (r *PostgresEventRepository) Query(ctx context.Context, query models.EventQ
 	countQuery := r.buildCountQuery(query)
 	var total int
 	if err := r.db.QueryRowContext(ctx, countQuery, args[:len(args)-2]...).Scan(&total); err != nil {
-		return nil, fmt.Errorf("failed to count events: %w", err)
+		return nil, semanticQueryError(query, fmt.Errorf("failed to count events: %w", err))
 	}
 
 	// Execute main query
 	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query events: %w", err)
+		return nil, semanticQueryError(query, fmt.Errorf("failed to query events: %w", err))
 	}
 	defer rows.Close()
 
@@ -309,6 +403,8 @@ func (r *PostgresEventRepository) Query(ctx context.Context, query models.EventQ
 		var lon, lat sql.NullFloat64
 		var locationCountry, locationCity, locationRegion sql.NullString
 		var tags pq.StringArray
+		var rejectionReason sql.NullString
+		var rejectionThresholdsJSON []byte
 
 		err := rows.Scan(
 			&event.ID,
@@ -328,6 +424,11 @@ func (r *PostgresEventRepository) Query(ctx context.Context, query models.EventQ
 			&locationRegion,
 			&event.CreatedAt,
 			&event.UpdatedAt,
+			&rejectionReason,
+			&rejectionThresholdsJSON,
+			&event.EnrichmentModel,
+			&event.WorkspaceID,
+			&event.PromptVariant,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
@@ -338,6 +439,9 @@ func (r *PostgresEventRepository) Query(ctx context.Context, query models.EventQ
 		}
 
 		event.Tags = tags
+		if err := setRejection(&event, rejectionReason, rejectionThresholdsJSON); err != nil {
+			return nil, err
+		}
 
 		// Set location if any location data is present
 		if lon.Valid || lat.Valid || locationCountry.Valid || locationCity.Valid || locationRegion.Valid {
@@ -371,14 +475,38 @@ func (r *PostgresEventRepository) Query(ctx context.Context, query models.EventQ
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return &models.EventResponse{
+	response := &models.EventResponse{
 		Events:  events,
 		Page:    query.Page,
 		Limit:   query.Limit,
 		Total:   total,
 		HasMore: (query.Page * query.Limit) < total,
 		Query:   query.SearchQuery,
-	}, nil
+	}
+	response.SetNextCursor(query)
+	return response, nil
+}
+
+// checkCursors returns models.ErrUnknownCursor if a query's after_id or before_id names no event
+// in its workspace, rather than quietly returning no events
+func (r *PostgresEventRepository) checkCursors(ctx context.Context, query models.EventQuery) error {
+	for _, id := range []string{query.AfterID, query.BeforeID} {
+		if id == "" {
+			continue
+		}
+		var exists bool
+		err := r.db.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM events WHERE id = $1 AND ($2 = '' OR workspace_id = $2))",
+			id, query.WorkspaceID,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to look up cursor event: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("%w: %s", models.ErrUnknownCursor, id)
+		}
+	}
+	return nil
 }
 
 // buildQuery constructs the SQL query from EventQuery.
@@ -443,11 +571,46 @@ func (r *PostgresEventRepository) buildQuery(q models.EventQuery) (string, []int
 		argIdx++
 	}
 
+	// Workspace filter
+	if q.WorkspaceID != "" {
+		conditions = append(conditions, fmt.Sprintf("workspace_id = $%d", argIdx))
+		args = append(args, q.WorkspaceID)
+		argIdx++
+	}
+
+	// Cursor pagination
+	if q.AfterID != "" {
+		conditions = append(conditions, cursorCondition(">", argIdx))
+		args = append(args, q.AfterID)
+		argIdx++
+	}
+	if q.BeforeID != "" {
+		conditions = append(conditions, cursorCondition("<", argIdx))
+		args = append(args, q.BeforeID)
+		argIdx++
+	}
+
+	// Semantic search
+	embeddingIdx := 0
+	if len(q.Embedding) > 0 {
+		conditions = append(conditions, semanticCondition(argIdx))
+		args = append(args, vectorLiteral(q.Embedding), q.MinSimilarity)
+		embeddingIdx = argIdx
+		argIdx += 2
+	}
+
 	// Build WHERE clause
 	whereClause := "WHERE " + strings.Join(conditions, " AND ")
 
-	// Build ORDER BY clause
-	orderBy := fmt.Sprintf("ORDER BY %s %s", q.SortBy, q.SortOrder)
+	// Build ORDER BY clause, with the ID breaking ties so pages are stable; cursor pages follow
+	// cursor_seq and semantic matches are ranked nearest first
+	orderBy := fmt.Sprintf("ORDER BY %s %s, id %s", q.SortBy, q.SortOrder, q.SortOrder)
+	if q.UsesCursor() {
+		orderBy = fmt.Sprintf("ORDER BY cursor_seq %s", q.SortOrder)
+	}
+	if embeddingIdx > 0 {
+		orderBy = fmt.Sprintf("ORDER BY embedding <=> $%d::vector", embeddingIdx)
+	}
 
 	// Add LIMIT and OFFSET
 	args = append(args, q.Limit, q.GetOffset())
@@ -456,7 +619,8 @@ func (r *PostgresEventRepository) buildQuery(q models.EventQuery) (string, []int
 		SELECT id, timestamp, title, summary, raw_content, magnitude, confidence,
 		       category, status, tags, ST_X(location::geometry), ST_Y(location::geometry),
 		       location_country, location_city, location_region,
-		       created_at, updated_at
+		       created_at, updated_at, rejection_reason, rejection_thresholds,
+		       COALESCE(enrichment_model, ''), workspace_id, COALESCE(prompt_variant, '')
 		FROM events
 		%s
 		%s
@@ -466,6 +630,18 @@ func (r *PostgresEventRepository) buildQuery(q models.EventQuery) (string, []int
 	return query, args
 }
 
+// cursorCondition matches events past the cursor event, whose ID is bound at $idx, in
+// cursor_seq order; op is ">" for events after it and "<" for events before it
+func cursorCondition(op string, idx int) string {
+	return fmt.Sprintf("cursor_seq %s (SELECT cursor_seq FROM events WHERE id = $%d)", op, idx)
+}
+
+// semanticCondition matches events whose embedding, bound at $idx, is at least as similar as the
+// floor bound at $idx+1
+func semanticCondition(idx int) string {
+	return fmt.Sprintf("embedding IS NOT NULL AND 1 - (embedding <=> $%d::vector) >= $%d", idx, idx+1)
+}
+
 // buildCountQuery constructs the count query.
 func (r *PostgresEventRepository) buildCountQuery(q models.EventQuery) string {
 	conditions := []string{}
@@ -512,6 +688,25 @@ func (r *PostgresEventRepository) buildCountQuery(q models.EventQuery) string {
 		argIdx++
 	}
 
+	if q.WorkspaceID != "" {
+		conditions = append(conditions, fmt.Sprintf("workspace_id = $%d", argIdx))
+		argIdx++
+	}
+
+	if q.AfterID != "" {
+		conditions = append(conditions, cursorCondition(">", argIdx))
+		argIdx++
+	}
+	if q.BeforeID != "" {
+		conditions = append(conditions, cursorCondition("<", argIdx))
+		argIdx++
+	}
+
+	if len(q.Embedding) > 0 {
+		conditions = append(conditions, semanticCondition(argIdx))
+		argIdx += 2
+	}
+
 	whereClause := "WHERE " + strings.Join(conditions, " AND ")
 
 	return fmt.Sprintf("SELECT COUNT(*) FROM events %s", whereClause)
@@ -577,7 +772,8 @@ func (r *PostgresEventRepository) loadEventRelations(ctx context.Context, event
 	// Load sources
 	sourcesQuery := `
 		SELECT s.id, s.type, s.url, s.author, s.published_at, s.retrieved_at,
-		       s.raw_content, s.content_hash, s.credibility, s.metadata
+		       s.raw_content, s.content_hash, s.credibility, s.metadata,
+		       s.media, COALESCE(s.external_url, ''), COALESCE(s.enrichment_model, '')
 		FROM sources s
 		JOIN event_sources es ON s.id = es.source_id
 		WHERE es.event_id = $1
@@ -605,6 +801,9 @@ func (r *PostgresEventRepository) loadEventRelations(ctx context.Context, event
 			&source.ContentHash,
 			&source.Credibility,
 			&metadataJSON,
+			pq.Array(&source.Media),
+			&source.ExternalURL,
+			&source.EnrichmentModel,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to scan source: %w", err)
@@ -677,14 +876,494 @@ func (r *PostgresEventRepository) HasSourceEvents(ctx context.Context, sourceID
 	return count > 0, nil
 }
 
-// Count returns the total number of events matching the given query.
+// FindSimilarTitle returns the non-archived event since the given time whose title has the highest
+// trigram similarity to title, if it reaches minSimilarity, in the workspace ctx is scoped to.
+// Returns "" if there is no match.
+func (r *PostgresEventRepository) FindSimilarTitle(ctx context.Context, title string, since time.Time, minSimilarity float64) (string, float64, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The % operator can use the trigram index but compares against this setting,
+	// so set it for this transaction only
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)",
+		strconv.FormatFloat(minSimilarity, 'f', -1, 64)); err != nil {
+		return "", 0, fmt.Errorf("failed to set similarity threshold: %w", err)
+	}
+
+	var id string
+	var similarity float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, similarity(title, $1) AS sim
+		FROM events
+		WHERE title % $1
+			AND timestamp >= $2
+			AND status != 'archived'
+			AND ($3::text IS NULL OR workspace_id = $3)
+		ORDER BY sim DESC, timestamp DESC
+		LIMIT 1
+	`, title, since, workspaceFilter(ctx)).Scan(&id, &similarity)
+	if err == sql.ErrNoRows {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to find similar titles: %w", err)
+	}
+
+	return id, similarity, nil
+}
+
+// semanticQueryError marks err as models.ErrSemanticSearchUnavailable when a semantic query failed
+// because the database has no pgvector extension or events.embedding column.
+func semanticQueryError(q models.EventQuery, err error) error {
+	if len(q.Embedding) > 0 && vectorUnavailable(err) {
+		return fmt.Errorf("%w: %w", models.ErrSemanticSearchUnavailable, err)
+	}
+	return err
+}
+
+// vectorUnavailable reports whether err is Postgres rejecting the embedding column, the vector
+// type or its operators: undefined_column, undefined_object or undefined_function.
+func vectorUnavailable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "42703", "42704", "42883":
+		return true
+	}
+	return false
+}
+
+// isUniqueViolation reports whether err is Postgres rejecting a row that violates the named
+// unique constraint or index.
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// vectorLiteral formats an embedding as a pgvector literal, e.g. [0.1,-0.2]
+func vectorLiteral(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// StoreEmbedding saves the embedding of an event's title and summary for similarity search.
+func (r *PostgresEventRepository) StoreEmbedding(ctx context.Context, eventID string, embedding []float32) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE events SET embedding = $2::vector WHERE id = $1", eventID, vectorLiteral(embedding)); err != nil {
+		return fmt.Errorf("failed to store event embedding: %w", err)
+	}
+	return nil
+}
+
+// FindSimilarEmbeddings returns up to limit non-archived events since the given time whose
+// embeddings have at least minSimilarity cosine similarity to embedding, most similar first,
+// in the workspace ctx is scoped to.
+func (r *PostgresEventRepository) FindSimilarEmbeddings(ctx context.Context, embedding []float32, since time.Time, limit int, minSimilarity float64) ([]models.SimilarEvent, error) {
+	// Order by distance alone so the HNSW index can serve the nearest neighbours,
+	// then drop the ones below the similarity floor
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, similarity FROM (
+			SELECT id, 1 - (embedding <=> $1::vector) AS similarity
+			FROM events
+			WHERE embedding IS NOT NULL
+				AND timestamp >= $2
+				AND status != 'archived'
+				AND ($5::text IS NULL OR workspace_id = $5)
+			ORDER BY embedding <=> $1::vector
+			LIMIT $3
+		) nearest
+		WHERE similarity >= $4
+		ORDER BY similarity DESC
+	`, vectorLiteral(embedding), since, limit, minSimilarity, workspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var similar []models.SimilarEvent
+	for rows.Next() {
+		var s models.SimilarEvent
+		if err := rows.Scan(&s.ID, &s.Similarity); err != nil {
+			return nil, fmt.Errorf("failed to scan similar event: %w", err)
+		}
+		similar = append(similar, s)
+	}
+	return similar, rows.Err()
+}
+
+// FindDuplicatePairs returns pairs of non-archived events since the given time that are likely
+// duplicates: titles at least minSimilarity similar (pg_trgm), or sources with the same URL. Both
+// events of a pair are in the same workspace, the one ctx is scoped to.
+func (r *PostgresEventRepository) FindDuplicatePairs(ctx context.Context, since time.Time, minSimilarity float64) ([]models.DuplicatePair, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The % operator compares against this setting; see FindSimilarTitle
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)",
+		strconv.FormatFloat(minSimilarity, 'f', -1, 64)); err != nil {
+		return nil, fmt.Errorf("failed to set similarity threshold: %w", err)
+	}
+
+	query := `
+		WITH recent AS (
+			SELECT id, title, workspace_id FROM events
+			WHERE timestamp >= $1 AND status != 'archived'
+			  AND ($2::text IS NULL OR workspace_id = $2)
+		)
+		SELECT a.id, b.id, similarity(a.title, b.title), '` + models.DuplicateReasonTitle + `'
+		FROM recent a
+		JOIN recent b ON a.id < b.id AND a.workspace_id = b.workspace_id AND a.title % b.title
+		UNION ALL
+		SELECT DISTINCT a.id, b.id, 1.0::real, '` + models.DuplicateReasonSharedSource + `'
+		FROM recent a
+		JOIN event_sources esa ON esa.event_id = a.id
+		JOIN sources sa ON sa.id = esa.source_id AND sa.url <> ''
+		JOIN sources sb ON sb.url = sa.url
+		JOIN event_sources esb ON esb.source_id = sb.id
+		JOIN recent b ON b.id = esb.event_id AND a.id < b.id AND a.workspace_id = b.workspace_id
+	`
+
+	rows, err := tx.QueryContext(ctx, query, since, workspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate events: %w", err)
+	}
+	defer rows.Close()
+
+	var pairs []models.DuplicatePair
+	for rows.Next() {
+		var p models.DuplicatePair
+		if err := rows.Scan(&p.EventA, &p.EventB, &p.Similarity, &p.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate pair: %w", err)
+		}
+		pairs = append(pairs, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// GetDuplicateEvents returns summaries of the given events in the workspace ctx is scoped to,
+// keyed by ID.
+func (r *PostgresEventRepository) GetDuplicateEvents(ctx context.Context, ids []string) (map[string]models.DuplicateEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.id, e.title, e.status, e.timestamp,
+			(SELECT COUNT(*) FROM event_sources es WHERE es.event_id = e.id)
+		FROM events e
+		WHERE e.id = ANY($1) AND ($2::text IS NULL OR e.workspace_id = $2)
+	`, pq.Array(ids), workspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make(map[string]models.DuplicateEvent, len(ids))
+	for rows.Next() {
+		var e models.DuplicateEvent
+		if err := rows.Scan(&e.ID, &e.Title, &e.Status, &e.Timestamp, &e.SourceCount); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate event: %w", err)
+		}
+		events[e.ID] = e
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetEventDistribution counts events per magnitude and confidence bucket, grouped by category,
+// in the workspace ctx is scoped to.
+// The epsilon keeps values on a bucket boundary, such as 0.3 with width 0.1, out of the bucket below.
+func (r *PostgresEventRepository) GetEventDistribution(ctx context.Context, q models.DistributionQuery) (*models.EventDistribution, error) {
+	args := []interface{}{q.Since, q.MagnitudeWidth, q.ConfidenceWidth}
+	statusFilter := "status != 'archived'"
+	if q.Status != nil {
+		args = append(args, string(*q.Status))
+		statusFilter = "status = $4"
+	}
+	args = append(args, workspaceFilter(ctx))
+	workspaceFilterSQL := fmt.Sprintf("($%[1]d::text IS NULL OR workspace_id = $%[1]d)", len(args))
+
+	query := `
+		WITH scoped AS (
+			SELECT category, magnitude, COALESCE((confidence->>'score')::float, 0) AS confidence
+			FROM events
+			WHERE timestamp >= $1 AND ` + statusFilter + ` AND ` + workspaceFilterSQL + `
+		)
+		SELECT '` + models.DistributionMagnitude + `', category, FLOOR(magnitude::float / $2::float + 1e-9)::int AS bucket, COUNT(*)
+		FROM scoped
+		GROUP BY category, bucket
+		UNION ALL
+		SELECT '` + models.DistributionConfidence + `', category, FLOOR(confidence / $3::float + 1e-9)::int AS bucket, COUNT(*)
+		FROM scoped
+		GROUP BY category, bucket
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event distribution: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []models.DistributionCount
+	for rows.Next() {
+		var c models.DistributionCount
+		if err := rows.Scan(&c.Metric, &c.Category, &c.Bucket, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan distribution count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating distribution counts: %w", err)
+	}
+
+	return models.BuildDistribution(q, counts), nil
+}
+
+// GetPromptVariantStats aggregates the events each enrichment prompt variant created since the
+// given time, in the workspace ctx is scoped to. Events created outside an A/B test are left out.
+func (r *PostgresEventRepository) GetPromptVariantStats(ctx context.Context, since time.Time) ([]models.PromptVariantStats, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT prompt_variant,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE status IN ('published', 'archived')),
+		       COUNT(*) FILTER (WHERE status = 'rejected'),
+		       COALESCE(AVG((confidence->>'score')::float), 0),
+		       COALESCE(AVG(magnitude), 0)
+		FROM events
+		WHERE prompt_variant IS NOT NULL
+		  AND created_at >= $1
+		  AND ($2::text IS NULL OR workspace_id = $2)
+		GROUP BY prompt_variant
+	`, since, workspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt variant stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.PromptVariantStats
+	for rows.Next() {
+		var s models.PromptVariantStats
+		if err := rows.Scan(&s.Variant, &s.Events, &s.Published, &s.Rejected, &s.AvgConfidence, &s.AvgMagnitude); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt variant stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating prompt variant stats: %w", err)
+	}
+
+	return models.BuildPromptVariantStats(stats), nil
+}
+
+// GetEntityTimeline counts published events mentioning an entity per time bucket, in the
+// workspace ctx is scoped to.
+// Buckets with no events are omitted; see EntityTimelineQuery.FillGaps.
+func (r *PostgresEventRepository) GetEntityTimeline(ctx context.Context, q models.EntityTimelineQuery) ([]models.EntityTimelineBucket, error) {
+	args := []interface{}{q.Name, q.Since, q.Until}
+	categoryFilter := ""
+	if q.Category != nil {
+		args = append(args, *q.Category)
+		categoryFilter = "AND e.category = $4"
+	}
+	args = append(args, workspaceFilter(ctx))
+	workspaceFilterSQL := fmt.Sprintf("AND ($%[1]d::text IS NULL OR e.workspace_id = $%[1]d)", len(args))
+
+	// An event can link several entity rows with the same name, so dedupe before counting
+	query := `
+		WITH matched AS (
+			SELECT DISTINCT e.id, e.timestamp, e.magnitude
+			FROM events e
+			JOIN event_entities ee ON ee.event_id = e.id
+			JOIN entities en ON en.id = ee.entity_id
+			WHERE e.status = 'published'
+				AND (LOWER(en.name) = LOWER($1) OR LOWER(en.normalized_name) = LOWER($1))
+				AND e.timestamp >= $2
+				AND e.timestamp < $3
+				` + categoryFilter + `
+				` + workspaceFilterSQL + `
+		)
+		SELECT
+			EXTRACT(EPOCH FROM bucket)::bigint as time,
+			COUNT(*),
+			COALESCE(SUM(magnitude), 0)::float
+		FROM (
+			SELECT ` + epochBucket("timestamp", int(q.Granularity.Step().Seconds())) + ` as bucket, magnitude
+			FROM matched
+		) bucketed
+		GROUP BY bucket
+		ORDER BY bucket ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entity timeline: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []models.EntityTimelineBucket
+	for rows.Next() {
+		var bucket models.EntityTimelineBucket
+		var timestamp int64
+		var weighted float64
+
+		if err := rows.Scan(&timestamp, &bucket.Count, &weighted); err != nil {
+			return nil, fmt.Errorf("failed to scan entity timeline: %w", err)
+		}
+
+		bucket.Time = time.Unix(timestamp, 0).UTC()
+		if q.Weighted {
+			bucket.WeightedCount = &weighted
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating entity timeline: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// GetRelatedEvents finds recent published events sharing entities, tags or source URLs with an
+// event in the same workspace, ranked by models.RelatedScore. It returns nil if the event doesn't
+// exist, isn't published or is outside the workspace ctx is scoped to.
+func (r *PostgresEventRepository) GetRelatedEvents(ctx context.Context, q models.RelatedEventsQuery) ([]models.RelatedEvent, int, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM events WHERE id = $1 AND status = 'published' AND ($2::text IS NULL OR workspace_id = $2))`,
+		q.EventID, workspaceFilter(ctx),
+	).Scan(&exists); err != nil {
+		return nil, 0, fmt.Errorf("failed to check event: %w", err)
+	}
+	if !exists {
+		return nil, 0, nil
+	}
+
+	// Each overlap is found through an index (entity name, tags GIN, source URL) and scored
+	// in SQL, so ranking and paging happen in one pass
+	query := `
+		WITH target AS (
+			SELECT id, COALESCE(tags, '{}') AS tags, workspace_id FROM events WHERE id = $1
+		),
+		entity_matches AS (
+			SELECT ee.event_id, array_agg(DISTINCT en.normalized_name) AS names
+			FROM event_entities ee
+			JOIN entities en ON en.id = ee.entity_id
+			WHERE ee.event_id <> $1
+				AND en.normalized_name IN (
+					SELECT ten.normalized_name
+					FROM event_entities tee
+					JOIN entities ten ON ten.id = tee.entity_id
+					WHERE tee.event_id = $1
+				)
+			GROUP BY ee.event_id
+		),
+		tag_matches AS (
+			SELECT e.id AS event_id,
+				ARRAY(SELECT unnest(e.tags) INTERSECT SELECT unnest(t.tags)) AS tags
+			FROM events e, target t
+			WHERE e.id <> t.id AND e.tags && t.tags
+		),
+		source_matches AS (
+			SELECT es.event_id, COUNT(DISTINCT s.url) AS shared
+			FROM event_sources es
+			JOIN sources s ON s.id = es.source_id
+			WHERE es.event_id <> $1
+				AND s.url IN (
+					SELECT ts.url
+					FROM event_sources tes
+					JOIN sources ts ON ts.id = tes.source_id
+					WHERE tes.event_id = $1 AND ts.url <> ''
+				)
+			GROUP BY es.event_id
+		),
+		scored AS (
+			SELECT e.id, e.title, e.category, COALESCE(e.magnitude, 0)::float AS magnitude, e.timestamp,
+				COALESCE(em.names, '{}') AS names,
+				COALESCE(tm.tags, '{}') AS tags,
+				COALESCE(sm.shared, 0) AS shared,
+				COALESCE(cardinality(em.names), 0) * $3::float
+					+ COALESCE(cardinality(tm.tags), 0) * $4::float
+					+ COALESCE(sm.shared, 0) * $5::float AS score
+			FROM events e
+			LEFT JOIN entity_matches em ON em.event_id = e.id
+			LEFT JOIN tag_matches tm ON tm.event_id = e.id
+			LEFT JOIN source_matches sm ON sm.event_id = e.id
+			WHERE e.status = 'published'
+				AND e.workspace_id = (SELECT workspace_id FROM target)
+				AND e.timestamp >= $2
+				AND (em.event_id IS NOT NULL OR tm.event_id IS NOT NULL OR sm.event_id IS NOT NULL)
+		)
+		SELECT id, title, category, magnitude, timestamp, names, tags, shared, score, COUNT(*) OVER ()
+		FROM scored
+		ORDER BY score DESC, timestamp DESC
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, q.EventID, q.Since,
+		models.RelatedEntityWeight, models.RelatedTagWeight, models.RelatedSourceWeight,
+		q.Limit, q.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get related events: %w", err)
+	}
+	defer rows.Close()
+
+	related := []models.RelatedEvent{}
+	total := 0
+	for rows.Next() {
+		var e models.RelatedEvent
+		var names, tags pq.StringArray
+		if err := rows.Scan(&e.ID, &e.Title, &e.Category, &e.Magnitude, &e.Timestamp,
+			&names, &tags, &e.SharedSources, &e.Score, &total); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan related event: %w", err)
+		}
+		e.SharedEntities = []string(names)
+		e.SharedTags = []string(tags)
+		related = append(related, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating related events: %w", err)
+	}
+
+	return related, total, nil
+}
+
+// Count returns the total number of events matching the given query, scoped like Query.
 func (r *PostgresEventRepository) Count(ctx context.Context, query models.EventQuery) (int, error) {
+	query.WorkspaceID = queryWorkspace(ctx, query.WorkspaceID)
+	if err := r.checkCursors(ctx, query); err != nil {
+		return 0, err
+	}
+
 	// Build count query using the existing helper
 	countQuery, args := r.buildCountQueryWithArgs(query)
 
 	var total int
 	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return 0, fmt.Errorf("failed to count events: %w", err)
+		return 0, semanticQueryError(query, fmt.Errorf("failed to count events: %w", err))
 	}
 
 	return total, nil
@@ -746,21 +1425,45 @@ func (r *PostgresEventRepository) buildCountQueryWithArgs(q models.EventQuery) (
 		argIdx++
 	}
 
+	if q.WorkspaceID != "" {
+		conditions = append(conditions, fmt.Sprintf("workspace_id = $%d", argIdx))
+		args = append(args, q.WorkspaceID)
+		argIdx++
+	}
+
+	if q.AfterID != "" {
+		conditions = append(conditions, cursorCondition(">", argIdx))
+		args = append(args, q.AfterID)
+		argIdx++
+	}
+	if q.BeforeID != "" {
+		conditions = append(conditions, cursorCondition("<", argIdx))
+		args = append(args, q.BeforeID)
+		argIdx++
+	}
+
+	if len(q.Embedding) > 0 {
+		conditions = append(conditions, semanticCondition(argIdx))
+		args = append(args, vectorLiteral(q.Embedding), q.MinSimilarity)
+		argIdx += 2
+	}
+
 	whereClause := "WHERE " + strings.Join(conditions, " AND ")
 
 	return fmt.Sprintf("SELECT COUNT(*) FROM events %s", whereClause), args
 }
 
-// GetEventsBetween retrieves events within a time range
+// GetEventsBetween retrieves events within a time range in the workspace ctx is scoped to
 func (r *PostgresEventRepository) GetEventsBetween(ctx context.Context, startTime, endTime time.Time, categories []string, limit int) ([]models.Event, error) {
 	query := `
-		SELECT id, timestamp, title, summary, category, tags, created_at
+		SELECT id, timestamp, title, summary, magnitude, category, tags, created_at
 		FROM events
 		WHERE timestamp >= $1 AND timestamp <= $2
+		  AND ($3::text IS NULL OR workspace_id = $3)
 	`
 
-	args := []interface{}{startTime, endTime}
-	argIdx := 3
+	args := []interface{}{startTime, endTime, workspaceFilter(ctx)}
+	argIdx := 4
 
 	if len(categories) > 0 {
 		query += fmt.Sprintf(" AND category = ANY($%d)", argIdx)
@@ -787,6 +1490,60 @@ func (r *PostgresEventRepository) GetEventsBetween(ctx context.Context, startTim
 			&event.Timestamp,
 			&event.Title,
 			&event.Summary,
+			&event.Magnitude,
+			&event.Category,
+			&tags,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		event.Tags = tags
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetTopEventsByCategory returns up to perCategory events per category in the time range and the
+// workspace ctx is scoped to, ranked by magnitude and then recency. Rejected and archived events
+// are excluded.
+// An empty categories list includes every category.
+func (r *PostgresEventRepository) GetTopEventsByCategory(ctx context.Context, startTime, endTime time.Time, categories []string, perCategory int) ([]models.Event, error) {
+	query := `
+		SELECT id, timestamp, title, summary, magnitude, category, tags, created_at
+		FROM (
+			SELECT id, timestamp, title, summary, magnitude, category, tags, created_at,
+				ROW_NUMBER() OVER (PARTITION BY category ORDER BY magnitude DESC, timestamp DESC) AS rank
+			FROM events
+			WHERE timestamp >= $1 AND timestamp <= $2
+				AND status NOT IN ('rejected', 'archived')
+				AND (cardinality($3::text[]) = 0 OR category = ANY($3))
+				AND ($5::text IS NULL OR workspace_id = $5)
+		) ranked
+		WHERE rank <= $4
+		ORDER BY category, rank
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, startTime, endTime, pq.Array(categories), perCategory, workspaceFilter(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top events by category: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.Event
+	for rows.Next() {
+		var event models.Event
+		var tags pq.StringArray
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Timestamp,
+			&event.Title,
+			&event.Summary,
+			&event.Magnitude,
 			&event.Category,
 			&tags,
 			&event.CreatedAt,
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/lib/pq"
+)
+
+func TestVectorLiteral(t *testing.T) {
+	if got := vectorLiteral([]float32{0.5, -1, 0.125}); got != "[0.5,-1,0.125]" {
+		t.Errorf("vectorLiteral = %q", got)
+	}
+	if got := vectorLiteral(nil); got != "[]" {
+		t.Errorf("vectorLiteral(nil) = %q", got)
+	}
+}
+
+func TestSemanticQueryError(t *testing.T) {
+	semantic := models.EventQuery{Embedding: []float32{0.5}}
+	missingColumn := fmt.Errorf("failed to count events: %w", &pq.Error{Code: "42703", Message: `column "embedding" does not exist`})
+
+	if err := semanticQueryError(semantic, missingColumn); !errors.Is(err, models.ErrSemanticSearchUnavailable) {
+		t.Errorf("expected a missing embedding column to make semantic search unavailable, got %v", err)
+	}
+	if err := semanticQueryError(models.EventQuery{}, missingColumn); errors.Is(err, models.ErrSemanticSearchUnavailable) {
+		t.Error("expected non-semantic queries to keep their error")
+	}
+	if err := semanticQueryError(semantic, &pq.Error{Code: "57014"}); errors.Is(err, models.ErrSemanticSearchUnavailable) {
+		t.Error("expected other database errors to keep their error")
+	}
+}
+
+func TestBuildQuerySemantic(t *testing.T) {
+	r := &PostgresEventRepository{}
+	q := models.EventQuery{
+		Categories:    []models.Category{models.CategoryMilitary},
+		Embedding:     []float32{0.5, -1},
+		MinSimilarity: 0.3,
+	}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	query, args := r.buildQuery(q)
+	// status, categories, embedding, similarity floor, limit, offset
+	if len(args) != 6 {
+		t.Fatalf("got %d args, want 6: %v", len(args), args)
+	}
+	if args[2] != "[0.5,-1]" || args[3] != 0.3 {
+		t.Errorf("semantic args = %v, %v", args[2], args[3])
+	}
+	if !strings.Contains(query, "category = ANY($2) AND embedding IS NOT NULL AND 1 - (embedding <=> $3::vector) >= $4") {
+		t.Errorf("semantic search should be ANDed with the other filters:\n%s", query)
+	}
+	if !strings.Contains(query, "ORDER BY embedding <=> $3::vector") {
+		t.Errorf("semantic results should be ranked by distance:\n%s", query)
+	}
+
+	// The count query must take the same arguments, minus limit and offset
+	countQuery, countArgs := r.buildCountQueryWithArgs(q)
+	if countQuery != r.buildCountQuery(q) {
+		t.Errorf("count queries differ:\n%s\n%s", countQuery, r.buildCountQuery(q))
+	}
+	if len(countArgs) != len(args)-2 || !strings.Contains(countQuery, "$4") {
+		t.Errorf("count query args = %v for:\n%s", countArgs, countQuery)
+	}
+}
+
+func TestBuildQueryWorkspace(t *testing.T) {
+	r := &PostgresEventRepository{}
+	q := models.EventQuery{Tags: []string{"ukraine"}, WorkspaceID: "acme"}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	query, args := r.buildQuery(q)
+	// status, tags, workspace, limit, offset
+	if len(args) != 5 || args[2] != "acme" {
+		t.Fatalf("args = %v", args)
+	}
+	if !strings.Contains(query, "tags && $2 AND workspace_id = $3") {
+		t.Errorf("workspace should be ANDed with the other filters:\n%s", query)
+	}
+
+	countQuery, countArgs := r.buildCountQueryWithArgs(q)
+	if countQuery != r.buildCountQuery(q) || len(countArgs) != 3 {
+		t.Errorf("count query args = %v for:\n%s", countArgs, countQuery)
+	}
+
+	// Without a workspace every workspace is queried
+	q.WorkspaceID = ""
+	if query, _ := r.buildQuery(q); strings.Contains(query, "workspace_id = ") {
+		t.Errorf("unscoped query should not filter by workspace:\n%s", query)
+	}
+}
+
+func TestBuildQueryCursor(t *testing.T) {
+	r := &PostgresEventRepository{}
+	q := models.EventQuery{AfterID: "evt-1", SortBy: models.SortByCreatedAt}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	query, args := r.buildQuery(q)
+	// status, cursor, limit, offset
+	if len(args) != 4 || args[1] != "evt-1" || args[3] != 0 {
+		t.Fatalf("args = %v", args)
+	}
+	if !strings.Contains(query, "cursor_seq > (SELECT cursor_seq FROM events WHERE id = $2)") {
+		t.Errorf("expected an after_id cursor condition:\n%s", query)
+	}
+	if !strings.Contains(query, "ORDER BY cursor_seq asc") {
+		t.Errorf("after_id pages should run in cursor order, oldest first:\n%s", query)
+	}
+
+	countQuery, countArgs := r.buildCountQueryWithArgs(q)
+	if countQuery != r.buildCountQuery(q) || len(countArgs) != 2 {
+		t.Errorf("count query args = %v for:\n%s", countArgs, countQuery)
+	}
+
+	q = models.EventQuery{BeforeID: "evt-9"}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if query, _ := r.buildQuery(q); !strings.Contains(query, "cursor_seq < (SELECT cursor_seq FROM events WHERE id = $2)") ||
+		!strings.Contains(query, "ORDER BY cursor_seq desc") {
+		t.Errorf("expected a before_id cursor condition, newest first:\n%s", query)
+	}
+}
+
+func TestQueryAfterID_ReturnsLateEventsWithOlderTimestamps(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewPostgresEventRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	newEvent := func(id string, timestamp, createdAt time.Time) models.Event {
+		return models.Event{
+			ID:         id,
+			Timestamp:  timestamp,
+			Title:      "Cursor test " + id,
+			Summary:    "Cursor test event",
+			Magnitude:  5,
+			Category:   models.CategoryGeopolitics,
+			Status:     models.EventStatusPublished,
+			Confidence: models.Confidence{Score: 0.8, Level: models.ConfidenceHigh},
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		}
+	}
+
+	// The poller saw the cursor event; the late event is stored after it but happened earlier
+	if err := repo.Create(ctx, newEvent("evt-cursor", now, now.Add(-time.Minute))); err != nil {
+		t.Fatalf("failed to create cursor event: %v", err)
+	}
+	if err := repo.Create(ctx, newEvent("evt-late", now.Add(-time.Hour), now)); err != nil {
+		t.Fatalf("failed to create late event: %v", err)
+	}
+
+	q := models.EventQuery{AfterID: "evt-cursor"}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := repo.Query(ctx, q)
+	if err != nil {
+		t.Fatalf("Query() returned error: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].ID != "evt-late" {
+		t.Errorf("after_id page = %v, want the late event", resp.Events)
+	}
+}
+
+func TestQueryAfterID_ReturnsEventsPublishedAfterTheCursor(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewPostgresEventRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	newEvent := func(id string, status models.EventStatus, createdAt time.Time) models.Event {
+		return models.Event{
+			ID:         id,
+			Timestamp:  createdAt,
+			Title:      "Cursor test " + id,
+			Summary:    "Cursor test event",
+			Magnitude:  5,
+			Category:   models.CategoryGeopolitics,
+			Status:     status,
+			Confidence: models.Confidence{Score: 0.8, Level: models.ConfidenceHigh},
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		}
+	}
+
+	// The corroborated event is stored first but only published after the poller's cursor
+	if err := repo.Create(ctx, newEvent("evt-corroborated", models.EventStatusPending, now.Add(-time.Hour))); err != nil {
+		t.Fatalf("failed to create pending event: %v", err)
+	}
+	if err := repo.Create(ctx, newEvent("evt-cursor", models.EventStatusPublished, now)); err != nil {
+		t.Fatalf("failed to create cursor event: %v", err)
+	}
+	if err := repo.UpdateStatus(ctx, "evt-corroborated", models.EventStatusPublished); err != nil {
+		t.Fatalf("failed to publish event: %v", err)
+	}
+
+	published := models.EventStatusPublished
+	q := models.EventQuery{AfterID: "evt-cursor", Status: &published}
+	if err := q.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := repo.Query(ctx, q)
+	if err != nil {
+		t.Fatalf("Query() returned error: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].ID != "evt-corroborated" {
+		t.Errorf("after_id page = %v, want the event published after the cursor", resp.Events)
+	}
+}
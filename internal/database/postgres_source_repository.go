@@ -9,11 +9,13 @@ import (
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/lib/pq"
 )
 
 // PostgresSourceRepository implements SourceRepository using PostgreSQL.
 type PostgresSourceRepository struct {
-	db *sql.DB
+	db          *sql.DB
+	dedupWindow time.Duration
 }
 
 // NewPostgresSourceRepository creates a new PostgreSQL source repository.
@@ -21,15 +23,35 @@ func NewPostgresSourceRepository(db *sql.DB) *PostgresSourceRepository {
 	return &PostgresSourceRepository{db: db}
 }
 
-// StoreRaw saves a raw source to the repository (alias for Store).
-func (r *PostgresSourceRepository) StoreRaw(ctx context.Context, source models.Source) error {
+// SetDedupWindow limits duplicate checks by content hash, and GetByTitleAndURL, to sources
+// created within window, so recycled content from long ago doesn't block a new item. Zero
+// checks all history. URL duplicates are always skipped by the unique URL index.
+func (r *PostgresSourceRepository) SetDedupWindow(window time.Duration) {
+	r.dedupWindow = window
+}
+
+// dedupSince returns the earliest creation time a duplicate can have, or nil for no bound.
+func (r *PostgresSourceRepository) dedupSince() *time.Time {
+	if r.dedupWindow <= 0 {
+		return nil
+	}
+	since := time.Now().Add(-r.dedupWindow)
+	return &since
+}
+
+// StoreRaw saves a raw source to the repository (alias for Store), in the source's workspace or
+// else the one ctx is scoped to.
+func (r *PostgresSourceRepository) StoreRaw(ctx context.Context, source models.Source) (bool, error) {
 	return r.Store(ctx, source)
 }
 
-// Exists checks if a source with the given ID exists.
+// Exists checks if a source with the given ID exists in the workspace ctx is scoped to.
 func (r *PostgresSourceRepository) Exists(ctx context.Context, id string) (bool, error) {
 	var exists bool
-	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM sources WHERE id = $1)", id).Scan(&exists)
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM sources WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2))",
+		id, workspaceFilter(ctx),
+	).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check source existence: %w", err)
 	}
@@ -43,6 +65,7 @@ func (r *PostgresSourceRepository) GetByURL(ctx context.Context, url string) (*m
 		       raw_content, content_hash, credibility, metadata, created_at
 		FROM sources
 		WHERE url = $1
+		  AND ($2::text IS NULL OR workspace_id = $2)
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
@@ -50,7 +73,7 @@ func (r *PostgresSourceRepository) GetByURL(ctx context.Context, url string) (*m
 	var source models.Source
 	var metadataJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, url).Scan(
+	err := r.db.QueryRowContext(ctx, query, url, workspaceFilter(ctx)).Scan(
 		&source.ID,
 		&source.Type,
 		&source.URL,
@@ -82,13 +105,15 @@ func (r *PostgresSourceRepository) GetByURL(ctx context.Context, url string) (*m
 	return &source, nil
 }
 
-// GetByTitleAndURL checks if a source with the same title and URL exists.
+// GetByTitleAndURL checks if a source with the same title and URL was created within the dedup window.
 func (r *PostgresSourceRepository) GetByTitleAndURL(ctx context.Context, title, url string) (*models.Source, error) {
 	query := `
 		SELECT id, type, url, title, author, author_id, published_at, retrieved_at,
 		       raw_content, content_hash, credibility, metadata, created_at
 		FROM sources
 		WHERE title = $1 AND url = $2
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::text IS NULL OR workspace_id = $4)
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
@@ -96,7 +121,7 @@ func (r *PostgresSourceRepository) GetByTitleAndURL(ctx context.Context, title,
 	var source models.Source
 	var metadataJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, title, url).Scan(
+	err := r.db.QueryRowContext(ctx, query, title, url, r.dedupSince(), workspaceFilter(ctx)).Scan(
 		&source.ID,
 		&source.Type,
 		&source.URL,
@@ -128,11 +153,37 @@ func (r *PostgresSourceRepository) GetByTitleAndURL(ctx context.Context, title,
 	return &source, nil
 }
 
-// Store inserts a single source into the database.
-func (r *PostgresSourceRepository) Store(ctx context.Context, source models.Source) error {
+// Store inserts a single source into the database, or updates it if a source with its ID exists.
+// A new source whose URL matches another source, or whose content hash matches a source created
+// within the dedup window, is a duplicate: it is skipped and Store returns false with no error,
+// so callers can count skips without checking first. Duplicates are only looked for in the
+// source's own workspace.
+func (r *PostgresSourceRepository) Store(ctx context.Context, source models.Source) (bool, error) {
 	metadataJSON, err := json.Marshal(source.Metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		return false, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	source.WorkspaceID = writeWorkspace(ctx, source.WorkspaceID)
+
+	// The unique URL index catches URL duplicates on insert; content hashes aren't unique in
+	// existing data, so check them here
+	if source.ContentHash != "" {
+		var duplicate bool
+		err := r.db.QueryRowContext(ctx, `
+			SELECT NOT EXISTS(SELECT 1 FROM sources WHERE id = $1)
+			   AND EXISTS(
+			       SELECT 1 FROM sources
+			       WHERE content_hash = $2 AND id <> $1
+			         AND ($3::timestamptz IS NULL OR created_at >= $3)
+			         AND workspace_id = $4
+			   )
+		`, source.ID, source.ContentHash, r.dedupSince(), source.WorkspaceID).Scan(&duplicate)
+		if err != nil {
+			return false, fmt.Errorf("failed to check for duplicate source: %w", err)
+		}
+		if duplicate {
+			return false, nil
+		}
 	}
 
 	// First try to insert. If there's a conflict on URL (duplicate article), ignore it.
@@ -143,8 +194,8 @@ func (r *PostgresSourceRepository) Store(ctx context.Context, source models.Sour
 		INSERT INTO sources (
 			id, type, url, title, author, author_id, published_at, retrieved_at,
 			raw_content, content_hash, credibility, metadata,
-			scrape_status, scrape_error, scraped_at, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			scrape_status, scrape_error, scraped_at, created_at, media, external_url, workspace_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NULLIF($18, ''), $19)
 		ON CONFLICT (id) DO UPDATE SET
 			type = EXCLUDED.type,
 			url = EXCLUDED.url,
@@ -159,10 +210,13 @@ func (r *PostgresSourceRepository) Store(ctx context.Context, source models.Sour
 			metadata = EXCLUDED.metadata,
 			scrape_status = EXCLUDED.scrape_status,
 			scrape_error = EXCLUDED.scrape_error,
-			scraped_at = EXCLUDED.scraped_at
+			scraped_at = EXCLUDED.scraped_at,
+			media = EXCLUDED.media,
+			external_url = EXCLUDED.external_url
+		WHERE sources.workspace_id = EXCLUDED.workspace_id
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	result, err := r.db.ExecContext(ctx, query,
 		source.ID,
 		source.Type,
 		source.URL,
@@ -179,20 +233,27 @@ func (r *PostgresSourceRepository) Store(ctx context.Context, source models.Sour
 		source.ScrapeError,
 		source.ScrapedAt,
 		source.CreatedAt,
+		pq.Array(source.Media),
+		source.ExternalURL,
+		source.WorkspaceID,
 	)
 
 	if err != nil {
 		// Check if this is a unique constraint violation on URL
-		// This means we're trying to insert a duplicate URL with a different ID - ignore it
+		// This means we're trying to insert a duplicate URL with a different ID - skip it
 		if strings.Contains(err.Error(), "idx_sources_url_unique") ||
 			strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			// Silently ignore URL duplicates
-			return nil
+			return false, nil
 		}
-		return fmt.Errorf("failed to store source: %w", err)
+		return false, fmt.Errorf("failed to store source: %w", err)
 	}
 
-	return nil
+	// A source with the same ID in another workspace is left alone
+	stored, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return stored > 0, nil
 }
 
 // StoreBatch inserts multiple sources in a single transaction.
@@ -211,8 +272,8 @@ func (r *PostgresSourceRepository) StoreBatch(ctx context.Context, sources []mod
 		INSERT INTO sources (
 			id, type, url, title, author, author_id, published_at, retrieved_at,
 			raw_content, content_hash, credibility, metadata,
-			scrape_status, scrape_error, scraped_at, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			scrape_status, scrape_error, scraped_at, created_at, media, external_url, workspace_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NULLIF($18, ''), $19)
 		ON CONFLICT (id) DO NOTHING
 	`)
 	if err != nil {
@@ -243,6 +304,9 @@ func (r *PostgresSourceRepository) StoreBatch(ctx context.Context, sources []mod
 			source.ScrapeError,
 			source.ScrapedAt,
 			source.CreatedAt,
+			pq.Array(source.Media),
+			source.ExternalURL,
+			writeWorkspace(ctx, source.WorkspaceID),
 		)
 		if err != nil {
 			// Check if this is a unique constraint violation on URL - if so, skip it
@@ -262,15 +326,17 @@ func (r *PostgresSourceRepository) StoreBatch(ctx context.Context, sources []mod
 func (r *PostgresSourceRepository) GetByID(ctx context.Context, id string) (*models.Source, error) {
 	query := `
 		SELECT id, type, url, title, author, author_id, published_at, retrieved_at,
-		       raw_content, content_hash, credibility, metadata, created_at
+		       raw_content, content_hash, credibility, metadata, created_at,
+		       media, COALESCE(external_url, ''), workspace_id
 		FROM sources
 		WHERE id = $1
+		  AND ($2::text IS NULL OR workspace_id = $2)
 	`
 
 	var source models.Source
 	var metadataJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, workspaceFilter(ctx)).Scan(
 		&source.ID,
 		&source.Type,
 		&source.URL,
@@ -284,6 +350,9 @@ func (r *PostgresSourceRepository) GetByID(ctx context.Context, id string) (*mod
 		&source.Credibility,
 		&metadataJSON,
 		&source.CreatedAt,
+		pq.Array(&source.Media),
+		&source.ExternalURL,
+		&source.WorkspaceID,
 	)
 
 	if err == sql.ErrNoRows {
@@ -310,6 +379,7 @@ func (r *PostgresSourceRepository) GetByContentHash(ctx context.Context, hash st
 		       raw_content, content_hash, credibility, metadata, created_at
 		FROM sources
 		WHERE content_hash = $1
+		  AND ($2::text IS NULL OR workspace_id = $2)
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
@@ -317,7 +387,7 @@ func (r *PostgresSourceRepository) GetByContentHash(ctx context.Context, hash st
 	var source models.Source
 	var metadataJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, hash).Scan(
+	err := r.db.QueryRowContext(ctx, query, hash, workspaceFilter(ctx)).Scan(
 		&source.ID,
 		&source.Type,
 		&source.URL,
@@ -356,11 +426,12 @@ func (r *PostgresSourceRepository) ListRecent(ctx context.Context, since time.Ti
 		       raw_content, content_hash, credibility, metadata, created_at, scrape_status, scrape_error, scraped_at
 		FROM sources
 		WHERE created_at >= $1
+		  AND ($3::text IS NULL OR workspace_id = $3)
 		ORDER BY created_at DESC
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, since, limit)
+	rows, err := r.db.QueryContext(ctx, query, since, limit, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query recent sources: %w", err)
 	}
@@ -426,11 +497,12 @@ func (r *PostgresSourceRepository) ListByType(ctx context.Context, sourceType mo
 		       raw_content, content_hash, credibility, metadata, created_at
 		FROM sources
 		WHERE type = $1
+		  AND ($3::text IS NULL OR workspace_id = $3)
 		ORDER BY published_at DESC
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, sourceType, limit)
+	rows, err := r.db.QueryContext(ctx, query, sourceType, limit, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sources by type: %w", err)
 	}
@@ -476,29 +548,39 @@ func (r *PostgresSourceRepository) ListByType(ctx context.Context, sourceType mo
 	return sources, nil
 }
 
-// Count returns the total number of sources.
+// Count returns the total number of sources in the workspace ctx is scoped to.
 func (r *PostgresSourceRepository) Count(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources").Scan(&count)
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sources WHERE ($1::text IS NULL OR workspace_id = $1)",
+		workspaceFilter(ctx),
+	).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count sources: %w", err)
 	}
 	return count, nil
 }
 
-// CountByType returns the count of sources by type.
+// CountByType returns the count of sources by type in the workspace ctx is scoped to.
 func (r *PostgresSourceRepository) CountByType(ctx context.Context, sourceType models.SourceType) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE type = $1", sourceType).Scan(&count)
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sources WHERE type = $1 AND ($2::text IS NULL OR workspace_id = $2)",
+		sourceType, workspaceFilter(ctx),
+	).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count sources by type: %w", err)
 	}
 	return count, nil
 }
 
-// DeleteOlderThan removes sources older than the specified time (for retention policies).
+// DeleteOlderThan removes sources older than the specified time in the workspace ctx is scoped
+// to (for retention policies).
 func (r *PostgresSourceRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int, error) {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM sources WHERE published_at < $1", before)
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM sources WHERE published_at < $1 AND ($2::text IS NULL OR workspace_id = $2)",
+		before, workspaceFilter(ctx),
+	)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete old sources: %w", err)
 	}
@@ -511,19 +593,97 @@ func (r *PostgresSourceRepository) DeleteOlderThan(ctx context.Context, before t
 	return int(rows), nil
 }
 
-// GetByStatus retrieves sources with a specific scrape status.
+// GetRawSource returns the raw content and enrichment state of a source, or nil if it doesn't
+// exist in the workspace ctx is scoped to.
+func (r *PostgresSourceRepository) GetRawSource(ctx context.Context, id string) (*models.RawSource, error) {
+	var source models.RawSource
+	var title, contentHash, language, translatedTitle, translatedContent, enrichmentError, enrichmentModel, eventID sql.NullString
+	var enrichedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, type, url, title, raw_content, content_hash, published_at, retrieved_at,
+			original_language, translated_title, translated_content,
+			enrichment_status, enrichment_error, enriched_at, enrichment_model, event_id
+		FROM sources
+		WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2)
+	`, id, workspaceFilter(ctx)).Scan(
+		&source.ID, &source.Type, &source.URL, &title, &source.RawContent, &contentHash,
+		&source.PublishedAt, &source.RetrievedAt,
+		&language, &translatedTitle, &translatedContent,
+		&source.EnrichmentStatus, &enrichmentError, &enrichedAt, &enrichmentModel, &eventID,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw source: %w", err)
+	}
+
+	source.Title = title.String
+	source.ContentHash = contentHash.String
+	source.OriginalLanguage = language.String
+	source.TranslatedTitle = translatedTitle.String
+	source.TranslatedContent = translatedContent.String
+	source.EnrichmentError = enrichmentError.String
+	source.EnrichmentModel = enrichmentModel.String
+	source.EventID = eventID.String
+	if enrichedAt.Valid {
+		source.EnrichedAt = &enrichedAt.Time
+	}
+	return &source, nil
+}
+
+// enrichmentCleanupWhere selects sources with enrichment status $1 created before $2 in the
+// workspace bound to $3 by workspaceFilter. Sources still backing an event are never selected,
+// so cleanup can't leave events without sources, and neither are sources in enrichment whose
+// claim was taken or refreshed at or after $4, which a live worker may still be enriching.
+const enrichmentCleanupWhere = `
+	enrichment_status = $1
+	AND created_at < $2
+	AND ($3::text IS NULL OR workspace_id = $3)
+	AND (enrichment_status <> 'enriching' OR enrichment_claimed_at IS NULL OR enrichment_claimed_at < $4)
+	AND NOT EXISTS (SELECT 1 FROM event_sources es WHERE es.source_id = sources.id)
+	AND NOT EXISTS (SELECT 1 FROM events e WHERE e.id = sources.event_id)`
+
+// CountByEnrichmentStatus counts the sources DeleteByEnrichmentStatus would delete.
+func (r *PostgresSourceRepository) CountByEnrichmentStatus(ctx context.Context, status models.EnrichmentStatus, before, claimedBefore time.Time) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources WHERE "+enrichmentCleanupWhere, status, before, workspaceFilter(ctx), claimedBefore).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count sources by enrichment status: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteByEnrichmentStatus deletes sources with the given enrichment status created before the
+// cutoff, skipping any that still back an event and any in enrichment claimed at or after
+// claimedBefore. It returns the number deleted.
+func (r *PostgresSourceRepository) DeleteByEnrichmentStatus(ctx context.Context, status models.EnrichmentStatus, before, claimedBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM sources WHERE "+enrichmentCleanupWhere, status, before, workspaceFilter(ctx), claimedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sources by enrichment status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
+
+// GetByStatus retrieves sources with a specific scrape status in the workspace ctx is scoped to.
 func (r *PostgresSourceRepository) GetByStatus(ctx context.Context, status models.ScrapeStatus, limit int) ([]models.Source, error) {
 	query := `
 		SELECT id, type, url, title, author, author_id, published_at, retrieved_at,
 		       raw_content, content_hash, credibility, metadata,
-		       scrape_status, scrape_error, scraped_at, created_at
+		       scrape_status, scrape_error, scraped_at, created_at,
+		       media, COALESCE(external_url, '')
 		FROM sources
-		WHERE scrape_status = $1
+		WHERE scrape_status = $1 AND ($3::text IS NULL OR workspace_id = $3)
 		ORDER BY created_at ASC
 		LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, status, limit)
+	rows, err := r.db.QueryContext(ctx, query, status, limit, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sources by status: %w", err)
 	}
@@ -545,7 +705,7 @@ func (r *PostgresSourceRepository) GetByStatus(ctx context.Context, status model
 	return sources, nil
 }
 
-// Update modifies an existing source in the database.
+// Update modifies an existing source in the workspace ctx is scoped to.
 func (r *PostgresSourceRepository) Update(ctx context.Context, source models.Source) error {
 	metadataJSON, err := json.Marshal(source.Metadata)
 	if err != nil {
@@ -567,8 +727,10 @@ func (r *PostgresSourceRepository) Update(ctx context.Context, source models.Sou
 		    metadata = $12,
 		    scrape_status = $13,
 		    scrape_error = $14,
-		    scraped_at = $15
-		WHERE id = $1
+		    scraped_at = $15,
+		    media = $16,
+		    external_url = NULLIF($17, '')
+		WHERE id = $1 AND ($18::text IS NULL OR workspace_id = $18)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -587,6 +749,9 @@ func (r *PostgresSourceRepository) Update(ctx context.Context, source models.Sou
 		source.ScrapeStatus,
 		source.ScrapeError,
 		source.ScrapedAt,
+		pq.Array(source.Media),
+		source.ExternalURL,
+		workspaceFilter(ctx),
 	)
 
 	if err != nil {
@@ -605,10 +770,10 @@ func (r *PostgresSourceRepository) Update(ctx context.Context, source models.Sou
 	return nil
 }
 
-// Delete removes a source by its ID from the database.
+// Delete removes a source by its ID from the workspace ctx is scoped to.
 func (r *PostgresSourceRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM sources WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+	query := `DELETE FROM sources WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2)`
+	result, err := r.db.ExecContext(ctx, query, id, workspaceFilter(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to delete source: %w", err)
 	}
@@ -651,6 +816,8 @@ func (r *PostgresSourceRepository) scanSource(scanner interface {
 		&scrapeError,
 		&scrapedAt,
 		&source.CreatedAt,
+		pq.Array(&source.Media),
+		&source.ExternalURL,
 	)
 
 	if err != nil {
@@ -683,7 +850,8 @@ func (r *PostgresSourceRepository) ClaimSourcesForEnrichment(ctx context.Context
 	// Claim sources that are:
 	// 1. Scrape status is 'completed'
 	// 2. Enrichment status is 'pending' OR
-	// 3. Enrichment status is 'enriching' but claim is stale (>15 min old, indicating crashed worker)
+	// 3. Enrichment status is 'enriching' but claim is older than staleAfter (indicating a crashed worker)
+	// The whole batch is claimed in one statement; SKIP LOCKED lets concurrent workers take disjoint batches
 	query := `
 		UPDATE sources
 		SET enrichment_status = 'enriching',
@@ -705,10 +873,10 @@ func (r *PostgresSourceRepository) ClaimSourcesForEnrichment(ctx context.Context
 		          raw_content, content_hash, credibility, metadata,
 		          scrape_status, scrape_error, scraped_at,
 		          enrichment_status, enrichment_error, enriched_at, enrichment_claimed_at,
-		          created_at
+		          created_at, media, COALESCE(external_url, ''), workspace_id
 	`
 
-	staleInterval := fmt.Sprintf("%d minutes", int(staleAfter.Minutes()))
+	staleInterval := fmt.Sprintf("%d seconds", int(staleAfter.Seconds()))
 	rows, err := r.db.QueryContext(ctx, query, limit, staleInterval)
 	if err != nil {
 		return nil, fmt.Errorf("failed to claim sources for enrichment: %w", err)
@@ -743,6 +911,9 @@ func (r *PostgresSourceRepository) ClaimSourcesForEnrichment(ctx context.Context
 			&enrichedAt,
 			&enrichmentClaimedAt,
 			&source.CreatedAt,
+			pq.Array(&source.Media),
+			&source.ExternalURL,
+			&source.WorkspaceID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan claimed source: %w", err)
@@ -782,12 +953,38 @@ func (r *PostgresSourceRepository) ClaimSourcesForEnrichment(ctx context.Context
 	return sources, nil
 }
 
+// TouchEnrichmentClaims refreshes the claim time of sources still being enriched, so a worker
+// busy with a long batch keeps its claims. Sources whose enrichment already finished are skipped.
+func (r *PostgresSourceRepository) TouchEnrichmentClaims(ctx context.Context, sourceIDs []string) (int64, error) {
+	if len(sourceIDs) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		UPDATE sources
+		SET enrichment_claimed_at = NOW()
+		WHERE id = ANY($1) AND enrichment_status = 'enriching'
+	`
+	result, err := r.db.ExecContext(ctx, query, pq.Array(sourceIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to touch enrichment claims: %w", err)
+	}
+	touched, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get touched claim count: %w", err)
+	}
+	return touched, nil
+}
+
 // UpdateEnrichmentStatus updates the enrichment status of a source.
 func (r *PostgresSourceRepository) UpdateEnrichmentStatus(ctx context.Context, sourceID string, status models.EnrichmentStatus, errorMsg string) error {
-	var enrichedAt *time.Time
-	if status == models.EnrichmentStatusCompleted {
-		now := time.Now()
+	var enrichedAt, failedAt *time.Time
+	now := time.Now()
+	switch status {
+	case models.EnrichmentStatusCompleted:
 		enrichedAt = &now
+	case models.EnrichmentStatusFailed:
+		failedAt = &now
 	}
 
 	query := `
@@ -795,11 +992,12 @@ func (r *PostgresSourceRepository) UpdateEnrichmentStatus(ctx context.Context, s
 		SET enrichment_status = $1,
 		    enrichment_error = $2,
 		    enriched_at = $3,
+		    enrichment_failed_at = $4,
 		    enrichment_claimed_at = NULL
-		WHERE id = $4
+		WHERE id = $5
 	`
 
-	_, err := r.db.ExecContext(ctx, query, status, errorMsg, enrichedAt, sourceID)
+	_, err := r.db.ExecContext(ctx, query, status, errorMsg, enrichedAt, failedAt, sourceID)
 	if err != nil {
 		return fmt.Errorf("failed to update enrichment status: %w", err)
 	}
@@ -807,6 +1005,45 @@ func (r *PostgresSourceRepository) UpdateEnrichmentStatus(ctx context.Context, s
 	return nil
 }
 
+// GetTranslation returns the stored English translation of a source, or nil if it has none.
+func (r *PostgresSourceRepository) GetTranslation(ctx context.Context, sourceID string) (*models.SourceTranslation, error) {
+	query := `
+		SELECT original_language, COALESCE(translated_title, ''), translated_content
+		FROM sources
+		WHERE id = $1 AND translated_content IS NOT NULL
+	`
+
+	var t models.SourceTranslation
+	err := r.db.QueryRowContext(ctx, query, sourceID).Scan(&t.Language, &t.Title, &t.Content)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source translation: %w", err)
+	}
+
+	return &t, nil
+}
+
+// SaveTranslation stores an English translation alongside the source's original text.
+func (r *PostgresSourceRepository) SaveTranslation(ctx context.Context, sourceID string, t models.SourceTranslation) error {
+	query := `
+		UPDATE sources
+		SET original_language = $1,
+		    translated_title = $2,
+		    translated_content = $3,
+		    translated_at = NOW()
+		WHERE id = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query, t.Language, t.Title, t.Content, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to save source translation: %w", err)
+	}
+
+	return nil
+}
+
 // SetEventID sets the event_id for a source after enrichment.
 func (r *PostgresSourceRepository) SetEventID(ctx context.Context, sourceID, eventID string) error {
 	query := `
@@ -823,6 +1060,14 @@ func (r *PostgresSourceRepository) SetEventID(ctx context.Context, sourceID, eve
 	return nil
 }
 
+// SetEnrichmentModel records which model enriched a source.
+func (r *PostgresSourceRepository) SetEnrichmentModel(ctx context.Context, sourceID, model string) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE sources SET enrichment_model = NULLIF($2, '') WHERE id = $1", sourceID, model); err != nil {
+		return fmt.Errorf("failed to set enrichment model: %w", err)
+	}
+	return nil
+}
+
 // GetRecentEnrichments retrieves recent sources with their enrichment status and event IDs.
 func (r *PostgresSourceRepository) GetRecentEnrichments(ctx context.Context, limit int) ([]models.Source, error) {
 	query := `
@@ -830,14 +1075,15 @@ func (r *PostgresSourceRepository) GetRecentEnrichments(ctx context.Context, lim
 		       raw_content, content_hash, credibility, metadata,
 		       scrape_status, scrape_error, scraped_at,
 		       enrichment_status, enrichment_error, enriched_at, enrichment_claimed_at,
-		       event_id, created_at
+		       event_id, created_at, COALESCE(enrichment_model, '')
 		FROM sources
 		WHERE enrichment_status != 'pending'
+		  AND ($2::text IS NULL OR workspace_id = $2)
 		ORDER BY enriched_at DESC NULLS LAST, created_at DESC
 		LIMIT $1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := r.db.QueryContext(ctx, query, limit, workspaceFilter(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query recent enrichments: %w", err)
 	}
@@ -873,6 +1119,7 @@ func (r *PostgresSourceRepository) GetRecentEnrichments(ctx context.Context, lim
 			&enrichmentClaimedAt,
 			&eventID,
 			&source.CreatedAt,
+			&source.EnrichmentModel,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan source: %w", err)
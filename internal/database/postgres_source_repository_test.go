@@ -42,7 +42,7 @@ func TestGetByTitleAndURL(t *testing.T) {
 	}
 
 	// Store the test source
-	err = repo.Store(ctx, testSource)
+	_, err = repo.Store(ctx, testSource)
 	if err != nil {
 		t.Fatalf("failed to store test source: %v", err)
 	}
@@ -210,7 +210,7 @@ func TestGetByTitleAndURL_MultipleSources(t *testing.T) {
 
 	// Store all sources
 	for _, source := range sources {
-		err := repo.Store(ctx, source)
+		_, err := repo.Store(ctx, source)
 		if err != nil {
 			t.Fatalf("failed to store source %s: %v", source.ID, err)
 		}
@@ -3,9 +3,12 @@ package database
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
+	"github.com/lib/pq"
 )
 
 type PostgresTrackedAccountRepository struct {
@@ -21,20 +24,38 @@ func (r *PostgresTrackedAccountRepository) Store(account *models.TrackedAccount)
 	if err != nil {
 		return err
 	}
+	if account.Tags == nil {
+		account.Tags = []string{}
+	}
+	var fetchAuth interface{} // NULL unless the account has credentials
+	if !account.FetchAuth.IsEmpty() {
+		stored, err := encryptFeedAuth(account.FetchAuth)
+		if err != nil {
+			return err
+		}
+		fetchAuthJSON, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		fetchAuth = string(fetchAuthJSON)
+	}
 
 	if account.ID == "" {
 		// New account - let DB generate ID
+		account.WorkspaceID = models.WorkspaceOrDefault(account.WorkspaceID)
 		query := `
 			INSERT INTO tracked_accounts
 			(platform, account_identifier, display_name, enabled,
-			 last_fetched_id, last_fetched_at, fetch_interval_minutes, metadata)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-			ON CONFLICT (platform, account_identifier)
+			 last_fetched_id, last_fetched_at, fetch_interval_minutes, metadata, tags, fetch_auth, workspace_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (workspace_id, platform, account_identifier)
 			DO UPDATE SET
 				display_name = EXCLUDED.display_name,
 				enabled = EXCLUDED.enabled,
 				fetch_interval_minutes = EXCLUDED.fetch_interval_minutes,
 				metadata = EXCLUDED.metadata,
+				tags = EXCLUDED.tags,
+				fetch_auth = EXCLUDED.fetch_auth,
 				updated_at = NOW()
 			RETURNING id, created_at, updated_at
 		`
@@ -48,6 +69,9 @@ func (r *PostgresTrackedAccountRepository) Store(account *models.TrackedAccount)
 			account.LastFetchedAt,
 			account.FetchIntervalMinutes,
 			metadataJSON,
+			pq.Array(account.Tags),
+			fetchAuth,
+			account.WorkspaceID,
 		).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
 	} else {
 		// Existing account - update
@@ -57,6 +81,8 @@ func (r *PostgresTrackedAccountRepository) Store(account *models.TrackedAccount)
 				enabled = $3,
 				fetch_interval_minutes = $4,
 				metadata = $5,
+				tags = $6,
+				fetch_auth = $7,
 				updated_at = NOW()
 			WHERE id = $1
 			RETURNING id, created_at, updated_at
@@ -68,6 +94,8 @@ func (r *PostgresTrackedAccountRepository) Store(account *models.TrackedAccount)
 			account.Enabled,
 			account.FetchIntervalMinutes,
 			metadataJSON,
+			pq.Array(account.Tags),
+			fetchAuth,
 		).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
 	}
 
@@ -78,13 +106,13 @@ func (r *PostgresTrackedAccountRepository) GetByID(id string) (*models.TrackedAc
 	query := `
 		SELECT id, platform, account_identifier, display_name, enabled,
 		       last_fetched_id, last_fetched_at, fetch_interval_minutes,
-		       metadata, created_at, updated_at
+		       metadata, tags, fetch_auth, workspace_id, created_at, updated_at
 		FROM tracked_accounts
 		WHERE id = $1
 	`
 
 	var account models.TrackedAccount
-	var metadataJSON []byte
+	var metadataJSON, fetchAuthJSON []byte
 
 	err := r.db.QueryRow(query, id).Scan(
 		&account.ID,
@@ -96,6 +124,9 @@ func (r *PostgresTrackedAccountRepository) GetByID(id string) (*models.TrackedAc
 		&account.LastFetchedAt,
 		&account.FetchIntervalMinutes,
 		&metadataJSON,
+		pq.Array(&account.Tags),
+		&fetchAuthJSON,
+		&account.WorkspaceID,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -112,6 +143,14 @@ func (r *PostgresTrackedAccountRepository) GetByID(id string) (*models.TrackedAc
 			return nil, err
 		}
 	}
+	if len(fetchAuthJSON) > 0 {
+		if err := json.Unmarshal(fetchAuthJSON, &account.FetchAuth); err != nil {
+			return nil, err
+		}
+		if err := decryptFeedAuth(account.FetchAuth); err != nil {
+			return nil, err
+		}
+	}
 
 	return &account, nil
 }
@@ -120,13 +159,13 @@ func (r *PostgresTrackedAccountRepository) GetByPlatformAndIdentifier(platform,
 	query := `
 		SELECT id, platform, account_identifier, display_name, enabled,
 		       last_fetched_id, last_fetched_at, fetch_interval_minutes,
-		       metadata, created_at, updated_at
+		       metadata, tags, fetch_auth, workspace_id, created_at, updated_at
 		FROM tracked_accounts
 		WHERE platform = $1 AND account_identifier = $2
 	`
 
 	var account models.TrackedAccount
-	var metadataJSON []byte
+	var metadataJSON, fetchAuthJSON []byte
 
 	err := r.db.QueryRow(query, platform, identifier).Scan(
 		&account.ID,
@@ -138,6 +177,9 @@ func (r *PostgresTrackedAccountRepository) GetByPlatformAndIdentifier(platform,
 		&account.LastFetchedAt,
 		&account.FetchIntervalMinutes,
 		&metadataJSON,
+		pq.Array(&account.Tags),
+		&fetchAuthJSON,
+		&account.WorkspaceID,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -154,6 +196,14 @@ func (r *PostgresTrackedAccountRepository) GetByPlatformAndIdentifier(platform,
 			return nil, err
 		}
 	}
+	if len(fetchAuthJSON) > 0 {
+		if err := json.Unmarshal(fetchAuthJSON, &account.FetchAuth); err != nil {
+			return nil, err
+		}
+		if err := decryptFeedAuth(account.FetchAuth); err != nil {
+			return nil, err
+		}
+	}
 
 	return &account, nil
 }
@@ -162,7 +212,7 @@ func (r *PostgresTrackedAccountRepository) ListByPlatform(platform string, enabl
 	query := `
 		SELECT id, platform, account_identifier, display_name, enabled,
 		       last_fetched_id, last_fetched_at, fetch_interval_minutes,
-		       metadata, created_at, updated_at
+		       metadata, tags, fetch_auth, workspace_id, created_at, updated_at
 		FROM tracked_accounts
 		WHERE platform = $1
 	`
@@ -186,7 +236,7 @@ func (r *PostgresTrackedAccountRepository) ListAll(enabledOnly bool) ([]*models.
 	query := `
 		SELECT id, platform, account_identifier, display_name, enabled,
 		       last_fetched_id, last_fetched_at, fetch_interval_minutes,
-		       metadata, created_at, updated_at
+		       metadata, tags, fetch_auth, workspace_id, created_at, updated_at
 		FROM tracked_accounts
 	`
 
@@ -205,6 +255,30 @@ func (r *PostgresTrackedAccountRepository) ListAll(enabledOnly bool) ([]*models.
 	return r.scanAccounts(rows)
 }
 
+func (r *PostgresTrackedAccountRepository) ListByWorkspace(workspaceID, platform string, enabledOnly bool) ([]*models.TrackedAccount, error) {
+	query := `
+		SELECT id, platform, account_identifier, display_name, enabled,
+		       last_fetched_id, last_fetched_at, fetch_interval_minutes,
+		       metadata, tags, fetch_auth, workspace_id, created_at, updated_at
+		FROM tracked_accounts
+		WHERE workspace_id = $1 AND ($2 = '' OR platform = $2)
+	`
+
+	if enabledOnly {
+		query += " AND enabled = true"
+	}
+
+	query += " ORDER BY platform, created_at DESC"
+
+	rows, err := r.db.Query(query, workspaceID, platform)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAccounts(rows)
+}
+
 func (r *PostgresTrackedAccountRepository) UpdateLastFetched(id, lastFetchedID string, lastFetchedAt time.Time) error {
 	query := `
 		UPDATE tracked_accounts
@@ -235,12 +309,33 @@ func (r *PostgresTrackedAccountRepository) SetEnabled(id string, enabled bool) e
 	return err
 }
 
+func (r *PostgresTrackedAccountRepository) SetEnabledMatching(workspaceID, platform, tag string, enabled bool) (int, error) {
+	query := `
+		UPDATE tracked_accounts
+		SET enabled = $4, updated_at = NOW()
+		WHERE workspace_id = $1
+		  AND ($2 = '' OR platform = $2)
+		  AND ($3 = '' OR $3 = ANY(tags))
+		  AND enabled <> $4
+	`
+
+	result, err := r.db.Exec(query, workspaceID, platform, tag, enabled)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
 func (r *PostgresTrackedAccountRepository) scanAccounts(rows *sql.Rows) ([]*models.TrackedAccount, error) {
 	var accounts []*models.TrackedAccount
 
 	for rows.Next() {
 		var account models.TrackedAccount
-		var metadataJSON []byte
+		var metadataJSON, fetchAuthJSON []byte
 
 		err := rows.Scan(
 			&account.ID,
@@ -252,6 +347,9 @@ func (r *PostgresTrackedAccountRepository) scanAccounts(rows *sql.Rows) ([]*mode
 			&account.LastFetchedAt,
 			&account.FetchIntervalMinutes,
 			&metadataJSON,
+			pq.Array(&account.Tags),
+			&fetchAuthJSON,
+			&account.WorkspaceID,
 			&account.CreatedAt,
 			&account.UpdatedAt,
 		)
@@ -264,9 +362,58 @@ func (r *PostgresTrackedAccountRepository) scanAccounts(rows *sql.Rows) ([]*mode
 				return nil, err
 			}
 		}
+		if len(fetchAuthJSON) > 0 {
+			if err := json.Unmarshal(fetchAuthJSON, &account.FetchAuth); err != nil {
+				return nil, err
+			}
+			if err := decryptFeedAuth(account.FetchAuth); err != nil {
+				return nil, err
+			}
+		}
 
 		accounts = append(accounts, &account)
 	}
 
 	return accounts, rows.Err()
 }
+
+// encryptFeedAuth returns a copy of auth with its password and header values encrypted for storage
+func encryptFeedAuth(auth *models.FeedAuth) (*models.FeedAuth, error) {
+	stored := &models.FeedAuth{Username: auth.Username}
+	password, err := secrets.Encrypt(auth.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt feed password: %w", err)
+	}
+	stored.Password = password
+	if auth.Headers != nil {
+		stored.Headers = make(map[string]string, len(auth.Headers))
+	}
+	for name, value := range auth.Headers {
+		encrypted, err := secrets.Encrypt(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt feed header %s: %w", name, err)
+		}
+		stored.Headers[name] = encrypted
+	}
+	return stored, nil
+}
+
+// decryptFeedAuth decrypts the password and header values of stored feed credentials in place
+func decryptFeedAuth(auth *models.FeedAuth) error {
+	if auth == nil {
+		return nil
+	}
+	password, err := secrets.Decrypt(auth.Password)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt feed password: %w", err)
+	}
+	auth.Password = password
+	for name, value := range auth.Headers {
+		plaintext, err := secrets.Decrypt(value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt feed header %s: %w", name, err)
+		}
+		auth.Headers[name] = plaintext
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ReprocessJobRepository stores bulk reprocessing jobs and resets their sources batch by batch.
+type ReprocessJobRepository struct {
+	db *sql.DB
+}
+
+// NewReprocessJobRepository creates a new reprocess job repository.
+func NewReprocessJobRepository(db *sql.DB) *ReprocessJobRepository {
+	return &ReprocessJobRepository{db: db}
+}
+
+const reprocessJobColumns = `
+	id, status, COALESCE(platform, ''), since, until, batch_size, total, processed, queued,
+	cursor_created_at, COALESCE(cursor_id, ''), COALESCE(created_by, ''), created_at, updated_at, completed_at,
+	workspace_id
+`
+
+// reprocessJobConditions builds the WHERE conditions and arguments selecting a job's sources,
+// which are always in the job's workspace
+func reprocessJobConditions(job models.ReprocessJob) ([]string, []interface{}) {
+	args := []interface{}{models.WorkspaceOrDefault(job.WorkspaceID)}
+	conditions := []string{"workspace_id = $1"}
+
+	if job.Platform != "" {
+		args = append(args, job.Platform)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if job.Since != nil {
+		args = append(args, *job.Since)
+		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", len(args)))
+	}
+	if job.Until != nil {
+		args = append(args, *job.Until)
+		conditions = append(conditions, fmt.Sprintf("published_at < $%d", len(args)))
+	}
+
+	return conditions, args
+}
+
+// whereSQL joins conditions into a WHERE clause, or returns "" when there are none
+func whereSQL(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// Create counts the sources matching the request in the workspace ctx is scoped to and stores a
+// running job for them.
+func (r *ReprocessJobRepository) Create(ctx context.Context, req models.ReprocessJobRequest, createdBy string) (*models.ReprocessJob, error) {
+	now := time.Now()
+	job := models.ReprocessJob{
+		ID:        uuid.New().String(),
+		Status:    models.ReprocessJobRunning,
+		Platform:  req.Platform,
+		Since:     req.Since,
+		Until:     req.Until,
+		BatchSize: req.BatchSize,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+
+		WorkspaceID: writeWorkspace(ctx, ""),
+	}
+
+	conditions, args := reprocessJobConditions(job)
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sources"+whereSQL(conditions), args...).Scan(&job.Total); err != nil {
+		return nil, fmt.Errorf("failed to count sources to reprocess: %w", err)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO reprocess_jobs (id, status, platform, since, until, batch_size, total, created_by, created_at, updated_at, workspace_id)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11)
+	`, job.ID, job.Status, job.Platform, job.Since, job.Until, job.BatchSize, job.Total, job.CreatedBy, job.CreatedAt, job.UpdatedAt, job.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reprocess job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// Get returns a job by ID, or nil if it doesn't exist in the workspace ctx is scoped to.
+func (r *ReprocessJobRepository) Get(ctx context.Context, id string) (*models.ReprocessJob, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+
+	job, err := scanReprocessJob(r.db.QueryRowContext(ctx, "SELECT "+reprocessJobColumns+" FROM reprocess_jobs WHERE id = $1 AND ($2::text IS NULL OR workspace_id = $2)", id, workspaceFilter(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reprocess job: %w", err)
+	}
+	return job, nil
+}
+
+// List returns the most recent jobs in the workspace ctx is scoped to, newest first.
+func (r *ReprocessJobRepository) List(ctx context.Context, limit int) ([]models.ReprocessJob, error) {
+	return r.query(ctx, "SELECT "+reprocessJobColumns+` FROM reprocess_jobs
+		WHERE ($2::text IS NULL OR workspace_id = $2)
+		ORDER BY created_at DESC LIMIT $1`, limit, workspaceFilter(ctx))
+}
+
+// ListRunning returns the running jobs in the workspace ctx is scoped to, oldest first.
+func (r *ReprocessJobRepository) ListRunning(ctx context.Context) ([]models.ReprocessJob, error) {
+	return r.query(ctx, "SELECT "+reprocessJobColumns+` FROM reprocess_jobs
+		WHERE status = $1 AND ($2::text IS NULL OR workspace_id = $2)
+		ORDER BY created_at ASC`, models.ReprocessJobRunning, workspaceFilter(ctx))
+}
+
+func (r *ReprocessJobRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.ReprocessJob, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reprocess jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.ReprocessJob{}
+	for rows.Next() {
+		job, err := scanReprocessJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reprocess job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+func scanReprocessJob(row interface{ Scan(...interface{}) error }) (*models.ReprocessJob, error) {
+	var job models.ReprocessJob
+	var since, until, cursorCreatedAt, completedAt sql.NullTime
+	err := row.Scan(
+		&job.ID,
+		&job.Status,
+		&job.Platform,
+		&since,
+		&until,
+		&job.BatchSize,
+		&job.Total,
+		&job.Processed,
+		&job.Queued,
+		&cursorCreatedAt,
+		&job.CursorID,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&completedAt,
+		&job.WorkspaceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if since.Valid {
+		job.Since = &since.Time
+	}
+	if until.Valid {
+		job.Until = &until.Time
+	}
+	if cursorCreatedAt.Valid {
+		job.CursorCreatedAt = &cursorCreatedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+	return &job, nil
+}
+
+// Cancel stops a running job in the workspace ctx is scoped to. Sources it already queued stay
+// pending. It returns false if the job isn't running.
+func (r *ReprocessJobRepository) Cancel(ctx context.Context, id string) (bool, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return false, nil
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE reprocess_jobs
+		SET status = $2, updated_at = NOW(), completed_at = NOW()
+		WHERE id = $1 AND status = $3 AND ($4::text IS NULL OR workspace_id = $4)
+	`, id, models.ReprocessJobCancelled, models.ReprocessJobRunning, workspaceFilter(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel reprocess job: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CountPending returns the number of sources waiting for enrichment in the workspace ctx is
+// scoped to.
+func (r *ReprocessJobRepository) CountPending(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sources WHERE enrichment_status = 'pending' AND ($1::text IS NULL OR workspace_id = $1)",
+		workspaceFilter(ctx),
+	).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count pending sources: %w", err)
+	}
+	return count, nil
+}
+
+// QueueNextBatch resets the job's next batch of sources to pending and advances its cursor,
+// completing the job once no sources are left. Sources already pending or being enriched are
+// skipped. It returns how many sources were reset and whether the job is done. A job cancelled
+// or advanced by another instance since it was read queues nothing.
+func (r *ReprocessJobRepository) QueueNextBatch(ctx context.Context, job models.ReprocessJob) (int, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	conditions, args := reprocessJobConditions(job)
+	if job.CursorCreatedAt != nil {
+		args = append(args, *job.CursorCreatedAt, job.CursorID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, job.BatchSize)
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		"SELECT id, created_at FROM sources%s ORDER BY created_at, id LIMIT $%d", whereSQL(conditions), len(args)), args...)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to select sources to reprocess: %w", err)
+	}
+	var ids []string
+	var cursorCreatedAt *time.Time
+	for rows.Next() {
+		var id string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			rows.Close()
+			return 0, false, fmt.Errorf("failed to scan source: %w", err)
+		}
+		ids = append(ids, id)
+		cursorCreatedAt = &createdAt
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, false, fmt.Errorf("failed to read sources to reprocess: %w", err)
+	}
+
+	queued := 0
+	if len(ids) > 0 {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE sources
+			SET
+				enrichment_status = 'pending',
+				enrichment_error = NULL,
+				enriched_at = NULL,
+				enrichment_failed_at = NULL,
+				enrichment_claimed_at = NULL
+			WHERE id = ANY($1) AND enrichment_status IN ($2, $3)
+		`, pq.Array(ids), models.EnrichmentStatusCompleted, models.EnrichmentStatusFailed)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to reset sources to pending: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		queued = int(n)
+	}
+
+	done := len(ids) < job.BatchSize
+	cursorID := job.CursorID
+	if len(ids) > 0 {
+		cursorID = ids[len(ids)-1]
+	} else {
+		cursorCreatedAt = job.CursorCreatedAt
+	}
+	status := models.ReprocessJobRunning
+	if done {
+		status = models.ReprocessJobCompleted
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE reprocess_jobs
+		SET
+			processed = processed + $2,
+			queued = queued + $3,
+			cursor_created_at = $4,
+			cursor_id = NULLIF($5, ''),
+			status = $6,
+			updated_at = NOW(),
+			completed_at = CASE WHEN $6 = 'completed' THEN NOW() ELSE NULL END
+		WHERE id = $1 AND status = 'running' AND cursor_id IS NOT DISTINCT FROM NULLIF($7, '')
+	`, job.ID, len(ids), queued, cursorCreatedAt, cursorID, status, job.CursorID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to update reprocess job: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return 0, false, fmt.Errorf("failed to get rows affected: %w", err)
+	} else if n == 0 {
+		// Cancelled, or advanced by another instance, since it was listed; roll back so
+		// no sources are reset twice
+		return 0, false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return queued, done, nil
+}
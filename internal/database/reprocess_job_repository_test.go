@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestQueueNextBatch_RequeuesCompletedSources(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// The fixtures get their own workspace and publication window so the job matches only them
+	const workspace = "reprocess-test"
+	published := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	since, until := published.Add(-time.Minute), published.Add(time.Minute)
+	ctx := models.ContextWithWorkspace(context.Background(), workspace)
+
+	sources := createTestSources(t, db, 3)
+	statuses := []models.EnrichmentStatus{
+		models.EnrichmentStatusCompleted,
+		models.EnrichmentStatusFailed,
+		models.EnrichmentStatusEnriching,
+	}
+	for i, status := range statuses {
+		if _, err := db.Exec("UPDATE sources SET enrichment_status = $1, workspace_id = $2, published_at = $3 WHERE id = $4", status, workspace, published, sources[i].ID); err != nil {
+			t.Fatalf("failed to set up source: %v", err)
+		}
+		defer db.Exec("DELETE FROM sources WHERE id = $1", sources[i].ID)
+	}
+
+	repo := NewReprocessJobRepository(db)
+	job, err := repo.Create(ctx, models.ReprocessJobRequest{Since: &since, Until: &until, BatchSize: 10}, "test")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	defer db.Exec("DELETE FROM reprocess_jobs WHERE id = $1", job.ID)
+	if job.Total != 3 {
+		t.Fatalf("job matched %d sources, want the 3 fixtures", job.Total)
+	}
+	queued, done, err := repo.QueueNextBatch(ctx, *job)
+	if err != nil {
+		t.Fatalf("QueueNextBatch: %v", err)
+	}
+	if queued != 2 || !done {
+		t.Errorf("QueueNextBatch = %d queued, done %v; want 2 queued, done", queued, done)
+	}
+
+	// Completed and failed sources go back to pending; one being enriched is left alone
+	want := []models.EnrichmentStatus{
+		models.EnrichmentStatusPending,
+		models.EnrichmentStatusPending,
+		models.EnrichmentStatusEnriching,
+	}
+	for i, source := range sources {
+		var status models.EnrichmentStatus
+		if err := db.QueryRow("SELECT enrichment_status FROM sources WHERE id = $1", source.ID).Scan(&status); err != nil {
+			t.Fatalf("failed to read enrichment status: %v", err)
+		}
+		if status != want[i] {
+			t.Errorf("source %s status = %s, want %s", source.ID, status, want[i])
+		}
+	}
+}
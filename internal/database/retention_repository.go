@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// RetentionRepository deletes old rejected events, orphaned sources and resolved ingestion errors.
+type RetentionRepository struct {
+	db *sql.DB
+}
+
+// NewRetentionRepository creates a new retention repository.
+func NewRetentionRepository(db *sql.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// retentionRule selects the rows one retention rule removes from a table.
+type retentionRule struct {
+	table  string // Table with alias, e.g. "events e"
+	where  string
+	args   []interface{}
+	result *int64
+}
+
+// purgedEvent matches events the rejected event rule removes; $2 is that rule's cutoff (NULL if disabled)
+const purgedEvent = `($2::timestamptz IS NOT NULL AND e.status = 'rejected' AND e.created_at < $2)`
+
+// rules returns the enabled retention rules, writing their counts into report.
+func (r *RetentionRepository) rules(c models.RetentionCutoffs, report *models.RetentionReport) []retentionRule {
+	var rules []retentionRule
+
+	if c.RejectedEventsBefore != nil {
+		rules = append(rules, retentionRule{
+			table:  "events e",
+			where:  "e.status = 'rejected' AND e.created_at < $1",
+			args:   []interface{}{*c.RejectedEventsBefore},
+			result: &report.RejectedEvents,
+		})
+	}
+
+	// Sources still waiting for enrichment are never orphans. A source is orphaned once no
+	// event it belongs to survives, counting events the rejected event rule removes as gone.
+	if c.OrphanedSourcesBefore != nil {
+		rules = append(rules, retentionRule{
+			table: "sources s",
+			where: `s.created_at < $1
+				AND s.enrichment_status IN ('completed', 'failed')
+				AND NOT EXISTS (
+					SELECT 1 FROM event_sources es
+					JOIN events e ON e.id = es.event_id
+					WHERE es.source_id = s.id AND NOT ` + purgedEvent + `
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM events e
+					WHERE e.id = s.event_id AND NOT ` + purgedEvent + `
+				)`,
+			args:   []interface{}{*c.OrphanedSourcesBefore, c.RejectedEventsBefore},
+			result: &report.OrphanedSources,
+		})
+	}
+
+	if c.ResolvedErrorsBefore != nil {
+		rules = append(rules, retentionRule{
+			table:  "ingestion_errors ie",
+			where:  "ie.resolved = TRUE AND ie.resolved_at < $1",
+			args:   []interface{}{*c.ResolvedErrorsBefore},
+			result: &report.ResolvedErrors,
+		})
+	}
+
+	return rules
+}
+
+// Count reports how many rows the retention rules would delete, without deleting anything.
+func (r *RetentionRepository) Count(ctx context.Context, c models.RetentionCutoffs) (*models.RetentionReport, error) {
+	report := &models.RetentionReport{DryRun: true, Cutoffs: c, RanAt: time.Now()}
+
+	for _, rule := range r.rules(c, report) {
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", rule.table, rule.where)
+		if err := r.db.QueryRowContext(ctx, query, rule.args...).Scan(rule.result); err != nil {
+			return nil, fmt.Errorf("failed to count %s for retention: %w", rule.table, err)
+		}
+	}
+
+	return report, nil
+}
+
+// Purge deletes the rows matched by the retention rules in a single transaction.
+// Rejected events go first; their source and entity links and status history cascade.
+func (r *RetentionRepository) Purge(ctx context.Context, c models.RetentionCutoffs) (*models.RetentionReport, error) {
+	report := &models.RetentionReport{Cutoffs: c, RanAt: time.Now()}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, rule := range r.rules(c, report) {
+		query := fmt.Sprintf("DELETE FROM %s WHERE %s", rule.table, rule.where)
+		result, err := tx.ExecContext(ctx, query, rule.args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete %s for retention: %w", rule.table, err)
+		}
+		if *rule.result, err = result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit retention: %w", err)
+	}
+
+	return report, nil
+}
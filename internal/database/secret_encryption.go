@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
+)
+
+// secretColumns are the columns holding a single credential per row
+var secretColumns = []struct {
+	table  string
+	column string
+}{
+	{"forecast_models", "api_key"},
+	{"parent_forecast_models", "api_key"},
+	{"strategy_models", "api_key"},
+	{"openai_config", "api_key"},
+	{"forecasts", "completion_webhook_secret"},
+	{"twitter_config", "api_key"},
+	{"twitter_config", "api_secret"},
+	{"twitter_config", "access_token"},
+	{"twitter_config", "access_token_secret"},
+	{"twitter_config", "bearer_token"},
+}
+
+// secretJSONColumns are the JSON columns holding credentials among other settings, with the
+// function encrypting the plaintext credentials of one stored value
+var secretJSONColumns = []struct {
+	table   string
+	column  string
+	encrypt func(data []byte) ([]byte, int, error)
+}{
+	{"connector_config", "config", encryptStoredConnectorSettings},
+	{"summaries", "models", encryptStoredSummaryModels},
+	{"tracked_accounts", "fetch_auth", encryptStoredFeedAuth},
+}
+
+// EncryptStoredSecrets encrypts the API keys and other credentials still stored in plaintext,
+// such as those saved before an encryption key was configured, and returns how many values it
+// encrypted. It does nothing without a key and is safe to run on every start.
+func EncryptStoredSecrets(ctx context.Context, db *sql.DB) (int, error) {
+	if !secrets.Enabled() {
+		return 0, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	encrypted := 0
+	for _, sc := range secretColumns {
+		n, err := encryptColumn(ctx, tx, sc.table, sc.column)
+		if err != nil {
+			return 0, err
+		}
+		encrypted += n
+	}
+	for _, sc := range secretJSONColumns {
+		n, err := encryptJSONColumn(ctx, tx, sc.table, sc.column, sc.encrypt)
+		if err != nil {
+			return 0, err
+		}
+		encrypted += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return encrypted, nil
+}
+
+// isPlaintextSecret reports whether a stored credential still needs encrypting
+func isPlaintextSecret(value string) bool {
+	return value != "" && !secrets.IsEncrypted(value)
+}
+
+// encryptColumn encrypts every plaintext value of a secret column
+func encryptColumn(ctx context.Context, tx *sql.Tx, table, column string) (int, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT id, %s FROM %s WHERE %s <> ''`, column, table, column))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s.%s: %w", table, column, err)
+	}
+
+	plaintext := map[string]string{}
+	for rows.Next() {
+		var id, value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan %s.%s: %w", table, column, err)
+		}
+		if isPlaintextSecret(value) {
+			plaintext[id] = value
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read %s.%s: %w", table, column, err)
+	}
+
+	update := fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE id = $2`, table, column)
+	for id, value := range plaintext {
+		stored, err := secrets.Encrypt(value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt %s.%s: %w", table, column, err)
+		}
+		if _, err := tx.ExecContext(ctx, update, stored, id); err != nil {
+			return 0, fmt.Errorf("failed to update %s.%s: %w", table, column, err)
+		}
+	}
+	return len(plaintext), nil
+}
+
+// encryptJSONColumn encrypts the plaintext credentials inside every value of a JSON column
+func encryptJSONColumn(ctx context.Context, tx *sql.Tx, table, column string, encrypt func(data []byte) ([]byte, int, error)) (int, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT id, %s FROM %s WHERE %s IS NOT NULL`, column, table, column))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s.%s: %w", table, column, err)
+	}
+
+	values := map[string][]byte{}
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan %s.%s: %w", table, column, err)
+		}
+		values[id] = data
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read %s.%s: %w", table, column, err)
+	}
+
+	encrypted := 0
+	update := fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE id = $2`, table, column)
+	for id, data := range values {
+		stored, n, err := encrypt(data)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt %s.%s of %s: %w", table, column, id, err)
+		}
+		if n == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, update, stored, id); err != nil {
+			return 0, fmt.Errorf("failed to update %s.%s of %s: %w", table, column, id, err)
+		}
+		encrypted += n
+	}
+	return encrypted, nil
+}
+
+// encryptStoredConnectorSettings encrypts the plaintext secret settings of a stored connector config
+func encryptStoredConnectorSettings(data []byte) ([]byte, int, error) {
+	var config map[string]string
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, 0, err
+	}
+	n := 0
+	for key, value := range config {
+		if models.IsSecretSetting(key) && isPlaintextSecret(value) {
+			n++
+		}
+	}
+	if n == 0 {
+		return data, 0, nil
+	}
+	stored, err := encryptSettings(config)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err = json.Marshal(stored)
+	return data, n, err
+}
+
+// encryptStoredSummaryModels encrypts the plaintext API keys of a summary's stored models
+func encryptStoredSummaryModels(data []byte) ([]byte, int, error) {
+	var summaryModels []models.SummaryModel
+	if err := json.Unmarshal(data, &summaryModels); err != nil {
+		return nil, 0, err
+	}
+	n := 0
+	for _, model := range summaryModels {
+		if isPlaintextSecret(model.APIKey) {
+			n++
+		}
+	}
+	if n == 0 {
+		return data, 0, nil
+	}
+	stored, err := encryptSummaryModels(summaryModels)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err = json.Marshal(stored)
+	return data, n, err
+}
+
+// encryptStoredFeedAuth encrypts the plaintext password and header values of a feed's stored
+// fetch credentials
+func encryptStoredFeedAuth(data []byte) ([]byte, int, error) {
+	var auth models.FeedAuth
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return nil, 0, err
+	}
+	n := 0
+	if isPlaintextSecret(auth.Password) {
+		n++
+	}
+	for _, value := range auth.Headers {
+		if isPlaintextSecret(value) {
+			n++
+		}
+	}
+	if n == 0 {
+		return data, 0, nil
+	}
+	stored, err := encryptFeedAuth(&auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err = json.Marshal(stored)
+	return data, n, err
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
+)
+
+func TestConnectorSettingsEncryption(t *testing.T) {
+	if err := secrets.Configure(bytes.Repeat([]byte{3}, secrets.KeySize)); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { secrets.Configure(nil) })
+
+	config := map[string]string{"bot_token": "123:abc", "translate_non_english": "true", "bearer_token": ""}
+
+	stored, err := encryptSettings(config)
+	if err != nil {
+		t.Fatalf("encryptSettings: %v", err)
+	}
+	if !secrets.IsEncrypted(stored["bot_token"]) || stored["translate_non_english"] != "true" || stored["bearer_token"] != "" {
+		t.Fatalf("expected only the non-empty secret to be encrypted, got %v", stored)
+	}
+	if config["bot_token"] != "123:abc" {
+		t.Error("encryptSettings modified the config it was given")
+	}
+
+	if err := decryptSettings(stored); err != nil {
+		t.Fatalf("decryptSettings: %v", err)
+	}
+	if stored["bot_token"] != "123:abc" {
+		t.Errorf("decrypted bot_token = %q", stored["bot_token"])
+	}
+}
+
+func TestEncryptStoredJSON(t *testing.T) {
+	if err := secrets.Configure(bytes.Repeat([]byte{3}, secrets.KeySize)); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { secrets.Configure(nil) })
+
+	tests := []struct {
+		name    string
+		encrypt func([]byte) ([]byte, int, error)
+		value   interface{}
+		want    int
+	}{
+		{"connector settings", encryptStoredConnectorSettings, map[string]string{"bot_token": "123:abc", "channels": "news"}, 1},
+		{"summary models", encryptStoredSummaryModels, []models.SummaryModel{{Provider: "openai", APIKey: "sk-1"}, {Provider: "anthropic", APIKey: "sk-2"}}, 2},
+		{"feed auth", encryptStoredFeedAuth, models.FeedAuth{Headers: map[string]string{"X-API-Key": "k"}, Username: "u", Password: "p"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored, n, err := tt.encrypt(data)
+			if err != nil {
+				t.Fatalf("encrypt: %v", err)
+			}
+			if n != tt.want {
+				t.Errorf("encrypted %d values, want %d", n, tt.want)
+			}
+			if bytes.Equal(stored, data) {
+				t.Error("expected the stored value to change")
+			}
+
+			// Once encrypted, a second pass has nothing to do
+			again, n, err := tt.encrypt(stored)
+			if err != nil || n != 0 || !bytes.Equal(again, stored) {
+				t.Errorf("second pass encrypted %d values (err %v)", n, err)
+			}
+		})
+	}
+}
+
+func TestSummaryAndFeedAuthRoundTrip(t *testing.T) {
+	if err := secrets.Configure(bytes.Repeat([]byte{3}, secrets.KeySize)); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { secrets.Configure(nil) })
+
+	summaryModels := []models.SummaryModel{{Provider: "openai", ModelName: "gpt-4o", APIKey: "sk-summary"}}
+	stored, err := encryptSummaryModels(summaryModels)
+	if err != nil {
+		t.Fatalf("encryptSummaryModels: %v", err)
+	}
+	if !secrets.IsEncrypted(stored[0].APIKey) || summaryModels[0].APIKey != "sk-summary" {
+		t.Fatalf("expected an encrypted copy, got %q (original %q)", stored[0].APIKey, summaryModels[0].APIKey)
+	}
+	if err := decryptSummaryModels(stored); err != nil || stored[0].APIKey != "sk-summary" {
+		t.Errorf("decryptSummaryModels = %q, %v", stored[0].APIKey, err)
+	}
+
+	auth := &models.FeedAuth{Headers: map[string]string{"X-API-Key": "feed-key"}, Username: "reader", Password: "hunter2"}
+	storedAuth, err := encryptFeedAuth(auth)
+	if err != nil {
+		t.Fatalf("encryptFeedAuth: %v", err)
+	}
+	if !secrets.IsEncrypted(storedAuth.Password) || !secrets.IsEncrypted(storedAuth.Headers["X-API-Key"]) || storedAuth.Username != "reader" {
+		t.Fatalf("expected the password and headers encrypted, got %+v", storedAuth)
+	}
+	if auth.Password != "hunter2" || auth.Headers["X-API-Key"] != "feed-key" {
+		t.Error("encryptFeedAuth modified the auth it was given")
+	}
+	if err := decryptFeedAuth(storedAuth); err != nil || storedAuth.Password != "hunter2" || storedAuth.Headers["X-API-Key"] != "feed-key" {
+		t.Errorf("decryptFeedAuth = %+v, %v", storedAuth, err)
+	}
+}
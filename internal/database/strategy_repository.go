@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
@@ -22,6 +23,46 @@ func NewStrategyRepository(db *sql.DB) *StrategyRepository {
 	return &StrategyRepository{db: db}
 }
 
+// strategyColumns is the column list scanned by scanStrategy
+const strategyColumns = `id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, structured_output, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at`
+
+// scanStrategy scans a row selected with strategyColumns into a strategy
+func scanStrategy(row rowScanner) (*models.Strategy, error) {
+	var strategy models.Strategy
+	var forecastHistoryCount sql.NullInt32
+
+	err := row.Scan(
+		&strategy.ID,
+		&strategy.Name,
+		&strategy.Prompt,
+		pq.Array(&strategy.InvestmentSymbols),
+		pq.Array(&strategy.Categories),
+		&strategy.HeadlineCount,
+		&strategy.Iterations,
+		pq.Array(&strategy.ForecastIDs),
+		&forecastHistoryCount,
+		&strategy.StructuredOutput,
+		&strategy.Active,
+		&strategy.Public,
+		&strategy.DisplayOrder,
+		&strategy.ScheduleEnabled,
+		&strategy.ScheduleInterval,
+		&strategy.LastRunAt,
+		&strategy.NextRunAt,
+		&strategy.CreatedAt,
+		&strategy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if forecastHistoryCount.Valid {
+		strategy.ForecastHistoryCount = int(forecastHistoryCount.Int32)
+	}
+
+	return &strategy, nil
+}
+
 // CreateStrategy creates a new strategy with its models
 func (r *StrategyRepository) CreateStrategy(ctx context.Context, req models.CreateStrategyRequest) (*models.Strategy, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -35,8 +76,8 @@ func (r *StrategyRepository) CreateStrategy(ctx context.Context, req models.Crea
 	now := time.Now()
 
 	query := `
-		INSERT INTO strategies (id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, active, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		INSERT INTO strategies (id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, structured_output, active, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
 	iterations := req.Iterations
@@ -49,7 +90,7 @@ func (r *StrategyRepository) CreateStrategy(ctx context.Context, req models.Crea
 		forecastHistoryCount = 1
 	}
 
-	_, err = tx.ExecContext(ctx, query, strategyID, req.Name, req.Prompt, pq.Array(req.InvestmentSymbols), pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ForecastIDs), forecastHistoryCount, true, false, 0, nil, nil, now, now)
+	_, err = tx.ExecContext(ctx, query, strategyID, req.Name, req.Prompt, pq.Array(req.InvestmentSymbols), pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ForecastIDs), forecastHistoryCount, req.StructuredOutput, true, false, 0, nil, nil, now, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create strategy: %w", err)
 	}
@@ -61,7 +102,11 @@ func (r *StrategyRepository) CreateStrategy(ctx context.Context, req models.Crea
 			INSERT INTO strategy_models (id, strategy_id, provider, model_name, api_key, weight, active, created_at)
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		`
-		_, err = tx.ExecContext(ctx, modelQuery, modelID, strategyID, model.Provider, model.ModelName, model.APIKey, model.Weight, true, now)
+		apiKey, err := secrets.Encrypt(model.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt api key of strategy model %s: %w", model.ModelName, err)
+		}
+		_, err = tx.ExecContext(ctx, modelQuery, modelID, strategyID, model.Provider, model.ModelName, apiKey, model.Weight, true, now)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create strategy model: %w", err)
 		}
@@ -97,11 +142,11 @@ func (r *StrategyRepository) UpdateStrategy(ctx context.Context, id string, req
 
 	query := `
 		UPDATE strategies
-		SET name = $2, prompt = $3, investment_symbols = $4, categories = $5, headline_count = $6, iterations = $7, forecast_ids = $8, forecast_history_count = $9, updated_at = $10
+		SET name = $2, prompt = $3, investment_symbols = $4, categories = $5, headline_count = $6, iterations = $7, forecast_ids = $8, forecast_history_count = $9, structured_output = $10, updated_at = $11
 		WHERE id = $1
 	`
 
-	result, err := tx.ExecContext(ctx, query, id, req.Name, req.Prompt, pq.Array(req.InvestmentSymbols), pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ForecastIDs), forecastHistoryCount, now)
+	result, err := tx.ExecContext(ctx, query, id, req.Name, req.Prompt, pq.Array(req.InvestmentSymbols), pq.Array(req.Categories), req.HeadlineCount, iterations, pq.Array(req.ForecastIDs), forecastHistoryCount, req.StructuredOutput, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update strategy: %w", err)
 	}
@@ -128,7 +173,11 @@ func (r *StrategyRepository) UpdateStrategy(ctx context.Context, id string, req
 			INSERT INTO strategy_models (id, strategy_id, provider, model_name, api_key, weight, active, created_at)
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		`
-		_, err = tx.ExecContext(ctx, modelQuery, modelID, id, model.Provider, model.ModelName, model.APIKey, model.Weight, true, now)
+		apiKey, err := secrets.Encrypt(model.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt api key of strategy model %s: %w", model.ModelName, err)
+		}
+		_, err = tx.ExecContext(ctx, modelQuery, modelID, id, model.Provider, model.ModelName, apiKey, model.Weight, true, now)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create strategy model: %w", err)
 		}
@@ -143,33 +192,12 @@ func (r *StrategyRepository) UpdateStrategy(ctx context.Context, id string, req
 
 // GetStrategy retrieves a single strategy by ID
 func (r *StrategyRepository) GetStrategy(ctx context.Context, id string) (*models.Strategy, error) {
-	query := `
-		SELECT id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+	query := `SELECT ` + strategyColumns + `
 		FROM strategies
 		WHERE id = $1
 	`
 
-	var strategy models.Strategy
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&strategy.ID,
-		&strategy.Name,
-		&strategy.Prompt,
-		pq.Array(&strategy.InvestmentSymbols),
-		pq.Array(&strategy.Categories),
-		&strategy.HeadlineCount,
-		&strategy.Iterations,
-		pq.Array(&strategy.ForecastIDs),
-		&strategy.ForecastHistoryCount,
-		&strategy.Active,
-		&strategy.Public,
-		&strategy.DisplayOrder,
-		&strategy.ScheduleEnabled,
-		&strategy.ScheduleInterval,
-		&strategy.LastRunAt,
-		&strategy.NextRunAt,
-		&strategy.CreatedAt,
-		&strategy.UpdatedAt,
-	)
+	strategy, err := scanStrategy(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("strategy not found")
@@ -177,13 +205,13 @@ func (r *StrategyRepository) GetStrategy(ctx context.Context, id string) (*model
 		return nil, fmt.Errorf("failed to get strategy: %w", err)
 	}
 
-	return &strategy, nil
+	return strategy, nil
 }
 
 // ListStrategies retrieves all strategies
 func (r *StrategyRepository) ListStrategies(ctx context.Context) ([]models.Strategy, error) {
 	query := `
-		SELECT id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+		SELECT ` + strategyColumns + `
 		FROM strategies
 		ORDER BY created_at DESC
 	`
@@ -196,32 +224,12 @@ func (r *StrategyRepository) ListStrategies(ctx context.Context) ([]models.Strat
 
 	var strategies []models.Strategy
 	for rows.Next() {
-		var strategy models.Strategy
-		err := rows.Scan(
-			&strategy.ID,
-			&strategy.Name,
-			&strategy.Prompt,
-			pq.Array(&strategy.InvestmentSymbols),
-			pq.Array(&strategy.Categories),
-			&strategy.HeadlineCount,
-			&strategy.Iterations,
-			pq.Array(&strategy.ForecastIDs),
-			&strategy.ForecastHistoryCount,
-			&strategy.Active,
-			&strategy.Public,
-			&strategy.DisplayOrder,
-			&strategy.ScheduleEnabled,
-			&strategy.ScheduleInterval,
-			&strategy.LastRunAt,
-			&strategy.NextRunAt,
-			&strategy.CreatedAt,
-			&strategy.UpdatedAt,
-		)
+		strategy, err := scanStrategy(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan strategy: %w", err)
 		}
 
-		strategies = append(strategies, strategy)
+		strategies = append(strategies, *strategy)
 	}
 
 	return strategies, nil
@@ -230,7 +238,7 @@ func (r *StrategyRepository) ListStrategies(ctx context.Context) ([]models.Strat
 // ListPublicStrategies retrieves all public strategies ordered by display_order
 func (r *StrategyRepository) ListPublicStrategies(ctx context.Context) ([]models.Strategy, error) {
 	query := `
-		SELECT id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+		SELECT ` + strategyColumns + `
 		FROM strategies
 		WHERE public = true
 		ORDER BY display_order DESC, created_at DESC
@@ -244,32 +252,12 @@ func (r *StrategyRepository) ListPublicStrategies(ctx context.Context) ([]models
 
 	var strategies []models.Strategy
 	for rows.Next() {
-		var strategy models.Strategy
-		err := rows.Scan(
-			&strategy.ID,
-			&strategy.Name,
-			&strategy.Prompt,
-			pq.Array(&strategy.InvestmentSymbols),
-			pq.Array(&strategy.Categories),
-			&strategy.HeadlineCount,
-			&strategy.Iterations,
-			pq.Array(&strategy.ForecastIDs),
-			&strategy.ForecastHistoryCount,
-			&strategy.Active,
-			&strategy.Public,
-			&strategy.DisplayOrder,
-			&strategy.ScheduleEnabled,
-			&strategy.ScheduleInterval,
-			&strategy.LastRunAt,
-			&strategy.NextRunAt,
-			&strategy.CreatedAt,
-			&strategy.UpdatedAt,
-		)
+		strategy, err := scanStrategy(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan strategy: %w", err)
 		}
 
-		strategies = append(strategies, strategy)
+		strategies = append(strategies, *strategy)
 	}
 
 	return strategies, nil
@@ -326,6 +314,9 @@ func (r *StrategyRepository) GetStrategyModels(ctx context.Context, strategyID s
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan strategy model: %w", err)
 		}
+		if model.APIKey, err = secrets.Decrypt(model.APIKey); err != nil {
+			return nil, fmt.Errorf("failed to decrypt api key of strategy model %s: %w", model.ID, err)
+		}
 
 		strategyModels = append(strategyModels, model)
 	}
@@ -404,11 +395,11 @@ func (r *StrategyRepository) CreateModelResponse(ctx context.Context, response m
 	}
 
 	query := `
-		INSERT INTO strategy_model_responses (id, run_id, model_id, iteration, provider, model_name, allocations, reasoning, raw_response, tokens_used, response_time_ms, status, error_message, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		INSERT INTO strategy_model_responses (id, run_id, model_id, iteration, provider, model_name, allocations, reasoning, parse_mode, raw_response, tokens_used, response_time_ms, status, error_message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err = r.db.ExecContext(ctx, query, responseID, response.RunID, response.ModelID, response.Iteration, response.Provider, response.ModelName, allocationsJSON, response.Reasoning, rawResponseJSON, response.TokensUsed, response.ResponseTimeMs, response.Status, response.ErrorMessage, time.Now())
+	_, err = r.db.ExecContext(ctx, query, responseID, response.RunID, response.ModelID, response.Iteration, response.Provider, response.ModelName, allocationsJSON, response.Reasoning, response.ParseMode, rawResponseJSON, response.TokensUsed, response.ResponseTimeMs, response.Status, response.ErrorMessage, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to create model response: %w", err)
 	}
@@ -487,7 +478,7 @@ func (r *StrategyRepository) GetStrategyRun(ctx context.Context, runID string) (
 
 	// Get all responses
 	responsesQuery := `
-		SELECT id, run_id, model_id, iteration, provider, model_name, allocations, reasoning, raw_response, tokens_used, response_time_ms, status, error_message, created_at
+		SELECT id, run_id, model_id, iteration, provider, model_name, allocations, reasoning, parse_mode, raw_response, tokens_used, response_time_ms, status, error_message, created_at
 		FROM strategy_model_responses
 		WHERE run_id = $1
 		ORDER BY iteration, created_at
@@ -503,6 +494,7 @@ func (r *StrategyRepository) GetStrategyRun(ctx context.Context, runID string) (
 	for rows.Next() {
 		var response models.StrategyModelResponse
 		var allocationsJSON, rawResponseJSON []byte
+		var parseMode sql.NullString
 
 		err := rows.Scan(
 			&response.ID,
@@ -513,6 +505,7 @@ func (r *StrategyRepository) GetStrategyRun(ctx context.Context, runID string) (
 			&response.ModelName,
 			&allocationsJSON,
 			&response.Reasoning,
+			&parseMode,
 			&rawResponseJSON,
 			&response.TokensUsed,
 			&response.ResponseTimeMs,
@@ -523,6 +516,7 @@ func (r *StrategyRepository) GetStrategyRun(ctx context.Context, runID string) (
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan model response: %w", err)
 		}
+		response.ParseMode = parseMode.String
 
 		if err := json.Unmarshal(allocationsJSON, &response.Allocations); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal allocations: %w", err)
@@ -714,7 +708,7 @@ func (r *StrategyRepository) GetScheduledStrategies(ctx context.Context) ([]mode
 			ORDER BY next_run_at ASC NULLS FIRST
 			FOR UPDATE SKIP LOCKED
 		)
-		RETURNING id, name, prompt, investment_symbols, categories, headline_count, iterations, forecast_ids, forecast_history_count, active, public, display_order, schedule_enabled, schedule_interval, last_run_at, next_run_at, created_at, updated_at
+		RETURNING ` + strategyColumns + `
 	`
 
 	now := time.Now()
@@ -726,44 +720,12 @@ func (r *StrategyRepository) GetScheduledStrategies(ctx context.Context) ([]mode
 
 	var strategies []models.Strategy
 	for rows.Next() {
-		var strategy models.Strategy
-		var lastRunAt, nextRunAt sql.NullTime
-		var forecastHistoryCount sql.NullInt32
-		err := rows.Scan(
-			&strategy.ID,
-			&strategy.Name,
-			&strategy.Prompt,
-			pq.Array(&strategy.InvestmentSymbols),
-			pq.Array(&strategy.Categories),
-			&strategy.HeadlineCount,
-			&strategy.Iterations,
-			pq.Array(&strategy.ForecastIDs),
-			&forecastHistoryCount,
-			&strategy.Active,
-			&strategy.Public,
-			&strategy.DisplayOrder,
-			&strategy.ScheduleEnabled,
-			&strategy.ScheduleInterval,
-			&lastRunAt,
-			&nextRunAt,
-			&strategy.CreatedAt,
-			&strategy.UpdatedAt,
-		)
+		strategy, err := scanStrategy(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan strategy: %w", err)
 		}
 
-		if lastRunAt.Valid {
-			strategy.LastRunAt = &lastRunAt.Time
-		}
-		if nextRunAt.Valid {
-			strategy.NextRunAt = &nextRunAt.Time
-		}
-		if forecastHistoryCount.Valid {
-			strategy.ForecastHistoryCount = int(forecastHistoryCount.Int32)
-		}
-
-		strategies = append(strategies, strategy)
+		strategies = append(strategies, *strategy)
 	}
 
 	if err = rows.Err(); err != nil {
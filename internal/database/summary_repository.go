@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
 	"github.com/lib/pq"
 )
 
@@ -35,14 +36,18 @@ func formatTimeOfDay(tod *string) *string {
 }
 
 func (r *SummaryRepository) Create(ctx context.Context, summary *models.Summary) error {
-	modelsJSON, err := json.Marshal(summary.Models)
+	storedModels, err := encryptSummaryModels(summary.Models)
+	if err != nil {
+		return err
+	}
+	modelsJSON, err := json.Marshal(storedModels)
 	if err != nil {
 		return fmt.Errorf("failed to marshal models: %w", err)
 	}
 
 	query := `
-		INSERT INTO summaries (name, prompt, time_of_day, lookback_hours, categories, headline_count, models, active, schedule_enabled, schedule_interval, auto_post_to_twitter, include_forecasts)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO summaries (name, prompt, time_of_day, lookback_hours, categories, headline_count, models, active, schedule_enabled, schedule_interval, auto_post_to_twitter, include_forecasts, mode, digest_per_category, email_enabled, email_recipients, format)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id, created_at, updated_at
 	`
 	return r.db.QueryRowContext(ctx, query,
@@ -58,12 +63,17 @@ func (r *SummaryRepository) Create(ctx context.Context, summary *models.Summary)
 		summary.ScheduleInterval,
 		summary.AutoPostToTwitter,
 		summary.IncludeForecasts,
+		summary.Mode,
+		summary.DigestPerCategory,
+		summary.EmailEnabled,
+		pq.Array(summary.EmailRecipients),
+		summary.Format,
 	).Scan(&summary.ID, &summary.CreatedAt, &summary.UpdatedAt)
 }
 
 func (r *SummaryRepository) List(ctx context.Context) ([]models.Summary, error) {
 	query := `
-		SELECT id, name, prompt, time_of_day::text, lookback_hours, categories, headline_count, models, active, schedule_enabled, schedule_interval, auto_post_to_twitter, include_forecasts, last_run_at, next_run_at, created_at, updated_at
+		SELECT id, name, prompt, time_of_day::text, lookback_hours, categories, headline_count, models, active, schedule_enabled, schedule_interval, auto_post_to_twitter, include_forecasts, mode, digest_per_category, email_enabled, email_recipients, format, last_run_at, next_run_at, created_at, updated_at
 		FROM summaries
 		ORDER BY created_at DESC
 	`
@@ -81,7 +91,7 @@ func (r *SummaryRepository) List(ctx context.Context) ([]models.Summary, error)
 			&s.ID, &s.Name, &s.Prompt, &s.TimeOfDay, &s.LookbackHours,
 			pq.Array(&s.Categories), &s.HeadlineCount, &modelsJSON,
 			&s.Active, &s.ScheduleEnabled, &s.ScheduleInterval, &s.AutoPostToTwitter, &s.IncludeForecasts,
-			&s.LastRunAt, &s.NextRunAt, &s.CreatedAt, &s.UpdatedAt,
+			&s.Mode, &s.DigestPerCategory, &s.EmailEnabled, pq.Array(&s.EmailRecipients), &s.Format, &s.LastRunAt, &s.NextRunAt, &s.CreatedAt, &s.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -89,6 +99,9 @@ func (r *SummaryRepository) List(ctx context.Context) ([]models.Summary, error)
 		if err := json.Unmarshal(modelsJSON, &s.Models); err != nil {
 			return nil, err
 		}
+		if err := decryptSummaryModels(s.Models); err != nil {
+			return nil, err
+		}
 		// Format time_of_day from HH:MM:SS to HH:MM for HTML time inputs
 		s.TimeOfDay = formatTimeOfDay(s.TimeOfDay)
 		summaries = append(summaries, s)
@@ -98,7 +111,7 @@ func (r *SummaryRepository) List(ctx context.Context) ([]models.Summary, error)
 
 func (r *SummaryRepository) Get(ctx context.Context, id string) (*models.Summary, error) {
 	query := `
-		SELECT id, name, prompt, time_of_day::text, lookback_hours, categories, headline_count, models, active, schedule_enabled, schedule_interval, auto_post_to_twitter, include_forecasts, last_run_at, next_run_at, created_at, updated_at
+		SELECT id, name, prompt, time_of_day::text, lookback_hours, categories, headline_count, models, active, schedule_enabled, schedule_interval, auto_post_to_twitter, include_forecasts, mode, digest_per_category, email_enabled, email_recipients, format, last_run_at, next_run_at, created_at, updated_at
 		FROM summaries
 		WHERE id = $1
 	`
@@ -108,7 +121,7 @@ func (r *SummaryRepository) Get(ctx context.Context, id string) (*models.Summary
 		&s.ID, &s.Name, &s.Prompt, &s.TimeOfDay, &s.LookbackHours,
 		pq.Array(&s.Categories), &s.HeadlineCount, &modelsJSON,
 		&s.Active, &s.ScheduleEnabled, &s.ScheduleInterval, &s.AutoPostToTwitter, &s.IncludeForecasts,
-		&s.LastRunAt, &s.NextRunAt, &s.CreatedAt, &s.UpdatedAt,
+		&s.Mode, &s.DigestPerCategory, &s.EmailEnabled, pq.Array(&s.EmailRecipients), &s.Format, &s.LastRunAt, &s.NextRunAt, &s.CreatedAt, &s.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -116,30 +129,66 @@ func (r *SummaryRepository) Get(ctx context.Context, id string) (*models.Summary
 	if err := json.Unmarshal(modelsJSON, &s.Models); err != nil {
 		return nil, err
 	}
+	if err := decryptSummaryModels(s.Models); err != nil {
+		return nil, err
+	}
 	// Format time_of_day from HH:MM:SS to HH:MM for HTML time inputs
 	s.TimeOfDay = formatTimeOfDay(s.TimeOfDay)
 	return &s, nil
 }
 
 func (r *SummaryRepository) Update(ctx context.Context, summary *models.Summary) error {
-	modelsJSON, err := json.Marshal(summary.Models)
+	storedModels, err := encryptSummaryModels(summary.Models)
+	if err != nil {
+		return err
+	}
+	modelsJSON, err := json.Marshal(storedModels)
 	if err != nil {
 		return err
 	}
 
 	query := `
 		UPDATE summaries
-		SET name = $1, prompt = $2, time_of_day = $3, lookback_hours = $4, categories = $5, headline_count = $6, models = $7, active = $8, schedule_enabled = $9, schedule_interval = $10, auto_post_to_twitter = $11, include_forecasts = $12
-		WHERE id = $13
+		SET name = $1, prompt = $2, time_of_day = $3, lookback_hours = $4, categories = $5, headline_count = $6, models = $7, active = $8, schedule_enabled = $9, schedule_interval = $10, auto_post_to_twitter = $11, include_forecasts = $12, mode = $13, digest_per_category = $14, email_enabled = $15, email_recipients = $16, format = $17
+		WHERE id = $18
 	`
 	_, err = r.db.ExecContext(ctx, query,
 		summary.Name, summary.Prompt, summary.TimeOfDay, summary.LookbackHours,
 		pq.Array(summary.Categories), summary.HeadlineCount, modelsJSON,
-		summary.Active, summary.ScheduleEnabled, summary.ScheduleInterval, summary.AutoPostToTwitter, summary.IncludeForecasts, summary.ID,
+		summary.Active, summary.ScheduleEnabled, summary.ScheduleInterval, summary.AutoPostToTwitter, summary.IncludeForecasts, summary.Mode, summary.DigestPerCategory, summary.EmailEnabled, pq.Array(summary.EmailRecipients), summary.Format, summary.ID,
 	)
 	return err
 }
 
+// encryptSummaryModels returns a copy of a summary's models with their API keys encrypted for storage
+func encryptSummaryModels(summaryModels []models.SummaryModel) ([]models.SummaryModel, error) {
+	if summaryModels == nil {
+		return nil, nil
+	}
+	stored := make([]models.SummaryModel, len(summaryModels))
+	for i, model := range summaryModels {
+		apiKey, err := secrets.Encrypt(model.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt api key of summary model %s: %w", model.ModelName, err)
+		}
+		model.APIKey = apiKey
+		stored[i] = model
+	}
+	return stored, nil
+}
+
+// decryptSummaryModels decrypts the API keys of a summary's stored models in place
+func decryptSummaryModels(summaryModels []models.SummaryModel) error {
+	for i := range summaryModels {
+		apiKey, err := secrets.Decrypt(summaryModels[i].APIKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt api key of summary model %s: %w", summaryModels[i].ModelName, err)
+		}
+		summaryModels[i].APIKey = apiKey
+	}
+	return nil
+}
+
 func (r *SummaryRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.db.ExecContext(ctx, "DELETE FROM summaries WHERE id = $1", id)
 	return err
@@ -155,11 +204,11 @@ func (r *SummaryRepository) CreateRun(ctx context.Context, summaryID string, hea
 	return runID, err
 }
 
-func (r *SummaryRepository) SaveResult(ctx context.Context, runID, summaryText, provider, modelName string) error {
+func (r *SummaryRepository) SaveResult(ctx context.Context, runID, summaryText, format, provider, modelName string) error {
 	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO summary_results (run_id, summary_text, model_provider, model_name)
-		 VALUES ($1, $2, $3, $4)`,
-		runID, summaryText, provider, modelName,
+		`INSERT INTO summary_results (run_id, summary_text, format, model_provider, model_name)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		runID, summaryText, format, provider, modelName,
 	)
 	return err
 }
@@ -172,6 +221,56 @@ func (r *SummaryRepository) CompleteRun(ctx context.Context, runID string, statu
 	return err
 }
 
+// SetRunEventIDs records the events a run's prompt was built from
+func (r *SummaryRepository) SetRunEventIDs(ctx context.Context, runID string, eventIDs []string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE summary_runs SET event_ids = $1 WHERE id = $2`,
+		pq.Array(eventIDs), runID,
+	)
+	return err
+}
+
+// loadCitations returns the events behind a run in prompt order, skipping events since deleted
+func (r *SummaryRepository) loadCitations(ctx context.Context, eventIDs []string) ([]models.SummaryCitation, error) {
+	citations := []models.SummaryCitation{}
+	if len(eventIDs) == 0 {
+		return citations, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, title, category, magnitude, timestamp
+		 FROM events
+		 WHERE id = ANY($1::text[])
+		 ORDER BY array_position($1::text[], id::text)`,
+		pq.Array(eventIDs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load summary citations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.SummaryCitation
+		if err := rows.Scan(&c.EventID, &c.Title, &c.Category, &c.Magnitude, &c.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan summary citation: %w", err)
+		}
+		citations = append(citations, c)
+	}
+	return citations, rows.Err()
+}
+
+// RecordEmailDelivery stores the outcome of emailing a run; errorMsg is nil when it was sent
+func (r *SummaryRepository) RecordEmailDelivery(ctx context.Context, runID string, status string, errorMsg *string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE summary_runs
+		 SET email_status = $1, email_error = $2,
+		     email_sent_at = CASE WHEN $1 = 'sent' THEN CURRENT_TIMESTAMP END
+		 WHERE id = $3`,
+		status, errorMsg, runID,
+	)
+	return err
+}
+
 func (r *SummaryRepository) GetLatestRun(ctx context.Context, summaryID string) (*models.SummaryRunDetail, error) {
 	// Get latest completed run
 	var runID string
@@ -186,18 +285,20 @@ func (r *SummaryRepository) GetLatestRun(ctx context.Context, summaryID string)
 	// Get run details
 	var run models.SummaryRun
 	err = r.db.QueryRowContext(ctx,
-		`SELECT id, summary_id, run_at, headline_count, lookback_start, lookback_end, status, error_message, completed_at
+		`SELECT id, summary_id, run_at, headline_count, lookback_start, lookback_end, status, error_message, completed_at,
+		        email_status, email_error, email_sent_at, event_ids
 		 FROM summary_runs WHERE id = $1`,
 		runID,
 	).Scan(&run.ID, &run.SummaryID, &run.RunAt, &run.HeadlineCount, &run.LookbackStart,
-		&run.LookbackEnd, &run.Status, &run.ErrorMessage, &run.CompletedAt)
+		&run.LookbackEnd, &run.Status, &run.ErrorMessage, &run.CompletedAt,
+		&run.EmailStatus, &run.EmailError, &run.EmailSentAt, pq.Array(&run.EventIDs))
 	if err != nil {
 		return nil, err
 	}
 
 	// Get results
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, run_id, summary_text, model_provider, model_name, created_at
+		`SELECT id, run_id, summary_text, format, model_provider, model_name, created_at
 		 FROM summary_results WHERE run_id = $1 ORDER BY created_at`,
 		runID,
 	)
@@ -209,31 +310,38 @@ func (r *SummaryRepository) GetLatestRun(ctx context.Context, summaryID string)
 	var results []models.SummaryResult
 	for rows.Next() {
 		var r models.SummaryResult
-		if err := rows.Scan(&r.ID, &r.RunID, &r.SummaryText, &r.ModelProvider, &r.ModelName, &r.CreatedAt); err != nil {
+		if err := rows.Scan(&r.ID, &r.RunID, &r.SummaryText, &r.Format, &r.ModelProvider, &r.ModelName, &r.CreatedAt); err != nil {
 			return nil, err
 		}
 		results = append(results, r)
 	}
 
-	return &models.SummaryRunDetail{Run: run, Results: results}, nil
+	citations, err := r.loadCitations(ctx, run.EventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SummaryRunDetail{Run: run, Results: results, Citations: citations}, nil
 }
 
 func (r *SummaryRepository) GetRunByID(ctx context.Context, runID string) (*models.SummaryRunDetail, error) {
 	// Get run details
 	var run models.SummaryRun
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, summary_id, run_at, headline_count, lookback_start, lookback_end, status, error_message, completed_at
+		`SELECT id, summary_id, run_at, headline_count, lookback_start, lookback_end, status, error_message, completed_at,
+		        email_status, email_error, email_sent_at, event_ids
 		 FROM summary_runs WHERE id = $1`,
 		runID,
 	).Scan(&run.ID, &run.SummaryID, &run.RunAt, &run.HeadlineCount, &run.LookbackStart,
-		&run.LookbackEnd, &run.Status, &run.ErrorMessage, &run.CompletedAt)
+		&run.LookbackEnd, &run.Status, &run.ErrorMessage, &run.CompletedAt,
+		&run.EmailStatus, &run.EmailError, &run.EmailSentAt, pq.Array(&run.EventIDs))
 	if err != nil {
 		return nil, err
 	}
 
 	// Get results
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, run_id, summary_text, model_provider, model_name, created_at
+		`SELECT id, run_id, summary_text, format, model_provider, model_name, created_at
 		 FROM summary_results WHERE run_id = $1 ORDER BY created_at`,
 		runID,
 	)
@@ -245,18 +353,24 @@ func (r *SummaryRepository) GetRunByID(ctx context.Context, runID string) (*mode
 	var results []models.SummaryResult
 	for rows.Next() {
 		var r models.SummaryResult
-		if err := rows.Scan(&r.ID, &r.RunID, &r.SummaryText, &r.ModelProvider, &r.ModelName, &r.CreatedAt); err != nil {
+		if err := rows.Scan(&r.ID, &r.RunID, &r.SummaryText, &r.Format, &r.ModelProvider, &r.ModelName, &r.CreatedAt); err != nil {
 			return nil, err
 		}
 		results = append(results, r)
 	}
 
-	return &models.SummaryRunDetail{Run: run, Results: results}, nil
+	citations, err := r.loadCitations(ctx, run.EventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SummaryRunDetail{Run: run, Results: results, Citations: citations}, nil
 }
 
 func (r *SummaryRepository) ListRuns(ctx context.Context, summaryID string) ([]models.SummaryRun, error) {
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, summary_id, run_at, headline_count, lookback_start, lookback_end, status, error_message, completed_at
+		`SELECT id, summary_id, run_at, headline_count, lookback_start, lookback_end, status, error_message, completed_at,
+		        email_status, email_error, email_sent_at, event_ids
 		 FROM summary_runs WHERE summary_id = $1 ORDER BY run_at DESC LIMIT 50`,
 		summaryID,
 	)
@@ -269,7 +383,8 @@ func (r *SummaryRepository) ListRuns(ctx context.Context, summaryID string) ([]m
 	for rows.Next() {
 		var r models.SummaryRun
 		if err := rows.Scan(&r.ID, &r.SummaryID, &r.RunAt, &r.HeadlineCount, &r.LookbackStart,
-			&r.LookbackEnd, &r.Status, &r.ErrorMessage, &r.CompletedAt); err != nil {
+			&r.LookbackEnd, &r.Status, &r.ErrorMessage, &r.CompletedAt,
+			&r.EmailStatus, &r.EmailError, &r.EmailSentAt, pq.Array(&r.EventIDs)); err != nil {
 			return nil, err
 		}
 		runs = append(runs, r)
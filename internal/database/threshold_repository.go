@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
@@ -18,20 +19,29 @@ func NewThresholdRepository(db *sql.DB) *ThresholdRepository {
 	return &ThresholdRepository{db: db}
 }
 
-// Get retrieves the current threshold configuration.
+// Get retrieves the current threshold configuration of the workspace ctx is scoped to. Workspaces
+// that never saved their own thresholds use the default workspace's.
 func (r *ThresholdRepository) Get(ctx context.Context) (*models.ThresholdConfig, error) {
 	query := `
-		SELECT min_confidence, min_magnitude, max_source_age_hours, updated_at
+		SELECT min_confidence, min_magnitude, max_source_age_hours, min_sources,
+		       min_source_types, min_source_domains,
+		       breaking_min_magnitude, breaking_window_hours, updated_at
 		FROM threshold_config
-		ORDER BY id DESC
+		WHERE workspace_id IN ($1, $2)
+		ORDER BY (workspace_id = $1) DESC, id DESC
 		LIMIT 1
 	`
 
 	var config models.ThresholdConfig
-	err := r.db.QueryRowContext(ctx, query).Scan(
+	err := r.db.QueryRowContext(ctx, query, writeWorkspace(ctx, ""), models.DefaultWorkspace).Scan(
 		&config.MinConfidence,
 		&config.MinMagnitude,
 		&config.MaxSourceAgeHours,
+		&config.MinSources,
+		&config.MinSourceTypes,
+		&config.MinSourceDomains,
+		&config.BreakingMinMagnitude,
+		&config.BreakingWindowHours,
 		&config.UpdatedAt,
 	)
 	if err != nil {
@@ -41,25 +51,59 @@ func (r *ThresholdRepository) Get(ctx context.Context) (*models.ThresholdConfig,
 	return &config, nil
 }
 
-// Update updates the threshold configuration.
+// Update updates the threshold configuration of the workspace ctx is scoped to, giving the
+// workspace its own configuration the first time it is saved.
 func (r *ThresholdRepository) Update(ctx context.Context, config *models.ThresholdConfig) error {
+	workspaceID := writeWorkspace(ctx, "")
 	query := `
 		UPDATE threshold_config
 		SET min_confidence = $1,
 		    min_magnitude = $2,
 		    max_source_age_hours = $3,
-		    updated_at = $4
-		WHERE id = (SELECT id FROM threshold_config ORDER BY id DESC LIMIT 1)
+		    min_sources = $4,
+		    breaking_min_magnitude = $5,
+		    breaking_window_hours = $6,
+		    updated_at = $7,
+		    min_source_types = $8,
+		    min_source_domains = $9
+		WHERE id = (SELECT id FROM threshold_config WHERE workspace_id = $10 ORDER BY id DESC LIMIT 1)
 	`
 
 	config.UpdatedAt = time.Now()
 
-	_, err := r.db.ExecContext(ctx, query,
+	args := []interface{}{
 		config.MinConfidence,
 		config.MinMagnitude,
 		config.MaxSourceAgeHours,
+		config.MinSources,
+		config.BreakingMinMagnitude,
+		config.BreakingWindowHours,
 		config.UpdatedAt,
-	)
+		config.MinSourceTypes,
+		config.MinSourceDomains,
+		workspaceID,
+	}
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update thresholds: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if updated > 0 {
+		return nil
+	}
 
-	return err
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO threshold_config (
+			min_confidence, min_magnitude, max_source_age_hours, min_sources,
+			breaking_min_magnitude, breaking_window_hours, updated_at,
+			min_source_types, min_source_domains, workspace_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to create workspace thresholds: %w", err)
+	}
+	return nil
 }
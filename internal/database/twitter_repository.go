@@ -3,9 +3,11 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
+	"github.com/STRATINT/stratint/internal/secrets"
 )
 
 // TwitterRepository handles Twitter configuration storage.
@@ -62,9 +64,32 @@ func (r *TwitterRepository) Get(ctx context.Context) (*models.TwitterConfig, err
 		return nil, err
 	}
 
+	for _, secret := range twitterSecrets(&config.APIKey, &config.APISecret, &config.AccessToken, &config.AccessTokenSecret, &config.BearerToken) {
+		if *secret.value, err = secrets.Decrypt(*secret.value); err != nil {
+			return nil, fmt.Errorf("failed to decrypt twitter %s: %w", secret.name, err)
+		}
+	}
+
 	return &config, nil
 }
 
+// twitterSecret is one of the stored Twitter credentials
+type twitterSecret struct {
+	name  string
+	value *string
+}
+
+// twitterSecrets names the Twitter credentials, given in column order
+func twitterSecrets(apiKey, apiSecret, accessToken, accessTokenSecret, bearerToken *string) []twitterSecret {
+	return []twitterSecret{
+		{"api_key", apiKey},
+		{"api_secret", apiSecret},
+		{"access_token", accessToken},
+		{"access_token_secret", accessTokenSecret},
+		{"bearer_token", bearerToken},
+	}
+}
+
 // Update updates the Twitter configuration.
 func (r *TwitterRepository) Update(ctx context.Context, update *models.TwitterConfigUpdate) error {
 	query := `
@@ -87,12 +112,21 @@ func (r *TwitterRepository) Update(ctx context.Context, update *models.TwitterCo
 
 	now := time.Now()
 
+	stored := *update
+	for _, secret := range twitterSecrets(&stored.APIKey, &stored.APISecret, &stored.AccessToken, &stored.AccessTokenSecret, &stored.BearerToken) {
+		encrypted, err := secrets.Encrypt(*secret.value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt twitter %s: %w", secret.name, err)
+		}
+		*secret.value = encrypted
+	}
+
 	_, err := r.db.ExecContext(ctx, query,
-		update.APIKey,
-		update.APISecret,
-		update.AccessToken,
-		update.AccessTokenSecret,
-		update.BearerToken,
+		stored.APIKey,
+		stored.APISecret,
+		stored.AccessToken,
+		stored.AccessTokenSecret,
+		stored.BearerToken,
 		update.TweetGenerationPrompt,
 		update.MinMagnitudeForTweet,
 		update.MinConfidenceForTweet,
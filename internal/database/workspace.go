@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// workspaceFilter returns the workspace ctx is scoped to as a query argument, for use as
+// ($N::text IS NULL OR workspace_id = $N). Only contexts from models.ContextWithAllWorkspaces give
+// nil and match every workspace; other unscoped contexts fail closed to the default workspace.
+func workspaceFilter(ctx context.Context) interface{} {
+	if workspaceID, ok := models.WorkspaceFromContext(ctx); ok {
+		return workspaceID
+	}
+	if models.SpansAllWorkspaces(ctx) {
+		return nil
+	}
+	return models.DefaultWorkspace
+}
+
+// queryWorkspace returns the workspace a query is limited to: its own, else the one ctx is
+// scoped to. Empty means every workspace, and is only returned for contexts from
+// models.ContextWithAllWorkspaces.
+func queryWorkspace(ctx context.Context, workspaceID string) string {
+	if workspaceID != "" {
+		return workspaceID
+	}
+	if filter, ok := workspaceFilter(ctx).(string); ok {
+		return filter
+	}
+	return ""
+}
+
+// WorkspaceFilter is workspaceFilter for callers outside the package that run their own SQL
+func WorkspaceFilter(ctx context.Context) interface{} {
+	return workspaceFilter(ctx)
+}
+
+// writeWorkspace returns the workspace a new row belongs to: its own, else the one ctx is
+// scoped to, else the default workspace
+func writeWorkspace(ctx context.Context, workspaceID string) string {
+	if workspaceID != "" {
+		return workspaceID
+	}
+	if scoped, ok := models.WorkspaceFromContext(ctx); ok {
+		return scoped
+	}
+	return models.DefaultWorkspace
+}
+
+// forecastInWorkspace returns a condition limiting rows keyed by a forecast ID column to
+// forecasts in the workspace bound to $n by workspaceFilter
+func forecastInWorkspace(column string, n int) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM forecasts wf WHERE wf.id = %s AND ($%[2]d::text IS NULL OR wf.workspace_id = $%[2]d))", column, n)
+}
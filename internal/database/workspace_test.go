@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestWorkspaceFilter_FailsClosed(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantFilter interface{}
+		wantQuery  string
+	}{
+		{"unscoped", context.Background(), models.DefaultWorkspace, models.DefaultWorkspace},
+		{"scoped", models.ContextWithWorkspace(context.Background(), "acme"), "acme", "acme"},
+		{"all workspaces", models.ContextWithAllWorkspaces(context.Background()), nil, ""},
+		{"rescoped", models.ContextWithWorkspace(models.ContextWithAllWorkspaces(context.Background()), "acme"), "acme", "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workspaceFilter(tt.ctx); got != tt.wantFilter {
+				t.Errorf("workspaceFilter() = %v, want %v", got, tt.wantFilter)
+			}
+			if got := queryWorkspace(tt.ctx, ""); got != tt.wantQuery {
+				t.Errorf("queryWorkspace() = %q, want %q", got, tt.wantQuery)
+			}
+			if got := queryWorkspace(tt.ctx, "globex"); got != "globex" {
+				t.Errorf("queryWorkspace() with a workspace = %q, want globex", got)
+			}
+		})
+	}
+}
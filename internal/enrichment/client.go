@@ -2,12 +2,15 @@ package enrichment
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/database"
@@ -40,15 +43,29 @@ type OpenAIClient struct {
 	configRepo      *database.OpenAIConfigRepository
 	logger          *slog.Logger
 	inferenceLogger *inference.Logger
+	callSlots       chan struct{} // Bounds in-flight API calls; nil means unlimited
+
+	minEntityConfidence float64                // Extracted entities below this confidence are dropped; 0 keeps all
+	embeddingModel      string                 // Model used by Embed; empty uses DefaultEmbeddingModel
+	categoryMapping     models.CategoryMapping // Folds raw model categories into the deployment's taxonomy
+
+	// Prompt A/B test: promptBFraction of sources are analyzed with promptsB. Off when 0.
+	promptsB        *PromptTemplates
+	promptBFraction float64
+
+	// clientMu guards client, which ReloadCredentials swaps while calls are in flight
+	clientMu sync.RWMutex
 }
 
 // OpenAIConfig holds configuration for OpenAI API usage.
 type OpenAIConfig struct {
-	APIKey      string
-	Model       string
-	Temperature float32
-	MaxTokens   int
-	Timeout     int // seconds
+	APIKey         string
+	Model          string
+	FallbackModels []string // Tried in order when Model fails hard or is over quota
+	Temperature    float32
+	MaxTokens      int
+	Timeout        int    // seconds
+	Provider       string // models.ProviderOpenAI or models.ProviderOpenAICompatible
 }
 
 // DefaultOpenAIConfig returns sensible defaults for OSINT processing.
@@ -89,7 +106,7 @@ func NewOpenAIClient(apiKey string, config OpenAIConfig) *OpenAIClient {
 
 	prompts := NewPromptTemplates()
 
-	return &OpenAIClient{
+	c := &OpenAIClient{
 		client:     client,
 		config:     config,
 		prompts:    prompts,
@@ -100,6 +117,8 @@ func NewOpenAIClient(apiKey string, config OpenAIConfig) *OpenAIClient {
 		configRepo: nil,
 		logger:     slog.Default(),
 	}
+	c.correlator.complete = c.createChatCompletion
+	return c
 }
 
 // NewOpenAIClientFromDB creates a new OpenAI-powered enricher using database configuration.
@@ -119,37 +138,37 @@ func NewOpenAIClientFromDB(ctx context.Context, configRepo *database.OpenAIConfi
 		return nil, fmt.Errorf("openai enrichment is disabled in configuration")
 	}
 
-	// Validate API key
-	if dbConfig.APIKey == "" {
+	// Validate API key; local OpenAI-compatible servers don't need one
+	if dbConfig.APIKey == "" && dbConfig.Provider != models.ProviderOpenAICompatible {
 		return nil, fmt.Errorf("openai api key not configured - please set in admin panel")
 	}
 
-	// Create OpenAI client
-	client := openai.NewClient(dbConfig.APIKey)
+	// Create OpenAI client (public API, custom base URL or Azure deployment)
+	client := inference.NewOpenAIClient(dbConfig.APIKey, dbConfig.Endpoint())
 
 	// Convert database config to internal config
 	config := OpenAIConfig{
-		APIKey:      dbConfig.APIKey,
-		Model:       dbConfig.Model,
-		Temperature: dbConfig.Temperature,
-		MaxTokens:   dbConfig.MaxTokens,
-		Timeout:     dbConfig.TimeoutSeconds,
+		APIKey:         dbConfig.APIKey,
+		Model:          dbConfig.Model,
+		FallbackModels: dbConfig.FallbackModels,
+		Temperature:    dbConfig.Temperature,
+		MaxTokens:      dbConfig.MaxTokens,
+		Timeout:        dbConfig.TimeoutSeconds,
+		Provider:       dbConfig.Provider,
 	}
 
 	// Create prompts from database configuration
-	prompts := &PromptTemplates{
-		SystemPrompt:            dbConfig.SystemPrompt,
-		AnalysisTemplate:        dbConfig.AnalysisTemplate,
-		EntityExtractionPrompt:  dbConfig.EntityExtractionPrompt,
-		CorrelationSystemPrompt: dbConfig.CorrelationSystemPrompt,
-	}
+	prompts := PromptTemplatesFromConfig(dbConfig)
 
 	logger.Info("initialized openai enricher from database config",
 		"model", config.Model,
+		"fallback_models", config.FallbackModels,
 		"temperature", config.Temperature,
+		"category_mappings", len(dbConfig.CategoryMapping),
+		"prompt_b_fraction", dbConfig.PromptBFraction,
 		"enabled", dbConfig.Enabled)
 
-	return &OpenAIClient{
+	c := &OpenAIClient{
 		client:          client,
 		config:          config,
 		prompts:         prompts,
@@ -160,7 +179,65 @@ func NewOpenAIClientFromDB(ctx context.Context, configRepo *database.OpenAIConfi
 		configRepo:      configRepo,
 		logger:          logger,
 		inferenceLogger: inferenceLogger,
-	}, nil
+		categoryMapping: dbConfig.CategoryMapping,
+		promptsB:        PromptBTemplatesFromConfig(dbConfig),
+		promptBFraction: dbConfig.PromptBFraction,
+	}
+	c.correlator.complete = c.createChatCompletion
+	return c, nil
+}
+
+// SetMaxConcurrentCalls limits how many OpenAI calls this client makes at once, across all
+// goroutines using it. Call before the client is shared; n <= 0 removes the limit.
+func (c *OpenAIClient) SetMaxConcurrentCalls(n int) {
+	if n <= 0 {
+		c.callSlots = nil
+		return
+	}
+	c.callSlots = make(chan struct{}, n)
+}
+
+// SetMinEntityConfidence drops extracted entities whose confidence is below min before they
+// are scored and stored. Dropped entities are still logged. min <= 0 keeps every entity.
+func (c *OpenAIClient) SetMinEntityConfidence(min float64) {
+	c.minEntityConfidence = min
+}
+
+// SetEntityAliases adds aliases, mapped to canonical names, used to normalize and merge
+// extracted entities on top of the built-in country and city aliases.
+func (c *OpenAIClient) SetEntityAliases(aliases map[string]string) {
+	c.extractor.normalizer.AddAliases(aliases)
+}
+
+// acquireCallSlot waits for a free call slot and returns the function that frees it.
+func (c *OpenAIClient) acquireCallSlot(ctx context.Context) (func(), error) {
+	if c.callSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.callSlots <- struct{}{}:
+		return func() { <-c.callSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for OpenAI call slot: %w", ctx.Err())
+	}
+}
+
+// createChatCompletion calls the API once a call slot is free.
+func (c *OpenAIClient) createChatCompletion(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	release, err := c.acquireCallSlot(ctx)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	defer release()
+
+	return c.apiClient().CreateChatCompletion(ctx, request)
+}
+
+// apiClient returns the API client with the current credentials.
+func (c *OpenAIClient) apiClient() *openai.Client {
+	c.clientMu.RLock()
+	defer c.clientMu.RUnlock()
+	return c.client
 }
 
 // GetCorrelator returns the event correlator for this client.
@@ -186,11 +263,20 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 		return nil, fmt.Errorf("insufficient content for enrichment: only %d chars (minimum 50 required)", len(source.RawContent))
 	}
 
-	// Generate prompt for analysis
+	// Generate prompt for analysis, with prompt B if the source falls in an A/B test's B share
 	promptStart := time.Now()
-	prompt := c.prompts.BuildAnalysisPrompt(source)
+	prompts := c.prompts
+	variant := ""
+	if c.promptsB != nil {
+		variant = models.PromptVariantFor(source.ID, c.promptBFraction)
+		if variant == models.PromptVariantB {
+			prompts = c.promptsB
+		}
+	}
+	prompt := prompts.BuildAnalysisPrompt(source)
 	c.logger.Debug("[PROMPT BUILT]",
 		"source_id", source.ID,
+		"prompt_variant", variant,
 		"duration_ms", time.Since(promptStart).Milliseconds())
 
 	// Create a timeout context for the API call
@@ -201,6 +287,133 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 		timeout = c.config.Timeout
 	}
 
+	// Stop before spending tokens once the inference budget is used up
+	if err := c.inferenceLogger.CheckBudget(ctx); err != nil {
+		return nil, err
+	}
+
+	// Try the primary model, then each fallback in turn when a model fails hard or is over quota
+	var analysis, model string
+	var err error
+	chain := c.enrichmentModels()
+	for i, candidate := range chain {
+		analysis, err = c.completeAnalysis(ctx, source, prompts.SystemPrompt, prompt, candidate, timeout)
+		if err == nil {
+			model = candidate
+			break
+		}
+		if i == len(chain)-1 || ctx.Err() != nil || !shouldFallBack(err) {
+			return nil, err
+		}
+		c.logger.Warn("enrichment model failed, falling back",
+			"source_id", source.ID,
+			"model", candidate,
+			"fallback_model", chain[i+1],
+			"error", err)
+	}
+
+	// Parse analysis into structured event
+	parseStart := time.Now()
+	event, err := c.parseAnalysis(source, analysis)
+	c.logger.Debug("[PARSE ANALYSIS]",
+		"source_id", source.ID,
+		"duration_ms", time.Since(parseStart).Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse analysis: %w", err)
+	}
+
+	// Extract entities using the configured entity extraction prompt
+	entityStart := time.Now()
+	c.logger.Info("[ENTITY EXTRACTION START]", "source_id", source.ID)
+	entityPrompt := c.prompts.BuildEntityExtractionPrompt(source.RawContent)
+	entityConfig := c.config
+	entityConfig.Model = model
+	entities, err := c.extractor.Extract(ctx, source.RawContent, c.createChatCompletion, entityConfig, entityPrompt)
+	c.logger.Info("[ENTITY EXTRACTION COMPLETE]",
+		"source_id", source.ID,
+		"duration_ms", time.Since(entityStart).Milliseconds(),
+		"entity_count", len(entities))
+	if err != nil {
+		// Non-fatal: log warning and continue with empty entities
+		if c.logger != nil {
+			c.logger.Warn("entity extraction failed, continuing without entities", "error", err, "source_id", source.ID)
+		}
+		entities = []models.Entity{}
+	}
+
+	// Drop entities the model was guessing at so they don't add noise to scoring and entity filters
+	entities, dropped := filterEntitiesByConfidence(entities, c.minEntityConfidence)
+	if len(dropped) > 0 {
+		c.logger.Info("[ENTITY CONFIDENCE FILTER]",
+			"source_id", source.ID,
+			"min_confidence", c.minEntityConfidence,
+			"kept_count", len(entities),
+			"dropped_count", len(dropped),
+			"dropped_entities", describeEntities(dropped))
+	}
+	event.Entities = entities
+
+	// If location wasn't populated by AI, try to extract from entities
+	if event.Location == nil {
+		event.Location = extractLocationFromEntities(entities)
+	}
+
+	// Calculate confidence score
+	scoreStart := time.Now()
+	confidence := c.scorer.Score(source, event, entities)
+	event.Confidence = confidence
+	c.logger.Debug("[CONFIDENCE SCORE]",
+		"source_id", source.ID,
+		"duration_ms", time.Since(scoreStart).Milliseconds())
+
+	// Magnitude is now determined by OpenAI in the analysis phase
+	c.logger.Debug("[MAGNITUDE]",
+		"source_id", source.ID,
+		"magnitude", event.Magnitude,
+		"source", "openai")
+
+	// Set metadata, recording which model did the work for auditing
+	source.EnrichmentModel = model
+	event.Sources = []models.Source{source}
+	event.Status = models.EventStatusEnriched
+	event.EnrichmentModel = model
+	event.PromptVariant = variant
+
+	totalDuration := time.Since(enrichStart)
+	c.logger.Info("[ENRICH COMPLETE]",
+		"source_id", source.ID,
+		"model", model,
+		"total_duration_ms", totalDuration.Milliseconds())
+
+	return event, nil
+}
+
+// enrichmentModels returns the primary model followed by the configured fallbacks.
+func (c *OpenAIClient) enrichmentModels() []string {
+	return append([]string{c.config.Model}, c.config.FallbackModels...)
+}
+
+// shouldFallBack reports whether an enrichment error is worth retrying on the next model: quota
+// and rate limits, unknown models, server errors, timeouts and empty responses. Other client
+// errors such as a bad API key would fail the same way on every model.
+func shouldFallBack(err error) bool {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return fallbackStatus(apiErr.HTTPStatusCode)
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return fallbackStatus(reqErr.HTTPStatusCode)
+	}
+	return true
+}
+
+func fallbackStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusNotFound || code >= http.StatusInternalServerError
+}
+
+// completeAnalysis asks model to analyze the source, retrying rate-limited calls with backoff.
+func (c *OpenAIClient) completeAnalysis(ctx context.Context, source models.Source, systemPrompt, prompt, model string, timeout int) (string, error) {
 	// Retry logic for rate limiting
 	maxRetries := 3
 	baseDelay := 1 * time.Second
@@ -218,19 +431,19 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 		apiCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
 
 		// Detect o1/o4/gpt-5 reasoning models which have different API requirements (no JSON mode, merged system prompt)
-		isO1Model := strings.Contains(strings.ToLower(c.config.Model), "o1") ||
-			strings.Contains(strings.ToLower(c.config.Model), "o4") ||
-			strings.Contains(strings.ToLower(c.config.Model), "gpt-5")
+		isO1Model := strings.Contains(strings.ToLower(model), "o1") ||
+			strings.Contains(strings.ToLower(model), "o4") ||
+			strings.Contains(strings.ToLower(model), "gpt-5")
 
 		var request openai.ChatCompletionRequest
 
 		if isO1Model {
 			// o1 models don't support: response_format, system messages (must merge into user)
 			// Combine system prompt and user prompt into a single user message
-			combinedPrompt := c.prompts.SystemPrompt + "\n\n" + prompt
+			combinedPrompt := systemPrompt + "\n\n" + prompt
 
 			request = openai.ChatCompletionRequest{
-				Model:               c.config.Model,
+				Model:               model,
 				MaxCompletionTokens: c.config.MaxTokens,
 				Messages: []openai.ChatCompletionMessage{
 					{
@@ -240,11 +453,11 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 				},
 			}
 
-			c.logger.Debug("[O1 MODEL DETECTED]", "model", c.config.Model, "no_json_mode", true)
+			c.logger.Debug("[O1 MODEL DETECTED]", "model", model, "no_json_mode", true)
 		} else {
 			// Standard models (gpt-4, gpt-4o, gpt-4o-mini) support JSON mode and system messages
 			request = openai.ChatCompletionRequest{
-				Model:               c.config.Model,
+				Model:               model,
 				MaxCompletionTokens: c.config.MaxTokens,
 				ResponseFormat: &openai.ChatCompletionResponseFormat{
 					Type: openai.ChatCompletionResponseFormatTypeJSONObject,
@@ -252,7 +465,7 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 				Messages: []openai.ChatCompletionMessage{
 					{
 						Role:    openai.ChatMessageRoleSystem,
-						Content: c.prompts.SystemPrompt,
+						Content: systemPrompt,
 					},
 					{
 						Role:    openai.ChatMessageRoleUser,
@@ -260,9 +473,14 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 					},
 				},
 			}
+
+			// Not every OpenAI-compatible server supports JSON mode; the parser tolerates extra text
+			if c.config.Provider == models.ProviderOpenAICompatible {
+				request.ResponseFormat = nil
+			}
 		}
 
-		resp, err = c.client.CreateChatCompletion(apiCtx, request)
+		resp, err = c.createChatCompletion(apiCtx, request)
 
 		cancel()
 
@@ -298,7 +516,7 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 				}
 			}
 
-			c.inferenceLogger.LogOpenAICall(ctx, c.config.Model, "event_creation", usage, apiCallDuration, err, metadata)
+			c.inferenceLogger.LogOpenAIProviderCall(ctx, c.config.Provider, model, "event_creation", usage, apiCallDuration, err, metadata)
 		}
 
 		// If successful, break out of retry loop
@@ -351,15 +569,15 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("openai api call failed for source %s: %w", source.ID, err)
+		return "", fmt.Errorf("openai api call failed for source %s: %w", source.ID, err)
 	}
 
 	if len(resp.Choices) == 0 {
 		c.logger.Error("[OPENAI NO CHOICES]",
 			"source_id", source.ID,
-			"model", c.config.Model,
+			"model", model,
 			"response_id", resp.ID)
-		return nil, fmt.Errorf("no completion choices returned from model %s", c.config.Model)
+		return "", fmt.Errorf("no completion choices returned from model %s", model)
 	}
 
 	analysis := resp.Choices[0].Message.Content
@@ -368,69 +586,13 @@ func (c *OpenAIClient) Enrich(ctx context.Context, source models.Source) (*model
 	if analysis == "" {
 		c.logger.Error("[OPENAI EMPTY RESPONSE]",
 			"source_id", source.ID,
-			"model", c.config.Model,
+			"model", model,
 			"finish_reason", resp.Choices[0].FinishReason,
 			"response_id", resp.ID)
-		return nil, fmt.Errorf("empty response from model %s (finish_reason: %s)", c.config.Model, resp.Choices[0].FinishReason)
-	}
-
-	// Parse analysis into structured event
-	parseStart := time.Now()
-	event, err := c.parseAnalysis(source, analysis)
-	c.logger.Debug("[PARSE ANALYSIS]",
-		"source_id", source.ID,
-		"duration_ms", time.Since(parseStart).Milliseconds())
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse analysis: %w", err)
-	}
-
-	// Extract entities using the configured entity extraction prompt
-	entityStart := time.Now()
-	c.logger.Info("[ENTITY EXTRACTION START]", "source_id", source.ID)
-	entityPrompt := c.prompts.BuildEntityExtractionPrompt(source.RawContent)
-	entities, err := c.extractor.Extract(ctx, source.RawContent, c.client, c.config, entityPrompt)
-	c.logger.Info("[ENTITY EXTRACTION COMPLETE]",
-		"source_id", source.ID,
-		"duration_ms", time.Since(entityStart).Milliseconds(),
-		"entity_count", len(entities))
-	if err != nil {
-		// Non-fatal: log warning and continue with empty entities
-		if c.logger != nil {
-			c.logger.Warn("entity extraction failed, continuing without entities", "error", err, "source_id", source.ID)
-		}
-		entities = []models.Entity{}
-	}
-	event.Entities = entities
-
-	// If location wasn't populated by AI, try to extract from entities
-	if event.Location == nil {
-		event.Location = extractLocationFromEntities(entities)
+		return "", fmt.Errorf("empty response from model %s (finish_reason: %s)", model, resp.Choices[0].FinishReason)
 	}
 
-	// Calculate confidence score
-	scoreStart := time.Now()
-	confidence := c.scorer.Score(source, event, entities)
-	event.Confidence = confidence
-	c.logger.Debug("[CONFIDENCE SCORE]",
-		"source_id", source.ID,
-		"duration_ms", time.Since(scoreStart).Milliseconds())
-
-	// Magnitude is now determined by OpenAI in the analysis phase
-	c.logger.Debug("[MAGNITUDE]",
-		"source_id", source.ID,
-		"magnitude", event.Magnitude,
-		"source", "openai")
-
-	// Set metadata
-	event.Sources = []models.Source{source}
-	event.Status = models.EventStatusEnriched
-
-	totalDuration := time.Since(enrichStart)
-	c.logger.Info("[ENRICH COMPLETE]",
-		"source_id", source.ID,
-		"total_duration_ms", totalDuration.Milliseconds())
-
-	return event, nil
+	return analysis, nil
 }
 
 // ExtractArticleText uses OpenAI to extract article content from raw HTML
@@ -453,13 +615,17 @@ URL: %s
 
 Return the extracted article text in plain text format. If the page is blocked, paywalled, or contains no article content, return "ERROR: No article content found".`, url)
 
+	if err := c.inferenceLogger.CheckBudget(ctx); err != nil {
+		return "", err
+	}
+
 	// Create timeout context
 	apiCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Call OpenAI API
 	startTime := time.Now()
-	resp, err := c.client.CreateChatCompletion(apiCtx, openai.ChatCompletionRequest{
+	resp, err := c.createChatCompletion(apiCtx, openai.ChatCompletionRequest{
 		Model:               c.config.Model,
 		MaxCompletionTokens: 4000, // Allow longer responses for article content
 		Messages: []openai.ChatCompletionMessage{
@@ -487,7 +653,7 @@ Return the extracted article text in plain text format. If the page is blocked,
 			usage.CompletionTokens = resp.Usage.CompletionTokens
 			usage.TotalTokens = resp.Usage.TotalTokens
 		}
-		c.inferenceLogger.LogOpenAICall(ctx, c.config.Model, "article_extraction", usage, latency, err, map[string]interface{}{
+		c.inferenceLogger.LogOpenAIProviderCall(ctx, c.config.Provider, c.config.Model, "article_extraction", usage, latency, err, map[string]interface{}{
 			"url": url,
 		})
 	}
@@ -639,12 +805,14 @@ func (c *OpenAIClient) parseAnalysis(source models.Source, analysis string) (*mo
 		Title:      parsed.Title,
 		Summary:    "", // No longer generating summaries from RSS descriptions
 		RawContent: source.RawContent,
-		Category:   parsed.Category,
+		Category:   c.categoryMapping.Normalize(parsed.RawCategory),
 		Magnitude:  parsed.Magnitude,
 		Tags:       parsed.Tags,
 		Location:   parsed.Location,
 		CreatedAt:  now,
 		UpdatedAt:  now,
+
+		WorkspaceID: source.WorkspaceID,
 	}
 
 	return event, nil
@@ -652,14 +820,15 @@ func (c *OpenAIClient) parseAnalysis(source models.Source, analysis string) (*mo
 
 // generateEventID creates a deterministic event identifier based on source.
 // This ensures that enriching the same source multiple times produces the same event ID,
-// preventing duplicate events from race conditions in the enrichment pipeline.
+// preventing duplicate events from race conditions in the enrichment pipeline. The same content
+// gets a different ID in each workspace.
 func generateEventID(source models.Source) string {
 	// Use source content hash if available (most reliable)
 	if source.ContentHash != "" {
-		return fmt.Sprintf("evt-%s", source.ContentHash)
+		return models.ScopedID(source.WorkspaceID, fmt.Sprintf("evt-%s", source.ContentHash))
 	}
 	// Fallback to source ID (also deterministic)
-	return fmt.Sprintf("evt-%s", source.ID)
+	return models.ScopedID(source.WorkspaceID, fmt.Sprintf("evt-%s", source.ID))
 }
 
 func generateTimestamp() int64 {
@@ -711,36 +880,21 @@ func extractLocationFromEntities(entities []models.Entity) *models.Location {
 // AssessSourceCredibility uses LLM to evaluate the credibility of a source based on its domain/URL.
 // Returns a score between 0.0 (not credible) and 1.0 (highly credible).
 func (c *OpenAIClient) AssessSourceCredibility(ctx context.Context, url string, sourceType models.SourceType) (float64, error) {
-	prompt := fmt.Sprintf(`Assess the credibility of this source for OSINT analysis.
+	prompt := c.prompts.BuildCredibilityPrompt(url, sourceType)
 
-URL: %s
-Source Type: %s
-
-Consider:
-- Domain reputation and authority
-- Known track record for accuracy
-- Editorial standards
-- Bias/reliability ratings
-- Historical trustworthiness
-
-Respond with ONLY a decimal number between 0.0 (not credible) and 1.0 (highly credible).
-Examples:
-- Reuters, AP News: 0.95
-- CNN, BBC: 0.85
-- Local news sites: 0.70
-- Personal blogs: 0.40
-- Twitter/social media: 0.60
-- Unknown/suspicious sites: 0.20
-
-Score:`, url, sourceType)
+	// Fall back to the default score rather than spending tokens over budget
+	if err := c.inferenceLogger.CheckBudget(ctx); err != nil {
+		c.logger.Warn("skipping source credibility assessment", "url", url, "error", err)
+		return c.getDefaultCredibility(sourceType), nil
+	}
 
 	startTime := time.Now()
-	resp, err := c.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	resp, err := c.createChatCompletion(ctx, openai.ChatCompletionRequest{
 		Model: c.config.Model,
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: "You are an OSINT analyst expert at assessing source credibility. Respond only with a decimal number.",
+				Content: c.prompts.CredibilitySystemPrompt,
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -763,7 +917,7 @@ Score:`, url, sourceType)
 			usage.CompletionTokens = resp.Usage.CompletionTokens
 			usage.TotalTokens = resp.Usage.TotalTokens
 		}
-		c.inferenceLogger.LogOpenAICall(ctx, c.config.Model, "source_credibility", usage, latency, err, map[string]interface{}{
+		c.inferenceLogger.LogOpenAIProviderCall(ctx, c.config.Provider, c.config.Model, "source_credibility", usage, latency, err, map[string]interface{}{
 			"url":         url,
 			"source_type": string(sourceType),
 		})
@@ -832,6 +986,10 @@ func (c *OpenAIClient) getDefaultCredibility(sourceType models.SourceType) float
 // GenerateText generates text using OpenAI with a simple system/user prompt
 // This is useful for generating tweets, summaries, or other text based on templates
 func (c *OpenAIClient) GenerateText(ctx context.Context, systemPrompt, userPrompt string, temperature float32, maxTokens int) (string, error) {
+	if err := c.inferenceLogger.CheckBudget(ctx); err != nil {
+		return "", err
+	}
+
 	// Create timeout context
 	timeout := 180 // Default to 180 seconds for o1 models
 	if c.config.Timeout > 0 {
@@ -893,7 +1051,7 @@ func (c *OpenAIClient) GenerateText(ctx context.Context, systemPrompt, userPromp
 
 	// Call OpenAI API
 	startTime := time.Now()
-	resp, err := c.client.CreateChatCompletion(apiCtx, request)
+	resp, err := c.createChatCompletion(apiCtx, request)
 	latency := time.Since(startTime)
 
 	// Log inference call
@@ -908,7 +1066,7 @@ func (c *OpenAIClient) GenerateText(ctx context.Context, systemPrompt, userPromp
 			usage.CompletionTokens = resp.Usage.CompletionTokens
 			usage.TotalTokens = resp.Usage.TotalTokens
 		}
-		c.inferenceLogger.LogOpenAICall(ctx, c.config.Model, "text_generation", usage, latency, err, map[string]interface{}{
+		c.inferenceLogger.LogOpenAIProviderCall(ctx, c.config.Provider, c.config.Model, "text_generation", usage, latency, err, map[string]interface{}{
 			"temperature": temperature,
 			"max_tokens":  maxTokens,
 		})
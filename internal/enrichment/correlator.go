@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
@@ -17,16 +18,39 @@ type EventCorrelator struct {
 	config  OpenAIConfig
 	prompts *PromptTemplates
 	logger  *slog.Logger
+
+	// complete sends chat requests; the enricher owning this correlator routes them through its call slots
+	complete chatCompleter
+
+	clientMu sync.RWMutex // Guards client, which is swapped when credentials are reloaded
 }
 
 // NewEventCorrelator creates a new event correlator.
 func NewEventCorrelator(client *openai.Client, config OpenAIConfig, prompts *PromptTemplates, logger *slog.Logger) *EventCorrelator {
-	return &EventCorrelator{
+	c := &EventCorrelator{
 		client:  client,
 		config:  config,
 		prompts: prompts,
 		logger:  logger,
 	}
+	c.complete = func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+		return c.apiClient().CreateChatCompletion(ctx, request)
+	}
+	return c
+}
+
+// apiClient returns the API client with the current credentials.
+func (c *EventCorrelator) apiClient() *openai.Client {
+	c.clientMu.RLock()
+	defer c.clientMu.RUnlock()
+	return c.client
+}
+
+// setClient replaces the API client used for new correlation calls.
+func (c *EventCorrelator) setClient(client *openai.Client) {
+	c.clientMu.Lock()
+	c.client = client
+	c.clientMu.Unlock()
 }
 
 // CorrelationResult describes how a new source relates to an existing event.
@@ -57,7 +81,7 @@ func (c *EventCorrelator) AnalyzeCorrelation(ctx context.Context, newSource mode
 	apiCtx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout)*time.Second)
 	defer cancel()
 
-	resp, err := c.client.CreateChatCompletion(apiCtx, openai.ChatCompletionRequest{
+	request := openai.ChatCompletionRequest{
 		Model:               c.config.Model,
 		MaxCompletionTokens: 1000, // Shorter response for correlation
 		ResponseFormat: &openai.ChatCompletionResponseFormat{
@@ -73,7 +97,13 @@ func (c *EventCorrelator) AnalyzeCorrelation(ctx context.Context, newSource mode
 				Content: prompt,
 			},
 		},
-	})
+	}
+	// Not every OpenAI-compatible server supports JSON mode
+	if c.config.Provider == models.ProviderOpenAICompatible {
+		request.ResponseFormat = nil
+	}
+
+	resp, err := c.complete(apiCtx, request)
 
 	if err != nil {
 		return nil, fmt.Errorf("openai correlation analysis failed: %w", err)
@@ -85,7 +115,7 @@ func (c *EventCorrelator) AnalyzeCorrelation(ctx context.Context, newSource mode
 
 	// Parse JSON response
 	var result CorrelationResult
-	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &result); err != nil {
+	if err := json.Unmarshal([]byte(extractJSONObject(resp.Choices[0].Message.Content)), &result); err != nil {
 		return nil, fmt.Errorf("failed to parse correlation result: %w", err)
 	}
 
@@ -148,86 +178,7 @@ func (c *EventCorrelator) FindBestMatch(ctx context.Context, newSource models.So
 
 // buildCorrelationPrompt creates the prompt for correlation analysis.
 func (c *EventCorrelator) buildCorrelationPrompt(newSource models.Source, existingEvent models.Event) string {
-	// Format existing event facts
-	existingFacts := "- " + formatKeyFacts(existingEvent)
-
-	prompt := fmt.Sprintf(`=== CORRELATION ANALYSIS REQUEST ===
-
-You are analyzing whether a new intelligence source should be merged with an existing event.
-
-EXISTING EVENT:
-Title: %s
-Summary: %s
-Category: %s
-Key Facts:
-%s
-
-NEW SOURCE:
-Title: %s
-URL: %s
-Published: %s
-Content Preview:
-%s
-
-=== ANALYSIS TASK ===
-
-Compare the new source against the existing event and determine:
-
-1. SIMILARITY (0.0-1.0): How closely related are they?
-   - 1.0 = Same event, same facts (duplicate)
-   - 0.8-0.9 = Same event, minor variations or updates
-   - 0.6-0.7 = Related event, significant overlap
-   - 0.4-0.5 = Tangentially related (e.g., reactions, responses, consequences)
-   - 0.2-0.3 = Same topic but different events
-   - 0.0-0.1 = Unrelated
-
-2. SHOULD_MERGE: Should this source be added to the existing event?
-   - true if similarity >= 0.6 AND sources discuss the SAME core event/incident
-   - false if discussing different events, even if related topic or cause/effect
-
-   DO NOT MERGE if the new source is:
-   - A REACTION or RESPONSE to the event (condemnations, statements ABOUT the event)
-   - A CONSEQUENCE or follow-on event (investigations, arrests, policy changes)
-   - A DIFFERENT INCIDENT in the same location or same topic area
-   - General commentary or analysis rather than factual reporting
-
-   DO MERGE if the new source is:
-   - Additional details about the SAME incident
-   - Updated information (casualty counts, damage assessments)
-   - Different perspective on the SAME event
-   - Conflicting claims about the SAME incident (e.g., disputes about attribution, casualty numbers, causes)
-   - Denials or counter-claims that directly contradict facts about the incident itself
-
-   KEY DISTINCTION: Statements ABOUT an event (reactions) ≠ Statements about the FACTS of an event (conflicting claims)
-
-3. NOVEL FACTS: Does the new source contain facts NOT in the existing event?
-   - Identify specific new information, claims, or developments
-   - Ignore stylistic variations or rephrasing of same facts
-   - Focus on substantive new information
-   - If the new source is a reaction/response, those reactions ARE novel facts
-
-Output ONLY valid JSON in this format:
-{
-  "similarity": 0.85,
-  "should_merge": true,
-  "has_novel_facts": true,
-  "novel_facts": [
-    "Specific new fact 1 not in existing event",
-    "Specific new fact 2 not in existing event"
-  ],
-  "reasoning": "Brief explanation of your decision"
-}`,
-		existingEvent.Title,
-		existingEvent.Summary,
-		existingEvent.Category,
-		existingFacts,
-		newSource.Title,
-		newSource.URL,
-		newSource.PublishedAt.Format(time.RFC3339),
-		truncateText(newSource.RawContent, 2000), // Limit content for token efficiency
-	)
-
-	return prompt
+	return c.prompts.BuildCorrelationPrompt(newSource, existingEvent)
 }
 
 // formatKeyFacts formats event key facts for display.
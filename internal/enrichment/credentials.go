@@ -0,0 +1,87 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/inference"
+	"github.com/STRATINT/stratint/internal/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// credentialCheckTimeout bounds the call that validates new credentials before they are used
+const credentialCheckTimeout = 15 * time.Second
+
+var (
+	// ErrCredentialsRejected is returned when the provider refuses the new credentials; the
+	// enricher keeps using the previous ones
+	ErrCredentialsRejected = errors.New("new credentials rejected by the provider")
+
+	// ErrProviderChanged is returned when the stored configuration switched provider, which
+	// changes how requests are built and still needs a restart
+	ErrProviderChanged = errors.New("provider changed; restart to switch providers")
+)
+
+// ReloadCredentials reads the API key and endpoint from the database configuration and, once
+// the provider accepts them, swaps them in for new calls. Calls already in flight finish with
+// the previous credentials. Other settings (model, prompts, timeouts) still apply on restart.
+func (c *OpenAIClient) ReloadCredentials(ctx context.Context) error {
+	if c.configRepo == nil {
+		return fmt.Errorf("enricher was not created from the database configuration")
+	}
+
+	dbConfig, err := c.configRepo.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load openai config from database: %w", err)
+	}
+	return c.applyCredentials(ctx, dbConfig)
+}
+
+// applyCredentials validates dbConfig's API key and endpoint and swaps them in
+func (c *OpenAIClient) applyCredentials(ctx context.Context, dbConfig *models.OpenAIConfig) error {
+	if dbConfig.Provider != c.config.Provider {
+		return fmt.Errorf("%w: %q to %q", ErrProviderChanged, c.config.Provider, dbConfig.Provider)
+	}
+	if dbConfig.APIKey == "" && dbConfig.Provider != models.ProviderOpenAICompatible {
+		return fmt.Errorf("openai api key not configured - please set in admin panel")
+	}
+
+	client := inference.NewOpenAIClient(dbConfig.APIKey, dbConfig.Endpoint())
+	if err := checkCredentials(ctx, client); err != nil {
+		c.logger.Warn("kept previous openai credentials; new ones failed validation", "error", err)
+		return err
+	}
+
+	c.clientMu.Lock()
+	c.client = client
+	c.clientMu.Unlock()
+	if c.correlator != nil {
+		c.correlator.setClient(client)
+	}
+
+	c.logger.Info("reloaded openai credentials",
+		"provider", dbConfig.Provider,
+		"base_url", dbConfig.BaseURL,
+		"azure_deployment", dbConfig.AzureDeployment)
+	return nil
+}
+
+// CheckCredentials confirms the provider accepts config's API key at its endpoint, so a key can
+// be validated before it is saved
+func CheckCredentials(ctx context.Context, config *models.OpenAIConfig) error {
+	return checkCredentials(ctx, inference.NewOpenAIClient(config.APIKey, config.Endpoint()))
+}
+
+// checkCredentials makes a cheap authenticated call, listing models, to confirm the provider
+// accepts client's credentials
+func checkCredentials(ctx context.Context, client *openai.Client) error {
+	checkCtx, cancel := context.WithTimeout(ctx, credentialCheckTimeout)
+	defer cancel()
+
+	if _, err := client.ListModels(checkCtx); err != nil {
+		return fmt.Errorf("%w: %w", ErrCredentialsRejected, err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package enrichment
 
 import (
 	"context"
+	"log/slog"
 	"net/url"
 	"strings"
 	"sync"
@@ -10,12 +11,23 @@ import (
 	"github.com/STRATINT/stratint/internal/models"
 )
 
+// CredibilityStore persists domain credibility scores so they survive restarts and are shared
+// across instances.
+type CredibilityStore interface {
+	GetCredibility(ctx context.Context, domain string, since time.Time) (*models.DomainCredibility, error)
+	SaveCredibility(ctx context.Context, c models.DomainCredibility) error
+	ListCredibility(ctx context.Context, since time.Time) ([]models.DomainCredibility, error)
+}
+
 // CredibilityCache caches domain credibility scores to avoid excessive LLM calls.
+// The in-memory map is an L1 cache in front of an optional CredibilityStore.
 type CredibilityCache struct {
 	cache    map[string]cacheEntry
 	mu       sync.RWMutex
 	enricher *OpenAIClient
 	ttl      time.Duration
+	store    CredibilityStore
+	logger   *slog.Logger
 }
 
 type cacheEntry struct {
@@ -32,6 +44,33 @@ func NewCredibilityCache(enricher *OpenAIClient, ttl time.Duration) *Credibility
 	}
 }
 
+// SetStore backs the cache with persistent storage. The TTL is enforced on each score's
+// computed_at, so a score expires at the same time on every instance.
+func (c *CredibilityCache) SetStore(store CredibilityStore, logger *slog.Logger) {
+	c.store = store
+	c.logger = logger
+}
+
+// Warm loads unexpired scores from the store into memory and returns how many were loaded.
+func (c *CredibilityCache) Warm(ctx context.Context) (int, error) {
+	if c.store == nil {
+		return 0, nil
+	}
+
+	scores, err := c.store.ListCredibility(ctx, time.Now().Add(-c.ttl))
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	for _, s := range scores {
+		c.cache[s.Domain] = cacheEntry{score: s.Score, timestamp: s.ComputedAt}
+	}
+	c.mu.Unlock()
+
+	return len(scores), nil
+}
+
 // GetCredibility returns cached credibility or fetches from LLM.
 func (c *CredibilityCache) GetCredibility(ctx context.Context, sourceURL string, sourceType models.SourceType) (float64, error) {
 	domain := extractDomain(sourceURL)
@@ -49,6 +88,17 @@ func (c *CredibilityCache) GetCredibility(ctx context.Context, sourceURL string,
 		return entry.score, nil
 	}
 
+	// Then the store, which another instance may have filled
+	if c.store != nil {
+		stored, err := c.store.GetCredibility(ctx, domain, time.Now().Add(-c.ttl))
+		if err != nil {
+			c.logger.Warn("failed to read stored credibility", "domain", domain, "error", err)
+		} else if stored != nil {
+			c.put(domain, stored.Score, stored.ComputedAt)
+			return stored.Score, nil
+		}
+	}
+
 	// Fetch from LLM
 	score, err := c.enricher.AssessSourceCredibility(ctx, sourceURL, sourceType)
 	if err != nil {
@@ -56,14 +106,25 @@ func (c *CredibilityCache) GetCredibility(ctx context.Context, sourceURL string,
 	}
 
 	// Cache the result
+	computedAt := time.Now()
+	c.put(domain, score, computedAt)
+	if c.store != nil {
+		if err := c.store.SaveCredibility(ctx, models.DomainCredibility{Domain: domain, Score: score, ComputedAt: computedAt}); err != nil {
+			c.logger.Warn("failed to store credibility", "domain", domain, "error", err)
+		}
+	}
+
+	return score, nil
+}
+
+// put caches a score in memory
+func (c *CredibilityCache) put(domain string, score float64, computedAt time.Time) {
 	c.mu.Lock()
 	c.cache[domain] = cacheEntry{
 		score:     score,
-		timestamp: time.Now(),
+		timestamp: computedAt,
 	}
 	c.mu.Unlock()
-
-	return score, nil
 }
 
 // extractDomain extracts the domain from a URL.
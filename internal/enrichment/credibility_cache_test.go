@@ -0,0 +1,85 @@
+package enrichment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// fakeCredibilityStore keeps scores in memory and counts reads
+type fakeCredibilityStore struct {
+	scores map[string]models.DomainCredibility
+	gets   int
+}
+
+func (s *fakeCredibilityStore) GetCredibility(ctx context.Context, domain string, since time.Time) (*models.DomainCredibility, error) {
+	s.gets++
+	c, ok := s.scores[domain]
+	if !ok || !c.ComputedAt.After(since) {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+func (s *fakeCredibilityStore) SaveCredibility(ctx context.Context, c models.DomainCredibility) error {
+	s.scores[c.Domain] = c
+	return nil
+}
+
+func (s *fakeCredibilityStore) ListCredibility(ctx context.Context, since time.Time) ([]models.DomainCredibility, error) {
+	var scores []models.DomainCredibility
+	for _, c := range s.scores {
+		if c.ComputedAt.After(since) {
+			scores = append(scores, c)
+		}
+	}
+	return scores, nil
+}
+
+func TestCredibilityCache_Store(t *testing.T) {
+	store := &fakeCredibilityStore{scores: map[string]models.DomainCredibility{
+		"reuters.com": {Domain: "reuters.com", Score: 0.92, ComputedAt: time.Now().Add(-time.Hour)},
+	}}
+	// No enricher: any LLM call would panic, so every score below must come from a cache layer
+	cache := NewCredibilityCache(nil, 24*time.Hour)
+	cache.SetStore(store, nil)
+	ctx := context.Background()
+
+	score, err := cache.GetCredibility(ctx, "https://reuters.com/world/article", models.SourceTypeNewsMedia)
+	if err != nil || score != 0.92 {
+		t.Fatalf("GetCredibility() = %v, %v; want stored 0.92", score, err)
+	}
+
+	// The second lookup is served from memory
+	if _, err := cache.GetCredibility(ctx, "https://reuters.com/other", models.SourceTypeNewsMedia); err != nil {
+		t.Fatalf("GetCredibility failed: %v", err)
+	}
+	if store.gets != 1 {
+		t.Errorf("expected 1 store read, got %d", store.gets)
+	}
+}
+
+func TestCredibilityCache_Warm(t *testing.T) {
+	store := &fakeCredibilityStore{scores: map[string]models.DomainCredibility{
+		"reuters.com": {Domain: "reuters.com", Score: 0.92, ComputedAt: time.Now().Add(-time.Hour)},
+		"stale.com":   {Domain: "stale.com", Score: 0.3, ComputedAt: time.Now().Add(-48 * time.Hour)},
+	}}
+	cache := NewCredibilityCache(nil, 24*time.Hour)
+	cache.SetStore(store, nil)
+	ctx := context.Background()
+
+	warmed, err := cache.Warm(ctx)
+	if err != nil || warmed != 1 {
+		t.Fatalf("Warm() = %d, %v; want 1 unexpired score", warmed, err)
+	}
+
+	score, err := cache.GetCredibility(ctx, "https://reuters.com/world/article", models.SourceTypeNewsMedia)
+	if err != nil || score != 0.92 {
+		t.Fatalf("GetCredibility() = %v, %v; want warmed 0.92", score, err)
+	}
+	if store.gets != 0 {
+		t.Errorf("expected warmed score to skip the store, got %d reads", store.gets)
+	}
+}
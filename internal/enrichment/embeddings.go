@@ -0,0 +1,118 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// EmbeddingDimensions is the length of event embeddings, fixed by the events.embedding column.
+const EmbeddingDimensions = 1536
+
+// DefaultEmbeddingModel is used unless SetEmbeddingModel picks another.
+const DefaultEmbeddingModel = string(openai.SmallEmbedding3)
+
+// Embedder turns text into a vector for semantic similarity search.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// SetEmbeddingModel sets the model Embed uses; it must return EmbeddingDimensions dimensions.
+// Empty uses DefaultEmbeddingModel.
+func (c *OpenAIClient) SetEmbeddingModel(model string) {
+	c.embeddingModel = model
+}
+
+// embeddingModelName returns the configured embedding model.
+func (c *OpenAIClient) embeddingModelName() string {
+	if c.embeddingModel != "" {
+		return c.embeddingModel
+	}
+	return DefaultEmbeddingModel
+}
+
+// EventEmbeddingText returns the text embedded for an event: its title and summary.
+func EventEmbeddingText(event *models.Event) string {
+	// Newlines degrade embedding quality, per the API docs
+	return strings.Join(strings.Fields(event.Title+". "+event.Summary), " ")
+}
+
+// Embed returns an EmbeddingDimensions-long embedding of text.
+func (c *OpenAIClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	if err := c.inferenceLogger.CheckBudget(ctx); err != nil {
+		return nil, err
+	}
+
+	release, err := c.acquireCallSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	model := c.embeddingModelName()
+	request := openai.EmbeddingRequestStrings{
+		Input: []string{text},
+		Model: openai.EmbeddingModel(model),
+	}
+	// text-embedding-3 models can be shortened to the column size; older models are fixed
+	if strings.HasPrefix(model, "text-embedding-3") {
+		request.Dimensions = EmbeddingDimensions
+	}
+
+	apiCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	resp, err := c.apiClient().CreateEmbeddings(apiCtx, request)
+	latency := time.Since(startTime)
+
+	if c.inferenceLogger != nil {
+		usage := struct {
+			PromptTokens     int
+			CompletionTokens int
+			TotalTokens      int
+		}{}
+		if err == nil {
+			usage.PromptTokens = resp.Usage.PromptTokens
+			usage.TotalTokens = resp.Usage.TotalTokens
+		}
+		c.inferenceLogger.LogOpenAIProviderCall(ctx, c.config.Provider, model, "event_embedding", usage, latency, err, nil)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI embeddings call failed: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no embedding returned")
+	}
+
+	embedding := resp.Data[0].Embedding
+	if len(embedding) != EmbeddingDimensions {
+		return nil, fmt.Errorf("embedding model %s returned %d dimensions, want %d", model, len(embedding), EmbeddingDimensions)
+	}
+	return embedding, nil
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors, or 0 if their lengths differ
+// or either is zero.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
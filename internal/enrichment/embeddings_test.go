@@ -0,0 +1,35 @@
+package enrichment
+
+import (
+	"math"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{"identical", []float32{1, 2, 3}, []float32{1, 2, 3}, 1},
+		{"scaled", []float32{1, 0}, []float32{5, 0}, 1},
+		{"orthogonal", []float32{1, 0}, []float32{0, 1}, 0},
+		{"opposite", []float32{1, 0}, []float32{-1, 0}, -1},
+		{"length mismatch", []float32{1, 0}, []float32{1, 0, 0}, 0},
+		{"zero vector", []float32{0, 0}, []float32{1, 0}, 0},
+	}
+	for _, tt := range tests {
+		if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: CosineSimilarity = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEventEmbeddingText(t *testing.T) {
+	event := &models.Event{Title: "Port closed", Summary: "Authorities closed the port\nafter an  explosion."}
+	if got, want := EventEmbeddingText(event), "Port closed. Authorities closed the port after an explosion."; got != want {
+		t.Errorf("EventEmbeddingText = %q, want %q", got, want)
+	}
+}
@@ -2,10 +2,23 @@ package enrichment
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/models"
+	openai "github.com/sashabaranov/go-openai"
 )
 
 func TestMockEnricher_Enrich(t *testing.T) {
@@ -284,6 +297,70 @@ func TestExtractMockEntities(t *testing.T) {
 	}
 }
 
+func TestFilterEntitiesByConfidence(t *testing.T) {
+	entities := []models.Entity{
+		{Name: "Ukraine", Type: models.EntityTypeCountry, Confidence: 0.95},
+		{Name: "Somewhere", Type: models.EntityTypeCity, Confidence: 0.3},
+		{Name: "NATO", Type: models.EntityTypeOrganization, Confidence: 0.5},
+	}
+
+	kept, dropped := filterEntitiesByConfidence(entities, 0.5)
+	if len(kept) != 2 || kept[0].Name != "Ukraine" || kept[1].Name != "NATO" {
+		t.Errorf("expected Ukraine and NATO to be kept (threshold is inclusive), got %+v", kept)
+	}
+	if len(dropped) != 1 || dropped[0].Name != "Somewhere" {
+		t.Errorf("expected Somewhere to be dropped, got %+v", dropped)
+	}
+	if got := describeEntities(dropped); !reflect.DeepEqual(got, []string{"Somewhere (city, 0.30)"}) {
+		t.Errorf("describeEntities() = %v", got)
+	}
+
+	kept, dropped = filterEntitiesByConfidence(entities, 0)
+	if len(kept) != len(entities) || len(dropped) != 0 {
+		t.Errorf("expected a zero threshold to keep every entity, kept %d dropped %d", len(kept), len(dropped))
+	}
+}
+
+func TestEntityExtractor_DedupesEntities(t *testing.T) {
+	extractor := NewEntityExtractor()
+	extractor.normalizer.AddAliases(map[string]string{"kremlin": "Russian Federation"})
+
+	entities, err := extractor.parseEntityResponse(`{"entities": [
+		{"type": "country", "name": "US", "confidence": 0.7},
+		{"type": "country", "name": "United States", "confidence": 0.9, "context": "US forces"},
+		{"type": "country", "name": "Russia", "confidence": 0.8},
+		{"type": "country", "name": "russia", "confidence": 0.6},
+		{"type": "country", "name": "Kremlin", "confidence": 0.5},
+		{"type": "organization", "name": "US", "confidence": 0.4}
+	]}`)
+	if err != nil {
+		t.Fatalf("parseEntityResponse failed: %v", err)
+	}
+	for i := range entities {
+		extractor.normalizer.Normalize(&entities[i])
+	}
+	deduped := dedupeEntities(entities)
+
+	if len(deduped) != 3 {
+		t.Fatalf("expected 3 entities after dedup, got %d: %+v", len(deduped), deduped)
+	}
+
+	us := deduped[0]
+	if us.Name != "United States" || us.Confidence != 0.9 || !reflect.DeepEqual(us.Aliases, []string{"US"}) {
+		t.Errorf("expected United States at 0.9 with alias US, got %+v", us)
+	}
+	russia := deduped[1]
+	if russia.NormalizedName != "Russian Federation" || russia.Confidence != 0.8 || !reflect.DeepEqual(russia.Aliases, []string{"Kremlin"}) {
+		t.Errorf("expected Russia at 0.8 with alias Kremlin, got %+v", russia)
+	}
+	if russia.Attributes.CountryCode != "RU" {
+		t.Errorf("expected country code RU, got %q", russia.Attributes.CountryCode)
+	}
+	if deduped[2].Type != models.EntityTypeOrganization {
+		t.Errorf("expected entities of different types to stay separate, got %+v", deduped[2])
+	}
+}
+
 func TestEnrichment_HighQualitySource(t *testing.T) {
 	enricher := NewMockEnricher()
 	ctx := context.Background()
@@ -349,3 +426,532 @@ func TestEnrichment_LowQualitySource(t *testing.T) {
 		t.Errorf("Expected low or medium confidence level, got %v", event.Confidence.Level)
 	}
 }
+
+func TestOpenAIClient_CallSlots(t *testing.T) {
+	client := &OpenAIClient{}
+	client.SetMaxConcurrentCalls(1)
+
+	// Occupy the only slot; a caller whose context ends while waiting gives up
+	client.callSlots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.createChatCompletion(ctx, openai.ChatCompletionRequest{}); err == nil {
+		t.Fatal("expected error while all call slots are taken")
+	}
+
+	client.SetMaxConcurrentCalls(0)
+	if client.callSlots != nil {
+		t.Error("expected no limit after SetMaxConcurrentCalls(0)")
+	}
+}
+
+func TestOpenAIClient_CorrelatorUsesCallSlots(t *testing.T) {
+	client := NewOpenAIClient("test-key", OpenAIConfig{Model: "gpt-4o", Timeout: 1})
+	client.SetMaxConcurrentCalls(1)
+	client.callSlots <- struct{}{}
+
+	_, err := client.GetCorrelator().AnalyzeCorrelation(context.Background(), models.Source{}, models.Event{})
+	if err == nil || !strings.Contains(err.Error(), "waiting for OpenAI call slot") {
+		t.Fatalf("expected the correlation call to wait for a call slot, got %v", err)
+	}
+}
+
+// fallbackServer serves chat completions, failing calls to the given models with the given status
+func fallbackServer(t *testing.T, failing map[string]int, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openai.ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		*calls = append(*calls, req.Model)
+
+		if status, ok := failing[req.Model]; ok {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"error":{"message":"model %s unavailable","type":"server_error"}}`, req.Model)
+			return
+		}
+
+		content := `{"title":"Port closed","category":"military","magnitude":5,"tags":["port"]}`
+		if strings.Contains(req.Messages[0].Content, "entity extraction") {
+			content = `{"entities":[]}`
+		}
+		json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{
+				Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content},
+				FinishReason: openai.FinishReasonStop,
+			}},
+		})
+	}))
+}
+
+func TestOpenAIClient_EnrichFallsBackToNextModel(t *testing.T) {
+	source := models.Source{
+		ID:          "src-1",
+		Type:        models.SourceTypeNewsMedia,
+		RawContent:  "The port was closed on Tuesday after naval vessels were seen gathering offshore.",
+		PublishedAt: time.Now(),
+	}
+	config := DefaultOpenAIConfig()
+	config.Model = "gpt-4o"
+	config.FallbackModels = []string{"gpt-4o-mini", "local"}
+
+	tests := []struct {
+		name      string
+		failing   map[string]int
+		wantModel string // Empty means Enrich fails
+		wantCalls []string
+	}{
+		{"primary succeeds", nil, "gpt-4o", []string{"gpt-4o", "gpt-4o"}},
+		{"server error falls back", map[string]int{"gpt-4o": http.StatusServiceUnavailable}, "gpt-4o-mini",
+			[]string{"gpt-4o", "gpt-4o-mini", "gpt-4o-mini"}},
+		{"unknown model falls back twice", map[string]int{"gpt-4o": http.StatusNotFound, "gpt-4o-mini": http.StatusBadGateway}, "local",
+			[]string{"gpt-4o", "gpt-4o-mini", "local", "local"}},
+		{"bad key does not fall back", map[string]int{"gpt-4o": http.StatusUnauthorized}, "", []string{"gpt-4o"}},
+		{"chain exhausted", map[string]int{"gpt-4o": http.StatusInternalServerError, "gpt-4o-mini": http.StatusInternalServerError, "local": http.StatusInternalServerError}, "",
+			[]string{"gpt-4o", "gpt-4o-mini", "local"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := fallbackServer(t, tt.failing, &calls)
+			defer server.Close()
+
+			client := NewOpenAIClient("test-key", config)
+			client.client = inference.NewOpenAIClient("test-key", models.OpenAIEndpoint{BaseURL: server.URL})
+
+			event, err := client.Enrich(context.Background(), source)
+			if tt.wantModel == "" {
+				if err == nil {
+					t.Fatal("expected enrichment to fail")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Enrich failed: %v", err)
+				}
+				if event.EnrichmentModel != tt.wantModel || event.Sources[0].EnrichmentModel != tt.wantModel {
+					t.Errorf("enrichment model = %q (source %q), want %q", event.EnrichmentModel, event.Sources[0].EnrichmentModel, tt.wantModel)
+				}
+			}
+			// The analysis and entity extraction calls both go to the model that worked
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("models called = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestOpenAIClient_ApplyCredentials(t *testing.T) {
+	var mu sync.Mutex
+	var chatKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/models") {
+			if auth != "Bearer new-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":{"message":"invalid api key","type":"invalid_request_error"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"object":"list","data":[]}`)
+			return
+		}
+		mu.Lock()
+		chatKeys = append(chatKeys, auth)
+		mu.Unlock()
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`)
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient("old-key", OpenAIConfig{Model: "gpt-4o", Provider: models.ProviderOpenAI})
+	client.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	client.client = inference.NewOpenAIClient("old-key", models.OpenAIEndpoint{BaseURL: server.URL})
+	stored := &models.OpenAIConfig{Provider: models.ProviderOpenAI, APIKey: "bad-key", BaseURL: server.URL}
+
+	if err := client.applyCredentials(context.Background(), stored); !errors.Is(err, ErrCredentialsRejected) {
+		t.Fatalf("expected the bad key to be rejected, got %v", err)
+	}
+	if err := CheckCredentials(context.Background(), stored); !errors.Is(err, ErrCredentialsRejected) {
+		t.Fatalf("expected CheckCredentials to reject the bad key, got %v", err)
+	}
+	if err := CheckCredentials(context.Background(), &models.OpenAIConfig{APIKey: "new-key", BaseURL: server.URL}); err != nil {
+		t.Fatalf("expected CheckCredentials to accept the new key, got %v", err)
+	}
+	stored.Provider = models.ProviderOpenAICompatible
+	if err := client.applyCredentials(context.Background(), stored); !errors.Is(err, ErrProviderChanged) {
+		t.Fatalf("expected a provider change to need a restart, got %v", err)
+	}
+	if _, err := client.createChatCompletion(context.Background(), openai.ChatCompletionRequest{Model: "gpt-4o"}); err != nil {
+		t.Fatalf("call with previous credentials failed: %v", err)
+	}
+
+	stored.Provider = models.ProviderOpenAI
+	stored.APIKey = "new-key"
+	if err := client.applyCredentials(context.Background(), stored); err != nil {
+		t.Fatalf("applyCredentials: %v", err)
+	}
+	if _, err := client.createChatCompletion(context.Background(), openai.ChatCompletionRequest{Model: "gpt-4o"}); err != nil {
+		t.Fatalf("call with new credentials failed: %v", err)
+	}
+	if client.correlator.apiClient() != client.apiClient() {
+		t.Error("expected the correlator to use the new credentials too")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(chatKeys, []string{"Bearer old-key", "Bearer new-key"}) {
+		t.Errorf("chat calls authenticated with %v", chatKeys)
+	}
+}
+
+func TestFallbackEnricher_ReloadReplacesMock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"message":"invalid api key","type":"invalid_request_error"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	key := ""
+	fallback := NewFallbackEnricher(func(ctx context.Context) (*OpenAIClient, error) {
+		if key == "" {
+			return nil, fmt.Errorf("openai api key not configured")
+		}
+		client := NewOpenAIClient(key, OpenAIConfig{Model: "gpt-4o"})
+		client.client = inference.NewOpenAIClient(key, models.OpenAIEndpoint{BaseURL: server.URL})
+		return client, nil
+	}, logger)
+	var loaded *OpenAIClient
+	fallback.SetOnLoad(func(client *OpenAIClient) { loaded = client })
+
+	if err := fallback.ReloadCredentials(context.Background()); err == nil || !fallback.Mock() {
+		t.Fatalf("expected the mock to stay in use without a configuration, got %v", err)
+	}
+	key = "bad-key"
+	if err := fallback.ReloadCredentials(context.Background()); !errors.Is(err, ErrCredentialsRejected) || !fallback.Mock() {
+		t.Fatalf("expected rejected credentials to keep the mock, got %v", err)
+	}
+
+	key = "good-key"
+	if err := fallback.ReloadCredentials(context.Background()); err != nil {
+		t.Fatalf("ReloadCredentials: %v", err)
+	}
+	if fallback.Mock() || loaded == nil || fallback.current() != Enricher(loaded) {
+		t.Error("expected the loaded OpenAI enricher to replace the mock")
+	}
+}
+
+func TestWorkerStats(t *testing.T) {
+	var nilStats *WorkerStats
+	if nilStats.Active() != 0 || nilStats.Configured() != 0 {
+		t.Error("nil stats should report zero")
+	}
+
+	stats := NewWorkerStats(3)
+	stats.Start()
+	stats.Start()
+	stats.Done()
+
+	if stats.Configured() != 3 || stats.Active() != 1 {
+		t.Errorf("got %d active of %d, want 1 of 3", stats.Active(), stats.Configured())
+	}
+}
+
+type countingToucher struct {
+	mu      sync.Mutex
+	touches int
+	ids     []string
+}
+
+func (c *countingToucher) TouchEnrichmentClaims(ctx context.Context, sourceIDs []string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.touches++
+	c.ids = sourceIDs
+	return int64(len(sourceIDs)), nil
+}
+
+func TestKeepClaimsAlive(t *testing.T) {
+	toucher := &countingToucher{}
+	stop := KeepClaimsAlive(context.Background(), toucher, []string{"src-1", "src-2"}, 5*time.Millisecond, slog.Default())
+	time.Sleep(30 * time.Millisecond)
+	stop()
+
+	toucher.mu.Lock()
+	touches := toucher.touches
+	toucher.mu.Unlock()
+	if touches == 0 {
+		t.Fatal("expected claims to be refreshed while the batch runs")
+	}
+	if !reflect.DeepEqual(toucher.ids, []string{"src-1", "src-2"}) {
+		t.Errorf("refreshed %v, want the claimed sources", toucher.ids)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	toucher.mu.Lock()
+	defer toucher.mu.Unlock()
+	if toucher.touches != touches {
+		t.Errorf("claims refreshed %d more times after stop", toucher.touches-touches)
+	}
+
+	// Nothing to keep alive
+	KeepClaimsAlive(context.Background(), toucher, nil, time.Millisecond, slog.Default())()
+}
+
+func TestLooksEnglish(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"english", "The ministry said on Tuesday that troops were moved to the border after reports of shelling near the town, which has been under pressure for weeks.", true},
+		{"french", "Le ministère a déclaré mardi que des troupes avaient été déplacées vers la frontière après des informations faisant état de bombardements près de la ville.", false},
+		{"spanish", "El ministerio dijo el martes que las tropas fueron trasladadas a la frontera tras informes de bombardeos cerca de la ciudad, que lleva semanas bajo presión.", false},
+		{"russian", "Министерство заявило во вторник, что войска были переброшены к границе", false},
+		{"short headline", "Explosión en el puerto", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LooksEnglish(tt.text); got != tt.want {
+				t.Errorf("LooksEnglish() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsTranslation_ConnectorLanguage(t *testing.T) {
+	source := models.Source{RawContent: "Министерство заявило во вторник"}
+	source.Metadata.Language = "en-US"
+	if NeedsTranslation(source) {
+		t.Error("expected connector-reported English to skip translation")
+	}
+
+	source.RawContent = "The ministry said on Tuesday"
+	source.Metadata.Language = "uk"
+	if !NeedsTranslation(source) {
+		t.Error("expected connector-reported Ukrainian to need translation")
+	}
+}
+
+type fakeSourceTranslator struct {
+	calls int
+	lang  string
+}
+
+func (f *fakeSourceTranslator) TranslateSource(ctx context.Context, source models.Source) (*models.SourceTranslation, error) {
+	f.calls++
+	return &models.SourceTranslation{Language: f.lang, Title: "Translated title", Content: "Translated content"}, nil
+}
+
+type fakeConnectorConfigs map[string]map[string]string
+
+func (f fakeConnectorConfigs) Get(ctx context.Context, connectorID string) (*models.ConnectorConfig, error) {
+	return &models.ConnectorConfig{ID: connectorID, Config: f[connectorID]}, nil
+}
+
+type fakeTranslationStore map[string]models.SourceTranslation
+
+func (f fakeTranslationStore) GetTranslation(ctx context.Context, sourceID string) (*models.SourceTranslation, error) {
+	if t, ok := f[sourceID]; ok {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+func (f fakeTranslationStore) SaveTranslation(ctx context.Context, sourceID string, t models.SourceTranslation) error {
+	f[sourceID] = t
+	return nil
+}
+
+func TestTranslator_Prepare(t *testing.T) {
+	russian := "Министерство заявило во вторник, что войска были переброшены к границе"
+	sources := []models.Source{
+		{ID: "rss-ru", Type: models.SourceTypeNewsMedia, Title: "Заголовок", RawContent: russian},
+		{ID: "tg-ru", Type: models.SourceTypeTelegram, Title: "Заголовок", RawContent: russian},
+		{ID: "rss-en", Type: models.SourceTypeNewsMedia, Title: "Headline", RawContent: "The ministry said on Tuesday"},
+	}
+
+	client := &fakeSourceTranslator{lang: "ru"}
+	store := fakeTranslationStore{}
+	connectors := fakeConnectorConfigs{"rss": {TranslateSetting: "true"}}
+	translator := NewTranslator(client, connectors, store, slog.Default())
+
+	prepared := translator.Prepare(context.Background(), sources)
+
+	if prepared[0].RawContent != "Translated content" || prepared[0].Title != "Translated title" {
+		t.Errorf("expected opted-in source to be translated, got %q", prepared[0].RawContent)
+	}
+	if prepared[1].RawContent != russian {
+		t.Error("expected source from connector without translation to be unchanged")
+	}
+	if prepared[2].RawContent != "The ministry said on Tuesday" {
+		t.Error("expected English source to be unchanged")
+	}
+	if sources[0].RawContent != russian {
+		t.Error("expected Prepare not to modify its input")
+	}
+	if _, ok := store["rss-ru"]; !ok {
+		t.Error("expected translation to be stored")
+	}
+
+	// A stored translation is reused without another model call
+	translator.Prepare(context.Background(), sources[:1])
+	if client.calls != 1 {
+		t.Errorf("expected 1 translation call, got %d", client.calls)
+	}
+
+	var nilTranslator *Translator
+	if got := nilTranslator.Prepare(context.Background(), sources); got[0].RawContent != russian {
+		t.Error("expected nil translator to return sources unchanged")
+	}
+}
+
+func TestParseTranslation(t *testing.T) {
+	got, err := parseTranslation("```json\n{\"language\": \"fr\", \"title\": \"Title\", \"content\": \"Body\"}\n```")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Language != "fr" || got.Title != "Title" || got.Content != "Body" {
+		t.Errorf("unexpected translation: %+v", got)
+	}
+
+	if _, err := parseTranslation("I cannot translate this."); err == nil {
+		t.Error("expected error for response without JSON")
+	}
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	text := strings.Repeat("Привет", 4) // 2 bytes per rune
+	for maxBytes := 0; maxBytes <= len(text)+1; maxBytes++ {
+		got := truncateUTF8(text, maxBytes)
+		if !utf8.ValidString(got) {
+			t.Fatalf("truncateUTF8(%d) split a rune: %q", maxBytes, got)
+		}
+		if len(got) > maxBytes || (got != text && len(got) < maxBytes-1) {
+			t.Fatalf("truncateUTF8(%d) returned %d bytes", maxBytes, len(got))
+		}
+	}
+}
+
+func TestPromptTemplatesFromConfig_DefaultsEmptyPrompts(t *testing.T) {
+	prompts := PromptTemplatesFromConfig(&models.OpenAIConfig{
+		SystemPrompt:        "custom system prompt",
+		CorrelationTemplate: "Compare {{.EventTitle}} with {{.SourceContent}}",
+	})
+	defaults := NewPromptTemplates()
+
+	if prompts.SystemPrompt != "custom system prompt" {
+		t.Errorf("expected configured system prompt, got %q", prompts.SystemPrompt)
+	}
+	if prompts.AnalysisTemplate != defaults.AnalysisTemplate {
+		t.Error("expected empty analysis template to fall back to default")
+	}
+	if prompts.CorrelationSystemPrompt != defaults.CorrelationSystemPrompt {
+		t.Error("expected empty correlation system prompt to fall back to default")
+	}
+
+	got := prompts.BuildCorrelationPrompt(
+		models.Source{RawContent: "Troops crossed the border"},
+		models.Event{Title: "Border incident"},
+	)
+	if got != "Compare Border incident with Troops crossed the border" {
+		t.Errorf("unexpected correlation prompt: %q", got)
+	}
+}
+
+func TestPromptBTemplatesFromConfig(t *testing.T) {
+	cfg := &models.OpenAIConfig{
+		SystemPrompt:        "prompt A system",
+		AnalysisTemplate:    "A: {{.RawContent}}",
+		PromptBSystemPrompt: "prompt B system",
+	}
+	if PromptBTemplatesFromConfig(cfg) != nil {
+		t.Fatal("expected no B prompts while the test is off")
+	}
+
+	cfg.PromptBFraction = 0.5
+	prompts := PromptBTemplatesFromConfig(cfg)
+	if prompts == nil {
+		t.Fatal("expected B prompts while the test is running")
+	}
+	if prompts.SystemPrompt != "prompt B system" {
+		t.Errorf("expected B system prompt, got %q", prompts.SystemPrompt)
+	}
+	if prompts.AnalysisTemplate != "A: {{.RawContent}}" {
+		t.Errorf("expected empty B template to fall back to prompt A's, got %q", prompts.AnalysisTemplate)
+	}
+}
+
+func TestDefaultCorrelationTemplate_FillsAllPlaceholders(t *testing.T) {
+	prompt := NewPromptTemplates().BuildCorrelationPrompt(
+		models.Source{Title: "New report", URL: "https://example.com/a", RawContent: "Details", PublishedAt: time.Now()},
+		models.Event{Title: "Existing event", Summary: "Summary", Category: models.CategoryMilitary},
+	)
+
+	if strings.Contains(prompt, "{{.") {
+		t.Errorf("unfilled placeholder in default correlation prompt:\n%s", prompt)
+	}
+	for _, want := range []string{"Existing event", "New report", "https://example.com/a", "Details"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q", want)
+		}
+	}
+}
+
+func TestBuildCredibilityPrompt(t *testing.T) {
+	prompt := NewPromptTemplates().BuildCredibilityPrompt("https://example.com/feed", models.SourceTypeNewsMedia)
+	if strings.Contains(prompt, "{{.") {
+		t.Errorf("unfilled placeholder in default credibility prompt:\n%s", prompt)
+	}
+	for _, want := range []string{"https://example.com/feed", string(models.SourceTypeNewsMedia)} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q", want)
+		}
+	}
+
+	prompts := PromptTemplatesFromConfig(&models.OpenAIConfig{
+		CredibilityTemplate: "Rate {{.URL}} ({{.SourceType}})",
+	})
+	if got := prompts.BuildCredibilityPrompt("https://example.com", models.SourceTypeNewsMedia); got != "Rate https://example.com (news_media)" {
+		t.Errorf("unexpected configured credibility prompt: %q", got)
+	}
+	if prompts.CredibilitySystemPrompt != NewPromptTemplates().CredibilitySystemPrompt {
+		t.Error("expected empty credibility system prompt to fall back to default")
+	}
+}
+
+func TestValidatePromptTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		template string
+		wantErr  bool
+	}{
+		{"empty uses default", "analysis_template", "", false},
+		{"analysis with content", "analysis_template", "Analyze {{.RawContent}}", false},
+		{"analysis without content", "analysis_template", "Analyze this", true},
+		{"entity extraction without content", "entity_extraction_prompt", "Extract entities", true},
+		{"correlation missing source", "correlation_template", "Compare {{.EventTitle}}", true},
+		{"correlation complete", "correlation_template", "{{.EventTitle}} vs {{.SourceContent}}", false},
+		{"credibility without url", "credibility_template", "Score this source", true},
+		{"credibility with url", "credibility_template", "Score {{.URL}}", false},
+		{"system prompt has no requirements", "system_prompt", "Be an analyst", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePromptTemplate(tt.field, tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePromptTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/STRATINT/stratint/internal/models"
 	openai "github.com/sashabaranov/go-openai"
@@ -21,14 +22,17 @@ func NewEntityExtractor() *EntityExtractor {
 	}
 }
 
+// chatCompleter sends a chat completion request, e.g. OpenAIClient.createChatCompletion.
+type chatCompleter func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error)
+
 // Extract pulls named entities from content using OpenAI.
-func (e *EntityExtractor) Extract(ctx context.Context, content string, client *openai.Client, config OpenAIConfig, entityPrompt string) ([]models.Entity, error) {
+func (e *EntityExtractor) Extract(ctx context.Context, content string, complete chatCompleter, config OpenAIConfig, entityPrompt string) ([]models.Entity, error) {
 	// Use the provided entity extraction prompt (should already have content substituted)
 	if entityPrompt == "" {
 		return nil, fmt.Errorf("entity extraction prompt is empty")
 	}
 
-	resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	resp, err := complete(ctx, openai.ChatCompletionRequest{
 		Model:               config.Model,
 		MaxCompletionTokens: 2000, // Increased to handle larger entity lists
 		ResponseFormat: &openai.ChatCompletionResponseFormat{
@@ -62,12 +66,82 @@ func (e *EntityExtractor) Extract(ctx context.Context, content string, client *o
 		return nil, fmt.Errorf("failed to parse entities: %w", err)
 	}
 
-	// Normalize entities
+	// Normalize entities, then merge any the model listed twice under the same canonical name
 	for i := range entities {
 		e.normalizer.Normalize(&entities[i])
 	}
 
-	return entities, nil
+	return dedupeEntities(entities), nil
+}
+
+// dedupeEntities merges entities of the same type whose normalized names match
+// (case-insensitively). The highest-confidence entity is kept, and the names of the others are
+// added to its aliases. Entities stay in the order they first appeared.
+func dedupeEntities(entities []models.Entity) []models.Entity {
+	deduped := make([]models.Entity, 0, len(entities))
+	index := make(map[string]int, len(entities))
+	for _, entity := range entities {
+		key := string(entity.Type) + "|" + strings.ToLower(strings.TrimSpace(entity.NormalizedName))
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(deduped)
+			deduped = append(deduped, entity)
+			continue
+		}
+
+		kept, other := deduped[i], entity
+		if other.Confidence > kept.Confidence {
+			kept, other = other, kept
+		}
+		for _, name := range append([]string{other.Name}, other.Aliases...) {
+			kept.Aliases = addAlias(kept.Aliases, kept.Name, name)
+		}
+		if kept.Context == "" {
+			kept.Context = other.Context
+		}
+		deduped[i] = kept
+	}
+	return deduped
+}
+
+// addAlias appends name to aliases unless it matches the entity's name or an existing alias
+func addAlias(aliases []string, entityName, name string) []string {
+	if name == "" || strings.EqualFold(name, entityName) {
+		return aliases
+	}
+	for _, alias := range aliases {
+		if strings.EqualFold(alias, name) {
+			return aliases
+		}
+	}
+	return append(aliases, name)
+}
+
+// filterEntitiesByConfidence splits entities into those at or above minConfidence and those
+// below it. A minConfidence of 0 or less keeps every entity.
+func filterEntitiesByConfidence(entities []models.Entity, minConfidence float64) (kept, dropped []models.Entity) {
+	if minConfidence <= 0 {
+		return entities, nil
+	}
+
+	kept = make([]models.Entity, 0, len(entities))
+	for _, entity := range entities {
+		if entity.Confidence < minConfidence {
+			dropped = append(dropped, entity)
+			continue
+		}
+		kept = append(kept, entity)
+	}
+	return kept, dropped
+}
+
+// describeEntities formats entities as "name (type, confidence)" for logging.
+func describeEntities(entities []models.Entity) []string {
+	described := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		described = append(described, fmt.Sprintf("%s (%s, %.2f)", entity.Name, entity.Type, entity.Confidence))
+	}
+	return described
 }
 
 // parseEntityResponse converts JSON response to entity structs.
@@ -221,6 +295,7 @@ func generateEntityID() string {
 type EntityNormalizer struct {
 	countryAliases map[string]string
 	cityAliases    map[string]string
+	customAliases  map[string]string // Lowercased alias -> canonical name, for any entity type
 }
 
 // NewEntityNormalizer creates a new entity normalizer with reference data.
@@ -231,11 +306,32 @@ func NewEntityNormalizer() *EntityNormalizer {
 	}
 }
 
+// AddAliases maps extra aliases to canonical names for entities of any type. Matching is
+// case-insensitive and takes precedence over the built-in country and city aliases.
+func (n *EntityNormalizer) AddAliases(aliases map[string]string) {
+	if n.customAliases == nil {
+		n.customAliases = make(map[string]string, len(aliases))
+	}
+	for alias, canonical := range aliases {
+		n.customAliases[strings.ToLower(strings.TrimSpace(alias))] = canonical
+	}
+}
+
 // Normalize standardizes an entity's name and adds metadata.
 func (n *EntityNormalizer) Normalize(entity *models.Entity) {
+	for _, name := range []string{entity.Name, entity.NormalizedName} {
+		if canonical, ok := n.customAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			entity.NormalizedName = canonical
+			if entity.Type == models.EntityTypeCountry {
+				entity.Attributes.CountryCode = getCountryCode(canonical)
+			}
+			return
+		}
+	}
+
 	switch entity.Type {
 	case models.EntityTypeCountry:
-		if normalized, ok := n.countryAliases[entity.Name]; ok {
+		if normalized, ok := lookupAlias(n.countryAliases, entity.Name); ok {
 			entity.NormalizedName = normalized
 			// Add country code if available
 			if code := getCountryCode(normalized); code != "" {
@@ -244,7 +340,7 @@ func (n *EntityNormalizer) Normalize(entity *models.Entity) {
 		}
 
 	case models.EntityTypeCity:
-		if normalized, ok := n.cityAliases[entity.Name]; ok {
+		if normalized, ok := lookupAlias(n.cityAliases, entity.Name); ok {
 			entity.NormalizedName = normalized
 		}
 	}
@@ -255,6 +351,19 @@ func (n *EntityNormalizer) Normalize(entity *models.Entity) {
 	}
 }
 
+// lookupAlias finds name in aliases, falling back to a case-insensitive match
+func lookupAlias(aliases map[string]string, name string) (string, bool) {
+	if canonical, ok := aliases[name]; ok {
+		return canonical, true
+	}
+	for alias, canonical := range aliases {
+		if strings.EqualFold(alias, name) {
+			return canonical, true
+		}
+	}
+	return "", false
+}
+
 // buildCountryAliases returns common country name variations.
 func buildCountryAliases() map[string]string {
 	return map[string]string{
@@ -0,0 +1,99 @@
+package enrichment
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// FallbackEnricher runs the mock enricher until the OpenAI enricher can be loaded, so a server
+// that started without a working configuration recovers on a credentials reload instead of a
+// restart. Correlation, embeddings, credibility scoring and translation are wired up at startup
+// and still need a restart to use the loaded client.
+type FallbackEnricher struct {
+	mock   *MockEnricher
+	load   func(ctx context.Context) (*OpenAIClient, error)
+	logger *slog.Logger
+	onLoad func(client *OpenAIClient)
+
+	reloadMu sync.Mutex    // Serializes reloads so the client is loaded once
+	mu       sync.RWMutex  // Guards client for the enrichment calls
+	client   *OpenAIClient // nil while the mock is in use
+}
+
+// NewFallbackEnricher creates an enricher that uses the mock until load succeeds.
+func NewFallbackEnricher(load func(ctx context.Context) (*OpenAIClient, error), logger *slog.Logger) *FallbackEnricher {
+	return &FallbackEnricher{
+		mock:   NewMockEnricher(),
+		load:   load,
+		logger: logger,
+	}
+}
+
+// SetOnLoad registers fn to run once the OpenAI enricher replaces the mock.
+func (f *FallbackEnricher) SetOnLoad(fn func(client *OpenAIClient)) {
+	f.onLoad = fn
+}
+
+// Mock reports whether the mock enricher is still in use.
+func (f *FallbackEnricher) Mock() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.client == nil
+}
+
+// current returns the enricher new calls go to.
+func (f *FallbackEnricher) current() Enricher {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.client != nil {
+		return f.client
+	}
+	return f.mock
+}
+
+// ReloadCredentials loads the OpenAI enricher from the database configuration and, once the
+// provider accepts its credentials, uses it in place of the mock. After that it reloads the
+// loaded client's credentials.
+func (f *FallbackEnricher) ReloadCredentials(ctx context.Context) error {
+	f.reloadMu.Lock()
+	defer f.reloadMu.Unlock()
+	// client is only written under reloadMu, so it can be read here without mu
+	if f.client != nil {
+		return f.client.ReloadCredentials(ctx)
+	}
+
+	client, err := f.load(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkCredentials(ctx, client.apiClient()); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.client = client
+	f.mu.Unlock()
+	f.logger.Info("loaded openai enricher, replacing mock enricher")
+	if f.onLoad != nil {
+		f.onLoad(client)
+	}
+	return nil
+}
+
+// Enrich processes a source with the enricher in use.
+func (f *FallbackEnricher) Enrich(ctx context.Context, source models.Source) (*models.Event, error) {
+	return f.current().Enrich(ctx, source)
+}
+
+// EnrichBatch processes multiple sources with the enricher in use.
+func (f *FallbackEnricher) EnrichBatch(ctx context.Context, sources []models.Source) ([]models.Event, error) {
+	return f.current().EnrichBatch(ctx, sources)
+}
+
+// ExtractArticleText extracts article content with the enricher in use.
+func (f *FallbackEnricher) ExtractArticleText(ctx context.Context, html, url string) (string, error) {
+	return f.current().ExtractArticleText(ctx, html, url)
+}
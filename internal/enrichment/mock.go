@@ -37,6 +37,8 @@ func (m *MockEnricher) Enrich(ctx context.Context, source models.Source) (*model
 		Status:     models.EventStatusEnriched,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
+
+		WorkspaceID: source.WorkspaceID,
 	}
 
 	// Extract mock entities
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/STRATINT/stratint/internal/models"
 )
@@ -15,6 +16,9 @@ type PromptTemplates struct {
 	AnalysisTemplate        string
 	EntityExtractionPrompt  string
 	CorrelationSystemPrompt string
+	CorrelationTemplate     string
+	CredibilitySystemPrompt string
+	CredibilityTemplate     string
 }
 
 // NewPromptTemplates creates optimized prompts for OSINT intelligence processing.
@@ -24,9 +28,86 @@ func NewPromptTemplates() *PromptTemplates {
 		AnalysisTemplate:        buildAnalysisTemplate(),
 		EntityExtractionPrompt:  buildEntityExtractionPrompt(),
 		CorrelationSystemPrompt: buildCorrelationSystemPrompt(),
+		CorrelationTemplate:     buildCorrelationTemplate(),
+		CredibilitySystemPrompt: buildCredibilitySystemPrompt(),
+		CredibilityTemplate:     buildCredibilityTemplate(),
 	}
 }
 
+// PromptTemplatesFromConfig builds prompts from stored configuration, using the built-in
+// default for any prompt left empty.
+func PromptTemplatesFromConfig(cfg *models.OpenAIConfig) *PromptTemplates {
+	prompts := NewPromptTemplates()
+	if cfg.SystemPrompt != "" {
+		prompts.SystemPrompt = cfg.SystemPrompt
+	}
+	if cfg.AnalysisTemplate != "" {
+		prompts.AnalysisTemplate = cfg.AnalysisTemplate
+	}
+	if cfg.EntityExtractionPrompt != "" {
+		prompts.EntityExtractionPrompt = cfg.EntityExtractionPrompt
+	}
+	if cfg.CorrelationSystemPrompt != "" {
+		prompts.CorrelationSystemPrompt = cfg.CorrelationSystemPrompt
+	}
+	if cfg.CorrelationTemplate != "" {
+		prompts.CorrelationTemplate = cfg.CorrelationTemplate
+	}
+	if cfg.CredibilitySystemPrompt != "" {
+		prompts.CredibilitySystemPrompt = cfg.CredibilitySystemPrompt
+	}
+	if cfg.CredibilityTemplate != "" {
+		prompts.CredibilityTemplate = cfg.CredibilityTemplate
+	}
+	return prompts
+}
+
+// PromptBTemplatesFromConfig builds the B prompts of an A/B test: the configured prompts with the
+// B system prompt and analysis template swapped in where set. Nil when no test is running.
+func PromptBTemplatesFromConfig(cfg *models.OpenAIConfig) *PromptTemplates {
+	if cfg.PromptBFraction <= 0 {
+		return nil
+	}
+	prompts := PromptTemplatesFromConfig(cfg)
+	if cfg.PromptBSystemPrompt != "" {
+		prompts.SystemPrompt = cfg.PromptBSystemPrompt
+	}
+	if cfg.PromptBAnalysisTemplate != "" {
+		prompts.AnalysisTemplate = cfg.PromptBAnalysisTemplate
+	}
+	return prompts
+}
+
+// requiredPlaceholders lists, per template, the placeholders without which the model never sees
+// the content it is asked about. System prompts take no placeholders.
+var requiredPlaceholders = map[string][]string{
+	"analysis_template":          {"{{.RawContent}}"},
+	"prompt_b_analysis_template": {"{{.RawContent}}"},
+	"entity_extraction_prompt":   {"{{.Content}}"},
+	"correlation_template":       {"{{.EventTitle}}", "{{.SourceContent}}"},
+	"credibility_template":       {"{{.URL}}"},
+}
+
+// ValidatePromptTemplate checks that a template contains the placeholders it requires. An empty
+// template is valid because it falls back to the default.
+func ValidatePromptTemplate(name, template string) error {
+	if template == "" {
+		return nil
+	}
+
+	var missing []string
+	for _, placeholder := range requiredPlaceholders[name] {
+		if !strings.Contains(template, placeholder) {
+			missing = append(missing, placeholder)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required placeholders: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
 func buildSystemPrompt() string {
 	return `CRITICAL: You MUST output ONLY valid JSON. Do not include any text before or after the JSON object. Do not wrap it in markdown code blocks. Output the raw JSON object directly.
 
@@ -231,7 +312,7 @@ func (p *PromptTemplates) BuildAnalysisPrompt(source models.Source) string {
 	template = strings.ReplaceAll(template, "{{.URL}}", source.URL)
 	template = strings.ReplaceAll(template, "{{.Credibility}}", fmt.Sprintf("%.2f", source.Credibility))
 	template = strings.ReplaceAll(template, "{{.RawContent}}", source.RawContent)
-	template = strings.ReplaceAll(template, "{{.Metadata}}", formatMetadata(source.Metadata))
+	template = strings.ReplaceAll(template, "{{.Metadata}}", formatMetadata(source))
 
 	return template
 }
@@ -243,8 +324,31 @@ func (p *PromptTemplates) BuildEntityExtractionPrompt(content string) string {
 	return template
 }
 
-// formatMetadata converts source metadata into human-readable format.
-func formatMetadata(metadata models.SourceMetadata) string {
+// BuildCorrelationPrompt creates the user prompt comparing a new source against an existing event.
+func (p *PromptTemplates) BuildCorrelationPrompt(source models.Source, event models.Event) string {
+	template := p.CorrelationTemplate
+	template = strings.ReplaceAll(template, "{{.EventTitle}}", event.Title)
+	template = strings.ReplaceAll(template, "{{.EventSummary}}", event.Summary)
+	template = strings.ReplaceAll(template, "{{.EventCategory}}", string(event.Category))
+	template = strings.ReplaceAll(template, "{{.KeyFacts}}", "- "+formatKeyFacts(event))
+	template = strings.ReplaceAll(template, "{{.SourceTitle}}", source.Title)
+	template = strings.ReplaceAll(template, "{{.SourceURL}}", source.URL)
+	template = strings.ReplaceAll(template, "{{.SourcePublished}}", source.PublishedAt.Format(time.RFC3339))
+	template = strings.ReplaceAll(template, "{{.SourceContent}}", truncateText(source.RawContent, 2000)) // Limit content for token efficiency
+	return template
+}
+
+// BuildCredibilityPrompt creates the user prompt scoring a source's credibility from its URL.
+func (p *PromptTemplates) BuildCredibilityPrompt(url string, sourceType models.SourceType) string {
+	template := p.CredibilityTemplate
+	template = strings.ReplaceAll(template, "{{.URL}}", url)
+	template = strings.ReplaceAll(template, "{{.SourceType}}", string(sourceType))
+	return template
+}
+
+// formatMetadata converts source metadata, links and attachments into human-readable format.
+func formatMetadata(source models.Source) string {
+	metadata := source.Metadata
 	parts := []string{}
 
 	if metadata.TweetID != "" {
@@ -269,6 +373,14 @@ func formatMetadata(metadata models.SourceMetadata) string {
 		parts = append(parts, fmt.Sprintf("Language: %s", metadata.Language))
 	}
 
+	if source.ExternalURL != "" {
+		parts = append(parts, fmt.Sprintf("Linked URL: %s", source.ExternalURL))
+	}
+
+	if len(source.Media) > 0 {
+		parts = append(parts, fmt.Sprintf("Attached media: %s", strings.Join(source.Media, ", ")))
+	}
+
 	if len(parts) == 0 {
 		return "No additional metadata"
 	}
@@ -276,10 +388,22 @@ func formatMetadata(metadata models.SourceMetadata) string {
 	return strings.Join(parts, "\n")
 }
 
+// extractJSONObject returns the text from the first { to the last }, dropping any prose or
+// reasoning a model put around its JSON. Text without an object is returned unchanged.
+func extractJSONObject(text string) string {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return text
+	}
+	return text[start : end+1]
+}
+
 // ParsedAnalysis represents the structured output from AI analysis.
 type ParsedAnalysis struct {
 	Title           string
 	Category        models.Category
+	RawCategory     string // As returned by the model, before normalization
 	Magnitude       float64
 	Tags            []string
 	Location        *models.Location
@@ -298,11 +422,8 @@ func ParseStructuredAnalysis(analysis string) (*ParsedAnalysis, error) {
 	if matches := re.FindStringSubmatch(analysis); len(matches) > 1 {
 		jsonStr = matches[1]
 	} else {
-		// Try to find raw JSON object - match from first { to last }
-		re = regexp.MustCompile("(?s)^\\s*({.+})\\s*$")
-		if matches := re.FindStringSubmatch(analysis); len(matches) > 1 {
-			jsonStr = matches[1]
-		}
+		// Otherwise take the outermost object, ignoring any text around it
+		jsonStr = extractJSONObject(analysis)
 	}
 
 	// Define struct for JSON unmarshaling
@@ -333,7 +454,8 @@ func ParseStructuredAnalysis(analysis string) (*ParsedAnalysis, error) {
 	// Convert to ParsedAnalysis
 	parsed := &ParsedAnalysis{
 		Title:           rawData.Title,
-		Category:        parseCategory(rawData.Category),
+		Category:        models.CategoryMapping(nil).Normalize(rawData.Category),
+		RawCategory:     rawData.Category,
 		Magnitude:       rawData.Magnitude,
 		Tags:            rawData.Tags,
 		KeyFacts:        rawData.KeyFacts,
@@ -399,34 +521,6 @@ func extractField(text, field string) string {
 	return text[start : start+end]
 }
 
-// parseCategory converts string to Category enum.
-func parseCategory(cat string) models.Category {
-	cat = strings.ToLower(strings.TrimSpace(cat))
-
-	switch cat {
-	case "geopolitics":
-		return models.CategoryGeopolitics
-	case "military":
-		return models.CategoryMilitary
-	case "economic":
-		return models.CategoryEconomic
-	case "cyber":
-		return models.CategoryCyber
-	case "disaster":
-		return models.CategoryDisaster
-	case "terrorism":
-		return models.CategoryTerrorism
-	case "diplomacy":
-		return models.CategoryDiplomacy
-	case "intelligence":
-		return models.CategoryIntelligence
-	case "humanitarian":
-		return models.CategoryHumanitarian
-	default:
-		return models.CategoryOther
-	}
-}
-
 // parseTags extracts tags from structured text.
 func parseTags(tagStr string) []string {
 	if tagStr == "" {
@@ -536,3 +630,101 @@ Output: {"similarity": 0.3, "should_merge": false, "has_novel_facts": false, "no
 
 Remember: Output ONLY the JSON object. No additional text.`
 }
+
+func buildCorrelationTemplate() string {
+	return `=== CORRELATION ANALYSIS REQUEST ===
+
+You are analyzing whether a new intelligence source should be merged with an existing event.
+
+EXISTING EVENT:
+Title: {{.EventTitle}}
+Summary: {{.EventSummary}}
+Category: {{.EventCategory}}
+Key Facts:
+{{.KeyFacts}}
+
+NEW SOURCE:
+Title: {{.SourceTitle}}
+URL: {{.SourceURL}}
+Published: {{.SourcePublished}}
+Content Preview:
+{{.SourceContent}}
+
+=== ANALYSIS TASK ===
+
+Compare the new source against the existing event and determine:
+
+1. SIMILARITY (0.0-1.0): How closely related are they?
+   - 1.0 = Same event, same facts (duplicate)
+   - 0.8-0.9 = Same event, minor variations or updates
+   - 0.6-0.7 = Related event, significant overlap
+   - 0.4-0.5 = Tangentially related (e.g., reactions, responses, consequences)
+   - 0.2-0.3 = Same topic but different events
+   - 0.0-0.1 = Unrelated
+
+2. SHOULD_MERGE: Should this source be added to the existing event?
+   - true if similarity >= 0.6 AND sources discuss the SAME core event/incident
+   - false if discussing different events, even if related topic or cause/effect
+
+   DO NOT MERGE if the new source is:
+   - A REACTION or RESPONSE to the event (condemnations, statements ABOUT the event)
+   - A CONSEQUENCE or follow-on event (investigations, arrests, policy changes)
+   - A DIFFERENT INCIDENT in the same location or same topic area
+   - General commentary or analysis rather than factual reporting
+
+   DO MERGE if the new source is:
+   - Additional details about the SAME incident
+   - Updated information (casualty counts, damage assessments)
+   - Different perspective on the SAME event
+   - Conflicting claims about the SAME incident (e.g., disputes about attribution, casualty numbers, causes)
+   - Denials or counter-claims that directly contradict facts about the incident itself
+
+   KEY DISTINCTION: Statements ABOUT an event (reactions) ≠ Statements about the FACTS of an event (conflicting claims)
+
+3. NOVEL FACTS: Does the new source contain facts NOT in the existing event?
+   - Identify specific new information, claims, or developments
+   - Ignore stylistic variations or rephrasing of same facts
+   - Focus on substantive new information
+   - If the new source is a reaction/response, those reactions ARE novel facts
+
+Output ONLY valid JSON in this format:
+{
+  "similarity": 0.85,
+  "should_merge": true,
+  "has_novel_facts": true,
+  "novel_facts": [
+    "Specific new fact 1 not in existing event",
+    "Specific new fact 2 not in existing event"
+  ],
+  "reasoning": "Brief explanation of your decision"
+}`
+}
+
+func buildCredibilitySystemPrompt() string {
+	return "You are an OSINT analyst expert at assessing source credibility. Respond only with a decimal number."
+}
+
+func buildCredibilityTemplate() string {
+	return `Assess the credibility of this source for OSINT analysis.
+
+URL: {{.URL}}
+Source Type: {{.SourceType}}
+
+Consider:
+- Domain reputation and authority
+- Known track record for accuracy
+- Editorial standards
+- Bias/reliability ratings
+- Historical trustworthiness
+
+Respond with ONLY a decimal number between 0.0 (not credible) and 1.0 (highly credible).
+Examples:
+- Reuters, AP News: 0.95
+- CNN, BBC: 0.85
+- Local news sites: 0.70
+- Personal blogs: 0.40
+- Twitter/social media: 0.60
+- Unknown/suspicious sites: 0.20
+
+Score:`
+}
@@ -3,6 +3,7 @@ package enrichment
 import (
 	"fmt"
 	"math"
+	"net/url"
 	"strings"
 	"time"
 
@@ -29,28 +30,40 @@ func NewConfidenceScorer() *ConfidenceScorer {
 	}
 }
 
-// Score calculates a comprehensive confidence score for an event.
-func (s *ConfidenceScorer) Score(source models.Source, event *models.Event, entities []models.Entity) models.Confidence {
-	// Check if the event indicates insufficient data for analysis
-	insufficientDataPhrases := []string{
-		"insufficient data",
-		"lacks sufficient detail",
-		"not enough information",
-		"missing critical details",
-		"unable to provide",
-		"cannot be determined",
-		"information is too limited",
-		"provided information lacks",
-	}
+// insufficientDataPhrases mark summaries where the model found too little to analyze
+var insufficientDataPhrases = []string{
+	"insufficient data",
+	"lacks sufficient detail",
+	"not enough information",
+	"missing critical details",
+	"unable to provide",
+	"cannot be determined",
+	"information is too limited",
+	"provided information lacks",
+}
+
+// Corroboration: each additional independent outlet reporting an event raises its confidence by
+// corroborationPerSource scaled by the outlet's credibility, up to maxCorroborationBoost in total.
+const (
+	corroborationPerSource = 0.08
+	maxCorroborationBoost  = 0.25
+)
 
+// hasInsufficientData reports whether an event's summary says there was too little to analyze
+func hasInsufficientData(event *models.Event) bool {
 	summaryLower := strings.ToLower(event.Summary)
-	hasInsufficientData := false
 	for _, phrase := range insufficientDataPhrases {
 		if strings.Contains(summaryLower, phrase) {
-			hasInsufficientData = true
-			break
+			return true
 		}
 	}
+	return false
+}
+
+// Score calculates a comprehensive confidence score for an event.
+func (s *ConfidenceScorer) Score(source models.Source, event *models.Event, entities []models.Entity) models.Confidence {
+	// Check if the event indicates insufficient data for analysis
+	insufficient := hasInsufficientData(event)
 
 	factors := []scoreFactor{
 		{name: "source_credibility", weight: 0.35, score: source.Credibility},
@@ -71,19 +84,33 @@ func (s *ConfidenceScorer) Score(source models.Source, event *models.Event, enti
 
 	finalScore := totalScore / totalWeight
 
+	breakdown := make([]models.ConfidenceFactor, 0, len(factors)+1)
+	for _, factor := range factors {
+		breakdown = append(breakdown, models.ConfidenceFactor{
+			Name:         factor.name,
+			Score:        factor.score,
+			Weight:       factor.weight / totalWeight,
+			Contribution: factor.score * factor.weight / totalWeight,
+		})
+	}
+
 	// If analysis indicates insufficient data, cap confidence at 0.05
-	if hasInsufficientData {
+	if insufficient {
+		breakdown = addAdjustment(breakdown, "insufficient_data", math.Min(finalScore, 0.05)-finalScore)
 		finalScore = math.Min(finalScore, 0.05)
 	}
 
 	// Clamp to [0, 1]
-	finalScore = math.Max(0.0, math.Min(1.0, finalScore))
+	clamped := math.Max(0.0, math.Min(1.0, finalScore))
+	breakdown = addAdjustment(breakdown, "clamp", clamped-finalScore)
+	finalScore = clamped
 
 	confidence := models.Confidence{
 		Score:       finalScore,
 		Level:       models.ConfidenceLow, // Will be set by DeriveLevel
 		SourceCount: 1,
 		Reasoning:   s.buildReasoning(factors, finalScore),
+		Breakdown:   breakdown,
 	}
 
 	confidence.Level = confidence.DeriveLevel()
@@ -91,6 +118,87 @@ func (s *ConfidenceScorer) Score(source models.Source, event *models.Event, enti
 	return confidence
 }
 
+// ScoreSources scores an event reported by several sources. The strongest single source sets the
+// base score and every other independent outlet (distinct URL host) adds corroboration weighted by
+// its credibility. With one source it matches Score.
+func (s *ConfidenceScorer) ScoreSources(sources []models.Source, event *models.Event) models.Confidence {
+	if len(sources) == 0 {
+		return event.Confidence
+	}
+
+	var best models.Confidence
+	bestIndex := 0
+	for i, source := range sources {
+		confidence := s.Score(source, event, event.Entities)
+		if i == 0 || confidence.Score > best.Score {
+			best = confidence
+			bestIndex = i
+		}
+	}
+
+	// Credibility of each outlet other than the best source's, counting an outlet once
+	primary := outletKey(sources[bestIndex])
+	outlets := make(map[string]float64)
+	for _, source := range sources {
+		key := outletKey(source)
+		if key == primary {
+			continue
+		}
+		outlets[key] = math.Max(outlets[key], source.Credibility)
+	}
+
+	boost := 0.0
+	for _, credibility := range outlets {
+		boost += corroborationPerSource * credibility
+	}
+	boost = math.Min(maxCorroborationBoost, boost)
+
+	finalScore := math.Min(1.0, best.Score+boost)
+	breakdown := append([]models.ConfidenceFactor(nil), best.Breakdown...)
+	breakdown = addAdjustment(breakdown, "corroboration", finalScore-best.Score)
+	if hasInsufficientData(event) {
+		breakdown = addAdjustment(breakdown, "insufficient_data", math.Min(finalScore, 0.05)-finalScore)
+		finalScore = math.Min(finalScore, 0.05)
+	}
+
+	confidence := models.Confidence{
+		Score:       finalScore,
+		SourceCount: len(sources),
+		Reasoning:   best.Reasoning,
+		Breakdown:   breakdown,
+	}
+	if len(outlets) > 0 {
+		confidence.Reasoning = fmt.Sprintf("%s; corroborated by %d other outlet(s) (+%.2f)", best.Reasoning, len(outlets), finalScore-best.Score)
+	}
+	confidence.Level = confidence.DeriveLevel()
+
+	return confidence
+}
+
+// outletKey identifies the outlet a source came from, so several articles from one site count
+// as a single corroborating source
+func outletKey(source models.Source) string {
+	if u, err := url.Parse(source.URL); err == nil && u.Host != "" {
+		return strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	}
+	return "source:" + source.ID
+}
+
+// addAdjustment adds a non-zero adjustment to a score breakdown, combining it with an earlier
+// adjustment of the same name
+func addAdjustment(breakdown []models.ConfidenceFactor, name string, delta float64) []models.ConfidenceFactor {
+	if delta == 0 {
+		return breakdown
+	}
+	for i := range breakdown {
+		if breakdown[i].Name == name {
+			breakdown[i].Contribution += delta
+			return breakdown
+		}
+	}
+	return append(breakdown, models.ConfidenceFactor{Name: name, Contribution: delta})
+}
+
 type scoreFactor struct {
 	name   string
 	weight float64
@@ -1,6 +1,8 @@
 package enrichment
 
 import (
+	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -323,3 +325,132 @@ func TestMagnitudeEstimator_ScopeModifier(t *testing.T) {
 		})
 	}
 }
+
+func TestConfidenceScorer_ScoreSources(t *testing.T) {
+	scorer := NewConfidenceScorer()
+	event := &models.Event{
+		Title:   "Border Clash Reported",
+		Summary: "Troops exchanged fire along the border overnight.",
+	}
+	source := func(id, url string) models.Source {
+		return models.Source{
+			ID:          id,
+			Type:        models.SourceTypeNewsMedia,
+			URL:         url,
+			Credibility: 0.8,
+			PublishedAt: time.Now().Add(-1 * time.Hour),
+			RawContent:  "A detailed report on the overnight clash between border units, citing officials on both sides.",
+		}
+	}
+
+	single := scorer.ScoreSources([]models.Source{source("s1", "https://reuters.com/a")}, event)
+	if want := scorer.Score(source("s1", "https://reuters.com/a"), event, nil); single.Score != want.Score {
+		t.Errorf("single source score = %v, want %v", single.Score, want.Score)
+	}
+
+	sameOutlet := scorer.ScoreSources([]models.Source{
+		source("s1", "https://reuters.com/a"),
+		source("s2", "https://www.reuters.com/b"),
+	}, event)
+	if sameOutlet.Score != single.Score {
+		t.Errorf("articles from one outlet should not corroborate: %v vs %v", sameOutlet.Score, single.Score)
+	}
+	if sameOutlet.SourceCount != 2 {
+		t.Errorf("SourceCount = %d, want 2", sameOutlet.SourceCount)
+	}
+
+	sources := []models.Source{
+		source("s1", "https://reuters.com/a"),
+		source("s2", "https://apnews.com/b"),
+		source("s3", "https://bbc.co.uk/c"),
+	}
+	corroborated := scorer.ScoreSources(sources, event)
+	if corroborated.Score <= single.Score {
+		t.Errorf("corroborated score %v should exceed single source score %v", corroborated.Score, single.Score)
+	}
+
+	var many []models.Source
+	for i := 0; i < 20; i++ {
+		many = append(many, source(fmt.Sprintf("s%d", i), fmt.Sprintf("https://outlet%d.com/x", i)))
+	}
+	capped := scorer.ScoreSources(many, event)
+	if capped.Score > single.Score+maxCorroborationBoost+1e-9 {
+		t.Errorf("corroboration boost should be capped at %v, got %v", maxCorroborationBoost, capped.Score-single.Score)
+	}
+
+	insufficient := &models.Event{Title: "Insufficient data", Summary: "Insufficient data for analysis"}
+	if got := scorer.ScoreSources(sources, insufficient); got.Score > 0.05 {
+		t.Errorf("insufficient data should stay capped, got %v", got.Score)
+	}
+}
+
+func TestConfidenceScorer_Breakdown(t *testing.T) {
+	scorer := NewConfidenceScorer()
+	event := &models.Event{Title: "Port Closed", Summary: "The port was closed after vessels gathered offshore."}
+	source := func(id, url string) models.Source {
+		return models.Source{
+			ID:          id,
+			Type:        models.SourceTypeNewsMedia,
+			URL:         url,
+			Credibility: 0.9,
+			PublishedAt: time.Now().Add(-1 * time.Hour),
+			RawContent:  "The port authority closed the harbor on Tuesday after naval vessels were seen gathering offshore.",
+		}
+	}
+
+	contribution := func(c models.Confidence, name string) (float64, bool) {
+		for _, f := range c.Breakdown {
+			if f.Name == name {
+				return f.Contribution, true
+			}
+		}
+		return 0, false
+	}
+	assertSums := func(t *testing.T, c models.Confidence) {
+		t.Helper()
+		sum := 0.0
+		for _, f := range c.Breakdown {
+			sum += f.Contribution
+		}
+		if math.Abs(sum-c.Score) > 1e-9 {
+			t.Errorf("breakdown sums to %v, want score %v: %+v", sum, c.Score, c.Breakdown)
+		}
+	}
+
+	// No entities: entity confidence is neutral, and the breakdown shows what it cost
+	single := scorer.Score(source("s1", "https://bbc.co.uk/a"), event, nil)
+	assertSums(t, single)
+	if len(single.Breakdown) != 5 {
+		t.Fatalf("expected the 5 weighted factors, got %+v", single.Breakdown)
+	}
+	weights := 0.0
+	for _, f := range single.Breakdown {
+		weights += f.Weight
+	}
+	if math.Abs(weights-1) > 1e-9 {
+		t.Errorf("weights sum to %v, want 1", weights)
+	}
+	if got, _ := contribution(single, "entity_confidence"); math.Abs(got-0.5*0.15) > 1e-9 {
+		t.Errorf("entity_confidence contribution = %v, want %v", got, 0.5*0.15)
+	}
+
+	corroborated := scorer.ScoreSources([]models.Source{source("s1", "https://bbc.co.uk/a"), source("s2", "https://apnews.com/b")}, event)
+	assertSums(t, corroborated)
+	if got, ok := contribution(corroborated, "corroboration"); !ok || math.Abs(got-corroborationPerSource*0.9) > 1e-9 {
+		t.Errorf("corroboration contribution = %v, want %v", got, corroborationPerSource*0.9)
+	}
+
+	insufficient := &models.Event{Title: "Unclear", Summary: "Insufficient data for analysis"}
+	capped := scorer.ScoreSources([]models.Source{source("s1", "https://bbc.co.uk/a"), source("s2", "https://apnews.com/b")}, insufficient)
+	assertSums(t, capped)
+	if got, ok := contribution(capped, "insufficient_data"); !ok || got >= 0 {
+		t.Errorf("insufficient_data contribution = %v, want a negative adjustment", got)
+	}
+	names := map[string]int{}
+	for _, f := range capped.Breakdown {
+		names[f.Name]++
+	}
+	if names["insufficient_data"] != 1 {
+		t.Errorf("adjustments should be combined, got %+v", capped.Breakdown)
+	}
+}
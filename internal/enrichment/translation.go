@@ -0,0 +1,301 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/STRATINT/stratint/internal/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// TranslateSetting is the connector config key that opts a connector's sources into translation.
+const TranslateSetting = "translate_non_english"
+
+// maxTranslationChars caps the content sent for translation (~4k tokens).
+const maxTranslationChars = 16000
+
+// englishStopwords are common English function words; their share of a text's words is a cheap
+// signal for whether the text is English.
+var englishStopwords = map[string]bool{
+	"the": true, "and": true, "of": true, "to": true, "is": true, "that": true, "for": true,
+	"with": true, "was": true, "by": true, "from": true, "are": true, "it": true, "has": true,
+	"have": true, "be": true, "this": true, "said": true, "will": true, "were": true, "been": true,
+	"after": true, "their": true, "which": true, "they": true, "not": true, "its": true,
+}
+
+// LooksEnglish guesses whether text is English. Text mostly in a non-Latin script is not; Latin
+// text with enough words is English when common English function words make up a fair share.
+// Short text is assumed English so we don't pay to translate headlines and fragments.
+func LooksEnglish(text string) bool {
+	var letters, latin int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.Is(unicode.Latin, r) {
+				latin++
+			}
+		}
+	}
+	if letters == 0 {
+		return true
+	}
+	if float64(latin)/float64(letters) < 0.5 {
+		return false
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < 12 {
+		return true
+	}
+
+	stopwords := 0
+	for _, w := range words {
+		if englishStopwords[w] {
+			stopwords++
+		}
+	}
+	return float64(stopwords)/float64(len(words)) >= 0.08
+}
+
+// NeedsTranslation reports whether a source appears to be in a language other than English.
+// A language set by the connector takes precedence over detection.
+func NeedsTranslation(source models.Source) bool {
+	if lang := strings.ToLower(source.Metadata.Language); lang != "" {
+		return !strings.HasPrefix(lang, "en")
+	}
+	return !LooksEnglish(source.Title + "\n" + source.RawContent)
+}
+
+// ConnectorForSourceType maps a source type to the connector that ingests it.
+func ConnectorForSourceType(t models.SourceType) string {
+	switch t {
+	case models.SourceTypeTwitter:
+		return "twitter"
+	case models.SourceTypeTelegram:
+		return "telegram"
+	default:
+		return "rss"
+	}
+}
+
+// SourceTranslator translates a source's title and content into English.
+type SourceTranslator interface {
+	TranslateSource(ctx context.Context, source models.Source) (*models.SourceTranslation, error)
+}
+
+// ConnectorConfigGetter loads a connector's configuration.
+type ConnectorConfigGetter interface {
+	Get(ctx context.Context, connectorID string) (*models.ConnectorConfig, error)
+}
+
+// TranslationStore persists translations next to the original source text.
+type TranslationStore interface {
+	GetTranslation(ctx context.Context, sourceID string) (*models.SourceTranslation, error)
+	SaveTranslation(ctx context.Context, sourceID string, t models.SourceTranslation) error
+}
+
+// Translator swaps non-English sources for their English translations before enrichment, for
+// connectors that have opted in.
+type Translator struct {
+	client     SourceTranslator
+	connectors ConnectorConfigGetter
+	store      TranslationStore
+	logger     *slog.Logger
+}
+
+// NewTranslator creates a translator for the enrichment pipeline.
+func NewTranslator(client SourceTranslator, connectors ConnectorConfigGetter, store TranslationStore, logger *slog.Logger) *Translator {
+	return &Translator{
+		client:     client,
+		connectors: connectors,
+		store:      store,
+		logger:     logger,
+	}
+}
+
+// Prepare returns the sources to enrich. Non-English sources from connectors with translation
+// enabled are replaced by copies carrying the English text; a stored translation is reused.
+// If translation fails the original source is enriched as-is. A nil Translator returns sources unchanged.
+func (t *Translator) Prepare(ctx context.Context, sources []models.Source) []models.Source {
+	if t == nil {
+		return sources
+	}
+
+	prepared := make([]models.Source, len(sources))
+	copy(prepared, sources)
+
+	enabled := make(map[string]bool)
+	for i, source := range prepared {
+		if !NeedsTranslation(source) {
+			continue
+		}
+
+		connector := ConnectorForSourceType(source.Type)
+		on, checked := enabled[connector]
+		if !checked {
+			if cfg, err := t.connectors.Get(ctx, connector); err == nil {
+				on = cfg.Config[TranslateSetting] == "true"
+			}
+			enabled[connector] = on
+		}
+		if !on {
+			continue
+		}
+
+		translation, err := t.translation(ctx, source)
+		if err != nil {
+			t.logger.Warn("translation failed, enriching original text",
+				"source_id", source.ID,
+				"error", err)
+			continue
+		}
+		if translation == nil {
+			continue
+		}
+
+		if translation.Title != "" {
+			prepared[i].Title = translation.Title
+		}
+		prepared[i].RawContent = translation.Content
+	}
+
+	return prepared
+}
+
+// translation returns the stored translation of a source or makes a new one. It returns nil
+// when the model reports the source is already English.
+func (t *Translator) translation(ctx context.Context, source models.Source) (*models.SourceTranslation, error) {
+	stored, err := t.store.GetTranslation(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		return stored, nil
+	}
+
+	translation, err := t.client.TranslateSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(strings.ToLower(translation.Language), "en") || translation.Content == "" {
+		return nil, nil
+	}
+
+	if err := t.store.SaveTranslation(ctx, source.ID, *translation); err != nil {
+		// Still enrich from the translation; it will be redone if the source is reprocessed
+		t.logger.Error("failed to save source translation", "source_id", source.ID, "error", err)
+	}
+
+	t.logger.Info("translated source",
+		"source_id", source.ID,
+		"language", translation.Language)
+
+	return translation, nil
+}
+
+// TranslateSource uses OpenAI to translate a source's title and content into English.
+func (c *OpenAIClient) TranslateSource(ctx context.Context, source models.Source) (*models.SourceTranslation, error) {
+	content := truncateUTF8(source.RawContent, maxTranslationChars)
+
+	prompt := fmt.Sprintf(`Translate this news source into English. Preserve names, numbers, dates and quotes faithfully; do not summarize or add commentary.
+
+Respond with ONLY a JSON object:
+{"language": "<ISO 639-1 code of the original language>", "title": "<English title>", "content": "<English content>"}
+
+If the text is already English, set "language" to "en" and leave title and content empty.
+
+Title: %s
+
+Content:
+%s`, source.Title, content)
+
+	if err := c.inferenceLogger.CheckBudget(ctx); err != nil {
+		return nil, err
+	}
+
+	timeout := 180
+	if c.config.Timeout > 0 {
+		timeout = c.config.Timeout
+	}
+	apiCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	resp, err := c.createChatCompletion(apiCtx, openai.ChatCompletionRequest{
+		Model:               c.config.Model,
+		MaxCompletionTokens: 6000, // Translations run about as long as the original
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+	})
+	latency := time.Since(startTime)
+
+	// Log inference call
+	if c.inferenceLogger != nil {
+		usage := struct {
+			PromptTokens     int
+			CompletionTokens int
+			TotalTokens      int
+		}{}
+		if err == nil {
+			usage.PromptTokens = resp.Usage.PromptTokens
+			usage.CompletionTokens = resp.Usage.CompletionTokens
+			usage.TotalTokens = resp.Usage.TotalTokens
+		}
+		c.inferenceLogger.LogOpenAIProviderCall(ctx, c.config.Provider, c.config.Model, "translation", usage, latency, err, map[string]interface{}{
+			"source_id": source.ID,
+			"url":       source.URL,
+		})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI API call failed: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no response from OpenAI")
+	}
+
+	return parseTranslation(resp.Choices[0].Message.Content)
+}
+
+// parseTranslation extracts the translation JSON from a model response, tolerating code fences.
+func parseTranslation(response string) (*models.SourceTranslation, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in translation response")
+	}
+
+	var translation models.SourceTranslation
+	if err := json.Unmarshal([]byte(response[start:end+1]), &translation); err != nil {
+		return nil, fmt.Errorf("failed to parse translation: %w", err)
+	}
+	if translation.Language == "" {
+		return nil, fmt.Errorf("translation response missing language")
+	}
+
+	return &translation, nil
+}
+
+// truncateUTF8 cuts text to at most maxBytes, backing off to a rune boundary so multi-byte
+// characters aren't split.
+func truncateUTF8(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+	for maxBytes > 0 && !utf8.RuneStart(text[maxBytes]) {
+		maxBytes--
+	}
+	return text[:maxBytes]
+}
@@ -0,0 +1,88 @@
+package enrichment
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerStats tracks how many enrichment workers are running and how many are busy.
+// Safe for concurrent use; a nil WorkerStats reports zero.
+type WorkerStats struct {
+	configured int
+	active     atomic.Int64
+}
+
+// NewWorkerStats creates stats for the given number of workers.
+func NewWorkerStats(configured int) *WorkerStats {
+	return &WorkerStats{configured: configured}
+}
+
+// Start marks a worker as busy enriching a claimed batch.
+func (s *WorkerStats) Start() {
+	s.active.Add(1)
+}
+
+// Done marks a worker as idle again.
+func (s *WorkerStats) Done() {
+	s.active.Add(-1)
+}
+
+// Configured returns the number of workers started.
+func (s *WorkerStats) Configured() int {
+	if s == nil {
+		return 0
+	}
+	return s.configured
+}
+
+// Active returns the number of workers currently enriching.
+func (s *WorkerStats) Active() int {
+	if s == nil {
+		return 0
+	}
+	return int(s.active.Load())
+}
+
+// ClaimToucher refreshes the claims a worker holds on sources it is enriching.
+type ClaimToucher interface {
+	TouchEnrichmentClaims(ctx context.Context, sourceIDs []string) (int64, error)
+}
+
+// KeepClaimsAlive refreshes the claims on sourceIDs every interval until the returned stop
+// function is called, so long batches are not reclaimed by other workers while still running.
+// Failures are logged and retried on the next tick. Stop waits for any refresh in flight.
+func KeepClaimsAlive(ctx context.Context, toucher ClaimToucher, sourceIDs []string, interval time.Duration, logger *slog.Logger) (stop func()) {
+	if interval <= 0 || len(sourceIDs) == 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				touched, err := toucher.TouchEnrichmentClaims(ctx, sourceIDs)
+				if err != nil {
+					if ctx.Err() == nil {
+						logger.Warn("failed to refresh enrichment claims", "sources", len(sourceIDs), "error", err)
+					}
+					continue
+				}
+				logger.Debug("refreshed enrichment claims", "sources", len(sourceIDs), "touched", touched)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
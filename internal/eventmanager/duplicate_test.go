@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/models"
 )
 
@@ -29,6 +30,7 @@ func TestProcessEvent_NoDuplicateCreation(t *testing.T) {
 	manager := &EventLifecycleManager{
 		eventRepo:     eventRepo,
 		thresholdRepo: thresholdRepo,
+		scorer:        enrichment.NewConfidenceScorer(),
 		config: LifecycleConfig{
 			AutoPublish: true,
 			MinSources:  1,
@@ -113,6 +115,7 @@ func TestMultipleSourcesSameEvent(t *testing.T) {
 	manager := &EventLifecycleManager{
 		eventRepo:     eventRepo,
 		thresholdRepo: thresholdRepo,
+		scorer:        enrichment.NewConfidenceScorer(),
 		config: LifecycleConfig{
 			AutoPublish: true,
 			MinSources:  1,
@@ -278,6 +281,20 @@ func (m *mockEventRepo) UpdateStatus(ctx context.Context, id string, status mode
 	return nil
 }
 
+func (m *mockEventRepo) Reject(ctx context.Context, id string, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	event, exists := m.events[id]
+	if !exists {
+		return fmt.Errorf("event %s doesn't exist", id)
+	}
+
+	event.Status = models.EventStatusRejected
+	event.RejectionReason = reason
+	return nil
+}
+
 func (m *mockEventRepo) HasSourceEvents(ctx context.Context, sourceID string) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -0,0 +1,100 @@
+package eventmanager
+
+import (
+	"sync"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// subscriberBuffer is how many published events a subscriber may fall behind before it is dropped
+const subscriberBuffer = 64
+
+// StreamFilter selects which published events a subscriber receives. Zero values match everything
+// in the workspace.
+type StreamFilter struct {
+	WorkspaceID  string
+	Categories   []models.Category
+	MinMagnitude float64
+}
+
+// Matches reports whether event passes the filter
+func (f StreamFilter) Matches(event *models.Event) bool {
+	if models.WorkspaceOrDefault(event.WorkspaceID) != models.WorkspaceOrDefault(f.WorkspaceID) {
+		return false
+	}
+	if event.Magnitude < f.MinMagnitude {
+		return false
+	}
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, category := range f.Categories {
+		if event.Category == category {
+			return true
+		}
+	}
+	return false
+}
+
+// EventHub fans newly published events out to live subscribers, such as WebSocket clients. It is
+// in-process only: events published by another server instance are not seen.
+type EventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan models.Event]StreamFilter
+}
+
+// NewEventHub creates an empty hub
+func NewEventHub() *EventHub {
+	return &EventHub{subscribers: make(map[chan models.Event]StreamFilter)}
+}
+
+// Subscribe follows events published from now on that match filter. The channel is closed by
+// unsubscribe, or by the hub if the subscriber falls subscriberBuffer events behind; a closed
+// channel the caller didn't unsubscribe from means it was too slow.
+func (h *EventHub) Subscribe(filter StreamFilter) (<-chan models.Event, func()) {
+	ch := make(chan models.Event, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = filter
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(ch)
+	}
+	return ch, unsubscribe
+}
+
+// Subscribers returns the number of live subscribers
+func (h *EventHub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// Publish delivers event to every matching subscriber without blocking. A subscriber whose buffer
+// is full is dropped rather than holding up the pipeline or other subscribers.
+func (h *EventHub) Publish(event models.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, filter := range h.subscribers {
+		if !filter.Matches(&event) {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+			h.remove(ch)
+		}
+	}
+}
+
+// remove unregisters a subscriber and closes its channel. Callers must hold h.mu.
+func (h *EventHub) remove(ch chan models.Event) {
+	if _, ok := h.subscribers[ch]; !ok {
+		return
+	}
+	delete(h.subscribers, ch)
+	close(ch)
+}
@@ -0,0 +1,111 @@
+package eventmanager
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/config"
+	"github.com/STRATINT/stratint/internal/ingestion"
+	"github.com/STRATINT/stratint/internal/logging"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestStreamFilter_Matches(t *testing.T) {
+	event := &models.Event{Category: models.CategoryMilitary, Magnitude: 6}
+
+	tests := []struct {
+		name   string
+		filter StreamFilter
+		want   bool
+	}{
+		{"empty filter", StreamFilter{}, true},
+		{"matching category", StreamFilter{Categories: []models.Category{models.CategoryCyber, models.CategoryMilitary}}, true},
+		{"other category", StreamFilter{Categories: []models.Category{models.CategoryCyber}}, false},
+		{"magnitude at minimum", StreamFilter{MinMagnitude: 6}, true},
+		{"magnitude below minimum", StreamFilter{MinMagnitude: 6.5}, false},
+		{"default workspace", StreamFilter{WorkspaceID: models.DefaultWorkspace}, true},
+		{"other workspace", StreamFilter{WorkspaceID: "acme"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(event); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventHub_Publish(t *testing.T) {
+	hub := NewEventHub()
+	military, unsubscribeMilitary := hub.Subscribe(StreamFilter{Categories: []models.Category{models.CategoryMilitary}})
+	defer unsubscribeMilitary()
+	all, unsubscribeAll := hub.Subscribe(StreamFilter{})
+
+	hub.Publish(models.Event{ID: "evt-1", Category: models.CategoryCyber})
+	hub.Publish(models.Event{ID: "evt-2", Category: models.CategoryMilitary})
+
+	if got := (<-military).ID; got != "evt-2" {
+		t.Errorf("military subscriber got %s, want evt-2", got)
+	}
+	if len(military) != 0 {
+		t.Errorf("military subscriber has %d extra events", len(military))
+	}
+	if got := len(all); got != 2 {
+		t.Errorf("unfiltered subscriber has %d events, want 2", got)
+	}
+
+	unsubscribeAll()
+	unsubscribeAll() // Safe to call twice
+	if hub.Subscribers() != 1 {
+		t.Errorf("Subscribers() = %d, want 1", hub.Subscribers())
+	}
+}
+
+func TestEventHub_DropsSlowSubscriber(t *testing.T) {
+	hub := NewEventHub()
+	slow, unsubscribeSlow := hub.Subscribe(StreamFilter{})
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := hub.Subscribe(StreamFilter{})
+	defer unsubscribeFast()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		hub.Publish(models.Event{ID: "evt"})
+		<-fast
+	}
+
+	if hub.Subscribers() != 1 {
+		t.Fatalf("Subscribers() = %d, want the slow subscriber dropped", hub.Subscribers())
+	}
+	for i := 0; i < subscriberBuffer; i++ {
+		<-slow
+	}
+	if _, open := <-slow; open {
+		t.Error("slow subscriber's channel should be closed after its buffered events")
+	}
+}
+
+func TestEventLifecycleManager_NotifiesPublished(t *testing.T) {
+	eventRepo := ingestion.NewMemoryEventRepository()
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+	manager := NewEventLifecycleManager(nil, eventRepo, nil, newMockThresholdRepository(), nil, nil, logger, DefaultLifecycleConfig())
+	ctx := context.Background()
+
+	events, unsubscribe := manager.EventHub().Subscribe(StreamFilter{})
+	defer unsubscribe()
+
+	eventRepo.Create(ctx, models.Event{ID: "evt-1", Title: "Test Event", Magnitude: 5, Status: models.EventStatusRejected})
+	if err := manager.PublishEvent(ctx, "evt-1", "admin", ""); err != nil {
+		t.Fatalf("PublishEvent failed: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.ID != "evt-1" || event.Status != models.EventStatusPublished {
+			t.Errorf("got event %s with status %s, want published evt-1", event.ID, event.Status)
+		}
+	default:
+		t.Fatal("published event was not sent to the hub")
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/config"
 	"github.com/STRATINT/stratint/internal/enrichment"
 	"github.com/STRATINT/stratint/internal/ingestion"
 	"github.com/STRATINT/stratint/internal/models"
@@ -23,10 +27,16 @@ type EventLifecycleManager struct {
 	eventRepo     ingestion.EventRepository
 	enricher      enrichment.Enricher
 	correlator    *enrichment.EventCorrelator
+	titleMatcher  TitleMatcher
+	embedder      enrichment.Embedder
+	vectorMatcher VectorMatcher
 	scorer        *enrichment.ConfidenceScorer
+	estimator     *enrichment.MagnitudeEstimator
 	thresholdRepo ThresholdRepository
 	twitterPoster TwitterPoster
 	activityRepo  ActivityLogger
+	statusHistory StatusHistoryRepository
+	hub           *EventHub
 	config        LifecycleConfig
 	logger        *slog.Logger
 }
@@ -36,6 +46,34 @@ type ActivityLogger interface {
 	Log(ctx context.Context, log models.ActivityLog) error
 }
 
+// StatusHistoryRepository defines the interface for the event status audit trail.
+type StatusHistoryRepository interface {
+	Record(ctx context.Context, change models.EventStatusChange) error
+	ListByEvent(ctx context.Context, eventID string) ([]models.EventStatusChange, error)
+}
+
+// TitleMatcher finds recent events with near-duplicate titles. Event repositories that
+// implement it enable title deduplication in ProcessEvent.
+type TitleMatcher interface {
+	// FindSimilarTitle returns the ID and similarity (0-1) of the non-archived event since the given
+	// time whose title is most similar to title, or "" if none reaches minSimilarity.
+	FindSimilarTitle(ctx context.Context, title string, since time.Time, minSimilarity float64) (string, float64, error)
+}
+
+// VectorMatcher stores event embeddings and finds the nearest ones. Event repositories that
+// implement it, with an enricher that implements enrichment.Embedder, enable the semantic
+// pre-filter in ProcessEvent.
+type VectorMatcher interface {
+	StoreEmbedding(ctx context.Context, eventID string, embedding []float32) error
+	// FindSimilarEmbeddings returns up to limit non-archived events since the given time whose
+	// embeddings are at least minSimilarity similar to embedding, most similar first.
+	FindSimilarEmbeddings(ctx context.Context, embedding []float32, since time.Time, limit int, minSimilarity float64) ([]models.SimilarEvent, error)
+}
+
+// ErrSemanticSearchUnavailable is returned for semantic queries when there is no embedder or
+// vector-capable event repository, or the database lacks pgvector.
+var ErrSemanticSearchUnavailable = models.ErrSemanticSearchUnavailable
+
 // ThresholdRepository defines the interface for threshold configuration storage.
 type ThresholdRepository interface {
 	Get(ctx context.Context) (*models.ThresholdConfig, error)
@@ -47,20 +85,53 @@ type LifecycleConfig struct {
 	MinConfidence float64       // Minimum confidence to publish
 	MinMagnitude  float64       // Minimum magnitude to publish
 	MaxAge        time.Duration // Maximum age of source to consider (0 = no limit)
-	MinSources    int           // Minimum number of sources required
+	MinSources    int           // Minimum number of sources required, unless set in the threshold config
 	AutoPublish   bool          // Automatically publish events that meet criteria
 	BatchSize     int           // Batch size for processing
+
+	// New events whose title is at least this similar to a recent event's are merged into it
+	// instead of being created (0 disables). Requires an event repository implementing TitleMatcher.
+	TitleSimilarity  float64
+	TitleDedupWindow time.Duration // How far back to look for near-duplicate titles
+
+	// New events are embedded and compared with up to EmbeddingCandidates recent events at least
+	// EmbeddingSimilarity similar (0 candidates disables). The correlator decides whether to merge;
+	// without one, the nearest event is merged if it reaches EmbeddingMergeSimilarity. Requires an
+	// enricher implementing enrichment.Embedder and an event repository implementing VectorMatcher.
+	EmbeddingCandidates      int
+	EmbeddingSimilarity      float64
+	EmbeddingMergeSimilarity float64
+	EmbeddingWindow          time.Duration // How far back to look for similar events
+
+	// Semantic search (semantic_query) leaves out events less similar to the query than this.
+	// Queries are short, so matches score well below the merge thresholds above.
+	SemanticSearchSimilarity float64
 }
 
 // DefaultLifecycleConfig returns sensible defaults.
 func DefaultLifecycleConfig() LifecycleConfig {
-	return LifecycleConfig{
+	c := LifecycleConfig{
 		MinConfidence: 0.30,
 		MinMagnitude:  1.0,
 		MinSources:    1,
 		AutoPublish:   true,
 		BatchSize:     50,
 	}
+	// Deduplication and similarity defaults live with the environment settings that override them
+	c.ApplyEventsConfig(config.DefaultEventsConfig())
+	return c
+}
+
+// ApplyEventsConfig overrides the deduplication and similarity settings with those loaded by
+// config.Load.
+func (c *LifecycleConfig) ApplyEventsConfig(events config.EventsConfig) {
+	c.TitleSimilarity = events.TitleSimilarity
+	c.TitleDedupWindow = events.TitleDedupWindow
+	c.EmbeddingCandidates = events.EmbeddingCandidates
+	c.EmbeddingSimilarity = events.EmbeddingSimilarity
+	c.EmbeddingMergeSimilarity = events.EmbeddingMergeSimilarity
+	c.EmbeddingWindow = events.EmbeddingWindow
+	c.SemanticSearchSimilarity = events.SemanticSearchSimilarity
 }
 
 // NewEventLifecycleManager creates a new lifecycle manager.
@@ -87,21 +158,84 @@ func NewEventLifecycleManager(
 	} else {
 		logger.Warn("enricher does not support correlation - events will not be deduplicated")
 	}
+	if scorer == nil {
+		// Merged events are re-scored with all their sources
+		scorer = enrichment.NewConfidenceScorer()
+	}
+
+	titleMatcher, _ := eventRepo.(TitleMatcher)
+	embedder, _ := enricher.(enrichment.Embedder)
+	vectorMatcher, _ := eventRepo.(VectorMatcher)
 
 	return &EventLifecycleManager{
 		sourceRepo:    sourceRepo,
 		eventRepo:     eventRepo,
 		enricher:      enricher,
 		correlator:    correlator,
+		titleMatcher:  titleMatcher,
+		embedder:      embedder,
+		vectorMatcher: vectorMatcher,
 		scorer:        scorer,
+		estimator:     enrichment.NewMagnitudeEstimator(),
 		thresholdRepo: thresholdRepo,
 		twitterPoster: twitterPoster,
 		activityRepo:  activityRepo,
+		hub:           NewEventHub(),
 		config:        config,
 		logger:        logger,
 	}
 }
 
+// SetStatusHistoryRepository enables recording every event status transition.
+func (m *EventLifecycleManager) SetStatusHistoryRepository(repo StatusHistoryRepository) {
+	m.statusHistory = repo
+}
+
+// EventHub returns the hub that live subscribers use to receive newly published events.
+func (m *EventLifecycleManager) EventHub() *EventHub {
+	return m.hub
+}
+
+// notifyPublished hands a newly published event to live subscribers, marked breaking as it
+// would be when served.
+func (m *EventLifecycleManager) notifyPublished(ctx context.Context, event models.Event) {
+	if m.hub.Subscribers() == 0 {
+		return
+	}
+	event.IsBreaking = m.breakingThresholds(ctx).IsBreaking(&event, time.Now())
+	m.hub.Publish(event)
+}
+
+// recordStatusChange writes a status transition to the audit trail. Failures are logged
+// rather than returned so that auditing never blocks the pipeline.
+func (m *EventLifecycleManager) recordStatusChange(ctx context.Context, eventID string, oldStatus *models.EventStatus, newStatus models.EventStatus, actor, reason string) {
+	if m.statusHistory == nil {
+		return
+	}
+
+	change := models.EventStatusChange{
+		EventID:   eventID,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+		Actor:     actor,
+		Reason:    reason,
+	}
+	if err := m.statusHistory.Record(ctx, change); err != nil {
+		m.logger.Error("failed to record event status change",
+			"event_id", eventID,
+			"new_status", newStatus,
+			"error", err)
+	}
+}
+
+// GetStatusHistory returns an event's status transitions, oldest first.
+func (m *EventLifecycleManager) GetStatusHistory(ctx context.Context, eventID string) ([]models.EventStatusChange, error) {
+	if m.statusHistory == nil {
+		return []models.EventStatusChange{}, nil
+	}
+	return m.statusHistory.ListByEvent(ctx, eventID)
+}
+
 // ProcessScrapedSources processes already-stored sources that have been scraped.
 // This is used after the scraping service has updated sources to "completed" status.
 func (m *EventLifecycleManager) ProcessScrapedSources(ctx context.Context, limit int) (ProcessResult, error) {
@@ -351,6 +485,10 @@ func (m *EventLifecycleManager) ProcessSources(ctx context.Context, sources []mo
 // ProcessEvent handles a single event through its lifecycle.
 // It checks for duplicates, performs correlation, applies thresholds, and saves the event.
 func (m *EventLifecycleManager) ProcessEvent(ctx context.Context, event *models.Event) error {
+	// Duplicate lookups, merges and thresholds only consider the event's own workspace
+	event.WorkspaceID = models.WorkspaceOrDefault(event.WorkspaceID)
+	ctx = models.ContextWithWorkspace(ctx, event.WorkspaceID)
+
 	m.logger.Debug("ProcessEvent: Entered",
 		"event_id", event.ID,
 		"title", event.Title,
@@ -379,83 +517,19 @@ func (m *EventLifecycleManager) ProcessEvent(ctx context.Context, event *models.
 	}
 	m.logger.Debug("ProcessEvent: Event is new, will check correlation", "event_id", event.ID)
 
-	// TEMPORARILY DISABLED: Check for similar events using OpenAI-based correlation (if available)
-	// This was making 50+ OpenAI calls per event, causing 2-minute delays
-	if false && m.correlator != nil {
-		m.logger.Debug("ProcessEvent: Correlator available, checking for similar events", "event_id", event.ID)
-		// Get recent events for correlation analysis
-		since := time.Now().Add(-7 * 24 * time.Hour)
-		query := models.EventQuery{
-			Since: &since,
-			Limit: 100,
-			Page:  1,
-		}
-
-		resp, err := m.eventRepo.Query(ctx, query)
-		if err != nil {
-			m.logger.Debug("ProcessEvent: Failed to query events for correlation",
-				"event_id", event.ID,
-				"error", err,
-				"error_type", fmt.Sprintf("%T", err))
-		} else if resp != nil && len(resp.Events) > 0 {
-			m.logger.Debug("ProcessEvent: Found existing events for correlation",
-				"event_id", event.ID,
-				"existing_events_count", len(resp.Events))
+	// Semantic pre-filter: only the nearest recent events by embedding are considered for a merge
+	merged, embedding, err := m.mergeSimilarEmbedding(ctx, event)
+	if err != nil {
+		m.logger.Warn("embedding similarity check failed, continuing", "event_id", event.ID, "error", err)
+	} else if merged {
+		return nil
+	}
 
-			// Find best matching event using OpenAI
-			bestMatch, corrResult, err := m.correlator.FindBestMatch(ctx, event.Sources[0], resp.Events)
-			if err != nil {
-				m.logger.Debug("ProcessEvent: Correlation analysis failed",
-					"event_id", event.ID,
-					"error", err)
-			} else if bestMatch != nil && corrResult.ShouldMerge {
-				m.logger.Debug("ProcessEvent: Found similar event, will merge",
-					"new_event_id", event.ID,
-					"existing_event_id", bestMatch.ID,
-					"similarity", corrResult.Similarity,
-					"should_merge", corrResult.ShouldMerge,
-					"has_novel_facts", corrResult.HasNovelFacts,
-					"novel_fact_count", len(corrResult.NovelFacts),
-				)
-
-				// Add source to existing event (merge operation)
-				bestMatch.Sources = append(bestMatch.Sources, event.Sources...)
-
-				// If this source contains novel facts, create a separate event for them
-				if corrResult.HasNovelFacts && len(corrResult.NovelFacts) > 0 {
-					m.logger.Debug("ProcessEvent: Creating novel facts event",
-						"event_id", event.ID,
-						"related_to", bestMatch.ID)
-					if err := m.createNovelFactsEvent(ctx, event, bestMatch, corrResult); err != nil {
-						m.logger.Debug("ProcessEvent: Failed to create novel facts event",
-							"error", err,
-							"original_event_id", bestMatch.ID,
-						)
-						// Continue with merge even if novel facts event creation fails
-					}
-				}
-
-				// Update the existing event with merged sources
-				m.logger.Debug("ProcessEvent: Updating existing event with merged sources",
-					"existing_event_id", bestMatch.ID,
-					"source_count", len(bestMatch.Sources))
-				return m.eventRepo.Update(ctx, *bestMatch)
-			} else {
-				m.logger.Debug("ProcessEvent: No similar events found or merge not needed",
-					"event_id", event.ID,
-					"best_match_id", func() string {
-						if bestMatch != nil {
-							return bestMatch.ID
-						}
-						return "none"
-					}(),
-					"should_merge", corrResult != nil && corrResult.ShouldMerge)
-			}
-		} else {
-			m.logger.Debug("ProcessEvent: No existing events for correlation", "event_id", event.ID)
-		}
-	} else {
-		m.logger.Debug("ProcessEvent: No correlator available, skipping similarity check", "event_id", event.ID)
+	// Cheap alternative to correlation: merge syndicated coverage with near-identical titles
+	if merged, err := m.mergeDuplicateTitle(ctx, event); err != nil {
+		m.logger.Warn("title deduplication failed, creating event", "event_id", event.ID, "error", err)
+	} else if merged {
+		return nil
 	}
 
 	// New event - evaluate for publication
@@ -464,14 +538,16 @@ func (m *EventLifecycleManager) ProcessEvent(ctx context.Context, event *models.
 		"auto_publish", m.config.AutoPublish,
 		"current_status", event.Status)
 
-	shouldPub := m.shouldPublish(event)
+	shouldPub := m.shouldPublish(ctx, event)
 	m.logger.Debug("ProcessEvent: shouldPublish result",
 		"event_id", event.ID,
 		"should_publish", shouldPub,
 		"auto_publish", m.config.AutoPublish)
 
+	var statusReason string
 	if m.config.AutoPublish && shouldPub {
 		event.Status = models.EventStatusPublished
+		statusReason = "met publication thresholds"
 		m.logger.Debug("ProcessEvent: Event marked as PUBLISHED",
 			"event_id", event.ID,
 			"magnitude", event.Magnitude,
@@ -482,12 +558,18 @@ func (m *EventLifecycleManager) ProcessEvent(ctx context.Context, event *models.
 		m.tryPostToTwitter(ctx, event)
 	} else {
 		event.Status = models.EventStatusRejected
-		reason := m.rejectionReason(event)
+		if shouldPub {
+			statusReason = "auto-publish disabled"
+		} else {
+			statusReason = m.rejectionReason(ctx, event)
+		}
+		event.RejectionReason = statusReason
+		event.RejectionThresholds = m.rejectionThresholds(ctx, event)
 		m.logger.Debug("ProcessEvent: Event marked as REJECTED",
 			"event_id", event.ID,
 			"magnitude", event.Magnitude,
 			"confidence", event.Confidence.Score,
-			"reason", reason,
+			"reason", statusReason,
 			"status", event.Status)
 	}
 
@@ -511,9 +593,139 @@ func (m *EventLifecycleManager) ProcessEvent(ctx context.Context, event *models.
 		"event_id", event.ID,
 		"status", event.Status)
 
+	m.recordStatusChange(ctx, event.ID, nil, event.Status, models.StatusActorSystem, statusReason)
+	if event.Status == models.EventStatusPublished {
+		m.notifyPublished(ctx, *event)
+	}
+
+	if embedding != nil {
+		if err := m.vectorMatcher.StoreEmbedding(ctx, event.ID, embedding); err != nil {
+			m.logger.Warn("failed to store event embedding", "event_id", event.ID, "error", err)
+		}
+	}
+
 	return nil
 }
 
+// mergeSimilarEmbedding embeds the event's title and summary and looks up the nearest recent events.
+// With a correlator, those candidates go to the model to decide on a merge; without one, the
+// nearest is merged if it reaches EmbeddingMergeSimilarity. On merge the event takes the existing
+// event's ID and status, as in mergeDuplicateTitle. The embedding is returned so a new event can
+// be stored with it.
+func (m *EventLifecycleManager) mergeSimilarEmbedding(ctx context.Context, event *models.Event) (bool, []float32, error) {
+	if m.embedder == nil || m.vectorMatcher == nil || m.config.EmbeddingCandidates <= 0 {
+		return false, nil, nil
+	}
+
+	embedding, err := m.embedder.Embed(ctx, enrichment.EventEmbeddingText(event))
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to embed event: %w", err)
+	}
+
+	since := time.Now().Add(-m.config.EmbeddingWindow)
+	similar, err := m.vectorMatcher.FindSimilarEmbeddings(ctx, embedding, since, m.config.EmbeddingCandidates, m.config.EmbeddingSimilarity)
+	if err != nil {
+		return false, embedding, fmt.Errorf("failed to find similar events: %w", err)
+	}
+	if len(similar) == 0 {
+		return false, embedding, nil
+	}
+
+	candidates := make([]models.Event, 0, len(similar))
+	for _, s := range similar {
+		candidate, err := m.eventRepo.GetByID(ctx, s.ID)
+		if err != nil {
+			return false, embedding, fmt.Errorf("failed to get similar event: %w", err)
+		}
+		if candidate != nil {
+			candidates = append(candidates, *candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return false, embedding, nil
+	}
+
+	var match *models.Event
+	var corrResult *enrichment.CorrelationResult
+	if m.correlator != nil && len(event.Sources) > 0 {
+		match, corrResult, err = m.correlator.FindBestMatch(ctx, event.Sources[0], candidates)
+		if err != nil {
+			return false, embedding, fmt.Errorf("failed to correlate similar events: %w", err)
+		}
+	} else if similar[0].Similarity >= m.config.EmbeddingMergeSimilarity && candidates[0].ID == similar[0].ID {
+		match = &candidates[0]
+	}
+	if match == nil {
+		m.logger.Debug("no similar event to merge into",
+			"event_id", event.ID,
+			"candidates", len(candidates),
+			"nearest_similarity", similar[0].Similarity)
+		return false, embedding, nil
+	}
+
+	m.logger.Info("merging event into semantically similar event",
+		"event_id", event.ID,
+		"existing_event_id", match.ID,
+		"candidates", len(candidates),
+		"correlated", corrResult != nil,
+	)
+
+	// Keep details the existing event lacks as a separate, linked event
+	if corrResult != nil && corrResult.HasNovelFacts && len(corrResult.NovelFacts) > 0 {
+		if err := m.createNovelFactsEvent(ctx, event, match, corrResult); err != nil {
+			m.logger.Warn("failed to create novel facts event", "event_id", match.ID, "error", err)
+		}
+	}
+
+	if err := m.updateExistingEvent(ctx, match, event); err != nil {
+		return false, embedding, fmt.Errorf("failed to merge into event %s: %w", match.ID, err)
+	}
+
+	event.ID = match.ID
+	event.Status = match.Status
+	return true, embedding, nil
+}
+
+// mergeDuplicateTitle merges the event's sources into a recent event with a near-duplicate title,
+// if there is one. On merge the event takes the existing event's ID and status so callers link
+// its sources to the right event.
+func (m *EventLifecycleManager) mergeDuplicateTitle(ctx context.Context, event *models.Event) (bool, error) {
+	if m.titleMatcher == nil || m.config.TitleSimilarity <= 0 || event.Title == "" {
+		return false, nil
+	}
+
+	since := time.Now().Add(-m.config.TitleDedupWindow)
+	matchID, similarity, err := m.titleMatcher.FindSimilarTitle(ctx, event.Title, since, m.config.TitleSimilarity)
+	if err != nil {
+		return false, fmt.Errorf("failed to find similar titles: %w", err)
+	}
+	if matchID == "" {
+		return false, nil
+	}
+
+	existing, err := m.eventRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get matching event: %w", err)
+	}
+	if existing == nil {
+		return false, nil
+	}
+
+	m.logger.Info("merging event into near-duplicate title",
+		"event_id", event.ID,
+		"existing_event_id", existing.ID,
+		"similarity", similarity,
+	)
+
+	if err := m.updateExistingEvent(ctx, existing, event); err != nil {
+		return false, fmt.Errorf("failed to merge into event %s: %w", existing.ID, err)
+	}
+
+	event.ID = existing.ID
+	event.Status = existing.Status
+	return true, nil
+}
+
 // createNovelFactsEvent creates a separate event containing only novel facts.
 // This is called when a source is merged with an existing event but contains new information.
 func (m *EventLifecycleManager) createNovelFactsEvent(
@@ -564,11 +776,15 @@ func (m *EventLifecycleManager) createNovelFactsEvent(
 		Status:     models.EventStatusEnriched,
 		Magnitude:  existingEvent.Magnitude * 0.7, // Slightly lower magnitude as it's supplementary
 		Confidence: confidence,
+
+		WorkspaceID: originalEvent.WorkspaceID,
 	}
 
 	// Evaluate if this novel facts event should be published
-	if m.config.AutoPublish && m.shouldPublish(novelEvent) {
+	var statusReason string
+	if m.config.AutoPublish && m.shouldPublish(ctx, novelEvent) {
 		novelEvent.Status = models.EventStatusPublished
+		statusReason = "met publication thresholds"
 		m.logger.Info("novel facts event published",
 			"novel_event_id", novelEvent.ID,
 			"related_event_id", existingEvent.ID,
@@ -579,10 +795,13 @@ func (m *EventLifecycleManager) createNovelFactsEvent(
 		m.tryPostToTwitter(ctx, novelEvent)
 	} else {
 		novelEvent.Status = models.EventStatusRejected
+		statusReason = m.rejectionReason(ctx, novelEvent)
+		novelEvent.RejectionReason = statusReason
+		novelEvent.RejectionThresholds = m.rejectionThresholds(ctx, novelEvent)
 		m.logger.Debug("novel facts event rejected",
 			"novel_event_id", novelEvent.ID,
 			"related_event_id", existingEvent.ID,
-			"reason", m.rejectionReason(novelEvent),
+			"reason", statusReason,
 		)
 	}
 
@@ -591,6 +810,11 @@ func (m *EventLifecycleManager) createNovelFactsEvent(
 		return fmt.Errorf("failed to create novel facts event: %w", err)
 	}
 
+	m.recordStatusChange(ctx, novelEvent.ID, nil, novelEvent.Status, models.StatusActorSystem, statusReason)
+	if novelEvent.Status == models.EventStatusPublished {
+		m.notifyPublished(ctx, *novelEvent)
+	}
+
 	m.logger.Info("created novel facts event",
 		"novel_event_id", novelEvent.ID,
 		"related_event_id", existingEvent.ID,
@@ -602,7 +826,7 @@ func (m *EventLifecycleManager) createNovelFactsEvent(
 
 // shouldPublish determines if an event meets publication criteria.
 // Reads thresholds from database to allow runtime updates.
-func (m *EventLifecycleManager) shouldPublish(event *models.Event) bool {
+func (m *EventLifecycleManager) shouldPublish(ctx context.Context, event *models.Event) bool {
 	m.logger.Debug("shouldPublish: Evaluating event",
 		"event_id", event.ID,
 		"confidence", event.Confidence.Score,
@@ -610,7 +834,7 @@ func (m *EventLifecycleManager) shouldPublish(event *models.Event) bool {
 		"sources", len(event.Sources))
 
 	// Read thresholds from database
-	thresholds, err := m.thresholdRepo.Get(context.Background())
+	thresholds, err := m.eventThresholds(ctx, event)
 	if err != nil {
 		m.logger.Debug("shouldPublish: Failed to get thresholds, using defaults",
 			"event_id", event.ID,
@@ -627,7 +851,7 @@ func (m *EventLifecycleManager) shouldPublish(event *models.Event) bool {
 		"event_id", event.ID,
 		"min_confidence", thresholds.MinConfidence,
 		"min_magnitude", thresholds.MinMagnitude,
-		"min_sources", m.config.MinSources,
+		"min_sources", m.minSources(thresholds),
 		"max_age_hours", thresholds.MaxSourceAgeHours)
 
 	if event.Confidence.Score < thresholds.MinConfidence {
@@ -646,11 +870,18 @@ func (m *EventLifecycleManager) shouldPublish(event *models.Event) bool {
 		return false
 	}
 
-	if len(event.Sources) < m.config.MinSources {
+	if len(event.Sources) < m.minSources(thresholds) {
 		m.logger.Debug("shouldPublish: Failed sources check",
 			"event_id", event.ID,
 			"event_sources", len(event.Sources),
-			"min_sources", m.config.MinSources)
+			"min_sources", m.minSources(thresholds))
+		return false
+	}
+
+	if reason := sourceDiversityFailure(event.Sources, thresholds); reason != "" {
+		m.logger.Debug("shouldPublish: Failed source diversity check",
+			"event_id", event.ID,
+			"reason", reason)
 		return false
 	}
 
@@ -686,10 +917,15 @@ func (m *EventLifecycleManager) tryPostToTwitter(ctx context.Context, event *mod
 	go m.twitterPoster.TryPostTweetForEvent(context.Background(), event)
 }
 
+// eventThresholds reads the publication thresholds of the event's workspace
+func (m *EventLifecycleManager) eventThresholds(ctx context.Context, event *models.Event) (*models.ThresholdConfig, error) {
+	return m.thresholdRepo.Get(models.ContextWithWorkspace(ctx, event.WorkspaceID))
+}
+
 // rejectionReason returns a human-readable rejection reason.
-func (m *EventLifecycleManager) rejectionReason(event *models.Event) string {
+func (m *EventLifecycleManager) rejectionReason(ctx context.Context, event *models.Event) string {
 	// Read thresholds from database
-	thresholds, err := m.thresholdRepo.Get(context.Background())
+	thresholds, err := m.eventThresholds(ctx, event)
 	if err != nil {
 		return "failed to get thresholds"
 	}
@@ -702,8 +938,12 @@ func (m *EventLifecycleManager) rejectionReason(event *models.Event) string {
 		return fmt.Sprintf("magnitude %.1f < %.1f", event.Magnitude, thresholds.MinMagnitude)
 	}
 
-	if len(event.Sources) < m.config.MinSources {
-		return fmt.Sprintf("sources %d < %d", len(event.Sources), m.config.MinSources)
+	if minSources := m.minSources(thresholds); len(event.Sources) < minSources {
+		return fmt.Sprintf("sources %d < %d", len(event.Sources), minSources)
+	}
+
+	if reason := sourceDiversityFailure(event.Sources, thresholds); reason != "" {
+		return reason
 	}
 
 	// Check source age if MaxSourceAgeHours is set
@@ -721,6 +961,67 @@ func (m *EventLifecycleManager) rejectionReason(event *models.Event) string {
 	return "unknown"
 }
 
+// rejectionThresholds snapshots the thresholds the event is currently judged against,
+// or returns nil if they can't be read.
+func (m *EventLifecycleManager) rejectionThresholds(ctx context.Context, event *models.Event) *models.RejectionThresholds {
+	thresholds, err := m.eventThresholds(ctx, event)
+	if err != nil {
+		return nil
+	}
+
+	return &models.RejectionThresholds{
+		MinConfidence:     thresholds.MinConfidence,
+		MinMagnitude:      thresholds.MinMagnitude,
+		MaxSourceAgeHours: thresholds.MaxSourceAgeHours,
+		MinSources:        m.minSources(thresholds),
+		MinSourceTypes:    thresholds.MinSourceTypes,
+		MinSourceDomains:  thresholds.MinSourceDomains,
+	}
+}
+
+// sourceDiversityFailure describes how sources fall short of the threshold config's source type
+// and domain diversity rules, or returns "" when they meet them (or the rules are off)
+func sourceDiversityFailure(sources []models.Source, thresholds *models.ThresholdConfig) string {
+	if thresholds.MinSourceTypes <= 0 && thresholds.MinSourceDomains <= 0 {
+		return ""
+	}
+
+	types := make(map[models.SourceType]bool)
+	domains := make(map[string]bool)
+	for _, source := range sources {
+		types[source.Type] = true
+		if domain := sourceDomain(source.URL); domain != "" {
+			domains[domain] = true
+		}
+	}
+
+	if thresholds.MinSourceTypes > 0 && len(types) < thresholds.MinSourceTypes {
+		return fmt.Sprintf("distinct source types %d < %d", len(types), thresholds.MinSourceTypes)
+	}
+	if thresholds.MinSourceDomains > 0 && len(domains) < thresholds.MinSourceDomains {
+		return fmt.Sprintf("distinct source domains %d < %d", len(domains), thresholds.MinSourceDomains)
+	}
+	return ""
+}
+
+// sourceDomain returns the lowercased host of a source URL without a leading "www."
+func sourceDomain(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// minSources is the number of sources an event needs to be published: the threshold config's,
+// or the lifecycle config's when the threshold config doesn't set one
+func (m *EventLifecycleManager) minSources(thresholds *models.ThresholdConfig) int {
+	if thresholds != nil && thresholds.MinSources > 0 {
+		return thresholds.MinSources
+	}
+	return m.config.MinSources
+}
+
 // updateExistingEvent handles updates to existing events.
 func (m *EventLifecycleManager) updateExistingEvent(ctx context.Context, existing, updated *models.Event) error {
 	// Merge sources
@@ -739,27 +1040,131 @@ func (m *EventLifecycleManager) updateExistingEvent(ctx context.Context, existin
 	}
 
 	// Update event with merged sources
+	promoted := false
+	previousScore := existing.Confidence.Score
 	existing.Sources = mergedSources
 	existing.UpdatedAt = time.Now()
 
-	// Recalculate if we have more sources now
-	if len(mergedSources) > m.config.MinSources {
-		existing.Confidence.SourceCount = len(mergedSources)
+	// Re-score with every source so corroboration raises confidence
+	existing.Confidence = m.scorer.ScoreSources(mergedSources, existing)
+	if existing.Confidence.Score != previousScore {
+		m.logger.Debug("re-scored merged event",
+			"event_id", existing.ID,
+			"source_count", len(mergedSources),
+			"previous_confidence", previousScore,
+			"confidence", existing.Confidence.Score)
+	}
 
-		// Re-evaluate publication status
-		if existing.Status == models.EventStatusRejected && m.shouldPublish(existing) {
-			existing.Status = models.EventStatusPublished
-			m.logger.Info("event promoted to published",
-				"event_id", existing.ID,
-				"source_count", len(mergedSources),
-			)
+	// Re-estimate magnitude with the merged content; a merge never downgrades an event
+	m.escalateMagnitude(ctx, existing, updated)
+
+	// Re-evaluate publication status
+	if existing.Status == models.EventStatusRejected && m.shouldPublish(ctx, existing) {
+		promoted = true
+		existing.Status = models.EventStatusPublished
+		existing.RejectionReason = ""
+		existing.RejectionThresholds = nil
+		m.logger.Info("event promoted to published",
+			"event_id", existing.ID,
+			"source_count", len(mergedSources),
+			"confidence", existing.Confidence.Score,
+		)
+
+		// Try to post to Twitter if enabled
+		m.tryPostToTwitter(ctx, existing)
+	}
+
+	if err := m.eventRepo.Update(ctx, *existing); err != nil {
+		return err
+	}
+
+	if promoted {
+		rejected := models.EventStatusRejected
+		m.recordStatusChange(ctx, existing.ID, &rejected, models.EventStatusPublished, models.StatusActorSystem,
+			fmt.Sprintf("met publication thresholds with %d sources (confidence %.2f)", len(mergedSources), existing.Confidence.Score))
+		m.notifyPublished(ctx, *existing)
+	}
+
+	return nil
+}
+
+// escalateMagnitude re-estimates the magnitude of existing after updated has been merged into it,
+// using their combined summaries, entities and tags, and keeps the higher of the old and new values.
+func (m *EventLifecycleManager) escalateMagnitude(ctx context.Context, existing, updated *models.Event) {
+	existing.Tags = mergeTags(existing.Tags, updated.Tags)
+	if m.estimator == nil {
+		return
+	}
+
+	merged := *existing
+	merged.Entities = mergeEntities(existing.Entities, updated.Entities)
+	if updated.Summary != "" && updated.Summary != existing.Summary {
+		merged.Summary = strings.TrimSpace(existing.Summary + " " + updated.Summary)
+	}
+
+	// Score against each source so engagement on any of them counts
+	estimate := 0.0
+	for _, source := range merged.Sources {
+		estimate = math.Max(estimate, m.estimator.Estimate(&merged, source))
+	}
+	if estimate <= existing.Magnitude {
+		return
+	}
+
+	previous := existing.Magnitude
+	existing.Magnitude = estimate
+	m.logger.Debug("merge escalated event magnitude",
+		"event_id", existing.ID,
+		"previous_magnitude", previous,
+		"magnitude", estimate)
+
+	thresholds, err := m.eventThresholds(ctx, existing)
+	if err != nil {
+		return
+	}
+	if previous < thresholds.MinMagnitude && estimate >= thresholds.MinMagnitude {
+		m.logger.Info("merge escalated magnitude past publication threshold",
+			"event_id", existing.ID,
+			"previous_magnitude", previous,
+			"magnitude", estimate,
+			"min_magnitude", thresholds.MinMagnitude)
+	}
+	if breakingMagnitude, _ := thresholds.BreakingCriteria(); previous < breakingMagnitude && estimate >= breakingMagnitude {
+		m.logger.Info("merge escalated magnitude past breaking threshold",
+			"event_id", existing.ID,
+			"previous_magnitude", previous,
+			"magnitude", estimate,
+			"breaking_min_magnitude", breakingMagnitude)
+	}
+}
 
-			// Try to post to Twitter if enabled
-			m.tryPostToTwitter(ctx, existing)
+// mergeTags returns the union of two tag lists, keeping the order of first appearance.
+func mergeTags(existing, added []string) []string {
+	seen := make(map[string]bool, len(existing)+len(added))
+	merged := make([]string, 0, len(existing)+len(added))
+	for _, tag := range append(append([]string{}, existing...), added...) {
+		if tag == "" || seen[tag] {
+			continue
 		}
+		seen[tag] = true
+		merged = append(merged, tag)
 	}
+	return merged
+}
 
-	return m.eventRepo.Update(ctx, *existing)
+// mergeEntities returns the union of two entity lists, matching entities by type and normalized name.
+func mergeEntities(existing, added []models.Entity) []models.Entity {
+	seen := make(map[string]bool, len(existing)+len(added))
+	merged := make([]models.Entity, 0, len(existing)+len(added))
+	for _, entity := range append(append([]models.Entity{}, existing...), added...) {
+		key := string(entity.Type) + ":" + strings.ToLower(strings.TrimSpace(entity.GetDisplayIdentifier()))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, entity)
+	}
+	return merged
 }
 
 // ProcessResult contains the outcome of processing a batch of sources.
@@ -773,7 +1178,8 @@ type ProcessResult struct {
 }
 
 // PublishEvent manually publishes a rejected event.
-func (m *EventLifecycleManager) PublishEvent(ctx context.Context, eventID string) error {
+// actor and reason are recorded in the event's status history.
+func (m *EventLifecycleManager) PublishEvent(ctx context.Context, eventID, actor, reason string) error {
 	event, err := m.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return fmt.Errorf("failed to get event: %w", err)
@@ -792,15 +1198,20 @@ func (m *EventLifecycleManager) PublishEvent(ctx context.Context, eventID string
 		return err
 	}
 
+	oldStatus := event.Status
+	m.recordStatusChange(ctx, eventID, &oldStatus, models.EventStatusPublished, actor, reason)
+
 	// Try to post to Twitter if enabled (after status is updated)
 	event.Status = models.EventStatusPublished
 	m.tryPostToTwitter(ctx, event)
+	m.notifyPublished(ctx, *event)
 
 	return nil
 }
 
 // RejectEvent manually rejects a published event.
-func (m *EventLifecycleManager) RejectEvent(ctx context.Context, eventID string) error {
+// actor and reason are recorded in the event's status history.
+func (m *EventLifecycleManager) RejectEvent(ctx context.Context, eventID, actor, reason string) error {
 	event, err := m.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return fmt.Errorf("failed to get event: %w", err)
@@ -814,12 +1225,41 @@ func (m *EventLifecycleManager) RejectEvent(ctx context.Context, eventID string)
 		return fmt.Errorf("event already rejected")
 	}
 
-	return m.eventRepo.UpdateStatus(ctx, eventID, models.EventStatusRejected)
+	// Save the reason on the event too, so it is shown alongside pipeline rejections
+	oldStatus := event.Status
+	rejectionReason := "rejected by " + actor
+	if reason != "" {
+		rejectionReason += ": " + reason
+	}
+	if err := m.eventRepo.Reject(ctx, eventID, rejectionReason); err != nil {
+		return err
+	}
+
+	m.recordStatusChange(ctx, eventID, &oldStatus, models.EventStatusRejected, actor, reason)
+
+	return nil
 }
 
 // ArchiveEvent moves an old event to archived status.
-func (m *EventLifecycleManager) ArchiveEvent(ctx context.Context, eventID string) error {
-	return m.eventRepo.UpdateStatus(ctx, eventID, models.EventStatusArchived)
+// actor and reason are recorded in the event's status history.
+func (m *EventLifecycleManager) ArchiveEvent(ctx context.Context, eventID, actor, reason string) error {
+	event, err := m.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event == nil {
+		return fmt.Errorf("event not found: %s", eventID)
+	}
+
+	if err := m.eventRepo.UpdateStatus(ctx, eventID, models.EventStatusArchived); err != nil {
+		return err
+	}
+
+	oldStatus := event.Status
+	m.recordStatusChange(ctx, eventID, &oldStatus, models.EventStatusArchived, actor, reason)
+
+	return nil
 }
 
 // GetPublishedEvents retrieves published events with filtering.
@@ -830,7 +1270,21 @@ func (m *EventLifecycleManager) GetPublishedEvents(ctx context.Context, query mo
 		query.Status = &published
 	}
 
-	return m.eventRepo.Query(ctx, query)
+	if err := m.embedSemanticQuery(ctx, &query); err != nil {
+		return nil, err
+	}
+
+	thresholds := m.breakingThresholds(ctx)
+	now := time.Now()
+	query.ApplyBreaking(thresholds, now)
+
+	resp, err := m.eventRepo.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	markBreaking(resp.Events, thresholds, now)
+
+	return resp, nil
 }
 
 // GetStats returns lifecycle statistics.
@@ -884,14 +1338,26 @@ func (m *EventLifecycleManager) GetEvents(query models.EventQuery) ([]models.Eve
 		return nil, fmt.Errorf("invalid query: %w", err)
 	}
 
+	// Queries carry their workspace; breaking thresholds come from the same one
+	ctx := models.ContextWithWorkspace(context.Background(), query.WorkspaceID)
+	if err := m.embedSemanticQuery(ctx, &query); err != nil {
+		return nil, err
+	}
+
+	thresholds := m.breakingThresholds(ctx)
+	now := time.Now()
+	query.ApplyBreaking(thresholds, now)
+
 	m.logger.Debug("querying events",
 		"limit", query.Limit,
 		"page", query.Page,
 		"categories", len(query.Categories),
+		"breaking", query.Breaking,
+		"semantic", query.SemanticQuery != "",
 	)
 
 	// Query events from repository
-	resp, err := m.eventRepo.Query(context.Background(), query)
+	resp, err := m.eventRepo.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query events: %w", err)
 	}
@@ -901,18 +1367,66 @@ func (m *EventLifecycleManager) GetEvents(query models.EventQuery) ([]models.Eve
 		"total", resp.Total,
 	)
 
+	markBreaking(resp.Events, thresholds, now)
+
 	return resp.Events, nil
 }
 
+// embedSemanticQuery embeds the query's semantic_query text so the repository can rank events by
+// similarity to it. It returns ErrSemanticSearchUnavailable if embeddings aren't supported.
+func (m *EventLifecycleManager) embedSemanticQuery(ctx context.Context, query *models.EventQuery) error {
+	if query.SemanticQuery == "" {
+		return nil
+	}
+	if m.embedder == nil || m.vectorMatcher == nil {
+		return ErrSemanticSearchUnavailable
+	}
+
+	embedding, err := m.embedder.Embed(ctx, query.SemanticQuery)
+	if err != nil {
+		return fmt.Errorf("failed to embed semantic query: %w", err)
+	}
+	query.Embedding = embedding
+	query.MinSimilarity = m.config.SemanticSearchSimilarity
+	return nil
+}
+
 // GetEventCount returns the total count of events matching the query.
 func (m *EventLifecycleManager) GetEventCount(query models.EventQuery) (int, error) {
 	// Note: We don't call Validate() here to allow counting without limit restrictions
-	return m.eventRepo.Count(context.Background(), query)
+	ctx := models.ContextWithWorkspace(context.Background(), query.WorkspaceID)
+	if query.Breaking {
+		query.ApplyBreaking(m.breakingThresholds(ctx), time.Now())
+	}
+	return m.eventRepo.Count(ctx, query)
 }
 
 // GetEventByID retrieves a specific event by its ID.
 func (m *EventLifecycleManager) GetEventByID(ctx context.Context, eventID string) (*models.Event, error) {
-	return m.eventRepo.GetByID(ctx, eventID)
+	event, err := m.eventRepo.GetByID(ctx, eventID)
+	if err != nil || event == nil {
+		return event, err
+	}
+	event.IsBreaking = m.breakingThresholds(ctx).IsBreaking(event, time.Now())
+	return event, nil
+}
+
+// breakingThresholds loads the threshold config for breaking checks. Nil (the defaults) when it
+// can't be read.
+func (m *EventLifecycleManager) breakingThresholds(ctx context.Context) *models.ThresholdConfig {
+	thresholds, err := m.thresholdRepo.Get(ctx)
+	if err != nil {
+		m.logger.Debug("failed to get thresholds, using default breaking criteria", "error", err)
+		return nil
+	}
+	return thresholds
+}
+
+// markBreaking sets IsBreaking on events being served
+func markBreaking(events []models.Event, thresholds *models.ThresholdConfig, now time.Time) {
+	for i := range events {
+		events[i].IsBreaking = thresholds.IsBreaking(&events[i], now)
+	}
 }
 
 // Source management methods
@@ -929,8 +1443,8 @@ func (m *EventLifecycleManager) GetSourceByID(ctx context.Context, id string) (*
 	return m.sourceRepo.GetByID(ctx, id)
 }
 
-// CreateSource creates a new source.
-func (m *EventLifecycleManager) CreateSource(ctx context.Context, source *models.Source) error {
+// CreateSource creates a new source. It returns false if the source duplicates an existing one.
+func (m *EventLifecycleManager) CreateSource(ctx context.Context, source *models.Source) (bool, error) {
 	// Set timestamps if not provided
 	if source.CreatedAt.IsZero() {
 		source.CreatedAt = time.Now()
@@ -944,7 +1458,8 @@ func (m *EventLifecycleManager) CreateSource(ctx context.Context, source *models
 
 // UpdateSource updates an existing source.
 func (m *EventLifecycleManager) UpdateSource(ctx context.Context, source *models.Source) error {
-	return m.sourceRepo.StoreRaw(ctx, *source)
+	_, err := m.sourceRepo.StoreRaw(ctx, *source)
+	return err
 }
 
 // DeleteSource deletes a source (note: this would need to be added to the repository interface).
@@ -2,7 +2,11 @@ package eventmanager
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -158,7 +162,7 @@ func TestEventLifecycleManager_ShouldPublish(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := manager.shouldPublish(tt.event)
+			result := manager.shouldPublish(context.Background(), tt.event)
 			if result != tt.expected {
 				t.Errorf("shouldPublish() = %v, want %v", result, tt.expected)
 			}
@@ -166,6 +170,193 @@ func TestEventLifecycleManager_ShouldPublish(t *testing.T) {
 	}
 }
 
+func TestEventLifecycleManager_ThresholdMinSources(t *testing.T) {
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+	thresholdRepo := newMockThresholdRepository()
+	thresholdRepo.cfg.MinSources = 2
+	manager := NewEventLifecycleManager(nil, nil, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+
+	event := &models.Event{
+		Confidence: models.Confidence{Score: 0.8},
+		Magnitude:  7.0,
+		Sources:    []models.Source{{ID: "src-1"}},
+	}
+	if manager.shouldPublish(context.Background(), event) {
+		t.Error("expected a single source to fail min_sources 2")
+	}
+	if reason := manager.rejectionReason(context.Background(), event); reason != "sources 1 < 2" {
+		t.Errorf("rejectionReason() = %q, want sources rejection", reason)
+	}
+
+	event.Sources = append(event.Sources, models.Source{ID: "src-2"})
+	if !manager.shouldPublish(context.Background(), event) {
+		t.Error("expected two sources to meet min_sources 2")
+	}
+}
+
+func TestEventLifecycleManager_ThresholdSourceDiversity(t *testing.T) {
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+	thresholdRepo := newMockThresholdRepository()
+	thresholdRepo.cfg.MinSourceTypes = 2
+	thresholdRepo.cfg.MinSourceDomains = 2
+	manager := NewEventLifecycleManager(nil, nil, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+
+	// Three tweets count as three sources but only one platform and one domain
+	event := &models.Event{
+		Confidence: models.Confidence{Score: 0.8},
+		Magnitude:  7.0,
+		Sources: []models.Source{
+			{ID: "src-1", Type: models.SourceTypeTwitter, URL: "https://x.com/acct/status/1"},
+			{ID: "src-2", Type: models.SourceTypeTwitter, URL: "https://x.com/acct/status/2"},
+			{ID: "src-3", Type: models.SourceTypeTwitter, URL: "https://x.com/acct/status/3"},
+		},
+	}
+	if manager.shouldPublish(context.Background(), event) {
+		t.Error("expected a single platform to fail min_source_types 2")
+	}
+	if reason := manager.rejectionReason(context.Background(), event); reason != "distinct source types 1 < 2" {
+		t.Errorf("rejectionReason() = %q, want source types rejection", reason)
+	}
+	if thresholds := manager.rejectionThresholds(context.Background(), event); thresholds.MinSourceTypes != 2 || thresholds.MinSourceDomains != 2 {
+		t.Errorf("expected diversity thresholds in the snapshot, got %+v", thresholds)
+	}
+
+	// A second platform on the same domain still fails the domain rule
+	event.Sources = append(event.Sources, models.Source{ID: "src-4", Type: models.SourceTypeNewsMedia, URL: "https://www.X.com/news"})
+	if reason := manager.rejectionReason(context.Background(), event); reason != "distinct source domains 1 < 2" {
+		t.Errorf("rejectionReason() = %q, want source domains rejection", reason)
+	}
+
+	event.Sources = append(event.Sources, models.Source{ID: "src-5", Type: models.SourceTypeNewsMedia, URL: "https://reuters.com/world"})
+	if !manager.shouldPublish(context.Background(), event) {
+		t.Error("expected two platforms and two domains to publish")
+	}
+
+	thresholdRepo.cfg.MinSourceTypes = 0
+	thresholdRepo.cfg.MinSourceDomains = 0
+	event.Sources = event.Sources[:1]
+	if !manager.shouldPublish(context.Background(), event) {
+		t.Error("expected diversity rules to be off at 0")
+	}
+}
+
+func TestEventLifecycleManager_CorroborationPromotes(t *testing.T) {
+	eventRepo := ingestion.NewMemoryEventRepository()
+	thresholdRepo := newMockThresholdRepository()
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+	manager := NewEventLifecycleManager(nil, eventRepo, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+
+	ctx := context.Background()
+	source := func(id, url string) models.Source {
+		return models.Source{
+			ID:          id,
+			Type:        models.SourceTypeNewsMedia,
+			URL:         url,
+			Credibility: 0.8,
+			PublishedAt: time.Now(),
+			RawContent:  "A detailed report on the overnight clash between border units, citing officials on both sides.",
+		}
+	}
+
+	existing := &models.Event{
+		ID:        "evt-1",
+		Title:     "Border Clash Reported",
+		Summary:   "Troops exchanged fire along the border overnight.",
+		Magnitude: 6.0,
+		Sources:   []models.Source{source("src-1", "https://reuters.com/a")},
+		Status:    models.EventStatusRejected,
+	}
+	single := enrichment.NewConfidenceScorer().ScoreSources(existing.Sources, existing)
+	existing.Confidence = single
+	// Just out of reach for one source, within reach once other outlets corroborate it
+	thresholdRepo.cfg.MinConfidence = single.Score + 0.05
+	if err := eventRepo.Create(ctx, *existing); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	update := &models.Event{
+		ID:      "evt-1",
+		Sources: []models.Source{source("src-2", "https://apnews.com/b"), source("src-3", "https://bbc.co.uk/c")},
+	}
+	if err := manager.updateExistingEvent(ctx, existing, update); err != nil {
+		t.Fatalf("updateExistingEvent failed: %v", err)
+	}
+
+	stored, _ := eventRepo.GetByID(ctx, "evt-1")
+	if stored.Confidence.Score <= single.Score {
+		t.Errorf("confidence %v should rise above single-source %v", stored.Confidence.Score, single.Score)
+	}
+	if stored.Confidence.SourceCount != 3 {
+		t.Errorf("SourceCount = %d, want 3", stored.Confidence.SourceCount)
+	}
+	if stored.Status != models.EventStatusPublished {
+		t.Errorf("Status = %s, want published after corroboration", stored.Status)
+	}
+}
+
+func TestEventLifecycleManager_MergeEscalatesMagnitude(t *testing.T) {
+	eventRepo := ingestion.NewMemoryEventRepository()
+	thresholdRepo := newMockThresholdRepository()
+	thresholdRepo.cfg.MinConfidence = 0
+	thresholdRepo.cfg.MinMagnitude = 7.0
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+	manager := NewEventLifecycleManager(nil, eventRepo, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+
+	ctx := context.Background()
+	source := func(id string) models.Source {
+		return models.Source{ID: id, Type: models.SourceTypeNewsMedia, URL: "https://example.com/" + id, Credibility: 0.8, PublishedAt: time.Now()}
+	}
+
+	existing := &models.Event{
+		ID:        "evt-1",
+		Title:     "Shelling Reported Near Border Town",
+		Summary:   "Local officials report shelling overnight.",
+		Category:  models.CategoryMilitary,
+		Magnitude: 6.0,
+		Tags:      []string{"border"},
+		Sources:   []models.Source{source("src-1")},
+		Status:    models.EventStatusRejected,
+	}
+	if err := eventRepo.Create(ctx, *existing); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	update := &models.Event{
+		ID:      "evt-1",
+		Summary: "Dozens killed as the attack widens into a major offensive.",
+		Tags:    []string{"border", "casualties"},
+		Entities: []models.Entity{
+			{Type: models.EntityTypeCountry, Name: "Country A"},
+			{Type: models.EntityTypeCountry, Name: "Country B"},
+		},
+		Sources: []models.Source{source("src-2")},
+	}
+	if err := manager.updateExistingEvent(ctx, existing, update); err != nil {
+		t.Fatalf("updateExistingEvent failed: %v", err)
+	}
+
+	stored, _ := eventRepo.GetByID(ctx, "evt-1")
+	if stored.Magnitude <= 6.0 {
+		t.Errorf("Magnitude = %v, want escalation above 6.0", stored.Magnitude)
+	}
+	if stored.Status != models.EventStatusPublished {
+		t.Errorf("Status = %s, want published once magnitude passes the threshold", stored.Status)
+	}
+	if len(stored.Tags) != 2 || stored.Tags[1] != "casualties" {
+		t.Errorf("Tags = %v, want merged [border casualties]", stored.Tags)
+	}
+
+	// A weaker corroborating source never lowers the magnitude
+	stored.Magnitude = 9.5
+	if err := manager.updateExistingEvent(ctx, stored, &models.Event{ID: "evt-1", Sources: []models.Source{source("src-3")}}); err != nil {
+		t.Fatalf("updateExistingEvent failed: %v", err)
+	}
+	stored, _ = eventRepo.GetByID(ctx, "evt-1")
+	if stored.Magnitude != 9.5 {
+		t.Errorf("Magnitude = %v, want 9.5 kept after merge", stored.Magnitude)
+	}
+}
+
 func TestEventLifecycleManager_PublishEvent(t *testing.T) {
 	sourceRepo := ingestion.NewMemorySourceRepository()
 	eventRepo := ingestion.NewMemoryEventRepository()
@@ -190,7 +381,7 @@ func TestEventLifecycleManager_PublishEvent(t *testing.T) {
 	eventRepo.Create(ctx, event)
 
 	// Manually publish it
-	err := manager.PublishEvent(ctx, "evt-1")
+	err := manager.PublishEvent(ctx, "evt-1", "admin", "")
 	if err != nil {
 		t.Fatalf("PublishEvent failed: %v", err)
 	}
@@ -226,7 +417,7 @@ func TestEventLifecycleManager_RejectEvent(t *testing.T) {
 	eventRepo.Create(ctx, event)
 
 	// Manually reject it
-	err := manager.RejectEvent(ctx, "evt-1")
+	err := manager.RejectEvent(ctx, "evt-1", "admin", "")
 	if err != nil {
 		t.Fatalf("RejectEvent failed: %v", err)
 	}
@@ -236,6 +427,334 @@ func TestEventLifecycleManager_RejectEvent(t *testing.T) {
 	if updated.Status != models.EventStatusRejected {
 		t.Errorf("Expected status rejected, got %v", updated.Status)
 	}
+	if updated.RejectionReason != "rejected by admin" {
+		t.Errorf("Expected no trailing separator without a reason, got %q", updated.RejectionReason)
+	}
+}
+
+// mockStatusHistory records status changes in memory
+type mockStatusHistory struct {
+	changes []models.EventStatusChange
+}
+
+func (m *mockStatusHistory) Record(ctx context.Context, change models.EventStatusChange) error {
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *mockStatusHistory) ListByEvent(ctx context.Context, eventID string) ([]models.EventStatusChange, error) {
+	var changes []models.EventStatusChange
+	for _, c := range m.changes {
+		if c.EventID == eventID {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+func TestEventLifecycleManager_StatusHistory(t *testing.T) {
+	sourceRepo := ingestion.NewMemorySourceRepository()
+	eventRepo := ingestion.NewMemoryEventRepository()
+	enricher := enrichment.NewMockEnricher()
+	thresholdRepo := newMockThresholdRepository()
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+
+	config := DefaultLifecycleConfig()
+	manager := NewEventLifecycleManager(sourceRepo, eventRepo, enricher, thresholdRepo, nil, nil, logger, config)
+	history := &mockStatusHistory{}
+	manager.SetStatusHistoryRepository(history)
+
+	ctx := context.Background()
+
+	// A low-magnitude event is rejected by the pipeline
+	event := &models.Event{
+		ID:         "evt-1",
+		Title:      "Test Event",
+		Confidence: models.Confidence{Score: 0.8},
+		Magnitude:  0.5,
+		Sources:    []models.Source{{ID: "src-1", PublishedAt: time.Now()}},
+		Status:     models.EventStatusEnriched,
+	}
+	if err := manager.ProcessEvent(ctx, event); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+
+	rejected, _ := eventRepo.GetByID(ctx, "evt-1")
+	if rejected.RejectionReason != "magnitude 0.5 < 1.0" {
+		t.Errorf("RejectionReason = %q, want magnitude rejection", rejected.RejectionReason)
+	}
+	if rejected.RejectionThresholds == nil || rejected.RejectionThresholds.MinMagnitude != 1.0 {
+		t.Errorf("RejectionThresholds = %+v, want snapshot with min magnitude 1.0", rejected.RejectionThresholds)
+	}
+
+	// An admin then publishes it
+	if err := manager.PublishEvent(ctx, "evt-1", "admin", "verified manually"); err != nil {
+		t.Fatalf("PublishEvent failed: %v", err)
+	}
+
+	republished, _ := eventRepo.GetByID(ctx, "evt-1")
+	if republished.RejectionReason != "" || republished.RejectionThresholds != nil {
+		t.Error("expected rejection reason to be cleared on publish")
+	}
+
+	changes, _ := manager.GetStatusHistory(ctx, "evt-1")
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 status changes, got %d", len(changes))
+	}
+
+	created := changes[0]
+	if created.OldStatus != nil || created.NewStatus != models.EventStatusRejected || created.Actor != models.StatusActorSystem {
+		t.Errorf("unexpected creation entry: %+v", created)
+	}
+	if created.Reason != "magnitude 0.5 < 1.0" {
+		t.Errorf("creation reason = %q, want magnitude rejection", created.Reason)
+	}
+
+	published := changes[1]
+	if published.OldStatus == nil || *published.OldStatus != models.EventStatusRejected {
+		t.Errorf("expected old status rejected, got %v", published.OldStatus)
+	}
+	if published.NewStatus != models.EventStatusPublished || published.Actor != "admin" || published.Reason != "verified manually" {
+		t.Errorf("unexpected publish entry: %+v", published)
+	}
+}
+
+// titleMatchingRepo adds exact-title matching to the in-memory event repository
+type titleMatchingRepo struct {
+	*ingestion.MemoryEventRepository
+	titles map[string]string // title -> event ID
+}
+
+func (r *titleMatchingRepo) Create(ctx context.Context, event models.Event) error {
+	r.titles[event.Title] = event.ID
+	return r.MemoryEventRepository.Create(ctx, event)
+}
+
+func (r *titleMatchingRepo) FindSimilarTitle(ctx context.Context, title string, since time.Time, minSimilarity float64) (string, float64, error) {
+	if id, ok := r.titles[title]; ok {
+		return id, 1.0, nil
+	}
+	return "", 0, nil
+}
+
+func TestEventLifecycleManager_TitleDedup(t *testing.T) {
+	sourceRepo := ingestion.NewMemorySourceRepository()
+	eventRepo := &titleMatchingRepo{MemoryEventRepository: ingestion.NewMemoryEventRepository(), titles: map[string]string{}}
+	enricher := enrichment.NewMockEnricher()
+	thresholdRepo := newMockThresholdRepository()
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+
+	lifecycleConfig := DefaultLifecycleConfig()
+	lifecycleConfig.TitleSimilarity = 0.6
+	manager := NewEventLifecycleManager(sourceRepo, eventRepo, enricher, thresholdRepo, nil, nil, logger, lifecycleConfig)
+
+	ctx := context.Background()
+	newEvent := func(id, sourceID string) *models.Event {
+		return &models.Event{
+			ID:         id,
+			Title:      "Explosion reported near port",
+			Confidence: models.Confidence{Score: 0.8},
+			Magnitude:  5.0,
+			Sources:    []models.Source{{ID: sourceID, PublishedAt: time.Now()}},
+			Status:     models.EventStatusEnriched,
+		}
+	}
+
+	if err := manager.ProcessEvent(ctx, newEvent("evt-1", "src-1")); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+
+	duplicate := newEvent("evt-2", "src-2")
+	if err := manager.ProcessEvent(ctx, duplicate); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+
+	if duplicate.ID != "evt-1" {
+		t.Errorf("merged event ID = %q, want evt-1", duplicate.ID)
+	}
+	if created, _ := eventRepo.GetByID(ctx, "evt-2"); created != nil {
+		t.Error("near-duplicate event should not have been created")
+	}
+
+	merged, _ := eventRepo.GetByID(ctx, "evt-1")
+	if len(merged.Sources) != 2 {
+		t.Errorf("expected 2 merged sources, got %d", len(merged.Sources))
+	}
+
+	// Disabling the threshold creates events as before
+	manager.config.TitleSimilarity = 0
+	if err := manager.ProcessEvent(ctx, newEvent("evt-3", "src-3")); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if created, _ := eventRepo.GetByID(ctx, "evt-3"); created == nil {
+		t.Error("expected event to be created with deduplication disabled")
+	}
+}
+
+// vectorMatchingRepo adds brute-force embedding search to the in-memory event repository
+type vectorMatchingRepo struct {
+	*ingestion.MemoryEventRepository
+	embeddings map[string][]float32
+}
+
+func (r *vectorMatchingRepo) StoreEmbedding(ctx context.Context, eventID string, embedding []float32) error {
+	r.embeddings[eventID] = embedding
+	return nil
+}
+
+func (r *vectorMatchingRepo) FindSimilarEmbeddings(ctx context.Context, embedding []float32, since time.Time, limit int, minSimilarity float64) ([]models.SimilarEvent, error) {
+	var similar []models.SimilarEvent
+	for id, e := range r.embeddings {
+		if sim := enrichment.CosineSimilarity(embedding, e); sim >= minSimilarity {
+			similar = append(similar, models.SimilarEvent{ID: id, Similarity: sim})
+		}
+	}
+	sort.Slice(similar, func(i, j int) bool { return similar[i].Similarity > similar[j].Similarity })
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
+
+// keywordEmbedder embeds text as one dimension per topic it mentions
+type keywordEmbedder struct {
+	calls int
+}
+
+func (e *keywordEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	e.calls++
+	text = strings.ToLower(text)
+	embedding := make([]float32, 3)
+	for i, topic := range []string{"port", "border", "election"} {
+		if strings.Contains(text, topic) {
+			embedding[i] = 1
+		}
+	}
+	return embedding, nil
+}
+
+func TestEventLifecycleManager_EmbeddingMerge(t *testing.T) {
+	eventRepo := &vectorMatchingRepo{MemoryEventRepository: ingestion.NewMemoryEventRepository(), embeddings: map[string][]float32{}}
+	thresholdRepo := newMockThresholdRepository()
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+
+	manager := NewEventLifecycleManager(nil, eventRepo, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+	embedder := &keywordEmbedder{}
+	manager.embedder = embedder
+
+	ctx := context.Background()
+	newEvent := func(id, title string) *models.Event {
+		return &models.Event{
+			ID:         id,
+			Title:      title,
+			Confidence: models.Confidence{Score: 0.8},
+			Magnitude:  5.0,
+			Sources:    []models.Source{{ID: "src-" + id, PublishedAt: time.Now()}},
+			Status:     models.EventStatusEnriched,
+		}
+	}
+
+	if err := manager.ProcessEvent(ctx, newEvent("evt-1", "Explosion reported near port")); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if _, ok := eventRepo.embeddings["evt-1"]; !ok {
+		t.Fatal("expected the new event's embedding to be stored")
+	}
+
+	// Different wording, same story: merged without a correlator as the vectors match exactly
+	reworded := newEvent("evt-2", "Blast rocks the port district")
+	if err := manager.ProcessEvent(ctx, reworded); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if reworded.ID != "evt-1" {
+		t.Errorf("merged event ID = %q, want evt-1", reworded.ID)
+	}
+	if merged, _ := eventRepo.GetByID(ctx, "evt-1"); merged == nil || len(merged.Sources) != 2 {
+		t.Errorf("expected evt-1 to have 2 sources after merge, got %+v", merged)
+	}
+	if _, ok := eventRepo.embeddings["evt-2"]; ok {
+		t.Error("merged event should not get its own embedding")
+	}
+
+	// Unrelated story is created
+	if err := manager.ProcessEvent(ctx, newEvent("evt-3", "Border clash overnight")); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if created, _ := eventRepo.GetByID(ctx, "evt-3"); created == nil {
+		t.Error("expected unrelated event to be created")
+	}
+
+	// Related but below the merge threshold is created too
+	if err := manager.ProcessEvent(ctx, newEvent("evt-4", "Port closed after border incident")); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if created, _ := eventRepo.GetByID(ctx, "evt-4"); created == nil {
+		t.Error("expected partially similar event to be created")
+	}
+
+	// Zero candidates disables the pre-filter entirely
+	manager.config.EmbeddingCandidates = 0
+	calls := embedder.calls
+	if err := manager.ProcessEvent(ctx, newEvent("evt-5", "Explosion reported near port")); err != nil {
+		t.Fatalf("ProcessEvent failed: %v", err)
+	}
+	if embedder.calls != calls {
+		t.Error("expected no embedding call with the pre-filter disabled")
+	}
+}
+
+// queryRecordingRepo records the last query passed to the event repository
+type queryRecordingRepo struct {
+	*vectorMatchingRepo
+	last models.EventQuery
+}
+
+func (r *queryRecordingRepo) Query(ctx context.Context, query models.EventQuery) (*models.EventResponse, error) {
+	r.last = query
+	return r.vectorMatchingRepo.Query(ctx, query)
+}
+
+func TestEventLifecycleManager_SemanticQuery(t *testing.T) {
+	eventRepo := &queryRecordingRepo{vectorMatchingRepo: &vectorMatchingRepo{MemoryEventRepository: ingestion.NewMemoryEventRepository(), embeddings: map[string][]float32{}}}
+	thresholdRepo := newMockThresholdRepository()
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+
+	manager := NewEventLifecycleManager(nil, eventRepo, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+	ctx := context.Background()
+
+	// No embedder: semantic queries are refused rather than silently ignored
+	query := models.EventQuery{SemanticQuery: "unrest near the port"}
+	if _, err := manager.GetPublishedEvents(ctx, query); !errors.Is(err, ErrSemanticSearchUnavailable) {
+		t.Fatalf("GetPublishedEvents error = %v, want ErrSemanticSearchUnavailable", err)
+	}
+
+	manager.embedder = &keywordEmbedder{}
+	if _, err := manager.GetPublishedEvents(ctx, query); err != nil {
+		t.Fatalf("GetPublishedEvents failed: %v", err)
+	}
+	if want := []float32{1, 0, 0}; !reflect.DeepEqual(eventRepo.last.Embedding, want) {
+		t.Errorf("query embedding = %v, want %v", eventRepo.last.Embedding, want)
+	}
+	if eventRepo.last.MinSimilarity != manager.config.SemanticSearchSimilarity {
+		t.Errorf("query min similarity = %v, want %v", eventRepo.last.MinSimilarity, manager.config.SemanticSearchSimilarity)
+	}
+
+	// Plain queries are not embedded
+	if _, err := manager.GetEvents(models.EventQuery{}); err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if eventRepo.last.Embedding != nil {
+		t.Error("expected no embedding without a semantic query")
+	}
+
+	// The unauthenticated MCP endpoints never pay for an embedding
+	eventRepo.last = models.EventQuery{}
+	if _, err := NewMCPHandler(manager).GetEvents(ctx, `{"semantic_query":"unrest near the port"}`); err == nil {
+		t.Error("expected MCP semantic queries to be rejected")
+	}
+	if eventRepo.last.Embedding != nil {
+		t.Error("expected no embedding for an MCP query")
+	}
 }
 
 func TestEventLifecycleManager_GetPublishedEvents(t *testing.T) {
@@ -300,6 +819,52 @@ func TestEventLifecycleManager_GetPublishedEvents(t *testing.T) {
 	}
 }
 
+func TestEventLifecycleManager_BreakingEvents(t *testing.T) {
+	eventRepo := ingestion.NewMemoryEventRepository()
+	thresholdRepo := newMockThresholdRepository()
+	thresholdRepo.cfg.BreakingMinMagnitude = 7.0
+	thresholdRepo.cfg.BreakingWindowHours = 6
+	logger, _ := logging.New(config.LoggingConfig{Level: slog.LevelDebug, Format: "json"})
+	manager := NewEventLifecycleManager(nil, eventRepo, nil, thresholdRepo, nil, nil, logger, DefaultLifecycleConfig())
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, e := range []models.Event{
+		{ID: "fresh-major", Magnitude: 8.0, Timestamp: now.Add(-time.Hour), Status: models.EventStatusPublished},
+		{ID: "stale-major", Magnitude: 9.0, Timestamp: now.Add(-12 * time.Hour), Status: models.EventStatusPublished},
+		{ID: "fresh-minor", Magnitude: 4.0, Timestamp: now.Add(-time.Hour), Status: models.EventStatusPublished},
+	} {
+		eventRepo.Create(ctx, e)
+	}
+
+	published := models.EventStatusPublished
+	all, err := manager.GetEvents(models.EventQuery{Status: &published})
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(all))
+	}
+	for _, event := range all {
+		if event.IsBreaking != (event.ID == "fresh-major") {
+			t.Errorf("event %s IsBreaking = %v", event.ID, event.IsBreaking)
+		}
+	}
+
+	breaking, err := manager.GetEvents(models.EventQuery{Status: &published, Breaking: true})
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(breaking) != 1 || breaking[0].ID != "fresh-major" {
+		t.Errorf("Expected only fresh-major with breaking=true, got %+v", breaking)
+	}
+
+	event, _ := manager.GetEventByID(ctx, "fresh-major")
+	if !event.IsBreaking {
+		t.Error("Expected GetEventByID to flag fresh-major as breaking")
+	}
+}
+
 func TestEventLifecycleManager_GetStats(t *testing.T) {
 	sourceRepo := ingestion.NewMemorySourceRepository()
 	eventRepo := ingestion.NewMemoryEventRepository()
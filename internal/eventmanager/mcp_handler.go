@@ -34,16 +34,18 @@ type MCPEvent struct {
 	Sources    []models.Source   `json:"sources"`
 	Tags       []string          `json:"tags"`
 	Location   *models.Location  `json:"location,omitempty"`
+	IsBreaking bool              `json:"is_breaking"`
 	CreatedAt  time.Time         `json:"created_at"`
 	UpdatedAt  time.Time         `json:"updated_at"`
 }
 
 // MCPEventResponse represents the MCP-specific response format
 type MCPEventResponse struct {
-	Events []MCPEvent `json:"events"`
-	Total  int        `json:"total"`
-	Page   int        `json:"page"`
-	Limit  int        `json:"limit"`
+	Events     []MCPEvent `json:"events"`
+	Total      int        `json:"total"`
+	Page       int        `json:"page"`
+	Limit      int        `json:"limit"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
 
 // GetEvents implements the get_events MCP function.
@@ -56,6 +58,11 @@ func (h *MCPHandler) GetEvents(ctx context.Context, queryJSON string) (string, e
 		return "", fmt.Errorf("invalid query JSON: %w", err)
 	}
 
+	// MCP endpoints are unauthenticated, and every semantic query pays for an embedding call
+	if query.SemanticQuery != "" {
+		return "", fmt.Errorf("invalid query parameters: semantic_query is not available over MCP")
+	}
+
 	// Force status to published - MCP clients should only see published events
 	publishedStatus := models.EventStatusPublished
 	query.Status = &publishedStatus
@@ -85,16 +92,18 @@ func (h *MCPHandler) GetEvents(ctx context.Context, queryJSON string) (string, e
 			Sources:    event.Sources,
 			Tags:       event.Tags,
 			Location:   event.Location,
+			IsBreaking: event.IsBreaking,
 			CreatedAt:  event.CreatedAt,
 			UpdatedAt:  event.UpdatedAt,
 		}
 	}
 
 	mcpResponse := MCPEventResponse{
-		Events: mcpEvents,
-		Total:  response.Total,
-		Page:   response.Page,
-		Limit:  response.Limit,
+		Events:     mcpEvents,
+		Total:      response.Total,
+		Page:       response.Page,
+		Limit:      response.Limit,
+		NextCursor: response.NextCursor,
 	}
 
 	// Serialize response to JSON
@@ -140,6 +149,10 @@ func (h *MCPHandler) MCPToolDefinition() map[string]interface{} {
 					"maximum":     1,
 					"description": "Minimum confidence score (0-1 scale)",
 				},
+				"breaking": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only return breaking events: high magnitude and recent, per the configured breaking thresholds",
+				},
 				"categories": map[string]interface{}{
 					"type": "array",
 					"items": map[string]interface{}{
@@ -188,6 +201,14 @@ func (h *MCPHandler) MCPToolDefinition() map[string]interface{} {
 					"default":     1,
 					"description": "Page number for pagination (1-indexed)",
 				},
+				"after_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Only events after this event ID in sort_by order, oldest first. To poll for new events, pass the next_cursor of the previous response",
+				},
+				"before_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Only events before this event ID in sort_by order, newest first",
+				},
 				"limit": map[string]interface{}{
 					"type":        "integer",
 					"minimum":     1,
@@ -0,0 +1,181 @@
+package forecaster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/httpclient"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// ActivityLogger defines the interface for logging forecast activity
+type ActivityLogger interface {
+	Log(ctx context.Context, log models.ActivityLog) error
+}
+
+// webhookTimeout bounds how long an alert webhook may take to respond
+const webhookTimeout = 10 * time.Second
+
+// ModelEstimate is a single model's headline value included in alerts
+type ModelEstimate struct {
+	ModelID   string  `json:"model_id"`
+	Provider  string  `json:"provider"`
+	ModelName string  `json:"model_name"`
+	Value     float64 `json:"value"`     // P50, point estimate or probability depending on prediction type
+	Deviation float64 `json:"deviation"` // Distance from the aggregated value
+}
+
+// DisagreementAlert is the payload describing a run where models diverged sharply
+type DisagreementAlert struct {
+	Text           string          `json:"text"` // Human readable summary (rendered by Slack)
+	ForecastID     string          `json:"forecast_id"`
+	ForecastName   string          `json:"forecast_name"`
+	RunID          string          `json:"run_id"`
+	ConsensusLevel float64         `json:"consensus_level"`
+	Threshold      float64         `json:"threshold"`
+	Aggregate      float64         `json:"aggregate"`
+	Outlier        *ModelEstimate  `json:"outlier,omitempty"`
+	Estimates      []ModelEstimate `json:"estimates"`
+}
+
+// SetActivityLogger enables activity logging for forecast alerts
+func (f *Forecaster) SetActivityLogger(activityLogger ActivityLogger) {
+	f.activityLogger = activityLogger
+}
+
+// headlineValue returns the value used to compare models for a response or result:
+// the P50 for percentile forecasts, otherwise the point estimate or probability
+func headlineValue(percentiles *models.PercentilePredictions, pointEstimate, probability *float64) (float64, bool) {
+	switch {
+	case percentiles != nil:
+		return percentiles.P50, true
+	case probability != nil:
+		return *probability, true
+	case pointEstimate != nil:
+		return *pointEstimate, true
+	}
+	return 0, false
+}
+
+// checkDisagreement raises an alert when a completed run's consensus std dev exceeds the
+// forecast's configured disagreement threshold
+func (f *Forecaster) checkDisagreement(ctx context.Context, forecast *models.Forecast, runID string, result models.ForecastResult, responses []models.ForecastModelResponse) {
+	if forecast.DisagreementThreshold == nil || result.ConsensusLevel == nil {
+		return
+	}
+	if *result.ConsensusLevel <= *forecast.DisagreementThreshold {
+		return
+	}
+
+	aggregate, _ := headlineValue(result.AggregatedPercentiles, result.AggregatedPointEstimate, result.AggregatedProbability)
+
+	alert := DisagreementAlert{
+		ForecastID:     forecast.ID,
+		ForecastName:   forecast.Name,
+		RunID:          runID,
+		ConsensusLevel: *result.ConsensusLevel,
+		Threshold:      *forecast.DisagreementThreshold,
+		Aggregate:      aggregate,
+	}
+
+	for _, resp := range responses {
+		if resp.Status != "completed" {
+			continue
+		}
+		value, ok := headlineValue(resp.PercentilePredictions, resp.PointEstimate, resp.Probability)
+		if !ok {
+			continue
+		}
+		alert.Estimates = append(alert.Estimates, ModelEstimate{
+			ModelID:   resp.ModelID,
+			Provider:  resp.Provider,
+			ModelName: resp.ModelName,
+			Value:     value,
+			Deviation: math.Abs(value - aggregate),
+		})
+	}
+
+	for i := range alert.Estimates {
+		if alert.Outlier == nil || alert.Estimates[i].Deviation > alert.Outlier.Deviation {
+			alert.Outlier = &alert.Estimates[i]
+		}
+	}
+
+	alert.Text = fmt.Sprintf("Forecast %q: models disagree (consensus std dev %.2f > threshold %.2f, aggregate %.2f)",
+		forecast.Name, alert.ConsensusLevel, alert.Threshold, alert.Aggregate)
+	if alert.Outlier != nil {
+		alert.Text += fmt.Sprintf(". Outlier: %s/%s at %.2f", alert.Outlier.Provider, alert.Outlier.ModelName, alert.Outlier.Value)
+	}
+
+	f.logger.Warn("forecast ensemble disagreement",
+		"forecast_id", forecast.ID,
+		"run_id", runID,
+		"consensus_level", alert.ConsensusLevel,
+		"threshold", alert.Threshold)
+
+	if f.activityLogger != nil {
+		if err := f.activityLogger.Log(ctx, models.ActivityLog{
+			ActivityType: models.ActivityTypeForecastAlert,
+			Platform:     "forecast",
+			Message:      alert.Text,
+			Details: map[string]interface{}{
+				"forecast_id":     alert.ForecastID,
+				"run_id":          alert.RunID,
+				"consensus_level": alert.ConsensusLevel,
+				"threshold":       alert.Threshold,
+				"aggregate":       alert.Aggregate,
+				"estimates":       alert.Estimates,
+			},
+		}); err != nil {
+			f.logger.Error("failed to log forecast alert", "run_id", runID, "error", err)
+		}
+	}
+
+	if forecast.AlertWebhookURL != "" {
+		if err := postWebhook(ctx, forecast.AlertWebhookURL, alert); err != nil {
+			f.logger.Error("failed to send forecast alert webhook", "run_id", runID, "error", err)
+		}
+	}
+}
+
+// postWebhook sends a JSON payload to a webhook URL. The payload should include a "text"
+// field so Slack-compatible incoming webhooks can render it.
+func postWebhook(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	return sendWebhook(ctx, url, body, nil)
+}
+
+// sendWebhook POSTs a JSON body to a webhook URL with the given extra headers
+func sendWebhook(ctx context.Context, url string, body []byte, headers map[string]string) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpclient.New(httpclient.WithoutRetries()).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &webhookStatusError{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package forecaster
+
+import "github.com/STRATINT/stratint/internal/models"
+
+// CompareRuns diffs two runs of a forecast: how the aggregated result moved from run A to run B
+// and which headlines entered or left the snapshot between them
+func CompareRuns(a, b *models.ForecastRunDetail) *models.ForecastRunComparison {
+	comparison := &models.ForecastRunComparison{
+		ForecastID:       b.Run.ForecastID,
+		RunA:             *a,
+		RunB:             *b,
+		HeadlinesAdded:   headlineDifference(b.Run.HeadlinesSnapshot, a.Run.HeadlinesSnapshot),
+		HeadlinesRemoved: headlineDifference(a.Run.HeadlinesSnapshot, b.Run.HeadlinesSnapshot),
+	}
+
+	if a.Result == nil || b.Result == nil {
+		return comparison
+	}
+
+	if a.Result.AggregatedPercentiles != nil && b.Result.AggregatedPercentiles != nil {
+		comparison.P50Delta = delta(&a.Result.AggregatedPercentiles.P50, &b.Result.AggregatedPercentiles.P50)
+	}
+	comparison.PointEstimateDelta = delta(a.Result.AggregatedPointEstimate, b.Result.AggregatedPointEstimate)
+	comparison.ProbabilityDelta = delta(a.Result.AggregatedProbability, b.Result.AggregatedProbability)
+	comparison.ConsensusDelta = delta(a.Result.ConsensusLevel, b.Result.ConsensusLevel)
+
+	return comparison
+}
+
+// delta returns b - a, or nil if either value is missing
+func delta(a, b *float64) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	d := *b - *a
+	return &d
+}
+
+// headlineDifference returns the headlines in from that are not in other
+func headlineDifference(from, other []models.ForecastHeadline) []models.ForecastHeadline {
+	seen := make(map[string]bool, len(other))
+	for _, h := range other {
+		seen[headlineKey(h)] = true
+	}
+
+	diff := []models.ForecastHeadline{}
+	for _, h := range from {
+		if !seen[headlineKey(h)] {
+			diff = append(diff, h)
+		}
+	}
+	return diff
+}
+
+// headlineKey identifies a headline by event ID, falling back to the title for older snapshots
+func headlineKey(h models.ForecastHeadline) string {
+	if h.EventID != "" {
+		return h.EventID
+	}
+	return h.Title
+}
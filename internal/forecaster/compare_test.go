@@ -0,0 +1,71 @@
+package forecaster
+
+import (
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestCompareRuns(t *testing.T) {
+	consensusA, consensusB := 4.0, 2.5
+	a := &models.ForecastRunDetail{
+		Run: models.ForecastRun{
+			ID:         "run-a",
+			ForecastID: "f1",
+			HeadlinesSnapshot: []models.ForecastHeadline{
+				{EventID: "e1", Title: "Rate cut expected"},
+				{EventID: "e2", Title: "Oil supply disruption"},
+			},
+		},
+		Result: &models.ForecastResult{
+			AggregatedPercentiles: &models.PercentilePredictions{P50: 3.0},
+			ConsensusLevel:        &consensusA,
+		},
+	}
+	b := &models.ForecastRunDetail{
+		Run: models.ForecastRun{
+			ID:         "run-b",
+			ForecastID: "f1",
+			HeadlinesSnapshot: []models.ForecastHeadline{
+				{EventID: "e2", Title: "Oil supply disruption"},
+				{EventID: "e3", Title: "Bank failure"},
+			},
+		},
+		Result: &models.ForecastResult{
+			AggregatedPercentiles: &models.PercentilePredictions{P50: 1.5},
+			ConsensusLevel:        &consensusB,
+		},
+	}
+
+	comparison := CompareRuns(a, b)
+
+	if comparison.P50Delta == nil || *comparison.P50Delta != -1.5 {
+		t.Errorf("P50Delta = %v, want -1.5", comparison.P50Delta)
+	}
+	if comparison.ConsensusDelta == nil || *comparison.ConsensusDelta != -1.5 {
+		t.Errorf("ConsensusDelta = %v, want -1.5", comparison.ConsensusDelta)
+	}
+	if comparison.PointEstimateDelta != nil {
+		t.Errorf("PointEstimateDelta = %v, want nil", *comparison.PointEstimateDelta)
+	}
+	if len(comparison.HeadlinesAdded) != 1 || comparison.HeadlinesAdded[0].EventID != "e3" {
+		t.Errorf("HeadlinesAdded = %+v, want [e3]", comparison.HeadlinesAdded)
+	}
+	if len(comparison.HeadlinesRemoved) != 1 || comparison.HeadlinesRemoved[0].EventID != "e1" {
+		t.Errorf("HeadlinesRemoved = %+v, want [e1]", comparison.HeadlinesRemoved)
+	}
+}
+
+func TestCompareRuns_MissingResult(t *testing.T) {
+	a := &models.ForecastRunDetail{Run: models.ForecastRun{ID: "run-a"}}
+	b := &models.ForecastRunDetail{Run: models.ForecastRun{ID: "run-b"}}
+
+	comparison := CompareRuns(a, b)
+
+	if comparison.P50Delta != nil || comparison.ConsensusDelta != nil {
+		t.Error("expected nil deltas when runs have no results")
+	}
+	if comparison.HeadlinesAdded == nil || comparison.HeadlinesRemoved == nil {
+		t.Error("expected empty, non-nil headline diffs")
+	}
+}
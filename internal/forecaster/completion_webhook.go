@@ -0,0 +1,186 @@
+package forecaster
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// Headers sent with completion webhooks. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the forecast's webhook secret, prefixed with "sha256=".
+const (
+	SignatureHeader = "X-Stratint-Signature"
+	TimestampHeader = "X-Stratint-Timestamp"
+)
+
+// CompletionEvent identifies completion webhook payloads
+const CompletionEvent = "forecast.run_completed"
+
+// completionWebhookAttempts is how many times a completion webhook is sent before giving up
+const completionWebhookAttempts = 4
+
+// completionWebhookBackoff is the delay before the first retry; it doubles after each attempt.
+// A variable so tests can shorten it.
+var completionWebhookBackoff = 2 * time.Second
+
+// CompletionNotification is the payload sent to a forecast's completion webhook
+type CompletionNotification struct {
+	Text                    string                        `json:"text"` // Human readable summary (rendered by Slack)
+	Event                   string                        `json:"event"`
+	ForecastID              string                        `json:"forecast_id"`
+	ForecastName            string                        `json:"forecast_name"`
+	Proposition             string                        `json:"proposition"`
+	PredictionType          string                        `json:"prediction_type"`
+	Units                   string                        `json:"units,omitempty"`
+	RunID                   string                        `json:"run_id"`
+	CompletedAt             time.Time                     `json:"completed_at"`
+	AggregatedPercentiles   *models.PercentilePredictions `json:"aggregated_percentiles,omitempty"`
+	AggregatedPointEstimate *float64                      `json:"aggregated_point_estimate,omitempty"`
+	AggregatedProbability   *float64                      `json:"aggregated_probability,omitempty"`
+	ConsensusLevel          *float64                      `json:"consensus_level,omitempty"`
+	ModelCount              int                           `json:"model_count"`
+	Value                   float64                       `json:"value"`                       // P50, point estimate or probability depending on prediction type
+	ThresholdCrossed        string                        `json:"threshold_crossed,omitempty"` // "above" or "below" when a threshold triggered the notification
+	Threshold               *float64                      `json:"threshold,omitempty"`
+}
+
+// webhookStatusError is returned when a webhook responds with a non-2xx status
+type webhookStatusError struct {
+	StatusCode int
+}
+
+func (e *webhookStatusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d", e.StatusCode)
+}
+
+// retryableWebhookError reports whether a failed webhook delivery may succeed if sent again:
+// network errors, rate limiting and server errors are retried, other client errors are not
+func retryableWebhookError(err error) bool {
+	var statusErr *webhookStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// signWebhook returns the signature header value for a webhook body sent at timestamp
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// completionThreshold returns which of the forecast's thresholds a value crosses. notify is
+// false when thresholds are configured and the value crosses none of them.
+func completionThreshold(forecast *models.Forecast, value float64) (crossed string, threshold *float64, notify bool) {
+	if forecast.CompletionWebhookAbove == nil && forecast.CompletionWebhookBelow == nil {
+		return "", nil, true
+	}
+	if forecast.CompletionWebhookAbove != nil && value >= *forecast.CompletionWebhookAbove {
+		return "above", forecast.CompletionWebhookAbove, true
+	}
+	if forecast.CompletionWebhookBelow != nil && value <= *forecast.CompletionWebhookBelow {
+		return "below", forecast.CompletionWebhookBelow, true
+	}
+	return "", nil, false
+}
+
+// notifyCompletion sends a completed run's result to the forecast's completion webhook,
+// retrying with exponential backoff on network errors, 429s and 5xx responses
+func (f *Forecaster) notifyCompletion(ctx context.Context, forecast *models.Forecast, runID string, result models.ForecastResult) {
+	if forecast.CompletionWebhookURL == "" {
+		return
+	}
+
+	value, ok := headlineValue(result.AggregatedPercentiles, result.AggregatedPointEstimate, result.AggregatedProbability)
+	if !ok {
+		return
+	}
+	crossed, threshold, notify := completionThreshold(forecast, value)
+	if !notify {
+		f.logger.Debug("forecast value within completion webhook thresholds, not notifying",
+			"forecast_id", forecast.ID,
+			"run_id", runID,
+			"value", value)
+		return
+	}
+
+	notification := CompletionNotification{
+		Event:                   CompletionEvent,
+		ForecastID:              forecast.ID,
+		ForecastName:            forecast.Name,
+		Proposition:             forecast.Proposition,
+		PredictionType:          forecast.PredictionType,
+		Units:                   forecast.Units,
+		RunID:                   runID,
+		CompletedAt:             time.Now().UTC(),
+		AggregatedPercentiles:   result.AggregatedPercentiles,
+		AggregatedPointEstimate: result.AggregatedPointEstimate,
+		AggregatedProbability:   result.AggregatedProbability,
+		ConsensusLevel:          result.ConsensusLevel,
+		ModelCount:              result.ModelCount,
+		Value:                   value,
+		ThresholdCrossed:        crossed,
+		Threshold:               threshold,
+	}
+	notification.Text = fmt.Sprintf("Forecast %q completed: %.2f", forecast.Name, value)
+	if forecast.Units != "" {
+		notification.Text += " " + forecast.Units
+	}
+	if threshold != nil {
+		notification.Text += fmt.Sprintf(" (%s threshold %.2f)", crossed, *threshold)
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		f.logger.Error("failed to marshal forecast completion webhook", "run_id", runID, "error", err)
+		return
+	}
+
+	backoff := completionWebhookBackoff
+	for attempt := 1; ; attempt++ {
+		headers := map[string]string{}
+		if forecast.CompletionWebhookSecret != "" {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			headers[TimestampHeader] = timestamp
+			headers[SignatureHeader] = signWebhook(forecast.CompletionWebhookSecret, timestamp, body)
+		}
+
+		err := sendWebhook(ctx, forecast.CompletionWebhookURL, body, headers)
+		if err == nil {
+			f.logger.Info("sent forecast completion webhook", "forecast_id", forecast.ID, "run_id", runID, "attempt", attempt)
+			return
+		}
+		if attempt >= completionWebhookAttempts || !retryableWebhookError(err) {
+			f.logger.Error("failed to send forecast completion webhook",
+				"forecast_id", forecast.ID,
+				"run_id", runID,
+				"attempts", attempt,
+				"error", err)
+			return
+		}
+
+		f.logger.Warn("forecast completion webhook failed, retrying",
+			"run_id", runID,
+			"attempt", attempt,
+			"backoff", backoff,
+			"error", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,136 @@
+package forecaster
+
+import (
+	"html"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxContextFetchBytes is the hard cap on bytes read from a context URL, regardless of budget
+	maxContextFetchBytes = 5 * 1024 * 1024
+
+	// charsPerToken is a rough estimate used to convert token budgets to character budgets
+	charsPerToken = 4
+
+	// contextURLTokenShare is the fraction of a model's context window reserved for context URLs
+	contextURLTokenShare = 0.25
+
+	// minContextURLChars ensures each context URL contributes something useful even for small models
+	minContextURLChars = 2000
+)
+
+// nonContentElements are elements whose content is never readable article text
+var nonContentElements = func() []*regexp.Regexp {
+	tags := []string{"script", "style", "noscript", "svg", "iframe", "template", "head", "nav", "header", "footer", "aside", "form"}
+	patterns := make([]*regexp.Regexp, len(tags))
+	for i, tag := range tags {
+		patterns[i] = regexp.MustCompile(`(?is)<` + tag + `\b[^>]*>.*?</` + tag + `\s*>`)
+	}
+	return patterns
+}()
+
+var (
+	htmlComments    = regexp.MustCompile(`(?s)<!--.*?-->`)
+	articleElement  = regexp.MustCompile(`(?is)<article\b[^>]*>(.*?)</article\s*>`)
+	mainElement     = regexp.MustCompile(`(?is)<main\b[^>]*>(.*?)</main\s*>`)
+	blockBoundaries = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h[1-6]|/tr|/section|/blockquote|/pre|/table)\b[^>]*>`)
+	listItems       = regexp.MustCompile(`(?i)<\s*li\b[^>]*>`)
+	htmlTags        = regexp.MustCompile(`(?s)<[^>]*>`)
+	horizontalSpace = regexp.MustCompile(`[ \t\f\v\x{00a0}]+`)
+	blankLines      = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// contextCharBudget returns the number of characters each context URL may contribute to the
+// prompt, given the model's context window and the number of URLs sharing the budget
+func contextCharBudget(maxTokens, urlCount int) int {
+	if urlCount < 1 {
+		urlCount = 1
+	}
+	budget := int(float64(maxTokens)*contextURLTokenShare) * charsPerToken / urlCount
+	if budget < minContextURLChars {
+		budget = minContextURLChars
+	}
+	return budget
+}
+
+// readableContent converts a fetched body into prompt-ready text based on its content type.
+// HTML is reduced to its main article text; JSON, CSV and other text formats pass through.
+// Returns false when the content type is not textual.
+func readableContent(contentType string, body []byte) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(body))
+	}
+
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		return extractReadableText(string(body)), true
+	case strings.HasPrefix(mediaType, "text/"),
+		mediaType == "application/json",
+		strings.HasSuffix(mediaType, "+json"),
+		mediaType == "application/xml",
+		strings.HasSuffix(mediaType, "+xml"),
+		mediaType == "application/csv":
+		return string(body), true
+	default:
+		return "", false
+	}
+}
+
+// extractReadableText strips markup from an HTML document, preferring the <article> or
+// <main> element when present, and returns whitespace-normalized text
+func extractReadableText(doc string) string {
+	doc = htmlComments.ReplaceAllString(doc, "")
+	for _, pattern := range nonContentElements {
+		doc = pattern.ReplaceAllString(doc, "")
+	}
+
+	// Prefer the main article body when the page marks it up
+	if matches := articleElement.FindAllStringSubmatch(doc, -1); len(matches) > 0 {
+		var parts []string
+		for _, m := range matches {
+			parts = append(parts, m[1])
+		}
+		doc = strings.Join(parts, "\n")
+	} else if m := mainElement.FindStringSubmatch(doc); m != nil {
+		doc = m[1]
+	}
+
+	doc = listItems.ReplaceAllString(doc, "\n- ")
+	doc = blockBoundaries.ReplaceAllString(doc, "\n")
+	doc = htmlTags.ReplaceAllString(doc, " ")
+	doc = html.UnescapeString(doc)
+
+	// Normalize whitespace line by line
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(horizontalSpace.ReplaceAllString(line, " "))
+	}
+	doc = strings.Join(lines, "\n")
+	doc = blankLines.ReplaceAllString(doc, "\n\n")
+
+	return strings.TrimSpace(doc)
+}
+
+// truncateText shortens text to at most maxChars, cutting at a whitespace boundary
+func truncateText(text string, maxChars int) (string, bool) {
+	if maxChars <= 0 || len(text) <= maxChars {
+		return text, false
+	}
+
+	// Back off to a rune boundary so multi-byte characters aren't split
+	for maxChars > 0 && !utf8.RuneStart(text[maxChars]) {
+		maxChars--
+	}
+
+	cut := text[:maxChars]
+	if idx := strings.LastIndexAny(cut, " \n\t"); idx > maxChars/2 {
+		cut = cut[:idx]
+	}
+
+	return strings.TrimSpace(cut), true
+}
@@ -0,0 +1,97 @@
+package forecaster
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExtractReadableText(t *testing.T) {
+	doc := `<html><head><title>Ignored</title><style>.x{color:red}</style></head>
+<body>
+<nav><a href="/">Home</a> | <a href="/markets">Markets</a></nav>
+<article>
+<h1>Fed holds rates</h1>
+<p>The Federal Reserve held rates steady &amp; signalled patience.</p>
+<script>trackPageView();</script>
+<ul><li>Inflation at 3.1%</li><li>Unemployment at 4.0%</li></ul>
+</article>
+<footer>Copyright</footer>
+</body></html>`
+
+	text := extractReadableText(doc)
+
+	for _, want := range []string{"Fed holds rates", "held rates steady & signalled patience.", "- Inflation at 3.1%", "- Unemployment at 4.0%"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected text to contain %q, got:\n%s", want, text)
+		}
+	}
+	for _, unwanted := range []string{"<", "trackPageView", "Markets", "Copyright", "color:red", "Ignored"} {
+		if strings.Contains(text, unwanted) {
+			t.Errorf("expected text not to contain %q, got:\n%s", unwanted, text)
+		}
+	}
+}
+
+func TestReadableContent(t *testing.T) {
+	json := `{"series":"CPI","value":3.1}`
+	if got, ok := readableContent("application/json; charset=utf-8", []byte(json)); !ok || got != json {
+		t.Errorf("expected JSON to pass through, got %q (ok=%v)", got, ok)
+	}
+
+	csv := "date,value\n2025-01-01,3.1\n"
+	if got, ok := readableContent("text/csv", []byte(csv)); !ok || got != csv {
+		t.Errorf("expected CSV to pass through, got %q (ok=%v)", got, ok)
+	}
+
+	if got, ok := readableContent("text/html", []byte("<p>Hello <b>world</b></p>")); !ok || got != "Hello world" {
+		t.Errorf("expected HTML to be stripped, got %q (ok=%v)", got, ok)
+	}
+
+	if _, ok := readableContent("application/pdf", []byte("%PDF-1.4")); ok {
+		t.Error("expected PDF to be rejected")
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	text := "alpha beta gamma delta"
+
+	if got, truncated := truncateText(text, 100); truncated || got != text {
+		t.Errorf("expected no truncation, got %q", got)
+	}
+
+	got, truncated := truncateText(text, 13)
+	if !truncated || got != "alpha beta" {
+		t.Errorf("expected truncation at word boundary, got %q (truncated=%v)", got, truncated)
+	}
+}
+
+func TestFetchURLContent_ChunkedBody(t *testing.T) {
+	// Stream the body in many small flushed chunks so a single Read would only see the first one
+	chunk := strings.Repeat("x", 1000) + "\n"
+	const chunks = 50
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		flusher := w.(http.Flusher)
+		for i := 0; i < chunks; i++ {
+			io.WriteString(w, chunk)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	f := &Forecaster{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	content, err := f.fetchURLContent(context.Background(), server.URL, len(chunk)*chunks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(content) != len(chunk)*chunks {
+		t.Errorf("expected %d bytes of content, got %d", len(chunk)*chunks, len(content))
+	}
+}
@@ -0,0 +1,114 @@
+package forecaster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// explanationSystemPrompt frames the synthesis call that explains an aggregated result
+const explanationSystemPrompt = "You are an expert intelligence analyst explaining a finished forecast to a decision maker. Be concise, concrete and grounded in the evidence provided."
+
+// explanationModel picks the model that explains a run's result: the highest-weight model that
+// returned a completed response, the first configured one on ties. Nil when none completed.
+func explanationModel(forecastModels []models.ForecastModel, responses []models.ForecastModelResponse) *models.ForecastModel {
+	completed := make(map[string]bool)
+	for _, response := range responses {
+		if response.Status == "completed" {
+			completed[response.ModelID] = true
+		}
+	}
+
+	var best *models.ForecastModel
+	for i := range forecastModels {
+		model := &forecastModels[i]
+		if !completed[model.ID] {
+			continue
+		}
+		if best == nil || model.Weight > best.Weight {
+			best = model
+		}
+	}
+	return best
+}
+
+// describeAggregate renders the aggregated value of a result for the forecast's prediction type
+func describeAggregate(forecast *models.Forecast, result models.ForecastResult) string {
+	var sb strings.Builder
+	switch {
+	case result.AggregatedPercentiles != nil:
+		p := result.AggregatedPercentiles
+		sb.WriteString(fmt.Sprintf("p10 %.2f, p25 %.2f, p50 %.2f, p75 %.2f, p90 %.2f (%s)", p.P10, p.P25, p.P50, p.P75, p.P90, forecast.Units))
+	case result.AggregatedProbability != nil:
+		sb.WriteString(fmt.Sprintf("%.1f%% probability that the answer is YES", *result.AggregatedProbability))
+	case result.AggregatedPointEstimate != nil:
+		sb.WriteString(fmt.Sprintf("%.2f (%s)", *result.AggregatedPointEstimate, forecast.Units))
+	}
+	sb.WriteString(fmt.Sprintf(", aggregated from %d models", result.ModelCount))
+	if result.ConsensusLevel != nil {
+		sb.WriteString(fmt.Sprintf(" with a standard deviation of %.2f between them", *result.ConsensusLevel))
+	}
+	return sb.String()
+}
+
+// buildExplanationPrompt asks for the reasoning behind an aggregated result given the headlines
+// the models saw
+func buildExplanationPrompt(forecast *models.Forecast, headlines []models.ForecastHeadline, result models.ForecastResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("QUESTION: %s\n\n", forecast.Proposition))
+	sb.WriteString(fmt.Sprintf("An ensemble of forecasting models answered this question with: %s.\n\n", describeAggregate(forecast, result)))
+
+	sb.WriteString("INTELLIGENCE SIGNALS the models were given (most recent first):\n")
+	for i, headline := range headlines {
+		sb.WriteString(fmt.Sprintf("%d. [%s | MAG %.1f] %s%s\n",
+			i+1,
+			headline.Category,
+			headline.Magnitude,
+			headline.Title,
+			headlineDate(headline)))
+	}
+
+	sb.WriteString("\nExplain in one or two short paragraphs why this estimate is reasonable: which signals and ")
+	sb.WriteString("base rates most plausibly drive it, and what are the main uncertainties or developments that ")
+	sb.WriteString("would move it up or down. Refer to signals by their number. Do not propose a different estimate.")
+
+	return sb.String()
+}
+
+// explainResult asks one model to explain a run's aggregated result, returning the explanation or
+// "" when no model completed or the call fails; a missing explanation never fails the run
+func (f *Forecaster) explainResult(ctx context.Context, forecast *models.Forecast, forecastModels []models.ForecastModel, headlines []models.ForecastHeadline, responses []models.ForecastModelResponse, result models.ForecastResult) string {
+	model := explanationModel(forecastModels, responses)
+	if model == nil {
+		return ""
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, callTimeout(model))
+	defer cancel()
+
+	prompt := buildExplanationPrompt(forecast, headlines, result)
+
+	var content string
+	var err error
+	switch model.Provider {
+	case models.ProviderOpenAI, models.ProviderOpenAICompatible:
+		content, _, err = f.callOpenAI(callCtx, model, taskForecastExplanation, explanationSystemPrompt, prompt)
+	case "anthropic":
+		content, _, err = f.callAnthropic(callCtx, model, taskForecastExplanation, explanationSystemPrompt, prompt)
+	default:
+		err = fmt.Errorf("unsupported provider: %s", model.Provider)
+	}
+	if err != nil {
+		f.logger.Warn("failed to explain forecast result",
+			"forecast_id", forecast.ID,
+			"run_id", result.RunID,
+			"model", model.ModelName,
+			"error", err)
+		return ""
+	}
+
+	return strings.TrimSpace(content)
+}
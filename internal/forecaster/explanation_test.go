@@ -0,0 +1,81 @@
+package forecaster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestExplanationModel(t *testing.T) {
+	forecastModels := []models.ForecastModel{
+		{ID: "m1", ModelName: "small", Weight: 1},
+		{ID: "m2", ModelName: "large", Weight: 3},
+		{ID: "m3", ModelName: "also-large", Weight: 3},
+		{ID: "m4", ModelName: "heaviest", Weight: 5},
+	}
+
+	tests := []struct {
+		name      string
+		responses []models.ForecastModelResponse
+		want      string
+	}{
+		{
+			name:      "highest weight completed",
+			responses: []models.ForecastModelResponse{{ModelID: "m1", Status: "completed"}, {ModelID: "m3", Status: "completed"}},
+			want:      "also-large",
+		},
+		{
+			name:      "first configured on ties",
+			responses: []models.ForecastModelResponse{{ModelID: "m3", Status: "completed"}, {ModelID: "m2", Status: "completed"}},
+			want:      "large",
+		},
+		{
+			name:      "failed models skipped",
+			responses: []models.ForecastModelResponse{{ModelID: "m4", Status: "failed"}, {ModelID: "m1", Status: "completed"}},
+			want:      "small",
+		},
+		{
+			name:      "none completed",
+			responses: []models.ForecastModelResponse{{ModelID: "m4", Status: "failed"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := explanationModel(forecastModels, tt.responses)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("explanationModel() = %s, want nil", got.ModelName)
+				}
+				return
+			}
+			if got == nil || got.ModelName != tt.want {
+				t.Errorf("explanationModel() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildExplanationPrompt(t *testing.T) {
+	forecast := &models.Forecast{Proposition: "Will the ceasefire hold through June?", PredictionType: models.PredictionTypeProbability}
+	probability := 62.5
+	consensus := 8.25
+	result := models.ForecastResult{AggregatedProbability: &probability, ModelCount: 3, ConsensusLevel: &consensus}
+	headlines := []models.ForecastHeadline{
+		{Title: "Talks resume in Doha", Category: "diplomacy", Magnitude: 6.5, Timestamp: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	prompt := buildExplanationPrompt(forecast, headlines, result)
+
+	for _, want := range []string{
+		"QUESTION: Will the ceasefire hold through June?",
+		"62.5% probability that the answer is YES, aggregated from 3 models with a standard deviation of 8.25",
+		"1. [diplomacy | MAG 6.5] Talks resume in Doha (2026-05-01)",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
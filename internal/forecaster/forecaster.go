@@ -2,26 +2,126 @@ package forecaster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"math"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/httpclient"
 	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/models"
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/google/uuid"
 	"github.com/sashabaranov/go-openai"
 )
 
 const (
 	// Temperature for sampling (higher = more randomness)
 	samplingTemperature = 1.0
+
+	// Maximum number of provider API calls in flight at once for a single forecast run,
+	// shared across all models and samples
+	defaultMaxConcurrentCalls = 8
+
+	// Maximum duration of a forecast run when the forecast doesn't configure one
+	defaultForecastTimeout = 30 * time.Minute
+
+	// Default run duration when a model is served by a local OpenAI-compatible server
+	defaultLocalForecastTimeout = 2 * time.Hour
+
+	// Maximum duration of a single provider call; local models run much slower than hosted APIs
+	hostedCallTimeout = 5 * time.Minute
+	localCallTimeout  = 20 * time.Minute
+
+	// Inference log task names for forecast calls
+	taskForecastGeneration  = "forecast_generation"
+	taskForecastExplanation = "forecast_explanation"
+
+	// Estimated prompt tokens per headline line, not counting its summary
+	tokensPerHeadline = 80
+
+	// Event summaries are cut to this many characters before going into a headline
+	maxHeadlineSummaryChars = 400
 )
 
+// DefaultMaxIterations is the most samples per model a forecast may request unless
+// SetMaxIterations changes it
+const DefaultMaxIterations = 50
+
+// DefaultMinHeadlines is how many headlines a run should have before it is widened beyond the
+// forecast's categories and, failing that, flagged as sparse, unless SetMinHeadlines changes it
+const DefaultMinHeadlines = 5
+
+// ErrRunInProgress is returned by ExecuteForecast when the forecast already has a run in progress
+var ErrRunInProgress = errors.New("forecast run already in progress")
+
+// ErrTooManyIterations is returned by ExecuteForecast when a forecast asks for more samples per
+// model than the forecaster allows
+var ErrTooManyIterations = errors.New("forecast iterations exceed the maximum")
+
+// ErrForecastArchived is returned by ExecuteForecast for an archived forecast
+var ErrForecastArchived = errors.New("forecast is archived")
+
+// RunStaleAfter is how long a pending or running run may go without a heartbeat before it is
+// presumed abandoned, e.g. by a restart, rather than executing on another instance
+const RunStaleAfter = 2 * time.Minute
+
+// runHeartbeatInterval is how often an executing run records that it is still alive
+var runHeartbeatInterval = 30 * time.Second
+
+// IdempotencyKeyWindow is how long an execute request's idempotency key keeps returning the run
+// it started
+const IdempotencyKeyWindow = 24 * time.Hour
+
+// idempotencyReservationTimeout is how long a reserved idempotency key waits for its run to start
+// before another request with the key takes it over, e.g. after a restart
+const idempotencyReservationTimeout = time.Minute
+
+// idempotencyPollInterval is how often a request checks whether the run another request with the
+// same idempotency key is starting has been created
+var idempotencyPollInterval = 200 * time.Millisecond
+
+// Errors returned when a run's responses can't be aggregated into a result
+var (
+	ErrNoValidResponses = errors.New("no model returned a usable prediction")
+	ErrNoUsableWeight   = errors.New("model weights sum to zero")
+)
+
+// startingForecasts holds the forecasts with a run starting or executing in this process. It
+// closes the gap between checking for an active run and creating one, which the database check
+// alone leaves open when the scheduler and an admin start the same forecast together.
+var startingForecasts = struct {
+	sync.Mutex
+	ids map[string]bool
+}{ids: make(map[string]bool)}
+
+// claimForecast marks a forecast as running in this process, returning false if it already is
+func claimForecast(forecastID string) bool {
+	startingForecasts.Lock()
+	defer startingForecasts.Unlock()
+	if startingForecasts.ids[forecastID] {
+		return false
+	}
+	startingForecasts.ids[forecastID] = true
+	return true
+}
+
+// releaseForecast allows a forecast to run again in this process
+func releaseForecast(forecastID string) {
+	startingForecasts.Lock()
+	defer startingForecasts.Unlock()
+	delete(startingForecasts.ids, forecastID)
+}
+
 // EventRepository defines methods needed to fetch events for forecasting
 type EventRepository interface {
 	Query(ctx context.Context, query models.EventQuery) (*models.EventResponse, error)
@@ -33,9 +133,16 @@ type ForecastRepository interface {
 	GetForecastModels(ctx context.Context, forecastID string) ([]models.ForecastModel, error)
 	CreateForecastRun(ctx context.Context, forecastID string, headlines []models.ForecastHeadline) (string, error)
 	UpdateForecastRunStatus(ctx context.Context, runID, status, errorMsg string) error
+	TouchForecastRun(ctx context.Context, runID string) error
 	CreateModelResponse(ctx context.Context, response models.ForecastModelResponse) error
 	CreateForecastResult(ctx context.Context, result models.ForecastResult) error
+	CreateForecastSamples(ctx context.Context, samples []models.ForecastSample) error
 	GetForecastRun(ctx context.Context, runID string) (*models.ForecastRunDetail, error)
+	GetActiveForecastRun(ctx context.Context, forecastID string, since time.Time) (*models.ForecastRun, error)
+	FailStaleForecastRuns(ctx context.Context, forecastID, errorMsg string, staleBefore time.Time) (int64, error)
+	ReserveIdempotencyKey(ctx context.Context, forecastID, key string, expiredBefore, staleBefore time.Time) (string, bool, error)
+	SetIdempotentRun(ctx context.Context, forecastID, key, runID string) error
+	ReleaseIdempotencyKey(ctx context.Context, forecastID, key string) error
 }
 
 // Forecaster executes forecasts using multiple AI models
@@ -44,28 +151,88 @@ type Forecaster struct {
 	forecastRepo    ForecastRepository
 	logger          *slog.Logger
 	inferenceLogger *inference.Logger
+	activityLogger  ActivityLogger
+	embedder        Embedder
+
+	maxConcurrentCalls int
+	maxIterations      int
+	minHeadlines       int
+	storeSamples       bool
 }
 
 // NewForecaster creates a new forecaster
 func NewForecaster(eventRepo EventRepository, forecastRepo ForecastRepository, logger *slog.Logger, inferenceLogger *inference.Logger) *Forecaster {
 	return &Forecaster{
-		eventRepo:       eventRepo,
-		forecastRepo:    forecastRepo,
-		logger:          logger,
-		inferenceLogger: inferenceLogger,
+		eventRepo:          eventRepo,
+		forecastRepo:       forecastRepo,
+		logger:             logger,
+		inferenceLogger:    inferenceLogger,
+		maxConcurrentCalls: defaultMaxConcurrentCalls,
+		maxIterations:      DefaultMaxIterations,
+		minHeadlines:       DefaultMinHeadlines,
+	}
+}
+
+// SetMaxIterations sets the most samples per model a forecast may request
+func (f *Forecaster) SetMaxIterations(n int) {
+	if n > 0 {
+		f.maxIterations = n
+	}
+}
+
+// MaxIterations returns the most samples per model a forecast may request
+func (f *Forecaster) MaxIterations() int {
+	return f.maxIterations
+}
+
+// SetMinHeadlines sets how many headlines a run should have before it is widened beyond the
+// forecast's categories and flagged as sparse; 0 turns both off
+func (f *Forecaster) SetMinHeadlines(n int) {
+	if n >= 0 {
+		f.minHeadlines = n
 	}
 }
 
+// minHeadlinesFor returns the forecast's minimum headline count, which is never more than it asks for
+func (f *Forecaster) minHeadlinesFor(forecast *models.Forecast) int {
+	return min(f.minHeadlines, forecast.HeadlineCount)
+}
+
+// SetStoreSamples sets whether each model's individual samples are stored as rows of their own,
+// on top of the averaged response
+func (f *Forecaster) SetStoreSamples(store bool) {
+	f.storeSamples = store
+}
+
+// reasoningBlock matches the <think> sections some models (often local ones) emit before answering
+var reasoningBlock = regexp.MustCompile(`(?is)<think>.*?</think>`)
+
+// percentileLabel matches labels like "P10:" or "p90 =" in front of a percentile value
+var percentileLabel = regexp.MustCompile(`(?i)\bp\d{1,2}\s*[:=]`)
+
+// answerText strips the reasoning and formatting that chattier models wrap around their answer:
+// <think> blocks, markdown emphasis and code fences
+func answerText(content string) string {
+	content = reasoningBlock.ReplaceAllString(content, "")
+	// Drop an unterminated reasoning block whose opening tag was cut off
+	if i := strings.LastIndex(content, "</think>"); i >= 0 {
+		content = content[i+len("</think>"):]
+	}
+	content = strings.NewReplacer("**", "", "__", "", "`", "").Replace(content)
+	return strings.TrimSpace(content)
+}
+
 // parsePercentiles extracts five comma-separated percentile values from model response
 // Returns PercentilePredictions or error if not found/invalid
 func parsePercentiles(content string) (*models.PercentilePredictions, error) {
 	// Trim and clean the response
-	content = strings.TrimSpace(content)
+	content = answerText(content)
 
-	// Look for comma-separated numbers in the last few lines
+	// Look for comma-separated numbers, preferring the last such line since models often
+	// explain themselves before (and sometimes after) the answer
 	lines := strings.Split(content, "\n")
-	for i := len(lines) - 1; i >= 0 && i >= len(lines)-3; i-- {
-		line := strings.TrimSpace(lines[i])
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(percentileLabel.ReplaceAllString(lines[i], ""))
 		if line == "" {
 			continue
 		}
@@ -107,20 +274,42 @@ func parsePercentiles(content string) (*models.PercentilePredictions, error) {
 	return nil, fmt.Errorf("could not parse percentiles from response: %s", content)
 }
 
+// bareNumber parses a line that holds only a number, optionally labelled ("Final answer: 42%")
+func bareNumber(line string) (float64, bool) {
+	if i := strings.LastIndex(line, ":"); i >= 0 {
+		line = line[i+1:]
+	}
+	line = strings.TrimSpace(line)
+	line = strings.TrimRight(line, ".%")
+	line = strings.TrimLeft(line, "~$")
+	line = strings.ReplaceAll(line, ",", "")
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(line), 64)
+	return value, err == nil
+}
+
 // parsePointEstimate extracts a single numeric value from model response
 // Returns the value as float64 or error if not found
 func parsePointEstimate(content string) (float64, error) {
 	// Trim and clean the response
-	content = strings.TrimSpace(content)
+	content = answerText(content)
 
-	// Look in the last few lines
+	// Any line holding just a number is taken as the answer; in the last few lines a number
+	// embedded in text also counts
 	lines := strings.Split(content, "\n")
-	for i := len(lines) - 1; i >= 0 && i >= len(lines)-3; i-- {
+	for i := len(lines) - 1; i >= 0; i-- {
 		line := strings.TrimSpace(lines[i])
 		if line == "" {
 			continue
 		}
 
+		if num, ok := bareNumber(line); ok {
+			return num, nil
+		}
+		if i < len(lines)-3 {
+			continue
+		}
+
 		// Remove any non-numeric characters except . and -
 		cleaned := ""
 		for _, ch := range line {
@@ -138,10 +327,40 @@ func parsePointEstimate(content string) (float64, error) {
 	return 0, fmt.Errorf("could not parse point estimate from response: %s", content)
 }
 
-// ExecuteForecast runs a forecast
+// parseProbability extracts a single 0-100 probability from model response
+// Values outside the valid range are clamped to [0,100]
+func parseProbability(content string) (float64, error) {
+	value, err := parsePointEstimate(content)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse probability from response: %s", strings.TrimSpace(content))
+	}
+
+	if value < 0 {
+		value = 0
+	}
+	if value > 100 {
+		value = 100
+	}
+
+	return value, nil
+}
+
+// ExecuteForecast runs a forecast. It returns ErrRunInProgress if the forecast is already running,
+// here or in another instance.
 func (f *Forecaster) ExecuteForecast(ctx context.Context, forecastID string) (string, error) {
 	f.logger.Info("starting forecast execution", "forecast_id", forecastID)
 
+	if !claimForecast(forecastID) {
+		return "", fmt.Errorf("%w: forecast %s", ErrRunInProgress, forecastID)
+	}
+	// Ownership of the claim passes to the run once it starts
+	started := false
+	defer func() {
+		if !started {
+			releaseForecast(forecastID)
+		}
+	}()
+
 	// Get forecast config
 	forecast, err := f.forecastRepo.GetForecast(ctx, forecastID)
 	if err != nil {
@@ -150,28 +369,59 @@ func (f *Forecaster) ExecuteForecast(ctx context.Context, forecastID string) (st
 	if forecast == nil {
 		return "", fmt.Errorf("forecast not found: %s", forecastID)
 	}
+	if forecast.ArchivedAt != nil {
+		return "", fmt.Errorf("%w: %s", ErrForecastArchived, forecastID)
+	}
+	// The run outlives the request or scheduler tick that started it, but stays in its workspace
+	runCtx := models.ContextWithWorkspace(context.Background(), forecast.WorkspaceID)
+	// Forecasts are checked when saved; this also catches rows saved before the cap or under a higher one
+	if forecast.Iterations > f.maxIterations {
+		return "", fmt.Errorf("%w: %d > %d", ErrTooManyIterations, forecast.Iterations, f.maxIterations)
+	}
 
 	// Get forecast models
-	models, err := f.forecastRepo.GetForecastModels(ctx, forecastID)
+	forecastModels, err := f.forecastRepo.GetForecastModels(ctx, forecastID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get forecast models: %w", err)
 	}
-	if len(models) == 0 {
+	if len(forecastModels) == 0 {
 		return "", fmt.Errorf("no models configured for forecast: %s", forecastID)
 	}
 
-	// Fetch recent headlines
-	headlines, err := f.fetchHeadlines(ctx, forecast)
+	// A run started by another instance counts until it would have timed out
+	active, err := f.forecastRepo.GetActiveForecastRun(ctx, forecastID, time.Now().Add(-forecastTimeout(forecast, forecastModels)))
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch headlines: %w", err)
+		return "", fmt.Errorf("failed to check for active runs: %w", err)
+	}
+	if active != nil {
+		return "", fmt.Errorf("%w: run %s started at %s", ErrRunInProgress, active.ID, active.RunAt.Format(time.RFC3339))
+	}
+
+	// Fetch recent headlines, unless the forecast is pinned to a fixed set
+	headlines := fixedHeadlines(forecast)
+	if headlines == nil {
+		headlines, err = f.fetchHeadlines(ctx, forecast)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch headlines: %w", err)
+		}
 	}
 
 	f.logger.Info("fetched headlines for forecast",
 		"forecast_id", forecastID,
 		"headline_count", len(headlines))
 
-	// Create forecast run
+	// Only one run may be in progress, so clear out any abandoned by a restart first. Another
+	// instance may still start a run between the check above and here; the database then rejects
+	// this one.
+	if failed, err := f.forecastRepo.FailStaleForecastRuns(ctx, forecastID, "abandoned: no heartbeat", time.Now().Add(-RunStaleAfter)); err != nil {
+		return "", fmt.Errorf("failed to clear abandoned runs: %w", err)
+	} else if failed > 0 {
+		f.logger.Warn("failed abandoned forecast runs", "forecast_id", forecastID, "count", failed)
+	}
 	runID, err := f.forecastRepo.CreateForecastRun(ctx, forecastID, headlines)
+	if errors.Is(err, models.ErrForecastRunActive) {
+		return "", fmt.Errorf("%w: %w", ErrRunInProgress, err)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to create forecast run: %w", err)
 	}
@@ -181,103 +431,310 @@ func (f *Forecaster) ExecuteForecast(ctx context.Context, forecastID string) (st
 		return "", fmt.Errorf("failed to update run status: %w", err)
 	}
 
+	// Register the run before it starts so its progress can be streamed from the first sample
+	progress := startRunProgress(runID, forecastModels, forecast.Iterations)
+
 	// Execute forecast asynchronously
-	go f.executeForecastAsync(context.Background(), runID, forecast, models, headlines)
+	started = true
+	go f.executeForecastAsync(runCtx, runID, progress, forecast, forecastModels, headlines)
 
 	return runID, nil
 }
 
-func (f *Forecaster) executeForecastAsync(ctx context.Context, runID string, forecast *models.Forecast, forecastModels []models.ForecastModel, headlines []models.ForecastHeadline) {
+// ExecuteForecastIdempotent runs a forecast like ExecuteForecast, unless a run was already started
+// with the same idempotency key within IdempotencyKeyWindow, in which case it returns that run with
+// existing set. The key is reserved before the run starts, so a concurrent request with the same
+// key waits for the first one's run instead of racing it. An empty key always starts a run.
+func (f *Forecaster) ExecuteForecastIdempotent(ctx context.Context, forecastID, key string) (runID string, existing bool, err error) {
+	if key == "" {
+		runID, err = f.ExecuteForecast(ctx, forecastID)
+		return runID, false, err
+	}
+
+	for {
+		now := time.Now()
+		previous, reserved, err := f.forecastRepo.ReserveIdempotencyKey(ctx, forecastID, key,
+			now.Add(-IdempotencyKeyWindow), now.Add(-idempotencyReservationTimeout))
+		if err != nil {
+			return "", false, err
+		}
+		if reserved {
+			break
+		}
+		if previous != "" {
+			f.logger.Info("forecast execution already started for idempotency key",
+				"forecast_id", forecastID,
+				"run_id", previous)
+			return previous, true, nil
+		}
+
+		// Another request with the key is starting the run
+		select {
+		case <-ctx.Done():
+			return "", false, fmt.Errorf("waiting for the run started with the idempotency key: %w", ctx.Err())
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+
+	runID, err = f.ExecuteForecast(ctx, forecastID)
+	if err != nil {
+		// Free the key so a retry can start the run
+		if releaseErr := f.forecastRepo.ReleaseIdempotencyKey(context.WithoutCancel(ctx), forecastID, key); releaseErr != nil {
+			f.logger.Warn("failed to release forecast idempotency key",
+				"forecast_id", forecastID,
+				"error", releaseErr)
+		}
+		return "", false, err
+	}
+
+	// The run has started either way, so a failure here only loses protection against retries
+	if err := f.forecastRepo.SetIdempotentRun(ctx, forecastID, key, runID); err != nil {
+		f.logger.Warn("failed to save forecast idempotency key",
+			"forecast_id", forecastID,
+			"run_id", runID,
+			"error", err)
+	}
+	return runID, false, nil
+}
+
+// forecastTimeout returns the maximum duration a run of the forecast may take
+func forecastTimeout(forecast *models.Forecast, forecastModels []models.ForecastModel) time.Duration {
+	if forecast.TimeoutMinutes > 0 {
+		return time.Duration(forecast.TimeoutMinutes) * time.Minute
+	}
+	for _, model := range forecastModels {
+		if model.Provider == models.ProviderOpenAICompatible {
+			return defaultLocalForecastTimeout
+		}
+	}
+	return defaultForecastTimeout
+}
+
+// callTimeout returns the maximum duration of a single call to the model's provider
+func callTimeout(model *models.ForecastModel) time.Duration {
+	if model.Provider == models.ProviderOpenAICompatible {
+		return localCallTimeout
+	}
+	return hostedCallTimeout
+}
+
+// finishRun records a run's final status and ends its progress stream
+func (f *Forecaster) finishRun(ctx context.Context, runID string, progress *runProgress, status, errorMsg string) {
+	if err := f.forecastRepo.UpdateForecastRunStatus(ctx, runID, status, errorMsg); err != nil {
+		f.logger.Error("failed to update run status", "run_id", runID, "status", status, "error", err)
+	}
+	progress.finish(status, errorMsg)
+}
+
+// keepRunAlive refreshes the run's heartbeat until stop is called, so other instances can tell it
+// is still executing
+func (f *Forecaster) keepRunAlive(ctx context.Context, runID string) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(runHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := f.forecastRepo.TouchForecastRun(ctx, runID); err != nil {
+					f.logger.Warn("failed to record forecast run heartbeat", "run_id", runID, "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// executeForecastAsync queries all models and stores the aggregated result. Provider calls run
+// under a context bounded by the forecast timeout that CancelRun can also cancel; ctx itself is
+// only used for persistence so the outcome can still be recorded after the run is stopped.
+func (f *Forecaster) executeForecastAsync(ctx context.Context, runID string, progress *runProgress, forecast *models.Forecast, forecastModels []models.ForecastModel, headlines []models.ForecastHeadline) {
+	defer releaseForecast(forecast.ID)
+	defer f.keepRunAlive(ctx, runID)()
 	defer func() {
 		if r := recover(); r != nil {
 			f.logger.Error("panic in forecast execution", "run_id", runID, "panic", r)
-			f.forecastRepo.UpdateForecastRunStatus(ctx, runID, "failed", fmt.Sprintf("panic: %v", r))
+			f.finishRun(ctx, runID, progress, "failed", fmt.Sprintf("panic: %v", r))
 		}
 	}()
 
-	// Query each model
+	cancelCtx, cancelRun := context.WithCancelCause(ctx)
+	defer cancelRun(nil)
+	progress.setCancel(cancelRun)
+
+	timeout := forecastTimeout(forecast, forecastModels)
+	runCtx, cancel := context.WithTimeout(cancelCtx, timeout)
+	defer cancel()
+
+	// Query all models concurrently; the semaphore bounds provider calls across models and samples
 	var responses []models.ForecastModelResponse
-	var totalWeight float64
+	var timedOut int // Models cut off by the deadline
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, f.maxConcurrentCalls)
 
-	// Use iterations as the number of samples (configurable 1-50)
+	// Use iterations as the number of samples (1 up to the configured maximum)
 	numSamples := forecast.Iterations
 
 	for _, model := range forecastModels {
-		f.logger.Info("querying model",
-			"run_id", runID,
-			"provider", model.Provider,
-			"model", model.ModelName,
-			"num_samples", numSamples)
-
-		startTime := time.Now()
-		response, err := f.queryModel(ctx, forecast, &model, headlines, numSamples)
-		responseTime := int(time.Since(startTime).Milliseconds())
+		wg.Add(1)
+
+		go func(model models.ForecastModel) {
+			defer wg.Done()
+			defer progress.modelDone(&model)
+
+			startTime := time.Now()
+			defer func() {
+				if r := recover(); r != nil {
+					f.logger.Error("panic querying model", "run_id", runID, "model", model.ModelName, "panic", r)
+					responseTime := int(time.Since(startTime).Milliseconds())
+					f.forecastRepo.CreateModelResponse(ctx, models.ForecastModelResponse{
+						RunID:          runID,
+						ModelID:        model.ID,
+						Provider:       model.Provider,
+						ModelName:      model.ModelName,
+						Status:         "failed",
+						ErrorMessage:   fmt.Sprintf("panic: %v", r),
+						ResponseTimeMs: &responseTime,
+					})
+				}
+			}()
 
-		if err != nil {
-			f.logger.Error("model query failed",
+			f.logger.Info("querying model",
 				"run_id", runID,
 				"provider", model.Provider,
 				"model", model.ModelName,
-				"error", err)
+				"num_samples", numSamples)
+
+			response, err := f.queryModel(runCtx, forecast, &model, headlines, numSamples, semaphore, progress)
+			responseTime := int(time.Since(startTime).Milliseconds())
 
-			// Store failed response
-			failedResp := models.ForecastModelResponse{
-				RunID:          runID,
-				ModelID:        model.ID,
-				Provider:       model.Provider,
-				ModelName:      model.ModelName,
-				Status:         "failed",
-				ErrorMessage:   err.Error(),
-				ResponseTimeMs: &responseTime,
+			if err != nil {
+				f.logger.Error("model query failed",
+					"run_id", runID,
+					"provider", model.Provider,
+					"model", model.ModelName,
+					"error", err)
+				if runCtx.Err() == context.DeadlineExceeded {
+					mu.Lock()
+					timedOut++
+					mu.Unlock()
+				}
+
+				// Store failed response
+				failedResp := models.ForecastModelResponse{
+					RunID:          runID,
+					ModelID:        model.ID,
+					Provider:       model.Provider,
+					ModelName:      model.ModelName,
+					Status:         "failed",
+					ErrorMessage:   err.Error(),
+					ResponseTimeMs: &responseTime,
+				}
+				f.forecastRepo.CreateModelResponse(ctx, failedResp)
+				return
 			}
-			f.forecastRepo.CreateModelResponse(ctx, failedResp)
-			continue
-		}
 
-		// Update response with run metadata
-		response.RunID = runID
-		response.ResponseTimeMs = &responseTime
+			// Update response with run metadata
+			response.RunID = runID
+			response.ResponseTimeMs = &responseTime
 
-		responses = append(responses, *response)
-		totalWeight += model.Weight
+			// Store response as soon as it completes
+			if f.storeSamples {
+				response.ID = uuid.New().String()
+			}
+			if err := f.forecastRepo.CreateModelResponse(ctx, *response); err != nil {
+				f.logger.Error("failed to store model response", "error", err)
+			} else if f.storeSamples {
+				f.saveSamples(ctx, response)
+			}
 
-		// Store response
-		if err := f.forecastRepo.CreateModelResponse(ctx, *response); err != nil {
-			f.logger.Error("failed to store model response", "error", err)
-		}
+			mu.Lock()
+			responses = append(responses, *response)
+			mu.Unlock()
+		}(model)
+	}
+
+	wg.Wait()
+
+	// Responses gathered before cancellation have already been stored; record the reason and stop
+	if context.Cause(runCtx) == errRunCancelled {
+		f.logger.Info("forecast execution cancelled", "run_id", runID, "completed_models", len(responses))
+		f.finishRun(ctx, runID, progress, "failed", errRunCancelled.Error())
+		return
+	}
+
+	// Completed model responses have already been stored; record the timeout and stop. A deadline
+	// that passes after every model has returned doesn't fail the run.
+	if timedOut > 0 {
+		msg := fmt.Sprintf("forecast timed out after %s (%d of %d models completed)", timeout, len(responses), len(forecastModels))
+		f.logger.Error("forecast execution timed out", "run_id", runID, "timeout", timeout, "completed_models", len(responses))
+		f.finishRun(ctx, runID, progress, "failed", msg)
+		return
 	}
 
 	if len(responses) == 0 {
-		f.forecastRepo.UpdateForecastRunStatus(ctx, runID, "failed", "all models failed")
+		f.finishRun(ctx, runID, progress, "failed", "all models failed")
 		return
 	}
 
 	// Calculate weighted average
-	result := f.calculateWeightedResult(responses, forecastModels, totalWeight)
+	result, err := f.calculateWeightedResult(responses, forecastModels)
+	if err != nil {
+		f.logger.Error("failed to aggregate forecast responses", "run_id", runID, "error", err)
+		f.finishRun(ctx, runID, progress, "failed", fmt.Sprintf("failed to aggregate responses: %v", err))
+		return
+	}
 	result.RunID = runID
+	result.HeadlinesWidened, result.SparseHeadlines = headlineCoverage(headlines, f.minHeadlinesFor(forecast))
+
+	if forecast.ExplainResult {
+		result.Explanation = f.explainResult(runCtx, forecast, forecastModels, headlines, responses, result)
+	}
 
 	// Store result
 	if err := f.forecastRepo.CreateForecastResult(ctx, result); err != nil {
 		f.logger.Error("failed to store forecast result", "error", err)
-		f.forecastRepo.UpdateForecastRunStatus(ctx, runID, "failed", fmt.Sprintf("failed to store result: %v", err))
+		f.finishRun(ctx, runID, progress, "failed", fmt.Sprintf("failed to store result: %v", err))
 		return
 	}
 
 	// Mark run as completed
-	f.forecastRepo.UpdateForecastRunStatus(ctx, runID, "completed", "")
+	f.finishRun(ctx, runID, progress, "completed", "")
+
+	// Alert if the models diverged sharply
+	f.checkDisagreement(ctx, forecast, runID, result, responses)
+
+	// Retries can take a while; don't hold the forecast's run claim while they do
+	go f.notifyCompletion(ctx, forecast, runID, result)
 
 	f.logger.Info("forecast execution completed",
 		"run_id", runID,
 		"model_count", result.ModelCount)
 }
 
+// fetchHeadlines returns the forecast's headlines: the newest HeadlineCount events, or with
+// relevant selection the HeadlineCount most related to the proposition among a larger pool of
+// the newest events
 func (f *Forecaster) fetchHeadlines(ctx context.Context, forecast *models.Forecast) ([]models.ForecastHeadline, error) {
-	// Build query
+	relevant := forecast.HeadlineSelection == models.HeadlineSelectionRelevant
+
+	limit := forecast.HeadlineCount
+	if relevant {
+		limit = min(forecast.HeadlineCount*relevanceCandidateFactor, maxRelevanceCandidates)
+	}
+
+	// Build query; headlines come from the forecast's own workspace
 	query := models.EventQuery{
-		Limit:     forecast.HeadlineCount,
-		Page:      1,
-		SortBy:    "timestamp",
-		SortOrder: "desc",
+		Limit:       limit,
+		Page:        1,
+		SortBy:      "timestamp",
+		SortOrder:   "desc",
+		WorkspaceID: models.WorkspaceOrDefault(forecast.WorkspaceID),
 	}
 
 	// Filter by categories if specified
@@ -295,34 +752,191 @@ func (f *Forecaster) fetchHeadlines(ctx context.Context, forecast *models.Foreca
 		return nil, err
 	}
 
+	events := resp.Events
+	if relevant {
+		events = f.selectRelevantEvents(ctx, forecast, query, events, forecast.HeadlineCount)
+	}
+
 	f.logger.Info("fetched headlines from database",
 		"requested", forecast.HeadlineCount,
-		"received", len(resp.Events),
+		"candidates", len(resp.Events),
+		"received", len(events),
+		"selection", forecast.HeadlineSelection,
 		"categories", forecast.Categories)
 
+	// Too few recent events in the forecast's categories: top up from every category
+	var outside map[string]bool
+	if len(events) < f.minHeadlinesFor(forecast) && len(query.Categories) > 0 {
+		extra, err := f.widenHeadlines(ctx, forecast, query, events)
+		if err != nil {
+			f.logger.Warn("failed to widen headlines beyond the forecast's categories",
+				"forecast_id", forecast.ID,
+				"error", err)
+		}
+		if len(extra) > 0 {
+			f.logger.Info("widened headlines beyond the forecast's categories",
+				"forecast_id", forecast.ID,
+				"matched", len(events),
+				"added", len(extra))
+			outside = make(map[string]bool, len(extra))
+			for _, event := range extra {
+				outside[event.ID] = true
+			}
+			events = append(events, extra...)
+			sort.SliceStable(events, func(i, j int) bool {
+				return events[i].Timestamp.After(events[j].Timestamp)
+			})
+		}
+	}
+
 	// Convert to headlines
-	headlines := make([]models.ForecastHeadline, 0, len(resp.Events))
-	for _, event := range resp.Events {
-		headlines = append(headlines, models.ForecastHeadline{
-			EventID:   event.ID,
-			Title:     event.Title,
-			Category:  string(event.Category),
-			Magnitude: event.Magnitude,
-			Timestamp: event.Timestamp,
-		})
+	headlines := make([]models.ForecastHeadline, 0, len(events))
+	for _, event := range events {
+		headline := models.ForecastHeadline{
+			EventID:           event.ID,
+			Title:             event.Title,
+			Category:          string(event.Category),
+			Magnitude:         event.Magnitude,
+			Timestamp:         event.Timestamp,
+			OutsideCategories: outside[event.ID],
+		}
+		if forecast.IncludeSummaries {
+			summary, truncated := truncateText(strings.Join(strings.Fields(event.Summary), " "), maxHeadlineSummaryChars)
+			if truncated {
+				summary += "..."
+			}
+			headline.Summary = summary
+		}
+		headlines = append(headlines, headline)
 	}
 
 	return headlines, nil
 }
 
-func (f *Forecaster) queryModel(ctx context.Context, forecast *models.Forecast, model *models.ForecastModel, headlines []models.ForecastHeadline, numSamples int) (*models.ForecastModelResponse, error) {
+// fixedHeadlines returns a copy of the headlines a forecast is pinned to, or nil if it isn't.
+// Their summaries are kept, and truncated like fetched ones, only when the forecast includes them.
+func fixedHeadlines(forecast *models.Forecast) []models.ForecastHeadline {
+	if len(forecast.FixedHeadlines) == 0 {
+		return nil
+	}
+	headlines := make([]models.ForecastHeadline, len(forecast.FixedHeadlines))
+	copy(headlines, forecast.FixedHeadlines)
+	for i := range headlines {
+		if !forecast.IncludeSummaries {
+			headlines[i].Summary = ""
+			continue
+		}
+		summary, truncated := truncateText(strings.Join(strings.Fields(headlines[i].Summary), " "), maxHeadlineSummaryChars)
+		if truncated {
+			summary += "..."
+		}
+		headlines[i].Summary = summary
+	}
+	return headlines
+}
+
+// headlineDate returns the date a headline is listed with in prompts, or nothing for a fixed
+// headline entered without a timestamp
+func headlineDate(headline models.ForecastHeadline) string {
+	if headline.Timestamp.IsZero() {
+		return ""
+	}
+	return " (" + headline.Timestamp.Format("2006-01-02") + ")"
+}
+
+// widenHeadlines picks events from any category to top the forecast's headlines up to its
+// HeadlineCount, the same way the category-filtered ones were picked, skipping events already had
+func (f *Forecaster) widenHeadlines(ctx context.Context, forecast *models.Forecast, query models.EventQuery, had []models.Event) ([]models.Event, error) {
+	wide := query
+	wide.Categories = nil
+	wide.Limit = query.Limit + len(had) // Room for the events already had, which are skipped
+
+	resp, err := f.eventRepo.Query(ctx, wide)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(had))
+	for _, event := range had {
+		seen[event.ID] = true
+	}
+	pool := make([]models.Event, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		if !seen[event.ID] {
+			pool = append(pool, event)
+		}
+	}
+
+	need := forecast.HeadlineCount - len(had)
+	if forecast.HeadlineSelection == models.HeadlineSelectionRelevant {
+		pool = f.selectRelevantEvents(ctx, forecast, wide, pool, need)
+	}
+
+	extra := make([]models.Event, 0, need)
+	for _, event := range pool {
+		if len(extra) == need {
+			break
+		}
+		// Embedding search may bring back events already had
+		if !seen[event.ID] {
+			seen[event.ID] = true
+			extra = append(extra, event)
+		}
+	}
+	return extra, nil
+}
+
+// headlineCoverage reports whether a run's headlines were widened beyond the forecast's
+// categories, and whether they still number fewer than minimum
+func headlineCoverage(headlines []models.ForecastHeadline, minimum int) (widened, sparse bool) {
+	for _, headline := range headlines {
+		if headline.OutsideCategories {
+			widened = true
+			break
+		}
+	}
+	return widened, len(headlines) < minimum
+}
+
+// fitSummaries keeps headline summaries, in order, while their estimated tokens fit within
+// budget and drops the rest. It returns a copy of the headlines and how many kept a summary.
+func fitSummaries(headlines []models.ForecastHeadline, budget int) ([]models.ForecastHeadline, int) {
+	fitted := make([]models.ForecastHeadline, len(headlines))
+	copy(fitted, headlines)
+
+	summarized := 0
+	full := false
+	for i := range fitted {
+		if fitted[i].Summary == "" {
+			continue
+		}
+		cost := len(fitted[i].Summary)/charsPerToken + 1
+		if full || cost > budget {
+			// Stop at the first summary that doesn't fit so older headlines never carry
+			// details the newer ones lack
+			full = true
+			fitted[i].Summary = ""
+			continue
+		}
+		budget -= cost
+		summarized++
+	}
+	return fitted, summarized
+}
+
+func (f *Forecaster) queryModel(ctx context.Context, forecast *models.Forecast, model *models.ForecastModel, headlines []models.ForecastHeadline, numSamples int, semaphore chan struct{}, progress *runProgress) (*models.ForecastModelResponse, error) {
 	// Get max context length for this model
 	maxTokens := f.getModelContextLength(model)
 
 	// Truncate headlines if needed to fit in context window
-	// Reserve ~1500 tokens for system prompt, proposition, and response
+	// Reserve ~1500 tokens for system prompt, proposition, and response,
+	// plus the share of the window set aside for context URL content
 	// Estimate ~80 tokens per headline on average
-	maxHeadlines := (maxTokens - 1500) / 80
+	reservedTokens := 1500
+	if len(forecast.ContextURLs) > 0 {
+		reservedTokens += int(float64(maxTokens) * contextURLTokenShare)
+	}
+	maxHeadlines := (maxTokens - reservedTokens) / tokensPerHeadline
 	if maxHeadlines < 10 {
 		maxHeadlines = 10 // Always include at least 10 headlines
 	}
@@ -343,8 +957,18 @@ func (f *Forecaster) queryModel(ctx context.Context, forecast *models.Forecast,
 			"max_tokens", maxTokens)
 	}
 
+	// Summaries only get the room left once the headlines themselves fit
+	if forecast.IncludeSummaries {
+		var summarized int
+		truncatedHeadlines, summarized = fitSummaries(truncatedHeadlines, maxTokens-reservedTokens-len(truncatedHeadlines)*tokensPerHeadline)
+		f.logger.Info("including headline summaries",
+			"model", model.ModelName,
+			"summarized", summarized,
+			"headline_count", len(truncatedHeadlines))
+	}
+
 	// Build prompt with context from URLs if provided
-	prompt, err := f.buildForecastPrompt(ctx, forecast, truncatedHeadlines)
+	prompt, err := f.buildForecastPrompt(ctx, forecast, truncatedHeadlines, contextCharBudget(maxTokens, len(forecast.ContextURLs)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build prompt: %w", err)
 	}
@@ -357,14 +981,15 @@ func (f *Forecaster) queryModel(ctx context.Context, forecast *models.Forecast,
 		"prediction_type", forecast.PredictionType)
 
 	// Use unified query function for all providers
-	return f.queryModelUnified(ctx, forecast, model, prompt, numSamples)
+	return f.queryModelUnified(ctx, forecast, model, prompt, numSamples, semaphore, progress)
 }
 
-func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.Forecast, model *models.ForecastModel, prompt string, numSamples int) (*models.ForecastModelResponse, error) {
+func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.Forecast, model *models.ForecastModel, prompt string, numSamples int, semaphore chan struct{}, progress *runProgress) (*models.ForecastModelResponse, error) {
 	// System prompt adapted for value-based predictions
 	systemPrompt := "You are an expert intelligence analyst providing forecasts based on evidence. Analyze the data carefully and provide your forecast in the exact format requested."
 
-	isPercentile := forecast.PredictionType == "percentile"
+	isPercentile := forecast.PredictionType == models.PredictionTypePercentile
+	isProbability := forecast.PredictionType == models.PredictionTypeProbability
 
 	var allResponses []string
 	var totalTokens int
@@ -373,29 +998,67 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 	// For percentile forecasts
 	var percentileSamples []models.PercentilePredictions
 
-	// For point estimate forecasts
+	// For point estimate and probability forecasts
 	var pointEstimates []float64
 
+	// Every parsed sample, in order, for storing individually
+	var parsedSamples []models.ForecastSample
+
 	f.logger.Info("starting forecast sampling",
 		"model", model.ModelName,
 		"provider", model.Provider,
 		"num_samples", numSamples,
 		"prediction_type", forecast.PredictionType)
 
-	// Run multiple samples
+	switch model.Provider {
+	case models.ProviderOpenAI, models.ProviderOpenAICompatible, "anthropic":
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", model.Provider)
+	}
+
+	// Run multiple samples concurrently, bounded by the shared semaphore
+	type sampleResult struct {
+		content string
+		tokens  int
+		err     error
+	}
+	samples := make([]sampleResult, numSamples)
+
+	var wg sync.WaitGroup
 	for i := 0; i < numSamples; i++ {
-		var content string
-		var tokens int
-		var err error
+		wg.Add(1)
 
-		switch model.Provider {
-		case "openai":
-			content, tokens, err = f.callOpenAI(ctx, model, systemPrompt, prompt)
-		case "anthropic":
-			content, tokens, err = f.callAnthropic(ctx, model, systemPrompt, prompt)
-		default:
-			return nil, fmt.Errorf("unsupported provider: %s", model.Provider)
-		}
+		go func(i int) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				samples[i] = sampleResult{err: ctx.Err()}
+				return
+			}
+
+			callCtx, cancel := context.WithTimeout(ctx, callTimeout(model))
+			defer cancel()
+
+			var result sampleResult
+			switch model.Provider {
+			case models.ProviderOpenAI, models.ProviderOpenAICompatible:
+				// OpenAI-compatible servers are reached through the OpenAI client at the model's base URL
+				result.content, result.tokens, result.err = f.callOpenAI(callCtx, model, taskForecastGeneration, systemPrompt, prompt)
+			case "anthropic":
+				result.content, result.tokens, result.err = f.callAnthropic(callCtx, model, taskForecastGeneration, systemPrompt, prompt)
+			}
+			samples[i] = result
+			progress.sampleDone(model)
+		}(i)
+	}
+	wg.Wait()
+
+	// Parse samples in order
+	for i, sample := range samples {
+		content, tokens, err := sample.content, sample.tokens, sample.err
 
 		if err != nil {
 			f.logger.Error("sample failed", "sample", i+1, "error", err)
@@ -431,6 +1094,20 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 				"p90", percentiles.P90)
 
 			percentileSamples = append(percentileSamples, *percentiles)
+			parsedSamples = append(parsedSamples, models.ForecastSample{ModelID: model.ID, SampleIndex: i, Percentiles: percentiles})
+		} else if isProbability {
+			value, err := parseProbability(content)
+			if err != nil {
+				f.logger.Warn("failed to parse probability", "sample", i+1, "error", err, "content", content)
+				continue
+			}
+
+			f.logger.Info("PARSED PROBABILITY",
+				"sample", i+1,
+				"value", value)
+
+			pointEstimates = append(pointEstimates, value)
+			parsedSamples = append(parsedSamples, models.ForecastSample{ModelID: model.ID, SampleIndex: i, Value: &value})
 		} else {
 			// Point estimate
 			value, err := parsePointEstimate(content)
@@ -444,6 +1121,7 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 				"value", value)
 
 			pointEstimates = append(pointEstimates, value)
+			parsedSamples = append(parsedSamples, models.ForecastSample{ModelID: model.ID, SampleIndex: i, Value: &value})
 		}
 
 		if (i+1)%10 == 0 {
@@ -466,6 +1144,16 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 		}, fmt.Errorf("no valid percentile responses")
 	}
 
+	if isProbability && len(pointEstimates) == 0 {
+		return &models.ForecastModelResponse{
+			ModelID:      model.ID,
+			Provider:     model.Provider,
+			ModelName:    model.ModelName,
+			Status:       "failed",
+			ErrorMessage: fmt.Sprintf("no valid probability responses after %d samples", numSamples),
+		}, fmt.Errorf("no valid probability responses")
+	}
+
 	if !isPercentile && len(pointEstimates) == 0 {
 		return &models.ForecastModelResponse{
 			ModelID:      model.ID,
@@ -483,6 +1171,7 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 		Reasoning:  firstContent,
 		TokensUsed: &totalTokens,
 		Status:     "completed",
+		Samples:    parsedSamples,
 		RawResponse: map[string]interface{}{
 			"model":          model.ModelName,
 			"num_samples":    numSamples,
@@ -505,6 +1194,20 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 			"avg_p50", avgPercentiles.P50,
 			"avg_p75", avgPercentiles.P75,
 			"avg_p90", avgPercentiles.P90)
+	} else if isProbability {
+		// Average the probability samples
+		var sum float64
+		for _, v := range pointEstimates {
+			sum += v
+		}
+		avgValue := sum / float64(len(pointEstimates))
+		response.Probability = &avgValue
+		response.RawResponse["valid_samples"] = len(pointEstimates)
+		response.RawResponse["all_probabilities"] = pointEstimates
+
+		f.logger.Info("probability sampling complete",
+			"valid_samples", len(pointEstimates),
+			"avg_probability", avgValue)
 	} else {
 		// Average the point estimates
 		var sum float64
@@ -524,6 +1227,18 @@ func (f *Forecaster) queryModelUnified(ctx context.Context, forecast *models.For
 	return response, nil
 }
 
+// saveSamples stores the samples of a stored model response. Failing to store them is logged and
+// leaves the response and run as they are.
+func (f *Forecaster) saveSamples(ctx context.Context, response *models.ForecastModelResponse) {
+	for i := range response.Samples {
+		response.Samples[i].ResponseID = response.ID
+		response.Samples[i].RunID = response.RunID
+	}
+	if err := f.forecastRepo.CreateForecastSamples(ctx, response.Samples); err != nil {
+		f.logger.Warn("failed to store forecast samples", "run_id", response.RunID, "model", response.ModelName, "error", err)
+	}
+}
+
 // averagePercentiles calculates the average of multiple percentile predictions
 func averagePercentiles(samples []models.PercentilePredictions) models.PercentilePredictions {
 	if len(samples) == 0 {
@@ -588,18 +1303,23 @@ func (f *Forecaster) getModelContextLength(model *models.ForecastModel) int {
 	return 4096
 }
 
-func (f *Forecaster) buildForecastPrompt(ctx context.Context, forecast *models.Forecast, headlines []models.ForecastHeadline) (string, error) {
+// buildForecastPrompt builds the forecast prompt; maxContextChars bounds how much text each
+// context URL may contribute
+func (f *Forecaster) buildForecastPrompt(ctx context.Context, forecast *models.Forecast, headlines []models.ForecastHeadline, maxContextChars int) (string, error) {
 	var sb strings.Builder
 
 	sb.WriteString("You are an expert intelligence analyst providing objective forecasts based on OSINT signals.\n\n")
 
 	sb.WriteString(fmt.Sprintf("QUESTION: %s\n\n", forecast.Proposition))
 
-	// Determine if this is a percentile or point estimate forecast
-	isPercentile := forecast.PredictionType == "percentile"
+	// Determine the kind of forecast being requested
+	isPercentile := forecast.PredictionType == models.PredictionTypePercentile
+	isProbability := forecast.PredictionType == models.PredictionTypeProbability
 
 	if isPercentile {
 		sb.WriteString(fmt.Sprintf("Review the %d intelligence signals below and provide a percentile-based forecast distribution.\n\n", len(headlines)))
+	} else if isProbability {
+		sb.WriteString(fmt.Sprintf("Review the %d intelligence signals below and provide the probability that the event occurs.\n\n", len(headlines)))
 	} else {
 		sb.WriteString(fmt.Sprintf("Review the %d intelligence signals below and provide a point estimate forecast.\n\n", len(headlines)))
 	}
@@ -624,7 +1344,7 @@ func (f *Forecaster) buildForecastPrompt(ctx context.Context, forecast *models.F
 		for i, url := range forecast.ContextURLs {
 			f.logger.Info("fetching context from URL", "url", url, "index", i+1)
 
-			content, err := f.fetchURLContent(ctx, url)
+			content, err := f.fetchURLContent(ctx, url, maxContextChars)
 			if err != nil {
 				f.logger.Error("failed to fetch URL content", "url", url, "error", err)
 				sb.WriteString(fmt.Sprintf("%d. [FAILED TO FETCH: %s] Error: %v\n\n", i+1, url, err))
@@ -637,14 +1357,26 @@ func (f *Forecaster) buildForecastPrompt(ctx context.Context, forecast *models.F
 		sb.WriteString("---\n\n")
 	}
 
+	sb.WriteString(sparseSignalsNote(headlines, f.minHeadlinesFor(forecast)))
+
 	sb.WriteString("INTELLIGENCE SIGNALS (most recent first):\n")
+	if len(headlines) == 0 {
+		sb.WriteString("None available.\n")
+	}
 	for i, headline := range headlines {
-		sb.WriteString(fmt.Sprintf("%d. [%s | MAG %.1f] %s (%s)\n",
+		category := headline.Category
+		if headline.OutsideCategories {
+			category += ", OUTSIDE TOPIC"
+		}
+		sb.WriteString(fmt.Sprintf("%d. [%s | MAG %.1f] %s%s\n",
 			i+1,
-			headline.Category,
+			category,
 			headline.Magnitude,
 			headline.Title,
-			headline.Timestamp.Format("2006-01-02")))
+			headlineDate(headline)))
+		if headline.Summary != "" {
+			sb.WriteString(fmt.Sprintf("   %s\n", headline.Summary))
+		}
 	}
 
 	sb.WriteString("\n\n=== RESPONSE INSTRUCTIONS ===\n")
@@ -667,6 +1399,16 @@ func (f *Forecaster) buildForecastPrompt(ctx context.Context, forecast *models.F
 		sb.WriteString("- Units or % symbols\n")
 		sb.WriteString("- Any other text\n\n")
 		sb.WriteString("Respond now with ONLY the five comma-separated numbers:")
+	} else if isProbability {
+		sb.WriteString("Provide the probability (0 to 100) that the answer to the question is YES.\n")
+		sb.WriteString("0 means it certainly will not happen, 100 means it certainly will happen.\n\n")
+		sb.WriteString("CRITICAL: Your response MUST contain ONLY a single number between 0 and 100.\n")
+		sb.WriteString("Do NOT include:\n")
+		sb.WriteString("- Reasoning or explanation\n")
+		sb.WriteString("- % symbols or labels\n")
+		sb.WriteString("- Any other text\n\n")
+		sb.WriteString("Example valid responses: 35 or 72.5 or 4\n")
+		sb.WriteString("Respond now with ONLY the probability:")
 	} else {
 		sb.WriteString("Provide your best point estimate for the question.\n")
 		sb.WriteString(fmt.Sprintf("Express your answer in %s.\n\n", forecast.Units))
@@ -682,9 +1424,31 @@ func (f *Forecaster) buildForecastPrompt(ctx context.Context, forecast *models.F
 	return sb.String(), nil
 }
 
-// callOpenAI makes a single OpenAI API call and returns (content, tokens, error)
-func (f *Forecaster) callOpenAI(ctx context.Context, model *models.ForecastModel, systemPrompt, userPrompt string) (string, int, error) {
-	client := openai.NewClient(model.APIKey)
+// sparseSignalsNote tells the model when its signals are thin or partly off-topic, so it leans on
+// base rates rather than reading much into them. Empty when neither applies.
+func sparseSignalsNote(headlines []models.ForecastHeadline, minimum int) string {
+	widened, sparse := headlineCoverage(headlines, minimum)
+	var sb strings.Builder
+	if sparse {
+		sb.WriteString(fmt.Sprintf("NOTE: Only %d recent signal(s) were available for this question, so the evidence is sparse. Rely mainly on base rates and keep your uncertainty wide.\n", len(headlines)))
+	}
+	if widened {
+		sb.WriteString("NOTE: Too few recent signals matched this question's topics, so signals marked OUTSIDE TOPIC were added from other categories; they may bear on the question only indirectly.\n")
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// callOpenAI makes a single OpenAI API call, logged as an inference call for task, and returns
+// (content, tokens, error)
+func (f *Forecaster) callOpenAI(ctx context.Context, model *models.ForecastModel, task, systemPrompt, userPrompt string) (string, int, error) {
+	if err := f.inferenceLogger.CheckBudget(ctx); err != nil {
+		return "", 0, err
+	}
+
+	client := inference.NewOpenAIClient(model.APIKey, model.Endpoint())
 	modelNameLower := strings.ToLower(model.ModelName)
 
 	// Reasoning models (o1, o3, o4) don't support system messages or temperature
@@ -755,7 +1519,7 @@ func (f *Forecaster) callOpenAI(ctx context.Context, model *models.ForecastModel
 			usage.CompletionTokens = resp.Usage.CompletionTokens
 			usage.TotalTokens = resp.Usage.TotalTokens
 		}
-		f.inferenceLogger.LogOpenAICall(ctx, model.ModelName, "forecast_generation", usage, latency, err, map[string]interface{}{
+		f.inferenceLogger.LogOpenAIProviderCall(ctx, model.Provider, model.ModelName, task, usage, latency, err, map[string]interface{}{
 			"model_id": model.ID,
 		})
 	}
@@ -778,8 +1542,13 @@ func (f *Forecaster) callOpenAI(ctx context.Context, model *models.ForecastModel
 	return content, tokens, nil
 }
 
-// callAnthropic makes a single Anthropic API call and returns (content, tokens, error)
-func (f *Forecaster) callAnthropic(ctx context.Context, model *models.ForecastModel, systemPrompt, userPrompt string) (string, int, error) {
+// callAnthropic makes a single Anthropic API call, logged as an inference call for task, and
+// returns (content, tokens, error)
+func (f *Forecaster) callAnthropic(ctx context.Context, model *models.ForecastModel, task, systemPrompt, userPrompt string) (string, int, error) {
+	if err := f.inferenceLogger.CheckBudget(ctx); err != nil {
+		return "", 0, err
+	}
+
 	client := anthropic.NewClient(option.WithAPIKey(model.APIKey))
 
 	req := anthropic.MessageNewParams{
@@ -808,7 +1577,7 @@ func (f *Forecaster) callAnthropic(ctx context.Context, model *models.ForecastMo
 			usage.InputTokens = int(resp.Usage.InputTokens)
 			usage.OutputTokens = int(resp.Usage.OutputTokens)
 		}
-		f.inferenceLogger.LogAnthropicCall(ctx, model.ModelName, "forecast_generation", usage, latency, err, map[string]interface{}{
+		f.inferenceLogger.LogAnthropicCall(ctx, model.ModelName, task, usage, latency, err, map[string]interface{}{
 			"model_id": model.ID,
 		})
 	}
@@ -838,26 +1607,31 @@ func (f *Forecaster) callAnthropic(ctx context.Context, model *models.ForecastMo
 	return content, tokens, nil
 }
 
-func (f *Forecaster) calculateWeightedResult(responses []models.ForecastModelResponse, modelConfigs []models.ForecastModel, totalWeight float64) models.ForecastResult {
+// calculateWeightedResult aggregates the completed responses into a weighted mean, normalized by
+// the weights of the responses that contributed a value. It returns ErrNoValidResponses when no
+// response has a value and ErrNoUsableWeight when those responses' weights sum to zero, rather
+// than a misleading aggregate.
+func (f *Forecaster) calculateWeightedResult(responses []models.ForecastModelResponse, modelConfigs []models.ForecastModel) (models.ForecastResult, error) {
 	// Build model weight map
 	weights := make(map[string]float64)
 	for _, config := range modelConfigs {
 		weights[config.ID] = config.Weight
 	}
 
-	// Determine if this is percentile or point estimate based on first valid response
-	var isPercentile bool
+	// Determine the prediction type based on first valid response
+	var isPercentile, isProbability bool
 	for _, resp := range responses {
 		if resp.Status == "completed" {
 			isPercentile = resp.PercentilePredictions != nil
+			isProbability = resp.Probability != nil
 			break
 		}
 	}
 
-	var validCount int
-	var consensus *float64
-
 	if isPercentile {
+		var validCount int
+		var totalWeight float64
+
 		// Calculate weighted average of percentiles
 		var weightedP10, weightedP25, weightedP50, weightedP75, weightedP90 float64
 
@@ -872,18 +1646,22 @@ func (f *Forecaster) calculateWeightedResult(responses []models.ForecastModelRes
 			weightedP50 += resp.PercentilePredictions.P50 * weight
 			weightedP75 += resp.PercentilePredictions.P75 * weight
 			weightedP90 += resp.PercentilePredictions.P90 * weight
+			totalWeight += weight
 			validCount++
 		}
 
-		if totalWeight > 0 {
-			weightedP10 /= totalWeight
-			weightedP25 /= totalWeight
-			weightedP50 /= totalWeight
-			weightedP75 /= totalWeight
-			weightedP90 /= totalWeight
+		if err := checkAggregate(validCount, totalWeight); err != nil {
+			return models.ForecastResult{}, err
 		}
 
+		weightedP10 /= totalWeight
+		weightedP25 /= totalWeight
+		weightedP50 /= totalWeight
+		weightedP75 /= totalWeight
+		weightedP90 /= totalWeight
+
 		// Calculate consensus based on variance in median estimates (P50)
+		var consensus *float64
 		if validCount > 1 {
 			var sumSquaredDiff float64
 			for _, resp := range responses {
@@ -907,53 +1685,97 @@ func (f *Forecaster) calculateWeightedResult(responses []models.ForecastModelRes
 			},
 			ModelCount:     validCount,
 			ConsensusLevel: consensus,
+		}, nil
+	}
+
+	if isProbability {
+		// Calculate weighted average of probabilities
+		weightedProbability, validCount, consensus, err := weightedScalar(responses, weights, func(resp models.ForecastModelResponse) *float64 {
+			return resp.Probability
+		})
+		if err != nil {
+			return models.ForecastResult{}, err
 		}
-	} else {
-		// Calculate weighted average of point estimates
-		var weightedEstimate float64
 
-		for _, resp := range responses {
-			if resp.Status != "completed" || resp.PointEstimate == nil {
-				continue
-			}
+		return models.ForecastResult{
+			AggregatedProbability: &weightedProbability,
+			ModelCount:            validCount,
+			ConsensusLevel:        consensus,
+		}, nil
+	}
 
-			weight := weights[resp.ModelID]
-			weightedEstimate += *resp.PointEstimate * weight
-			validCount++
-		}
+	// Calculate weighted average of point estimates
+	weightedEstimate, validCount, consensus, err := weightedScalar(responses, weights, func(resp models.ForecastModelResponse) *float64 {
+		return resp.PointEstimate
+	})
+	if err != nil {
+		return models.ForecastResult{}, err
+	}
 
-		if totalWeight > 0 {
-			weightedEstimate /= totalWeight
-		}
+	return models.ForecastResult{
+		AggregatedPointEstimate: &weightedEstimate,
+		ModelCount:              validCount,
+		ConsensusLevel:          consensus,
+	}, nil
+}
 
-		// Calculate consensus based on variance in point estimates
-		if validCount > 1 {
-			var sumSquaredDiff float64
-			for _, resp := range responses {
-				if resp.Status != "completed" || resp.PointEstimate == nil {
-					continue
-				}
-				diff := *resp.PointEstimate - weightedEstimate
-				sumSquaredDiff += diff * diff
-			}
-			stdDev := math.Sqrt(sumSquaredDiff / float64(validCount))
-			consensus = &stdDev
+// checkAggregate reports whether validCount responses with a combined weight of totalWeight
+// can produce a meaningful weighted mean
+func checkAggregate(validCount int, totalWeight float64) error {
+	if validCount == 0 {
+		return ErrNoValidResponses
+	}
+	if !(totalWeight > 0) || math.IsInf(totalWeight, 0) {
+		return fmt.Errorf("%w: %d responses with total weight %v", ErrNoUsableWeight, validCount, totalWeight)
+	}
+	return nil
+}
+
+// weightedScalar computes the weighted mean of a single-valued prediction across completed
+// responses, along with the number of valid responses and the standard deviation (consensus)
+func weightedScalar(responses []models.ForecastModelResponse, weights map[string]float64, value func(models.ForecastModelResponse) *float64) (float64, int, *float64, error) {
+	var weighted, totalWeight float64
+	var validCount int
+
+	for _, resp := range responses {
+		v := value(resp)
+		if resp.Status != "completed" || v == nil {
+			continue
 		}
 
-		return models.ForecastResult{
-			AggregatedPointEstimate: &weightedEstimate,
-			ModelCount:              validCount,
-			ConsensusLevel:          consensus,
+		weighted += *v * weights[resp.ModelID]
+		totalWeight += weights[resp.ModelID]
+		validCount++
+	}
+
+	if err := checkAggregate(validCount, totalWeight); err != nil {
+		return 0, 0, nil, err
+	}
+	weighted /= totalWeight
+
+	// Calculate consensus based on variance in values
+	var consensus *float64
+	if validCount > 1 {
+		var sumSquaredDiff float64
+		for _, resp := range responses {
+			v := value(resp)
+			if resp.Status != "completed" || v == nil {
+				continue
+			}
+			diff := *v - weighted
+			sumSquaredDiff += diff * diff
 		}
+		stdDev := math.Sqrt(sumSquaredDiff / float64(validCount))
+		consensus = &stdDev
 	}
+
+	return weighted, validCount, consensus, nil
 }
 
-// fetchURLContent fetches content from a URL and returns it as a string
-func (f *Forecaster) fetchURLContent(ctx context.Context, url string) (string, error) {
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+// fetchURLContent fetches content from a URL and returns prompt-ready text of at most maxChars.
+// HTML pages are reduced to their readable article text; JSON, CSV and plain text pass through.
+func (f *Forecaster) fetchURLContent(ctx context.Context, url string, maxChars int) (string, error) {
+	client := httpclient.New()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -973,12 +1795,25 @@ func (f *Forecaster) fetchURLContent(ctx context.Context, url string) (string, e
 		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	// Read response body with size limit (1MB max)
-	bodyBytes := make([]byte, 1024*1024)
-	n, err := resp.Body.Read(bodyBytes)
-	if err != nil && err != io.EOF {
+	// Read the full body up to the hard cap; markup is stripped before applying the text budget
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxContextFetchBytes))
+	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return string(bodyBytes[:n]), nil
+	contentType := resp.Header.Get("Content-Type")
+	content, ok := readableContent(contentType, bodyBytes)
+	if !ok {
+		return "", fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	content, truncated := truncateText(content, maxChars)
+	if truncated {
+		f.logger.Info("truncated context URL content to budget",
+			"url", url,
+			"max_chars", maxChars)
+		content += "\n[truncated]"
+	}
+
+	return content, nil
 }
@@ -0,0 +1,686 @@
+package forecaster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+type recordingActivityLogger struct {
+	logs []models.ActivityLog
+}
+
+func (r *recordingActivityLogger) Log(ctx context.Context, log models.ActivityLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func TestParseProbability(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    float64
+		wantErr bool
+	}{
+		{name: "plain number", content: "35", want: 35},
+		{name: "percent symbol", content: "72.5%", want: 72.5},
+		{name: "above range clamped", content: "140", want: 100},
+		{name: "below range clamped", content: "-12", want: 0},
+		{name: "trailing line", content: "Considering the signals...\n\n18", want: 18},
+		{name: "no number", content: "unlikely", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProbability(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCalculateWeightedResult_Probability(t *testing.T) {
+	f := &Forecaster{}
+
+	p1, p2 := 20.0, 60.0
+	responses := []models.ForecastModelResponse{
+		{ModelID: "a", Status: "completed", Probability: &p1},
+		{ModelID: "b", Status: "completed", Probability: &p2},
+	}
+	configs := []models.ForecastModel{
+		{ID: "a", Weight: 1},
+		{ID: "b", Weight: 3},
+	}
+
+	result, err := f.calculateWeightedResult(responses, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.AggregatedProbability == nil {
+		t.Fatal("expected aggregated probability to be set")
+	}
+	if *result.AggregatedProbability != 50 {
+		t.Errorf("expected weighted probability 50, got %v", *result.AggregatedProbability)
+	}
+	if result.AggregatedPointEstimate != nil || result.AggregatedPercentiles != nil {
+		t.Error("expected only the probability aggregate to be set")
+	}
+	if result.ModelCount != 2 {
+		t.Errorf("expected model count 2, got %d", result.ModelCount)
+	}
+	if result.ConsensusLevel == nil {
+		t.Error("expected consensus level to be set")
+	}
+}
+
+func TestCalculateWeightedResult_ZeroTotalWeight(t *testing.T) {
+	f := &Forecaster{}
+
+	percentiles := func(p50 float64) *models.PercentilePredictions {
+		return &models.PercentilePredictions{P10: p50 - 4, P25: p50 - 2, P50: p50, P75: p50 + 2, P90: p50 + 4}
+	}
+	estimate := 8.0
+	configs := []models.ForecastModel{{ID: "a", Weight: 0}, {ID: "b", Weight: 0}}
+
+	tests := []struct {
+		name      string
+		responses []models.ForecastModelResponse
+	}{
+		{
+			name: "percentiles",
+			responses: []models.ForecastModelResponse{
+				{ModelID: "a", Status: "completed", PercentilePredictions: percentiles(10)},
+				{ModelID: "b", Status: "completed", PercentilePredictions: percentiles(20)},
+			},
+		},
+		{
+			name:      "point estimate",
+			responses: []models.ForecastModelResponse{{ModelID: "a", Status: "completed", PointEstimate: &estimate}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Zero weights must fail the run rather than store unnormalized sums
+			_, err := f.calculateWeightedResult(tt.responses, configs)
+			if !errors.Is(err, ErrNoUsableWeight) {
+				t.Fatalf("expected ErrNoUsableWeight, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCalculateWeightedResult_NoValidResponses(t *testing.T) {
+	f := &Forecaster{}
+
+	responses := []models.ForecastModelResponse{
+		{ModelID: "a", Status: "failed"},
+		{ModelID: "b", Status: "completed"}, // Completed but unparseable: no value
+	}
+	configs := []models.ForecastModel{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}}
+
+	if _, err := f.calculateWeightedResult(responses, configs); !errors.Is(err, ErrNoValidResponses) {
+		t.Fatalf("expected ErrNoValidResponses, got %v", err)
+	}
+	if _, err := f.calculateWeightedResult(nil, configs); !errors.Is(err, ErrNoValidResponses) {
+		t.Fatalf("expected ErrNoValidResponses for no responses, got %v", err)
+	}
+}
+
+func TestCalculateWeightedResult_SingleResponse(t *testing.T) {
+	f := &Forecaster{}
+
+	// Only model b answered; its weight alone normalizes the mean
+	p := &models.PercentilePredictions{P10: 1, P25: 2, P50: 3, P75: 4, P90: 5}
+	responses := []models.ForecastModelResponse{
+		{ModelID: "a", Status: "failed"},
+		{ModelID: "b", Status: "completed", PercentilePredictions: p},
+	}
+	configs := []models.ForecastModel{{ID: "a", Weight: 2}, {ID: "b", Weight: 0.5}}
+
+	result, err := f.calculateWeightedResult(responses, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.AggregatedPercentiles == nil || *result.AggregatedPercentiles != *p {
+		t.Errorf("expected the single response's percentiles, got %+v", result.AggregatedPercentiles)
+	}
+	if result.ModelCount != 1 {
+		t.Errorf("expected model count 1, got %d", result.ModelCount)
+	}
+	if result.ConsensusLevel != nil {
+		t.Errorf("expected no consensus with a single response, got %v", *result.ConsensusLevel)
+	}
+	if math.IsNaN(result.AggregatedPercentiles.P50) {
+		t.Error("aggregate is NaN")
+	}
+}
+
+func TestCheckDisagreement(t *testing.T) {
+	var received DisagreementAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode webhook payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	activity := &recordingActivityLogger{}
+	f := &Forecaster{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	f.SetActivityLogger(activity)
+
+	threshold := 5.0
+	forecast := &models.Forecast{ID: "f1", Name: "Test", DisagreementThreshold: &threshold, AlertWebhookURL: server.URL}
+
+	v1, v2, v3 := 10.0, 11.0, 40.0
+	responses := []models.ForecastModelResponse{
+		{ModelID: "a", ModelName: "model-a", Status: "completed", PointEstimate: &v1},
+		{ModelID: "b", ModelName: "model-b", Status: "completed", PointEstimate: &v2},
+		{ModelID: "c", ModelName: "model-c", Status: "completed", PointEstimate: &v3},
+	}
+	configs := []models.ForecastModel{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}, {ID: "c", Weight: 1}}
+	result, err := f.calculateWeightedResult(responses, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f.checkDisagreement(context.Background(), forecast, "run1", result, responses)
+
+	if len(activity.logs) != 1 {
+		t.Fatalf("expected 1 activity log, got %d", len(activity.logs))
+	}
+	if activity.logs[0].ActivityType != models.ActivityTypeForecastAlert {
+		t.Errorf("unexpected activity type: %s", activity.logs[0].ActivityType)
+	}
+	if received.RunID != "run1" || len(received.Estimates) != 3 {
+		t.Fatalf("unexpected webhook payload: %+v", received)
+	}
+	if received.Outlier == nil || received.Outlier.ModelID != "c" {
+		t.Errorf("expected model c to be the outlier, got %+v", received.Outlier)
+	}
+
+	// Below threshold should not alert
+	threshold = 100
+	f.checkDisagreement(context.Background(), forecast, "run2", result, responses)
+	if len(activity.logs) != 1 {
+		t.Errorf("expected no new alert below threshold, got %d logs", len(activity.logs))
+	}
+}
+
+func TestParsePercentiles_ChattyOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    models.PercentilePredictions
+	}{
+		{
+			name:    "plain",
+			content: "-5, -1, 2, 6, 11",
+			want:    models.PercentilePredictions{P10: -5, P25: -1, P50: 2, P75: 6, P90: 11},
+		},
+		{
+			name:    "reasoning block with numbers",
+			content: "<think>\nMaybe 1, 2, 3, 4, 5? No, too narrow.\n</think>\n\n-5, -1, 2, 6, 11",
+			want:    models.PercentilePredictions{P10: -5, P25: -1, P50: 2, P75: 6, P90: 11},
+		},
+		{
+			name:    "markdown and closing remarks",
+			content: "Here is my forecast.\n\n**-5%, -1%, 2%, 6%, 11%**\n\nThese reflect elevated uncertainty.\nLet me know if you need more detail.\nGood luck!\nI hope this helps.",
+			want:    models.PercentilePredictions{P10: -5, P25: -1, P50: 2, P75: 6, P90: 11},
+		},
+		{
+			name:    "labelled percentiles",
+			content: "P10: -5, P25: -1, P50: 2, P75: 6, P90: 11",
+			want:    models.PercentilePredictions{P10: -5, P25: -1, P50: 2, P75: 6, P90: 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePercentiles(tt.content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestParsePointEstimate_ChattyOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    float64
+	}{
+		{name: "reasoning block", content: "<think>The base rate is 12, but recent news suggests 40.</think>\n42", want: 42},
+		{name: "labelled answer", content: "Final answer: **4,250.5**", want: 4250.5},
+		{name: "answer before closing remarks", content: "Weighing the evidence...\n\n37.5\n\nThis estimate reflects the latest reports.\nI hope this helps.\nLet me know if you have questions.\nThanks!", want: 37.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePointEstimate(tt.content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestForecastTimeout_LocalModels(t *testing.T) {
+	hosted := []models.ForecastModel{{Provider: models.ProviderOpenAI}}
+	local := []models.ForecastModel{{Provider: models.ProviderOpenAI}, {Provider: models.ProviderOpenAICompatible}}
+
+	if got := forecastTimeout(&models.Forecast{}, hosted); got != defaultForecastTimeout {
+		t.Errorf("expected default timeout for hosted models, got %v", got)
+	}
+	if got := forecastTimeout(&models.Forecast{}, local); got != defaultLocalForecastTimeout {
+		t.Errorf("expected local timeout, got %v", got)
+	}
+	if got := forecastTimeout(&models.Forecast{TimeoutMinutes: 10}, local); got.Minutes() != 10 {
+		t.Errorf("expected configured timeout to win, got %v", got)
+	}
+}
+
+func TestFitSummaries(t *testing.T) {
+	headlines := []models.ForecastHeadline{
+		{EventID: "a", Summary: strings.Repeat("x", 40)}, // 11 tokens
+		{EventID: "b"},
+		{EventID: "c", Summary: strings.Repeat("y", 40)},
+		{EventID: "d", Summary: "short"},
+	}
+
+	fitted, summarized := fitSummaries(headlines, 23)
+	if summarized != 2 {
+		t.Errorf("expected 2 summaries to fit, got %d", summarized)
+	}
+	if fitted[0].Summary == "" || fitted[2].Summary == "" {
+		t.Error("expected the newest summaries to be kept")
+	}
+	if fitted[3].Summary != "" {
+		t.Error("expected summaries after the budget ran out to be dropped")
+	}
+	if headlines[3].Summary != "short" {
+		t.Error("expected the input headlines to be left unchanged")
+	}
+
+	// A summary that doesn't fit stops later, smaller ones from being included
+	_, summarized = fitSummaries(headlines, 5)
+	if summarized != 0 {
+		t.Errorf("expected no summaries to fit, got %d", summarized)
+	}
+}
+
+func TestExecuteForecast_RejectsRunInProgress(t *testing.T) {
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "busy", PredictionType: models.PredictionTypeProbability, Iterations: 1},
+		models:   []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAI, ModelName: "gpt-4o", Weight: 1}},
+		active:   &models.ForecastRun{ID: "other-instance-run", RunAt: time.Now()},
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	// A run recorded by another instance
+	if _, err := f.ExecuteForecast(context.Background(), "busy"); !errors.Is(err, ErrRunInProgress) {
+		t.Fatalf("expected ErrRunInProgress for a run in the database, got %v", err)
+	}
+
+	// The failed attempt released its claim, so only a run starting in this process blocks
+	if !claimForecast("busy") {
+		t.Fatal("expected the forecast claim to be released after a rejected run")
+	}
+	defer releaseForecast("busy")
+
+	repo.active = nil
+	if _, err := f.ExecuteForecast(context.Background(), "busy"); !errors.Is(err, ErrRunInProgress) {
+		t.Fatalf("expected ErrRunInProgress for a run starting in this process, got %v", err)
+	}
+}
+
+func TestExecuteForecast_RejectsConcurrentRunCreation(t *testing.T) {
+	// Another instance created its run between the active-run check and this insert
+	repo := &stubForecastRepo{
+		forecast:  &models.Forecast{ID: "raced", PredictionType: models.PredictionTypeProbability, Iterations: 1},
+		models:    []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAI, ModelName: "gpt-4o", Weight: 1}},
+		createErr: fmt.Errorf("%w: forecast raced", models.ErrForecastRunActive),
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	if _, err := f.ExecuteForecast(context.Background(), "raced"); !errors.Is(err, ErrRunInProgress) {
+		t.Fatalf("expected ErrRunInProgress when the insert hits the unique index, got %v", err)
+	}
+	if !claimForecast("raced") {
+		t.Fatal("expected the forecast claim to be released after a rejected run")
+	}
+	releaseForecast("raced")
+}
+
+func TestExecuteForecast_RejectsTooManyIterations(t *testing.T) {
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "costly", PredictionType: models.PredictionTypeProbability, Iterations: 10000},
+		models:   []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAI, ModelName: "gpt-4o", Weight: 1}},
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	if _, err := f.ExecuteForecast(context.Background(), "costly"); !errors.Is(err, ErrTooManyIterations) {
+		t.Fatalf("expected ErrTooManyIterations above the default cap, got %v", err)
+	}
+
+	f.SetMaxIterations(20)
+	repo.forecast.Iterations = 25
+	if _, err := f.ExecuteForecast(context.Background(), "costly"); !errors.Is(err, ErrTooManyIterations) {
+		t.Fatalf("expected ErrTooManyIterations above a configured cap, got %v", err)
+	}
+
+	f.SetMaxIterations(0)
+	if f.MaxIterations() != 20 {
+		t.Errorf("a non-positive maximum should be ignored, got %d", f.MaxIterations())
+	}
+}
+
+func TestExecuteForecast_RejectsArchived(t *testing.T) {
+	archivedAt := time.Now()
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "shelved", PredictionType: models.PredictionTypeProbability, Iterations: 1, ArchivedAt: &archivedAt},
+		models:   []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAI, ModelName: "gpt-4o", Weight: 1}},
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	if _, err := f.ExecuteForecast(context.Background(), "shelved"); !errors.Is(err, ErrForecastArchived) {
+		t.Fatalf("expected ErrForecastArchived, got %v", err)
+	}
+}
+
+func TestExecuteForecast_StoresSamples(t *testing.T) {
+	// Every answer parses except the second one served
+	var calls int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		calls++
+		content := "42"
+		if calls == 2 {
+			content = "no idea"
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}],"usage":{"total_tokens":10}}`, content)
+	}))
+	defer server.Close()
+
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "f1", PredictionType: models.PredictionTypePointEstimate, Iterations: 3},
+		models:   []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAICompatible, ModelName: "llama3", Weight: 1, BaseURL: server.URL}},
+		final:    make(chan models.ForecastRun, 1),
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+	f.SetStoreSamples(true)
+
+	if _, err := f.ExecuteForecast(context.Background(), "f1"); err != nil {
+		t.Fatalf("ExecuteForecast: %v", err)
+	}
+	select {
+	case run := <-repo.final:
+		if run.Status != "completed" {
+			t.Fatalf("expected a completed run, got %+v", run)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not finish")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.responses) != 1 || repo.responses[0].ID == "" {
+		t.Fatalf("expected one response with an ID, got %+v", repo.responses)
+	}
+	if len(repo.samples) != 2 {
+		t.Fatalf("expected the 2 parsed samples to be stored, got %+v", repo.samples)
+	}
+	for _, s := range repo.samples {
+		if s.ResponseID != repo.responses[0].ID || s.RunID != "cancel-run" || s.ModelID != "m1" || s.Value == nil || *s.Value != 42 {
+			t.Errorf("unexpected sample %+v", s)
+		}
+	}
+	if repo.samples[0].SampleIndex == repo.samples[1].SampleIndex {
+		t.Errorf("expected distinct sample indexes, got %+v", repo.samples)
+	}
+}
+
+func TestExecuteForecast_UsesFixedHeadlines(t *testing.T) {
+	var prompt string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		prompt = string(body)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"42"}}],"usage":{"total_tokens":10}}`)
+	}))
+	defer server.Close()
+
+	fixed := []models.ForecastHeadline{
+		{EventID: "old-1", Title: "Central bank raises rates", Summary: "Not sent without summaries", Category: "economic"},
+		{Title: "Hypothetical port closure", Category: "military"},
+	}
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "f1", PredictionType: models.PredictionTypePointEstimate, Iterations: 1, HeadlineCount: 2, FixedHeadlines: fixed},
+		models:   []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAICompatible, ModelName: "llama3", Weight: 1, BaseURL: server.URL}},
+		final:    make(chan models.ForecastRun, 1),
+	}
+	events := &fakeEventRepo{candidates: newestFirst("Fetched instead of fixed")}
+	f := NewForecaster(events, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	if _, err := f.ExecuteForecast(context.Background(), "f1"); err != nil {
+		t.Fatalf("ExecuteForecast: %v", err)
+	}
+	select {
+	case run := <-repo.final:
+		if run.Status != "completed" {
+			t.Fatalf("expected a completed run, got %+v", run)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not finish")
+	}
+
+	if len(events.queries) != 0 {
+		t.Errorf("expected no event queries, got %+v", events.queries)
+	}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.snapshot) != 2 || repo.snapshot[0].Title != fixed[0].Title || repo.snapshot[0].Summary != "" || repo.snapshot[1].Title != fixed[1].Title {
+		t.Errorf("expected the fixed headlines without summaries in the snapshot, got %+v", repo.snapshot)
+	}
+	if fixed[0].Summary == "" {
+		t.Error("the forecast's fixed headlines were modified")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !strings.Contains(prompt, "Hypothetical port closure") || strings.Contains(prompt, "Fetched instead of fixed") {
+		t.Errorf("expected the prompt to carry only the fixed headlines, got %s", prompt)
+	}
+	// Headlines entered without a timestamp are listed without a date
+	if strings.Contains(prompt, "0001-01-01") || !strings.Contains(prompt, `Hypothetical port closure\n`) {
+		t.Errorf("expected no date on fixed headlines without a timestamp, got %s", prompt)
+	}
+}
+
+func TestExecuteForecastIdempotent(t *testing.T) {
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "retried", PredictionType: models.PredictionTypeProbability, Iterations: 1},
+		models:   []models.ForecastModel{{ID: "m1", Provider: models.ProviderOpenAI, ModelName: "gpt-4o", Weight: 1}},
+		keys:     map[string]string{"key-1": "first-run"},
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	// A retried request gets the run its key already started, even while that run is executing
+	if !claimForecast("retried") {
+		t.Fatal("expected to claim the forecast")
+	}
+	defer releaseForecast("retried")
+
+	runID, existing, err := f.ExecuteForecastIdempotent(context.Background(), "retried", "key-1")
+	if err != nil || !existing || runID != "first-run" {
+		t.Fatalf("expected the existing run, got %q (existing %v, err %v)", runID, existing, err)
+	}
+
+	// A new key still respects the run in progress and isn't recorded
+	if _, _, err := f.ExecuteForecastIdempotent(context.Background(), "retried", "key-2"); !errors.Is(err, ErrRunInProgress) {
+		t.Fatalf("expected ErrRunInProgress for a new key, got %v", err)
+	}
+	if _, saved := repo.keys["key-2"]; saved {
+		t.Error("a rejected request should not record its key")
+	}
+
+	// A concurrent request with a reserved key waits for the first request's run
+	defer func(interval time.Duration) { idempotencyPollInterval = interval }(idempotencyPollInterval)
+	idempotencyPollInterval = time.Millisecond
+	repo.mu.Lock()
+	repo.keys["key-3"] = ""
+	repo.mu.Unlock()
+	type result struct {
+		runID    string
+		existing bool
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		runID, existing, err := f.ExecuteForecastIdempotent(context.Background(), "retried", "key-3")
+		done <- result{runID, existing, err}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if err := repo.SetIdempotentRun(context.Background(), "retried", "key-3", "concurrent-run"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-done:
+		if got.err != nil || !got.existing || got.runID != "concurrent-run" {
+			t.Fatalf("expected the concurrent request's run, got %q (existing %v, err %v)", got.runID, got.existing, got.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request with a reserved key did not return")
+	}
+}
+
+func TestNotifyCompletion(t *testing.T) {
+	completionWebhookBackoff = time.Millisecond
+	defer func() { completionWebhookBackoff = 2 * time.Second }()
+
+	var (
+		attempts int
+		received CompletionNotification
+		body     []byte
+		header   http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	f := &Forecaster{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	above := 2.0
+	forecast := &models.Forecast{
+		ID:                      "f1",
+		Name:                    "S&P 500",
+		PredictionType:          models.PredictionTypePercentile,
+		Units:                   "percent_change",
+		CompletionWebhookURL:    server.URL,
+		CompletionWebhookSecret: "s3cret",
+		CompletionWebhookAbove:  &above,
+	}
+	consensus := 1.5
+	result := models.ForecastResult{
+		AggregatedPercentiles: &models.PercentilePredictions{P10: -5, P25: -1, P50: 3, P75: 6, P90: 11},
+		ModelCount:            2,
+		ConsensusLevel:        &consensus,
+	}
+
+	f.notifyCompletion(context.Background(), forecast, "run1", result)
+
+	if attempts != 2 {
+		t.Fatalf("expected a retry after the 503, got %d attempts", attempts)
+	}
+	if err := json.Unmarshal(body, &received); err != nil {
+		t.Fatalf("failed to decode webhook payload: %v", err)
+	}
+	if received.Event != CompletionEvent || received.RunID != "run1" || received.Value != 3 || received.ThresholdCrossed != "above" {
+		t.Errorf("unexpected payload: %+v", received)
+	}
+	if received.AggregatedPercentiles == nil || received.ConsensusLevel == nil || *received.ConsensusLevel != 1.5 {
+		t.Errorf("expected percentiles and consensus in payload: %+v", received)
+	}
+	want := signWebhook("s3cret", header.Get(TimestampHeader), body)
+	if header.Get(SignatureHeader) != want {
+		t.Errorf("signature = %q, want %q", header.Get(SignatureHeader), want)
+	}
+
+	// Below the threshold nothing is sent
+	attempts = 0
+	above = 10
+	f.notifyCompletion(context.Background(), forecast, "run2", result)
+	if attempts != 0 {
+		t.Errorf("expected no webhook below the threshold, got %d attempts", attempts)
+	}
+}
+
+func TestNotifyCompletion_NoRetryOnClientError(t *testing.T) {
+	completionWebhookBackoff = time.Millisecond
+	defer func() { completionWebhookBackoff = 2 * time.Second }()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get(SignatureHeader) != "" {
+			t.Error("expected an unsigned webhook without a secret")
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	f := &Forecaster{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	estimate := 4.0
+	forecast := &models.Forecast{ID: "f1", Name: "Test", CompletionWebhookURL: server.URL}
+
+	f.notifyCompletion(context.Background(), forecast, "run1", models.ForecastResult{AggregatedPointEstimate: &estimate})
+
+	if attempts != 1 {
+		t.Errorf("expected a single attempt for a 400 response, got %d", attempts)
+	}
+}
@@ -0,0 +1,232 @@
+package forecaster
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// Progress event types
+const (
+	// ProgressEventProgress reports the run's counts after a sample completes
+	ProgressEventProgress = "progress"
+	// ProgressEventModel reports that a model has finished all of its samples
+	ProgressEventModel = "model"
+	// ProgressEventDone is the last event of a run, sent when it completes or fails
+	ProgressEventDone = "done"
+)
+
+// errRunCancelled is the cause attached to a run's context when it is cancelled through CancelRun
+var errRunCancelled = errors.New("cancelled")
+
+// progressBuffer is the number of events a slow subscriber may fall behind before intermediate
+// events are dropped; every event carries cumulative counts, so dropping them loses nothing
+const progressBuffer = 16
+
+// ProgressEvent reports how far a forecast run has got
+type ProgressEvent struct {
+	Type             string `json:"type"`
+	RunID            string `json:"run_id"`
+	Provider         string `json:"provider,omitempty"`
+	ModelName        string `json:"model_name,omitempty"`
+	SamplesCompleted int    `json:"samples_completed"`
+	SamplesTotal     int    `json:"samples_total"`
+	ModelsCompleted  int    `json:"models_completed"`
+	ModelsTotal      int    `json:"models_total"`
+	Status           string `json:"status,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// runProgress tracks an in-flight run, the subscribers following it and how to cancel it. A nil
+// runProgress ignores updates, so code paths without a registered run need no checks.
+type runProgress struct {
+	mu          sync.Mutex
+	state       ProgressEvent
+	finished    bool
+	subscribers map[chan ProgressEvent]struct{}
+	cancel      context.CancelCauseFunc
+	cancelled   bool
+}
+
+// activeRuns holds the runs executing in this process. It is shared by every Forecaster so runs
+// started by the scheduler can be followed through the API too.
+var activeRuns = struct {
+	sync.Mutex
+	runs map[string]*runProgress
+}{runs: make(map[string]*runProgress)}
+
+// startRunProgress registers a run so it can be followed until finish is called
+func startRunProgress(runID string, forecastModels []models.ForecastModel, numSamples int) *runProgress {
+	p := &runProgress{
+		state: ProgressEvent{
+			Type:         ProgressEventProgress,
+			RunID:        runID,
+			SamplesTotal: len(forecastModels) * numSamples,
+			ModelsTotal:  len(forecastModels),
+			Status:       "running",
+		},
+		subscribers: make(map[chan ProgressEvent]struct{}),
+	}
+
+	activeRuns.Lock()
+	activeRuns.runs[runID] = p
+	activeRuns.Unlock()
+
+	return p
+}
+
+// SubscribeProgress follows a run executing in this process. The channel first receives the
+// run's current counts, then an event per completed sample and model, and is closed after the
+// done event. It returns false when the run isn't executing here, e.g. because it has finished.
+func SubscribeProgress(runID string) (<-chan ProgressEvent, func(), bool) {
+	activeRuns.Lock()
+	p, ok := activeRuns.runs[runID]
+	activeRuns.Unlock()
+	if !ok {
+		return nil, nil, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return nil, nil, false
+	}
+
+	ch := make(chan ProgressEvent, progressBuffer)
+	ch <- p.state
+	p.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if _, ok := p.subscribers[ch]; ok {
+			delete(p.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe, true
+}
+
+// CancelRun cancels a run executing in this process. In-flight provider calls are abandoned, the
+// responses gathered so far are stored and the run is marked failed with reason "cancelled".
+// It returns false when the run isn't executing here.
+func CancelRun(runID string) bool {
+	activeRuns.Lock()
+	p, ok := activeRuns.runs[runID]
+	activeRuns.Unlock()
+	if !ok {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return false
+	}
+
+	p.cancelled = true
+	if p.cancel != nil {
+		p.cancel(errRunCancelled)
+	}
+	return true
+}
+
+// setCancel attaches the function that cancels the run's context, calling it straight away if
+// the run was cancelled before it started
+func (p *runProgress) setCancel(cancel context.CancelCauseFunc) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cancel = cancel
+	if p.cancelled {
+		cancel(errRunCancelled)
+	}
+}
+
+// sampleDone records a completed sample, successful or not
+func (p *runProgress) sampleDone(model *models.ForecastModel) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state.SamplesCompleted++
+	p.publish(ProgressEventProgress, model)
+}
+
+// modelDone records that a model has finished
+func (p *runProgress) modelDone(model *models.ForecastModel) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state.ModelsCompleted++
+	p.publish(ProgressEventModel, model)
+}
+
+// finish sends the done event, closes every subscriber and unregisters the run. Only the first
+// call has any effect.
+func (p *runProgress) finish(status, errorMsg string) {
+	if p == nil {
+		return
+	}
+
+	activeRuns.Lock()
+	if activeRuns.runs[p.state.RunID] == p {
+		delete(activeRuns.runs, p.state.RunID)
+	}
+	activeRuns.Unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return
+	}
+	p.finished = true
+
+	p.state.Status = status
+	p.state.Error = errorMsg
+	done := p.event(ProgressEventDone, nil)
+	for ch := range p.subscribers {
+		// Make room for the done event; a full buffer only holds superseded progress
+		select {
+		case ch <- done:
+		default:
+			<-ch
+			ch <- done
+		}
+		close(ch)
+	}
+	p.subscribers = nil
+}
+
+// publish sends an event to subscribers without blocking the run. The caller holds p.mu.
+func (p *runProgress) publish(eventType string, model *models.ForecastModel) {
+	event := p.event(eventType, model)
+	for ch := range p.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// event builds an event from the run's current state
+func (p *runProgress) event(eventType string, model *models.ForecastModel) ProgressEvent {
+	event := p.state
+	event.Type = eventType
+	if model != nil {
+		event.Provider = model.Provider
+		event.ModelName = model.ModelName
+	}
+	return event
+}
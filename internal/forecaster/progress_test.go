@@ -0,0 +1,287 @@
+package forecaster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestRunProgress_Stream(t *testing.T) {
+	forecastModels := []models.ForecastModel{
+		{Provider: "openai", ModelName: "gpt-4o"},
+		{Provider: "anthropic", ModelName: "claude-sonnet-4-20250514"},
+	}
+	p := startRunProgress("progress-run", forecastModels, 3)
+
+	events, unsubscribe, ok := SubscribeProgress("progress-run")
+	if !ok {
+		t.Fatal("expected to subscribe to an active run")
+	}
+	defer unsubscribe()
+
+	p.sampleDone(&forecastModels[0])
+	p.modelDone(&forecastModels[0])
+	p.finish("completed", "")
+
+	var got []ProgressEvent
+	for event := range events {
+		got = append(got, event)
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("expected 4 events, got %d: %+v", len(got), got)
+	}
+	if got[0].Type != ProgressEventProgress || got[0].SamplesTotal != 6 || got[0].ModelsTotal != 2 {
+		t.Errorf("unexpected initial event: %+v", got[0])
+	}
+	if got[1].SamplesCompleted != 1 || got[1].ModelName != "gpt-4o" {
+		t.Errorf("unexpected sample event: %+v", got[1])
+	}
+	if got[2].Type != ProgressEventModel || got[2].ModelsCompleted != 1 {
+		t.Errorf("unexpected model event: %+v", got[2])
+	}
+	if got[3].Type != ProgressEventDone || got[3].Status != "completed" {
+		t.Errorf("unexpected done event: %+v", got[3])
+	}
+
+	if _, _, ok := SubscribeProgress("progress-run"); ok {
+		t.Error("expected finished run to be unregistered")
+	}
+}
+
+func TestRunProgress_SlowSubscriberGetsDone(t *testing.T) {
+	model := models.ForecastModel{Provider: "openai", ModelName: "gpt-4o"}
+	p := startRunProgress("slow-run", []models.ForecastModel{model}, 50)
+
+	events, unsubscribe, ok := SubscribeProgress("slow-run")
+	if !ok {
+		t.Fatal("expected to subscribe to an active run")
+	}
+	defer unsubscribe()
+
+	// Overflow the buffer without reading; extra progress events are dropped
+	for i := 0; i < 50; i++ {
+		p.sampleDone(&model)
+	}
+	p.finish("failed", "all models failed")
+	p.finish("completed", "") // ignored
+
+	var last ProgressEvent
+	for event := range events {
+		last = event
+	}
+	if last.Type != ProgressEventDone || last.Status != "failed" || last.SamplesCompleted != 50 {
+		t.Errorf("expected final done event, got %+v", last)
+	}
+}
+
+func TestRunProgress_NilIgnoresUpdates(t *testing.T) {
+	var p *runProgress
+	p.sampleDone(&models.ForecastModel{})
+	p.modelDone(&models.ForecastModel{})
+	p.finish("completed", "")
+}
+
+// stubForecastRepo serves one forecast and records the stored responses and final status of its run
+type stubForecastRepo struct {
+	mu        sync.Mutex
+	forecast  *models.Forecast
+	models    []models.ForecastModel
+	responses []models.ForecastModelResponse
+	samples   []models.ForecastSample
+	snapshot  []models.ForecastHeadline // Headlines the run was created with
+	final     chan models.ForecastRun
+	active    *models.ForecastRun
+	keys      map[string]string // Idempotency key to run ID
+	touches   int               // Heartbeats recorded
+	createErr error             // Returned by CreateForecastRun when set
+}
+
+func (r *stubForecastRepo) GetForecast(ctx context.Context, id string) (*models.Forecast, error) {
+	return r.forecast, nil
+}
+
+func (r *stubForecastRepo) GetForecastModels(ctx context.Context, forecastID string) ([]models.ForecastModel, error) {
+	return r.models, nil
+}
+
+func (r *stubForecastRepo) CreateForecastRun(ctx context.Context, forecastID string, headlines []models.ForecastHeadline) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.createErr != nil {
+		return "", r.createErr
+	}
+	r.snapshot = headlines
+	return "cancel-run", nil
+}
+
+func (r *stubForecastRepo) UpdateForecastRunStatus(ctx context.Context, runID, status, errorMsg string) error {
+	if status != "running" {
+		r.final <- models.ForecastRun{ID: runID, Status: status, ErrorMessage: errorMsg}
+	}
+	return nil
+}
+
+func (r *stubForecastRepo) TouchForecastRun(ctx context.Context, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touches++
+	return nil
+}
+
+func (r *stubForecastRepo) CreateModelResponse(ctx context.Context, response models.ForecastModelResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, response)
+	return nil
+}
+
+func (r *stubForecastRepo) CreateForecastResult(ctx context.Context, result models.ForecastResult) error {
+	return nil
+}
+
+func (r *stubForecastRepo) CreateForecastSamples(ctx context.Context, samples []models.ForecastSample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples = append(r.samples, samples...)
+	return nil
+}
+
+func (r *stubForecastRepo) GetForecastRun(ctx context.Context, runID string) (*models.ForecastRunDetail, error) {
+	return nil, nil
+}
+
+func (r *stubForecastRepo) GetActiveForecastRun(ctx context.Context, forecastID string, since time.Time) (*models.ForecastRun, error) {
+	return r.active, nil
+}
+
+func (r *stubForecastRepo) FailStaleForecastRuns(ctx context.Context, forecastID, errorMsg string, staleBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *stubForecastRepo) ReserveIdempotencyKey(ctx context.Context, forecastID, key string, expiredBefore, staleBefore time.Time) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if runID, ok := r.keys[key]; ok {
+		return runID, false, nil
+	}
+	if r.keys == nil {
+		r.keys = make(map[string]string)
+	}
+	r.keys[key] = ""
+	return "", true, nil
+}
+
+func (r *stubForecastRepo) SetIdempotentRun(ctx context.Context, forecastID, key, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[key] = runID
+	return nil
+}
+
+func (r *stubForecastRepo) ReleaseIdempotencyKey(ctx context.Context, forecastID, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys[key] == "" {
+		delete(r.keys, key)
+	}
+	return nil
+}
+
+type emptyEventRepo struct{}
+
+func (emptyEventRepo) Query(ctx context.Context, query models.EventQuery) (*models.EventResponse, error) {
+	return &models.EventResponse{}, nil
+}
+
+func TestCancelRun(t *testing.T) {
+	defer func(interval time.Duration) { runHeartbeatInterval = interval }(runHeartbeatInterval)
+	runHeartbeatInterval = 10 * time.Millisecond
+
+	// The first sample answers; the rest hang until their request is cancelled
+	var calls atomic.Int32
+	blocked := make(chan struct{}, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Drain the body so the server notices when the client goes away
+		io.Copy(io.Discard, r.Body)
+		if calls.Add(1) > 1 {
+			blocked <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"42"}}],"usage":{"total_tokens":10}}`)
+	}))
+	defer server.Close()
+
+	repo := &stubForecastRepo{
+		forecast: &models.Forecast{ID: "f1", PredictionType: models.PredictionTypePointEstimate, Iterations: 3},
+		models: []models.ForecastModel{{
+			ID:        "m1",
+			Provider:  models.ProviderOpenAICompatible,
+			ModelName: "llama3",
+			Weight:    1,
+			BaseURL:   server.URL,
+		}},
+		final: make(chan models.ForecastRun, 1),
+	}
+	f := NewForecaster(emptyEventRepo{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	runID, err := f.ExecuteForecast(context.Background(), "f1")
+	if err != nil {
+		t.Fatalf("ExecuteForecast: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-blocked:
+		case <-time.After(5 * time.Second):
+			t.Fatal("samples never reached the server")
+		}
+	}
+	// A run waiting on its provider still reports that it is alive
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		repo.mu.Lock()
+		touches := repo.touches
+		repo.mu.Unlock()
+		if touches > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the executing run to record a heartbeat")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !CancelRun(runID) {
+		t.Fatal("expected the run to be cancellable")
+	}
+
+	select {
+	case run := <-repo.final:
+		if run.Status != "failed" || run.ErrorMessage != "cancelled" {
+			t.Errorf("expected run failed with reason cancelled, got %+v", run)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not finish after cancellation")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.responses) != 1 || repo.responses[0].PointEstimate == nil || *repo.responses[0].PointEstimate != 42 {
+		t.Fatalf("expected the partial response to be stored, got %+v", repo.responses)
+	}
+	if CancelRun(runID) {
+		t.Error("expected a finished run not to be cancellable")
+	}
+}
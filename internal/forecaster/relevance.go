@@ -0,0 +1,145 @@
+package forecaster
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// Relevant headline selection ranks a pool of the newest candidates, relevanceCandidateFactor
+// times the forecast's headline count, capped at maxRelevanceCandidates (the event query limit)
+const (
+	relevanceCandidateFactor = 4
+	maxRelevanceCandidates   = 1000
+)
+
+// Embedder turns text into a vector for similarity search
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// SetEmbedder enables embedding similarity for forecasts that select relevant headlines.
+// Without one, relevance falls back to keyword overlap with the proposition.
+func (f *Forecaster) SetEmbedder(embedder Embedder) {
+	f.embedder = embedder
+}
+
+// stopWords are left out of keyword relevance scoring
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "will": true, "what": true, "which": true, "who": true,
+	"when": true, "where": true, "how": true, "from": true, "with": true, "that": true, "this": true,
+	"than": true, "then": true, "into": true, "over": true, "under": true, "about": true, "after": true,
+	"before": true, "between": true, "its": true, "are": true, "was": true, "were": true, "been": true,
+	"being": true, "have": true, "has": true, "had": true, "does": true, "did": true, "not": true,
+	"any": true, "all": true, "one": true, "year": true, "years": true, "month": true, "months": true,
+	"day": true, "days": true, "today": true, "now": true, "per": true, "percent": true, "change": true,
+	"value": true, "level": true, "end": true, "there": true, "their": true, "they": true, "our": true,
+}
+
+// keywords returns the distinct lowercase words of text worth matching on: at least three
+// characters (or two-character acronyms such as "US") and not a stop word
+func keywords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		lower := strings.ToLower(word)
+		if stopWords[lower] {
+			continue
+		}
+		if len(lower) >= 3 || (len(word) == 2 && strings.ToUpper(word) == word && unicode.IsLetter(rune(word[0]))) {
+			words[lower] = true
+		}
+	}
+	return words
+}
+
+// rankByKeywords orders events by how many of the proposition's keywords appear in their title
+// and summary. Ties keep the input order, so equally relevant events stay newest first.
+func rankByKeywords(proposition string, events []models.Event) []models.Event {
+	terms := keywords(proposition)
+	scores := make(map[string]int, len(events))
+	for _, event := range events {
+		score := 0
+		for word := range keywords(event.Title + " " + event.Summary) {
+			if terms[word] {
+				score++
+			}
+		}
+		scores[event.ID] = score
+	}
+
+	ranked := append([]models.Event(nil), events...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] > scores[ranked[j].ID]
+	})
+	return ranked
+}
+
+// selectRelevantEvents picks the count events most related to the forecast's proposition from
+// candidates, which are ordered newest first. With an embedder, events are ranked by embedding
+// similarity among the candidates' time window and keyword ranking only fills the remainder,
+// e.g. with events that have no embedding yet. The selection is returned newest first.
+func (f *Forecaster) selectRelevantEvents(ctx context.Context, forecast *models.Forecast, query models.EventQuery, candidates []models.Event, count int) []models.Event {
+	if len(candidates) <= count {
+		return candidates
+	}
+
+	selected := make([]models.Event, 0, count)
+	seen := make(map[string]bool, count)
+
+	if f.embedder != nil {
+		similar, err := f.similarEvents(ctx, forecast, query, candidates, count)
+		if err != nil {
+			f.logger.Warn("failed to rank headlines by embedding similarity, using keyword overlap",
+				"forecast_id", forecast.ID,
+				"error", err)
+		}
+		for _, event := range similar {
+			if !seen[event.ID] {
+				seen[event.ID] = true
+				selected = append(selected, event)
+			}
+		}
+	}
+
+	for _, event := range rankByKeywords(forecast.Proposition, candidates) {
+		if len(selected) >= count {
+			break
+		}
+		if !seen[event.ID] {
+			seen[event.ID] = true
+			selected = append(selected, event)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Timestamp.After(selected[j].Timestamp)
+	})
+	return selected
+}
+
+// similarEvents returns up to count events most similar to the proposition, restricted to the
+// time window and filters the candidates were drawn from
+func (f *Forecaster) similarEvents(ctx context.Context, forecast *models.Forecast, query models.EventQuery, candidates []models.Event, count int) ([]models.Event, error) {
+	embedding, err := f.embedder.Embed(ctx, forecast.Proposition)
+	if err != nil {
+		return nil, err
+	}
+
+	oldest := candidates[len(candidates)-1].Timestamp
+	query.SinceTimestamp = &oldest
+	query.Since = &oldest
+	query.Embedding = embedding
+	query.MinSimilarity = 0
+	query.Limit = count
+
+	resp, err := f.eventRepo.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Events, nil
+}
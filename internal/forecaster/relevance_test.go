@@ -0,0 +1,237 @@
+package forecaster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// fakeEventRepo returns candidates for recency queries and similar for embedding queries.
+// Category-filtered recency queries get inCategories instead when it is set.
+type fakeEventRepo struct {
+	candidates   []models.Event
+	inCategories []models.Event
+	similar      []models.Event
+	similarErr   error
+	queries      []models.EventQuery
+}
+
+func (r *fakeEventRepo) Query(ctx context.Context, query models.EventQuery) (*models.EventResponse, error) {
+	r.queries = append(r.queries, query)
+	if len(query.Embedding) > 0 {
+		if r.similarErr != nil {
+			return nil, r.similarErr
+		}
+		return &models.EventResponse{Events: r.similar}, nil
+	}
+	events := r.candidates
+	if len(query.Categories) > 0 && r.inCategories != nil {
+		events = r.inCategories
+	}
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+	return &models.EventResponse{Events: events}, nil
+}
+
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return []float32{1, 0}, nil
+}
+
+// newestFirst builds events titled as given, one hour apart, newest first
+func newestFirst(titles ...string) []models.Event {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	events := make([]models.Event, len(titles))
+	for i, title := range titles {
+		events[i] = models.Event{ID: fmt.Sprintf("e%d", i), Title: title, Timestamp: now.Add(-time.Duration(i) * time.Hour)}
+	}
+	return events
+}
+
+func headlineIDs(headlines []models.ForecastHeadline) []string {
+	ids := make([]string, len(headlines))
+	for i, h := range headlines {
+		ids[i] = h.EventID
+	}
+	return ids
+}
+
+func TestFetchHeadlines_Relevant(t *testing.T) {
+	candidates := newestFirst(
+		"Earthquake hits coastal city",                      // e0
+		"Fed signals rate cut as inflation eases",           // e1
+		"Football final draws record crowd",                 // e2
+		"Oil prices climb on supply fears",                  // e3
+		"Inflation data surprises markets; Fed holds rates", // e4
+		"New smartphone launched",                           // e5
+	)
+	forecast := &models.Forecast{
+		ID:                "f1",
+		Proposition:       "Will the Fed cut interest rates as inflation falls?",
+		HeadlineCount:     2,
+		HeadlineSelection: models.HeadlineSelectionRelevant,
+	}
+
+	t.Run("keyword overlap", func(t *testing.T) {
+		repo := &fakeEventRepo{candidates: candidates}
+		f := &Forecaster{eventRepo: repo, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+		headlines, err := f.fetchHeadlines(context.Background(), forecast)
+		if err != nil {
+			t.Fatalf("fetchHeadlines failed: %v", err)
+		}
+		if repo.queries[0].Limit != 2*relevanceCandidateFactor {
+			t.Errorf("candidate limit = %d, want %d", repo.queries[0].Limit, 2*relevanceCandidateFactor)
+		}
+		if got := headlineIDs(headlines); len(got) != 2 || got[0] != "e1" || got[1] != "e4" {
+			t.Errorf("headlines = %v, want [e1 e4]", got)
+		}
+	})
+
+	t.Run("embedding similarity topped up by keywords", func(t *testing.T) {
+		repo := &fakeEventRepo{candidates: candidates, similar: []models.Event{candidates[3]}}
+		f := &Forecaster{eventRepo: repo, embedder: fakeEmbedder{}, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+		headlines, err := f.fetchHeadlines(context.Background(), forecast)
+		if err != nil {
+			t.Fatalf("fetchHeadlines failed: %v", err)
+		}
+		similarQuery := repo.queries[1]
+		if similarQuery.SinceTimestamp == nil || !similarQuery.SinceTimestamp.Equal(candidates[5].Timestamp) {
+			t.Errorf("similarity search should be limited to the candidates' window, got since %v", similarQuery.SinceTimestamp)
+		}
+		if similarQuery.Limit != 2 {
+			t.Errorf("similarity limit = %d, want 2", similarQuery.Limit)
+		}
+		// e3 from embeddings, then the best keyword match, returned newest first
+		if got := headlineIDs(headlines); len(got) != 2 || got[0] != "e1" || got[1] != "e3" {
+			t.Errorf("headlines = %v, want [e1 e3]", got)
+		}
+	})
+
+	t.Run("embedding failure falls back to keywords", func(t *testing.T) {
+		repo := &fakeEventRepo{candidates: candidates, similarErr: errors.New("pgvector unavailable")}
+		f := &Forecaster{eventRepo: repo, embedder: fakeEmbedder{}, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+		headlines, err := f.fetchHeadlines(context.Background(), forecast)
+		if err != nil {
+			t.Fatalf("fetchHeadlines failed: %v", err)
+		}
+		if got := headlineIDs(headlines); len(got) != 2 || got[0] != "e1" || got[1] != "e4" {
+			t.Errorf("headlines = %v, want [e1 e4]", got)
+		}
+	})
+}
+
+func TestFetchHeadlines_Recent(t *testing.T) {
+	repo := &fakeEventRepo{candidates: newestFirst("a", "b", "c", "d")}
+	f := &Forecaster{eventRepo: repo, embedder: fakeEmbedder{}, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	forecast := &models.Forecast{ID: "f1", Proposition: "Anything", HeadlineCount: 2, HeadlineSelection: models.HeadlineSelectionRecent}
+
+	headlines, err := f.fetchHeadlines(context.Background(), forecast)
+	if err != nil {
+		t.Fatalf("fetchHeadlines failed: %v", err)
+	}
+	if len(repo.queries) != 1 || repo.queries[0].Limit != 2 {
+		t.Fatalf("expected a single newest-first query for 2 events, got %+v", repo.queries)
+	}
+	if got := headlineIDs(headlines); len(got) != 2 || got[0] != "e0" || got[1] != "e1" {
+		t.Errorf("headlines = %v, want [e0 e1]", got)
+	}
+}
+
+func TestFetchHeadlines_WidensSparseCategories(t *testing.T) {
+	all := newestFirst("a", "b", "c", "d", "e")
+	forecast := &models.Forecast{ID: "f1", Proposition: "Anything", HeadlineCount: 3, HeadlineSelection: models.HeadlineSelectionRecent, Categories: []string{"military"}}
+
+	t.Run("tops up from other categories", func(t *testing.T) {
+		repo := &fakeEventRepo{candidates: all, inCategories: []models.Event{all[2]}}
+		f := &Forecaster{eventRepo: repo, minHeadlines: 3, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+		headlines, err := f.fetchHeadlines(context.Background(), forecast)
+		if err != nil {
+			t.Fatalf("fetchHeadlines failed: %v", err)
+		}
+		if len(repo.queries) != 2 || repo.queries[1].Categories != nil {
+			t.Fatalf("expected a second query without categories, got %+v", repo.queries)
+		}
+		if got := headlineIDs(headlines); len(got) != 3 || got[0] != "e0" || got[1] != "e1" || got[2] != "e2" {
+			t.Fatalf("headlines = %v, want [e0 e1 e2]", got)
+		}
+		for _, h := range headlines {
+			if h.OutsideCategories != (h.EventID != "e2") {
+				t.Errorf("headline %s outside categories = %v", h.EventID, h.OutsideCategories)
+			}
+		}
+		if widened, sparse := headlineCoverage(headlines, 3); !widened || sparse {
+			t.Errorf("coverage = (%v, %v), want widened and not sparse", widened, sparse)
+		}
+	})
+
+	t.Run("enough matches are left alone", func(t *testing.T) {
+		repo := &fakeEventRepo{candidates: all, inCategories: all[2:]}
+		f := &Forecaster{eventRepo: repo, minHeadlines: 3, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+		headlines, err := f.fetchHeadlines(context.Background(), forecast)
+		if err != nil {
+			t.Fatalf("fetchHeadlines failed: %v", err)
+		}
+		if len(repo.queries) != 1 {
+			t.Errorf("expected no widening query, got %d queries", len(repo.queries))
+		}
+		if widened, _ := headlineCoverage(headlines, 3); widened {
+			t.Error("expected headlines to stay within the categories")
+		}
+	})
+
+	t.Run("disabled minimum never widens", func(t *testing.T) {
+		repo := &fakeEventRepo{candidates: all, inCategories: []models.Event{}}
+		f := &Forecaster{eventRepo: repo, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+		headlines, err := f.fetchHeadlines(context.Background(), forecast)
+		if err != nil {
+			t.Fatalf("fetchHeadlines failed: %v", err)
+		}
+		if len(repo.queries) != 1 || len(headlines) != 0 {
+			t.Errorf("expected one query and no headlines, got %d queries and %d headlines", len(repo.queries), len(headlines))
+		}
+	})
+}
+
+func TestSparseSignalsNote(t *testing.T) {
+	inside := models.ForecastHeadline{EventID: "e0"}
+	outside := models.ForecastHeadline{EventID: "e1", OutsideCategories: true}
+
+	if note := sparseSignalsNote([]models.ForecastHeadline{inside, inside}, 2); note != "" {
+		t.Errorf("expected no note with enough matching headlines, got %q", note)
+	}
+	if note := sparseSignalsNote([]models.ForecastHeadline{inside}, 2); !strings.Contains(note, "sparse") || strings.Contains(note, "OUTSIDE TOPIC") {
+		t.Errorf("expected only a sparse note, got %q", note)
+	}
+	if note := sparseSignalsNote([]models.ForecastHeadline{inside, outside}, 2); strings.Contains(note, "sparse") || !strings.Contains(note, "OUTSIDE TOPIC") {
+		t.Errorf("expected only a widened note, got %q", note)
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	words := keywords("Will the US S&P 500 close above 6000 by year end?")
+	for _, want := range []string{"us", "500", "close", "above", "6000"} {
+		if !words[want] {
+			t.Errorf("expected keyword %q in %v", want, words)
+		}
+	}
+	for _, unwanted := range []string{"will", "the", "by", "year", "end", "s", "p"} {
+		if words[unwanted] {
+			t.Errorf("unexpected keyword %q", unwanted)
+		}
+	}
+}
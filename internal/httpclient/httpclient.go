@@ -0,0 +1,156 @@
+// Package httpclient builds the HTTP clients used for requests to external services, so feeds,
+// articles, market data, webhooks and the Twitter API share timeouts, retries and a User-Agent.
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+)
+
+// DefaultUserAgent is sent with requests that set no User-Agent of their own.
+const DefaultUserAgent = "STRATINT/1.0 (+https://stratint.ai)"
+
+// Backoff between retries doubles from initialBackoff up to maxBackoff. Variables so tests
+// can shorten them.
+var (
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 8 * time.Second
+)
+
+var (
+	mu       sync.RWMutex
+	settings = config.HTTPClientConfig{
+		Timeout:    30 * time.Second,
+		MaxRetries: 2,
+		UserAgent:  DefaultUserAgent,
+	}
+)
+
+// Configure sets the defaults for every client. Call it at startup, before creating clients;
+// retries and the User-Agent also apply to clients created earlier.
+func Configure(cfg config.HTTPClientConfig) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if cfg.Timeout > 0 {
+		settings.Timeout = cfg.Timeout
+	}
+	if cfg.MaxRetries >= 0 {
+		settings.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.UserAgent != "" {
+		settings.UserAgent = cfg.UserAgent
+	}
+}
+
+func current() config.HTTPClientConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+	return settings
+}
+
+// Option overrides a default for one client.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	timeout   time.Duration
+	noRetries bool
+}
+
+// WithTimeout overrides the timeout, for sources that are slower or should fail faster.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) { o.timeout = timeout }
+}
+
+// WithoutRetries disables retries, for callers that run their own retry policy.
+func WithoutRetries() Option {
+	return func(o *clientOptions) { o.noRetries = true }
+}
+
+// New creates a client with the configured timeout, retries and User-Agent.
+func New(opts ...Option) *http.Client {
+	o := clientOptions{timeout: current().Timeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &http.Client{
+		Timeout: o.timeout,
+		Transport: &transport{
+			base:      http.DefaultTransport,
+			noRetries: o.noRetries,
+		},
+	}
+}
+
+// transport sets the default User-Agent and retries idempotent requests after network errors
+// and 5xx responses. POSTs are never retried: the upstream may have acted on the first attempt.
+type transport struct {
+	base      http.RoundTripper
+	noRetries bool
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := current()
+
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", s.UserAgent)
+	}
+
+	maxRetries := s.MaxRetries
+	if t.noRetries || !retryableMethod(req.Method) {
+		maxRetries = 0
+	}
+
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= maxRetries || !shouldRetry(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if req.Body != nil && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+		if resp != nil {
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func retryableMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// shouldRetry reports whether a failure is likely transient
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func init() {
+	initialBackoff = time.Millisecond
+	maxBackoff = 5 * time.Millisecond
+}
+
+// flakyServer fails with status the first failures requests and then succeeds
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(r.Header.Get("User-Agent")))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_RetriesServerErrors(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusServiceUnavailable)
+
+	resp, err := New().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("expected success on the third attempt, got status %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := flakyServer(t, 10, http.StatusBadGateway)
+
+	resp, err := New().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway || calls.Load() != 3 {
+		t.Errorf("expected the last 502 after 3 calls, got status %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClient_DoesNotRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		do     func(url string) (*http.Response, error)
+	}{
+		{"client errors", http.StatusNotFound, func(url string) (*http.Response, error) { return New().Get(url) }},
+		{"POST requests", http.StatusServiceUnavailable, func(url string) (*http.Response, error) {
+			return New().Post(url, "application/json", strings.NewReader("{}"))
+		}},
+		{"clients without retries", http.StatusServiceUnavailable, func(url string) (*http.Response, error) {
+			return New(WithoutRetries()).Get(url)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := flakyServer(t, 1, tt.status)
+			resp, err := tt.do(server.URL)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if calls.Load() != 1 {
+				t.Errorf("expected 1 call, got %d", calls.Load())
+			}
+		})
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	server, _ := flakyServer(t, 0, http.StatusOK)
+
+	body := func(req *http.Request) string {
+		resp, err := New().Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if got := body(req); got != DefaultUserAgent {
+		t.Errorf("expected default User-Agent, got %q", got)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	if got := body(req); got != "Mozilla/5.0" {
+		t.Errorf("expected the request's own User-Agent, got %q", got)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	if got := New(WithTimeout(15 * time.Second)).Timeout; got != 15*time.Second {
+		t.Errorf("Timeout = %v, want 15s", got)
+	}
+	if got := New().Timeout; got != current().Timeout {
+		t.Errorf("Timeout = %v, want the default %v", got, current().Timeout)
+	}
+}
@@ -0,0 +1,248 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// How long token usage read from the database is trusted before it is re-queried.
+// Tokens logged by this process in the meantime are added to the cached figure.
+const usageCacheTTL = 30 * time.Second
+
+// ErrBudgetExceeded is returned by CheckBudget once a token budget has been used up
+var ErrBudgetExceeded = errors.New("inference token budget exceeded")
+
+// Budget caps the tokens LLM calls may consume. A zero limit disables that period.
+type Budget struct {
+	DailyTokens   int64
+	MonthlyTokens int64
+}
+
+// LoadBudgetFromEnv loads the token budget from environment variables. Budgets are off by default.
+func LoadBudgetFromEnv() Budget {
+	var budget Budget
+	if v, err := strconv.ParseInt(os.Getenv("INFERENCE_DAILY_TOKEN_BUDGET"), 10, 64); err == nil && v > 0 {
+		budget.DailyTokens = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("INFERENCE_MONTHLY_TOKEN_BUDGET"), 10, 64); err == nil && v > 0 {
+		budget.MonthlyTokens = v
+	}
+	return budget
+}
+
+// Enabled reports whether any budget is set
+func (b Budget) Enabled() bool {
+	return b.DailyTokens > 0 || b.MonthlyTokens > 0
+}
+
+// budgetPeriod is a window over which a token budget applies
+type budgetPeriod struct {
+	name  string
+	limit int64
+	start time.Time
+	end   time.Time
+}
+
+// periods returns the enabled budget periods containing now (UTC calendar day and month)
+func (b Budget) periods(now time.Time) []budgetPeriod {
+	now = now.UTC()
+	var periods []budgetPeriod
+	if b.DailyTokens > 0 {
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		periods = append(periods, budgetPeriod{name: "daily", limit: b.DailyTokens, start: start, end: start.AddDate(0, 0, 1)})
+	}
+	if b.MonthlyTokens > 0 {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		periods = append(periods, budgetPeriod{name: "monthly", limit: b.MonthlyTokens, start: start, end: start.AddDate(0, 1, 0)})
+	}
+	return periods
+}
+
+// usageCache holds the tokens used since the start of a budget period
+type usageCache struct {
+	start     time.Time
+	used      int64
+	fetchedAt time.Time
+}
+
+// budgetState is the mutable budget tracking shared by a Logger's calls
+type budgetState struct {
+	mu       sync.Mutex
+	budget   Budget
+	usage    map[string]*usageCache // keyed by period name
+	alerted  map[string]time.Time   // period name -> start of the period already alerted
+	activity *database.ActivityLogRepository
+}
+
+// SetBudget enables token budget enforcement for calls gated by CheckBudget
+func (l *Logger) SetBudget(budget Budget) {
+	l.budget.mu.Lock()
+	defer l.budget.mu.Unlock()
+	l.budget.budget = budget
+	l.budget.usage = make(map[string]*usageCache)
+	l.budget.alerted = make(map[string]time.Time)
+}
+
+// SetActivityLogger enables an activity log entry when a budget is exhausted
+func (l *Logger) SetActivityLogger(activityLogRepo *database.ActivityLogRepository) {
+	l.budget.mu.Lock()
+	defer l.budget.mu.Unlock()
+	l.budget.activity = activityLogRepo
+}
+
+// CheckBudget returns ErrBudgetExceeded if any configured token budget has been used up.
+// Call it before making an LLM request. It is a no-op on a nil Logger or when no budget is set.
+func (l *Logger) CheckBudget(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.budget.mu.Lock()
+	budget := l.budget.budget
+	l.budget.mu.Unlock()
+
+	if !budget.Enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	for _, period := range budget.periods(now) {
+		used, err := l.usage(ctx, period, now)
+		if err != nil {
+			// Don't block inference because usage couldn't be read
+			l.logger.Warn("failed to check inference budget", "period", period.name, "error", err)
+			continue
+		}
+		if used < period.limit {
+			continue
+		}
+
+		l.budget.mu.Lock()
+		alert := l.budget.alerted[period.name] != period.start
+		if alert {
+			l.budget.alerted[period.name] = period.start
+		}
+		l.budget.mu.Unlock()
+		if alert {
+			l.alertBudgetExceeded(ctx, period, used)
+		}
+		return fmt.Errorf("%w: %s usage %d of %d tokens (resets %s)", ErrBudgetExceeded, period.name, used, period.limit, period.end.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// BudgetStatus reports current usage against each configured budget
+func (l *Logger) BudgetStatus(ctx context.Context) (*models.InferenceBudgetStatus, error) {
+	l.budget.mu.Lock()
+	budget := l.budget.budget
+	l.budget.mu.Unlock()
+
+	status := &models.InferenceBudgetStatus{Enabled: budget.Enabled()}
+
+	now := time.Now()
+	for _, period := range budget.periods(now) {
+		used, err := l.usage(ctx, period, now)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := period.limit - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		report := &models.InferenceBudgetPeriod{
+			Budget:    period.limit,
+			Used:      used,
+			Remaining: remaining,
+			Exceeded:  used >= period.limit,
+			ResetsAt:  period.end,
+		}
+
+		switch period.name {
+		case "daily":
+			status.Daily = report
+		case "monthly":
+			status.Monthly = report
+		}
+	}
+
+	return status, nil
+}
+
+// recordUsage adds tokens from a call logged by this process to the cached usage
+func (l *Logger) recordUsage(tokens int) {
+	l.budget.mu.Lock()
+	defer l.budget.mu.Unlock()
+	for _, cache := range l.budget.usage {
+		cache.used += int64(tokens)
+	}
+}
+
+// usage returns the tokens used in the period, refreshing from the database when the cache is
+// stale or belongs to an earlier period. The query runs without l.budget.mu held, so a slow
+// database doesn't stall every other LLM call behind it.
+func (l *Logger) usage(ctx context.Context, period budgetPeriod, now time.Time) (int64, error) {
+	l.budget.mu.Lock()
+	if cache, ok := l.budget.usage[period.name]; ok && cache.start.Equal(period.start) && now.Sub(cache.fetchedAt) < usageCacheTTL {
+		used := cache.used
+		l.budget.mu.Unlock()
+		return used, nil
+	}
+	l.budget.mu.Unlock()
+
+	used, err := l.repo.GetTokenUsageSince(ctx, period.start)
+	if err != nil {
+		return 0, err
+	}
+
+	l.budget.mu.Lock()
+	defer l.budget.mu.Unlock()
+	// A concurrent call may have refreshed the cache meanwhile; keep the fresher figure
+	if cache, ok := l.budget.usage[period.name]; ok && cache.start.Equal(period.start) && !cache.fetchedAt.Before(now) {
+		return cache.used, nil
+	}
+	if l.budget.usage == nil {
+		l.budget.usage = make(map[string]*usageCache)
+	}
+	l.budget.usage[period.name] = &usageCache{start: period.start, used: used, fetchedAt: now}
+	return used, nil
+}
+
+// alertBudgetExceeded logs that a budget has been exhausted, once per period
+func (l *Logger) alertBudgetExceeded(ctx context.Context, period budgetPeriod, used int64) {
+	message := fmt.Sprintf("Inference %s token budget exceeded: %d of %d tokens used; LLM calls paused until %s",
+		period.name, used, period.limit, period.end.Format(time.RFC3339))
+	l.logger.Error("inference token budget exceeded",
+		"period", period.name,
+		"used", used,
+		"budget", period.limit,
+		"resets_at", period.end)
+
+	l.budget.mu.Lock()
+	activity := l.budget.activity
+	l.budget.mu.Unlock()
+	if activity == nil {
+		return
+	}
+	if err := activity.Log(ctx, models.ActivityLog{
+		ActivityType: models.ActivityTypeInferenceBudget,
+		Message:      message,
+		Details: map[string]interface{}{
+			"period":    period.name,
+			"used":      used,
+			"budget":    period.limit,
+			"resets_at": period.end,
+		},
+	}); err != nil {
+		l.logger.Error("failed to log inference budget activity", "error", err)
+	}
+}
@@ -0,0 +1,81 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestLoadBudgetFromEnv(t *testing.T) {
+	t.Setenv("INFERENCE_DAILY_TOKEN_BUDGET", "1000")
+	t.Setenv("INFERENCE_MONTHLY_TOKEN_BUDGET", "")
+
+	budget := LoadBudgetFromEnv()
+	if budget.DailyTokens != 1000 {
+		t.Errorf("DailyTokens = %d, want 1000", budget.DailyTokens)
+	}
+	if budget.MonthlyTokens != 0 {
+		t.Errorf("MonthlyTokens = %d, want 0", budget.MonthlyTokens)
+	}
+	if !budget.Enabled() {
+		t.Error("expected budget to be enabled")
+	}
+
+	t.Setenv("INFERENCE_DAILY_TOKEN_BUDGET", "-5")
+	if LoadBudgetFromEnv().Enabled() {
+		t.Error("expected negative budget to be ignored")
+	}
+}
+
+func TestBudgetPeriods(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 15, 30, 0, 0, time.UTC)
+	periods := Budget{DailyTokens: 10, MonthlyTokens: 100}.periods(now)
+	if len(periods) != 2 {
+		t.Fatalf("got %d periods, want 2", len(periods))
+	}
+
+	daily, monthly := periods[0], periods[1]
+	if !daily.start.Equal(time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)) || !daily.end.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("daily period = %v - %v", daily.start, daily.end)
+	}
+	if !monthly.start.Equal(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)) || !monthly.end.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("monthly period = %v - %v", monthly.start, monthly.end)
+	}
+
+	if len((Budget{}).periods(now)) != 0 {
+		t.Error("expected no periods for a disabled budget")
+	}
+}
+
+func TestCheckBudget_Disabled(t *testing.T) {
+	var nilLogger *Logger
+	if err := nilLogger.CheckBudget(context.Background()); err != nil {
+		t.Errorf("nil logger: unexpected error %v", err)
+	}
+
+	// No budget set, so the repository is never consulted
+	l := &Logger{}
+	if err := l.CheckBudget(context.Background()); err != nil {
+		t.Errorf("disabled budget: unexpected error %v", err)
+	}
+}
+
+func TestCheckBudget_UsesCachedUsage(t *testing.T) {
+	// Usage cached within the TTL is used without querying the repository
+	l := &Logger{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	l.SetBudget(Budget{DailyTokens: 1000})
+	period := l.budget.budget.periods(time.Now())[0]
+	l.budget.usage[period.name] = &usageCache{start: period.start, used: 900, fetchedAt: time.Now()}
+
+	if err := l.CheckBudget(context.Background()); err != nil {
+		t.Fatalf("under budget: unexpected error %v", err)
+	}
+
+	l.recordUsage(200)
+	if err := l.CheckBudget(context.Background()); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded after recorded usage, got %v", err)
+	}
+}
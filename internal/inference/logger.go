@@ -14,6 +14,7 @@ import (
 type Logger struct {
 	repo   *database.InferenceLogRepository
 	logger *slog.Logger
+	budget budgetState
 }
 
 // NewLogger creates a new inference logger
@@ -63,6 +64,8 @@ func (l *Logger) LogCall(ctx context.Context, params LogCallParams) {
 		Metadata:     metadataJSON,
 	}
 
+	l.recordUsage(params.TokensUsed)
+
 	// Log asynchronously to avoid blocking the main operation
 	go func() {
 		bgCtx := context.Background()
@@ -78,8 +81,21 @@ func (l *Logger) LogOpenAICall(ctx context.Context, model, operation string, usa
 	CompletionTokens int
 	TotalTokens      int
 }, latency time.Duration, err error, metadata map[string]interface{}) {
+	l.LogOpenAIProviderCall(ctx, models.ProviderOpenAI, model, operation, usage, latency, err, metadata)
+}
+
+// LogOpenAIProviderCall logs a call made through the OpenAI client to the given provider.
+// Calls to OpenAI-compatible (usually local) servers are logged at no cost.
+func (l *Logger) LogOpenAIProviderCall(ctx context.Context, provider, model, operation string, usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}, latency time.Duration, err error, metadata map[string]interface{}) {
+	if provider == "" {
+		provider = models.ProviderOpenAI
+	}
 	params := LogCallParams{
-		Provider:     "openai",
+		Provider:     provider,
 		Model:        model,
 		Operation:    operation,
 		TokensUsed:   usage.TotalTokens,
@@ -100,7 +116,10 @@ func (l *Logger) LogOpenAICall(ctx context.Context, model, operation string, usa
 	}
 
 	// Estimate cost (rough estimates - update with actual pricing)
-	cost := estimateOpenAICost(model, usage.PromptTokens, usage.CompletionTokens)
+	var cost float64
+	if provider != models.ProviderOpenAICompatible {
+		cost = estimateOpenAICost(model, usage.PromptTokens, usage.CompletionTokens)
+	}
 	params.CostUSD = &cost
 
 	l.LogCall(ctx, params)
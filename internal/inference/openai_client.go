@@ -0,0 +1,31 @@
+package inference
+
+import (
+	"strings"
+
+	"github.com/STRATINT/stratint/internal/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// NewOpenAIClient creates an OpenAI client for the endpoint: the public OpenAI API by default,
+// a custom base URL, or an Azure OpenAI deployment.
+func NewOpenAIClient(apiKey string, endpoint models.OpenAIEndpoint) *openai.Client {
+	baseURL := strings.TrimSuffix(endpoint.BaseURL, "/")
+
+	if endpoint.IsAzure() {
+		cfg := openai.DefaultAzureConfig(apiKey, baseURL)
+		if endpoint.AzureAPIVersion != "" {
+			cfg.APIVersion = endpoint.AzureAPIVersion
+		}
+		// Azure routes by deployment rather than by model name
+		deployment := endpoint.AzureDeployment
+		cfg.AzureModelMapperFunc = func(string) string { return deployment }
+		return openai.NewClientWithConfig(cfg)
+	}
+
+	cfg := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	return openai.NewClientWithConfig(cfg)
+}
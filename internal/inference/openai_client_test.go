@@ -0,0 +1,75 @@
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// chatServer records the last chat completion request and answers it.
+func chatServer(t *testing.T, got **http.Request) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = r
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: "ok"}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewOpenAIClient_Azure(t *testing.T) {
+	var got *http.Request
+	server := chatServer(t, &got)
+
+	client := NewOpenAIClient("azure-key", models.OpenAIEndpoint{
+		BaseURL:         server.URL + "/",
+		AzureDeployment: "prod-gpt4o",
+		AzureAPIVersion: "2024-06-01",
+	})
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.URL.Path != "/openai/deployments/prod-gpt4o/chat/completions" {
+		t.Errorf("expected deployment path, got %s", got.URL.Path)
+	}
+	if v := got.URL.Query().Get("api-version"); v != "2024-06-01" {
+		t.Errorf("expected api-version 2024-06-01, got %q", v)
+	}
+	if key := got.Header.Get("api-key"); key != "azure-key" {
+		t.Errorf("expected api-key header, got %q", key)
+	}
+}
+
+func TestNewOpenAIClient_CustomBaseURL(t *testing.T) {
+	var got *http.Request
+	server := chatServer(t, &got)
+
+	client := NewOpenAIClient("sk-test", models.OpenAIEndpoint{BaseURL: server.URL + "/v1"})
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.URL.Path != "/v1/chat/completions" {
+		t.Errorf("expected /v1/chat/completions, got %s", got.URL.Path)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "Bearer sk-test" {
+		t.Errorf("expected bearer auth, got %q", auth)
+	}
+}
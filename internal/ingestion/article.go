@@ -0,0 +1,107 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/STRATINT/stratint/internal/httpclient"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// FetchArticlesSetting is the RSS connector config key that enables fetching the linked
+// article for items whose body is only a summary.
+const FetchArticlesSetting = "fetch_full_articles"
+
+const (
+	// summaryMaxChars is the longest RSS body still treated as a summary worth expanding
+	summaryMaxChars = 600
+
+	// maxArticleChars caps the article text kept, in line with what enrichment sends to the model
+	maxArticleChars = 20000
+
+	// maxArticleBytes caps how much of an article page is read
+	maxArticleBytes = 5 << 20
+)
+
+var (
+	articleClient = httpclient.New(httpclient.WithTimeout(15 * time.Second))
+
+	nonContentPattern = regexp.MustCompile(`(?is)<script[^>]*>.*?</script>|<style[^>]*>.*?</style>|<noscript[^>]*>.*?</noscript>`)
+	paragraphPattern  = regexp.MustCompile(`(?is)<p(?:\s[^>]*)?>(.*?)</p>`)
+)
+
+// ExpandSummary replaces an RSS item's summary with the text of the article it links to. Items
+// with a full body are left alone, as is content when the article yields less text than the
+// summary. It reports whether the content was replaced.
+func ExpandSummary(ctx context.Context, source *models.Source) (bool, error) {
+	if len(source.RawContent) > summaryMaxChars {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", source.URL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+
+	resp, err := articleClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("http get failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	if err != nil {
+		return false, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	text := extractArticleText(string(body))
+	if len(text) <= len(source.RawContent) {
+		return false, nil
+	}
+
+	source.RawContent = text
+	return true, nil
+}
+
+// extractArticleText pulls the readable text out of an article page by joining its paragraphs.
+// Short paragraphs are dropped as they are usually captions, bylines or navigation.
+func extractArticleText(page string) string {
+	page = nonContentPattern.ReplaceAllString(page, "")
+
+	var paragraphs []string
+	length := 0
+	for _, match := range paragraphPattern.FindAllStringSubmatch(page, -1) {
+		paragraph := strings.Join(strings.Fields(html.UnescapeString(cleanText(match[1]))), " ")
+		if len(paragraph) < 40 {
+			continue
+		}
+		paragraphs = append(paragraphs, paragraph)
+		length += len(paragraph)
+		if length >= maxArticleChars {
+			break
+		}
+	}
+
+	text := strings.Join(paragraphs, "\n\n")
+	if len(text) > maxArticleChars {
+		// Back off to a rune boundary so multi-byte characters aren't split
+		end := maxArticleChars
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		text = text[:end]
+	}
+	return text
+}
@@ -0,0 +1,62 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestExpandSummary(t *testing.T) {
+	page := `<html><head><script>var p = "<p>not article text at all, just a script string</p>";</script></head>
+<body><nav><p>Home</p></nav>
+<article>
+<p class="lead">Dock workers at the country's three largest ports walked out on Monday &amp; halted container traffic.</p>
+<p>Photo: Reuters</p>
+<p>The union said the strike would continue until talks over automation resume, with <a href="/more">more walkouts</a> planned.</p>
+</article></body></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	source := &models.Source{URL: server.URL, RawContent: "Dock workers walked out."}
+	expanded, err := ExpandSummary(context.Background(), source)
+	if err != nil {
+		t.Fatalf("ExpandSummary: %v", err)
+	}
+	if !expanded {
+		t.Fatal("expected the summary to be replaced")
+	}
+
+	want := "Dock workers at the country's three largest ports walked out on Monday & halted container traffic.\n\n" +
+		"The union said the strike would continue until talks over automation resume, with more walkouts planned."
+	if source.RawContent != want {
+		t.Errorf("unexpected article text:\n%s", source.RawContent)
+	}
+
+	// A full body is kept without fetching the article
+	long := strings.Repeat("Full article body. ", 50)
+	source = &models.Source{URL: "http://127.0.0.1:0/unreachable", RawContent: long}
+	if expanded, err := ExpandSummary(context.Background(), source); expanded || err != nil || source.RawContent != long {
+		t.Errorf("expected a full body to be left alone, got expanded=%v err=%v", expanded, err)
+	}
+}
+
+func TestExtractArticleText_TruncatesOnRuneBoundary(t *testing.T) {
+	// Odd-length paragraphs of 2-byte runes put the cap mid-rune
+	paragraph := "<p>" + strings.Repeat("Войска пересекли границу. ", 200) + "x</p>"
+	text := extractArticleText(strings.Repeat(paragraph, 10))
+
+	if len(text) > maxArticleChars {
+		t.Errorf("expected at most %d bytes, got %d", maxArticleChars, len(text))
+	}
+	if !utf8.ValidString(text) {
+		t.Error("expected truncation to keep the text valid UTF-8")
+	}
+}
@@ -0,0 +1,115 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// ErrFeedParse is wrapped by errors for feeds that are neither valid RSS nor Atom.
+var ErrFeedParse = errors.New("failed to parse feed")
+
+// StatusError is an unexpected HTTP response status from an upstream service.
+type StatusError struct {
+	StatusCode int
+	Body       string // Response body, when it was read
+}
+
+func (e *StatusError) Error() string {
+	if e.Body != "" {
+		return fmt.Sprintf("unexpected status code: %d - %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// ClassifyError maps an error to the failure mode it represents, so ingestion errors can be
+// grouped reliably.
+func ClassifyError(err error) models.ErrorCategory {
+	if err == nil {
+		return models.ErrorCategoryUnknown
+	}
+
+	if _, ok := IsRateLimited(err); ok {
+		return models.ErrorCategoryRateLimit
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch code := statusErr.StatusCode; {
+		case code == http.StatusTooManyRequests:
+			return models.ErrorCategoryRateLimit
+		case code == http.StatusUnauthorized || code == http.StatusForbidden:
+			return models.ErrorCategoryAuth
+		case code >= 500:
+			return models.ErrorCategoryHTTP5xx
+		case code >= 400:
+			return models.ErrorCategoryHTTP4xx
+		}
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && !dnsErr.IsTimeout {
+		return models.ErrorCategoryDNS
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return models.ErrorCategoryTimeout
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return models.ErrorCategoryConnection
+	}
+
+	var xmlErr *xml.SyntaxError
+	var jsonSyntaxErr *json.SyntaxError
+	var jsonTypeErr *json.UnmarshalTypeError
+	if errors.Is(err, ErrFeedParse) || errors.As(err, &xmlErr) || errors.As(err, &jsonSyntaxErr) || errors.As(err, &jsonTypeErr) {
+		return models.ErrorCategoryParse
+	}
+
+	return models.ErrorCategoryUnknown
+}
+
+// NewIngestionError records err as an ingestion error, classified by ClassifyError.
+func NewIngestionError(platform string, errorType models.IngestionErrorType, url string, err error) models.IngestionError {
+	return models.IngestionError{
+		Platform:  platform,
+		ErrorType: errorType,
+		Category:  ClassifyError(err),
+		URL:       url,
+		ErrorMsg:  err.Error(),
+		CreatedAt: time.Now(),
+	}
+}
+
+// ResolveTransientErrors auto-resolves open transient errors for a target after it fetched
+// successfully, so one-off failures don't linger in the admin view.
+func ResolveTransientErrors(ctx context.Context, repo database.IngestionErrorRepository, logger *slog.Logger, platform, url string) {
+	if repo == nil {
+		return
+	}
+
+	note := fmt.Sprintf("auto-resolved: fetch succeeded at %s", time.Now().UTC().Format(time.RFC3339))
+	resolved, err := repo.ResolveTransient(ctx, platform, url, note)
+	if err != nil {
+		logger.Error("failed to auto-resolve ingestion errors", "platform", platform, "url", url, "error", err)
+		return
+	}
+	if resolved > 0 {
+		logger.Info("auto-resolved transient ingestion errors", "platform", platform, "url", url, "count", resolved)
+	}
+}
@@ -0,0 +1,153 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestClassifyError(t *testing.T) {
+	var jsonErr error
+	var v map[string]interface{}
+	jsonErr = json.Unmarshal([]byte("{not json"), &v)
+
+	var xmlErr error
+	var x struct{}
+	xmlErr = xml.Unmarshal([]byte("<rss><channel>"), &x)
+
+	tests := []struct {
+		name string
+		err  error
+		want models.ErrorCategory
+	}{
+		{"nil", nil, models.ErrorCategoryUnknown},
+		{"not found", &StatusError{StatusCode: 404}, models.ErrorCategoryHTTP4xx},
+		{"unauthorized", &StatusError{StatusCode: 401}, models.ErrorCategoryAuth},
+		{"forbidden", &StatusError{StatusCode: 403}, models.ErrorCategoryAuth},
+		{"too many requests", &StatusError{StatusCode: 429}, models.ErrorCategoryRateLimit},
+		{"service unavailable", &StatusError{StatusCode: 503}, models.ErrorCategoryHTTP5xx},
+		{"wrapped status", fmt.Errorf("twitter API error: %w", &StatusError{StatusCode: 500, Body: "oops"}), models.ErrorCategoryHTTP5xx},
+		{"twitter rate limit", &RateLimitError{ResetAt: time.Now().Add(time.Minute)}, models.ErrorCategoryRateLimit},
+		{"dns", &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, models.ErrorCategoryDNS},
+		{"deadline", fmt.Errorf("failed to fetch feed: %w", context.DeadlineExceeded), models.ErrorCategoryTimeout},
+		{"dns timeout", &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, models.ErrorCategoryTimeout},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, models.ErrorCategoryConnection},
+		{"feed parse", fmt.Errorf("%w as RSS (error: a) or Atom (error: b)", ErrFeedParse), models.ErrorCategoryParse},
+		{"xml syntax", xmlErr, models.ErrorCategoryParse},
+		{"json syntax", jsonErr, models.ErrorCategoryParse},
+		{"other", errors.New("something odd"), models.ErrorCategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewIngestionError(t *testing.T) {
+	e := NewIngestionError("rss", models.ErrorTypeRSSFetchFailed, "https://example.com/feed", &StatusError{StatusCode: 502})
+
+	if e.Category != models.ErrorCategoryHTTP5xx {
+		t.Errorf("expected category %q, got %q", models.ErrorCategoryHTTP5xx, e.Category)
+	}
+	if e.ErrorMsg != "unexpected status code: 502" {
+		t.Errorf("unexpected error message %q", e.ErrorMsg)
+	}
+	if e.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
+	}
+}
+
+// recordingErrorRepo keeps stored errors in memory and resolves them like the Postgres repository.
+type recordingErrorRepo struct {
+	errors []models.IngestionError
+}
+
+func (r *recordingErrorRepo) Store(ctx context.Context, e models.IngestionError) error {
+	r.errors = append(r.errors, e)
+	return nil
+}
+
+func (r *recordingErrorRepo) List(ctx context.Context, filter models.IngestionErrorFilter) ([]models.IngestionError, error) {
+	return r.errors, nil
+}
+
+func (r *recordingErrorRepo) Stats(ctx context.Context, unresolvedOnly bool) ([]models.IngestionErrorStat, error) {
+	return nil, nil
+}
+
+func (r *recordingErrorRepo) GetByID(ctx context.Context, id string) (*models.IngestionError, error) {
+	return nil, nil
+}
+
+func (r *recordingErrorRepo) MarkResolved(ctx context.Context, id string) error { return nil }
+
+func (r *recordingErrorRepo) ResolveTransient(ctx context.Context, platform, url, note string) (int64, error) {
+	var resolved int64
+	for i := range r.errors {
+		e := &r.errors[i]
+		if e.Platform == platform && e.URL == url && !e.Resolved && e.Category.IsTransient() {
+			e.Resolved = true
+			e.ResolutionNote = note
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+func (r *recordingErrorRepo) Delete(ctx context.Context, id string) error { return nil }
+
+func (r *recordingErrorRepo) CountUnresolved(ctx context.Context) (int, error) { return 0, nil }
+
+func TestRSSConnector_AutoResolvesTransientErrors(t *testing.T) {
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0"?><rss version="2.0"><channel><title>Feed</title>
+<item><title>Back online</title><link>https://news.example.com/back</link><description>The feed recovered after a brief outage upstream.</description></item>
+</channel></rss>`)
+	}))
+	defer server.Close()
+
+	repo := &recordingErrorRepo{}
+	// A permanent failure for the same feed must stay open
+	repo.errors = append(repo.errors, NewIngestionError("rss", models.ErrorTypeRSSFetchFailed, server.URL, &StatusError{StatusCode: 401}))
+
+	connector, _ := NewRSSConnector([]string{server.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil)
+	connector.Fetch()
+
+	if len(repo.errors) != 2 || repo.errors[1].Category != models.ErrorCategoryRateLimit {
+		t.Fatalf("expected a logged rate_limit error, got %+v", repo.errors)
+	}
+
+	failing = false
+	sources, err := connector.Fetch()
+	if err != nil || len(sources) != 1 {
+		t.Fatalf("expected 1 source on retry, got %d (err %v)", len(sources), err)
+	}
+
+	if !repo.errors[1].Resolved || repo.errors[1].ResolutionNote == "" {
+		t.Errorf("expected transient error to be auto-resolved with a note, got %+v", repo.errors[1])
+	}
+	if repo.errors[0].Resolved {
+		t.Error("expected auth error to stay unresolved")
+	}
+}
@@ -3,15 +3,19 @@ package ingestion
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/httpclient"
 	"github.com/STRATINT/stratint/internal/models"
 	"log/slog"
 )
@@ -22,6 +26,7 @@ type RSSConnector struct {
 	logger       *slog.Logger
 	errorRepo    database.IngestionErrorRepository
 	activityRepo *database.ActivityLogRepository
+	feedAuth     map[string]*models.FeedAuth
 }
 
 // NewRSSConnector creates a new RSS connector.
@@ -44,6 +49,18 @@ func NewRSSConnector(feeds []string, logger *slog.Logger, errorRepo database.Ing
 	}, nil
 }
 
+// SetFeedAuth sends the given headers and basic auth credentials with every request for feedURL.
+func (c *RSSConnector) SetFeedAuth(feedURL string, auth *models.FeedAuth) {
+	if auth.IsEmpty() {
+		delete(c.feedAuth, feedURL)
+		return
+	}
+	if c.feedAuth == nil {
+		c.feedAuth = make(map[string]*models.FeedAuth)
+	}
+	c.feedAuth[feedURL] = auth
+}
+
 // Close shuts down the RSS connector.
 func (c *RSSConnector) Close() error {
 	return nil
@@ -68,6 +85,23 @@ type RSSItem struct {
 	GUID        string `xml:"guid"`
 	Category    string `xml:"category"`
 	RedditURL   string // Original Reddit discussion URL (only set for Reddit feeds)
+
+	Enclosures      []RSSEnclosure `xml:"enclosure"`
+	MediaContent    []RSSMedia     `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []RSSMedia     `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// RSSEnclosure represents a file attached to an RSS item.
+type RSSEnclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// RSSMedia represents a Media RSS (media:content or media:thumbnail) element.
+type RSSMedia struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
 }
 
 // AtomFeed represents the Atom feed structure (used by Reddit and others).
@@ -119,7 +153,7 @@ func (c *RSSConnector) Fetch() ([]models.Source, error) {
 
 			// Log error to database
 			if c.errorRepo != nil {
-				c.logError(context.Background(), "rss", string(models.ErrorTypeRSSFetchFailed), feedURL, err.Error(), nil)
+				c.logError(context.Background(), "rss", models.ErrorTypeRSSFetchFailed, feedURL, err, nil)
 			}
 			continue
 		}
@@ -127,6 +161,8 @@ func (c *RSSConnector) Fetch() ([]models.Source, error) {
 		duration := int(time.Since(startTime).Milliseconds())
 		c.logger.Info("fetched rss articles", "url", feedURL, "count", len(sources))
 
+		ResolveTransientErrors(context.Background(), c.errorRepo, c.logger, "rss", feedURL)
+
 		// Log successful fetch activity
 		if c.activityRepo != nil {
 			sourceCount := len(sources)
@@ -217,7 +253,7 @@ func (c *RSSConnector) fetchFeed(feedURL string) ([]models.Source, error) {
 					"url", feedURL,
 					"rss_error", rssErr,
 					"atom_error", atomErr.Error())
-				return nil, fmt.Errorf("failed to parse as RSS (error: %v) or Atom (error: %v)", rssErr, atomErr)
+				return nil, fmt.Errorf("%w as RSS (error: %v) or Atom (error: %v)", ErrFeedParse, rssErr, atomErr)
 			} else {
 				c.logger.Error("feed parsed but no items found", "url", feedURL, "rss_items", len(rss.Channel.Items), "atom_entries", len(atom.Entries))
 				return nil, fmt.Errorf("feed parsed successfully but contains no items")
@@ -301,6 +337,8 @@ func (c *RSSConnector) fetchFeed(feedURL string) ([]models.Source, error) {
 				FeedURL:   feedURL,
 				RedditURL: item.RedditURL,
 			},
+			Media:       itemMedia(item),
+			ExternalURL: descriptionExternalURL(item.Description, cleanURL, feedURL),
 		}
 
 		sources = append(sources, source)
@@ -395,15 +433,22 @@ func hashString(s string) string {
 
 // fetchFeedWithHTTP fetches RSS feed using standard HTTP client.
 func (c *RSSConnector) fetchFeedWithHTTP(feedURL string) ([]byte, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := httpclient.New()
 
 	req, err := http.NewRequest("GET", feedURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	if auth := c.feedAuth[feedURL]; auth != nil {
+		for name, value := range auth.Headers {
+			req.Header.Set(name, value)
+		}
+		if auth.Username != "" {
+			req.SetBasicAuth(auth.Username, auth.Password)
+		}
+		client.CheckRedirect = stripFeedAuthOnHostChange(auth)
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -412,7 +457,7 @@ func (c *RSSConnector) fetchFeedWithHTTP(feedURL string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -423,6 +468,25 @@ func (c *RSSConnector) fetchFeedWithHTTP(feedURL string) ([]byte, error) {
 	return body, nil
 }
 
+// stripFeedAuthOnHostChange returns a redirect policy that drops a feed's credentials once a
+// redirect leaves the feed's host, so they are only ever sent to the host they were set for.
+func stripFeedAuthOnHostChange(auth *models.FeedAuth) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if req.URL.Host != via[0].URL.Host {
+			for name := range auth.Headers {
+				req.Header.Del(name)
+			}
+			if auth.Username != "" {
+				req.Header.Del("Authorization")
+			}
+		}
+		return nil
+	}
+}
+
 // extractArticleURLFromReddit extracts the actual article URL from Reddit post content.
 // Reddit posts contain HTML like: <span><a href="https://example.com/article">[link]</a></span>
 // We want to extract the first external link (not reddit.com).
@@ -446,23 +510,86 @@ func extractArticleURLFromReddit(htmlContent string) (string, error) {
 	return "", fmt.Errorf("no external article URL found in Reddit content")
 }
 
-// logError logs an ingestion error to the database.
-func (c *RSSConnector) logError(ctx context.Context, platform, errorType, url, errorMsg string, metadataMap map[string]interface{}) {
+var (
+	imageSrcPattern = regexp.MustCompile(`(?i)<img\s[^>]*src="([^"]+)"`)
+	linkHrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*href="([^"]+)"`)
+)
+
+// itemMedia collects an item's image and video URLs from enclosures, Media RSS elements and
+// images embedded in its description, without duplicates.
+func itemMedia(item RSSItem) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(u, mediaType string) {
+		u = strings.TrimSpace(html.UnescapeString(u))
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") || seen[u] {
+			return
+		}
+		if mediaType != "" && !strings.HasPrefix(mediaType, "image") && !strings.HasPrefix(mediaType, "video") {
+			return
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+
+	for _, e := range item.Enclosures {
+		add(e.URL, e.Type)
+	}
+	for _, m := range item.MediaContent {
+		mediaType := m.Medium
+		if mediaType == "" {
+			mediaType = m.Type
+		}
+		add(m.URL, mediaType)
+	}
+	for _, m := range item.MediaThumbnails {
+		add(m.URL, "")
+	}
+	for _, match := range imageSrcPattern.FindAllStringSubmatch(item.Description, -1) {
+		add(match[1], "")
+	}
+
+	return urls
+}
+
+// descriptionExternalURL returns the first link in an item's description that points away from
+// both the item's and the feed's site, e.g. the story an aggregator or blog post is about.
+func descriptionExternalURL(description, itemURL, feedURL string) string {
+	ownHosts := map[string]bool{
+		linkHost(itemURL): true,
+		linkHost(feedURL): true,
+	}
+	for _, match := range linkHrefPattern.FindAllStringSubmatch(description, -1) {
+		link := strings.TrimSpace(html.UnescapeString(match[1]))
+		host := linkHost(link)
+		if host == "" || ownHosts[host] {
+			continue
+		}
+		return link
+	}
+	return ""
+}
+
+// linkHost returns the host of an absolute http(s) URL without any "www." prefix, or "" when the
+// URL is relative or can't be parsed.
+func linkHost(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// logError logs an ingestion error to the database, classified by the error's failure mode.
+func (c *RSSConnector) logError(ctx context.Context, platform string, errorType models.IngestionErrorType, url string, fetchErr error, metadataMap map[string]interface{}) {
 	metadata, err := database.CreateErrorMetadata(metadataMap)
 	if err != nil {
 		c.logger.Error("failed to create error metadata", "error", err)
 		metadata = ""
 	}
 
-	ingestionErr := models.IngestionError{
-		Platform:  platform,
-		ErrorType: errorType,
-		URL:       url,
-		ErrorMsg:  errorMsg,
-		Metadata:  metadata,
-		CreatedAt: time.Now(),
-		Resolved:  false,
-	}
+	ingestionErr := NewIngestionError(platform, errorType, url, fetchErr)
+	ingestionErr.Metadata = metadata
 
 	if err := c.errorRepo.Store(ctx, ingestionErr); err != nil {
 		c.logger.Error("failed to log ingestion error", "error", err)
@@ -0,0 +1,122 @@
+package ingestion
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func TestRSSConnector_CapturesMediaAndExternalLink(t *testing.T) {
+	feed := `<?xml version="1.0"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+<title>Example</title>
+<item>
+	<title>Port strike spreads</title>
+	<link>https://news.example.com/world/port-strike</link>
+	<description><![CDATA[<p>Dock workers walked out, <a href="https://news.example.com/world/earlier">as before</a>, according to <a href="https://agency.example.org/report?id=1&amp;lang=en">the agency report</a>.</p><img src="https://cdn.example.com/inline.jpg">]]></description>
+	<enclosure url="https://cdn.example.com/lead.jpg" type="image/jpeg" length="1000"/>
+	<enclosure url="https://cdn.example.com/episode.mp3" type="audio/mpeg" length="1000"/>
+	<media:content url="https://cdn.example.com/clip.mp4" medium="video"/>
+	<media:thumbnail url="https://cdn.example.com/lead.jpg"/>
+</item>
+</channel>
+</rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, feed)
+	}))
+	defer server.Close()
+
+	connector, _ := NewRSSConnector([]string{server.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)), nil, nil)
+	sources, err := connector.fetchFeed(server.URL)
+	if err != nil {
+		t.Fatalf("fetchFeed: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Fatalf("expected 1 source, got %d", len(sources))
+	}
+
+	wantMedia := []string{
+		"https://cdn.example.com/lead.jpg",
+		"https://cdn.example.com/clip.mp4",
+		"https://cdn.example.com/inline.jpg",
+	}
+	if !reflect.DeepEqual(sources[0].Media, wantMedia) {
+		t.Errorf("expected media %v, got %v", wantMedia, sources[0].Media)
+	}
+	if want := "https://agency.example.org/report?id=1&lang=en"; sources[0].ExternalURL != want {
+		t.Errorf("expected external URL %q, got %q", want, sources[0].ExternalURL)
+	}
+}
+
+func TestRSSConnector_SendsFeedAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if r.Header.Get("X-API-Key") != "abc123" || !ok || user != "analyst" || pass != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `<rss version="2.0"><channel><title>Private</title><item><title>Briefing</title><link>https://intel.example.com/briefings/1</link><description>Daily briefing for subscribers only.</description></item></channel></rss>`)
+	}))
+	defer server.Close()
+
+	connector, _ := NewRSSConnector([]string{server.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)), nil, nil)
+	if _, err := connector.fetchFeed(server.URL); err == nil {
+		t.Fatal("expected the feed to reject a request without credentials")
+	}
+
+	connector.SetFeedAuth(server.URL, &models.FeedAuth{
+		Headers:  map[string]string{"X-API-Key": "abc123"},
+		Username: "analyst",
+		Password: "hunter2",
+	})
+	sources, err := connector.fetchFeed(server.URL)
+	if err != nil {
+		t.Fatalf("fetchFeed: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Fatalf("expected 1 source, got %d", len(sources))
+	}
+}
+
+func TestRSSConnector_DropsFeedAuthOnCrossHostRedirect(t *testing.T) {
+	var leaked []string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "" {
+			leaked = append(leaked, "X-API-Key")
+		}
+		if r.Header.Get("Authorization") != "" {
+			leaked = append(leaked, "Authorization")
+		}
+		fmt.Fprint(w, `<rss version="2.0"><channel><title>Moved</title><item><title>Briefing</title><link>https://intel.example.com/briefings/1</link><description>Moved feed.</description></item></channel></rss>`)
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "abc123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Redirect(w, r, other.URL+"/feed", http.StatusFound)
+	}))
+	defer server.Close()
+
+	connector, _ := NewRSSConnector([]string{server.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)), nil, nil)
+	connector.SetFeedAuth(server.URL, &models.FeedAuth{
+		Headers:  map[string]string{"X-API-Key": "abc123"},
+		Username: "analyst",
+		Password: "hunter2",
+	})
+	if _, err := connector.fetchFeed(server.URL); err != nil {
+		t.Fatalf("fetchFeed: %v", err)
+	}
+	if len(leaked) > 0 {
+		t.Errorf("expected credentials to be dropped on a cross-host redirect, sent %v", leaked)
+	}
+}
@@ -9,8 +9,9 @@ import (
 
 // SourceRepository defines the interface for storing and retrieving sources.
 type SourceRepository interface {
-	// StoreRaw saves a raw source to the repository.
-	StoreRaw(ctx context.Context, source models.Source) error
+	// StoreRaw saves a raw source to the repository, updating it if its ID exists. A new source
+	// with the same URL or content hash as another is skipped and StoreRaw returns false.
+	StoreRaw(ctx context.Context, source models.Source) (bool, error)
 
 	// StoreBatch saves multiple raw sources in a single operation.
 	StoreBatch(ctx context.Context, sources []models.Source) error
@@ -63,9 +64,13 @@ type EventRepository interface {
 	// Delete removes an event by its ID.
 	Delete(ctx context.Context, id string) error
 
-	// UpdateStatus changes the status of an event.
+	// UpdateStatus changes the status of an event, clearing any rejection reason
+	// unless the new status is rejected.
 	UpdateStatus(ctx context.Context, id string, status models.EventStatus) error
 
+	// Reject marks an event as rejected with the given reason, leaving the rest of the row untouched.
+	Reject(ctx context.Context, id string, reason string) error
+
 	// HasSourceEvents checks if a source has any associated events.
 	HasSourceEvents(ctx context.Context, sourceID string) (bool, error)
 
@@ -75,8 +80,9 @@ type EventRepository interface {
 
 // MemorySourceRepository implements an in-memory source repository for testing/development.
 type MemorySourceRepository struct {
-	sources map[string]models.Source
-	urlIdx  map[string]string // URL -> ID mapping
+	sources     map[string]models.Source
+	urlIdx      map[string]string // URL -> ID mapping
+	dedupWindow time.Duration
 }
 
 // NewMemorySourceRepository creates a new in-memory source repository.
@@ -87,19 +93,44 @@ func NewMemorySourceRepository() *MemorySourceRepository {
 	}
 }
 
-// StoreRaw saves a raw source to memory.
-func (r *MemorySourceRepository) StoreRaw(ctx context.Context, source models.Source) error {
+// SetDedupWindow limits content hash duplicate checks to sources created within window;
+// zero checks all sources.
+func (r *MemorySourceRepository) SetDedupWindow(window time.Duration) {
+	r.dedupWindow = window
+}
+
+// inDedupWindow reports whether source is recent enough to count as a duplicate.
+func (r *MemorySourceRepository) inDedupWindow(source models.Source) bool {
+	return r.dedupWindow <= 0 || source.CreatedAt.IsZero() || time.Since(source.CreatedAt) <= r.dedupWindow
+}
+
+// StoreRaw saves a raw source to memory, skipping new sources that duplicate another's URL or
+// content hash.
+func (r *MemorySourceRepository) StoreRaw(ctx context.Context, source models.Source) (bool, error) {
+	if _, ok := r.sources[source.ID]; !ok {
+		if id, ok := r.urlIdx[source.URL]; ok && source.URL != "" && id != source.ID {
+			return false, nil
+		}
+		if source.ContentHash != "" {
+			for _, existing := range r.sources {
+				if existing.ContentHash == source.ContentHash && r.inDedupWindow(existing) {
+					return false, nil
+				}
+			}
+		}
+	}
+
 	r.sources[source.ID] = source
 	if source.URL != "" {
 		r.urlIdx[source.URL] = source.ID
 	}
-	return nil
+	return true, nil
 }
 
 // StoreBatch saves multiple sources to memory.
 func (r *MemorySourceRepository) StoreBatch(ctx context.Context, sources []models.Source) error {
 	for _, source := range sources {
-		if err := r.StoreRaw(ctx, source); err != nil {
+		if _, err := r.StoreRaw(ctx, source); err != nil {
 			return err
 		}
 	}
@@ -127,7 +158,7 @@ func (r *MemorySourceRepository) GetByURL(ctx context.Context, url string) (*mod
 // GetByTitleAndURL checks if a source with the same title and URL exists.
 func (r *MemorySourceRepository) GetByTitleAndURL(ctx context.Context, title, url string) (*models.Source, error) {
 	for _, source := range r.sources {
-		if source.Title == title && source.URL == url {
+		if source.Title == title && source.URL == url && r.inDedupWindow(source) {
 			return &source, nil
 		}
 	}
@@ -313,6 +344,26 @@ func (r *MemoryEventRepository) UpdateStatus(ctx context.Context, id string, sta
 
 	event.Status = status
 	event.UpdatedAt = time.Now()
+	if status != models.EventStatusRejected {
+		event.RejectionReason = ""
+		event.RejectionThresholds = nil
+	}
+	r.events[id] = event
+
+	return nil
+}
+
+// Reject marks an event as rejected with the given reason.
+func (r *MemoryEventRepository) Reject(ctx context.Context, id string, reason string) error {
+	event, ok := r.events[id]
+	if !ok {
+		return nil
+	}
+
+	event.Status = models.EventStatusRejected
+	event.RejectionReason = reason
+	event.RejectionThresholds = nil
+	event.UpdatedAt = time.Now()
 	r.events[id] = event
 
 	return nil
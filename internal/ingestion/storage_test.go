@@ -36,10 +36,10 @@ func TestMemorySourceRepository_GetByTitleAndURL(t *testing.T) {
 	}
 
 	// Store sources
-	if err := repo.StoreRaw(ctx, source1); err != nil {
+	if _, err := repo.StoreRaw(ctx, source1); err != nil {
 		t.Fatalf("failed to store source1: %v", err)
 	}
-	if err := repo.StoreRaw(ctx, source2); err != nil {
+	if _, err := repo.StoreRaw(ctx, source2); err != nil {
 		t.Fatalf("failed to store source2: %v", err)
 	}
 
@@ -126,7 +126,7 @@ func TestMemorySourceRepository_DuplicatePrevention(t *testing.T) {
 	}
 
 	// Store the source
-	if err := repo.StoreRaw(ctx, source); err != nil {
+	if _, err := repo.StoreRaw(ctx, source); err != nil {
 		t.Fatalf("failed to store source: %v", err)
 	}
 
@@ -173,3 +173,93 @@ func TestMemorySourceRepository_DuplicatePrevention(t *testing.T) {
 		t.Errorf("expected 1 source, got %d", repo.Size())
 	}
 }
+
+// TestMemorySourceRepository_StoreSkipsDuplicates tests that the repository itself skips new sources
+// that repeat another source's URL or content, while still updating a source stored again under its ID
+func TestMemorySourceRepository_StoreSkipsDuplicates(t *testing.T) {
+	repo := NewMemorySourceRepository()
+	ctx := context.Background()
+
+	original := models.Source{
+		ID:          "twitter-1",
+		Type:        models.SourceTypeTwitter,
+		URL:         "https://twitter.com/user/status/1",
+		RawContent:  "Explosion reported near the port",
+		ContentHash: "hash-1",
+	}
+	if stored, err := repo.StoreRaw(ctx, original); err != nil || !stored {
+		t.Fatalf("expected the original to be stored, got stored=%v err=%v", stored, err)
+	}
+
+	sameURL := original
+	sameURL.ID = "twitter-2"
+	sameURL.ContentHash = "hash-2"
+	if stored, err := repo.StoreRaw(ctx, sameURL); err != nil || stored {
+		t.Errorf("expected a source with the same URL to be skipped, got stored=%v err=%v", stored, err)
+	}
+
+	sameContent := original
+	sameContent.ID = "twitter-3"
+	sameContent.URL = "https://twitter.com/other/status/3"
+	if stored, err := repo.StoreRaw(ctx, sameContent); err != nil || stored {
+		t.Errorf("expected a source with the same content to be skipped, got stored=%v err=%v", stored, err)
+	}
+
+	updated := original
+	updated.Title = "Port explosion"
+	if stored, err := repo.StoreRaw(ctx, updated); err != nil || !stored {
+		t.Errorf("expected the original to be updated, got stored=%v err=%v", stored, err)
+	}
+
+	if repo.Size() != 1 {
+		t.Errorf("expected 1 source, got %d", repo.Size())
+	}
+	if got, _ := repo.GetByID(ctx, original.ID); got == nil || got.Title != "Port explosion" {
+		t.Errorf("expected the update to be stored, got %+v", got)
+	}
+}
+
+// TestMemorySourceRepository_DedupWindow tests that content stored before the dedup window
+// no longer blocks a new item, while recent duplicates are still skipped
+func TestMemorySourceRepository_DedupWindow(t *testing.T) {
+	repo := NewMemorySourceRepository()
+	repo.SetDedupWindow(30 * 24 * time.Hour)
+	ctx := context.Background()
+
+	old := models.Source{
+		ID:          "rss-old",
+		Title:       "Ceasefire announced",
+		URL:         "https://example.com/old",
+		ContentHash: "hash-old",
+		CreatedAt:   time.Now().Add(-90 * 24 * time.Hour),
+	}
+	recent := models.Source{
+		ID:          "rss-recent",
+		Title:       "Port closed",
+		URL:         "https://example.com/recent",
+		ContentHash: "hash-recent",
+		CreatedAt:   time.Now().Add(-24 * time.Hour),
+	}
+	for _, s := range []models.Source{old, recent} {
+		if _, err := repo.StoreRaw(ctx, s); err != nil {
+			t.Fatalf("StoreRaw failed: %v", err)
+		}
+	}
+
+	recycled := models.Source{ID: "rss-new", URL: "https://example.com/new", ContentHash: "hash-old", CreatedAt: time.Now()}
+	if stored, err := repo.StoreRaw(ctx, recycled); err != nil || !stored {
+		t.Errorf("expected content older than the window to be stored again, got stored=%v err=%v", stored, err)
+	}
+
+	repeat := models.Source{ID: "rss-repeat", URL: "https://example.com/repeat", ContentHash: "hash-recent", CreatedAt: time.Now()}
+	if stored, err := repo.StoreRaw(ctx, repeat); err != nil || stored {
+		t.Errorf("expected a recent duplicate to be skipped, got stored=%v err=%v", stored, err)
+	}
+
+	if found, _ := repo.GetByTitleAndURL(ctx, old.Title, old.URL); found != nil {
+		t.Errorf("expected no match outside the window, got %+v", found)
+	}
+	if found, _ := repo.GetByTitleAndURL(ctx, recent.Title, recent.URL); found == nil {
+		t.Error("expected a match within the window")
+	}
+}
@@ -5,23 +5,56 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/STRATINT/stratint/internal/enrichment"
+	"github.com/STRATINT/stratint/internal/httpclient"
 	"github.com/STRATINT/stratint/internal/models"
 )
 
+const (
+	twitterAPIBaseURL = "https://api.twitter.com/2"
+
+	// Backoff after a 429 that doesn't say when the limit resets, doubling on each consecutive
+	// 429 up to Twitter's 15 minute rate-limit window
+	twitterInitialRateLimitBackoff = 1 * time.Minute
+	twitterMaxRateLimitBackoff     = 15 * time.Minute
+)
+
+// RateLimitError is returned while the Twitter API rate limit is exhausted. Requests are not
+// sent until ResetAt, so callers should skip the remaining accounts rather than retry.
+type RateLimitError struct {
+	ResetAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("twitter API rate limited until %s", e.ResetAt.Format(time.RFC3339))
+}
+
+// twitterRateLimit tracks when the shared bearer token may be used again. Connectors are created
+// per poll and per manual fetch, so the state lives at package level to outlast them.
+var twitterRateLimit struct {
+	sync.Mutex
+	resetAt time.Time
+	strikes int // Consecutive 429s, for backoff when the reset header is missing
+}
+
 // TwitterConnector fetches tweets from tracked accounts using Twitter API v2
 type TwitterConnector struct {
 	bearerToken      string
 	logger           *slog.Logger
 	client           *http.Client
 	credibilityCache *enrichment.CredibilityCache
+	baseURL          string
+	retryPolicy      RetryPolicy
 }
 
 // NewTwitterConnector creates a new Twitter connector
@@ -30,9 +63,10 @@ func NewTwitterConnector(bearerToken string, logger *slog.Logger, credibilityCac
 		bearerToken:      bearerToken,
 		logger:           logger,
 		credibilityCache: credibilityCache,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		// The connector retries with its own policy, which also handles rate limits
+		client:      httpclient.New(httpclient.WithoutRetries()),
+		baseURL:     twitterAPIBaseURL,
+		retryPolicy: DefaultRetryPolicy(),
 	}
 }
 
@@ -42,6 +76,26 @@ type TwitterTweet struct {
 	Text      string    `json:"text"`
 	AuthorID  string    `json:"author_id"`
 	CreatedAt time.Time `json:"created_at"`
+	Entities  struct {
+		URLs []TwitterURL `json:"urls"`
+	} `json:"entities"`
+	Attachments struct {
+		MediaKeys []string `json:"media_keys"`
+	} `json:"attachments"`
+}
+
+// TwitterURL represents a link in a tweet's text
+type TwitterURL struct {
+	ExpandedURL string `json:"expanded_url"`
+	UnwoundURL  string `json:"unwound_url"` // Final URL after redirects, when Twitter resolved it
+}
+
+// TwitterMedia represents an attachment expanded into the response includes
+type TwitterMedia struct {
+	MediaKey        string `json:"media_key"`
+	Type            string `json:"type"` // photo, video or animated_gif
+	URL             string `json:"url"`
+	PreviewImageURL string `json:"preview_image_url"`
 }
 
 // TwitterUser represents a user from the API
@@ -53,9 +107,11 @@ type TwitterUser struct {
 
 // TwitterResponse represents the API response
 type TwitterResponse struct {
-	Data     []TwitterTweet         `json:"data"`
-	Includes map[string]interface{} `json:"includes"`
-	Meta     map[string]interface{} `json:"meta"`
+	Data     []TwitterTweet `json:"data"`
+	Includes struct {
+		Media []TwitterMedia `json:"media"`
+	} `json:"includes"`
+	Meta map[string]interface{} `json:"meta"`
 }
 
 // FetchAccountTweets fetches recent tweets from a specific account
@@ -78,7 +134,7 @@ func (tc *TwitterConnector) FetchAccountTweets(account *models.TrackedAccount) (
 	}
 
 	// Step 2: Fetch tweets
-	tweets, err := tc.getUserTweets(userID, account.LastFetchedID)
+	tweets, media, err := tc.getUserTweets(userID, account.LastFetchedID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch tweets: %w", err)
 	}
@@ -119,53 +175,119 @@ func (tc *TwitterConnector) FetchAccountTweets(account *models.TrackedAccount) (
 			Metadata: models.SourceMetadata{
 				TweetID: tweet.ID,
 			},
+			Media:       tweetMedia(tweet, media),
+			ExternalURL: tweetExternalURL(tweet),
 		}
+		source.AssignToWorkspace(account.WorkspaceID)
 		sources = append(sources, source)
 	}
 
 	return sources, nil
 }
 
-// getUserID fetches the Twitter user ID from username
-func (tc *TwitterConnector) getUserID(username string) (string, error) {
-	url := fmt.Sprintf("https://api.twitter.com/2/users/by/username/%s", username)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", err
+// get calls a Twitter API endpoint and decodes the JSON response into out. Network errors and
+// 5xx responses are retried with exponential backoff; a 429 records when the rate limit resets
+// and returns a RateLimitError, as does any call made before then.
+func (tc *TwitterConnector) get(url string, out interface{}) error {
+	twitterRateLimit.Lock()
+	resetAt := twitterRateLimit.resetAt
+	twitterRateLimit.Unlock()
+	if time.Now().Before(resetAt) {
+		return &RateLimitError{ResetAt: resetAt}
 	}
 
-	req.Header.Set("Authorization", "Bearer "+tc.bearerToken)
+	return Retry(context.Background(), tc.retryPolicy, func() error {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+tc.bearerToken)
 
-	resp, err := tc.client.Do(req)
-	if err != nil {
-		return "", err
+		resp, err := tc.client.Do(req)
+		if err != nil {
+			return NewRetryableError(err)
+		}
+		defer resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			return tc.rateLimited(resp)
+		case resp.StatusCode >= 500:
+			body, _ := io.ReadAll(resp.Body)
+			return NewRetryableError(fmt.Errorf("twitter API error: %w", &StatusError{StatusCode: resp.StatusCode, Body: string(body)}))
+		case resp.StatusCode != http.StatusOK:
+			body, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("twitter API error: %w", &StatusError{StatusCode: resp.StatusCode, Body: string(body)})
+		}
+
+		twitterRateLimit.Lock()
+		twitterRateLimit.strikes = 0
+		twitterRateLimit.Unlock()
+
+		return json.NewDecoder(resp.Body).Decode(out)
+	})
+}
+
+// rateLimited records a 429 response, backing off until the x-rate-limit-reset time or, without
+// one, for an exponentially growing interval
+func (tc *TwitterConnector) rateLimited(resp *http.Response) error {
+	twitterRateLimit.Lock()
+	defer twitterRateLimit.Unlock()
+
+	var resetAt time.Time
+	if reset, err := strconv.ParseInt(resp.Header.Get("x-rate-limit-reset"), 10, 64); err == nil {
+		resetAt = time.Unix(reset, 0)
+	} else {
+		backoff := twitterInitialRateLimitBackoff << twitterRateLimit.strikes
+		if backoff > twitterMaxRateLimitBackoff || backoff <= 0 {
+			backoff = twitterMaxRateLimitBackoff
+		}
+		resetAt = time.Now().Add(backoff)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("twitter API error: %d - %s", resp.StatusCode, string(body))
+	twitterRateLimit.strikes++
+	if resetAt.After(twitterRateLimit.resetAt) {
+		twitterRateLimit.resetAt = resetAt
 	}
 
+	tc.logger.Warn("twitter API rate limit reached", "reset_at", twitterRateLimit.resetAt)
+	return &RateLimitError{ResetAt: twitterRateLimit.resetAt}
+}
+
+// IsRateLimited reports whether err is, or wraps, a Twitter RateLimitError
+func IsRateLimited(err error) (*RateLimitError, bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr, true
+	}
+	return nil, false
+}
+
+// getUserID fetches the Twitter user ID from username
+func (tc *TwitterConnector) getUserID(username string) (string, error) {
+	url := fmt.Sprintf("%s/users/by/username/%s", tc.baseURL, username)
+
 	var result struct {
 		Data TwitterUser `json:"data"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := tc.get(url, &result); err != nil {
 		return "", err
 	}
 
 	return result.Data.ID, nil
 }
 
-// getUserTweets fetches tweets from a user
-func (tc *TwitterConnector) getUserTweets(userID, sinceID string) ([]TwitterTweet, error) {
-	url := fmt.Sprintf("https://api.twitter.com/2/users/%s/tweets", userID)
+// getUserTweets fetches tweets from a user, along with their attached media keyed by media key
+func (tc *TwitterConnector) getUserTweets(userID, sinceID string) ([]TwitterTweet, map[string]TwitterMedia, error) {
+	url := fmt.Sprintf("%s/users/%s/tweets", tc.baseURL, userID)
 
 	// Build query parameters
 	params := []string{
-		"tweet.fields=created_at,author_id",
+		"tweet.fields=created_at,author_id,entities,attachments",
+		"expansions=attachments.media_keys",
+		"media.fields=type,url,preview_image_url",
 		"max_results=10", // Fetch last 10 tweets
 	}
 
@@ -175,30 +297,52 @@ func (tc *TwitterConnector) getUserTweets(userID, sinceID string) ([]TwitterTwee
 
 	url += "?" + strings.Join(params, "&")
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+	var result TwitterResponse
+	if err := tc.get(url, &result); err != nil {
+		return nil, nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+tc.bearerToken)
-
-	resp, err := tc.client.Do(req)
-	if err != nil {
-		return nil, err
+	media := make(map[string]TwitterMedia, len(result.Includes.Media))
+	for _, m := range result.Includes.Media {
+		media[m.MediaKey] = m
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("twitter API error: %d - %s", resp.StatusCode, string(body))
-	}
+	return result.Data, media, nil
+}
 
-	var result TwitterResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+// tweetMedia returns the URLs of a tweet's attachments. Videos and GIFs have no direct URL in
+// the API, so their preview image is used.
+func tweetMedia(tweet TwitterTweet, media map[string]TwitterMedia) []string {
+	var urls []string
+	for _, key := range tweet.Attachments.MediaKeys {
+		m, ok := media[key]
+		if !ok {
+			continue
+		}
+		if m.URL != "" {
+			urls = append(urls, m.URL)
+		} else if m.PreviewImageURL != "" {
+			urls = append(urls, m.PreviewImageURL)
+		}
 	}
+	return urls
+}
 
-	return result.Data, nil
+// tweetExternalURL returns the first link in a tweet that leaves Twitter. Links to the tweet's
+// own media and to other tweets are skipped.
+func tweetExternalURL(tweet TwitterTweet) string {
+	for _, u := range tweet.Entities.URLs {
+		link := u.UnwoundURL
+		if link == "" {
+			link = u.ExpandedURL
+		}
+		switch linkHost(link) {
+		case "", "twitter.com", "x.com", "mobile.twitter.com", "mobile.x.com", "pic.twitter.com", "t.co":
+			continue
+		}
+		return link
+	}
+	return ""
 }
 
 // GetLatestTweetID returns the most recent tweet ID from a list of sources
@@ -0,0 +1,157 @@
+package ingestion
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+func resetTwitterRateLimit(t *testing.T) {
+	t.Helper()
+	clear := func() {
+		twitterRateLimit.Lock()
+		twitterRateLimit.resetAt = time.Time{}
+		twitterRateLimit.strikes = 0
+		twitterRateLimit.Unlock()
+	}
+	clear()
+	t.Cleanup(clear)
+}
+
+func newTestTwitterConnector(baseURL string) *TwitterConnector {
+	tc := NewTwitterConnector("token", slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+	tc.baseURL = baseURL
+	tc.retryPolicy = RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffFactor: 2}
+	return tc
+}
+
+func TestTwitterConnector_RateLimitBacksOffUntilReset(t *testing.T) {
+	resetTwitterRateLimit(t)
+
+	reset := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("x-rate-limit-reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	tc := newTestTwitterConnector(server.URL)
+	account := &models.TrackedAccount{Platform: "twitter", AccountIdentifier: "@first"}
+
+	_, err := tc.FetchAccountTweets(account)
+	rateLimitErr, ok := IsRateLimited(err)
+	if !ok {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if !rateLimitErr.ResetAt.Equal(reset) {
+		t.Errorf("expected reset at %v, got %v", reset, rateLimitErr.ResetAt)
+	}
+
+	// Later requests, even from a new connector, wait for the reset instead of calling the API
+	account.AccountIdentifier = "@second"
+	if _, err := newTestTwitterConnector(server.URL).FetchAccountTweets(account); err == nil {
+		t.Fatal("expected the second account to be skipped")
+	} else if _, ok := IsRateLimited(err); !ok {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 API call, got %d", calls.Load())
+	}
+}
+
+func TestTwitterConnector_RateLimitWithoutResetHeader(t *testing.T) {
+	resetTwitterRateLimit(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := newTestTwitterConnector(server.URL).getUserID("someone")
+	rateLimitErr, ok := IsRateLimited(err)
+	if !ok {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if wait := time.Until(rateLimitErr.ResetAt); wait <= 0 || wait > twitterInitialRateLimitBackoff {
+		t.Errorf("expected to back off for up to %v, got %v", twitterInitialRateLimitBackoff, wait)
+	}
+}
+
+func TestTwitterConnector_RetriesServerErrors(t *testing.T) {
+	resetTwitterRateLimit(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"id": "42", "username": "someone"}}`)
+	}))
+	defer server.Close()
+
+	id, err := newTestTwitterConnector(server.URL).getUserID("someone")
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if id != "42" || calls.Load() != 3 {
+		t.Errorf("expected user 42 after 3 calls, got %q after %d", id, calls.Load())
+	}
+}
+
+func TestTwitterConnector_CapturesMediaAndExternalLink(t *testing.T) {
+	resetTwitterRateLimit(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/users/by/username/") {
+			fmt.Fprint(w, `{"data": {"id": "42", "username": "someone"}}`)
+			return
+		}
+		fmt.Fprint(w, `{
+			"data": [{
+				"id": "100",
+				"text": "Satellite images of the port https://t.co/a https://t.co/b",
+				"author_id": "42",
+				"created_at": "2025-01-02T03:04:05Z",
+				"entities": {"urls": [
+					{"expanded_url": "https://twitter.com/someone/status/100/photo/1"},
+					{"expanded_url": "https://bit.ly/x", "unwound_url": "https://news.example.com/port"}
+				]},
+				"attachments": {"media_keys": ["3_1", "7_2", "3_missing"]}
+			}],
+			"includes": {"media": [
+				{"media_key": "3_1", "type": "photo", "url": "https://pbs.twimg.com/media/one.jpg"},
+				{"media_key": "7_2", "type": "video", "preview_image_url": "https://pbs.twimg.com/preview/two.jpg"}
+			]}
+		}`)
+	}))
+	defer server.Close()
+
+	sources, err := newTestTwitterConnector(server.URL).FetchAccountTweets(&models.TrackedAccount{Platform: "twitter", AccountIdentifier: "someone"})
+	if err != nil {
+		t.Fatalf("FetchAccountTweets: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Fatalf("expected 1 source, got %d", len(sources))
+	}
+
+	wantMedia := []string{"https://pbs.twimg.com/media/one.jpg", "https://pbs.twimg.com/preview/two.jpg"}
+	if !reflect.DeepEqual(sources[0].Media, wantMedia) {
+		t.Errorf("expected media %v, got %v", wantMedia, sources[0].Media)
+	}
+	if sources[0].ExternalURL != "https://news.example.com/port" {
+		t.Errorf("expected the unwound article link, got %q", sources[0].ExternalURL)
+	}
+}
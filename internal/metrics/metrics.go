@@ -9,11 +9,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// HTTPCollector exposes Prometheus metrics for inbound HTTP requests.
+// HTTPCollector exposes Prometheus metrics for inbound HTTP requests and the enricher in use.
 type HTTPCollector struct {
 	registry        *prometheus.Registry
 	requestDuration *prometheus.HistogramVec
 	requestTotal    *prometheus.CounterVec
+	mockEnricher    prometheus.Gauge
 }
 
 // NewHTTPCollector constructs a collector with default histograms/counters.
@@ -39,14 +40,26 @@ func NewHTTPCollector() (*HTTPCollector, error) {
 		return nil, err
 	}
 
+	mockEnricher := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "osintmcp",
+		Subsystem: "enrichment",
+		Name:      "mock_enricher_active",
+		Help:      "1 when enrichment has fallen back to the rule-based mock enricher, 0 when using OpenAI.",
+	})
+
 	if err := registry.Register(requestTotal); err != nil {
 		return nil, err
 	}
 
+	if err := registry.Register(mockEnricher); err != nil {
+		return nil, err
+	}
+
 	collector := &HTTPCollector{
 		registry:        registry,
 		requestDuration: requestDuration,
 		requestTotal:    requestTotal,
+		mockEnricher:    mockEnricher,
 	}
 
 	return collector, nil
@@ -57,6 +70,15 @@ func (c *HTTPCollector) Handler() http.Handler {
 	return promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{})
 }
 
+// SetMockEnricher records whether the mock enricher is in use.
+func (c *HTTPCollector) SetMockEnricher(active bool) {
+	if active {
+		c.mockEnricher.Set(1)
+	} else {
+		c.mockEnricher.Set(0)
+	}
+}
+
 // InstrumentHandler wraps the provided handler to record HTTP metrics.
 func (c *HTTPCollector) InstrumentHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -84,3 +106,8 @@ func (w *responseWriter) WriteHeader(code int) {
 	w.status = code
 	w.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap exposes the underlying writer to http.ResponseController, e.g. for flushing streams
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
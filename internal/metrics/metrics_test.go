@@ -52,3 +52,25 @@ func TestHTTPCollectorRecordsMetrics(t *testing.T) {
 		t.Fatalf("request_duration_seconds_count metric not recorded, body=%q", body)
 	}
 }
+
+func TestHTTPCollectorMockEnricherGauge(t *testing.T) {
+	collector, err := NewHTTPCollector()
+	if err != nil {
+		t.Fatalf("NewHTTPCollector returned error: %v", err)
+	}
+
+	scrape := func() string {
+		rr := httptest.NewRecorder()
+		collector.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rr.Body.String()
+	}
+
+	if body := scrape(); !strings.Contains(body, "osintmcp_enrichment_mock_enricher_active 0") {
+		t.Fatalf("expected mock enricher gauge to default to 0, body=%q", body)
+	}
+
+	collector.SetMockEnricher(true)
+	if body := scrape(); !strings.Contains(body, "osintmcp_enrichment_mock_enricher_active 1") {
+		t.Fatalf("expected mock enricher gauge to be 1, body=%q", body)
+	}
+}
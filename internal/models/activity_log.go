@@ -13,6 +13,13 @@ const (
 	ActivityTypeEnrichment       ActivityType = "enrichment"
 	ActivityTypeCorrelation      ActivityType = "correlation"
 	ActivityTypePublish          ActivityType = "publish"
+	ActivityTypeForecastAlert    ActivityType = "forecast_alert"
+	ActivityTypeInferenceBudget  ActivityType = "inference_budget"
+	ActivityTypeRetention        ActivityType = "retention"
+	ActivityTypeArchive          ActivityType = "archive"
+	ActivityTypeReprocess        ActivityType = "reprocess"
+	ActivityTypeTwitterRateLimit ActivityType = "twitter_rate_limit"
+	ActivityTypeTrackedAccounts  ActivityType = "tracked_accounts"
 )
 
 // ActivityLog represents a logged activity in the system.
@@ -26,3 +33,13 @@ type ActivityLog struct {
 	SourceCount  *int                   `json:"source_count,omitempty"`
 	DurationMs   *int                   `json:"duration_ms,omitempty"`
 }
+
+// ActivityLogQuery filters and pages the activity log. Zero values mean no filter.
+type ActivityLogQuery struct {
+	ActivityType ActivityType
+	Platform     string
+	Since        *time.Time // Inclusive lower bound on timestamp
+	Until        *time.Time // Exclusive upper bound on timestamp
+	Limit        int
+	Offset       int
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// API key scopes
+const (
+	APIKeyScopeRead  = "read"  // GET access to routes analysts can read
+	APIKeyScopeWrite = "write" // Full admin access
+)
+
+// APIKey is a long-lived credential for programmatic access. Only its hash is persisted.
+type APIKey struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	KeyPrefix   string     `json:"key_prefix"` // First characters of the key, for identification
+	KeyHash     string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	WorkspaceID string     `json:"workspace_id"` // Workspace the key acts in: the one it was created in
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyRequest is the payload for creating an API key
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"` // Defaults to read
+}
+
+// CreateAPIKeyResponse returns a new key. The key is never retrievable again.
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
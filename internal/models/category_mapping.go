@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CategoryMapping maps raw categories returned by the enrichment model to event categories,
+// e.g. {"security": "military", "terrorism": "geopolitics"}. Keys are matched case-insensitively
+// and take precedence over the built-in categories, so known categories can be folded too.
+type CategoryMapping map[string]Category
+
+// Normalize turns a raw model category into a stored category: the mapped category if there is
+// one, the category itself if it is known, otherwise CategoryOther.
+func (m CategoryMapping) Normalize(raw string) Category {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	for from, to := range m {
+		if strings.ToLower(strings.TrimSpace(from)) == raw {
+			return to
+		}
+	}
+	if category := Category(raw); ValidCategory(category) {
+		return category
+	}
+	return CategoryOther
+}
+
+// Validate checks that every raw category is named and maps to a known category.
+func (m CategoryMapping) Validate() error {
+	seen := make(map[string]bool, len(m))
+	for from, to := range m {
+		key := strings.ToLower(strings.TrimSpace(from))
+		if key == "" {
+			return fmt.Errorf("raw category names cannot be empty")
+		}
+		if seen[key] {
+			return fmt.Errorf("raw category %q is mapped more than once", key)
+		}
+		seen[key] = true
+		if !ValidCategory(to) {
+			return fmt.Errorf("%q maps to unknown category %q: must be one of %s", from, to, joinValues(Categories))
+		}
+	}
+	return nil
+}
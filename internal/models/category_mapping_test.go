@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCategoryMapping_Normalize(t *testing.T) {
+	mapping := CategoryMapping{"Security": CategoryMilitary, "terrorism": CategoryGeopolitics}
+
+	tests := []struct {
+		raw  string
+		want Category
+	}{
+		{"security", CategoryMilitary},
+		{" SECURITY ", CategoryMilitary},
+		{"terrorism", CategoryGeopolitics}, // Known categories can be folded too
+		{"Cyber", CategoryCyber},
+		{"weather", CategoryOther},
+		{"", CategoryOther},
+	}
+	for _, tt := range tests {
+		if got := mapping.Normalize(tt.raw); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+
+	if got := CategoryMapping(nil).Normalize("terrorism"); got != CategoryTerrorism {
+		t.Errorf("nil mapping should keep known categories, got %q", got)
+	}
+}
+
+func TestCategoryMapping_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping CategoryMapping
+		wantErr string
+	}{
+		{"empty", CategoryMapping{}, ""},
+		{"valid", CategoryMapping{"security": CategoryMilitary}, ""},
+		{"unknown target", CategoryMapping{"terrorism": "security"}, `maps to unknown category "security"`},
+		{"blank source", CategoryMapping{" ": CategoryOther}, "cannot be empty"},
+		{"duplicate source", CategoryMapping{"Security": CategoryMilitary, "security": CategoryCyber}, "mapped more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
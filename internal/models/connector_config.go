@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // ConnectorConfig represents configuration for a data source connector.
 type ConnectorConfig struct {
@@ -10,3 +13,10 @@ type ConnectorConfig struct {
 	UpdatedAt time.Time         `json:"updated_at"` // Last update timestamp
 	CreatedAt time.Time         `json:"created_at"` // Creation timestamp
 }
+
+// IsSecretSetting reports whether a connector config key holds a credential, which is encrypted
+// at rest and masked in responses
+func IsSecretSetting(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "token") || strings.Contains(key, "key") || strings.Contains(key, "secret")
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// DomainCredibility is a stored credibility assessment for a source domain.
+type DomainCredibility struct {
+	Domain     string    `json:"domain"`
+	Score      float64   `json:"score"`
+	ComputedAt time.Time `json:"computed_at"`
+}
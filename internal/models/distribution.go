@@ -0,0 +1,142 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Upper bounds of the magnitude and confidence scales histograms cover
+const (
+	MagnitudeScale  = 10.0
+	ConfidenceScale = 1.0
+)
+
+// Metrics a distribution count refers to
+const (
+	DistributionMagnitude  = "magnitude"
+	DistributionConfidence = "confidence"
+)
+
+// DistributionQuery selects the events and bucket widths for an event distribution.
+type DistributionQuery struct {
+	Since           time.Time
+	Status          *EventStatus // Nil counts every status except archived
+	MagnitudeWidth  float64
+	ConfidenceWidth float64
+	ByCategory      bool // Also break both histograms down per category
+}
+
+// HistogramBucket counts events whose value falls in [Min, Max). The last bucket also
+// includes the top of the scale.
+type HistogramBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// CategoryDistribution holds the histograms for one category.
+type CategoryDistribution struct {
+	Total      int               `json:"total"`
+	Magnitude  []HistogramBucket `json:"magnitude"`
+	Confidence []HistogramBucket `json:"confidence"`
+}
+
+// EventDistribution is the spread of magnitude and confidence across events, for picking
+// publication thresholds.
+type EventDistribution struct {
+	Total      int                               `json:"total"`
+	Magnitude  []HistogramBucket                 `json:"magnitude"`
+	Confidence []HistogramBucket                 `json:"confidence"`
+	ByCategory map[Category]CategoryDistribution `json:"by_category,omitempty"`
+}
+
+// DistributionCount is one grouped row behind a distribution: how many events of a category
+// fall in a metric's bucket, where Bucket is the value divided by the bucket width, rounded down.
+type DistributionCount struct {
+	Metric   string
+	Category Category
+	Bucket   int
+	Count    int
+}
+
+// BuildDistribution assembles the overall and, if requested, per-category histograms from
+// grouped counts
+func BuildDistribution(q DistributionQuery, counts []DistributionCount) *EventDistribution {
+	type bucketCounts struct {
+		total      int
+		magnitude  map[int]int
+		confidence map[int]int
+	}
+	newCounts := func() *bucketCounts {
+		return &bucketCounts{magnitude: make(map[int]int), confidence: make(map[int]int)}
+	}
+
+	overall := newCounts()
+	categories := make(map[Category]*bucketCounts)
+	for _, c := range counts {
+		perCategory := categories[c.Category]
+		if perCategory == nil {
+			perCategory = newCounts()
+			categories[c.Category] = perCategory
+		}
+		switch c.Metric {
+		case DistributionMagnitude:
+			i := clampBucket(c.Bucket, MagnitudeScale, q.MagnitudeWidth)
+			overall.magnitude[i] += c.Count
+			perCategory.magnitude[i] += c.Count
+			overall.total += c.Count
+			perCategory.total += c.Count
+		case DistributionConfidence:
+			i := clampBucket(c.Bucket, ConfidenceScale, q.ConfidenceWidth)
+			overall.confidence[i] += c.Count
+			perCategory.confidence[i] += c.Count
+		}
+	}
+
+	dist := &EventDistribution{
+		Total:      overall.total,
+		Magnitude:  BuildHistogram(overall.magnitude, MagnitudeScale, q.MagnitudeWidth),
+		Confidence: BuildHistogram(overall.confidence, ConfidenceScale, q.ConfidenceWidth),
+	}
+	if q.ByCategory {
+		dist.ByCategory = make(map[Category]CategoryDistribution, len(categories))
+		for category, c := range categories {
+			dist.ByCategory[category] = CategoryDistribution{
+				Total:      c.total,
+				Magnitude:  BuildHistogram(c.magnitude, MagnitudeScale, q.MagnitudeWidth),
+				Confidence: BuildHistogram(c.confidence, ConfidenceScale, q.ConfidenceWidth),
+			}
+		}
+	}
+	return dist
+}
+
+// HistogramBucketCount is how many buckets of the given width cover a scale from 0 to top
+func HistogramBucketCount(top, width float64) int {
+	return int(math.Ceil(top/width - 1e-9))
+}
+
+// clampBucket keeps a bucket index on the scale, so values at the top of the scale (or out of
+// range) count in the first or last bucket
+func clampBucket(index int, top, width float64) int {
+	return min(max(index, 0), HistogramBucketCount(top, width)-1)
+}
+
+// BuildHistogram turns counts keyed by bucket index into contiguous buckets from 0 to top,
+// including empty ones.
+func BuildHistogram(counts map[int]int, top, width float64) []HistogramBucket {
+	buckets := make([]HistogramBucket, HistogramBucketCount(top, width))
+	for i := range buckets {
+		buckets[i] = HistogramBucket{
+			Min:   roundBound(float64(i) * width),
+			Max:   roundBound(math.Min(float64(i+1)*width, top)),
+			Count: counts[i],
+		}
+	}
+	return buckets
+}
+
+// roundBound trims floating point noise such as 0.30000000000000004 from bucket bounds
+func roundBound(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
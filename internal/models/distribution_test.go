@@ -0,0 +1,55 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildDistribution(t *testing.T) {
+	q := DistributionQuery{MagnitudeWidth: 2.5, ConfidenceWidth: 0.3, ByCategory: true}
+	counts := []DistributionCount{
+		{Metric: DistributionMagnitude, Category: CategoryMilitary, Bucket: 2, Count: 3},
+		{Metric: DistributionMagnitude, Category: CategoryMilitary, Bucket: 4, Count: 1}, // Magnitude 10 tops the scale
+		{Metric: DistributionMagnitude, Category: CategoryCyber, Bucket: 0, Count: 2},
+		{Metric: DistributionConfidence, Category: CategoryMilitary, Bucket: 1, Count: 4},
+		{Metric: DistributionConfidence, Category: CategoryCyber, Bucket: 3, Count: 2}, // Confidence 0.9-1.0
+	}
+
+	dist := BuildDistribution(q, counts)
+	if dist.Total != 6 {
+		t.Errorf("Total = %d, want 6", dist.Total)
+	}
+
+	wantMagnitude := []HistogramBucket{
+		{Min: 0, Max: 2.5, Count: 2},
+		{Min: 2.5, Max: 5, Count: 0},
+		{Min: 5, Max: 7.5, Count: 3},
+		{Min: 7.5, Max: 10, Count: 1},
+	}
+	if !reflect.DeepEqual(dist.Magnitude, wantMagnitude) {
+		t.Errorf("Magnitude = %+v, want %+v", dist.Magnitude, wantMagnitude)
+	}
+
+	wantConfidence := []HistogramBucket{
+		{Min: 0, Max: 0.3, Count: 0},
+		{Min: 0.3, Max: 0.6, Count: 4},
+		{Min: 0.6, Max: 0.9, Count: 0},
+		{Min: 0.9, Max: 1, Count: 2},
+	}
+	if !reflect.DeepEqual(dist.Confidence, wantConfidence) {
+		t.Errorf("Confidence = %+v, want %+v", dist.Confidence, wantConfidence)
+	}
+
+	military := dist.ByCategory[CategoryMilitary]
+	if military.Total != 4 || military.Magnitude[2].Count != 3 || military.Confidence[1].Count != 4 {
+		t.Errorf("military distribution = %+v", military)
+	}
+	if cyber := dist.ByCategory[CategoryCyber]; cyber.Total != 2 || cyber.Confidence[3].Count != 2 {
+		t.Errorf("cyber distribution = %+v", cyber)
+	}
+
+	q.ByCategory = false
+	if dist := BuildDistribution(q, counts); dist.ByCategory != nil {
+		t.Error("ByCategory should be empty unless requested")
+	}
+}
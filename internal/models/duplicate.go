@@ -0,0 +1,104 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Reasons two events are considered likely duplicates
+const (
+	DuplicateReasonTitle        = "similar_title"
+	DuplicateReasonSharedSource = "shared_source_url"
+)
+
+// DuplicatePair links two events that are likely duplicates.
+type DuplicatePair struct {
+	EventA     string
+	EventB     string
+	Similarity float64 // 0-1; shared sources count as 1
+	Reason     string
+}
+
+// SimilarEvent is an event whose embedding is close to another event's.
+type SimilarEvent struct {
+	ID         string
+	Similarity float64 // Cosine similarity, 0-1 for related text
+}
+
+// DuplicateEvent is the summary of an event shown in a duplicate cluster.
+type DuplicateEvent struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Status      EventStatus `json:"status"`
+	Timestamp   time.Time   `json:"timestamp"`
+	SourceCount int         `json:"source_count"`
+}
+
+// DuplicateCluster is a group of events connected by likely-duplicate pairs.
+type DuplicateCluster struct {
+	EventIDs   []string         `json:"event_ids"`
+	Events     []DuplicateEvent `json:"events,omitempty"`
+	Similarity float64          `json:"similarity"` // Highest similarity of any pair in the cluster
+	Reasons    []string         `json:"reasons"`
+}
+
+// ClusterDuplicates groups events connected by duplicate pairs, directly or transitively.
+// Clusters are ordered largest first, then by similarity; event IDs are sorted.
+func ClusterDuplicates(pairs []DuplicatePair) []DuplicateCluster {
+	parent := make(map[string]string)
+	var find func(id string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, p := range pairs {
+		for _, id := range []string{p.EventA, p.EventB} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+			}
+		}
+		parent[find(p.EventA)] = find(p.EventB)
+	}
+
+	byRoot := make(map[string]*DuplicateCluster)
+	reasons := make(map[string]map[string]bool)
+	for id := range parent {
+		root := find(id)
+		if byRoot[root] == nil {
+			byRoot[root] = &DuplicateCluster{}
+			reasons[root] = make(map[string]bool)
+		}
+		byRoot[root].EventIDs = append(byRoot[root].EventIDs, id)
+	}
+	for _, p := range pairs {
+		root := find(p.EventA)
+		if p.Similarity > byRoot[root].Similarity {
+			byRoot[root].Similarity = p.Similarity
+		}
+		reasons[root][p.Reason] = true
+	}
+
+	clusters := make([]DuplicateCluster, 0, len(byRoot))
+	for root, c := range byRoot {
+		sort.Strings(c.EventIDs)
+		for reason := range reasons[root] {
+			c.Reasons = append(c.Reasons, reason)
+		}
+		sort.Strings(c.Reasons)
+		clusters = append(clusters, *c)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].EventIDs) != len(clusters[j].EventIDs) {
+			return len(clusters[i].EventIDs) > len(clusters[j].EventIDs)
+		}
+		if clusters[i].Similarity != clusters[j].Similarity {
+			return clusters[i].Similarity > clusters[j].Similarity
+		}
+		return clusters[i].EventIDs[0] < clusters[j].EventIDs[0]
+	})
+
+	return clusters
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClusterDuplicates(t *testing.T) {
+	pairs := []DuplicatePair{
+		{EventA: "evt-1", EventB: "evt-2", Similarity: 0.7, Reason: DuplicateReasonTitle},
+		{EventA: "evt-2", EventB: "evt-3", Similarity: 1.0, Reason: DuplicateReasonSharedSource},
+		{EventA: "evt-4", EventB: "evt-5", Similarity: 0.8, Reason: DuplicateReasonTitle},
+	}
+
+	clusters := ClusterDuplicates(pairs)
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters, want 2", len(clusters))
+	}
+
+	first := clusters[0]
+	if !reflect.DeepEqual(first.EventIDs, []string{"evt-1", "evt-2", "evt-3"}) {
+		t.Errorf("first cluster = %v, want evt-1..3 (transitive)", first.EventIDs)
+	}
+	if first.Similarity != 1.0 {
+		t.Errorf("first cluster similarity = %v, want 1.0", first.Similarity)
+	}
+	if !reflect.DeepEqual(first.Reasons, []string{DuplicateReasonSharedSource, DuplicateReasonTitle}) {
+		t.Errorf("first cluster reasons = %v", first.Reasons)
+	}
+
+	if !reflect.DeepEqual(clusters[1].EventIDs, []string{"evt-4", "evt-5"}) {
+		t.Errorf("second cluster = %v, want evt-4, evt-5", clusters[1].EventIDs)
+	}
+
+	if len(ClusterDuplicates(nil)) != 0 {
+		t.Error("expected no clusters without pairs")
+	}
+}
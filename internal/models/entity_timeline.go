@@ -0,0 +1,106 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimelineGranularity is the bucket size of an entity timeline.
+type TimelineGranularity string
+
+const (
+	TimelineGranularityHour TimelineGranularity = "hour"
+	TimelineGranularityDay  TimelineGranularity = "day"
+)
+
+// Longest time range allowed per granularity, to bound the number of buckets
+var maxTimelineRange = map[TimelineGranularity]time.Duration{
+	TimelineGranularityHour: 31 * 24 * time.Hour,
+	TimelineGranularityDay:  366 * 24 * time.Hour,
+}
+
+// Step returns the length of one bucket.
+func (g TimelineGranularity) Step() time.Duration {
+	if g == TimelineGranularityHour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// EntityTimelineQuery selects the published events mentioning an entity to bucket over time.
+type EntityTimelineQuery struct {
+	Name        string              `json:"name"` // Matched case-insensitively against name or normalized name
+	Category    *Category           `json:"category,omitempty"`
+	Granularity TimelineGranularity `json:"granularity"`
+	Since       time.Time           `json:"since"`
+	Until       time.Time           `json:"until"`
+	Weighted    bool                `json:"weighted"` // Also sum event magnitudes per bucket
+}
+
+// Validate checks the query and applies defaults: daily buckets over the last 30 days,
+// or hourly buckets over the last 48 hours.
+func (q *EntityTimelineQuery) Validate() error {
+	if q.Name == "" {
+		return fmt.Errorf("entity name is required")
+	}
+
+	if q.Granularity == "" {
+		q.Granularity = TimelineGranularityDay
+	}
+	maxRange, ok := maxTimelineRange[q.Granularity]
+	if !ok {
+		return fmt.Errorf("invalid granularity %q: must be hour or day", q.Granularity)
+	}
+
+	if q.Until.IsZero() {
+		q.Until = time.Now()
+	}
+	if q.Since.IsZero() {
+		if q.Granularity == TimelineGranularityHour {
+			q.Since = q.Until.Add(-48 * time.Hour)
+		} else {
+			q.Since = q.Until.Add(-30 * 24 * time.Hour)
+		}
+	}
+
+	if !q.Since.Before(q.Until) {
+		return fmt.Errorf("since must be before until")
+	}
+	if q.Until.Sub(q.Since) > maxRange {
+		return fmt.Errorf("time range too long for %s granularity (max %s)", q.Granularity, maxRange)
+	}
+
+	return nil
+}
+
+// EntityTimelineBucket counts the events mentioning an entity in one time bucket.
+type EntityTimelineBucket struct {
+	Time          time.Time `json:"time"` // Start of the bucket (UTC)
+	Count         int       `json:"count"`
+	WeightedCount *float64  `json:"weighted_count,omitempty"` // Sum of event magnitudes, if requested
+}
+
+// FillGaps returns one bucket per step from Since to Until, using the given buckets where
+// present and zero counts elsewhere, so charts show quiet periods.
+func (q *EntityTimelineQuery) FillGaps(buckets []EntityTimelineBucket) []EntityTimelineBucket {
+	step := q.Granularity.Step()
+	byTime := make(map[time.Time]EntityTimelineBucket, len(buckets))
+	for _, b := range buckets {
+		byTime[b.Time.UTC()] = b
+	}
+
+	var filled []EntityTimelineBucket
+	for t := q.Since.UTC().Truncate(step); t.Before(q.Until); t = t.Add(step) {
+		b, ok := byTime[t]
+		if !ok {
+			b = EntityTimelineBucket{Time: t}
+			if q.Weighted {
+				zero := 0.0
+				b.WeightedCount = &zero
+			}
+		}
+		filled = append(filled, b)
+	}
+
+	return filled
+}
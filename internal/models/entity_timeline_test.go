@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntityTimelineQuery_Validate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	q := EntityTimelineQuery{Name: "Iran", Until: now}
+	if err := q.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Granularity != TimelineGranularityDay {
+		t.Errorf("Granularity = %q, want day", q.Granularity)
+	}
+	if want := now.Add(-30 * 24 * time.Hour); !q.Since.Equal(want) {
+		t.Errorf("Since = %v, want %v", q.Since, want)
+	}
+
+	tests := []struct {
+		name  string
+		query EntityTimelineQuery
+	}{
+		{"missing name", EntityTimelineQuery{}},
+		{"bad granularity", EntityTimelineQuery{Name: "Iran", Granularity: "week"}},
+		{"since after until", EntityTimelineQuery{Name: "Iran", Since: now, Until: now.Add(-time.Hour)}},
+		{"hourly range too long", EntityTimelineQuery{Name: "Iran", Granularity: TimelineGranularityHour, Since: now.Add(-60 * 24 * time.Hour), Until: now}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query.Validate(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestEntityTimelineQuery_FillGaps(t *testing.T) {
+	since := time.Date(2025, 6, 1, 10, 30, 0, 0, time.UTC)
+	q := EntityTimelineQuery{
+		Name:        "NATO",
+		Granularity: TimelineGranularityHour,
+		Since:       since,
+		Until:       since.Add(3 * time.Hour),
+		Weighted:    true,
+	}
+
+	weighted := 7.5
+	buckets := q.FillGaps([]EntityTimelineBucket{
+		{Time: time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC), Count: 2, WeightedCount: &weighted},
+	})
+
+	if len(buckets) != 4 {
+		t.Fatalf("got %d buckets, want 4", len(buckets))
+	}
+	if !buckets[0].Time.Equal(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("first bucket = %v, want 10:00", buckets[0].Time)
+	}
+	if buckets[1].Count != 2 || *buckets[1].WeightedCount != 7.5 {
+		t.Errorf("bucket 11:00 = %+v, want count 2 weighted 7.5", buckets[1])
+	}
+	if buckets[2].Count != 0 || buckets[2].WeightedCount == nil || *buckets[2].WeightedCount != 0 {
+		t.Errorf("empty bucket = %+v, want zero counts", buckets[2])
+	}
+}
@@ -21,6 +21,33 @@ type Event struct {
 	CreatedAt  time.Time   `json:"created_at"`
 	UpdatedAt  time.Time   `json:"updated_at"`
 	Status     EventStatus `json:"status"`
+
+	// Workspace of the sources the event was created from
+	WorkspaceID string `json:"workspace_id,omitempty"`
+
+	// Model that enriched the source the event was created from; may be a fallback model
+	EnrichmentModel string `json:"enrichment_model,omitempty"`
+
+	// Enrichment prompt that created the event while a prompt A/B test is running: "a" or "b"
+	PromptVariant string `json:"prompt_variant,omitempty"`
+
+	// Computed when served, not stored: high magnitude within the breaking window
+	IsBreaking bool `json:"is_breaking"`
+
+	// Set while Status is rejected: why, and the thresholds it was judged against
+	RejectionReason     string               `json:"rejection_reason,omitempty"`
+	RejectionThresholds *RejectionThresholds `json:"rejection_thresholds,omitempty"`
+}
+
+// RejectionThresholds snapshots the publication thresholds in effect when an event was rejected.
+// Nil for manual rejections.
+type RejectionThresholds struct {
+	MinConfidence     float64 `json:"min_confidence"`
+	MinMagnitude      float64 `json:"min_magnitude"`
+	MaxSourceAgeHours int     `json:"max_source_age_hours"`
+	MinSources        int     `json:"min_sources"`
+	MinSourceTypes    int     `json:"min_source_types,omitempty"`
+	MinSourceDomains  int     `json:"min_source_domains,omitempty"`
 }
 
 // EventStatus represents the lifecycle state of an event.
@@ -50,6 +77,22 @@ const (
 	CategoryOther        Category = "other"
 )
 
+// Categories lists every known event category
+var Categories = []Category{
+	CategoryGeopolitics, CategoryMilitary, CategoryEconomic, CategoryCyber, CategoryDisaster,
+	CategoryTerrorism, CategoryDiplomacy, CategoryIntelligence, CategoryHumanitarian, CategoryOther,
+}
+
+// ValidCategory reports whether c is a known event category
+func ValidCategory(c Category) bool {
+	for _, known := range Categories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
 // Location represents geographic coordinates and place information.
 type Location struct {
 	Latitude  float64 `json:"latitude"`
@@ -65,6 +108,19 @@ type Confidence struct {
 	Level       ConfidenceLevel `json:"level"`        // Human-readable level
 	Reasoning   string          `json:"reasoning"`    // Explanation for the score
 	SourceCount int             `json:"source_count"` // Number of corroborating sources
+
+	// How each factor contributed to Score; the contributions sum to it
+	Breakdown []ConfidenceFactor `json:"breakdown,omitempty"`
+}
+
+// ConfidenceFactor is one input to a confidence score. Weighted factors have their own 0-1 score
+// and a weight; adjustments applied afterwards, such as corroboration or the insufficient-data
+// cap, only have a contribution.
+type ConfidenceFactor struct {
+	Name         string  `json:"name"`
+	Score        float64 `json:"score,omitempty"`
+	Weight       float64 `json:"weight,omitempty"` // Share of the weighted average, 0-1
+	Contribution float64 `json:"contribution"`     // Points added to the final score; negative if removed
 }
 
 // ConfidenceLevel provides human-readable confidence assessment.
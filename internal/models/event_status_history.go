@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// StatusActorSystem is the actor recorded for status changes made by the event pipeline
+const StatusActorSystem = "system"
+
+// EventStatusChange is one entry in an event's status audit trail
+type EventStatusChange struct {
+	ID        int64        `json:"id"`
+	EventID   string       `json:"event_id"`
+	OldStatus *EventStatus `json:"old_status"` // Nil when the event was first created
+	NewStatus EventStatus  `json:"new_status"`
+	Actor     string       `json:"actor"` // "system", or the user or API key that made the change
+	Reason    string       `json:"reason,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
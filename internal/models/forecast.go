@@ -1,42 +1,114 @@
 package models
 
 import (
+	"errors"
 	"time"
 )
 
+// ErrForecastRunActive is returned when creating a run for a forecast that already has one in
+// progress
+var ErrForecastRunActive = errors.New("forecast already has a run in progress")
+
+// Prediction types supported by forecasts
+const (
+	PredictionTypePercentile    = "percentile"     // Full distribution via p10-p90
+	PredictionTypePointEstimate = "point_estimate" // Single numeric value
+	PredictionTypeProbability   = "probability"    // 0-100 probability that the proposition occurs
+)
+
+// ValidPredictionType reports whether t is a supported prediction type
+func ValidPredictionType(t string) bool {
+	switch t {
+	case PredictionTypePercentile, PredictionTypePointEstimate, PredictionTypeProbability:
+		return true
+	}
+	return false
+}
+
+// Headline selection modes for forecasts
+const (
+	HeadlineSelectionRecent   = "recent"   // Newest headlines first
+	HeadlineSelectionRelevant = "relevant" // Headlines most related to the proposition
+)
+
+// ValidHeadlineSelection reports whether s is a supported headline selection mode
+func ValidHeadlineSelection(s string) bool {
+	return s == HeadlineSelectionRecent || s == HeadlineSelectionRelevant
+}
+
 // Forecast represents a value-based forecast configuration
 type Forecast struct {
-	ID               string     `json:"id"`
-	Name             string     `json:"name"`
-	Proposition      string     `json:"proposition"`           // e.g., "What will be the % change of the S&P 500 1 year from today?"
-	PredictionType   string     `json:"prediction_type"`       // "percentile" (full distribution) or "point_estimate" (single value)
-	Units            string     `json:"units"`                 // e.g., "percent_change", "dollars", "points"
-	TargetDate       *time.Time `json:"target_date,omitempty"` // When the prediction is for
-	Categories       []string   `json:"categories"`            // Categories to include in analysis
-	HeadlineCount    int        `json:"headline_count"`        // Number of headlines to use
-	Iterations       int        `json:"iterations"`            // Number of times to query each model
-	ContextURLs      []string   `json:"context_urls"`          // URLs to fetch and inject before headlines
-	Active           bool       `json:"active"`
-	Public           bool       `json:"public"`                // Whether the forecast is publicly visible on homepage
-	DisplayOrder     int        `json:"display_order"`         // Sort order for homepage display (higher = earlier)
-	ScheduleEnabled  bool       `json:"schedule_enabled"`      // Whether automatic scheduling is enabled
-	ScheduleInterval int        `json:"schedule_interval"`     // Interval in minutes (e.g., 60 for hourly, 1440 for daily)
-	LastRunAt        *time.Time `json:"last_run_at,omitempty"` // When the forecast was last executed
-	NextRunAt        *time.Time `json:"next_run_at,omitempty"` // When the forecast should run next
-	CreatedAt        time.Time  `json:"created_at"`
-	UpdatedAt        time.Time  `json:"updated_at"`
+	ID                    string     `json:"id"`
+	Name                  string     `json:"name"`
+	Proposition           string     `json:"proposition"`           // e.g., "What will be the % change of the S&P 500 1 year from today?"
+	PredictionType        string     `json:"prediction_type"`       // "percentile" (full distribution), "point_estimate" (single value) or "probability" (0-100)
+	Units                 string     `json:"units"`                 // e.g., "percent_change", "dollars", "points"
+	TargetDate            *time.Time `json:"target_date,omitempty"` // When the prediction is for
+	Categories            []string   `json:"categories"`            // Categories to include in analysis
+	HeadlineCount         int        `json:"headline_count"`        // Number of headlines to use
+	HeadlineSelection     string     `json:"headline_selection"`    // "recent" (newest first) or "relevant" (most related to the proposition)
+	IncludeSummaries      bool       `json:"include_summaries"`     // Add event summaries under headlines when the context window allows
+	ExplainResult         bool       `json:"explain_result"`        // Ask a model to explain the aggregated result of each completed run
+	Iterations            int        `json:"iterations"`            // Number of times to query each model
+	ContextURLs           []string   `json:"context_urls"`          // URLs to fetch and inject before headlines
+	Active                bool       `json:"active"`
+	Public                bool       `json:"public"`                           // Whether the forecast is publicly visible on homepage
+	DisplayOrder          int        `json:"display_order"`                    // Sort order for homepage display (higher = earlier)
+	ScheduleEnabled       bool       `json:"schedule_enabled"`                 // Whether automatic scheduling is enabled
+	ScheduleInterval      int        `json:"schedule_interval"`                // Interval in minutes (e.g., 60 for hourly, 1440 for daily)
+	LastRunAt             *time.Time `json:"last_run_at,omitempty"`            // When the forecast was last executed
+	NextRunAt             *time.Time `json:"next_run_at,omitempty"`            // When the forecast should run next
+	DisagreementThreshold *float64   `json:"disagreement_threshold,omitempty"` // Consensus std dev above which a run raises an alert (nil = disabled)
+	AlertWebhookURL       string     `json:"alert_webhook_url,omitempty"`      // Optional webhook (e.g. Slack) notified on alerts
+	TimeoutMinutes        int        `json:"timeout_minutes"`                  // Max run duration before cancellation (0 = default)
+	WorkspaceID           string     `json:"workspace_id,omitempty"`           // Headlines are drawn from this workspace's events
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+
+	// Completion webhook: a signed POST with the result of each completed run. When Above or
+	// Below is set, only runs whose headline value crosses one of them are sent.
+	CompletionWebhookURL    string   `json:"completion_webhook_url,omitempty"`
+	CompletionWebhookSecret string   `json:"completion_webhook_secret,omitempty"` // HMAC-SHA256 signing key; empty sends unsigned
+	CompletionWebhookAbove  *float64 `json:"completion_webhook_above,omitempty"`  // Notify only when the headline value >= this
+	CompletionWebhookBelow  *float64 `json:"completion_webhook_below,omitempty"`  // Notify only when the headline value <= this
+
+	// MarketSymbol names the symbol whose percent return the forecast predicts (e.g. "SPY"), so
+	// results can be compared with the return distribution priced by the symbol's options
+	MarketSymbol string `json:"market_symbol,omitempty"`
+
+	// ArchivedAt is set while the forecast is archived: hidden from lists and never run, with its
+	// history kept until it is restored or permanently deleted
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// FixedHeadlines, when set, are sent on every run instead of fetching the latest events, for
+	// reproducible re-runs and backtests against a historical headline set
+	FixedHeadlines []ForecastHeadline `json:"fixed_headlines,omitempty"`
 }
 
 // ForecastModel represents a model configuration for a forecast
 type ForecastModel struct {
 	ID         string    `json:"id"`
 	ForecastID string    `json:"forecast_id"`
-	Provider   string    `json:"provider"`   // 'anthropic' or 'openai'
+	Provider   string    `json:"provider"`   // 'anthropic', 'openai' or 'openai_compatible'
 	ModelName  string    `json:"model_name"` // e.g., 'claude-sonnet-4.5', 'gpt-4'
-	APIKey     string    `json:"api_key"`    // Should be encrypted in DB
+	APIKey     string    `json:"api_key"`    // Encrypted at rest; masked in API responses
 	Weight     float64   `json:"weight"`     // Weight for averaging
 	Active     bool      `json:"active"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	// OpenAI endpoint overrides; empty uses the public OpenAI API
+	BaseURL         string `json:"base_url,omitempty"`
+	AzureDeployment string `json:"azure_deployment,omitempty"`
+	AzureAPIVersion string `json:"azure_api_version,omitempty"`
+}
+
+// Endpoint returns where OpenAI calls for this model go.
+func (m *ForecastModel) Endpoint() OpenAIEndpoint {
+	return OpenAIEndpoint{
+		BaseURL:         m.BaseURL,
+		AzureDeployment: m.AzureDeployment,
+		AzureAPIVersion: m.AzureAPIVersion,
+	}
 }
 
 // ForecastRun represents a single execution of a forecast
@@ -55,9 +127,14 @@ type ForecastRun struct {
 type ForecastHeadline struct {
 	EventID   string    `json:"event_id"`
 	Title     string    `json:"title"`
+	Summary   string    `json:"summary,omitempty"` // Truncated event summary, only for forecasts that include summaries
 	Category  string    `json:"category"`
 	Magnitude float64   `json:"magnitude"`
 	Timestamp time.Time `json:"timestamp"`
+
+	// OutsideCategories marks an event added from outside the forecast's categories because too
+	// few recent events matched them
+	OutsideCategories bool `json:"outside_categories,omitempty"`
 }
 
 // PercentilePredictions represents a distribution via percentiles
@@ -78,6 +155,7 @@ type ForecastModelResponse struct {
 	ModelName             string                 `json:"model_name"`
 	PercentilePredictions *PercentilePredictions `json:"percentile_predictions,omitempty"` // For distribution forecasts
 	PointEstimate         *float64               `json:"point_estimate,omitempty"`         // For single-value forecasts
+	Probability           *float64               `json:"probability,omitempty"`            // For probability forecasts (0-100)
 	Reasoning             string                 `json:"reasoning,omitempty"`
 	RawResponse           map[string]interface{} `json:"raw_response,omitempty"`
 	TokensUsed            *int                   `json:"tokens_used,omitempty"`
@@ -85,6 +163,21 @@ type ForecastModelResponse struct {
 	Status                string                 `json:"status"` // 'pending', 'completed', 'failed'
 	ErrorMessage          string                 `json:"error_message,omitempty"`
 	CreatedAt             time.Time              `json:"created_at"`
+
+	// Samples are the parsed answers averaged into the prediction, stored as rows of their own
+	// when the forecaster is set to keep them
+	Samples []ForecastSample `json:"-"`
+}
+
+// ForecastSample is one parsed answer of a model in a forecast run
+type ForecastSample struct {
+	ResponseID  string                 `json:"response_id"`
+	RunID       string                 `json:"run_id"`
+	ModelID     string                 `json:"model_id"`
+	SampleIndex int                    `json:"sample_index"` // Position among the model's samples in the run, from 0
+	Percentiles *PercentilePredictions `json:"percentiles,omitempty"`
+	Value       *float64               `json:"value,omitempty"` // Point estimate or probability (0-100)
+	CreatedAt   time.Time              `json:"created_at"`
 }
 
 // ForecastResult represents the aggregated result of a forecast run
@@ -93,9 +186,16 @@ type ForecastResult struct {
 	RunID                   string                 `json:"run_id"`
 	AggregatedPercentiles   *PercentilePredictions `json:"aggregated_percentiles,omitempty"`    // Weighted avg of model percentiles
 	AggregatedPointEstimate *float64               `json:"aggregated_point_estimate,omitempty"` // Weighted avg of point estimates
+	AggregatedProbability   *float64               `json:"aggregated_probability,omitempty"`    // Weighted avg of probabilities (0-100)
 	ModelCount              int                    `json:"model_count"`
 	ConsensusLevel          *float64               `json:"consensus_level,omitempty"` // Standard deviation across models
+	Explanation             string                 `json:"explanation,omitempty"`     // Model-written rationale for the aggregate, when the forecast asks for one
 	CreatedAt               time.Time              `json:"created_at"`
+
+	// HeadlinesWidened is set when the run took headlines from outside the forecast's categories
+	// because too few matched; SparseHeadlines when it still had fewer than the minimum
+	HeadlinesWidened bool `json:"headlines_widened,omitempty"`
+	SparseHeadlines  bool `json:"sparse_headlines,omitempty"`
 }
 
 // ForecastRunDetail combines run info with responses and result
@@ -103,20 +203,106 @@ type ForecastRunDetail struct {
 	Run       ForecastRun             `json:"run"`
 	Responses []ForecastModelResponse `json:"responses"`
 	Result    *ForecastResult         `json:"result,omitempty"`
+
+	// ModelEstimates are the completed responses without provider or model details, for
+	// public responses that show the spread of the ensemble
+	ModelEstimates []AnonymousModelEstimate `json:"model_estimates,omitempty"`
+}
+
+// ForecastConsistencyReport describes forecast rows left inconsistent by partial failures or
+// manual deletes: what a repair fixed, or on a dry run what it would fix
+type ForecastConsistencyReport struct {
+	DryRun                     bool      `json:"dry_run"`
+	OrphanedRuns               int64     `json:"orphaned_runs"`                 // Runs whose forecast no longer exists
+	OrphanedModelResponses     int64     `json:"orphaned_model_responses"`      // Responses whose run or forecast no longer exists
+	OrphanedResults            int64     `json:"orphaned_results"`              // Results whose run or forecast no longer exists
+	OrphanedModels             int64     `json:"orphaned_models"`               // Model configs whose forecast no longer exists
+	OrphanedSamples            int64     `json:"orphaned_samples"`              // Samples whose model response no longer exists
+	CompletedRunsWithoutResult int64     `json:"completed_runs_without_result"` // Marked failed on repair
+	CheckedAt                  time.Time `json:"checked_at"`
+
+	// Repaired lists each row a repair deleted or updated
+	Repaired []ForecastConsistencyRepair `json:"repaired,omitempty"`
+}
+
+// ForecastConsistencyRepair identifies one row changed by a forecast consistency repair
+type ForecastConsistencyRepair struct {
+	Table       string `json:"table"`
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id,omitempty"` // Empty for orphans, whose forecast is gone
+}
+
+// AnonymousModelEstimate is one model's prediction labeled "Model A", "Model B", ... so the
+// public API can show how models disagreed without revealing which provider or model it was
+type AnonymousModelEstimate struct {
+	Label                 string                 `json:"label"`
+	PercentilePredictions *PercentilePredictions `json:"percentile_predictions,omitempty"`
+	PointEstimate         *float64               `json:"point_estimate,omitempty"`
+	Probability           *float64               `json:"probability,omitempty"`
+}
+
+// ForecastHistoryPoint is one completed run in a forecast history export. Only the values for
+// the forecast's prediction type are set.
+type ForecastHistoryPoint struct {
+	RunID          string     `json:"run_id"`
+	RunAt          time.Time  `json:"run_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	HeadlineCount  int        `json:"headline_count"` // Headlines in the run's snapshot
+	P10            *float64   `json:"p10,omitempty"`
+	P25            *float64   `json:"p25,omitempty"`
+	P50            *float64   `json:"p50,omitempty"`
+	P75            *float64   `json:"p75,omitempty"`
+	P90            *float64   `json:"p90,omitempty"`
+	PointEstimate  *float64   `json:"point_estimate,omitempty"`
+	Probability    *float64   `json:"probability,omitempty"`
+	ModelCount     int        `json:"model_count"`
+	ConsensusLevel *float64   `json:"consensus_level,omitempty"`
+}
+
+// ForecastRunComparison compares two runs of the same forecast. Deltas are run B minus run A and
+// are nil when either run lacks the value.
+type ForecastRunComparison struct {
+	ForecastID         string             `json:"forecast_id"`
+	RunA               ForecastRunDetail  `json:"run_a"`
+	RunB               ForecastRunDetail  `json:"run_b"`
+	P50Delta           *float64           `json:"p50_delta,omitempty"`
+	PointEstimateDelta *float64           `json:"point_estimate_delta,omitempty"`
+	ProbabilityDelta   *float64           `json:"probability_delta,omitempty"`
+	ConsensusDelta     *float64           `json:"consensus_delta,omitempty"`
+	HeadlinesAdded     []ForecastHeadline `json:"headlines_added"`   // In run B's snapshot but not run A's
+	HeadlinesRemoved   []ForecastHeadline `json:"headlines_removed"` // In run A's snapshot but not run B's
 }
 
 // CreateForecastRequest represents the request to create a new value-based forecast
 type CreateForecastRequest struct {
-	Name           string          `json:"name"`
-	Proposition    string          `json:"proposition"`     // e.g., "What will be the % change of the S&P 500 1 year from today?"
-	PredictionType string          `json:"prediction_type"` // "percentile" or "point_estimate"
-	Units          string          `json:"units"`           // e.g., "percent_change", "dollars"
-	TargetDate     *time.Time      `json:"target_date,omitempty"`
-	Categories     []string        `json:"categories"`
-	HeadlineCount  int             `json:"headline_count"`
-	Iterations     int             `json:"iterations"`
-	ContextURLs    []string        `json:"context_urls"`
-	Models         []ForecastModel `json:"models"`
+	Name              string          `json:"name"`
+	Proposition       string          `json:"proposition"`     // e.g., "What will be the % change of the S&P 500 1 year from today?"
+	PredictionType    string          `json:"prediction_type"` // "percentile", "point_estimate" or "probability"
+	Units             string          `json:"units"`           // e.g., "percent_change", "dollars"
+	TargetDate        *time.Time      `json:"target_date,omitempty"`
+	Categories        []string        `json:"categories"`
+	HeadlineCount     int             `json:"headline_count"`
+	HeadlineSelection string          `json:"headline_selection,omitempty"` // "recent" (default) or "relevant"
+	IncludeSummaries  bool            `json:"include_summaries"`            // Add event summaries to the prompt (default titles only)
+	ExplainResult     bool            `json:"explain_result"`               // Explain the aggregated result of each completed run
+	Iterations        int             `json:"iterations"`
+	ContextURLs       []string        `json:"context_urls"`
+	Models            []ForecastModel `json:"models"`
+
+	DisagreementThreshold *float64 `json:"disagreement_threshold,omitempty"` // Alert when consensus std dev exceeds this
+	AlertWebhookURL       string   `json:"alert_webhook_url,omitempty"`
+	TimeoutMinutes        int      `json:"timeout_minutes"` // Max run duration before cancellation (0 = default)
+
+	CompletionWebhookURL    string   `json:"completion_webhook_url,omitempty"`
+	CompletionWebhookSecret string   `json:"completion_webhook_secret,omitempty"`
+	CompletionWebhookAbove  *float64 `json:"completion_webhook_above,omitempty"`
+	CompletionWebhookBelow  *float64 `json:"completion_webhook_below,omitempty"`
+
+	MarketSymbol string `json:"market_symbol,omitempty"` // e.g. "SPY" when the proposition is SPY's percent change
+
+	FixedHeadlines []ForecastHeadline `json:"fixed_headlines,omitempty"` // Pin every run to these headlines (empty = fetch the latest)
+
+	DuplicateOf string `json:"duplicate_of,omitempty"` // On create, the forecast whose models' masked API keys to reuse
 }
 
 // ExecuteForecastRequest represents the request to run a forecast
@@ -29,6 +29,36 @@ type InferenceLogStats struct {
 	AvgLatencyMs    float64 `json:"avg_latency_ms"`
 }
 
+// InferenceUsageBreakdown aggregates inference calls for one provider, model and operation
+type InferenceUsageBreakdown struct {
+	Provider     string  `json:"provider"`
+	Model        string  `json:"model"`
+	Operation    string  `json:"operation"`
+	CallCount    int     `json:"call_count"`
+	TotalTokens  int64   `json:"total_tokens"`
+	TotalCostUSD float64 `json:"total_cost_usd"` // Estimated
+	ErrorCount   int     `json:"error_count"`
+	ErrorRate    float64 `json:"error_rate"` // Fraction of calls that failed (0-1)
+	P50LatencyMs float64 `json:"p50_latency_ms"`
+	P95LatencyMs float64 `json:"p95_latency_ms"`
+}
+
+// InferenceBudgetPeriod reports token usage against the budget for one period
+type InferenceBudgetPeriod struct {
+	Budget    int64     `json:"budget"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	Exceeded  bool      `json:"exceeded"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// InferenceBudgetStatus reports token usage against the configured budgets
+type InferenceBudgetStatus struct {
+	Enabled bool                   `json:"enabled"`
+	Daily   *InferenceBudgetPeriod `json:"daily,omitempty"`   // Nil when no daily budget is set
+	Monthly *InferenceBudgetPeriod `json:"monthly,omitempty"` // Nil when no monthly budget is set
+}
+
 // InferenceLogQuery represents query parameters for filtering logs
 type InferenceLogQuery struct {
 	Provider  string
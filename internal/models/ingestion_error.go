@@ -6,26 +6,82 @@ import (
 
 // IngestionError represents an error that occurred during data ingestion.
 type IngestionError struct {
-	ID         string     `json:"id"`
-	Platform   string     `json:"platform"`   // e.g., "rss", "twitter", "telegram"
-	ErrorType  string     `json:"error_type"` // e.g., "scrape_failed", "feed_fetch_failed"
-	URL        string     `json:"url"`        // The URL that failed
-	ErrorMsg   string     `json:"error_msg"`  // Error message
-	Metadata   string     `json:"metadata"`   // Additional JSON metadata
-	CreatedAt  time.Time  `json:"created_at"`
-	Resolved   bool       `json:"resolved"`
-	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	ID         string             `json:"id"`
+	Platform   string             `json:"platform"`   // e.g., "rss", "twitter", "telegram"
+	ErrorType  IngestionErrorType `json:"error_type"` // What failed, e.g. "rss_fetch_failed"
+	Category   ErrorCategory      `json:"category"`   // Why it failed, e.g. "timeout"
+	URL        string             `json:"url"`        // The URL that failed
+	ErrorMsg   string             `json:"error_msg"`  // Error message
+	Metadata   string             `json:"metadata"`   // Additional JSON metadata
+	CreatedAt  time.Time          `json:"created_at"`
+	Resolved   bool               `json:"resolved"`
+	ResolvedAt *time.Time         `json:"resolved_at,omitempty"`
+
+	// ResolutionNote explains an automatic resolution; empty when resolved by hand.
+	ResolutionNote string `json:"resolution_note,omitempty"`
 }
 
 // IngestionErrorType categorizes different types of ingestion errors.
 type IngestionErrorType string
 
 const (
-	ErrorTypeRSSFetchFailed    IngestionErrorType = "rss_fetch_failed"
-	ErrorTypeScrapeFailed      IngestionErrorType = "scrape_failed"
-	ErrorTypeParsingFailed     IngestionErrorType = "parsing_failed"
-	ErrorTypeConnectionFailed  IngestionErrorType = "connection_failed"
-	ErrorTypeAuthFailed        IngestionErrorType = "auth_failed"
-	ErrorTypeRateLimitExceeded IngestionErrorType = "rate_limit_exceeded"
-	ErrorTypeEnrichmentFailed  IngestionErrorType = "enrichment_failed"
+	ErrorTypeRSSFetchFailed     IngestionErrorType = "rss_fetch_failed"
+	ErrorTypeTwitterFetchFailed IngestionErrorType = "twitter_fetch_failed"
+	ErrorTypeScrapeFailed       IngestionErrorType = "scrape_failed"
+	ErrorTypeParsingFailed      IngestionErrorType = "parsing_failed"
+	ErrorTypeConnectionFailed   IngestionErrorType = "connection_failed"
+	ErrorTypeAuthFailed         IngestionErrorType = "auth_failed"
+	ErrorTypeRateLimitExceeded  IngestionErrorType = "rate_limit_exceeded"
+	ErrorTypeEnrichmentFailed   IngestionErrorType = "enrichment_failed"
 )
+
+// ErrorCategory is the failure mode behind an ingestion error, classified from the Go error.
+type ErrorCategory string
+
+const (
+	ErrorCategoryTimeout    ErrorCategory = "timeout"    // Deadline or network timeout
+	ErrorCategoryDNS        ErrorCategory = "dns"        // Host name didn't resolve
+	ErrorCategoryConnection ErrorCategory = "connection" // Refused, reset or otherwise failed connections
+	ErrorCategoryAuth       ErrorCategory = "auth"       // HTTP 401 or 403
+	ErrorCategoryRateLimit  ErrorCategory = "rate_limit" // HTTP 429 or a known rate limit
+	ErrorCategoryHTTP4xx    ErrorCategory = "http_4xx"   // Other client error responses
+	ErrorCategoryHTTP5xx    ErrorCategory = "http_5xx"   // Server error responses
+	ErrorCategoryParse      ErrorCategory = "parse"      // Malformed XML or JSON
+	ErrorCategoryUnknown    ErrorCategory = "unknown"
+)
+
+// TransientErrorCategories are failure modes that can clear up without intervention. Open
+// errors in these categories are resolved automatically once the same target fetches
+// successfully; auth, 4xx, parse and unknown failures wait for an operator.
+var TransientErrorCategories = []ErrorCategory{
+	ErrorCategoryTimeout,
+	ErrorCategoryDNS,
+	ErrorCategoryConnection,
+	ErrorCategoryRateLimit,
+	ErrorCategoryHTTP5xx,
+}
+
+// IsTransient reports whether the category is one of TransientErrorCategories.
+func (c ErrorCategory) IsTransient() bool {
+	for _, t := range TransientErrorCategories {
+		if c == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IngestionErrorFilter narrows an ingestion error listing.
+type IngestionErrorFilter struct {
+	Limit          int
+	UnresolvedOnly bool
+	Category       ErrorCategory // Empty for every category
+	Platform       string        // Empty for every platform
+}
+
+// IngestionErrorStat counts ingestion errors for one category on one platform.
+type IngestionErrorStat struct {
+	Category ErrorCategory `json:"category"`
+	Platform string        `json:"platform"`
+	Count    int           `json:"count"`
+}
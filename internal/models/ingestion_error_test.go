@@ -0,0 +1,19 @@
+package models
+
+import "testing"
+
+func TestErrorCategory_IsTransient(t *testing.T) {
+	transient := []ErrorCategory{ErrorCategoryTimeout, ErrorCategoryDNS, ErrorCategoryConnection, ErrorCategoryRateLimit, ErrorCategoryHTTP5xx}
+	for _, c := range transient {
+		if !c.IsTransient() {
+			t.Errorf("expected %q to be transient", c)
+		}
+	}
+
+	permanent := []ErrorCategory{ErrorCategoryAuth, ErrorCategoryHTTP4xx, ErrorCategoryParse, ErrorCategoryUnknown, ""}
+	for _, c := range permanent {
+		if c.IsTransient() {
+			t.Errorf("expected %q not to be transient", c)
+		}
+	}
+}
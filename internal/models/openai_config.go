@@ -2,33 +2,85 @@ package models
 
 import "time"
 
+// Providers reached through the OpenAI client
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai_compatible" // Local or self-hosted servers such as Ollama or LM Studio
+)
+
 // OpenAIConfig represents the configuration for OpenAI API integration.
 type OpenAIConfig struct {
-	ID                      int       `json:"id"`
-	APIKey                  string    `json:"api_key"`
-	Model                   string    `json:"model"`
-	Temperature             float32   `json:"temperature"`
-	MaxTokens               int       `json:"max_tokens"`
-	TimeoutSeconds          int       `json:"timeout_seconds"`
-	SystemPrompt            string    `json:"system_prompt"`
-	AnalysisTemplate        string    `json:"analysis_template"`
-	EntityExtractionPrompt  string    `json:"entity_extraction_prompt"`
-	CorrelationSystemPrompt string    `json:"correlation_system_prompt"`
-	Enabled                 bool      `json:"enabled"`
-	UpdatedAt               time.Time `json:"updated_at"`
-	CreatedAt               time.Time `json:"created_at"`
+	ID                      int             `json:"id"`
+	APIKey                  string          `json:"api_key"`
+	Model                   string          `json:"model"`
+	FallbackModels          []string        `json:"fallback_models"` // Tried in order when Model fails or is over quota
+	Temperature             float32         `json:"temperature"`
+	MaxTokens               int             `json:"max_tokens"`
+	TimeoutSeconds          int             `json:"timeout_seconds"`
+	SystemPrompt            string          `json:"system_prompt"`
+	AnalysisTemplate        string          `json:"analysis_template"`
+	EntityExtractionPrompt  string          `json:"entity_extraction_prompt"`
+	CorrelationSystemPrompt string          `json:"correlation_system_prompt"`
+	CorrelationTemplate     string          `json:"correlation_template"` // Empty uses the built-in default
+	CredibilitySystemPrompt string          `json:"credibility_system_prompt"`
+	CredibilityTemplate     string          `json:"credibility_template"` // Empty uses the built-in default
+	Provider                string          `json:"provider"`             // ProviderOpenAI or ProviderOpenAICompatible
+	BaseURL                 string          `json:"base_url"`             // Empty uses the public OpenAI API
+	AzureDeployment         string          `json:"azure_deployment"`     // Set to use Azure OpenAI
+	AzureAPIVersion         string          `json:"azure_api_version"`
+	CategoryMapping         CategoryMapping `json:"category_mapping"`           // Raw model categories folded into event categories
+	PromptBSystemPrompt     string          `json:"prompt_b_system_prompt"`     // A/B test system prompt; empty uses SystemPrompt
+	PromptBAnalysisTemplate string          `json:"prompt_b_analysis_template"` // A/B test analysis template; empty uses AnalysisTemplate
+	PromptBFraction         float64         `json:"prompt_b_fraction"`          // Share of sources enriched with prompt B; 0 turns the test off
+	Enabled                 bool            `json:"enabled"`
+	UpdatedAt               time.Time       `json:"updated_at"`
+	CreatedAt               time.Time       `json:"created_at"`
 }
 
 // OpenAIConfigUpdate represents fields that can be updated.
 type OpenAIConfigUpdate struct {
-	APIKey                  *string  `json:"api_key,omitempty"`
-	Model                   *string  `json:"model,omitempty"`
-	Temperature             *float32 `json:"temperature,omitempty"`
-	MaxTokens               *int     `json:"max_tokens,omitempty"`
-	TimeoutSeconds          *int     `json:"timeout_seconds,omitempty"`
-	SystemPrompt            *string  `json:"system_prompt,omitempty"`
-	AnalysisTemplate        *string  `json:"analysis_template,omitempty"`
-	EntityExtractionPrompt  *string  `json:"entity_extraction_prompt,omitempty"`
-	CorrelationSystemPrompt *string  `json:"correlation_system_prompt,omitempty"`
-	Enabled                 *bool    `json:"enabled,omitempty"`
+	APIKey                  *string         `json:"api_key,omitempty"`
+	Model                   *string         `json:"model,omitempty"`
+	FallbackModels          []string        `json:"fallback_models,omitempty"` // Replaces the list; [] clears it
+	Temperature             *float32        `json:"temperature,omitempty"`
+	MaxTokens               *int            `json:"max_tokens,omitempty"`
+	TimeoutSeconds          *int            `json:"timeout_seconds,omitempty"`
+	SystemPrompt            *string         `json:"system_prompt,omitempty"`
+	AnalysisTemplate        *string         `json:"analysis_template,omitempty"`
+	EntityExtractionPrompt  *string         `json:"entity_extraction_prompt,omitempty"`
+	CorrelationSystemPrompt *string         `json:"correlation_system_prompt,omitempty"`
+	CorrelationTemplate     *string         `json:"correlation_template,omitempty"`
+	CredibilitySystemPrompt *string         `json:"credibility_system_prompt,omitempty"`
+	CredibilityTemplate     *string         `json:"credibility_template,omitempty"`
+	Provider                *string         `json:"provider,omitempty"`
+	BaseURL                 *string         `json:"base_url,omitempty"`
+	AzureDeployment         *string         `json:"azure_deployment,omitempty"`
+	AzureAPIVersion         *string         `json:"azure_api_version,omitempty"`
+	CategoryMapping         CategoryMapping `json:"category_mapping,omitempty"` // Replaces the mapping; {} clears it
+	PromptBSystemPrompt     *string         `json:"prompt_b_system_prompt,omitempty"`
+	PromptBAnalysisTemplate *string         `json:"prompt_b_analysis_template,omitempty"`
+	PromptBFraction         *float64        `json:"prompt_b_fraction,omitempty"`
+	Enabled                 *bool           `json:"enabled,omitempty"`
+}
+
+// Endpoint returns where this configuration sends OpenAI calls.
+func (c *OpenAIConfig) Endpoint() OpenAIEndpoint {
+	return OpenAIEndpoint{
+		BaseURL:         c.BaseURL,
+		AzureDeployment: c.AzureDeployment,
+		AzureAPIVersion: c.AzureAPIVersion,
+	}
+}
+
+// OpenAIEndpoint selects the API that OpenAI-compatible calls go to. The zero value is the public
+// OpenAI API.
+type OpenAIEndpoint struct {
+	BaseURL         string // API base URL, or the Azure resource endpoint
+	AzureDeployment string // Azure deployment name; set to use Azure OpenAI
+	AzureAPIVersion string // Azure api-version; empty uses the client default
+}
+
+// IsAzure reports whether the endpoint is an Azure OpenAI deployment.
+func (e OpenAIEndpoint) IsAzure() bool {
+	return e.AzureDeployment != ""
 }
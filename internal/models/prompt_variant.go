@@ -0,0 +1,54 @@
+package models
+
+import "hash/fnv"
+
+// Enrichment prompt variants in an A/B test
+const (
+	PromptVariantA = "a" // The configured prompts
+	PromptVariantB = "b" // The prompt_b_* prompts
+)
+
+// PromptVariantFor picks the prompt variant for a source: B for about fraction of sources, A for
+// the rest. The choice is a hash of the source ID, so a source re-enriched during the same test
+// gets the same prompt. Empty when fraction <= 0, i.e. no test is running.
+func PromptVariantFor(sourceID string, fraction float64) string {
+	if fraction <= 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(sourceID))
+	if float64(h.Sum32()%10000) < fraction*10000 {
+		return PromptVariantB
+	}
+	return PromptVariantA
+}
+
+// PromptVariantStats aggregates quality proxies for the events one prompt variant created
+type PromptVariantStats struct {
+	Variant       string  `json:"variant"`
+	Events        int     `json:"events"`
+	Published     int     `json:"published"`
+	Rejected      int     `json:"rejected"`
+	AvgConfidence float64 `json:"avg_confidence"`
+	AvgMagnitude  float64 `json:"avg_magnitude"`
+	RejectionRate float64 `json:"rejection_rate"` // Rejected share of Events
+}
+
+// BuildPromptVariantStats returns stats for both variants in order, filling in a variant with no
+// events, and works out rejection rates
+func BuildPromptVariantStats(stats []PromptVariantStats) []PromptVariantStats {
+	result := []PromptVariantStats{{Variant: PromptVariantA}, {Variant: PromptVariantB}}
+	for _, s := range stats {
+		for i := range result {
+			if result[i].Variant == s.Variant {
+				result[i] = s
+			}
+		}
+	}
+	for i := range result {
+		if result[i].Events > 0 {
+			result[i].RejectionRate = float64(result[i].Rejected) / float64(result[i].Events)
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPromptVariantFor(t *testing.T) {
+	if got := PromptVariantFor("src-1", 0); got != "" {
+		t.Errorf("expected no variant while the test is off, got %q", got)
+	}
+	if got := PromptVariantFor("src-1", 1); got != PromptVariantB {
+		t.Errorf("expected every source on B at fraction 1, got %q", got)
+	}
+
+	first := PromptVariantFor("src-1", 0.5)
+	for i := 0; i < 5; i++ {
+		if got := PromptVariantFor("src-1", 0.5); got != first {
+			t.Fatalf("variant changed between calls: %q then %q", first, got)
+		}
+	}
+
+	b := 0
+	for i := 0; i < 10000; i++ {
+		if PromptVariantFor(fmt.Sprintf("source-%d", i), 0.2) == PromptVariantB {
+			b++
+		}
+	}
+	if b < 1800 || b > 2200 {
+		t.Errorf("expected about 2000 of 10000 sources on B at fraction 0.2, got %d", b)
+	}
+}
+
+func TestBuildPromptVariantStats(t *testing.T) {
+	stats := BuildPromptVariantStats([]PromptVariantStats{
+		{Variant: PromptVariantB, Events: 8, Published: 6, Rejected: 2, AvgConfidence: 0.7, AvgMagnitude: 5},
+	})
+
+	if len(stats) != 2 || stats[0].Variant != PromptVariantA || stats[1].Variant != PromptVariantB {
+		t.Fatalf("expected variants a and b in order, got %+v", stats)
+	}
+	if stats[0].Events != 0 || stats[0].RejectionRate != 0 {
+		t.Errorf("expected empty stats for variant a, got %+v", stats[0])
+	}
+	if stats[1].RejectionRate != 0.25 {
+		t.Errorf("rejection rate = %v, want 0.25", stats[1].RejectionRate)
+	}
+}
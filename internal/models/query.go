@@ -1,9 +1,19 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+// ErrUnknownCursor is returned when a query's after_id or before_id names no event
+var ErrUnknownCursor = errors.New("unknown cursor event")
+
+// ErrSemanticSearchUnavailable is returned for semantic queries when there is no embedder, or the
+// database has no pgvector extension or events.embedding column.
+var ErrSemanticSearchUnavailable = errors.New("semantic search is not available")
+
 // EventQuery represents filters and pagination for retrieving events via the MCP API.
 type EventQuery struct {
 	// Search and time filters
@@ -13,6 +23,7 @@ type EventQuery struct {
 	SinceTimestamp *time.Time `json:"since_timestamp,omitempty"` // Alias for MCP compatibility
 	Until          *time.Time `json:"until,omitempty"`
 	UntilTimestamp *time.Time `json:"until_timestamp,omitempty"` // Alias for MCP compatibility
+	Window         string     `json:"window,omitempty"`          // Relative time range such as "24h" or "7d"; ignored when a since bound is set
 
 	// Magnitude filters
 	MinMagnitude *float64 `json:"min_magnitude,omitempty"`
@@ -29,11 +40,32 @@ type EventQuery struct {
 	EntityTypes []EntityType `json:"entity_types,omitempty"`
 	Status      *EventStatus `json:"status,omitempty"`
 
+	// Breaking filter: only events above the breaking magnitude within the breaking window
+	Breaking bool `json:"breaking,omitempty"`
+
+	// Semantic search: rank events by embedding similarity to SemanticQuery instead of sort_by.
+	// The event manager embeds the text into Embedding; the other filters still apply.
+	SemanticQuery string    `json:"semantic_query,omitempty"`
+	Embedding     []float32 `json:"-"`
+	MinSimilarity float64   `json:"-"` // Events less similar than this are left out
+
+	// Workspace whose events are queried; set by the server, never by clients. Empty means the
+	// workspace the query's context is scoped to, or every workspace for background jobs.
+	WorkspaceID string `json:"-"`
+
 	// Pagination
 	Page   int `json:"page"`
 	Limit  int `json:"limit,omitempty"`
 	Offset int `json:"offset,omitempty"`
 
+	// Cursor pagination: events after or before the event with this ID in the order events were
+	// stored or last changed status, which stays consistent as new events arrive even when they
+	// carry older timestamps, and puts an event published after the cursor behind it even if it
+	// was stored earlier. AfterID returns the nearest events first (ascending), BeforeID likewise
+	// descending; page, offset and sort_order are ignored, and sort_by must be empty or created_at.
+	AfterID  string `json:"after_id,omitempty"`
+	BeforeID string `json:"before_id,omitempty"`
+
 	// Sorting
 	SortBy    EventSortField `json:"sort_by,omitempty"`
 	SortOrder SortOrder      `json:"sort_order,omitempty"`
@@ -76,6 +108,15 @@ func (q *EventQuery) Validate() error {
 		q.Limit = 1000
 	}
 
+	// Cursor pages follow arrival order; sorting by event time would put events stored after the
+	// cursor with older timestamps behind it, where a poller never sees them
+	if q.UsesCursor() {
+		if q.SortBy != "" && q.SortBy != SortByCreatedAt {
+			return fmt.Errorf("after_id and before_id page in arrival order and can't be combined with sort_by=%s", q.SortBy)
+		}
+		q.SortBy = SortByCreatedAt
+	}
+
 	// Set defaults for sorting
 	if q.SortBy == "" {
 		q.SortBy = SortByTimestamp
@@ -83,6 +124,26 @@ func (q *EventQuery) Validate() error {
 	if q.SortOrder == "" {
 		q.SortOrder = SortOrderDesc
 	}
+	q.SortOrder = SortOrder(strings.ToLower(string(q.SortOrder)))
+	if !validSortField(q.SortBy) {
+		return fmt.Errorf("invalid sort_by %q: must be one of %s", q.SortBy, joinValues(sortFields))
+	}
+	if q.SortOrder != SortOrderAsc && q.SortOrder != SortOrderDesc {
+		return fmt.Errorf("invalid sort_order %q: must be asc or desc", q.SortOrder)
+	}
+
+	// Cursor pages walk away from the cursor event, so the direction follows the cursor
+	if q.UsesCursor() {
+		if q.SemanticQuery != "" {
+			return errors.New("after_id and before_id can't be combined with semantic_query")
+		}
+		q.Page = 1
+		q.Offset = 0
+		q.SortOrder = SortOrderDesc
+		if q.AfterID != "" {
+			q.SortOrder = SortOrderAsc
+		}
+	}
 
 	// Sync aliases for MCP compatibility
 	if q.Search != nil && q.SearchQuery == "" {
@@ -94,10 +155,80 @@ func (q *EventQuery) Validate() error {
 	if q.Until != nil && q.UntilTimestamp == nil {
 		q.UntilTimestamp = q.Until
 	}
+	q.SemanticQuery = strings.TrimSpace(q.SemanticQuery)
+
+	// An absolute since bound takes precedence over a relative window
+	if q.Window != "" {
+		window, err := ParseWindow(q.Window)
+		if err != nil {
+			return fmt.Errorf("invalid window %q: %w", q.Window, err)
+		}
+		if q.SinceTimestamp == nil {
+			since := time.Now().Add(-window)
+			q.Since = &since
+			q.SinceTimestamp = &since
+		}
+	}
+
+	for _, category := range q.Categories {
+		if !ValidCategory(category) {
+			return fmt.Errorf("invalid category %q: must be one of %s", category, joinValues(Categories))
+		}
+	}
+	for _, sourceType := range q.SourceTypes {
+		if !ValidSourceType(sourceType) {
+			return fmt.Errorf("invalid source type %q: must be one of %s", sourceType, joinValues(SourceTypes))
+		}
+	}
 
 	return nil
 }
 
+// sortFields are the columns events can be sorted by
+var sortFields = []EventSortField{SortByTimestamp, SortByMagnitude, SortByConfidence, SortByCreatedAt, SortByUpdatedAt}
+
+func validSortField(field EventSortField) bool {
+	for _, valid := range sortFields {
+		if field == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesCursor reports whether the query pages by after_id/before_id instead of page and offset
+func (q *EventQuery) UsesCursor() bool {
+	return q.AfterID != "" || q.BeforeID != ""
+}
+
+// joinValues lists enum values for error messages
+func joinValues[T ~string](values []T) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = string(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplyBreaking narrows the query to breaking events by tightening its magnitude and time filters
+// to the breaking criteria. Call it after Validate.
+func (q *EventQuery) ApplyBreaking(thresholds *ThresholdConfig, now time.Time) {
+	if !q.Breaking {
+		return
+	}
+
+	minMagnitude, window := thresholds.BreakingCriteria()
+	if q.MinMagnitude == nil || *q.MinMagnitude < minMagnitude {
+		q.MinMagnitude = &minMagnitude
+	}
+
+	since := now.Add(-window)
+	if q.SinceTimestamp == nil || q.SinceTimestamp.Before(since) {
+		q.Since = &since
+		q.SinceTimestamp = &since
+	}
+}
+
 // GetOffset calculates the database offset for pagination.
 func (q *EventQuery) GetOffset() int {
 	if q.Offset > 0 {
@@ -117,4 +248,23 @@ type EventResponse struct {
 	Total   int     `json:"total"`
 	HasMore bool    `json:"has_more"`
 	Query   string  `json:"query,omitempty"`
+
+	// NextCursor continues a cursor query: pass it as the same after_id/before_id parameter to
+	// get the following page. It stays on the request's cursor when the page is empty.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SetNextCursor fills in NextCursor for a cursor query from the page's events
+func (r *EventResponse) SetNextCursor(q EventQuery) {
+	if !q.UsesCursor() {
+		return
+	}
+	switch {
+	case len(r.Events) > 0:
+		r.NextCursor = r.Events[len(r.Events)-1].ID
+	case q.AfterID != "":
+		r.NextCursor = q.AfterID
+	default:
+		r.NextCursor = q.BeforeID
+	}
 }
@@ -99,6 +99,103 @@ func TestEventQuery_Validate(t *testing.T) {
 	}
 }
 
+func TestEventQuery_ValidateEnums(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   EventQuery
+		wantErr string
+	}{
+		{
+			name:  "known values",
+			query: EventQuery{Categories: []Category{CategoryMilitary}, SourceTypes: []SourceType{SourceTypeNewsMedia}},
+		},
+		{
+			name:    "misspelled category",
+			query:   EventQuery{Categories: []Category{CategoryCyber, "millitary"}},
+			wantErr: `invalid category "millitary": must be one of geopolitics, military, economic, cyber, disaster, terrorism, diplomacy, intelligence, humanitarian, other`,
+		},
+		{
+			name:    "unknown source type",
+			query:   EventQuery{SourceTypes: []SourceType{"reddit"}},
+			wantErr: `invalid source type "reddit": must be one of twitter, telegram, glp, government, news_media, blog, other`,
+		},
+		{
+			name:    "unknown sort field",
+			query:   EventQuery{SortBy: "title; DROP TABLE events"},
+			wantErr: `invalid sort_by "title; DROP TABLE events": must be one of timestamp, magnitude, confidence, created_at, updated_at`,
+		},
+		{
+			name:    "unknown sort order",
+			query:   EventQuery{SortOrder: "sideways"},
+			wantErr: `invalid sort_order "sideways": must be asc or desc`,
+		},
+		{
+			name:    "cursor with semantic search",
+			query:   EventQuery{AfterID: "evt-1", SemanticQuery: "port strikes"},
+			wantErr: "after_id and before_id can't be combined with semantic_query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventQuery_Cursor(t *testing.T) {
+	q := EventQuery{AfterID: "evt-1", Page: 3, Offset: 40, SortOrder: SortOrderDesc}
+	if err := q.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if q.SortOrder != SortOrderAsc || q.GetOffset() != 0 || q.Page != 1 {
+		t.Errorf("after_id query = order %s, offset %d, page %d; want asc from the start", q.SortOrder, q.GetOffset(), q.Page)
+	}
+
+	if q.SortBy != SortByCreatedAt {
+		t.Errorf("after_id query sort_by = %s, want %s", q.SortBy, SortByCreatedAt)
+	}
+
+	q = EventQuery{BeforeID: "evt-1", SortOrder: "ASC"}
+	if err := q.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if q.SortOrder != SortOrderDesc {
+		t.Errorf("before_id query sort order = %s, want desc", q.SortOrder)
+	}
+
+	q = EventQuery{AfterID: "evt-1", SortBy: SortByTimestamp}
+	if err := q.Validate(); err == nil {
+		t.Error("Validate() accepted after_id with sort_by=timestamp")
+	}
+
+	// The next cursor is the last event, or the request's cursor when nothing is newer
+	resp := EventResponse{Events: []Event{{ID: "evt-2"}, {ID: "evt-3"}}}
+	resp.SetNextCursor(EventQuery{AfterID: "evt-1"})
+	if resp.NextCursor != "evt-3" {
+		t.Errorf("NextCursor = %q, want evt-3", resp.NextCursor)
+	}
+	resp = EventResponse{}
+	resp.SetNextCursor(EventQuery{AfterID: "evt-3"})
+	if resp.NextCursor != "evt-3" {
+		t.Errorf("NextCursor for empty page = %q, want evt-3", resp.NextCursor)
+	}
+	resp = EventResponse{Events: []Event{{ID: "evt-2"}}}
+	resp.SetNextCursor(EventQuery{})
+	if resp.NextCursor != "" {
+		t.Errorf("NextCursor without a cursor = %q, want empty", resp.NextCursor)
+	}
+}
+
 func TestEventQuery_GetOffset(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -236,3 +333,60 @@ func TestEventQuery_Pagination(t *testing.T) {
 		t.Errorf("Expected offset %d, got %d", expectedOffset, offset)
 	}
 }
+
+func TestEventQuery_ApplyBreaking(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &ThresholdConfig{BreakingMinMagnitude: 6.0, BreakingWindowHours: 2}
+
+	q := EventQuery{}
+	q.ApplyBreaking(cfg, now)
+	if q.MinMagnitude != nil || q.SinceTimestamp != nil {
+		t.Fatalf("expected no filters without breaking, got %+v", q)
+	}
+
+	q = EventQuery{Breaking: true}
+	q.ApplyBreaking(cfg, now)
+	if q.MinMagnitude == nil || *q.MinMagnitude != 6.0 {
+		t.Errorf("MinMagnitude = %v, want 6.0", q.MinMagnitude)
+	}
+	if q.SinceTimestamp == nil || !q.SinceTimestamp.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("SinceTimestamp = %v, want 2h before now", q.SinceTimestamp)
+	}
+
+	// Stricter filters already on the query are kept
+	minMag := 8.0
+	since := now.Add(-time.Hour)
+	q = EventQuery{Breaking: true, MinMagnitude: &minMag, SinceTimestamp: &since}
+	q.ApplyBreaking(cfg, now)
+	if *q.MinMagnitude != 8.0 || !q.SinceTimestamp.Equal(since) {
+		t.Errorf("expected stricter filters kept, got magnitude %v since %v", *q.MinMagnitude, *q.SinceTimestamp)
+	}
+}
+
+func TestEventQuery_ValidateWindow(t *testing.T) {
+	query := EventQuery{Window: "7d"}
+	before := time.Now()
+	if err := query.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if query.SinceTimestamp == nil || query.Since == nil {
+		t.Fatal("window should set a since bound")
+	}
+	if want := before.Add(-7 * 24 * time.Hour); query.SinceTimestamp.Before(want) || query.SinceTimestamp.After(want.Add(time.Minute)) {
+		t.Errorf("since = %v, want 7 days before %v", query.SinceTimestamp, before)
+	}
+
+	absolute := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query = EventQuery{Window: "24h", Since: &absolute}
+	if err := query.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if !query.SinceTimestamp.Equal(absolute) {
+		t.Errorf("since = %v, want the absolute bound %v", query.SinceTimestamp, absolute)
+	}
+
+	query = EventQuery{Window: "last week"}
+	if err := query.Validate(); err == nil {
+		t.Error("Validate() should reject an unparseable window")
+	}
+}
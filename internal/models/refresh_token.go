@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RefreshToken is a stored refresh token. The token itself is never persisted, only its hash.
+type RefreshToken struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Role        string     `json:"role"`         // Role granted at login; rotated tokens keep it
+	FamilyID    string     `json:"family_id"`    // Shared by all tokens rotated from the same login
+	WorkspaceID string     `json:"workspace_id"` // Workspace chosen at login; rotated tokens keep it
+	TokenHash   string     `json:"-"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
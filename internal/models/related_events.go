@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Weights of each kind of overlap in a related event's score. A shared source URL is strong
+// evidence of the same story; a shared tag is weak on its own.
+const (
+	RelatedEntityWeight = 1.0
+	RelatedTagWeight    = 0.5
+	RelatedSourceWeight = 2.0
+)
+
+const (
+	defaultRelatedDays  = 7
+	maxRelatedDays      = 90
+	defaultRelatedLimit = 10
+	maxRelatedLimit     = 50
+)
+
+// RelatedEventsQuery selects published events that overlap with one event.
+type RelatedEventsQuery struct {
+	EventID string    `json:"event_id"`
+	Days    int       `json:"days"`  // Only events from the last this many days
+	Since   time.Time `json:"since"` // Computed from Days by Validate
+	Limit   int       `json:"limit"`
+	Offset  int       `json:"offset"`
+}
+
+// Validate checks the query and applies defaults: the top 10 related events of the last 7 days.
+func (q *RelatedEventsQuery) Validate(now time.Time) error {
+	if q.EventID == "" {
+		return fmt.Errorf("event ID is required")
+	}
+
+	if q.Days == 0 {
+		q.Days = defaultRelatedDays
+	}
+	if q.Days < 1 || q.Days > maxRelatedDays {
+		return fmt.Errorf("days must be between 1 and %d", maxRelatedDays)
+	}
+	q.Since = now.AddDate(0, 0, -q.Days)
+
+	if q.Limit <= 0 {
+		q.Limit = defaultRelatedLimit
+	}
+	q.Limit = min(q.Limit, maxRelatedLimit)
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	return nil
+}
+
+// RelatedEvent is an event sharing entities, tags or source URLs with another event.
+type RelatedEvent struct {
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	Category       Category  `json:"category"`
+	Magnitude      float64   `json:"magnitude"`
+	Timestamp      time.Time `json:"timestamp"`
+	SharedEntities []string  `json:"shared_entities"` // Normalized entity names
+	SharedTags     []string  `json:"shared_tags"`
+	SharedSources  int       `json:"shared_sources"` // Source URLs both events cite
+	Score          float64   `json:"score"`
+}
+
+// RelatedScore weighs the overlap between two events.
+func RelatedScore(sharedEntities, sharedTags, sharedSources int) float64 {
+	return float64(sharedEntities)*RelatedEntityWeight +
+		float64(sharedTags)*RelatedTagWeight +
+		float64(sharedSources)*RelatedSourceWeight
+}
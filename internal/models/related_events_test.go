@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelatedEventsQuery_Validate(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	q := RelatedEventsQuery{EventID: "evt-1"}
+	if err := q.Validate(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Days != 7 || q.Limit != 10 || q.Offset != 0 {
+		t.Errorf("unexpected defaults: %+v", q)
+	}
+	if !q.Since.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("Since = %v, want 7 days before now", q.Since)
+	}
+
+	q = RelatedEventsQuery{EventID: "evt-1", Limit: 500, Offset: -5}
+	if err := q.Validate(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Limit != 50 || q.Offset != 0 {
+		t.Errorf("expected limit clamped to 50 and offset 0, got %d/%d", q.Limit, q.Offset)
+	}
+
+	for _, bad := range []RelatedEventsQuery{{}, {EventID: "evt-1", Days: -1}, {EventID: "evt-1", Days: 91}} {
+		if err := bad.Validate(now); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestRelatedScore(t *testing.T) {
+	if got := RelatedScore(2, 1, 1); got != 4.5 {
+		t.Errorf("RelatedScore(2, 1, 1) = %v, want 4.5", got)
+	}
+	// One shared source URL outweighs a single shared entity
+	if RelatedScore(0, 0, 1) <= RelatedScore(1, 0, 0) {
+		t.Error("expected a shared source to outweigh a shared entity")
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReprocessJobStatus is the state of a bulk reprocessing job.
+type ReprocessJobStatus string
+
+const (
+	ReprocessJobRunning   ReprocessJobStatus = "running"
+	ReprocessJobCompleted ReprocessJobStatus = "completed"
+	ReprocessJobCancelled ReprocessJobStatus = "cancelled"
+)
+
+const (
+	DefaultReprocessBatchSize = 100
+	MaxReprocessBatchSize     = 1000
+)
+
+// ReprocessJobRequest selects the sources a bulk reprocessing job re-enriches.
+// Empty fields match everything.
+type ReprocessJobRequest struct {
+	Platform  SourceType `json:"platform,omitempty"` // Source type, e.g. "twitter"
+	Since     *time.Time `json:"since,omitempty"`    // Only sources published at or after this time
+	Until     *time.Time `json:"until,omitempty"`    // Only sources published before this time
+	BatchSize int        `json:"batch_size,omitempty"`
+}
+
+// Validate checks the request and applies the default batch size.
+func (r *ReprocessJobRequest) Validate() error {
+	if r.Platform != "" && !ValidSourceType(r.Platform) {
+		return fmt.Errorf("invalid platform: %s", r.Platform)
+	}
+	if r.Since != nil && r.Until != nil && !r.Since.Before(*r.Until) {
+		return fmt.Errorf("since must be before until")
+	}
+	if r.BatchSize == 0 {
+		r.BatchSize = DefaultReprocessBatchSize
+	}
+	if r.BatchSize < 1 || r.BatchSize > MaxReprocessBatchSize {
+		return fmt.Errorf("batch_size must be between 1 and %d", MaxReprocessBatchSize)
+	}
+	return nil
+}
+
+// ReprocessJob resets matching sources to pending a batch at a time, so re-enriching the
+// corpus after a model or prompt change doesn't flood the enrichment workers at once.
+type ReprocessJob struct {
+	ID          string             `json:"id"`
+	Status      ReprocessJobStatus `json:"status"`
+	Platform    SourceType         `json:"platform,omitempty"`
+	Since       *time.Time         `json:"since,omitempty"`
+	Until       *time.Time         `json:"until,omitempty"`
+	BatchSize   int                `json:"batch_size"`
+	Total       int                `json:"total"`     // Sources matching the filter when the job was created
+	Processed   int                `json:"processed"` // Sources the job has walked past so far
+	Queued      int                `json:"queued"`    // Sources reset to pending; ones already pending or enriching are left alone
+	CreatedBy   string             `json:"created_by,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	WorkspaceID string             `json:"workspace_id,omitempty"` // Only sources in this workspace are reset
+
+	// Position of the last source processed, ordered by (created_at, id)
+	CursorCreatedAt *time.Time `json:"-"`
+	CursorID        string     `json:"-"`
+}
+
+// Progress returns the fraction of matching sources processed, from 0 to 1.
+func (j ReprocessJob) Progress() float64 {
+	if j.Status == ReprocessJobCompleted || j.Total == 0 {
+		return 1
+	}
+	return min(1, float64(j.Processed)/float64(j.Total))
+}
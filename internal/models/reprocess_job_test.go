@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReprocessJobRequestValidate(t *testing.T) {
+	req := ReprocessJobRequest{}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if req.BatchSize != DefaultReprocessBatchSize {
+		t.Errorf("BatchSize = %d, want default %d", req.BatchSize, DefaultReprocessBatchSize)
+	}
+
+	since := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, -1)
+	for name, bad := range map[string]ReprocessJobRequest{
+		"platform":   {Platform: "fax"},
+		"range":      {Since: &since, Until: &until},
+		"batch size": {BatchSize: MaxReprocessBatchSize + 1},
+		"negative":   {BatchSize: -1},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestReprocessJobProgress(t *testing.T) {
+	tests := []struct {
+		job  ReprocessJob
+		want float64
+	}{
+		{ReprocessJob{Status: ReprocessJobRunning, Total: 200, Processed: 50}, 0.25},
+		{ReprocessJob{Status: ReprocessJobRunning, Total: 0}, 1},
+		{ReprocessJob{Status: ReprocessJobCompleted, Total: 200, Processed: 150}, 1},
+		{ReprocessJob{Status: ReprocessJobCancelled, Total: 200, Processed: 100}, 0.5},
+		// Sources created after the job started can push processed past the original total
+		{ReprocessJob{Status: ReprocessJobRunning, Total: 100, Processed: 120}, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.job.Progress(); got != tt.want {
+			t.Errorf("Progress(%+v) = %v, want %v", tt.job, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// RetentionCutoffs holds the age cutoff for each retention rule. A nil cutoff disables the rule.
+type RetentionCutoffs struct {
+	RejectedEventsBefore  *time.Time `json:"rejected_events_before,omitempty"`  // Rejected events created before this
+	OrphanedSourcesBefore *time.Time `json:"orphaned_sources_before,omitempty"` // Sources linked to no kept event, created before this
+	ResolvedErrorsBefore  *time.Time `json:"resolved_errors_before,omitempty"`  // Ingestion errors resolved before this
+}
+
+// RetentionCutoffsFromDays computes cutoffs relative to now. Zero or negative days disables a rule.
+func RetentionCutoffsFromDays(now time.Time, rejectedEventDays, orphanedSourceDays, resolvedErrorDays int) RetentionCutoffs {
+	cutoff := func(days int) *time.Time {
+		if days <= 0 {
+			return nil
+		}
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+
+	return RetentionCutoffs{
+		RejectedEventsBefore:  cutoff(rejectedEventDays),
+		OrphanedSourcesBefore: cutoff(orphanedSourceDays),
+		ResolvedErrorsBefore:  cutoff(resolvedErrorDays),
+	}
+}
+
+// Enabled reports whether any retention rule is enabled.
+func (c RetentionCutoffs) Enabled() bool {
+	return c.RejectedEventsBefore != nil || c.OrphanedSourcesBefore != nil || c.ResolvedErrorsBefore != nil
+}
+
+// RetentionReport describes what a retention run deleted, or would delete on a dry run.
+type RetentionReport struct {
+	DryRun          bool             `json:"dry_run"`
+	Cutoffs         RetentionCutoffs `json:"cutoffs"`
+	RejectedEvents  int64            `json:"rejected_events"`
+	OrphanedSources int64            `json:"orphaned_sources"`
+	ResolvedErrors  int64            `json:"resolved_errors"`
+	RanAt           time.Time        `json:"ran_at"`
+}
+
+// ArchivePolicy decides when published events have aged out of the feed.
+type ArchivePolicy struct {
+	Before              time.Time `json:"before"`                // Archive published events older than this
+	HighMagnitude       float64   `json:"high_magnitude"`        // Events at or above this magnitude...
+	HighMagnitudeBefore time.Time `json:"high_magnitude_before"` // ...are kept until older than this instead
+}
+
+// ArchivePolicyFromDays computes an archive policy relative to now. It returns nil when days is
+// zero or negative. A highMagnitudeDays not beyond days gives high-magnitude events no extra time.
+func ArchivePolicyFromDays(now time.Time, days, highMagnitudeDays int, highMagnitude float64) *ArchivePolicy {
+	if days <= 0 {
+		return nil
+	}
+
+	policy := &ArchivePolicy{
+		Before:        now.AddDate(0, 0, -days),
+		HighMagnitude: highMagnitude,
+	}
+	policy.HighMagnitudeBefore = policy.Before
+	if highMagnitudeDays > days {
+		policy.HighMagnitudeBefore = now.AddDate(0, 0, -highMagnitudeDays)
+	}
+	return policy
+}
+
+// ShouldArchive reports whether a published event has aged out under the policy.
+func (p ArchivePolicy) ShouldArchive(event Event) bool {
+	before := p.Before
+	if event.Magnitude >= p.HighMagnitude {
+		before = p.HighMagnitudeBefore
+	}
+	return event.Timestamp.Before(before)
+}
+
+// ArchiveReport describes what an archive run did.
+type ArchiveReport struct {
+	Policy   ArchivePolicy `json:"policy"`
+	Archived int           `json:"archived"`
+	Kept     int           `json:"kept"`   // Old enough for the base cutoff but kept for their magnitude
+	Failed   int           `json:"failed"` // Events that could not be archived
+	RanAt    time.Time     `json:"ran_at"`
+}
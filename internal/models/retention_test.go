@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetentionCutoffsFromDays(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	cutoffs := RetentionCutoffsFromDays(now, 30, 0, 7)
+	if !cutoffs.Enabled() {
+		t.Fatal("expected cutoffs to be enabled")
+	}
+	if cutoffs.RejectedEventsBefore == nil || !cutoffs.RejectedEventsBefore.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("RejectedEventsBefore = %v, want 2025-03-01 12:00", cutoffs.RejectedEventsBefore)
+	}
+	if cutoffs.OrphanedSourcesBefore != nil {
+		t.Error("expected orphaned source rule to be disabled")
+	}
+	if cutoffs.ResolvedErrorsBefore == nil || !cutoffs.ResolvedErrorsBefore.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("ResolvedErrorsBefore = %v, want 7 days before now", cutoffs.ResolvedErrorsBefore)
+	}
+
+	if RetentionCutoffsFromDays(now, 0, 0, 0).Enabled() {
+		t.Error("expected all rules disabled")
+	}
+}
+
+func TestArchivePolicy(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	if ArchivePolicyFromDays(now, 0, 90, 8) != nil {
+		t.Error("expected archival disabled with zero days")
+	}
+
+	policy := ArchivePolicyFromDays(now, 30, 90, 8)
+	if policy == nil {
+		t.Fatal("expected a policy")
+	}
+
+	tests := []struct {
+		name      string
+		age       int // days
+		magnitude float64
+		want      bool
+	}{
+		{"recent", 10, 5, false},
+		{"old", 31, 5, true},
+		{"old high magnitude kept", 31, 8, false},
+		{"very old high magnitude", 91, 9, true},
+	}
+	for _, tt := range tests {
+		event := Event{Timestamp: now.AddDate(0, 0, -tt.age), Magnitude: tt.magnitude}
+		if got := policy.ShouldArchive(event); got != tt.want {
+			t.Errorf("%s: ShouldArchive = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// High-magnitude days at or below the base days give no extra time
+	policy = ArchivePolicyFromDays(now, 30, 7, 8)
+	if !policy.HighMagnitudeBefore.Equal(policy.Before) {
+		t.Errorf("expected high-magnitude cutoff %v to match base cutoff %v", policy.HighMagnitudeBefore, policy.Before)
+	}
+	if !policy.ShouldArchive(Event{Timestamp: now.AddDate(0, 0, -31), Magnitude: 9}) {
+		t.Error("expected old high-magnitude event to be archived without extra retention")
+	}
+}
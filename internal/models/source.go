@@ -17,7 +17,9 @@ type Source struct {
 	RawContent          string           `json:"raw_content"`
 	ContentHash         string           `json:"content_hash"` // SHA-256 hash for deduplication
 	Metadata            SourceMetadata   `json:"metadata"`
-	Credibility         float64          `json:"credibility"` // 0-1 scale for source reliability
+	Media               []string         `json:"media,omitempty"`        // Attached image and video URLs
+	ExternalURL         string           `json:"external_url,omitempty"` // Primary link the source points to, e.g. the article a tweet shares
+	Credibility         float64          `json:"credibility"`            // 0-1 scale for source reliability
 	VerificationURL     string           `json:"verification_url,omitempty"`
 	ScrapeStatus        ScrapeStatus     `json:"scrape_status"`                   // Status of content scraping
 	ScrapeError         string           `json:"scrape_error,omitempty"`          // Error message if scraping failed
@@ -26,7 +28,9 @@ type Source struct {
 	EnrichmentError     string           `json:"enrichment_error,omitempty"`      // Error message if enrichment failed
 	EnrichedAt          *time.Time       `json:"enriched_at,omitempty"`           // When enrichment completed
 	EnrichmentClaimedAt *time.Time       `json:"enrichment_claimed_at,omitempty"` // When enrichment was claimed (for stale lock detection)
+	EnrichmentModel     string           `json:"enrichment_model,omitempty"`      // Model that enriched the source; may be a fallback model
 	EventID             string           `json:"event_id,omitempty"`              // ID of the event created from this source
+	WorkspaceID         string           `json:"workspace_id,omitempty"`          // Workspace of the tracked account the source came from
 	CreatedAt           time.Time        `json:"created_at"`                      // Database timestamp
 }
 
@@ -43,6 +47,22 @@ const (
 	SourceTypeOther      SourceType = "other"
 )
 
+// SourceTypes lists every known source type
+var SourceTypes = []SourceType{
+	SourceTypeTwitter, SourceTypeTelegram, SourceTypeGLP, SourceTypeGovernment,
+	SourceTypeNewsMedia, SourceTypeBlog, SourceTypeOther,
+}
+
+// ValidSourceType reports whether t is a known source type
+func ValidSourceType(t SourceType) bool {
+	for _, known := range SourceTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
 // ScrapeStatus indicates the scraping state of a source.
 type ScrapeStatus string
 
@@ -64,6 +84,36 @@ const (
 	EnrichmentStatusFailed    EnrichmentStatus = "failed"    // Enrichment failed
 )
 
+// ValidEnrichmentStatus reports whether s is a known enrichment status
+func ValidEnrichmentStatus(s EnrichmentStatus) bool {
+	switch s {
+	case EnrichmentStatusPending, EnrichmentStatusEnriching, EnrichmentStatusCompleted, EnrichmentStatusFailed:
+		return true
+	}
+	return false
+}
+
+// RawSource is exactly what the enricher received for a source, with its enrichment state, for
+// diagnosing feeds that produce bad events.
+type RawSource struct {
+	ID                string           `json:"id"`
+	Type              SourceType       `json:"type"`
+	URL               string           `json:"url"`
+	Title             string           `json:"title"`
+	RawContent        string           `json:"raw_content"`
+	ContentHash       string           `json:"content_hash"`
+	PublishedAt       time.Time        `json:"published_at"`
+	RetrievedAt       time.Time        `json:"retrieved_at"` // When the content was fetched
+	OriginalLanguage  string           `json:"original_language,omitempty"`
+	TranslatedTitle   string           `json:"translated_title,omitempty"`   // Enrichment uses the translation when present
+	TranslatedContent string           `json:"translated_content,omitempty"` // Enrichment uses the translation when present
+	EnrichmentStatus  EnrichmentStatus `json:"enrichment_status"`
+	EnrichmentError   string           `json:"enrichment_error,omitempty"`
+	EnrichedAt        *time.Time       `json:"enriched_at,omitempty"`
+	EnrichmentModel   string           `json:"enrichment_model,omitempty"`
+	EventID           string           `json:"event_id,omitempty"`
+}
+
 // SourceMetadata holds platform-specific metadata for attribution and traceability.
 type SourceMetadata struct {
 	// Twitter-specific
@@ -87,6 +137,13 @@ type SourceMetadata struct {
 	Language string   `json:"language,omitempty"`
 }
 
+// SourceTranslation is an English translation of a non-English source.
+type SourceTranslation struct {
+	Language string `json:"language"` // ISO 639-1 code of the original text
+	Title    string `json:"title,omitempty"`
+	Content  string `json:"content"`
+}
+
 // GetDisplayName returns a human-readable identifier for the source.
 func (s *Source) GetDisplayName() string {
 	if s.Title != "" {
@@ -4,6 +4,12 @@ import (
 	"time"
 )
 
+// Parse modes recorded on strategy model responses
+const (
+	StrategyParseModeStructured = "structured" // JSON-schema constrained output
+	StrategyParseModeText       = "text"       // JSON scraped from free-text output
+)
+
 // Strategy represents an AI-generated portfolio allocation strategy
 type Strategy struct {
 	ID                   string          `json:"id"`
@@ -15,6 +21,7 @@ type Strategy struct {
 	Iterations           int             `json:"iterations"`             // Number of times to run before averaging
 	ForecastIDs          []string        `json:"forecast_ids"`           // Forecast IDs to inject
 	ForecastHistoryCount int             `json:"forecast_history_count"` // Number of past forecast runs to include (default: 1)
+	StructuredOutput     bool            `json:"structured_output"`      // Request JSON-schema constrained output from models
 	Models               []StrategyModel `json:"models,omitempty"`       // Associated models (populated when fetching single strategy)
 	Active               bool            `json:"active"`
 	Public               bool            `json:"public"`            // Whether visible on homepage
@@ -33,7 +40,7 @@ type StrategyModel struct {
 	StrategyID string    `json:"strategy_id"`
 	Provider   string    `json:"provider"`   // 'anthropic' or 'openai'
 	ModelName  string    `json:"model_name"` // e.g., 'claude-sonnet-4.5', 'gpt-4'
-	APIKey     string    `json:"api_key"`    // Should be encrypted in DB
+	APIKey     string    `json:"api_key"`    // Encrypted at rest; masked in API responses
 	Weight     float64   `json:"weight"`     // Weight for averaging
 	Active     bool      `json:"active"`
 	CreatedAt  time.Time `json:"created_at"`
@@ -78,8 +85,9 @@ type StrategyModelResponse struct {
 	Iteration      int                    `json:"iteration"` // Which iteration (1 to N)
 	Provider       string                 `json:"provider"`
 	ModelName      string                 `json:"model_name"`
-	Allocations    map[string]float64     `json:"allocations"`         // {"SPY": 40.0, "VNQ": 25.0, ...}
-	Reasoning      string                 `json:"reasoning,omitempty"` // Model's explanation
+	Allocations    map[string]float64     `json:"allocations"`          // {"SPY": 40.0, "VNQ": 25.0, ...}
+	Reasoning      string                 `json:"reasoning,omitempty"`  // Model's explanation
+	ParseMode      string                 `json:"parse_mode,omitempty"` // 'structured' or 'text'
 	RawResponse    map[string]interface{} `json:"raw_response,omitempty"`
 	TokensUsed     *int                   `json:"tokens_used,omitempty"`
 	ResponseTimeMs *int                   `json:"response_time_ms,omitempty"`
@@ -118,10 +126,36 @@ type CreateStrategyRequest struct {
 	Iterations           int             `json:"iterations"`
 	ForecastIDs          []string        `json:"forecast_ids"`
 	ForecastHistoryCount int             `json:"forecast_history_count"`
+	StructuredOutput     bool            `json:"structured_output"`
 	Models               []StrategyModel `json:"models"`
+
+	DuplicateOf string `json:"duplicate_of,omitempty"` // On create, the strategy whose models' masked API keys to reuse
 }
 
 // ExecuteStrategyRequest represents the request to run a strategy
 type ExecuteStrategyRequest struct {
 	StrategyID string `json:"strategy_id"`
 }
+
+// HypotheticalEvent is an analyst-supplied event injected into a what-if strategy simulation
+type HypotheticalEvent struct {
+	Title     string  `json:"title"`
+	Category  string  `json:"category"`
+	Magnitude float64 `json:"magnitude"`
+}
+
+// SimulateStrategyRequest represents the request to run a what-if strategy simulation
+type SimulateStrategyRequest struct {
+	Events []HypotheticalEvent `json:"events"`
+}
+
+// StrategySimulation is the output of a what-if strategy run. Simulations are never persisted.
+type StrategySimulation struct {
+	StrategyID         string                  `json:"strategy_id"`
+	HypotheticalEvents []HypotheticalEvent     `json:"hypothetical_events"`
+	HeadlineCount      int                     `json:"headline_count"` // Real plus hypothetical headlines in the prompt
+	ForecastSnapshots  []ForecastSnapshot      `json:"forecast_snapshots"`
+	Responses          []StrategyModelResponse `json:"responses"`
+	Result             StrategyResult          `json:"result"`
+	SimulatedAt        time.Time               `json:"simulated_at"`
+}
@@ -20,12 +20,36 @@ type Summary struct {
 	ScheduleInterval  int            `json:"schedule_interval"` // in minutes
 	AutoPostToTwitter bool           `json:"auto_post_to_twitter"`
 	IncludeForecasts  bool           `json:"include_forecasts"`
+	Mode              string         `json:"mode"`                // SummaryModeNarrative or SummaryModeDigest
+	DigestPerCategory int            `json:"digest_per_category"` // Events per category in digest mode
+	EmailEnabled      bool           `json:"email_enabled"`       // Email scheduled runs to EmailRecipients
+	EmailRecipients   []string       `json:"email_recipients"`
+	Format            string         `json:"format"` // SummaryFormatText or SummaryFormatMarkdown
 	LastRunAt         *time.Time     `json:"last_run_at,omitempty"`
 	NextRunAt         *time.Time     `json:"next_run_at,omitempty"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
+// Summary modes
+const (
+	// SummaryModeNarrative summarizes the latest headlines in a single narrative
+	SummaryModeNarrative = "narrative"
+	// SummaryModeDigest rolls up the top events by magnitude in each category, one line each
+	SummaryModeDigest = "digest"
+)
+
+// Summary output formats
+const (
+	// SummaryFormatText is plain text, suitable for Twitter
+	SummaryFormatText = "text"
+	// SummaryFormatMarkdown is markdown with event links and magnitudes, rendered to HTML by the API
+	SummaryFormatMarkdown = "markdown"
+)
+
+// DefaultDigestPerCategory is the number of events per category in a digest when none is set
+const DefaultDigestPerCategory = 3
+
 type SummaryModel struct {
 	Provider  string  `json:"provider"`
 	ModelName string  `json:"model_name"`
@@ -43,20 +67,43 @@ type SummaryRun struct {
 	Status        string     `json:"status"` // pending, running, completed, failed
 	ErrorMessage  *string    `json:"error_message,omitempty"`
 	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	EmailStatus   *string    `json:"email_status,omitempty"` // sent, failed; nil when not emailed
+	EmailError    *string    `json:"email_error,omitempty"`
+	EmailSentAt   *time.Time `json:"email_sent_at,omitempty"`
+	EventIDs      []string   `json:"event_ids"` // Events the summary was built from, in prompt order
 }
 
+// Email delivery statuses of a summary run
+const (
+	SummaryEmailSent   = "sent"
+	SummaryEmailFailed = "failed"
+)
+
 type SummaryResult struct {
 	ID            string    `json:"id"`
 	RunID         string    `json:"run_id"`
 	SummaryText   string    `json:"summary_text"`
 	ModelProvider string    `json:"model_provider"`
 	ModelName     string    `json:"model_name"`
+	Format        string    `json:"format"`         // Format of SummaryText, as generated
+	HTML          string    `json:"html,omitempty"` // Rendered by the API for markdown results
 	CreatedAt     time.Time `json:"created_at"`
 }
 
 type SummaryRunDetail struct {
-	Run     SummaryRun      `json:"run"`
-	Results []SummaryResult `json:"results"`
+	Run       SummaryRun        `json:"run"`
+	Results   []SummaryResult   `json:"results"`
+	Citations []SummaryCitation `json:"citations"`
+}
+
+// SummaryCitation is an event a summary run was built from
+type SummaryCitation struct {
+	EventID   string    `json:"event_id"`
+	Title     string    `json:"title"`
+	Category  string    `json:"category"`
+	Magnitude float64   `json:"magnitude"`
+	Timestamp time.Time `json:"timestamp"`
+	URL       string    `json:"url,omitempty"` // Public event page, filled in by the API
 }
 
 // Custom JSON marshaling for SummaryModel array
@@ -2,10 +2,41 @@ package models
 
 import "time"
 
+// Defaults for the breaking flag, used when the threshold config leaves them unset
+const (
+	DefaultBreakingMinMagnitude = 7.0
+	DefaultBreakingWindowHours  = 6
+)
+
 // ThresholdConfig holds auto-publish threshold configuration.
 type ThresholdConfig struct {
-	MinConfidence     float64   `json:"min_confidence"`
-	MinMagnitude      float64   `json:"min_magnitude"`
-	MaxSourceAgeHours int       `json:"max_source_age_hours"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	MinConfidence        float64   `json:"min_confidence"`
+	MinMagnitude         float64   `json:"min_magnitude"`
+	MaxSourceAgeHours    int       `json:"max_source_age_hours"`
+	MinSources           int       `json:"min_sources"`            // Sources an event needs to be published
+	MinSourceTypes       int       `json:"min_source_types"`       // Distinct source types (platforms) needed to publish; 0 disables
+	MinSourceDomains     int       `json:"min_source_domains"`     // Distinct source URL domains needed to publish; 0 disables
+	BreakingMinMagnitude float64   `json:"breaking_min_magnitude"` // Magnitude an event needs to be breaking
+	BreakingWindowHours  int       `json:"breaking_window_hours"`  // How recent a breaking event must be
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// BreakingCriteria returns the magnitude and recency window that make an event breaking,
+// falling back to the defaults for unset values
+func (c *ThresholdConfig) BreakingCriteria() (float64, time.Duration) {
+	minMagnitude := DefaultBreakingMinMagnitude
+	if c != nil && c.BreakingMinMagnitude > 0 {
+		minMagnitude = c.BreakingMinMagnitude
+	}
+	windowHours := DefaultBreakingWindowHours
+	if c != nil && c.BreakingWindowHours > 0 {
+		windowHours = c.BreakingWindowHours
+	}
+	return minMagnitude, time.Duration(windowHours) * time.Hour
+}
+
+// IsBreaking reports whether an event is high-magnitude and recent enough to flag as breaking
+func (c *ThresholdConfig) IsBreaking(event *Event, now time.Time) bool {
+	minMagnitude, window := c.BreakingCriteria()
+	return event.Magnitude >= minMagnitude && !event.Timestamp.Before(now.Add(-window))
 }
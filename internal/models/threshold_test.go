@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThresholdConfig_IsBreaking(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &ThresholdConfig{BreakingMinMagnitude: 6.0, BreakingWindowHours: 2}
+
+	tests := []struct {
+		name     string
+		cfg      *ThresholdConfig
+		event    Event
+		expected bool
+	}{
+		{"high magnitude and recent", cfg, Event{Magnitude: 6.0, Timestamp: now.Add(-time.Hour)}, true},
+		{"low magnitude", cfg, Event{Magnitude: 5.5, Timestamp: now.Add(-time.Hour)}, false},
+		{"outside window", cfg, Event{Magnitude: 9.0, Timestamp: now.Add(-3 * time.Hour)}, false},
+		{"defaults when unset", &ThresholdConfig{}, Event{Magnitude: 7.0, Timestamp: now.Add(-5 * time.Hour)}, true},
+		{"defaults without config", nil, Event{Magnitude: 6.9, Timestamp: now}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.IsBreaking(&tt.event, now); got != tt.expected {
+				t.Errorf("IsBreaking() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -13,10 +13,26 @@ type TrackedAccount struct {
 	LastFetchedAt        *time.Time             `json:"last_fetched_at,omitempty"`
 	FetchIntervalMinutes int                    `json:"fetch_interval_minutes"`
 	Metadata             map[string]interface{} `json:"metadata,omitempty"`
+	Tags                 []string               `json:"tags,omitempty"`         // Lowercase labels for grouping accounts, e.g. "ukraine" or "wires"
+	FetchAuth            *FeedAuth              `json:"fetch_auth,omitempty"`   // Credentials for private feeds (RSS only)
+	WorkspaceID          string                 `json:"workspace_id,omitempty"` // Sources fetched for the account belong to this workspace
 	CreatedAt            time.Time              `json:"created_at"`
 	UpdatedAt            time.Time              `json:"updated_at"`
 }
 
+// FeedAuth holds the extra headers and basic auth credentials sent when fetching a private feed,
+// e.g. an API key header for a paid provider's feed
+type FeedAuth struct {
+	Headers  map[string]string `json:"headers,omitempty"`
+	Username string            `json:"username,omitempty"`
+	Password string            `json:"password,omitempty"`
+}
+
+// IsEmpty reports whether the auth would change nothing about a request
+func (a *FeedAuth) IsEmpty() bool {
+	return a == nil || (len(a.Headers) == 0 && a.Username == "" && a.Password == "")
+}
+
 // TrackedAccountRepository defines operations for tracked accounts
 type TrackedAccountRepository interface {
 	// Store creates or updates a tracked account
@@ -28,12 +44,15 @@ type TrackedAccountRepository interface {
 	// GetByPlatformAndIdentifier retrieves an account by platform and identifier
 	GetByPlatformAndIdentifier(platform, identifier string) (*TrackedAccount, error)
 
-	// ListByPlatform returns all accounts for a given platform
+	// ListByPlatform returns all accounts for a given platform, across workspaces
 	ListByPlatform(platform string, enabledOnly bool) ([]*TrackedAccount, error)
 
-	// ListAll returns all tracked accounts
+	// ListAll returns all tracked accounts, across workspaces
 	ListAll(enabledOnly bool) ([]*TrackedAccount, error)
 
+	// ListByWorkspace returns a workspace's accounts, optionally for one platform only
+	ListByWorkspace(workspaceID, platform string, enabledOnly bool) ([]*TrackedAccount, error)
+
 	// UpdateLastFetched updates the last fetched ID and timestamp
 	UpdateLastFetched(id, lastFetchedID string, lastFetchedAt time.Time) error
 
@@ -42,4 +61,8 @@ type TrackedAccountRepository interface {
 
 	// SetEnabled enables or disables an account
 	SetEnabled(id string, enabled bool) error
+
+	// SetEnabledMatching enables or disables every account in a workspace on a platform and/or
+	// carrying a tag (empty matches any), returning how many accounts changed state
+	SetEnabledMatching(workspaceID, platform, tag string, enabled bool) (int, error)
 }
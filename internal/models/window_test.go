@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{input: "24h", expected: 24 * time.Hour},
+		{input: "90m", expected: 90 * time.Minute},
+		{input: "7d", expected: 7 * 24 * time.Hour},
+		{input: "xd", wantErr: true},
+		{input: "0d", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWindow(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseWindow(%q) expected error, got %v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWindow(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseWindow(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
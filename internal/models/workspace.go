@@ -0,0 +1,79 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
+
+// DefaultWorkspace holds everything created before workspaces existed, and everything in a
+// single-tenant deployment.
+const DefaultWorkspace = "default"
+
+// workspacePattern limits workspace IDs to short lowercase slugs
+var workspacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ValidWorkspaceID reports whether id can name a workspace
+func ValidWorkspaceID(id string) bool {
+	return workspacePattern.MatchString(id)
+}
+
+// WorkspaceOrDefault returns id, or DefaultWorkspace if id is empty
+func WorkspaceOrDefault(id string) string {
+	if id == "" {
+		return DefaultWorkspace
+	}
+	return id
+}
+
+type workspaceContextKey struct{}
+
+type allWorkspacesContextKey struct{}
+
+// ContextWithWorkspace scopes repository calls made with the returned context to a workspace.
+func ContextWithWorkspace(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, workspaceContextKey{}, WorkspaceOrDefault(workspaceID))
+}
+
+// WorkspaceFromContext returns the workspace a context is scoped to. Request contexts are always
+// scoped; repository calls made with a context that is not act in the default workspace, unless
+// it comes from ContextWithAllWorkspaces.
+func WorkspaceFromContext(ctx context.Context) (string, bool) {
+	workspaceID, ok := ctx.Value(workspaceContextKey{}).(string)
+	return workspaceID, ok
+}
+
+// ContextWithAllWorkspaces lets repository calls made with the returned context see every
+// workspace. It is for background jobs, such as enrichment and scheduling, that work across
+// workspaces; ContextWithWorkspace on the result scopes it to one workspace again.
+func ContextWithAllWorkspaces(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allWorkspacesContextKey{}, true)
+}
+
+// SpansAllWorkspaces reports whether ctx comes from ContextWithAllWorkspaces and is not scoped
+// to a single workspace
+func SpansAllWorkspaces(ctx context.Context) bool {
+	if _, ok := WorkspaceFromContext(ctx); ok {
+		return false
+	}
+	all, _ := ctx.Value(allWorkspacesContextKey{}).(bool)
+	return all
+}
+
+// ScopedID makes an ID derived from content, such as an event ID built from a content hash,
+// unique per workspace. IDs in the default workspace are unchanged.
+func ScopedID(workspaceID, id string) string {
+	if workspaceID == "" || workspaceID == DefaultWorkspace {
+		return id
+	}
+	sum := sha256.Sum256([]byte(workspaceID))
+	return id + "-" + hex.EncodeToString(sum[:8])
+}
+
+// AssignToWorkspace puts a newly fetched source in the workspace of the account it was fetched
+// for, scoping its ID so an item fetched for two workspaces is stored once in each.
+func (s *Source) AssignToWorkspace(workspaceID string) {
+	s.WorkspaceID = WorkspaceOrDefault(workspaceID)
+	s.ID = ScopedID(s.WorkspaceID, s.ID)
+}
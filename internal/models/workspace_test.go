@@ -0,0 +1,102 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidWorkspaceID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"default", true},
+		{"acme", true},
+		{"team-2_eu", true},
+		{"9lives", true},
+		{"", false},
+		{"Acme", false},
+		{"-acme", false},
+		{"acme corp", false},
+		{strings.Repeat("a", 33), false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidWorkspaceID(tt.id); got != tt.valid {
+			t.Errorf("ValidWorkspaceID(%q) = %v, want %v", tt.id, got, tt.valid)
+		}
+	}
+}
+
+func TestScopedID(t *testing.T) {
+	if got := ScopedID("", "evt-1"); got != "evt-1" {
+		t.Errorf("ScopedID with no workspace = %q, want unchanged", got)
+	}
+	if got := ScopedID(DefaultWorkspace, "evt-1"); got != "evt-1" {
+		t.Errorf("ScopedID in default workspace = %q, want unchanged", got)
+	}
+
+	acme := ScopedID("acme", "evt-1")
+	if !strings.HasPrefix(acme, "evt-1-") || acme == "evt-1" {
+		t.Errorf("ScopedID(acme) = %q, want evt-1 with a workspace suffix", acme)
+	}
+	if acme != ScopedID("acme", "evt-1") {
+		t.Error("ScopedID is not deterministic")
+	}
+	if acme == ScopedID("globex", "evt-1") {
+		t.Error("ScopedID gave two workspaces the same ID")
+	}
+}
+
+func TestWorkspaceContext(t *testing.T) {
+	if _, ok := WorkspaceFromContext(context.Background()); ok {
+		t.Error("background context should not be scoped to a workspace")
+	}
+
+	ctx := ContextWithWorkspace(context.Background(), "acme")
+	if ws, ok := WorkspaceFromContext(ctx); !ok || ws != "acme" {
+		t.Errorf("WorkspaceFromContext() = %q, %v, want acme, true", ws, ok)
+	}
+
+	ctx = ContextWithWorkspace(context.Background(), "")
+	if ws, ok := WorkspaceFromContext(ctx); !ok || ws != DefaultWorkspace {
+		t.Errorf("WorkspaceFromContext() = %q, %v, want %q, true", ws, ok, DefaultWorkspace)
+	}
+}
+
+func TestContextWithAllWorkspaces(t *testing.T) {
+	if SpansAllWorkspaces(context.Background()) {
+		t.Error("background context should not span all workspaces")
+	}
+
+	ctx := ContextWithAllWorkspaces(context.Background())
+	if !SpansAllWorkspaces(ctx) {
+		t.Error("ContextWithAllWorkspaces context should span all workspaces")
+	}
+	if _, ok := WorkspaceFromContext(ctx); ok {
+		t.Error("ContextWithAllWorkspaces context should not be scoped to a workspace")
+	}
+
+	scoped := ContextWithWorkspace(ctx, "acme")
+	if SpansAllWorkspaces(scoped) {
+		t.Error("rescoped context should no longer span all workspaces")
+	}
+	if ws, ok := WorkspaceFromContext(scoped); !ok || ws != "acme" {
+		t.Errorf("WorkspaceFromContext() = %q, %v, want acme, true", ws, ok)
+	}
+}
+
+func TestSource_AssignToWorkspace(t *testing.T) {
+	source := Source{ID: "src-1"}
+	source.AssignToWorkspace("")
+	if source.WorkspaceID != DefaultWorkspace || source.ID != "src-1" {
+		t.Errorf("default workspace source = %q/%q, want %q/src-1", source.WorkspaceID, source.ID, DefaultWorkspace)
+	}
+
+	source = Source{ID: "src-1"}
+	source.AssignToWorkspace("acme")
+	if source.WorkspaceID != "acme" || source.ID != ScopedID("acme", "src-1") {
+		t.Errorf("acme source = %q/%q, want acme/%q", source.WorkspaceID, source.ID, ScopedID("acme", "src-1"))
+	}
+}
@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// archiveBatchSize is how many candidate events are fetched per query during an archive run
+const archiveBatchSize = 200
+
+// EventArchiver finds published events and archives them through the event lifecycle
+type EventArchiver interface {
+	GetEvents(query models.EventQuery) ([]models.Event, error)
+	ArchiveEvent(ctx context.Context, eventID, actor, reason string) error
+}
+
+// ArchiveScheduler periodically archives published events that have aged out of the feed,
+// keeping high-magnitude events for longer when configured
+type ArchiveScheduler struct {
+	archiver     EventArchiver
+	config       config.ArchiveConfig
+	activityRepo ActivityLogger
+	logger       *slog.Logger
+	stopChan     chan struct{}
+}
+
+// NewArchiveScheduler creates a new archive scheduler
+func NewArchiveScheduler(
+	archiver EventArchiver,
+	cfg config.ArchiveConfig,
+	activityRepo ActivityLogger,
+	logger *slog.Logger,
+) *ArchiveScheduler {
+	return &ArchiveScheduler{
+		archiver:     archiver,
+		config:       cfg,
+		activityRepo: activityRepo,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins the scheduler loop. It returns immediately if archival is not configured.
+func (s *ArchiveScheduler) Start(ctx context.Context) {
+	if s.policy() == nil {
+		s.logger.Info("Archive scheduler disabled: ARCHIVE_PUBLISHED_EVENT_DAYS not set")
+		return
+	}
+
+	s.logger.Info("Starting archive scheduler",
+		"interval", s.config.Interval,
+		"published_event_days", s.config.PublishedEventDays,
+		"high_magnitude_days", s.config.HighMagnitudeDays,
+		"high_magnitude_threshold", s.config.HighMagnitudeThreshold)
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	// Run once immediately on start
+	s.runScheduled(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runScheduled(ctx)
+		case <-s.stopChan:
+			s.logger.Info("Archive scheduler stopped")
+			return
+		case <-ctx.Done():
+			s.logger.Info("Archive scheduler stopping due to context cancellation")
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler
+func (s *ArchiveScheduler) Stop() {
+	close(s.stopChan)
+}
+
+func (s *ArchiveScheduler) runScheduled(ctx context.Context) {
+	if _, err := s.Run(ctx); err != nil {
+		s.logger.Error("Archive run failed", "error", err)
+	}
+}
+
+// policy computes the current archive policy from the config, or nil when archival is off
+func (s *ArchiveScheduler) policy() *models.ArchivePolicy {
+	return models.ArchivePolicyFromDays(time.Now(),
+		s.config.PublishedEventDays, s.config.HighMagnitudeDays, s.config.HighMagnitudeThreshold)
+}
+
+// Run archives aged-out published events once. Runs that archive anything are recorded in
+// the activity log.
+func (s *ArchiveScheduler) Run(ctx context.Context) (*models.ArchiveReport, error) {
+	start := time.Now()
+	policy := s.policy()
+	if policy == nil {
+		return &models.ArchiveReport{RanAt: start}, nil
+	}
+	report := &models.ArchiveReport{Policy: *policy, RanAt: start}
+
+	published := models.EventStatusPublished
+	before := policy.Before
+	reason := fmt.Sprintf("published more than %d days ago", s.config.PublishedEventDays)
+
+	// Archived events drop out of the published set, so only skipped ones advance the offset
+	skipped := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		events, err := s.archiver.GetEvents(models.EventQuery{
+			Status:    &published,
+			Until:     &before,
+			SortBy:    models.SortByTimestamp,
+			SortOrder: models.SortOrderAsc,
+			Limit:     archiveBatchSize,
+			Offset:    skipped,
+		})
+		if err != nil {
+			return report, fmt.Errorf("failed to list published events: %w", err)
+		}
+
+		for _, event := range events {
+			if !policy.ShouldArchive(event) {
+				report.Kept++
+				skipped++
+				continue
+			}
+			if err := s.archiver.ArchiveEvent(ctx, event.ID, "system", reason); err != nil {
+				s.logger.Warn("Failed to archive event", "event_id", event.ID, "error", err)
+				report.Failed++
+				skipped++
+				continue
+			}
+			report.Archived++
+		}
+
+		if len(events) < archiveBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info("Archive run complete",
+		"archived", report.Archived,
+		"kept_high_magnitude", report.Kept,
+		"failed", report.Failed)
+
+	if s.activityRepo != nil && report.Archived > 0 {
+		durationMs := int(time.Since(start).Milliseconds())
+		if err := s.activityRepo.Log(ctx, models.ActivityLog{
+			ActivityType: models.ActivityTypeArchive,
+			Message: fmt.Sprintf("Archived %d published events older than %d days (%d high-magnitude events kept)",
+				report.Archived, s.config.PublishedEventDays, report.Kept),
+			Details: map[string]interface{}{
+				"archived":            report.Archived,
+				"kept_high_magnitude": report.Kept,
+				"failed":              report.Failed,
+				"before":              policy.Before,
+				"high_magnitude":      policy.HighMagnitude,
+				"high_before":         policy.HighMagnitudeBefore,
+			},
+			DurationMs: &durationMs,
+		}); err != nil {
+			s.logger.Warn("Failed to log archive activity", "error", err)
+		}
+	}
+
+	return report, nil
+}
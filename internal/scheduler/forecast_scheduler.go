@@ -2,9 +2,11 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
+	"github.com/STRATINT/stratint/internal/config"
 	"github.com/STRATINT/stratint/internal/database"
 	"github.com/STRATINT/stratint/internal/forecaster"
 )
@@ -13,6 +15,7 @@ import (
 type ForecastScheduler struct {
 	forecastRepo  *database.ForecastRepository
 	forecaster    *forecaster.Forecaster
+	config        config.ForecastScheduleConfig
 	logger        *slog.Logger
 	stopChan      chan struct{}
 	checkInterval time.Duration
@@ -22,11 +25,13 @@ type ForecastScheduler struct {
 func NewForecastScheduler(
 	forecastRepo *database.ForecastRepository,
 	forecaster *forecaster.Forecaster,
+	cfg config.ForecastScheduleConfig,
 	logger *slog.Logger,
 ) *ForecastScheduler {
 	return &ForecastScheduler{
 		forecastRepo:  forecastRepo,
 		forecaster:    forecaster,
+		config:        cfg,
 		logger:        logger,
 		stopChan:      make(chan struct{}),
 		checkInterval: 1 * time.Minute, // Check every minute
@@ -35,7 +40,10 @@ func NewForecastScheduler(
 
 // Start begins the scheduler loop
 func (s *ForecastScheduler) Start(ctx context.Context) {
-	s.logger.Info("Starting forecast scheduler", "check_interval", s.checkInterval)
+	s.logger.Info("Starting forecast scheduler",
+		"check_interval", s.checkInterval,
+		"max_per_tick", s.config.MaxPerTick,
+		"stale_after", s.config.StaleAfter)
 	ticker := time.NewTicker(s.checkInterval)
 	defer ticker.Stop()
 
@@ -61,9 +69,24 @@ func (s *ForecastScheduler) Stop() {
 	close(s.stopChan)
 }
 
-// checkAndRunForecasts checks for forecasts that need to run and executes them
+// checkAndRunForecasts checks for forecasts that need to run and executes them. Forecasts beyond
+// the per-tick cap stay due and are picked up on later ticks.
 func (s *ForecastScheduler) checkAndRunForecasts(ctx context.Context) {
-	forecasts, err := s.forecastRepo.GetScheduledForecasts(ctx)
+	if s.config.StaleAfter > 0 {
+		skipped, err := s.forecastRepo.SkipStaleScheduledForecasts(ctx, s.config.StaleAfter)
+		if err != nil {
+			s.logger.Error("Failed to skip stale scheduled forecasts", "error", err)
+		}
+		for _, forecast := range skipped {
+			s.logger.Warn("Skipped stale scheduled forecast run",
+				"forecast_id", forecast.ID,
+				"name", forecast.Name,
+				"next_run_at", forecast.NextRunAt,
+			)
+		}
+	}
+
+	forecasts, err := s.forecastRepo.GetScheduledForecasts(ctx, s.config.MaxPerTick)
 	if err != nil {
 		s.logger.Error("Failed to get scheduled forecasts", "error", err)
 		return
@@ -87,6 +110,14 @@ func (s *ForecastScheduler) checkAndRunForecasts(ctx context.Context) {
 
 		// Execute the forecast
 		runID, err := s.forecaster.ExecuteForecast(ctx, forecast.ID)
+		if errors.Is(err, forecaster.ErrRunInProgress) {
+			s.logger.Info("Skipping scheduled forecast with a run already in progress",
+				"forecast_id", forecast.ID,
+				"name", forecast.Name,
+				"error", err,
+			)
+			continue
+		}
 		if err != nil {
 			s.logger.Error("Failed to execute scheduled forecast",
 				"forecast_id", forecast.ID,
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// ReprocessJobQueue lists running reprocess jobs and resets their sources batch by batch
+type ReprocessJobQueue interface {
+	ListRunning(ctx context.Context) ([]models.ReprocessJob, error)
+	CountPending(ctx context.Context) (int, error)
+	QueueNextBatch(ctx context.Context, job models.ReprocessJob) (int, bool, error)
+}
+
+// ReprocessScheduler advances bulk reprocessing jobs, queuing another batch of sources only
+// while the enrichment backlog is below the configured limit
+type ReprocessScheduler struct {
+	queue        ReprocessJobQueue
+	config       config.ReprocessConfig
+	activityRepo ActivityLogger
+	logger       *slog.Logger
+	stopChan     chan struct{}
+}
+
+// NewReprocessScheduler creates a new reprocess scheduler
+func NewReprocessScheduler(
+	queue ReprocessJobQueue,
+	cfg config.ReprocessConfig,
+	activityRepo ActivityLogger,
+	logger *slog.Logger,
+) *ReprocessScheduler {
+	return &ReprocessScheduler{
+		queue:        queue,
+		config:       cfg,
+		activityRepo: activityRepo,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins the scheduler loop
+func (s *ReprocessScheduler) Start(ctx context.Context) {
+	s.logger.Info("Starting reprocess scheduler",
+		"interval", s.config.Interval,
+		"max_pending", s.config.MaxPending)
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runScheduled(ctx)
+		case <-s.stopChan:
+			s.logger.Info("Reprocess scheduler stopped")
+			return
+		case <-ctx.Done():
+			s.logger.Info("Reprocess scheduler stopping due to context cancellation")
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler
+func (s *ReprocessScheduler) Stop() {
+	close(s.stopChan)
+}
+
+func (s *ReprocessScheduler) runScheduled(ctx context.Context) {
+	if err := s.Run(ctx); err != nil {
+		s.logger.Error("Reprocess run failed", "error", err)
+	}
+}
+
+// Run queues the next batch of each running job, oldest job first, until the pending backlog
+// reaches the limit. Completed jobs are recorded in the activity log.
+func (s *ReprocessScheduler) Run(ctx context.Context) error {
+	jobs, err := s.queue.ListRunning(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list running reprocess jobs: %w", err)
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	pending, err := s.queue.CountPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if pending >= s.config.MaxPending {
+			s.logger.Debug("Reprocess waiting for enrichment backlog to drain",
+				"pending", pending,
+				"max_pending", s.config.MaxPending)
+			return nil
+		}
+
+		queued, done, err := s.queue.QueueNextBatch(ctx, job)
+		if err != nil {
+			s.logger.Warn("Failed to queue reprocess batch", "job_id", job.ID, "error", err)
+			continue
+		}
+		pending += queued
+
+		s.logger.Info("Queued reprocess batch",
+			"job_id", job.ID,
+			"queued", queued,
+			"done", done)
+
+		if done {
+			s.logCompleted(ctx, job, queued)
+		}
+	}
+
+	return nil
+}
+
+// logCompleted records a finished job in the activity log
+func (s *ReprocessScheduler) logCompleted(ctx context.Context, job models.ReprocessJob, lastBatch int) {
+	s.logger.Info("Reprocess job complete", "job_id", job.ID, "total", job.Total)
+	if s.activityRepo == nil {
+		return
+	}
+
+	queued := job.Queued + lastBatch
+	if err := s.activityRepo.Log(ctx, models.ActivityLog{
+		ActivityType: models.ActivityTypeReprocess,
+		Platform:     string(job.Platform),
+		Message:      fmt.Sprintf("Reprocess job %s complete: %d of %d matching sources queued for enrichment", job.ID, queued, job.Total),
+		Details: map[string]interface{}{
+			"job_id":     job.ID,
+			"total":      job.Total,
+			"queued":     queued,
+			"created_by": job.CreatedBy,
+		},
+		SourceCount: &queued,
+	}); err != nil {
+		s.logger.Warn("Failed to log reprocess activity", "error", err)
+	}
+}
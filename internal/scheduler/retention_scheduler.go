@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+	"github.com/STRATINT/stratint/internal/database"
+	"github.com/STRATINT/stratint/internal/models"
+)
+
+// ActivityLogger records activity log entries
+type ActivityLogger interface {
+	Log(ctx context.Context, log models.ActivityLog) error
+}
+
+// RetentionScheduler periodically deletes old rejected events, orphaned sources and
+// resolved ingestion errors according to the retention config
+type RetentionScheduler struct {
+	repo         *database.RetentionRepository
+	config       config.RetentionConfig
+	activityRepo ActivityLogger
+	logger       *slog.Logger
+	stopChan     chan struct{}
+}
+
+// NewRetentionScheduler creates a new retention scheduler
+func NewRetentionScheduler(
+	repo *database.RetentionRepository,
+	cfg config.RetentionConfig,
+	activityRepo ActivityLogger,
+	logger *slog.Logger,
+) *RetentionScheduler {
+	return &RetentionScheduler{
+		repo:         repo,
+		config:       cfg,
+		activityRepo: activityRepo,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins the scheduler loop. It returns immediately if no retention rule is enabled.
+func (s *RetentionScheduler) Start(ctx context.Context) {
+	if !s.cutoffs().Enabled() {
+		s.logger.Info("Retention scheduler disabled: no retention rules configured")
+		return
+	}
+
+	s.logger.Info("Starting retention scheduler", "interval", s.config.Interval)
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	// Run once immediately on start
+	s.runScheduled(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runScheduled(ctx)
+		case <-s.stopChan:
+			s.logger.Info("Retention scheduler stopped")
+			return
+		case <-ctx.Done():
+			s.logger.Info("Retention scheduler stopping due to context cancellation")
+			return
+		}
+	}
+}
+
+// Stop stops the scheduler
+func (s *RetentionScheduler) Stop() {
+	close(s.stopChan)
+}
+
+func (s *RetentionScheduler) runScheduled(ctx context.Context) {
+	if _, err := s.Run(ctx, false); err != nil {
+		s.logger.Error("Retention run failed", "error", err)
+	}
+}
+
+// cutoffs computes the current cutoff times from the configured retention days
+func (s *RetentionScheduler) cutoffs() models.RetentionCutoffs {
+	return models.RetentionCutoffsFromDays(time.Now(),
+		s.config.RejectedEventDays, s.config.OrphanedSourceDays, s.config.ResolvedErrorDays)
+}
+
+// Run applies the retention rules once. With dryRun it only reports what would be deleted.
+// Real runs that delete anything are recorded in the activity log.
+func (s *RetentionScheduler) Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error) {
+	cutoffs := s.cutoffs()
+	if dryRun {
+		return s.repo.Count(ctx, cutoffs)
+	}
+
+	if !cutoffs.Enabled() {
+		return &models.RetentionReport{Cutoffs: cutoffs, RanAt: time.Now()}, nil
+	}
+
+	start := time.Now()
+	report, err := s.repo.Purge(ctx, cutoffs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Retention run complete",
+		"rejected_events", report.RejectedEvents,
+		"orphaned_sources", report.OrphanedSources,
+		"resolved_errors", report.ResolvedErrors,
+	)
+
+	if s.activityRepo != nil && report.RejectedEvents+report.OrphanedSources+report.ResolvedErrors > 0 {
+		durationMs := int(time.Since(start).Milliseconds())
+		if err := s.activityRepo.Log(ctx, models.ActivityLog{
+			ActivityType: models.ActivityTypeRetention,
+			Message: fmt.Sprintf("Retention deleted %d rejected events, %d orphaned sources and %d resolved errors",
+				report.RejectedEvents, report.OrphanedSources, report.ResolvedErrors),
+			Details: map[string]interface{}{
+				"rejected_events":  report.RejectedEvents,
+				"orphaned_sources": report.OrphanedSources,
+				"resolved_errors":  report.ResolvedErrors,
+			},
+			DurationMs: &durationMs,
+		}); err != nil {
+			s.logger.Warn("Failed to log retention activity", "error", err)
+		}
+	}
+
+	return report, nil
+}
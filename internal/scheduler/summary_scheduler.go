@@ -103,7 +103,7 @@ func (s *SummaryScheduler) checkAndRunSummaries(ctx context.Context) {
 		)
 
 		// Execute the summary
-		runID, err := s.summaryExecutor.Execute(ctx, summary.ID)
+		runID, err := s.summaryExecutor.ExecuteScheduled(ctx, summary.ID)
 		if err != nil {
 			s.logger.Error("Failed to execute scheduled summary",
 				"summary_id", summary.ID,
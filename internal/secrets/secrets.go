@@ -0,0 +1,114 @@
+// Package secrets encrypts credentials stored in the database, such as provider API keys and
+// connector tokens, with AES-256-GCM under a key configured at startup.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// KeySize is the length in bytes of the encryption key (AES-256)
+const KeySize = 32
+
+// prefix marks an encrypted value; the version allows the format to change later
+const prefix = "enc:v1:"
+
+// ErrNoKey is returned when decrypting a stored secret while no encryption key is configured
+var ErrNoKey = errors.New("secret is encrypted but no encryption key is configured")
+
+var (
+	mu   sync.RWMutex
+	aead cipher.AEAD
+)
+
+// Configure sets the key secrets are encrypted with. Call it at startup, before any repository
+// reads or writes a secret. An empty key turns encryption off: new secrets are stored as given.
+func Configure(key []byte) error {
+	if len(key) == 0 {
+		mu.Lock()
+		aead = nil
+		mu.Unlock()
+		return nil
+	}
+	if len(key) != KeySize {
+		return fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	mu.Lock()
+	aead = gcm
+	mu.Unlock()
+	return nil
+}
+
+// Enabled reports whether an encryption key is configured
+func Enabled() bool {
+	return current() != nil
+}
+
+func current() cipher.AEAD {
+	mu.RLock()
+	defer mu.RUnlock()
+	return aead
+}
+
+// IsEncrypted reports whether a stored value was encrypted by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt returns the value to store for a secret. Empty and already encrypted values are
+// returned as they are, and so is everything while no key is configured.
+func Encrypt(plaintext string) (string, error) {
+	gcm := current()
+	if gcm == nil || plaintext == "" || IsEncrypted(plaintext) {
+		return plaintext, nil
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the secret held in a stored value. Values stored before encryption was turned
+// on are returned as they are.
+func Decrypt(stored string) (string, error) {
+	if !IsEncrypted(stored) {
+		return stored, nil
+	}
+	gcm := current()
+	if gcm == nil {
+		return "", ErrNoKey
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, prefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("encrypted secret is too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		// Usually a different key from the one the secret was encrypted with
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Cleanup(func() { Configure(nil) })
+
+	// Without a key, secrets pass through unchanged
+	if got, err := Encrypt("sk-plain"); err != nil || got != "sk-plain" {
+		t.Fatalf("Encrypt without a key = %q, %v", got, err)
+	}
+
+	if err := Configure(bytes.Repeat([]byte{7}, KeySize)); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	stored, err := Encrypt("sk-secret")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !IsEncrypted(stored) || strings.Contains(stored, "sk-secret") {
+		t.Fatalf("expected an encrypted value, got %q", stored)
+	}
+	if again, _ := Encrypt("sk-secret"); again == stored {
+		t.Error("expected a fresh nonce for every encryption")
+	}
+	if twice, _ := Encrypt(stored); twice != stored {
+		t.Error("expected an encrypted value not to be encrypted again")
+	}
+	if empty, _ := Encrypt(""); empty != "" {
+		t.Errorf("expected empty secrets to stay empty, got %q", empty)
+	}
+
+	if got, err := Decrypt(stored); err != nil || got != "sk-secret" {
+		t.Fatalf("Decrypt = %q, %v", got, err)
+	}
+	if got, err := Decrypt("sk-legacy"); err != nil || got != "sk-legacy" {
+		t.Errorf("expected values stored before encryption to pass through, got %q, %v", got, err)
+	}
+
+	// A different key can't read it, and neither can no key
+	Configure(bytes.Repeat([]byte{8}, KeySize))
+	if _, err := Decrypt(stored); err == nil {
+		t.Error("expected decryption with another key to fail")
+	}
+	Configure(nil)
+	if _, err := Decrypt(stored); !errors.Is(err, ErrNoKey) {
+		t.Errorf("expected ErrNoKey, got %v", err)
+	}
+}
+
+func TestConfigure_KeySize(t *testing.T) {
+	t.Cleanup(func() { Configure(nil) })
+
+	if err := Configure([]byte("too short")); err == nil {
+		t.Error("expected a short key to be rejected")
+	}
+	if Enabled() {
+		t.Error("expected encryption to stay off after a rejected key")
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+)
+
+// How often idle buckets are swept from memory
+const rateLimitSweepInterval = time.Minute
+
+// RateLimiter is a per-client token bucket rate limiter. A nil RateLimiter allows everything.
+type RateLimiter struct {
+	rate           float64 // tokens added per second
+	burst          float64
+	trustedProxies int
+	now            func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket tracks one client's remaining tokens
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a limiter for the given limit, or returns nil if the limit is disabled.
+// trustedProxies is the number of proxies that append to X-Forwarded-For (see ClientIP).
+func NewRateLimiter(limit config.RateLimit, trustedProxies int) *RateLimiter {
+	if limit.PerMinute <= 0 {
+		return nil
+	}
+
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:           float64(limit.PerMinute) / 60,
+		burst:          float64(burst),
+		trustedProxies: trustedProxies,
+		now:            time.Now,
+		buckets:        make(map[string]*tokenBucket),
+	}
+}
+
+// Allow takes a token from the client's bucket. When the bucket is empty it returns false
+// and how long until a token is available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweepLocked(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[key] = bucket
+	} else {
+		elapsed := now.Sub(bucket.updated).Seconds()
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed*l.rate)
+		bucket.updated = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// sweepLocked drops buckets that have refilled completely, since they behave the same as new ones.
+// The caller must hold l.mu.
+func (l *RateLimiter) sweepLocked(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	fullAfter := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.updated) >= fullAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware rejects requests over the limit with 429 Too Many Requests and a Retry-After header.
+// CORS preflight requests are never limited.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, wait := l.Allow(ClientIP(r, l.trustedProxies))
+		if !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientIP returns the address of the client that made the request. Each trusted proxy appends
+// the address it received the request from to X-Forwarded-For, so the client is the entry
+// trustedProxies from the end; anything before it was supplied by the client and can be forged.
+// Without X-Forwarded-For (or with no trusted proxies) the connection's remote address is used.
+func ClientIP(r *http.Request, trustedProxies int) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" && trustedProxies > 0 {
+		hops := strings.Split(forwarded, ",")
+		i := len(hops) - trustedProxies
+		if i < 0 {
+			i = 0
+		}
+		if ip := strings.TrimSpace(hops[i]); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(config.RateLimit{PerMinute: 60, Burst: 2}, 1)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("1.2.3.4"); !ok {
+			t.Fatalf("request %d within burst was rejected", i+1)
+		}
+	}
+
+	ok, wait := limiter.Allow("1.2.3.4")
+	if ok {
+		t.Fatal("request beyond burst was allowed")
+	}
+	if wait != time.Second {
+		t.Errorf("wait = %v, want 1s", wait)
+	}
+
+	if ok, _ := limiter.Allow("5.6.7.8"); !ok {
+		t.Error("other clients should have their own bucket")
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := limiter.Allow("1.2.3.4"); !ok {
+		t.Error("bucket should have refilled one token after a second")
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := NewRateLimiter(config.RateLimit{PerMinute: 0}, 1)
+	if limiter != nil {
+		t.Fatal("expected nil limiter when disabled")
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	if handler := limiter.Middleware(next); handler == nil {
+		t.Error("nil limiter should pass requests through")
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	limiter := NewRateLimiter(config.RateLimit{PerMinute: 30, Burst: 1}, 1)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+		req.Header.Set("X-Forwarded-For", "9.9.9.9")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := request(); rec.Code != http.StatusOK {
+		t.Fatalf("first request status = %d, want 200", rec.Code)
+	}
+
+	rec := request()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		forwarded      string
+		trustedProxies int
+		want           string
+	}{
+		{"no header", "", 1, "10.0.0.1"},
+		{"single proxy", "203.0.113.5", 1, "203.0.113.5"},
+		{"spoofed prefix ignored", "1.1.1.1, 203.0.113.5", 1, "203.0.113.5"},
+		{"load balancer in front", "1.1.1.1, 203.0.113.5, 35.0.0.1", 2, "203.0.113.5"},
+		{"fewer hops than proxies", "203.0.113.5", 3, "203.0.113.5"},
+		{"proxies not trusted", "203.0.113.5", 0, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "10.0.0.1:5555"
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := ClientIP(req, tt.trustedProxies); got != tt.want {
+				t.Errorf("ClientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
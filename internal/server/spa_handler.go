@@ -10,10 +10,11 @@ import (
 // It checks if the request was handled by API routes, and if not, serves the SPA
 func SPAMiddleware(next http.Handler, staticPath, indexPath string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip SPA for API, MCP endpoints, healthz, or metrics - let them pass through
+		// Skip SPA for API, MCP endpoints, healthz, readyz, or metrics - let them pass through
 		if strings.HasPrefix(r.URL.Path, "/api/") ||
 			strings.HasPrefix(r.URL.Path, "/mcp/") ||
 			r.URL.Path == "/healthz" ||
+			r.URL.Path == "/readyz" ||
 			r.URL.Path == "/metrics" {
 			next.ServeHTTP(w, r)
 			return
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// webSocketGUID is appended to the client's key to compute the handshake accept value (RFC 6455 §1.3)
+const webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxWebSocketFrame bounds frames read from clients. Server-push endpoints only expect control
+// frames, whose payloads are at most 125 bytes.
+const maxWebSocketFrame = 4096
+
+// webSocketWriteTimeout bounds each frame write, so a client that stops reading can't hold a
+// handler forever
+const webSocketWriteTimeout = 10 * time.Second
+
+// WebSocket opcodes
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// WebSocket close codes
+const (
+	CloseNormal         = 1000
+	CloseProtocolError  = 1002
+	CloseMessageTooBig  = 1009
+	CloseTryAgainLater  = 1013
+	closeNoStatusFrames = 1005
+)
+
+// errWebSocketClosed is returned by ReadMessage once the client has sent a close frame
+var errWebSocketClosed = errors.New("websocket closed by client")
+
+// errWebSocketFrameTooBig is returned for client frames over maxWebSocketFrame
+var errWebSocketFrameTooBig = errors.New("websocket frame too big")
+
+// WebSocketConn is a server-side WebSocket connection. Writes may come from several goroutines;
+// reads must come from one.
+type WebSocketConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+
+	mu     sync.Mutex // Serializes frame writes
+	closed bool
+
+	pongTimeout time.Duration // Read deadline each pong pushes out; 0 means none
+}
+
+// IsWebSocketUpgrade reports whether r asks to upgrade to the WebSocket protocol
+func IsWebSocketUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") &&
+		headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+// UpgradeWebSocket completes the WebSocket opening handshake and takes over the connection.
+// On failure it has already written an error response.
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request) (*WebSocketConn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return nil, errors.New("websocket upgrade requires GET")
+	}
+	if !IsWebSocketUpgrade(r) {
+		http.Error(w, "Expected a WebSocket upgrade", http.StatusUpgradeRequired)
+		return nil, errors.New("not a websocket upgrade request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "Unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, errors.New("unsupported websocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "Invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("invalid websocket key")
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+	// The server's read and write timeouts don't apply to a long-lived stream
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to clear connection deadline: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + webSocketAccept(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+
+	return &WebSocketConn{conn: conn, rw: rw}, nil
+}
+
+// webSocketAccept computes the Sec-WebSocket-Accept value for a client key
+func webSocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + webSocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerContainsToken reports whether a comma-separated header contains token, ignoring case
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WriteText sends a text message
+func (c *WebSocketConn) WriteText(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+// WritePing sends a ping; clients answer with a pong, which keeps proxies from timing out
+func (c *WebSocketConn) WritePing() error {
+	return c.writeFrame(wsOpPing, nil)
+}
+
+// WriteClose starts the closing handshake with a status code and short reason
+func (c *WebSocketConn) WriteClose(code int, reason string) error {
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	payload := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	copy(payload[2:], reason)
+	return c.writeFrame(wsOpClose, payload)
+}
+
+// writeFrame writes a single unfragmented, unmasked frame
+func (c *WebSocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	header := []byte{0x80 | opcode, 0}
+	switch n := len(payload); {
+	case n <= 125:
+		header[1] = byte(n)
+	case n <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	if err := c.rw.Flush(); err != nil {
+		return err
+	}
+	if opcode == wsOpClose {
+		c.closed = true
+	}
+	return nil
+}
+
+// SetReadDeadline sets the deadline for reading the client's next frame; zero means none
+func (c *WebSocketConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetPongTimeout makes ReadMessage fail once the client goes d without a pong, so a client that
+// vanished without closing is noticed. Each pong moves the read deadline d further out. Call it
+// before the first read, and ping more often than d.
+func (c *WebSocketConn) SetPongTimeout(d time.Duration) error {
+	c.pongTimeout = d
+	return c.SetReadDeadline(time.Now().Add(d))
+}
+
+// ReadMessage reads the next data message from the client, answering pings and skipping pongs
+// along the way. It returns an error once the client closes the connection or breaks the protocol.
+func (c *WebSocketConn) ReadMessage() ([]byte, error) {
+	var message []byte
+	for {
+		final, opcode, payload, err := c.readFrame()
+		if err != nil {
+			if errors.Is(err, errWebSocketFrameTooBig) {
+				c.WriteClose(CloseMessageTooBig, "message too big")
+			}
+			return nil, err
+		}
+
+		switch opcode {
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			if c.pongTimeout > 0 {
+				if err := c.SetReadDeadline(time.Now().Add(c.pongTimeout)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		case wsOpClose:
+			code := closeNoStatusFrames
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			if code == closeNoStatusFrames {
+				code = CloseNormal
+			}
+			c.WriteClose(code, "")
+			return nil, errWebSocketClosed
+		case wsOpText, wsOpBinary, wsOpContinuation:
+			message = append(message, payload...)
+			if len(message) > maxWebSocketFrame {
+				c.WriteClose(CloseMessageTooBig, "message too big")
+				return nil, errWebSocketFrameTooBig
+			}
+			if final {
+				return message, nil
+			}
+		default:
+			c.WriteClose(CloseProtocolError, "unknown opcode")
+			return nil, fmt.Errorf("unknown websocket opcode %#x", opcode)
+		}
+	}
+}
+
+// readFrame reads one frame and unmasks its payload. Client frames must be masked.
+func (c *WebSocketConn) readFrame() (final bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.rw, header[:]); err != nil {
+		return
+	}
+	final = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	if header[1]&0x80 == 0 {
+		c.WriteClose(CloseProtocolError, "client frames must be masked")
+		err = errors.New("unmasked client frame")
+		return
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.rw, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.rw, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxWebSocketFrame {
+		err = errWebSocketFrameTooBig
+		return
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(c.rw, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.rw, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return
+}
+
+// Close closes the underlying connection without a closing handshake
+func (c *WebSocketConn) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return c.conn.Close()
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebSocketAccept(t *testing.T) {
+	// Example from RFC 6455 §1.3
+	if got := webSocketAccept("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("webSocketAccept() = %q", got)
+	}
+}
+
+func TestUpgradeWebSocketRejectsPlainRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"no upgrade", nil, http.StatusUpgradeRequired},
+		{"wrong version", map[string]string{"Connection": "Upgrade", "Upgrade": "websocket", "Sec-WebSocket-Version": "8"}, http.StatusUpgradeRequired},
+		{"bad key", map[string]string{"Connection": "Upgrade", "Upgrade": "websocket", "Sec-WebSocket-Version": "13", "Sec-WebSocket-Key": "short"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			if _, err := UpgradeWebSocket(rec, req); err == nil {
+				t.Fatal("expected an error")
+			}
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebSocketRoundTrip(t *testing.T) {
+	received := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := UpgradeWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if err := conn.WriteText([]byte(strings.Repeat("x", 300))); err != nil {
+			return
+		}
+		msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		received <- string(msg)
+		conn.ReadMessage() // Until the client closes
+	}))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake := "GET /ws HTTP/1.1\r\nHost: test\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		t.Fatal(err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d, want 101", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept = %q", got)
+	}
+
+	// A 300-byte message uses the 16-bit extended length
+	opcode, payload := readTestFrame(t, reader)
+	if opcode != wsOpText || len(payload) != 300 {
+		t.Errorf("got opcode %#x with %d bytes, want a 300-byte text frame", opcode, len(payload))
+	}
+
+	// Pings are answered with a pong carrying the same payload
+	writeTestFrame(t, conn, wsOpPing, []byte("hi"))
+	if opcode, payload := readTestFrame(t, reader); opcode != wsOpPong || string(payload) != "hi" {
+		t.Errorf("got opcode %#x payload %q, want pong \"hi\"", opcode, payload)
+	}
+
+	writeTestFrame(t, conn, wsOpText, []byte("hello"))
+	select {
+	case msg := <-received:
+		if msg != "hello" {
+			t.Errorf("server received %q, want hello", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not receive the message")
+	}
+
+	// The server echoes the close code
+	writeTestFrame(t, conn, wsOpClose, []byte{0x03, 0xE8})
+	opcode, payload = readTestFrame(t, reader)
+	if opcode != wsOpClose || binary.BigEndian.Uint16(payload) != CloseNormal {
+		t.Errorf("got opcode %#x payload %v, want close 1000", opcode, payload)
+	}
+}
+
+func TestWebSocketPongTimeout(t *testing.T) {
+	const pongTimeout = 200 * time.Millisecond
+	readFailed := make(chan time.Duration, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := UpgradeWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		start := time.Now()
+		if err := conn.SetPongTimeout(pongTimeout); err != nil {
+			return
+		}
+		conn.ReadMessage()
+		readFailed <- time.Since(start)
+	}))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake := "GET /ws HTTP/1.1\r\nHost: test\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := http.ReadResponse(bufio.NewReader(conn), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A pong pushes the deadline out; after that the client goes quiet and is dropped
+	time.Sleep(pongTimeout / 2)
+	writeTestFrame(t, conn, wsOpPong, nil)
+	select {
+	case elapsed := <-readFailed:
+		if elapsed < pongTimeout*5/4 {
+			t.Errorf("read failed after %v; the pong should have extended the deadline", elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("read did not time out without pongs")
+	}
+}
+
+// writeTestFrame writes a masked client frame
+func writeTestFrame(t *testing.T, w io.Writer, opcode byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := w.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readTestFrame reads an unmasked server frame
+func readTestFrame(t *testing.T, r io.Reader) (byte, []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatal(err)
+	}
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			t.Fatal(err)
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return header[0] & 0x0F, payload
+}
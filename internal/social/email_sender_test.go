@@ -0,0 +1,129 @@
+package social
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/STRATINT/stratint/internal/config"
+)
+
+// fakeSMTPServer accepts one plain-text session and returns the commands and message it received
+func fakeSMTPServer(t *testing.T) (string, int, <-chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		var lines []string
+
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				received <- lines
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 fake")
+			case line == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil {
+						received <- lines
+						return
+					}
+					data = strings.TrimRight(data, "\r\n")
+					if data == "." {
+						break
+					}
+					lines = append(lines, data)
+				}
+				reply("250 queued")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, received
+}
+
+func TestEmailSender_SendHTML(t *testing.T) {
+	host, port, received := fakeSMTPServer(t)
+	sender := NewEmailSender(config.SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "stratint@example.com",
+		TLS:  config.SMTPTLSNone,
+	})
+
+	err := sender.SendHTML(context.Background(), []string{"a@example.com", "b@example.com"}, "Daily brief", "<p>Hello</p>")
+	if err != nil {
+		t.Fatalf("SendHTML: %v", err)
+	}
+
+	var lines []string
+	select {
+	case lines = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server received nothing")
+	}
+	session := strings.Join(lines, "\n")
+
+	for _, want := range []string{
+		"MAIL FROM:<stratint@example.com>",
+		"RCPT TO:<a@example.com>",
+		"RCPT TO:<b@example.com>",
+		"Subject: Daily brief",
+		"Content-Type: text/html; charset=\"utf-8\"",
+		base64.StdEncoding.EncodeToString([]byte("<p>Hello</p>")),
+	} {
+		if !strings.Contains(session, want) {
+			t.Errorf("session missing %q:\n%s", want, session)
+		}
+	}
+}
+
+func TestEmailSender_RequiresStartTLS(t *testing.T) {
+	host, port, _ := fakeSMTPServer(t)
+	sender := NewEmailSender(config.SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "stratint@example.com",
+		TLS:  config.SMTPTLSStartTLS,
+	})
+
+	err := sender.SendHTML(context.Background(), []string{"a@example.com"}, "Daily brief", "<p>Hello</p>")
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("expected STARTTLS error, got %v", err)
+	}
+}
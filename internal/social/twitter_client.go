@@ -17,6 +17,8 @@ import (
 	"time"
 
 	"log/slog"
+
+	"github.com/STRATINT/stratint/internal/httpclient"
 )
 
 // TwitterClient handles Twitter API v2 interactions
@@ -38,10 +40,8 @@ func NewTwitterClient(apiKey, apiSecret, accessToken, accessTokenSecret, bearerT
 		accessToken:       accessToken,
 		accessTokenSecret: accessTokenSecret,
 		bearerToken:       bearerToken,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger,
+		httpClient:        httpclient.New(),
+		logger:            logger,
 	}
 }
 
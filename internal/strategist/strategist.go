@@ -3,6 +3,7 @@ package strategist
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
@@ -70,25 +71,98 @@ func NewStrategist(eventRepo EventRepository, strategyRepo StrategyRepository, f
 func (s *Strategist) ExecuteStrategy(ctx context.Context, strategyID string) (string, error) {
 	s.logger.Info("starting strategy execution", "strategy_id", strategyID)
 
+	strategy, strategyModels, headlines, forecastSnapshots, err := s.loadInputs(ctx, strategyID)
+	if err != nil {
+		return "", err
+	}
+
+	// Create strategy run
+	runID, err := s.strategyRepo.CreateStrategyRun(ctx, strategyID, headlines, forecastSnapshots)
+	if err != nil {
+		return "", fmt.Errorf("failed to create strategy run: %w", err)
+	}
+
+	// Update status to running
+	if err := s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "running", ""); err != nil {
+		return "", fmt.Errorf("failed to update run status: %w", err)
+	}
+
+	// Execute strategy asynchronously
+	go s.executeStrategyAsync(context.Background(), runID, strategy, strategyModels, headlines, forecastSnapshots)
+
+	return runID, nil
+}
+
+// SimulateStrategy runs the strategy once with the given hypothetical events injected ahead of
+// the real headlines and returns the output. Nothing is persisted, so the call blocks until all
+// iterations have finished.
+func (s *Strategist) SimulateStrategy(ctx context.Context, strategyID string, events []models.HypotheticalEvent) (*models.StrategySimulation, error) {
+	s.logger.Info("starting strategy simulation", "strategy_id", strategyID, "hypothetical_events", len(events))
+
+	strategy, strategyModels, headlines, forecastSnapshots, err := s.loadInputs(ctx, strategyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	injected := make([]models.StrategyHeadline, 0, len(events)+len(headlines))
+	for i, event := range events {
+		injected = append(injected, models.StrategyHeadline{
+			EventID:   fmt.Sprintf("hypothetical-%d", i+1),
+			Title:     event.Title,
+			Category:  event.Category,
+			Magnitude: event.Magnitude,
+			Timestamp: now,
+		})
+	}
+	// Hypothetical events go first so they are never cut off by the prompt headline cap
+	injected = append(injected, headlines...)
+
+	responses := s.runIterations(ctx, "", strategy, strategyModels, injected, forecastSnapshots, func(models.StrategyModelResponse) {})
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("all iterations failed")
+	}
+
+	result := s.buildResult(ctx, strategy, strategyModels, responses)
+
+	s.logger.Info("strategy simulation completed",
+		"strategy_id", strategyID,
+		"model_count", result.ModelCount,
+		"iteration_count", result.IterationCount,
+		"parse_modes", parseModeCounts(responses))
+
+	return &models.StrategySimulation{
+		StrategyID:         strategyID,
+		HypotheticalEvents: events,
+		HeadlineCount:      len(injected),
+		ForecastSnapshots:  forecastSnapshots,
+		Responses:          responses,
+		Result:             result,
+		SimulatedAt:        now,
+	}, nil
+}
+
+// loadInputs fetches the strategy, its models and the headlines and forecast data it runs on
+func (s *Strategist) loadInputs(ctx context.Context, strategyID string) (*models.Strategy, []models.StrategyModel, []models.StrategyHeadline, []models.ForecastSnapshot, error) {
 	// Get strategy configuration
 	strategy, err := s.strategyRepo.GetStrategy(ctx, strategyID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get strategy: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get strategy: %w", err)
 	}
 
 	// Get strategy models
-	models, err := s.strategyRepo.GetStrategyModels(ctx, strategyID)
+	strategyModels, err := s.strategyRepo.GetStrategyModels(ctx, strategyID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get strategy models: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get strategy models: %w", err)
 	}
-	if len(models) == 0 {
-		return "", fmt.Errorf("no models configured for strategy: %s", strategyID)
+	if len(strategyModels) == 0 {
+		return nil, nil, nil, nil, fmt.Errorf("no models configured for strategy: %s", strategyID)
 	}
 
 	// Fetch recent headlines
 	headlines, err := s.fetchHeadlines(ctx, strategy)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch headlines: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to fetch headlines: %w", err)
 	}
 
 	s.logger.Info("fetched headlines for strategy",
@@ -102,28 +176,14 @@ func (s *Strategist) ExecuteStrategy(ctx context.Context, strategyID string) (st
 	}
 	forecastSnapshots, err := s.fetchForecastData(ctx, strategy.ForecastIDs, historyCount)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch forecast data: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to fetch forecast data: %w", err)
 	}
 
 	s.logger.Info("fetched forecast data for strategy",
 		"strategy_id", strategyID,
 		"forecast_count", len(forecastSnapshots))
 
-	// Create strategy run
-	runID, err := s.strategyRepo.CreateStrategyRun(ctx, strategyID, headlines, forecastSnapshots)
-	if err != nil {
-		return "", fmt.Errorf("failed to create strategy run: %w", err)
-	}
-
-	// Update status to running
-	if err := s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "running", ""); err != nil {
-		return "", fmt.Errorf("failed to update run status: %w", err)
-	}
-
-	// Execute strategy asynchronously
-	go s.executeStrategyAsync(context.Background(), runID, strategy, models, headlines, forecastSnapshots)
-
-	return runID, nil
+	return strategy, strategyModels, headlines, forecastSnapshots, nil
 }
 
 func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, strategy *models.Strategy, strategyModels []models.StrategyModel, headlines []models.StrategyHeadline, forecastSnapshots []models.ForecastSnapshot) {
@@ -134,7 +194,44 @@ func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, str
 		}
 	}()
 
-	// Run multiple iterations across all models
+	// Run multiple iterations across all models, storing every response
+	allResponses := s.runIterations(ctx, runID, strategy, strategyModels, headlines, forecastSnapshots, func(response models.StrategyModelResponse) {
+		if err := s.strategyRepo.CreateModelResponse(ctx, response); err != nil {
+			s.logger.Error("failed to store model response", "error", err)
+		}
+	})
+
+	if len(allResponses) == 0 {
+		s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "failed", "all iterations failed")
+		return
+	}
+
+	result := s.buildResult(ctx, strategy, strategyModels, allResponses)
+	result.RunID = runID
+
+	// Store result
+	if err := s.strategyRepo.CreateStrategyResult(ctx, result); err != nil {
+		s.logger.Error("failed to store strategy result", "error", err)
+		s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "failed", fmt.Sprintf("failed to store result: %v", err))
+		return
+	}
+
+	// Update strategy last run time
+	s.strategyRepo.UpdateStrategyLastRun(ctx, strategy.ID, time.Now())
+
+	// Mark run as completed
+	s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "completed", "")
+
+	s.logger.Info("strategy execution completed",
+		"run_id", runID,
+		"model_count", result.ModelCount,
+		"iteration_count", result.IterationCount,
+		"parse_modes", parseModeCounts(allResponses))
+}
+
+// runIterations queries every model for each configured iteration. Each response, completed or
+// failed, is passed to record; only the completed responses are returned.
+func (s *Strategist) runIterations(ctx context.Context, runID string, strategy *models.Strategy, strategyModels []models.StrategyModel, headlines []models.StrategyHeadline, forecastSnapshots []models.ForecastSnapshot, record func(models.StrategyModelResponse)) []models.StrategyModelResponse {
 	var allResponses []models.StrategyModelResponse
 
 	for _, model := range strategyModels {
@@ -158,8 +255,8 @@ func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, str
 					"iteration", iteration,
 					"error", err)
 
-				// Store failed response
-				failedResp := models.StrategyModelResponse{
+				// Record failed response
+				record(models.StrategyModelResponse{
 					RunID:          runID,
 					ModelID:        model.ID,
 					Iteration:      iteration,
@@ -168,8 +265,7 @@ func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, str
 					Status:         "failed",
 					ErrorMessage:   err.Error(),
 					ResponseTimeMs: &responseTime,
-				}
-				s.strategyRepo.CreateModelResponse(ctx, failedResp)
+				})
 				continue
 			}
 
@@ -178,24 +274,20 @@ func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, str
 			response.ResponseTimeMs = &responseTime
 
 			allResponses = append(allResponses, *response)
-
-			// Store response
-			if err := s.strategyRepo.CreateModelResponse(ctx, *response); err != nil {
-				s.logger.Error("failed to store model response", "error", err)
-			}
+			record(*response)
 		}
 	}
 
-	if len(allResponses) == 0 {
-		s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "failed", "all iterations failed")
-		return
-	}
+	return allResponses
+}
 
+// buildResult averages the responses, measures their variance and runs the normalization pass
+func (s *Strategist) buildResult(ctx context.Context, strategy *models.Strategy, strategyModels []models.StrategyModel, responses []models.StrategyModelResponse) models.StrategyResult {
 	// Calculate averaged allocations
-	averaged := s.averageAllocations(allResponses, strategy.InvestmentSymbols)
+	averaged := s.averageAllocations(responses, strategy.InvestmentSymbols)
 
 	// Calculate variance
-	variance := s.calculateVariance(allResponses, strategy.InvestmentSymbols)
+	variance := s.calculateVariance(responses, strategy.InvestmentSymbols)
 
 	// Perform normalization pass with AI
 	normalized, reasoning, err := s.normalizeAllocations(ctx, averaged, &strategyModels[0], strategy.InvestmentSymbols)
@@ -205,9 +297,7 @@ func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, str
 		reasoning = "Normalization pass failed, using raw averages"
 	}
 
-	// Create result
-	result := models.StrategyResult{
-		RunID:                  runID,
+	return models.StrategyResult{
 		AveragedAllocations:    averaged,
 		NormalizedAllocations:  normalized,
 		NormalizationReasoning: reasoning,
@@ -215,24 +305,6 @@ func (s *Strategist) executeStrategyAsync(ctx context.Context, runID string, str
 		IterationCount:         strategy.Iterations,
 		ConsensusVariance:      variance,
 	}
-
-	// Store result
-	if err := s.strategyRepo.CreateStrategyResult(ctx, result); err != nil {
-		s.logger.Error("failed to store strategy result", "error", err)
-		s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "failed", fmt.Sprintf("failed to store result: %v", err))
-		return
-	}
-
-	// Update strategy last run time
-	s.strategyRepo.UpdateStrategyLastRun(ctx, strategy.ID, time.Now())
-
-	// Mark run as completed
-	s.strategyRepo.UpdateStrategyRunStatus(ctx, runID, "completed", "")
-
-	s.logger.Info("strategy execution completed",
-		"run_id", runID,
-		"model_count", result.ModelCount,
-		"iteration_count", result.IterationCount)
 }
 
 func (s *Strategist) fetchHeadlines(ctx context.Context, strategy *models.Strategy) ([]models.StrategyHeadline, error) {
@@ -358,6 +430,33 @@ func (s *Strategist) executeIteration(ctx context.Context, strategy *models.Stra
 		"iteration", iteration,
 		"prompt_length", len(prompt))
 
+	response := &models.StrategyModelResponse{
+		ModelID:   model.ID,
+		Iteration: iteration,
+		Provider:  model.Provider,
+		ModelName: model.ModelName,
+		Status:    "completed",
+	}
+
+	// Prefer schema-constrained output when enabled, falling back to text parsing if unavailable
+	if strategy.StructuredOutput {
+		allocation, tokens, err := s.callModelStructured(ctx, model, prompt, strategy.InvestmentSymbols)
+		if err == nil {
+			response.Allocations = allocation.Allocations
+			response.Reasoning = allocation.Reasoning
+			response.TokensUsed = &tokens
+			response.ParseMode = models.StrategyParseModeStructured
+			return response, nil
+		}
+		if !errors.Is(err, errStructuredOutputUnavailable) {
+			return nil, err
+		}
+		s.logger.Warn("structured output unavailable, falling back to text parsing",
+			"provider", model.Provider,
+			"model", model.ModelName,
+			"error", err)
+	}
+
 	// Call AI model
 	content, tokens, err := s.callModel(ctx, model, prompt)
 	if err != nil {
@@ -370,16 +469,11 @@ func (s *Strategist) executeIteration(ctx context.Context, strategy *models.Stra
 		return nil, fmt.Errorf("failed to parse allocations: %w", err)
 	}
 
-	return &models.StrategyModelResponse{
-		ModelID:     model.ID,
-		Iteration:   iteration,
-		Provider:    model.Provider,
-		ModelName:   model.ModelName,
-		Allocations: allocations,
-		Reasoning:   reasoning,
-		TokensUsed:  &tokens,
-		Status:      "completed",
-	}, nil
+	response.Allocations = allocations
+	response.Reasoning = reasoning
+	response.TokensUsed = &tokens
+	response.ParseMode = models.StrategyParseModeText
+	return response, nil
 }
 
 func (s *Strategist) buildPrompt(strategy *models.Strategy, headlines []models.StrategyHeadline, forecastSnapshots []models.ForecastSnapshot) string {
@@ -468,15 +562,20 @@ func (s *Strategist) callModel(ctx context.Context, model *models.StrategyModel,
 
 	switch model.Provider {
 	case "openai":
-		return s.callOpenAI(ctx, model, systemPrompt, prompt)
+		return s.callOpenAI(ctx, model, systemPrompt, prompt, nil)
 	case "anthropic":
-		return s.callAnthropic(ctx, model, systemPrompt, prompt)
+		return s.callAnthropic(ctx, model, systemPrompt, prompt, nil)
 	default:
 		return "", 0, fmt.Errorf("unsupported provider: %s", model.Provider)
 	}
 }
 
-func (s *Strategist) callOpenAI(ctx context.Context, model *models.StrategyModel, systemPrompt, userPrompt string) (string, int, error) {
+// callOpenAI sends the prompt to OpenAI. A non-nil responseFormat constrains the output, e.g. to a JSON schema.
+func (s *Strategist) callOpenAI(ctx context.Context, model *models.StrategyModel, systemPrompt, userPrompt string, responseFormat *openai.ChatCompletionResponseFormat) (string, int, error) {
+	if err := s.inferenceLogger.CheckBudget(ctx); err != nil {
+		return "", 0, err
+	}
+
 	client := openai.NewClient(model.APIKey)
 
 	startTime := time.Now()
@@ -487,6 +586,7 @@ func (s *Strategist) callOpenAI(ctx context.Context, model *models.StrategyModel
 			{Role: openai.ChatMessageRoleSystem, Content: systemPrompt},
 			{Role: openai.ChatMessageRoleUser, Content: userPrompt},
 		},
+		ResponseFormat: responseFormat,
 	})
 	latency := time.Since(startTime)
 
@@ -518,7 +618,13 @@ func (s *Strategist) callOpenAI(ctx context.Context, model *models.StrategyModel
 	return resp.Choices[0].Message.Content, resp.Usage.TotalTokens, nil
 }
 
-func (s *Strategist) callAnthropic(ctx context.Context, model *models.StrategyModel, systemPrompt, userPrompt string) (string, int, error) {
+// callAnthropic sends the prompt to Anthropic. When tool is non-nil the model is forced to call it
+// and the tool input JSON is returned in place of the text response.
+func (s *Strategist) callAnthropic(ctx context.Context, model *models.StrategyModel, systemPrompt, userPrompt string, tool *anthropic.ToolParam) (string, int, error) {
+	if err := s.inferenceLogger.CheckBudget(ctx); err != nil {
+		return "", 0, err
+	}
+
 	client := anthropic.NewClient(option.WithAPIKey(model.APIKey))
 
 	req := anthropic.MessageNewParams{
@@ -532,6 +638,10 @@ func (s *Strategist) callAnthropic(ctx context.Context, model *models.StrategyMo
 			anthropic.NewUserMessage(anthropic.NewTextBlock(userPrompt)),
 		},
 	}
+	if tool != nil {
+		req.Tools = []anthropic.ToolUnionParam{{OfTool: tool}}
+		req.ToolChoice = anthropic.ToolChoiceUnionParam{OfTool: &anthropic.ToolChoiceToolParam{Name: tool.Name}}
+	}
 
 	startTime := time.Now()
 	message, err := client.Messages.New(ctx, req)
@@ -560,9 +670,19 @@ func (s *Strategist) callAnthropic(ctx context.Context, model *models.StrategyMo
 		return "", 0, fmt.Errorf("no response from anthropic")
 	}
 
-	textBlock := message.Content[0].Text
 	tokens := int(message.Usage.InputTokens + message.Usage.OutputTokens)
 
+	if tool != nil {
+		for _, block := range message.Content {
+			if block.Type == "tool_use" {
+				return string(block.Input), tokens, nil
+			}
+		}
+		return "", 0, fmt.Errorf("no tool call in anthropic response")
+	}
+
+	textBlock := message.Content[0].Text
+
 	return textBlock, tokens, nil
 }
 
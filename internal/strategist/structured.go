@@ -0,0 +1,160 @@
+package strategist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/STRATINT/stratint/internal/models"
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+const (
+	// Name of the schema (OpenAI) and forced tool (Anthropic) used for structured output
+	allocationSchemaName = "portfolio_allocation"
+
+	structuredInstruction = "\nReturn the allocation percentages and your reasoning using the provided portfolio_allocation schema.\n"
+)
+
+// errStructuredOutputUnavailable marks structured output failures that should fall back to text parsing
+var errStructuredOutputUnavailable = errors.New("structured output unavailable")
+
+// structuredAllocation is the typed output requested from models when structured output is enabled
+type structuredAllocation struct {
+	Allocations map[string]float64 `json:"allocations"`
+	Reasoning   string             `json:"reasoning"`
+}
+
+// allocationSchema returns the JSON schema for a structuredAllocation over the given symbols
+func allocationSchema(symbols []string) jsonschema.Definition {
+	properties := make(map[string]jsonschema.Definition, len(symbols))
+	for _, symbol := range symbols {
+		properties[symbol] = jsonschema.Definition{
+			Type:        jsonschema.Number,
+			Description: fmt.Sprintf("Percentage of the portfolio allocated to %s", symbol),
+		}
+	}
+
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"allocations": {
+				Type:                 jsonschema.Object,
+				Description:          "Allocation percentages per investment, summing to approximately 100",
+				Properties:           properties,
+				Required:             symbols,
+				AdditionalProperties: false,
+			},
+			"reasoning": {
+				Type:        jsonschema.String,
+				Description: "Brief reasoning (2-3 sentences) explaining the allocation strategy",
+			},
+		},
+		Required:             []string{"allocations", "reasoning"},
+		AdditionalProperties: false,
+	}
+}
+
+// callModelStructured requests schema-constrained output from the model and decodes it. Errors
+// wrapping errStructuredOutputUnavailable mean the caller should retry with text parsing.
+func (s *Strategist) callModelStructured(ctx context.Context, model *models.StrategyModel, prompt string, symbols []string) (*structuredAllocation, int, error) {
+	schema := allocationSchema(symbols)
+	prompt += structuredInstruction
+
+	var content string
+	var tokens int
+	var err error
+
+	switch model.Provider {
+	case "openai":
+		content, tokens, err = s.callOpenAI(ctx, model, systemPrompt, prompt, &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   allocationSchemaName,
+				Schema: &schema,
+				Strict: true,
+			},
+		})
+	case "anthropic":
+		content, tokens, err = s.callAnthropic(ctx, model, systemPrompt, prompt, &anthropic.ToolParam{
+			Name:        allocationSchemaName,
+			Description: anthropic.String("Record the recommended portfolio allocation"),
+			InputSchema: anthropic.ToolInputSchemaParam{
+				Properties: schema.Properties,
+				Required:   schema.Required,
+			},
+		})
+	default:
+		return nil, 0, fmt.Errorf("%w: unsupported provider %s", errStructuredOutputUnavailable, model.Provider)
+	}
+
+	if err != nil {
+		if isStructuredOutputRejected(err) {
+			return nil, 0, fmt.Errorf("%w: %v", errStructuredOutputUnavailable, err)
+		}
+		return nil, 0, err
+	}
+
+	allocation, err := decodeStructuredAllocation(content, symbols)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", errStructuredOutputUnavailable, err)
+	}
+
+	return allocation, tokens, nil
+}
+
+// isStructuredOutputRejected reports whether the provider rejected the request as invalid, which
+// is how models without JSON schema or tool support respond
+func isStructuredOutputRejected(err error) bool {
+	var openaiErr *openai.APIError
+	if errors.As(err, &openaiErr) {
+		return openaiErr.HTTPStatusCode == http.StatusBadRequest
+	}
+
+	var anthropicErr *anthropic.Error
+	if errors.As(err, &anthropicErr) {
+		return anthropicErr.StatusCode == http.StatusBadRequest
+	}
+
+	return false
+}
+
+// decodeStructuredAllocation parses structured output and checks every symbol has an allocation
+func decodeStructuredAllocation(content string, symbols []string) (*structuredAllocation, error) {
+	var allocation structuredAllocation
+	if err := json.Unmarshal([]byte(content), &allocation); err != nil {
+		return nil, fmt.Errorf("failed to decode structured output: %w", err)
+	}
+
+	for _, symbol := range symbols {
+		if _, ok := allocation.Allocations[symbol]; !ok {
+			return nil, fmt.Errorf("missing allocation for symbol: %s", symbol)
+		}
+	}
+
+	// Drop anything outside the configured investments
+	filtered := make(map[string]float64, len(symbols))
+	for _, symbol := range symbols {
+		filtered[symbol] = allocation.Allocations[symbol]
+	}
+	allocation.Allocations = filtered
+
+	if len(allocation.Reasoning) > 500 {
+		allocation.Reasoning = allocation.Reasoning[:500] + "..."
+	}
+
+	return &allocation, nil
+}
+
+// parseModeCounts tallies the parse mode of each response for run-level logging
+func parseModeCounts(responses []models.StrategyModelResponse) map[string]int {
+	counts := make(map[string]int)
+	for _, resp := range responses {
+		counts[resp.ParseMode]++
+	}
+	return counts
+}
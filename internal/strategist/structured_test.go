@@ -0,0 +1,80 @@
+package strategist
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestDecodeStructuredAllocation(t *testing.T) {
+	symbols := []string{"SPY", "TLT", "CASH"}
+
+	allocation, err := decodeStructuredAllocation(`{"allocations":{"SPY":50,"TLT":30.5,"CASH":19.5,"GLD":3},"reasoning":"Risk-on tilt."}`, symbols)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allocation.Allocations["TLT"] != 30.5 {
+		t.Errorf("TLT = %v, want 30.5", allocation.Allocations["TLT"])
+	}
+	if _, ok := allocation.Allocations["GLD"]; ok {
+		t.Error("expected unconfigured symbol GLD to be dropped")
+	}
+	if allocation.Reasoning != "Risk-on tilt." {
+		t.Errorf("reasoning = %q", allocation.Reasoning)
+	}
+
+	if _, err := decodeStructuredAllocation(`{"allocations":{"SPY":50,"TLT":50},"reasoning":""}`, symbols); err == nil {
+		t.Error("expected error for missing symbol")
+	}
+	if _, err := decodeStructuredAllocation(`SPY: 50`, symbols); err == nil {
+		t.Error("expected error for non-JSON content")
+	}
+}
+
+func TestAllocationSchema(t *testing.T) {
+	data, err := json.Marshal(allocationSchema([]string{"SPY", "CASH"}))
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Required             []string `json:"required"`
+			AdditionalProperties *bool    `json:"additionalProperties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	allocations := schema.Properties["allocations"]
+	if len(allocations.Required) != 2 {
+		t.Errorf("allocations required = %v, want both symbols", allocations.Required)
+	}
+	if allocations.AdditionalProperties == nil || *allocations.AdditionalProperties {
+		t.Error("expected allocations to disallow additional properties")
+	}
+	if len(schema.Required) != 2 {
+		t.Errorf("required = %v, want allocations and reasoning", schema.Required)
+	}
+}
+
+func TestIsStructuredOutputRejected(t *testing.T) {
+	badRequest := fmt.Errorf("openai api error: %w", &openai.APIError{HTTPStatusCode: 400, Message: "response_format not supported"})
+	if !isStructuredOutputRejected(badRequest) {
+		t.Error("expected 400 from openai to be treated as rejected structured output")
+	}
+
+	rateLimited := fmt.Errorf("openai api error: %w", &openai.APIError{HTTPStatusCode: 429})
+	if isStructuredOutputRejected(rateLimited) {
+		t.Error("expected 429 to be returned as a regular error")
+	}
+
+	if !isStructuredOutputRejected(fmt.Errorf("anthropic api error: %w", &anthropic.Error{StatusCode: 400})) {
+		t.Error("expected 400 from anthropic to be treated as rejected structured output")
+	}
+}
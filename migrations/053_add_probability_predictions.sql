@@ -0,0 +1,13 @@
+-- Add probability prediction type for binary "will X happen" forecasts
+-- Models return a single 0-100 probability which is aggregated across models
+
+ALTER TABLE forecast_model_responses
+  ADD COLUMN IF NOT EXISTS probability REAL; -- 0-100 probability returned by the model
+
+ALTER TABLE forecast_results
+  ADD COLUMN IF NOT EXISTS aggregated_probability REAL; -- Weighted avg of model probabilities
+
+-- Comments
+COMMENT ON COLUMN forecasts.prediction_type IS 'Type of prediction: percentile (full distribution), point_estimate (single value) or probability (0-100 chance of occurring)';
+COMMENT ON COLUMN forecast_model_responses.probability IS 'Model probability (0-100) for probability forecasts';
+COMMENT ON COLUMN forecast_results.aggregated_probability IS 'Weighted average of model probabilities (0-100)';
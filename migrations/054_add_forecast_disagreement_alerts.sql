@@ -0,0 +1,11 @@
+-- Add ensemble disagreement alerting to forecasts
+-- When a completed run's consensus (std dev across models) exceeds the threshold,
+-- an activity log entry is written and the optional webhook is notified
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS disagreement_threshold REAL, -- NULL disables alerting
+  ADD COLUMN IF NOT EXISTS alert_webhook_url TEXT;      -- Webhook (e.g. Slack incoming webhook) for alerts
+
+-- Comments
+COMMENT ON COLUMN forecasts.disagreement_threshold IS 'Consensus std dev above which a completed run raises a disagreement alert (NULL = disabled)';
+COMMENT ON COLUMN forecasts.alert_webhook_url IS 'Optional webhook URL notified when a forecast alert fires';
@@ -0,0 +1,5 @@
+-- Add a configurable execution timeout to forecasts
+-- Runs exceeding the timeout are marked failed and any completed model responses are kept
+ALTER TABLE forecasts ADD COLUMN IF NOT EXISTS timeout_minutes INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN forecasts.timeout_minutes IS 'Maximum minutes a run may take before it is cancelled (0 = default)';
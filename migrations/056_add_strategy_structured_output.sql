@@ -0,0 +1,10 @@
+-- Structured (JSON-schema constrained) model output for strategies
+-- When enabled the strategist asks the model for schema-conforming JSON and only falls back
+-- to scraping the free-text response when the model doesn't support structured output.
+ALTER TABLE strategies ADD COLUMN IF NOT EXISTS structured_output BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Which parser produced each response's allocations ('structured' or 'text')
+ALTER TABLE strategy_model_responses ADD COLUMN IF NOT EXISTS parse_mode TEXT;
+
+COMMENT ON COLUMN strategies.structured_output IS 'Request JSON-schema constrained output from models, falling back to text parsing';
+COMMENT ON COLUMN strategy_model_responses.parse_mode IS 'Parser used for the response: structured or text';
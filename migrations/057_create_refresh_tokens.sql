@@ -0,0 +1,16 @@
+-- Refresh tokens for the admin auth flow
+-- Only a SHA-256 hash of each token is stored. Tokens are single use: every refresh revokes the
+-- presented token and issues a new one in the same family. Presenting an already-revoked token
+-- revokes the whole family, so a stolen token stops working as soon as either party uses it.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL,
+  family_id UUID NOT NULL,          -- Shared by all tokens rotated from the same login
+  token_hash TEXT NOT NULL UNIQUE,  -- hex SHA-256 of the token
+  expires_at TIMESTAMP NOT NULL,
+  revoked_at TIMESTAMP,             -- NULL while the token is usable
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
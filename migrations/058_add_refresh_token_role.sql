@@ -0,0 +1,6 @@
+-- Record the role granted at login on refresh tokens
+-- Rotated access tokens inherit the role of the session they were refreshed from.
+-- Existing sessions predate roles and were all admin logins.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'admin';
+
+COMMENT ON COLUMN refresh_tokens.role IS 'Role granted at login: admin or analyst';
@@ -0,0 +1,15 @@
+-- Long-lived API keys for programmatic access
+-- Keys are sent in the X-API-Key header. Only a SHA-256 hash is stored; the key itself is shown
+-- once at creation. Scopes map onto auth roles: "read" behaves like an analyst, "write" like an admin.
+CREATE TABLE IF NOT EXISTS api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  key_prefix TEXT NOT NULL,          -- First characters of the key, to identify it in listings
+  key_hash TEXT NOT NULL UNIQUE,     -- hex SHA-256 of the key
+  scopes TEXT[] NOT NULL DEFAULT ARRAY['read']::TEXT[],
+  last_used_at TIMESTAMP,            -- NULL until first use
+  revoked_at TIMESTAMP,              -- NULL while the key is usable
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_last_used_at ON api_keys(last_used_at);
@@ -0,0 +1,14 @@
+-- Audit trail of event status transitions
+-- One row per change, written by the event pipeline (actor "system") and by manual status
+-- updates (actor is the authenticated user or API key). old_status is NULL for an event's first status.
+CREATE TABLE IF NOT EXISTS event_status_history (
+  id BIGSERIAL PRIMARY KEY,
+  event_id VARCHAR(64) NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+  old_status VARCHAR(32),
+  new_status VARCHAR(32) NOT NULL,
+  actor TEXT NOT NULL,
+  reason TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_status_history_event_id ON event_status_history(event_id, created_at);
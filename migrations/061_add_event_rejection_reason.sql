@@ -0,0 +1,8 @@
+-- Persist why the pipeline rejected an event
+-- rejection_thresholds snapshots the publication thresholds in effect at rejection time, so the
+-- reason stays explainable after thresholds are retuned. Both are cleared when an event is published.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS rejection_thresholds JSONB;
+
+COMMENT ON COLUMN events.rejection_reason IS 'Human-readable reason the event was rejected, e.g. "magnitude 0.5 < 1.0"';
+COMMENT ON COLUMN events.rejection_thresholds IS 'Publication thresholds in effect when the event was rejected';
@@ -0,0 +1,8 @@
+-- Record when a source's enrichment last failed
+-- Lets operators requeue only sources that failed after a given time, e.g. after a prompt change.
+-- Sources that failed before this migration have NULL and only match requeues without a time filter.
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS enrichment_failed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_sources_enrichment_failed_at ON sources(enrichment_failed_at) WHERE enrichment_status = 'failed';
+
+COMMENT ON COLUMN sources.enrichment_failed_at IS 'When enrichment last failed; NULL if it has not failed since this column was added';
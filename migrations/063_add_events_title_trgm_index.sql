@@ -0,0 +1,6 @@
+-- Trigram index on event titles for near-duplicate title detection
+-- ProcessEvent merges new events into recent events whose titles are at least
+-- TITLE_DEDUP_SIMILARITY similar (pg_trgm similarity) instead of creating duplicates.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_events_title_trgm ON events USING GIN(title gin_trgm_ops);
@@ -0,0 +1,12 @@
+-- Store English translations of non-English sources
+-- The original text stays in title/raw_content; enrichment uses the translation when present.
+-- Translation is opt-in per connector via the connector's translate_non_english setting.
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS original_language VARCHAR(16);
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS translated_title TEXT;
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS translated_content TEXT;
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS translated_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN sources.original_language IS 'ISO 639-1 code of the source language as reported by the translation model';
+COMMENT ON COLUMN sources.translated_title IS 'English translation of title; NULL if the source was not translated';
+COMMENT ON COLUMN sources.translated_content IS 'English translation of raw_content; NULL if the source was not translated';
+COMMENT ON COLUMN sources.translated_at IS 'When the translation was made';
@@ -0,0 +1,5 @@
+-- Add a configurable user prompt template for event correlation
+-- Empty uses the built-in template; see enrichment.ValidatePromptTemplate for required placeholders.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS correlation_template TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN openai_config.correlation_template IS 'User prompt comparing a new source with an existing event; empty uses the built-in default';
@@ -0,0 +1,17 @@
+-- Support Azure OpenAI and other custom OpenAI endpoints
+-- Empty base_url uses the public OpenAI API. A deployment name selects Azure OpenAI, where
+-- base_url is the resource endpoint and api_version the Azure API version.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS base_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS azure_deployment TEXT NOT NULL DEFAULT '';
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS azure_api_version TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE forecast_models ADD COLUMN IF NOT EXISTS base_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE forecast_models ADD COLUMN IF NOT EXISTS azure_deployment TEXT NOT NULL DEFAULT '';
+ALTER TABLE forecast_models ADD COLUMN IF NOT EXISTS azure_api_version TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN openai_config.base_url IS 'OpenAI API base URL, or the Azure resource endpoint; empty uses api.openai.com';
+COMMENT ON COLUMN openai_config.azure_deployment IS 'Azure OpenAI deployment name; set to use Azure';
+COMMENT ON COLUMN openai_config.azure_api_version IS 'Azure OpenAI api-version; empty uses the client default';
+COMMENT ON COLUMN forecast_models.base_url IS 'OpenAI API base URL, or the Azure resource endpoint; empty uses api.openai.com';
+COMMENT ON COLUMN forecast_models.azure_deployment IS 'Azure OpenAI deployment name; set to use Azure';
+COMMENT ON COLUMN forecast_models.azure_api_version IS 'Azure OpenAI api-version; empty uses the client default';
@@ -0,0 +1,6 @@
+-- Allow enrichment through OpenAI-compatible servers such as Ollama or LM Studio
+-- 'openai' covers the public API and Azure; 'openai_compatible' requires base_url (e.g.
+-- http://localhost:11434/v1), needs no real API key and accepts any model name.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS provider TEXT NOT NULL DEFAULT 'openai';
+
+COMMENT ON COLUMN openai_config.provider IS 'openai (public API or Azure) or openai_compatible (local/self-hosted server at base_url)';
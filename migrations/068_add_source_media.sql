@@ -0,0 +1,8 @@
+-- Capture attachments and outbound links alongside a source's text
+-- media holds image/video URLs from tweet attachments and RSS enclosures; external_url is the
+-- primary link the source points to, such as the article a tweet shares.
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS media TEXT[];
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS external_url TEXT;
+
+COMMENT ON COLUMN sources.media IS 'Attached image and video URLs; NULL or empty if the source has none';
+COMMENT ON COLUMN sources.external_url IS 'Primary external link the source points to; NULL if none';
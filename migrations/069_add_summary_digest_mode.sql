@@ -0,0 +1,8 @@
+-- Add a digest mode to summaries: a per-category rollup of the top events by magnitude
+-- 'narrative' (the default) sends the latest headlines to the model for a single summary;
+-- 'digest' sends the top digest_per_category events of each category for one-line takeaways.
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS mode TEXT NOT NULL DEFAULT 'narrative';
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS digest_per_category INTEGER NOT NULL DEFAULT 3;
+
+COMMENT ON COLUMN summaries.mode IS 'narrative (single summary of recent headlines) or digest (per-category rollup)';
+COMMENT ON COLUMN summaries.digest_per_category IS 'Events per category in digest mode, ranked by magnitude';
@@ -0,0 +1,11 @@
+-- Deliver scheduled summaries by email and record the outcome on each run
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS email_enabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS email_recipients TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE summary_runs ADD COLUMN IF NOT EXISTS email_status TEXT;
+ALTER TABLE summary_runs ADD COLUMN IF NOT EXISTS email_error TEXT;
+ALTER TABLE summary_runs ADD COLUMN IF NOT EXISTS email_sent_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN summaries.email_enabled IS 'Email the summary to email_recipients when a scheduled run completes';
+COMMENT ON COLUMN summary_runs.email_status IS 'sent or failed; NULL when the run was not emailed';
+COMMENT ON COLUMN summary_runs.email_error IS 'Why email delivery failed';
@@ -0,0 +1,7 @@
+-- Add an output format to summaries; results keep the format they were generated in
+-- so the API can render markdown to HTML and Twitter can get plain text
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS format TEXT NOT NULL DEFAULT 'text';
+ALTER TABLE summary_results ADD COLUMN IF NOT EXISTS format TEXT NOT NULL DEFAULT 'text';
+
+COMMENT ON COLUMN summaries.format IS 'text (plain) or markdown (with event links and magnitudes)';
+COMMENT ON COLUMN summary_results.format IS 'Format of summary_text: text or raw markdown';
@@ -0,0 +1,4 @@
+-- Record which events each summary run was built from, in prompt order, so summaries can cite them
+ALTER TABLE summary_runs ADD COLUMN IF NOT EXISTS event_ids TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN summary_runs.event_ids IS 'Events included in the prompt, in order; deleted events drop out of citations';
@@ -0,0 +1,4 @@
+-- Make the minimum number of sources for publication configurable at runtime
+ALTER TABLE threshold_config ADD COLUMN IF NOT EXISTS min_sources INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN threshold_config.min_sources IS 'Distinct sources an event needs before it can be published';
@@ -0,0 +1,6 @@
+-- Thresholds for flagging fresh, high-magnitude events as breaking
+ALTER TABLE threshold_config ADD COLUMN IF NOT EXISTS breaking_min_magnitude DECIMAL(3,1) NOT NULL DEFAULT 7.0;
+ALTER TABLE threshold_config ADD COLUMN IF NOT EXISTS breaking_window_hours INTEGER NOT NULL DEFAULT 6;
+
+COMMENT ON COLUMN threshold_config.breaking_min_magnitude IS 'Magnitude an event needs to be flagged as breaking';
+COMMENT ON COLUMN threshold_config.breaking_window_hours IS 'How recent a breaking event''s timestamp must be, in hours';
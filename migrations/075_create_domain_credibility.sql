@@ -0,0 +1,10 @@
+-- Domain credibility scores assessed by the LLM
+-- Shared by every instance and kept across restarts. Rows older than the cache TTL (24h) are
+-- treated as expired and re-assessed; the next assessment overwrites them.
+CREATE TABLE IF NOT EXISTS domain_credibility (
+  domain TEXT PRIMARY KEY,
+  score DOUBLE PRECISION NOT NULL,
+  computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_credibility_computed_at ON domain_credibility(computed_at);
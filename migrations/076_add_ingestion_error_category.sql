@@ -0,0 +1,8 @@
+-- Classify ingestion errors by failure mode
+-- error_type says which stage failed; category says why (timeout, dns, auth, http_5xx, parse, ...).
+-- Rows logged before classification existed keep the 'unknown' default.
+ALTER TABLE ingestion_errors ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT 'unknown';
+
+CREATE INDEX IF NOT EXISTS idx_ingestion_errors_category_platform ON ingestion_errors(category, platform);
+
+COMMENT ON COLUMN ingestion_errors.category IS 'Failure mode classified from the underlying error: timeout, dns, connection, auth, rate_limit, http_4xx, http_5xx, parse or unknown';
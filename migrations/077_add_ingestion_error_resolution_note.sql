@@ -0,0 +1,8 @@
+-- Record why an ingestion error was resolved
+-- Transient failures (timeouts, DNS, connection, rate limits, 5xx) are resolved automatically once
+-- the same feed or account fetches successfully; the note says when. Manual resolutions leave it NULL.
+ALTER TABLE ingestion_errors ADD COLUMN IF NOT EXISTS resolution_note TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_ingestion_errors_open_target ON ingestion_errors(platform, url) WHERE resolved = FALSE;
+
+COMMENT ON COLUMN ingestion_errors.resolution_note IS 'Reason for an automatic resolution, e.g. a later successful fetch; NULL when resolved manually';
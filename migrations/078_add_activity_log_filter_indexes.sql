@@ -0,0 +1,6 @@
+-- Composite indexes for filtered activity log paging
+-- The admin view filters by type and/or platform over a time range, newest first; these let
+-- Postgres walk the matching rows in timestamp order instead of sorting the whole table.
+CREATE INDEX IF NOT EXISTS idx_activity_logs_type_timestamp ON activity_logs(activity_type, timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_activity_logs_platform_timestamp ON activity_logs(platform, timestamp DESC);
@@ -0,0 +1,6 @@
+-- Composite indexes for the windowed source duplicate checks
+-- New sources are compared only against sources created within the dedup window, so these let
+-- Postgres check the most recent matches instead of every source ever stored with the same hash.
+CREATE INDEX IF NOT EXISTS idx_sources_content_hash_created_at ON sources(content_hash, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_sources_title_url_created_at ON sources(title, url, created_at DESC);
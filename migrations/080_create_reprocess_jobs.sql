@@ -0,0 +1,24 @@
+-- Bulk reprocessing jobs, which re-enrich sources after a model or prompt change
+-- A job walks the matching sources in (created_at, id) order and resets one batch at a time to
+-- pending, only while the enrichment backlog is small, so the whole corpus is never claimed at once.
+CREATE TABLE IF NOT EXISTS reprocess_jobs (
+  id UUID PRIMARY KEY,
+  status TEXT NOT NULL DEFAULT 'running',   -- running, completed, cancelled
+  platform TEXT,                            -- Source type filter; NULL matches all
+  since TIMESTAMPTZ,                        -- Sources published at or after this time
+  until TIMESTAMPTZ,                        -- Sources published before this time
+  batch_size INTEGER NOT NULL,
+  total INTEGER NOT NULL DEFAULT 0,         -- Matching sources when the job was created
+  processed INTEGER NOT NULL DEFAULT 0,
+  queued INTEGER NOT NULL DEFAULT 0,
+  cursor_created_at TIMESTAMPTZ,            -- Last source processed; NULL before the first batch
+  cursor_id TEXT,
+  created_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_reprocess_jobs_status ON reprocess_jobs(status, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_sources_created_at_id ON sources(created_at, id);
@@ -0,0 +1,16 @@
+-- Event embeddings for semantic similarity search (pgvector)
+-- New events are embedded from their title and summary and compared with the nearest recent
+-- events before correlation, instead of asking the model about every recent event. Databases
+-- without the vector extension skip this; events then fall back to title deduplication.
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+    CREATE EXTENSION IF NOT EXISTS vector;
+    ALTER TABLE events ADD COLUMN IF NOT EXISTS embedding vector(1536);
+    CREATE INDEX IF NOT EXISTS idx_events_embedding ON events USING hnsw (embedding vector_cosine_ops);
+    COMMENT ON COLUMN events.embedding IS 'Embedding of title and summary, for nearest-neighbour search';
+  ELSE
+    RAISE NOTICE 'pgvector extension not available; skipping event embeddings';
+  END IF;
+END
+$$;
@@ -0,0 +1,8 @@
+-- Enrichment model fallback chain
+-- fallback_models is tried in order when the primary model fails hard or is over quota.
+-- enrichment_model records which model actually enriched each source and created each event.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS fallback_models TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS enrichment_model TEXT;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS enrichment_model TEXT;
@@ -0,0 +1,16 @@
+-- Add completion webhooks to forecasts
+-- When a run completes, a signed POST with the aggregated result is sent to the webhook.
+-- Optional thresholds restrict notifications to runs whose headline value (P50, point
+-- estimate or probability) is at or above / at or below a value
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS completion_webhook_url TEXT,    -- NULL disables completion notifications
+  ADD COLUMN IF NOT EXISTS completion_webhook_secret TEXT, -- HMAC-SHA256 signing key (NULL = unsigned)
+  ADD COLUMN IF NOT EXISTS completion_webhook_above REAL,  -- Notify only when the headline value >= this
+  ADD COLUMN IF NOT EXISTS completion_webhook_below REAL;  -- Notify only when the headline value <= this
+
+-- Comments
+COMMENT ON COLUMN forecasts.completion_webhook_url IS 'Optional webhook URL sent a signed POST with the result of each completed run';
+COMMENT ON COLUMN forecasts.completion_webhook_secret IS 'Key used to sign completion webhooks with HMAC-SHA256 (NULL = unsigned)';
+COMMENT ON COLUMN forecasts.completion_webhook_above IS 'When set, notify only runs whose headline value is at or above this value';
+COMMENT ON COLUMN forecasts.completion_webhook_below IS 'When set, notify only runs whose headline value is at or below this value';
@@ -0,0 +1,9 @@
+-- Add headline selection modes to forecasts
+-- 'recent' sends the newest headlines (the original behavior); 'relevant' picks the headlines
+-- most related to the proposition from a larger pool of recent candidates
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS headline_selection TEXT NOT NULL DEFAULT 'recent';
+
+-- Comments
+COMMENT ON COLUMN forecasts.headline_selection IS 'How headlines are chosen for a run: recent (newest first) or relevant (ranked by similarity to the proposition)';
@@ -0,0 +1,9 @@
+-- Add optional event summaries to forecast prompts
+-- When enabled, each headline in a run's snapshot carries the event summary (truncated), and
+-- summaries are added to the prompt as far as the model's context window allows
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS include_summaries BOOLEAN NOT NULL DEFAULT false;
+
+-- Comments
+COMMENT ON COLUMN forecasts.include_summaries IS 'Whether forecast prompts include event summaries under each headline (false = titles only)';
@@ -0,0 +1,7 @@
+-- Optionally require sources from several platforms or sites before an event is auto-published,
+-- so a burst of posts from one platform can't publish on its own. 0 disables each rule.
+ALTER TABLE threshold_config ADD COLUMN IF NOT EXISTS min_source_types INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE threshold_config ADD COLUMN IF NOT EXISTS min_source_domains INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN threshold_config.min_source_types IS 'Distinct source types (platforms) an event needs before it can be published; 0 disables';
+COMMENT ON COLUMN threshold_config.min_source_domains IS 'Distinct source URL domains an event needs before it can be published; 0 disables';
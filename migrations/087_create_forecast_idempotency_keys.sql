@@ -0,0 +1,12 @@
+-- Idempotency keys sent with forecast execute requests, so a retried request returns the run the
+-- first one started instead of starting (and paying for) another. Keys only matter for a short
+-- window; expired ones are deleted when new keys are stored.
+CREATE TABLE IF NOT EXISTS forecast_idempotency_keys (
+  forecast_id TEXT NOT NULL REFERENCES forecasts(id) ON DELETE CASCADE,
+  idempotency_key TEXT NOT NULL,
+  run_id TEXT NOT NULL REFERENCES forecast_runs(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (forecast_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_forecast_idempotency_keys_created_at ON forecast_idempotency_keys(created_at);
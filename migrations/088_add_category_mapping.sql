@@ -0,0 +1,4 @@
+-- Category taxonomy mapping
+-- Maps raw categories returned by the enrichment model (e.g. "security") to one of the stored
+-- event categories. Applied after enrichment; an empty mapping keeps the model's category.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS category_mapping JSONB NOT NULL DEFAULT '{}';
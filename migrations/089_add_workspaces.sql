@@ -0,0 +1,24 @@
+-- Workspaces: isolated feed sets, thresholds and forecasts in one deployment
+-- Every existing row belongs to the 'default' workspace, so single-tenant deployments are unaffected.
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE forecasts ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE tracked_accounts ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE threshold_config ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS idx_sources_workspace ON sources (workspace_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_events_workspace ON events (workspace_id, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_forecasts_workspace ON forecasts (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_threshold_config_workspace ON threshold_config (workspace_id, id DESC);
+
+-- Workspaces may track the same feeds and store the same articles
+ALTER TABLE tracked_accounts DROP CONSTRAINT IF EXISTS tracked_accounts_platform_account_identifier_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tracked_accounts_workspace_identifier
+ON tracked_accounts (workspace_id, platform, account_identifier);
+
+DROP INDEX IF EXISTS idx_sources_url_unique;
+CREATE UNIQUE INDEX idx_sources_url_unique
+ON sources (workspace_id, url)
+WHERE url IS NOT NULL AND url != '';
@@ -0,0 +1,4 @@
+-- Free-form tags on tracked accounts, so feeds can be grouped and toggled together
+ALTER TABLE tracked_accounts ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_tracked_accounts_tags ON tracked_accounts USING GIN (tags);
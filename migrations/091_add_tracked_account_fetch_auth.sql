@@ -0,0 +1,2 @@
+-- Extra request headers and basic auth credentials for private feeds
+ALTER TABLE tracked_accounts ADD COLUMN IF NOT EXISTS fetch_auth JSONB;
@@ -0,0 +1,11 @@
+-- Enrichment prompt A/B test
+-- prompt_b_fraction of sources (0 turns the test off) are enriched with the B system prompt and
+-- analysis template instead of the configured ones; an empty B prompt falls back to A's.
+-- prompt_variant records which prompt created each event while a test is running.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS prompt_b_system_prompt TEXT NOT NULL DEFAULT '';
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS prompt_b_analysis_template TEXT NOT NULL DEFAULT '';
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS prompt_b_fraction REAL NOT NULL DEFAULT 0;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS prompt_variant TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_events_prompt_variant ON events(prompt_variant) WHERE prompt_variant IS NOT NULL;
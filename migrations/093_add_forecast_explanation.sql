@@ -0,0 +1,13 @@
+-- Add optional explanations of forecast results
+-- When enabled, a completed run makes one more model call that explains the aggregated estimate
+-- given the run's headlines, stored apart from the per-model reasoning
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS explain_result BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE forecast_results
+  ADD COLUMN IF NOT EXISTS explanation TEXT;
+
+-- Comments
+COMMENT ON COLUMN forecasts.explain_result IS 'Whether completed runs ask a model to explain the aggregated result';
+COMMENT ON COLUMN forecast_results.explanation IS 'Model-written explanation of the aggregated result (NULL when not requested or the call failed)';
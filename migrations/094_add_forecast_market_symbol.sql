@@ -0,0 +1,10 @@
+-- Map forecasts to a market symbol for comparison with options-implied distributions
+-- A forecast whose proposition is the percent change of SPY, GLD, IBIT, TLT, VNQ or USO can name
+-- that symbol; the market comparison endpoint then derives the return distribution priced by the
+-- symbol's options and sets it next to the model ensemble's latest result
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS market_symbol TEXT;
+
+-- Comments
+COMMENT ON COLUMN forecasts.market_symbol IS 'Symbol whose percent return the forecast predicts, for comparison with options-implied probabilities (NULL = none)';
@@ -0,0 +1,11 @@
+-- Archive forecasts instead of deleting them
+-- Deleting a forecast from the admin UI now archives it: it leaves forecast lists, public pages
+-- and the scheduler but keeps its runs, responses and results until restored or purged
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_forecasts_archived_at ON forecasts(archived_at) WHERE archived_at IS NULL;
+
+-- Comments
+COMMENT ON COLUMN forecasts.archived_at IS 'When the forecast was archived (NULL = live); archived forecasts keep their history but are hidden and never run';
@@ -0,0 +1,5 @@
+-- Index forecast runs by forecast and run time
+-- Serves the paged run history of a forecast (newest first) and its total count; forecasts that
+-- run every few minutes build up long histories
+
+CREATE INDEX IF NOT EXISTS idx_forecast_runs_forecast_id_run_at ON forecast_runs(forecast_id, run_at DESC);
@@ -0,0 +1,28 @@
+-- Store each forecast sample as a row
+-- A model response's prediction is the average of its samples; the samples themselves were only
+-- kept as JSON in raw_response. With FORECAST_STORE_SAMPLES=true each parsed sample is also
+-- written here, for within-model variance and calibration analysis. Off by default: a run stores
+-- up to models x iterations rows.
+
+CREATE TABLE IF NOT EXISTS forecast_samples (
+  id BIGSERIAL PRIMARY KEY,
+  response_id TEXT NOT NULL REFERENCES forecast_model_responses(id) ON DELETE CASCADE,
+  run_id TEXT NOT NULL REFERENCES forecast_runs(id) ON DELETE CASCADE,
+  model_id TEXT NOT NULL,
+  sample_index INTEGER NOT NULL,
+  p10 REAL,
+  p25 REAL,
+  p50 REAL,
+  p75 REAL,
+  p90 REAL,
+  value REAL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_forecast_samples_run_id ON forecast_samples(run_id);
+CREATE INDEX IF NOT EXISTS idx_forecast_samples_response_id ON forecast_samples(response_id);
+
+-- Comments
+COMMENT ON TABLE forecast_samples IS 'Individual parsed samples behind each forecast model response (written when FORECAST_STORE_SAMPLES is on)';
+COMMENT ON COLUMN forecast_samples.sample_index IS 'Position of the sample among the model''s samples in the run, from 0; failed or unparseable samples leave gaps';
+COMMENT ON COLUMN forecast_samples.value IS 'Point estimate or probability (0-100); NULL for percentile forecasts';
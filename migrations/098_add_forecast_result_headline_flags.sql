@@ -0,0 +1,12 @@
+-- Flag forecast results built on few or widened headlines
+-- When a forecast's categories match fewer than FORECAST_MIN_HEADLINES recent events, the run is
+-- topped up with events from any category; if there still aren't enough, the result is marked
+-- as resting on sparse data instead of silently looking like any other run
+
+ALTER TABLE forecast_results
+  ADD COLUMN IF NOT EXISTS headlines_widened BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS sparse_headlines BOOLEAN NOT NULL DEFAULT false;
+
+-- Comments
+COMMENT ON COLUMN forecast_results.headlines_widened IS 'The run included headlines outside the forecast''s categories because too few matched them';
+COMMENT ON COLUMN forecast_results.sparse_headlines IS 'The run had fewer headlines than the configured minimum, even after widening';
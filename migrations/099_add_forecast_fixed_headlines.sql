@@ -0,0 +1,9 @@
+-- Pin a forecast to a fixed set of headlines
+-- A forecast with fixed headlines sends exactly those on every run instead of fetching the latest
+-- events, so re-runs differ only by model variance and historical headline sets can be backtested
+
+ALTER TABLE forecasts
+  ADD COLUMN IF NOT EXISTS fixed_headlines JSONB;
+
+-- Comments
+COMMENT ON COLUMN forecasts.fixed_headlines IS 'Headlines every run uses instead of fetching events, in the forecast_runs.headlines_snapshot format (NULL = fetch the latest)';
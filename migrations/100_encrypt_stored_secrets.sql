@@ -0,0 +1,20 @@
+-- Document the encrypted format of stored credentials
+-- This migration changes no data. Encrypting needs SECRETS_ENCRYPTION_KEY, which the database never
+-- sees, so the server itself encrypts every credential still in plaintext on each start, right
+-- after migrations run, and refuses to start if that fails. Encrypted values are stored as
+-- "enc:v1:<base64 nonce||ciphertext>" (AES-256-GCM); values without the prefix are plaintext.
+
+-- Comments
+COMMENT ON COLUMN forecast_models.api_key IS 'Provider API key; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN parent_forecast_models.api_key IS 'Provider API key; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN strategy_models.api_key IS 'Provider API key; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN openai_config.api_key IS 'Enrichment provider API key; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN forecasts.completion_webhook_secret IS 'HMAC-SHA256 signing key (NULL = unsigned); "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY';
+COMMENT ON COLUMN twitter_config.api_key IS 'Twitter credential; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN twitter_config.api_secret IS 'Twitter credential; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN twitter_config.access_token IS 'Twitter credential; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN twitter_config.access_token_secret IS 'Twitter credential; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN twitter_config.bearer_token IS 'Twitter credential; "enc:v1:" prefix = encrypted with SECRETS_ENCRYPTION_KEY, otherwise plaintext';
+COMMENT ON COLUMN connector_config.config IS 'Connector settings; values of keys containing token, key or secret are encrypted with SECRETS_ENCRYPTION_KEY ("enc:v1:" prefix)';
+COMMENT ON COLUMN summaries.models IS 'Summary models; each api_key is encrypted with SECRETS_ENCRYPTION_KEY ("enc:v1:" prefix)';
+COMMENT ON COLUMN tracked_accounts.fetch_auth IS 'Feed fetch credentials; the password and header values are encrypted with SECRETS_ENCRYPTION_KEY ("enc:v1:" prefix)';
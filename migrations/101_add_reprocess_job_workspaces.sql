@@ -0,0 +1,5 @@
+-- Reprocess jobs belong to the workspace they were started in and only reset that workspace's sources.
+-- Existing jobs were started before workspaces could be chosen, so they belong to 'default'.
+ALTER TABLE reprocess_jobs ADD COLUMN IF NOT EXISTS workspace_id TEXT NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS idx_reprocess_jobs_workspace ON reprocess_jobs (workspace_id, created_at DESC);
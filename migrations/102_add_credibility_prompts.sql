@@ -0,0 +1,7 @@
+-- Add configurable prompts for source credibility assessment
+-- Empty uses the built-in prompt; see enrichment.ValidatePromptTemplate for required placeholders.
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS credibility_system_prompt TEXT NOT NULL DEFAULT '';
+ALTER TABLE openai_config ADD COLUMN IF NOT EXISTS credibility_template TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN openai_config.credibility_system_prompt IS 'System prompt for scoring source credibility; empty uses the built-in default';
+COMMENT ON COLUMN openai_config.credibility_template IS 'User prompt scoring a source URL for credibility; empty uses the built-in default';
@@ -0,0 +1,5 @@
+-- Executing forecast runs refresh heartbeat_at periodically, so a run abandoned by a restart can be
+-- told apart from one still executing on another instance. Runs without a heartbeat fall back to run_at.
+ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS heartbeat_at TIMESTAMP;
+
+COMMENT ON COLUMN forecast_runs.heartbeat_at IS 'Last time the instance executing the run reported it alive (NULL = never, use run_at)';
@@ -0,0 +1,37 @@
+-- after_id/before_id cursors page events by cursor_seq. It is assigned when an event is stored and
+-- again whenever its status changes, so an event stored earlier but published after a poller's
+-- cursor, e.g. once corroboration lifts it over the thresholds, still comes after that cursor.
+CREATE SEQUENCE IF NOT EXISTS events_cursor_seq;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cursor_seq BIGINT;
+
+-- Existing events keep the order they were stored in
+UPDATE events e
+SET cursor_seq = ordered.seq
+FROM (SELECT id, row_number() OVER (ORDER BY created_at, id) AS seq FROM events) ordered
+WHERE e.id = ordered.id AND e.cursor_seq IS NULL;
+
+SELECT setval('events_cursor_seq', COALESCE((SELECT MAX(cursor_seq) FROM events), 0) + 1, false);
+
+ALTER TABLE events ALTER COLUMN cursor_seq SET DEFAULT nextval('events_cursor_seq');
+ALTER TABLE events ALTER COLUMN cursor_seq SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_cursor_seq ON events (cursor_seq);
+
+CREATE OR REPLACE FUNCTION bump_event_cursor_seq()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status IS DISTINCT FROM OLD.status THEN
+        NEW.cursor_seq = nextval('events_cursor_seq');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_events_cursor_seq ON events;
+CREATE TRIGGER trigger_events_cursor_seq
+    BEFORE UPDATE OF status ON events
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_event_cursor_seq();
+
+COMMENT ON COLUMN events.cursor_seq IS 'Position in after_id/before_id cursor order; reassigned whenever the status changes';
@@ -0,0 +1,5 @@
+-- Execute requests reserve their idempotency key before starting the run, so a concurrent request
+-- with the same key waits for that run instead of racing it. A reserved key has no run yet.
+ALTER TABLE forecast_idempotency_keys ALTER COLUMN run_id DROP NOT NULL;
+
+COMMENT ON COLUMN forecast_idempotency_keys.run_id IS 'Run started with the key (NULL = reserved, run not started yet)';
@@ -0,0 +1,15 @@
+-- A forecast has at most one run in progress, so the scheduler and an admin, or two instances,
+-- can't both start one. Duplicates left by the earlier check-then-create race are failed first,
+-- keeping the newest.
+UPDATE forecast_runs fr
+SET status = 'failed', error_message = 'superseded by a concurrent run', completed_at = NOW()
+WHERE fr.status IN ('pending', 'running')
+  AND EXISTS (
+    SELECT 1 FROM forecast_runs newer
+    WHERE newer.forecast_id = fr.forecast_id
+      AND newer.status IN ('pending', 'running')
+      AND (newer.run_at, newer.id) > (fr.run_at, fr.id)
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_forecast_runs_one_in_progress
+  ON forecast_runs(forecast_id) WHERE status IN ('pending', 'running');
@@ -11,6 +11,7 @@ import (
 
 	"github.com/STRATINT/stratint/internal/database"
 	"github.com/STRATINT/stratint/internal/enrichment"
+	"github.com/STRATINT/stratint/internal/eventmanager"
 	"github.com/STRATINT/stratint/internal/inference"
 	"github.com/STRATINT/stratint/internal/models"
 	_ "github.com/lib/pq"
@@ -1275,6 +1276,97 @@ func testEconomicEvent(t *testing.T, estimator *enrichment.MagnitudeEstimator) {
 }
 
 // Helper function to hash content (simplified)
+// TestLifecycleReplay replays a fixed set of sources through the lifecycle under two threshold
+// configs, pinning down which events publish and why
+func TestLifecycleReplay(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	manager := eventmanager.NewEventLifecycleManager(nil, nil, nil, nil, nil, nil, logger, eventmanager.DefaultLifecycleConfig())
+
+	publishedAt := time.Now().Add(-time.Hour)
+	strike := "Russian troops launched a military strike near Kharkiv on Tuesday, regional officials said."
+	sources := []models.Source{
+		{ID: "replay-tweet", Type: models.SourceTypeTwitter, URL: "https://x.com/acct/status/1", RawContent: strike,
+			ContentHash: hashContent(strike), Credibility: 0.5, PublishedAt: publishedAt},
+		// Same story from a news site: same content hash, so it merges into the tweet's event
+		{ID: "replay-news", Type: models.SourceTypeNewsMedia, URL: "https://www.reuters.com/world/kharkiv", RawContent: strike,
+			ContentHash: hashContent(strike), Credibility: 0.9, PublishedAt: publishedAt},
+		{ID: "replay-blog", Type: models.SourceTypeBlog, URL: "https://example-blog.com/post", RawContent: "Local blog reports a cyber attack hit a regional power utility overnight.",
+			ContentHash: hashContent("blog"), Credibility: 0.4, PublishedAt: publishedAt},
+	}
+
+	scenarios := []struct {
+		name       string
+		thresholds models.ThresholdConfig
+		want       []eventmanager.ReplayDecision
+	}{
+		{
+			name:       "permissive",
+			thresholds: models.ThresholdConfig{MinSources: 1},
+			want: []eventmanager.ReplayDecision{
+				{SourceID: "replay-tweet", Status: models.EventStatusPublished},
+				{SourceID: "replay-news", Status: models.EventStatusPublished, Merged: true},
+				{SourceID: "replay-blog", Status: models.EventStatusPublished},
+			},
+		},
+		{
+			name:       "two source types",
+			thresholds: models.ThresholdConfig{MinSources: 1, MinSourceTypes: 2},
+			want: []eventmanager.ReplayDecision{
+				{SourceID: "replay-tweet", Status: models.EventStatusRejected, Reason: "distinct source types 1 < 2"},
+				// The news source corroborates the tweet, promoting the merged event
+				{SourceID: "replay-news", Status: models.EventStatusPublished, Merged: true},
+				{SourceID: "replay-blog", Status: models.EventStatusRejected, Reason: "distinct source types 1 < 2"},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		start := time.Now()
+		result, err := manager.Replay(ctx, sources, scenario.thresholds)
+
+		passed := err == nil && len(result.Decisions) == len(scenario.want) && len(result.Events) == 2
+		var mismatches []string
+		if err == nil {
+			for i, want := range scenario.want {
+				if i >= len(result.Decisions) {
+					break
+				}
+				got := result.Decisions[i]
+				if got.SourceID != want.SourceID || got.Status != want.Status || got.Merged != want.Merged ||
+					(want.Reason != "" && got.Reason != want.Reason) {
+					passed = false
+					mismatches = append(mismatches, fmt.Sprintf("%s: got %s merged=%v %q, want %s merged=%v %q",
+						want.SourceID, got.Status, got.Merged, got.Reason, want.Status, want.Merged, want.Reason))
+				}
+			}
+		}
+
+		addResult(TestResult{
+			TestName:        "Lifecycle Replay - " + scenario.name,
+			Category:        "Lifecycle",
+			Description:     "Replaying fixed sources with the mock enricher gives the expected publish decisions",
+			Passed:          passed,
+			ExpectedOutcome: fmt.Sprintf("%d decisions matching the expected statuses, 2 events", len(scenario.want)),
+			ActualOutcome:   fmt.Sprintf("%d decisions, %d events, mismatches: %v", len(result.Decisions), len(result.Events), mismatches),
+			Details: map[string]interface{}{
+				"published": result.EventsPublished,
+				"rejected":  result.EventsRejected,
+				"errors":    result.ErrorCount,
+			},
+			Duration: time.Since(start),
+		})
+
+		if err != nil {
+			t.Fatalf("%s: Replay failed: %v", scenario.name, err)
+		}
+		if !passed {
+			t.Errorf("%s: unexpected replay result: %d decisions, %d events, mismatches %v",
+				scenario.name, len(result.Decisions), len(result.Events), mismatches)
+		}
+	}
+}
+
 func hashContent(content string) string {
 	hash := uint32(0)
 	for _, c := range content {
@@ -20,12 +20,18 @@ export function ActivityLogsTab() {
   const [logs, setLogs] = useState<ActivityLog[]>([]);
   const [loading, setLoading] = useState(true);
   const [activityFilter, setActivityFilter] = useState<string>('');
+  const [windowFilter, setWindowFilter] = useState<string>('');
+  const [total, setTotal] = useState(0);
+  const [page, setPage] = useState(0);
+  const pageSize = 200;
 
   const fetchLogs = async () => {
     try {
       const params = new URLSearchParams();
-      params.append('limit', '200');
+      params.append('limit', String(pageSize));
+      params.append('offset', String(page * pageSize));
       if (activityFilter) params.append('activity_type', activityFilter);
+      if (windowFilter) params.append('window', windowFilter);
 
       const response = await fetch(`${API_BASE_URL}/api/activity-logs?${params}`, {
         headers: getAuthHeaders(),
@@ -33,6 +39,7 @@ export function ActivityLogsTab() {
       if (!response.ok) throw new Error('Failed to fetch activity logs');
       const data = await response.json();
       setLogs(data.logs || []);
+      setTotal(data.total || 0);
       setLoading(false);
     } catch (err) {
       console.error('Error fetching activity logs:', err);
@@ -45,7 +52,11 @@ export function ActivityLogsTab() {
     // Refresh every 5 seconds
     const interval = setInterval(fetchLogs, 5000);
     return () => clearInterval(interval);
-  }, [activityFilter]);
+  }, [activityFilter, windowFilter, page]);
+
+  useEffect(() => {
+    setPage(0);
+  }, [activityFilter, windowFilter]);
 
   const formatDuration = (ms?: number) => {
     if (!ms) return '-';
@@ -72,7 +83,7 @@ export function ActivityLogsTab() {
             <Activity className="w-5 h-5 text-terminal" />
             <span className="text-xs font-mono text-smoke font-medium">TOTAL ACTIVITIES</span>
           </div>
-          <span className="text-3xl font-mono font-bold text-chalk">{logs.length}</span>
+          <span className="text-3xl font-mono font-bold text-chalk">{total}</span>
         </div>
       </div>
 
@@ -118,6 +129,16 @@ export function ActivityLogsTab() {
         >
           PLAYWRIGHT
         </button>
+        <select
+          value={windowFilter}
+          onChange={(e) => setWindowFilter(e.target.value)}
+          className="px-4 py-2 border border-steel bg-void text-fog font-mono text-xs font-bold uppercase"
+        >
+          <option value="">ALL TIME</option>
+          <option value="1h">LAST HOUR</option>
+          <option value="24h">LAST 24 HOURS</option>
+          <option value="7d">LAST 7 DAYS</option>
+        </select>
       </div>
 
       {/* Activity Logs Table */}
@@ -190,8 +211,26 @@ export function ActivityLogsTab() {
         </div>
       )}
 
-      <div className="text-xs font-mono text-smoke">
-        SHOWING {logs.length} ACTIVITY LOG(S)
+      <div className="flex items-center justify-between text-xs font-mono text-smoke">
+        <span>
+          SHOWING {logs.length === 0 ? 0 : page * pageSize + 1}-{page * pageSize + logs.length} OF {total} ACTIVITY LOG(S)
+        </span>
+        <div className="flex gap-2">
+          <button
+            onClick={() => setPage(page - 1)}
+            disabled={page === 0}
+            className="px-3 py-1 border border-steel text-fog hover:border-iron hover:text-chalk disabled:opacity-40"
+          >
+            PREV
+          </button>
+          <button
+            onClick={() => setPage(page + 1)}
+            disabled={(page + 1) * pageSize >= total}
+            className="px-3 py-1 border border-steel text-fog hover:border-iron hover:text-chalk disabled:opacity-40"
+          >
+            NEXT
+          </button>
+        </div>
       </div>
     </div>
   );
@@ -2,6 +2,10 @@ import { useState, useEffect } from 'react';
 import { AdminLogin } from './AdminLogin';
 import { AdminDashboard } from './AdminDashboard';
 import { API_BASE_URL } from '../utils/api';
+import { clearAuthToken, clearRefreshToken, getRefreshToken, refreshAuthToken, setAuthToken, setRefreshToken } from '../utils/auth';
+
+// Access tokens last an hour; rotate them well before they expire
+const REFRESH_INTERVAL_MS = 45 * 60 * 1000;
 
 export function Admin() {
   const [isAuthenticated, setIsAuthenticated] = useState(false);
@@ -17,6 +21,21 @@ export function Admin() {
     }
   }, []);
 
+  useEffect(() => {
+    if (!isAuthenticated) {
+      return;
+    }
+
+    const interval = setInterval(async () => {
+      if (!(await refreshAuthToken(API_BASE_URL))) {
+        clearAuthToken();
+        setIsAuthenticated(false);
+      }
+    }, REFRESH_INTERVAL_MS);
+
+    return () => clearInterval(interval);
+  }, [isAuthenticated]);
+
   const validateToken = async (token: string) => {
     try {
       const response = await fetch(`${API_BASE_URL}/api/auth/validate`, {
@@ -27,13 +46,16 @@ export function Admin() {
 
       if (response.ok) {
         setIsAuthenticated(true);
+      } else if (await refreshAuthToken(API_BASE_URL)) {
+        // Access token expired but the session is still valid
+        setIsAuthenticated(true);
       } else {
         // Token invalid, remove it
-        localStorage.removeItem('admin_token');
+        clearAuthToken();
       }
     } catch (error) {
       console.error('Token validation failed:', error);
-      localStorage.removeItem('admin_token');
+      clearAuthToken();
     } finally {
       setIsLoading(false);
     }
@@ -51,7 +73,8 @@ export function Admin() {
 
       if (response.ok) {
         const data = await response.json();
-        localStorage.setItem('admin_token', data.token);
+        setAuthToken(data.token);
+        setRefreshToken(data.refresh_token);
         setIsAuthenticated(true);
         return { success: true };
       } else {
@@ -64,8 +87,21 @@ export function Admin() {
   };
 
   const handleLogout = () => {
+    const refreshToken = getRefreshToken();
+    if (refreshToken) {
+      // Revoke the session server-side; local state is cleared regardless
+      fetch(`${API_BASE_URL}/api/auth/logout`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ refresh_token: refreshToken }),
+      }).catch((error) => console.error('Logout failed:', error));
+    }
+
     setIsAuthenticated(false);
-    localStorage.removeItem('admin_token');
+    clearAuthToken();
+    clearRefreshToken();
   };
 
   if (isLoading) {
@@ -33,6 +33,7 @@ import { InferenceLogsTab } from './InferenceLogsTab';
 import { formatDateTime } from '../utils/dateFormat';
 import { API_BASE_URL } from '../utils/api';
 import { getAuthHeaders } from '../utils/auth';
+import type { Event } from '../types';
 
 interface AdminDashboardProps {
   onLogout: () => void;
@@ -640,7 +641,7 @@ function EventsTab() {
                       {event.confidence.score.toFixed(2)}
                     </td>
                     <td className="px-2 md:px-4 py-3">
-                      <span className={`px-2 py-1 text-xs font-mono font-bold border uppercase ${
+                      <span title={rejectionTitle(event)} className={`px-2 py-1 text-xs font-mono font-bold border uppercase ${
                         event.status === 'published' ? 'border-terminal text-terminal' :
                         event.status === 'pending' ? 'border-warning text-warning' :
                         event.status === 'enriched' ? 'border-electric text-electric' :
@@ -697,6 +698,25 @@ function EventsTab() {
   );
 }
 
+// Tooltip explaining why an event was rejected and the thresholds it was judged against
+function rejectionTitle(event: Event): string | undefined {
+  if (event.status !== 'rejected' || !event.rejection_reason) {
+    return undefined;
+  }
+  const t = event.rejection_thresholds;
+  if (!t) {
+    return event.rejection_reason;
+  }
+  let thresholds = `confidence >= ${t.min_confidence}, magnitude >= ${t.min_magnitude}, sources >= ${t.min_sources}, max age ${t.max_source_age_hours || 'none'}h`;
+  if (t.min_source_types) {
+    thresholds += `, source types >= ${t.min_source_types}`;
+  }
+  if (t.min_source_domains) {
+    thresholds += `, source domains >= ${t.min_source_domains}`;
+  }
+  return `${event.rejection_reason}\nThresholds: ${thresholds}`;
+}
+
 interface Connector {
   id: string;
   name: string;
@@ -837,6 +857,11 @@ function ThresholdsTab() {
   const [minConfidence, setMinConfidence] = useState(0.1);
   const [minMagnitude, setMinMagnitude] = useState(0.0);
   const [maxSourceAgeHours, setMaxSourceAgeHours] = useState(0);
+  const [minSources, setMinSources] = useState(1);
+  const [minSourceTypes, setMinSourceTypes] = useState(0);
+  const [minSourceDomains, setMinSourceDomains] = useState(0);
+  const [breakingMinMagnitude, setBreakingMinMagnitude] = useState(7.0);
+  const [breakingWindowHours, setBreakingWindowHours] = useState(6);
   const [saving, setSaving] = useState(false);
   const [message, setMessage] = useState<{ text: string; type: 'success' | 'error' } | null>(null);
 
@@ -852,6 +877,11 @@ function ThresholdsTab() {
         setMinConfidence(data.min_confidence);
         setMinMagnitude(data.min_magnitude);
         setMaxSourceAgeHours(data.max_source_age_hours || 0);
+        setMinSources(data.min_sources || 1);
+        setMinSourceTypes(data.min_source_types || 0);
+        setMinSourceDomains(data.min_source_domains || 0);
+        setBreakingMinMagnitude(data.breaking_min_magnitude || 7.0);
+        setBreakingWindowHours(data.breaking_window_hours || 6);
       } catch (err) {
         console.error('Error fetching thresholds:', err);
       }
@@ -871,6 +901,11 @@ function ThresholdsTab() {
           min_confidence: minConfidence,
           min_magnitude: minMagnitude,
           max_source_age_hours: maxSourceAgeHours,
+          min_sources: minSources,
+          min_source_types: minSourceTypes,
+          min_source_domains: minSourceDomains,
+          breaking_min_magnitude: breakingMinMagnitude,
+          breaking_window_hours: breakingWindowHours,
         }),
       });
 
@@ -982,6 +1017,124 @@ function ThresholdsTab() {
             </div>
           </div>
 
+          {/* Minimum Sources */}
+          <div className="space-y-4">
+            <div className="flex justify-between items-end">
+              <div>
+                <label className="block text-sm font-mono text-chalk font-bold">MINIMUM SOURCES</label>
+                <p className="text-xs font-mono text-fog mt-1">Events need this many sources; rejected events are re-checked as sources merge in</p>
+              </div>
+              <span className="text-2xl font-mono font-bold text-terminal">{minSources}</span>
+            </div>
+            <input
+              type="range"
+              min="1"
+              max="20"
+              step="1"
+              value={minSources}
+              onChange={(e) => setMinSources(parseInt(e.target.value))}
+              className="w-full"
+            />
+            <div className="flex justify-between text-xs font-mono text-fog">
+              <span>1 (Any)</span>
+              <span>20</span>
+            </div>
+          </div>
+
+          {/* Source Diversity */}
+          <div className="space-y-4">
+            <div className="flex justify-between items-end">
+              <div>
+                <label className="block text-sm font-mono text-chalk font-bold">DISTINCT SOURCE TYPES</label>
+                <p className="text-xs font-mono text-fog mt-1">Sources must come from this many platforms (twitter, news, ...) so one platform can't publish alone</p>
+              </div>
+              <span className="text-2xl font-mono font-bold text-terminal">{minSourceTypes || 'OFF'}</span>
+            </div>
+            <input
+              type="range"
+              min="0"
+              max="10"
+              step="1"
+              value={minSourceTypes}
+              onChange={(e) => setMinSourceTypes(parseInt(e.target.value))}
+              className="w-full"
+            />
+            <div className="flex justify-between text-xs font-mono text-fog">
+              <span>0 (Off)</span>
+              <span>10</span>
+            </div>
+          </div>
+
+          <div className="space-y-4">
+            <div className="flex justify-between items-end">
+              <div>
+                <label className="block text-sm font-mono text-chalk font-bold">DISTINCT SOURCE DOMAINS</label>
+                <p className="text-xs font-mono text-fog mt-1">Sources must come from this many different sites</p>
+              </div>
+              <span className="text-2xl font-mono font-bold text-terminal">{minSourceDomains || 'OFF'}</span>
+            </div>
+            <input
+              type="range"
+              min="0"
+              max="20"
+              step="1"
+              value={minSourceDomains}
+              onChange={(e) => setMinSourceDomains(parseInt(e.target.value))}
+              className="w-full"
+            />
+            <div className="flex justify-between text-xs font-mono text-fog">
+              <span>0 (Off)</span>
+              <span>20</span>
+            </div>
+          </div>
+
+          {/* Breaking Criteria */}
+          <div className="space-y-4">
+            <div className="flex justify-between items-end">
+              <div>
+                <label className="block text-sm font-mono text-chalk font-bold">BREAKING MAGNITUDE</label>
+                <p className="text-xs font-mono text-fog mt-1">Events at or above this magnitude are flagged as breaking while recent</p>
+              </div>
+              <span className="text-2xl font-mono font-bold text-terminal">{breakingMinMagnitude.toFixed(1)}</span>
+            </div>
+            <input
+              type="range"
+              min="0.5"
+              max="10"
+              step="0.5"
+              value={breakingMinMagnitude}
+              onChange={(e) => setBreakingMinMagnitude(parseFloat(e.target.value))}
+              className="w-full"
+            />
+            <div className="flex justify-between text-xs font-mono text-fog">
+              <span>0.5</span>
+              <span>10.0 (Critical)</span>
+            </div>
+          </div>
+
+          <div className="space-y-4">
+            <div className="flex justify-between items-end">
+              <div>
+                <label className="block text-sm font-mono text-chalk font-bold">BREAKING WINDOW (HOURS)</label>
+                <p className="text-xs font-mono text-fog mt-1">How long after its timestamp an event stays breaking</p>
+              </div>
+              <span className="text-2xl font-mono font-bold text-terminal">{breakingWindowHours}h</span>
+            </div>
+            <input
+              type="range"
+              min="1"
+              max="168"
+              step="1"
+              value={breakingWindowHours}
+              onChange={(e) => setBreakingWindowHours(parseInt(e.target.value))}
+              className="w-full"
+            />
+            <div className="flex justify-between text-xs font-mono text-fog">
+              <span>1h</span>
+              <span>168h (7 days)</span>
+            </div>
+          </div>
+
           {/* Message Display */}
           {message && (
             <div className={`p-4 border-2 ${
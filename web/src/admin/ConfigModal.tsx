@@ -49,7 +49,8 @@ export function ConfigModal({ connectorId, connectorName, onClose, onSave }: Con
         onSave(config);
         onClose();
       } else {
-        alert('Failed to save configuration');
+        const error = await response.text();
+        alert(`Failed to save configuration:\n${error}`);
       }
     } catch (error) {
       console.error('Failed to save config:', error);
@@ -1,5 +1,5 @@
 import { useState, useEffect } from 'react';
-import { TrendingUp, Plus, X, Play, Eye, Loader, AlertTriangle, Edit, Copy, Trash2 } from 'lucide-react';
+import { TrendingUp, Plus, X, Play, Eye, Loader, AlertTriangle, Edit, Copy, Trash2, Archive, RotateCcw } from 'lucide-react';
 import { API_BASE_URL } from '../utils/api';
 import { getAuthHeaders } from '../utils/auth';
 import { formatDateTime } from '../utils/dateFormat';
@@ -14,6 +14,10 @@ interface Forecast {
   target_date?: string;
   categories: string[];
   headline_count: number;
+  headline_selection?: string; // 'recent' or 'relevant'
+  include_summaries?: boolean;
+  explain_result?: boolean;
+  market_symbol?: string; // Symbol whose percent return the forecast predicts, e.g. 'SPY'
   iterations: number;
   context_urls: string[];
   active: boolean;
@@ -23,6 +27,8 @@ interface Forecast {
   schedule_interval: number; // Interval in minutes
   last_run_at?: string;
   next_run_at?: string;
+  archived_at?: string; // Set while the forecast is archived
+  fixed_headlines?: unknown[]; // Headlines every run is pinned to instead of the latest events
   created_at: string;
   updated_at: string;
 }
@@ -32,6 +38,19 @@ interface ForecastModel {
   model_name: string;
   api_key: string;
   weight: number;
+  base_url?: string;
+}
+
+interface RunProgress {
+  type: string;
+  run_id: string;
+  model_name?: string;
+  samples_completed: number;
+  samples_total: number;
+  models_completed: number;
+  models_total: number;
+  status?: string;
+  error?: string;
 }
 
 interface ForecastRun {
@@ -60,11 +79,20 @@ interface ForecastRunDetail {
     aggregated_point_estimate?: number;
     model_count: number;
     consensus_level?: number;
+    explanation?: string;
+    headlines_widened?: boolean;
+    sparse_headlines?: boolean;
   };
 }
 
 type ChartViewMode = 'hourly' | 'daily';
 
+// Runs loaded per page in a forecast's run history
+const RUN_PAGE_SIZE = 50;
+
+// Symbols with options analysis that a forecast can be compared against
+const MARKET_SYMBOLS = ['GLD', 'IBIT', 'SPY', 'TLT', 'USO', 'VNQ'];
+
 export function ForecastsTab() {
   const [forecasts, setForecasts] = useState<Forecast[]>([]);
   const [loading, setLoading] = useState(true);
@@ -73,14 +101,16 @@ export function ForecastsTab() {
   const [editingForecast, setEditingForecast] = useState<Forecast | null>(null);
   const [selectedRun, setSelectedRun] = useState<ForecastRunDetail | null>(null);
   const [chartViewMode, setChartViewMode] = useState<ChartViewMode>('hourly');
+  const [showArchived, setShowArchived] = useState(false);
 
   useEffect(() => {
     fetchForecasts();
-  }, []);
+  }, [showArchived]);
 
   const fetchForecasts = async () => {
     try {
-      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts`, {
+      const query = showArchived ? '?include_archived=true' : '';
+      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts${query}`, {
         headers: getAuthHeaders(),
       });
       if (!response.ok) throw new Error('Failed to fetch forecasts');
@@ -128,6 +158,15 @@ export function ForecastsTab() {
             </select>
           </div>
         )}
+        <label className="flex items-center gap-2 cursor-pointer">
+          <input
+            type="checkbox"
+            checked={showArchived}
+            onChange={(e) => setShowArchived(e.target.checked)}
+            className="w-4 h-4"
+          />
+          <span className="text-xs font-mono text-smoke font-bold">SHOW ARCHIVED</span>
+        </label>
       </div>
 
       {/* Forecasts List */}
@@ -208,11 +247,13 @@ export function ForecastsTab() {
 
 function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete, chartViewMode }: { forecast: Forecast; onRunSelected: (run: ForecastRunDetail) => void; onEdit: (forecast: Forecast) => void; onDuplicate: (forecast: Forecast) => void; onDelete: () => void; chartViewMode: 'hourly' | 'daily' }) {
   const [runs, setRuns] = useState<ForecastRun[]>([]);
+  const [runsTotal, setRunsTotal] = useState(0);
   const [expanded, setExpanded] = useState(false);
   const [executing, setExecuting] = useState(false);
   const [deleting, setDeleting] = useState(false);
   const [latestResult, setLatestResult] = useState<ForecastRunDetail | null>(null);
   const [scheduleSaving, setScheduleSaving] = useState(false);
+  const [progress, setProgress] = useState<RunProgress | null>(null);
 
   useEffect(() => {
     fetchLatestRun();
@@ -248,39 +289,92 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
     }
   };
 
-  const fetchRuns = async () => {
+  // Loads the first page of runs, or with more set the page after those already shown
+  const fetchRuns = async (more = false) => {
+    const offset = more ? runs.length : 0;
     try {
-      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/${forecast.id}/runs`, {
+      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/${forecast.id}/runs?limit=${RUN_PAGE_SIZE}&offset=${offset}`, {
         headers: getAuthHeaders(),
       });
       if (!response.ok) throw new Error('Failed to fetch runs');
       const data = await response.json();
-      setRuns(data.runs || []);
+      setRuns(more ? [...runs, ...(data.runs || [])] : data.runs || []);
+      setRunsTotal(data.total || 0);
     } catch (err) {
       console.error('Error fetching runs:', err);
     }
   };
 
+  // Follow a run's server-sent progress events; fetch is used because EventSource can't send auth headers
+  const streamProgress = async (runId: string) => {
+    try {
+      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/runs/${runId}/stream`, {
+        headers: getAuthHeaders(),
+      });
+      if (!response.ok || !response.body) return;
+
+      const reader = response.body.getReader();
+      const decoder = new TextDecoder();
+      let buffer = '';
+      while (true) {
+        const { value, done } = await reader.read();
+        if (done) break;
+        buffer += decoder.decode(value, { stream: true });
+
+        const messages = buffer.split('\n\n');
+        buffer = messages.pop() || '';
+        for (const message of messages) {
+          const data = message.split('\n').find(line => line.startsWith('data: '));
+          if (!data) continue;
+          const event: RunProgress = JSON.parse(data.slice('data: '.length));
+          setProgress(event);
+          if (event.type === 'done') {
+            fetchLatestRun();
+            fetchRuns();
+          }
+        }
+      }
+    } catch (err) {
+      console.error('Error streaming run progress:', err);
+    }
+  };
+
+  const handleCancelRun = async (runId: string) => {
+    if (!confirm('Cancel this forecast run? Responses gathered so far will be kept.')) {
+      return;
+    }
+
+    try {
+      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/runs/${runId}/cancel`, {
+        method: 'POST',
+        headers: getAuthHeaders(),
+      });
+      if (!response.ok) {
+        const error = await response.text();
+        throw new Error(error);
+      }
+    } catch (err) {
+      alert(`Failed to cancel run: ${err instanceof Error ? err.message : 'Unknown error'}`);
+    }
+  };
+
   const handleExecute = async () => {
     setExecuting(true);
     try {
+      // A resent request with the same key returns the run this one started instead of starting another
       const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/${forecast.id}/execute`, {
         method: 'POST',
-        headers: getAuthHeaders(),
+        headers: { ...getAuthHeaders(), 'Idempotency-Key': crypto.randomUUID() },
       });
       if (!response.ok) {
         const error = await response.text();
         throw new Error(error);
       }
       const result = await response.json();
-      alert(`Forecast execution started! Run ID: ${result.run_id}`);
       if (expanded) {
         fetchRuns();
       }
-      // Refresh latest result after a delay to allow the run to complete
-      setTimeout(() => {
-        fetchLatestRun();
-      }, 2000);
+      streamProgress(result.run_id);
     } catch (err) {
       alert(`Failed to execute forecast: ${err instanceof Error ? err.message : 'Unknown error'}`);
     } finally {
@@ -288,14 +382,22 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
     }
   };
 
+  // Archives a live forecast; an archived forecast is permanently deleted
   const handleDelete = async () => {
-    if (!confirm(`Are you sure you want to delete "${forecast.name}"? This action cannot be undone and will delete all associated runs and results.`)) {
+    const archived = Boolean(forecast.archived_at);
+    const message = archived
+      ? `Permanently delete "${forecast.name}"? This action cannot be undone and will delete all associated runs and results.`
+      : `Archive "${forecast.name}"? It will stop running and be hidden, but its runs and results are kept and it can be restored.`;
+    if (!confirm(message)) {
       return;
     }
 
     setDeleting(true);
     try {
-      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/${forecast.id}`, {
+      const url = archived
+        ? `${API_BASE_URL}/api/admin/forecasts/${forecast.id}/permanent`
+        : `${API_BASE_URL}/api/admin/forecasts/${forecast.id}`;
+      const response = await fetch(url, {
         method: 'DELETE',
         headers: getAuthHeaders(),
       });
@@ -303,7 +405,7 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
         const error = await response.text();
         throw new Error(error);
       }
-      alert(`Forecast "${forecast.name}" deleted successfully`);
+      alert(`Forecast "${forecast.name}" ${archived ? 'permanently deleted' : 'archived'}`);
       onDelete();
     } catch (err) {
       alert(`Failed to delete forecast: ${err instanceof Error ? err.message : 'Unknown error'}`);
@@ -311,6 +413,22 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
     }
   };
 
+  const handleRestore = async () => {
+    try {
+      const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/${forecast.id}/restore`, {
+        method: 'POST',
+        headers: getAuthHeaders(),
+      });
+      if (!response.ok) {
+        const error = await response.text();
+        throw new Error(error);
+      }
+      onDelete();
+    } catch (err) {
+      alert(`Failed to restore forecast: ${err instanceof Error ? err.message : 'Unknown error'}`);
+    }
+  };
+
   const handleViewRun = async (runId: string) => {
     try {
       const response = await fetch(`${API_BASE_URL}/api/admin/forecasts/runs/${runId}`, {
@@ -426,11 +544,16 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
       <div className="p-4 md:p-6">
         <div className="flex flex-col lg:flex-row lg:justify-between lg:items-start gap-4">
           <div className="flex-1 min-w-0">
-            <h3 className="font-mono font-bold text-chalk text-lg break-words">{forecast.name}</h3>
+            <h3 className="font-mono font-bold text-chalk text-lg break-words">
+              {forecast.name}
+              {forecast.archived_at && (
+                <span className="ml-3 text-xs text-fog border border-fog px-2 py-0.5 align-middle">ARCHIVED</span>
+              )}
+            </h3>
             <p className="text-sm font-mono text-fog mt-2 break-words">{forecast.proposition}</p>
             <div className="flex flex-wrap gap-2 md:gap-3 mt-4 text-xs font-mono">
               <span className="text-smoke">
-                Type: <span className="text-terminal font-bold">{forecast.prediction_type === 'percentile' ? 'DISTRIBUTION' : 'POINT ESTIMATE'}</span>
+                Type: <span className="text-terminal font-bold">{forecast.prediction_type === 'percentile' ? 'DISTRIBUTION' : forecast.prediction_type === 'probability' ? 'PROBABILITY' : 'POINT ESTIMATE'}</span>
               </span>
               <span className="text-smoke">
                 Units: <span className="text-terminal font-bold">{forecast.units}</span>
@@ -621,18 +744,74 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
             >
               {expanded ? 'HIDE' : 'RUNS'}
             </button>
+            {forecast.archived_at && (
+              <button
+                onClick={handleRestore}
+                className="flex items-center gap-2 px-3 py-2 border-2 border-terminal text-terminal hover:bg-terminal hover:text-void transition-all font-mono text-xs md:text-sm font-bold"
+              >
+                <RotateCcw className="w-3 h-3 md:w-4 md:h-4" />
+                <span className="hidden sm:inline">RESTORE</span>
+              </button>
+            )}
             <button
               onClick={handleDelete}
               disabled={deleting}
               className="flex items-center gap-2 px-3 py-2 border-2 border-threat-critical text-threat-critical hover:bg-threat-critical hover:text-void transition-all font-mono text-xs md:text-sm font-bold disabled:opacity-50"
             >
-              {deleting ? <Loader className="w-3 h-3 md:w-4 md:h-4 animate-spin" /> : <Trash2 className="w-3 h-3 md:w-4 md:h-4" />}
-              <span className="hidden sm:inline">{deleting ? 'DELETING...' : 'DELETE'}</span>
+              {deleting ? (
+                <Loader className="w-3 h-3 md:w-4 md:h-4 animate-spin" />
+              ) : forecast.archived_at ? (
+                <Trash2 className="w-3 h-3 md:w-4 md:h-4" />
+              ) : (
+                <Archive className="w-3 h-3 md:w-4 md:h-4" />
+              )}
+              <span className="hidden sm:inline">
+                {deleting ? 'DELETING...' : forecast.archived_at ? 'DELETE FOREVER' : 'ARCHIVE'}
+              </span>
             </button>
           </div>
         </div>
       </div>
 
+      {/* Run Progress */}
+      {progress && (
+        <div className="border-t-2 border-steel bg-void/50 px-6 py-3">
+          <div className="flex items-center justify-between text-xs font-mono mb-2">
+            <span className={progress.status === 'failed' ? 'text-threat-critical' : 'text-terminal'}>
+              {progress.type === 'done'
+                ? `RUN ${progress.status?.toUpperCase()}${progress.error ? `: ${progress.error}` : ''}`
+                : `RUNNING${progress.model_name ? ` · ${progress.model_name}` : ''}`}
+            </span>
+            <span className="text-fog">
+              {progress.samples_total > 0 && `${progress.samples_completed}/${progress.samples_total} SAMPLES · `}
+              {progress.models_completed}/{progress.models_total} MODELS
+            </span>
+            {progress.type === 'done' ? (
+              <button onClick={() => setProgress(null)} className="text-fog hover:text-chalk transition-colors">
+                <X className="w-4 h-4" />
+              </button>
+            ) : (
+              <button
+                onClick={() => handleCancelRun(progress.run_id)}
+                className="px-2 py-1 border border-threat-critical text-threat-critical hover:bg-threat-critical hover:text-void transition-all"
+              >
+                CANCEL
+              </button>
+            )}
+          </div>
+          <div className="h-2 bg-concrete border border-steel">
+            <div
+              className={`h-full transition-all ${progress.status === 'failed' ? 'bg-threat-critical' : 'bg-terminal'}`}
+              style={{
+                width: `${progress.type === 'done' ? 100 : progress.samples_total > 0
+                  ? (100 * progress.samples_completed) / progress.samples_total
+                  : progress.models_total > 0 ? (100 * progress.models_completed) / progress.models_total : 0}%`,
+              }}
+            />
+          </div>
+        </div>
+      )}
+
       {/* Runs List */}
       {expanded && (
         <div className="border-t-2 border-steel bg-void/50 p-6 space-y-6">
@@ -646,7 +825,7 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
           ) : (
             <div className="space-y-2">
               <div className="flex justify-between items-center mb-3">
-                <h4 className="text-xs font-mono text-smoke font-bold">RECENT RUNS</h4>
+                <h4 className="text-xs font-mono text-smoke font-bold">RECENT RUNS ({runs.length} OF {runsTotal})</h4>
                 <button
                   onClick={handleDeleteAllRuns}
                   className="flex items-center gap-2 px-3 py-1 border-2 border-threat-medium text-threat-medium hover:bg-threat-medium hover:text-void transition-all font-mono text-xs font-bold"
@@ -700,6 +879,14 @@ function ForecastCard({ forecast, onRunSelected, onEdit, onDuplicate, onDelete,
                   </div>
                 </div>
               ))}
+              {runs.length < runsTotal && (
+                <button
+                  onClick={() => fetchRuns(true)}
+                  className="w-full px-3 py-2 border border-steel text-chalk hover:border-iron transition-all font-mono text-xs font-bold"
+                >
+                  LOAD MORE
+                </button>
+              )}
             </div>
           )}
         </div>
@@ -716,6 +903,10 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
   const [targetDate, setTargetDate] = useState('');
   const [categories, setCategories] = useState<string[]>([]);
   const [headlineCount, setHeadlineCount] = useState(500);
+  const [headlineSelection, setHeadlineSelection] = useState('recent');
+  const [includeSummaries, setIncludeSummaries] = useState(false);
+  const [explainResult, setExplainResult] = useState(false);
+  const [marketSymbol, setMarketSymbol] = useState('');
   const [iterations, setIterations] = useState(1);
   const [contextUrls, setContextUrls] = useState<string[]>([]);
   const [models, setModels] = useState<ForecastModel[]>([
@@ -745,7 +936,7 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
       return;
     }
 
-    if (models.some(m => !m.api_key)) {
+    if (models.some(m => !m.api_key && m.provider !== 'openai_compatible')) {
       alert('Please provide API keys for all models');
       return;
     }
@@ -766,6 +957,10 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
           target_date: targetDate ? `${targetDate}T00:00:00Z` : null,
           categories,
           headline_count: headlineCount,
+          headline_selection: headlineSelection,
+          include_summaries: includeSummaries,
+          explain_result: explainResult,
+          market_symbol: predictionType === 'probability' ? '' : marketSymbol,
           iterations,
           context_urls: contextUrls,
           models,
@@ -823,7 +1018,7 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
               className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
             />
             <p className="text-xs font-mono text-fog">
-              Ask for an actual value prediction (e.g., "What will be the % change..."), not a yes/no question
+              Ask for an actual value prediction (e.g., "What will be the % change..."), or choose PROBABILITY for a yes/no question
             </p>
           </div>
 
@@ -851,11 +1046,23 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
               >
                 POINT ESTIMATE (Single Value)
               </button>
+              <button
+                onClick={() => setPredictionType('probability')}
+                className={`flex-1 px-4 py-3 border-2 font-mono text-sm font-bold transition-all ${
+                  predictionType === 'probability'
+                    ? 'border-terminal bg-terminal text-void'
+                    : 'border-steel bg-void text-fog hover:border-iron'
+                }`}
+              >
+                PROBABILITY (Yes/No)
+              </button>
             </div>
             <p className="text-xs font-mono text-fog">
               {predictionType === 'percentile'
                 ? 'Returns P10, P25, P50 (median), P75, P90 for uncertainty distribution'
-                : 'Returns a single best estimate value'}
+                : predictionType === 'probability'
+                  ? 'Returns a 0-100 probability that the proposition happens'
+                  : 'Returns a single best estimate value'}
             </p>
           </div>
 
@@ -938,6 +1145,78 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
             </div>
           </div>
 
+          {/* Headline Selection */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">
+              HEADLINE SELECTION
+            </label>
+            <select
+              value={headlineSelection}
+              onChange={(e) => setHeadlineSelection(e.target.value)}
+              className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
+            >
+              <option value="recent">Newest headlines</option>
+              <option value="relevant">Most relevant to the proposition</option>
+            </select>
+            <p className="text-xs font-mono text-fog">
+              Relevant picks the headlines closest to the proposition from the {headlineCount * 4 > 1000 ? 1000 : headlineCount * 4} newest
+            </p>
+          </div>
+
+          {/* Headline Summaries */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={includeSummaries}
+                onChange={(e) => setIncludeSummaries(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">INCLUDE EVENT SUMMARIES</span>
+            </label>
+            <p className="text-xs font-mono text-fog">
+              Adds each event's summary under its headline while the model's context window allows; titles only by default
+            </p>
+          </div>
+
+          {/* Result Explanation */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={explainResult}
+                onChange={(e) => setExplainResult(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">EXPLAIN RESULT</span>
+            </label>
+            <p className="text-xs font-mono text-fog">
+              After each run, asks the highest-weight model to explain the aggregated estimate given the headlines (one extra call)
+            </p>
+          </div>
+
+          {/* Market Symbol */}
+          {predictionType !== 'probability' && (
+            <div className="space-y-2">
+              <label className="block text-sm font-mono text-chalk font-bold">
+                MARKET SYMBOL
+              </label>
+              <select
+                value={marketSymbol}
+                onChange={(e) => setMarketSymbol(e.target.value)}
+                className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
+              >
+                <option value="">None</option>
+                {MARKET_SYMBOLS.map((symbol) => (
+                  <option key={symbol} value={symbol}>{symbol}</option>
+                ))}
+              </select>
+              <p className="text-xs font-mono text-fog">
+                Set when the proposition is this symbol's percent change, to compare results with the options-implied distribution
+              </p>
+            </div>
+          )}
+
           {/* Iterations */}
           <div className="space-y-2">
             <label className="block text-sm font-mono text-chalk font-bold">
@@ -1035,6 +1314,7 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
                       className="w-full px-3 py-2 border border-steel bg-concrete text-chalk font-mono text-sm focus:border-terminal focus:outline-none"
                     >
                       <option value="openai">OpenAI (logprobs)</option>
+                      <option value="openai_compatible">OpenAI-compatible (local)</option>
                     </select>
                   </div>
 
@@ -1061,6 +1341,19 @@ function CreateForecastModal({ onClose, onSuccess }: { onClose: () => void; onSu
                   />
                 </div>
 
+                {model.provider === 'openai_compatible' && (
+                  <div>
+                    <label className="block text-xs font-mono text-smoke mb-1">BASE URL</label>
+                    <input
+                      type="text"
+                      value={model.base_url || ''}
+                      onChange={(e) => updateModel(index, 'base_url', e.target.value)}
+                      placeholder="http://localhost:11434/v1"
+                      className="w-full px-3 py-2 border border-steel bg-concrete text-chalk font-mono text-sm focus:border-terminal focus:outline-none"
+                    />
+                  </div>
+                )}
+
                 <div>
                   <label className="block text-xs font-mono text-smoke mb-1">
                     WEIGHT: {model.weight.toFixed(2)}
@@ -1110,6 +1403,10 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
   const [targetDate, setTargetDate] = useState(forecast.target_date ? forecast.target_date.split('T')[0] : '');
   const [categories, setCategories] = useState<string[]>(forecast.categories || []);
   const [headlineCount, setHeadlineCount] = useState(forecast.headline_count);
+  const [headlineSelection, setHeadlineSelection] = useState(forecast.headline_selection || 'recent');
+  const [includeSummaries, setIncludeSummaries] = useState(forecast.include_summaries || false);
+  const [explainResult, setExplainResult] = useState(forecast.explain_result || false);
+  const [marketSymbol, setMarketSymbol] = useState(forecast.market_symbol || '');
   const [iterations, setIterations] = useState(forecast.iterations || 1);
   const [contextUrls, setContextUrls] = useState<string[]>(forecast.context_urls || []);
   const [models, setModels] = useState<ForecastModel[]>([]);
@@ -1157,7 +1454,7 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
       return;
     }
 
-    if (models.some(m => !m.api_key)) {
+    if (models.some(m => !m.api_key && m.provider !== 'openai_compatible')) {
       alert('Please provide API keys for all models');
       return;
     }
@@ -1178,8 +1475,14 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
           target_date: targetDate ? `${targetDate}T00:00:00Z` : null,
           categories,
           headline_count: headlineCount,
+          headline_selection: headlineSelection,
+          include_summaries: includeSummaries,
+          explain_result: explainResult,
+          market_symbol: predictionType === 'probability' ? '' : marketSymbol,
           iterations,
           context_urls: contextUrls,
+          fixed_headlines: forecast.fixed_headlines,
+          duplicate_of: forecast.id,
           models,
         }),
       });
@@ -1269,6 +1572,16 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
               >
                 POINT ESTIMATE (Single Value)
               </button>
+              <button
+                onClick={() => setPredictionType('probability')}
+                className={`flex-1 px-4 py-3 border-2 font-mono text-sm font-bold transition-all ${
+                  predictionType === 'probability'
+                    ? 'border-fog bg-fog text-void'
+                    : 'border-steel bg-void text-fog hover:border-iron'
+                }`}
+              >
+                PROBABILITY (Yes/No)
+              </button>
             </div>
           </div>
 
@@ -1343,6 +1656,78 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
             </div>
           </div>
 
+          {/* Headline Selection */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">
+              HEADLINE SELECTION
+            </label>
+            <select
+              value={headlineSelection}
+              onChange={(e) => setHeadlineSelection(e.target.value)}
+              className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
+            >
+              <option value="recent">Newest headlines</option>
+              <option value="relevant">Most relevant to the proposition</option>
+            </select>
+            <p className="text-xs font-mono text-fog">
+              Relevant picks the headlines closest to the proposition from the {headlineCount * 4 > 1000 ? 1000 : headlineCount * 4} newest
+            </p>
+          </div>
+
+          {/* Headline Summaries */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={includeSummaries}
+                onChange={(e) => setIncludeSummaries(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">INCLUDE EVENT SUMMARIES</span>
+            </label>
+            <p className="text-xs font-mono text-fog">
+              Adds each event's summary under its headline while the model's context window allows; titles only by default
+            </p>
+          </div>
+
+          {/* Result Explanation */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={explainResult}
+                onChange={(e) => setExplainResult(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">EXPLAIN RESULT</span>
+            </label>
+            <p className="text-xs font-mono text-fog">
+              After each run, asks the highest-weight model to explain the aggregated estimate given the headlines (one extra call)
+            </p>
+          </div>
+
+          {/* Market Symbol */}
+          {predictionType !== 'probability' && (
+            <div className="space-y-2">
+              <label className="block text-sm font-mono text-chalk font-bold">
+                MARKET SYMBOL
+              </label>
+              <select
+                value={marketSymbol}
+                onChange={(e) => setMarketSymbol(e.target.value)}
+                className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
+              >
+                <option value="">None</option>
+                {MARKET_SYMBOLS.map((symbol) => (
+                  <option key={symbol} value={symbol}>{symbol}</option>
+                ))}
+              </select>
+              <p className="text-xs font-mono text-fog">
+                Set when the proposition is this symbol's percent change, to compare results with the options-implied distribution
+              </p>
+            </div>
+          )}
+
           {/* Iterations */}
           <div className="space-y-2">
             <label className="block text-sm font-mono text-chalk font-bold">
@@ -1440,6 +1825,7 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
                       className="w-full px-3 py-2 border border-steel bg-concrete text-chalk font-mono text-sm focus:border-fog focus:outline-none"
                     >
                       <option value="openai">OpenAI (logprobs)</option>
+                      <option value="openai_compatible">OpenAI-compatible (local)</option>
                     </select>
                   </div>
 
@@ -1465,6 +1851,19 @@ function DuplicateForecastModal({ forecast, onClose, onSuccess }: { forecast: Fo
                   />
                 </div>
 
+                {model.provider === 'openai_compatible' && (
+                  <div>
+                    <label className="block text-xs font-mono text-smoke mb-1">BASE URL</label>
+                    <input
+                      type="text"
+                      value={model.base_url || ''}
+                      onChange={(e) => updateModel(index, 'base_url', e.target.value)}
+                      placeholder="http://localhost:11434/v1"
+                      className="w-full px-3 py-2 border border-steel bg-concrete text-chalk font-mono text-sm focus:border-fog focus:outline-none"
+                    />
+                  </div>
+                )}
+
                 <div>
                   <label className="block text-xs font-mono text-smoke mb-1">
                     WEIGHT: {model.weight.toFixed(2)}
@@ -1514,6 +1913,10 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
   const [targetDate, setTargetDate] = useState(forecast.target_date ? forecast.target_date.split('T')[0] : '');
   const [categories, setCategories] = useState<string[]>(forecast.categories || []);
   const [headlineCount, setHeadlineCount] = useState(forecast.headline_count);
+  const [headlineSelection, setHeadlineSelection] = useState(forecast.headline_selection || 'recent');
+  const [includeSummaries, setIncludeSummaries] = useState(forecast.include_summaries || false);
+  const [explainResult, setExplainResult] = useState(forecast.explain_result || false);
+  const [marketSymbol, setMarketSymbol] = useState(forecast.market_symbol || '');
   const [iterations, setIterations] = useState(forecast.iterations || 1);
   const [contextUrls, setContextUrls] = useState<string[]>(forecast.context_urls || []);
   const [models, setModels] = useState<ForecastModel[]>([]);
@@ -1561,7 +1964,7 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
       return;
     }
 
-    if (models.some(m => !m.api_key)) {
+    if (models.some(m => !m.api_key && m.provider !== 'openai_compatible')) {
       alert('Please provide API keys for all models');
       return;
     }
@@ -1582,8 +1985,13 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
           target_date: targetDate ? `${targetDate}T00:00:00Z` : null,
           categories,
           headline_count: headlineCount,
+          headline_selection: headlineSelection,
+          include_summaries: includeSummaries,
+          explain_result: explainResult,
+          market_symbol: predictionType === 'probability' ? '' : marketSymbol,
           iterations,
           context_urls: contextUrls,
+          fixed_headlines: forecast.fixed_headlines,
           models,
         }),
       });
@@ -1673,6 +2081,16 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
               >
                 POINT ESTIMATE (Single Value)
               </button>
+              <button
+                onClick={() => setPredictionType('probability')}
+                className={`flex-1 px-4 py-3 border-2 font-mono text-sm font-bold transition-all ${
+                  predictionType === 'probability'
+                    ? 'border-electric bg-electric text-void'
+                    : 'border-steel bg-void text-fog hover:border-iron'
+                }`}
+              >
+                PROBABILITY (Yes/No)
+              </button>
             </div>
           </div>
 
@@ -1749,6 +2167,78 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
             </div>
           </div>
 
+          {/* Headline Selection */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">
+              HEADLINE SELECTION
+            </label>
+            <select
+              value={headlineSelection}
+              onChange={(e) => setHeadlineSelection(e.target.value)}
+              className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
+            >
+              <option value="recent">Newest headlines</option>
+              <option value="relevant">Most relevant to the proposition</option>
+            </select>
+            <p className="text-xs font-mono text-fog">
+              Relevant picks the headlines closest to the proposition from the {headlineCount * 4 > 1000 ? 1000 : headlineCount * 4} newest
+            </p>
+          </div>
+
+          {/* Headline Summaries */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={includeSummaries}
+                onChange={(e) => setIncludeSummaries(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">INCLUDE EVENT SUMMARIES</span>
+            </label>
+            <p className="text-xs font-mono text-fog">
+              Adds each event's summary under its headline while the model's context window allows; titles only by default
+            </p>
+          </div>
+
+          {/* Result Explanation */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={explainResult}
+                onChange={(e) => setExplainResult(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">EXPLAIN RESULT</span>
+            </label>
+            <p className="text-xs font-mono text-fog">
+              After each run, asks the highest-weight model to explain the aggregated estimate given the headlines (one extra call)
+            </p>
+          </div>
+
+          {/* Market Symbol */}
+          {predictionType !== 'probability' && (
+            <div className="space-y-2">
+              <label className="block text-sm font-mono text-chalk font-bold">
+                MARKET SYMBOL
+              </label>
+              <select
+                value={marketSymbol}
+                onChange={(e) => setMarketSymbol(e.target.value)}
+                className="w-full px-4 py-2 border-2 border-steel bg-void text-chalk font-mono focus:border-terminal focus:outline-none"
+              >
+                <option value="">None</option>
+                {MARKET_SYMBOLS.map((symbol) => (
+                  <option key={symbol} value={symbol}>{symbol}</option>
+                ))}
+              </select>
+              <p className="text-xs font-mono text-fog">
+                Set when the proposition is this symbol's percent change, to compare results with the options-implied distribution
+              </p>
+            </div>
+          )}
+
           {/* Iterations */}
           <div className="space-y-2">
             <label className="block text-sm font-mono text-chalk font-bold">
@@ -1846,6 +2336,7 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
                       className="w-full px-3 py-2 border border-steel bg-concrete text-chalk font-mono text-sm focus:border-electric focus:outline-none"
                     >
                       <option value="openai">OpenAI (logprobs)</option>
+                      <option value="openai_compatible">OpenAI-compatible (local)</option>
                     </select>
                   </div>
 
@@ -1871,6 +2362,19 @@ function EditForecastModal({ forecast, onClose, onSuccess }: { forecast: Forecas
                   />
                 </div>
 
+                {model.provider === 'openai_compatible' && (
+                  <div>
+                    <label className="block text-xs font-mono text-smoke mb-1">BASE URL</label>
+                    <input
+                      type="text"
+                      value={model.base_url || ''}
+                      onChange={(e) => updateModel(index, 'base_url', e.target.value)}
+                      placeholder="http://localhost:11434/v1"
+                      className="w-full px-3 py-2 border border-steel bg-concrete text-chalk font-mono text-sm focus:border-electric focus:outline-none"
+                    />
+                  </div>
+                )}
+
                 <div>
                   <label className="block text-xs font-mono text-smoke mb-1">
                     WEIGHT: {model.weight.toFixed(2)}
@@ -2001,6 +2505,20 @@ function RunDetailModal({ runDetail, onClose }: { runDetail: ForecastRunDetail;
                     <span className="text-chalk">{runDetail.result.consensus_level.toFixed(3)}</span>
                   </div>
                 )}
+                {(runDetail.result.headlines_widened || runDetail.result.sparse_headlines) && (
+                  <div className="flex justify-between text-sm font-mono">
+                    <span className="text-smoke">Headlines:</span>
+                    <span className="text-warning">
+                      {[runDetail.result.headlines_widened && 'WIDENED BEYOND CATEGORIES', runDetail.result.sparse_headlines && 'SPARSE'].filter(Boolean).join(' • ')}
+                    </span>
+                  </div>
+                )}
+                {runDetail.result.explanation && (
+                  <div className="pt-3 border-t border-steel space-y-1">
+                    <div className="text-sm font-mono text-smoke">Explanation:</div>
+                    <p className="text-sm font-mono text-chalk whitespace-pre-wrap">{runDetail.result.explanation}</p>
+                  </div>
+                )}
               </div>
             </div>
           )}
@@ -9,12 +9,20 @@ interface IngestionError {
   id: string;
   platform: string;
   error_type: string;
+  category: string;
   url: string;
   error_msg: string;
   metadata: string;
   created_at: string;
   resolved: boolean;
   resolved_at?: string;
+  resolution_note?: string;
+}
+
+interface IngestionErrorStat {
+  category: string;
+  platform: string;
+  count: number;
 }
 
 export function IngestionErrorsTab() {
@@ -22,10 +30,33 @@ export function IngestionErrorsTab() {
   const [loading, setLoading] = useState(true);
   const [unresolvedOnly, setUnresolvedOnly] = useState(true);
   const [unresolvedCount, setUnresolvedCount] = useState(0);
+  const [category, setCategory] = useState('');
+  const [categoryCounts, setCategoryCounts] = useState<Record<string, number>>({});
+
+  const fetchStats = async () => {
+    try {
+      const response = await fetch(`${API_BASE_URL}/api/ingestion-errors/stats?unresolved_only=${unresolvedOnly}`, {
+        headers: getAuthHeaders(),
+      });
+      if (!response.ok) throw new Error('Failed to fetch error stats');
+      const data = await response.json();
+      const counts: Record<string, number> = {};
+      (data.stats || []).forEach((stat: IngestionErrorStat) => {
+        counts[stat.category] = (counts[stat.category] || 0) + stat.count;
+      });
+      setCategoryCounts(counts);
+    } catch (err) {
+      console.error('Error fetching ingestion error stats:', err);
+    }
+  };
 
   const fetchErrors = async () => {
+    fetchStats();
     try {
-      const url = `${API_BASE_URL}/api/ingestion-errors?limit=100&unresolved_only=${unresolvedOnly}`;
+      let url = `${API_BASE_URL}/api/ingestion-errors?limit=100&unresolved_only=${unresolvedOnly}`;
+      if (category) {
+        url += `&category=${encodeURIComponent(category)}`;
+      }
       const response = await fetch(url, {
         headers: getAuthHeaders(),
       });
@@ -45,7 +76,7 @@ export function IngestionErrorsTab() {
     // Refresh every 30 seconds
     const interval = setInterval(fetchErrors, 30000);
     return () => clearInterval(interval);
-  }, [unresolvedOnly]);
+  }, [unresolvedOnly, category]);
 
   const handleResolve = async (errorId: string) => {
     try {
@@ -141,6 +172,18 @@ export function IngestionErrorsTab() {
         >
           ALL ERRORS ({errors.length})
         </button>
+        <select
+          value={category}
+          onChange={(e) => setCategory(e.target.value)}
+          className="px-4 py-2 border border-steel bg-void text-fog font-mono text-xs font-bold uppercase"
+        >
+          <option value="">ALL CATEGORIES</option>
+          {Object.entries(categoryCounts).map(([name, count]) => (
+            <option key={name} value={name}>
+              {name.toUpperCase()} ({count})
+            </option>
+          ))}
+        </select>
       </div>
 
       {/* Errors Table */}
@@ -192,7 +235,10 @@ export function IngestionErrorsTab() {
                       </span>
                     </td>
                     <td className="px-4 py-3 text-xs font-mono text-fog">
-                      {error.error_type}
+                      <div>{error.error_type}</div>
+                      {error.category && error.category !== 'unknown' && (
+                        <div className="text-warning uppercase mt-1">{error.category}</div>
+                      )}
                     </td>
                     <td className="px-4 py-3 text-xs font-mono text-electric max-w-xs truncate">
                       <a
@@ -217,6 +263,9 @@ export function IngestionErrorsTab() {
                       >
                         {error.resolved ? 'RESOLVED' : 'UNRESOLVED'}
                       </span>
+                      {error.resolution_note && (
+                        <div className="text-xs font-mono text-smoke mt-1">{error.resolution_note}</div>
+                      )}
                     </td>
                     <td className="px-4 py-3">
                       <div className="flex gap-2">
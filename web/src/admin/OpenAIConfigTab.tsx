@@ -6,14 +6,27 @@ import { getAuthHeaders } from '../utils/auth';
 
 interface OpenAIConfig {
   id: number;
+  provider: string;
   api_key: string;
   model: string;
+  fallback_models: string[];
   temperature: number;
   max_tokens: number;
   timeout_seconds: number;
   system_prompt: string;
   analysis_template: string;
   entity_extraction_prompt: string;
+  correlation_system_prompt: string;
+  correlation_template: string;
+  credibility_system_prompt: string;
+  credibility_template: string;
+  base_url: string;
+  azure_deployment: string;
+  azure_api_version: string;
+  category_mapping: Record<string, string>;
+  prompt_b_system_prompt: string;
+  prompt_b_analysis_template: string;
+  prompt_b_fraction: number;
   enabled: boolean;
   updated_at: string;
   created_at: string;
@@ -21,6 +34,8 @@ interface OpenAIConfig {
 
 export function OpenAIConfigTab() {
   const [config, setConfig] = useState<OpenAIConfig | null>(null);
+  const [fallbackModels, setFallbackModels] = useState('');
+  const [categoryMapping, setCategoryMapping] = useState('');
   const [showApiKey, setShowApiKey] = useState(false);
   const [saving, setSaving] = useState(false);
   const [loading, setLoading] = useState(true);
@@ -39,6 +54,12 @@ export function OpenAIConfigTab() {
       if (!response.ok) throw new Error('Failed to fetch OpenAI configuration');
       const data = await response.json();
       setConfig(data);
+      setFallbackModels((data.fallback_models || []).join(', '));
+      setCategoryMapping(
+        Object.entries(data.category_mapping || {})
+          .map(([from, to]) => `${from}=${to}`)
+          .join(', ')
+      );
     } catch (err) {
       console.error('Error fetching OpenAI config:', err);
       setMessage({
@@ -61,14 +82,32 @@ export function OpenAIConfigTab() {
         method: 'PUT',
         headers: getAuthHeaders(),
         body: JSON.stringify({
+          provider: config.provider,
           api_key: config.api_key,
           model: config.model,
+          fallback_models: fallbackModels.split(',').map((m) => m.trim()).filter(Boolean),
           temperature: config.temperature,
           max_tokens: config.max_tokens,
           timeout_seconds: config.timeout_seconds,
           system_prompt: config.system_prompt,
           analysis_template: config.analysis_template,
           entity_extraction_prompt: config.entity_extraction_prompt,
+          correlation_system_prompt: config.correlation_system_prompt,
+          correlation_template: config.correlation_template,
+          credibility_system_prompt: config.credibility_system_prompt,
+          credibility_template: config.credibility_template,
+          base_url: config.base_url,
+          azure_deployment: config.azure_deployment,
+          azure_api_version: config.azure_api_version,
+          category_mapping: Object.fromEntries(
+            categoryMapping
+              .split(',')
+              .map((pair) => pair.split('=').map((part) => part.trim()))
+              .filter(([from, to]) => from && to)
+          ),
+          prompt_b_system_prompt: config.prompt_b_system_prompt,
+          prompt_b_analysis_template: config.prompt_b_analysis_template,
+          prompt_b_fraction: config.prompt_b_fraction,
           enabled: config.enabled,
         }),
       });
@@ -79,7 +118,8 @@ export function OpenAIConfigTab() {
       }
 
       const result = await response.json();
-      setMessage({ text: result.message, type: 'success' });
+      // Saved, but the running enricher couldn't switch to the new credentials and kept the old ones
+      setMessage({ text: result.message, type: result.credentials_reloaded === false ? 'error' : 'success' });
       // Refresh config to get updated timestamps
       await fetchConfig();
     } catch (err) {
@@ -160,6 +200,24 @@ export function OpenAIConfigTab() {
             </p>
           </div>
 
+          {/* Provider */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              PROVIDER
+            </label>
+            <select
+              value={config.provider || 'openai'}
+              onChange={(e) => setConfig({ ...config, provider: e.target.value })}
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-sm focus:border-terminal focus:outline-none transition-colors"
+            >
+              <option value="openai">OpenAI / Azure OpenAI</option>
+              <option value="openai_compatible">OpenAI-compatible (Ollama, vLLM, llama.cpp)</option>
+            </select>
+            <p className="text-xs font-mono text-fog mt-2">
+              OpenAI-compatible servers need a base URL below; the API key is optional and calls are logged at no cost
+            </p>
+          </div>
+
           {/* API Key */}
           <div>
             <label className="block text-sm font-mono text-chalk font-bold mb-2">
@@ -203,6 +261,40 @@ export function OpenAIConfigTab() {
             </p>
           </div>
 
+          {/* Fallback Models */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              FALLBACK MODELS
+            </label>
+            <input
+              type="text"
+              value={fallbackModels}
+              onChange={(e) => setFallbackModels(e.target.value)}
+              placeholder="gpt-4o-mini, gpt-3.5-turbo"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-sm focus:border-terminal focus:outline-none transition-colors"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Comma-separated, tried in order when the model above fails or is over quota. Served by the same endpoint. Leave empty to disable
+            </p>
+          </div>
+
+          {/* Category Mapping */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              CATEGORY MAPPING
+            </label>
+            <input
+              type="text"
+              value={categoryMapping}
+              onChange={(e) => setCategoryMapping(e.target.value)}
+              placeholder="security=military, terrorism=geopolitics"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-sm focus:border-terminal focus:outline-none transition-colors"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Comma-separated raw=category pairs folding categories returned by the model into the event categories. Applied after enrichment; the enricher picks up changes when it restarts
+            </p>
+          </div>
+
           {/* Temperature */}
           <div>
             <label className="block text-sm font-mono text-chalk font-bold mb-2">
@@ -257,6 +349,54 @@ export function OpenAIConfigTab() {
               Request timeout in seconds (recommended: 30-60)
             </p>
           </div>
+
+          {/* Endpoint */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              BASE URL
+            </label>
+            <input
+              type="text"
+              value={config.base_url}
+              onChange={(e) => setConfig({ ...config, base_url: e.target.value })}
+              placeholder="https://api.openai.com/v1"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-sm focus:border-terminal focus:outline-none transition-colors"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Leave empty for the public OpenAI API; for Azure, the resource endpoint
+            </p>
+          </div>
+
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              AZURE DEPLOYMENT
+            </label>
+            <input
+              type="text"
+              value={config.azure_deployment}
+              onChange={(e) => setConfig({ ...config, azure_deployment: e.target.value })}
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-sm focus:border-terminal focus:outline-none transition-colors"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Azure OpenAI deployment name (leave empty when not using Azure)
+            </p>
+          </div>
+
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              AZURE API VERSION
+            </label>
+            <input
+              type="text"
+              value={config.azure_api_version}
+              onChange={(e) => setConfig({ ...config, azure_api_version: e.target.value })}
+              placeholder="2024-06-01"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-sm focus:border-terminal focus:outline-none transition-colors"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Azure api-version (leave empty for the default)
+            </p>
+          </div>
         </div>
       </div>
 
@@ -313,6 +453,121 @@ export function OpenAIConfigTab() {
               Prompt for extracting named entities from text
             </p>
           </div>
+
+          {/* Correlation System Prompt */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              CORRELATION SYSTEM PROMPT
+            </label>
+            <textarea
+              value={config.correlation_system_prompt}
+              onChange={(e) => setConfig({ ...config, correlation_system_prompt: e.target.value })}
+              rows={6}
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-xs focus:border-terminal focus:outline-none transition-colors resize-y"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              System prompt for deciding whether a new source belongs to an existing event
+            </p>
+          </div>
+
+          {/* Correlation Template */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              CORRELATION TEMPLATE
+            </label>
+            <textarea
+              value={config.correlation_template}
+              onChange={(e) => setConfig({ ...config, correlation_template: e.target.value })}
+              rows={6}
+              placeholder="Leave empty to use the built-in template"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-xs focus:border-terminal focus:outline-none transition-colors resize-y"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Comparison prompt (requires &#123;&#123;.EventTitle&#125;&#125; and &#123;&#123;.SourceContent&#125;&#125;; also &#123;&#123;.EventSummary&#125;&#125;, &#123;&#123;.EventCategory&#125;&#125;, &#123;&#123;.KeyFacts&#125;&#125;, &#123;&#123;.SourceTitle&#125;&#125;, &#123;&#123;.SourceURL&#125;&#125;, &#123;&#123;.SourcePublished&#125;&#125;)
+            </p>
+          </div>
+
+          {/* Credibility System Prompt */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              CREDIBILITY SYSTEM PROMPT
+            </label>
+            <textarea
+              value={config.credibility_system_prompt}
+              onChange={(e) => setConfig({ ...config, credibility_system_prompt: e.target.value })}
+              rows={3}
+              placeholder="Leave empty to use the built-in prompt"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-xs focus:border-terminal focus:outline-none transition-colors resize-y"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              System prompt for scoring the credibility of new sources
+            </p>
+          </div>
+
+          {/* Credibility Template */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              CREDIBILITY TEMPLATE
+            </label>
+            <textarea
+              value={config.credibility_template}
+              onChange={(e) => setConfig({ ...config, credibility_template: e.target.value })}
+              rows={6}
+              placeholder="Leave empty to use the built-in template"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-xs focus:border-terminal focus:outline-none transition-colors resize-y"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Scoring prompt; the model must reply with a number from 0.0 to 1.0 (requires &#123;&#123;.URL&#125;&#125;; also &#123;&#123;.SourceType&#125;&#125;)
+            </p>
+          </div>
+
+          {/* Prompt A/B Test */}
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              PROMPT B FRACTION: {config.prompt_b_fraction.toFixed(2)}
+            </label>
+            <input
+              type="range"
+              min="0"
+              max="1"
+              step="0.05"
+              value={config.prompt_b_fraction}
+              onChange={(e) => setConfig({ ...config, prompt_b_fraction: parseFloat(e.target.value) })}
+              className="w-full"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Share of sources enriched with prompt B instead of the prompts above. 0 turns the A/B test off; compare results under Admin API /api/admin/events/prompt-variants
+            </p>
+          </div>
+
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              PROMPT B SYSTEM PROMPT
+            </label>
+            <textarea
+              value={config.prompt_b_system_prompt}
+              onChange={(e) => setConfig({ ...config, prompt_b_system_prompt: e.target.value })}
+              rows={6}
+              placeholder="Leave empty to use the system prompt above"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-xs focus:border-terminal focus:outline-none transition-colors resize-y"
+            />
+          </div>
+
+          <div>
+            <label className="block text-sm font-mono text-chalk font-bold mb-2">
+              PROMPT B ANALYSIS TEMPLATE
+            </label>
+            <textarea
+              value={config.prompt_b_analysis_template}
+              onChange={(e) => setConfig({ ...config, prompt_b_analysis_template: e.target.value })}
+              rows={6}
+              placeholder="Leave empty to use the analysis template above"
+              className="w-full px-4 py-3 bg-void border-2 border-steel text-chalk font-mono text-xs focus:border-terminal focus:outline-none transition-colors resize-y"
+            />
+            <p className="text-xs font-mono text-fog mt-2">
+              Requires &#123;&#123;.RawContent&#125;&#125;
+            </p>
+          </div>
         </div>
       </div>
 
@@ -48,6 +48,8 @@ interface PipelineMetrics {
     completed: number;
     failed: number;
   };
+  enrichment_workers: number;
+  enrichment_workers_active: number;
   sources_recent_count: number;
   events_total: number;
   events_by_status: {
@@ -63,8 +65,25 @@ interface PipelineMetrics {
   bottleneck_reason: string;
 }
 
+interface PipelineStatus {
+  oldest_pending_age_seconds: number;
+  events_per_minute: number;
+  throughput_window_minutes: number;
+  open_errors_by_category: Record<string, number>;
+  open_errors_total: number;
+  enricher: string;
+  enricher_mock: boolean;
+}
+
+const formatAge = (seconds: number) => {
+  if (seconds < 60) return `${Math.round(seconds)}s`;
+  if (seconds < 3600) return `${Math.round(seconds / 60)}m`;
+  return `${(seconds / 3600).toFixed(1)}h`;
+};
+
 export function PipelineFunnelTab() {
   const [metrics, setMetrics] = useState<PipelineMetrics | null>(null);
+  const [status, setStatus] = useState<PipelineStatus | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
   const [requeueing, setRequeueing] = useState(false);
@@ -99,6 +118,26 @@ export function PipelineFunnelTab() {
     return () => clearInterval(interval);
   }, []);
 
+  // Fetch pipeline health status
+  useEffect(() => {
+    const fetchStatus = async () => {
+      try {
+        const response = await fetch(`${API_BASE_URL}/api/pipeline/status`, {
+          headers: getAuthHeaders(),
+        });
+        if (!response.ok) throw new Error('Failed to fetch pipeline status');
+        setStatus(await response.json());
+      } catch (err) {
+        console.error('Error fetching pipeline status:', err);
+      }
+    };
+
+    fetchStatus();
+    // Refresh every 10 seconds
+    const interval = setInterval(fetchStatus, 10000);
+    return () => clearInterval(interval);
+  }, []);
+
   // Fetch recent enrichments
   useEffect(() => {
     const fetchEnrichments = async () => {
@@ -386,6 +425,41 @@ export function PipelineFunnelTab() {
         </div>
       )}
 
+      {/* Health Panel */}
+      {status && (
+        <div className="grid grid-cols-4 gap-4">
+          <div className="border-2 border-steel bg-concrete p-4">
+            <span className="text-xs font-mono text-smoke font-medium">ENRICHER</span>
+            <div className={`text-2xl font-mono font-bold uppercase ${status.enricher_mock ? 'text-warning' : 'text-terminal'}`}>
+              {status.enricher}
+            </div>
+          </div>
+          <div className="border-2 border-steel bg-concrete p-4">
+            <span className="text-xs font-mono text-smoke font-medium">
+              EVENTS/MIN ({status.throughput_window_minutes}M)
+            </span>
+            <div className="text-2xl font-mono font-bold text-chalk">{status.events_per_minute.toFixed(2)}</div>
+          </div>
+          <div className="border-2 border-steel bg-concrete p-4">
+            <span className="text-xs font-mono text-smoke font-medium">OLDEST PENDING SOURCE</span>
+            <div className="text-2xl font-mono font-bold text-chalk">
+              {status.oldest_pending_age_seconds > 0 ? formatAge(status.oldest_pending_age_seconds) : '-'}
+            </div>
+          </div>
+          <div className="border-2 border-steel bg-concrete p-4">
+            <span className="text-xs font-mono text-smoke font-medium">OPEN INGESTION ERRORS</span>
+            <div className={`text-2xl font-mono font-bold ${status.open_errors_total > 0 ? 'text-warning' : 'text-chalk'}`}>
+              {status.open_errors_total}
+            </div>
+            <div className="text-xs font-mono text-fog mt-1">
+              {Object.entries(status.open_errors_by_category)
+                .map(([category, count]) => `${category} ${count}`)
+                .join(' · ')}
+            </div>
+          </div>
+        </div>
+      )}
+
       {/* Visual Funnel */}
       <div className="space-y-4">
         {/* Stage 1: RSS Ingestion */}
@@ -429,6 +503,7 @@ export function PipelineFunnelTab() {
             { label: 'Pending', value: metrics.enrichment_by_status.pending, color: 'text-smoke' },
             { label: 'Enriching', value: metrics.enrichment_by_status.enriching, color: 'text-terminal' },
             { label: 'Failed', value: metrics.enrichment_by_status.failed, color: 'text-threat-critical' },
+            { label: `Workers (of ${metrics.enrichment_workers})`, value: metrics.enrichment_workers_active, color: 'text-terminal' },
           ]}
         />
 
@@ -14,6 +14,7 @@ interface Strategy {
   iterations: number;
   forecast_ids: string[];
   forecast_history_count: number;
+  structured_output: boolean;
   models?: StrategyModel[];
   active: boolean;
   public: boolean;
@@ -581,6 +582,7 @@ function CreateStrategyModal({ onClose, onSuccess }: { onClose: () => void; onSu
   const [headlineCount, setHeadlineCount] = useState(100);
   const [iterations, setIterations] = useState(3);
   const [forecastHistoryCount, setForecastHistoryCount] = useState(1);
+  const [structuredOutput, setStructuredOutput] = useState(false);
   const [models, setModels] = useState<StrategyModel[]>([
     { provider: 'anthropic', model_name: 'claude-sonnet-4-20250514', api_key: '', weight: 1.0 },
   ]);
@@ -648,6 +650,7 @@ function CreateStrategyModal({ onClose, onSuccess }: { onClose: () => void; onSu
           headline_count: headlineCount,
           iterations,
           forecast_history_count: forecastHistoryCount,
+          structured_output: structuredOutput,
           forecast_ids: [],
           models,
         }),
@@ -826,6 +829,20 @@ function CreateStrategyModal({ onClose, onSuccess }: { onClose: () => void; onSu
             <p className="text-xs font-mono text-fog">Number of past forecast runs to include in strategy context</p>
           </div>
 
+          {/* Structured Output */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={structuredOutput}
+                onChange={(e) => setStructuredOutput(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">STRUCTURED OUTPUT</span>
+            </label>
+            <p className="text-xs font-mono text-fog">Request JSON-schema output from models; falls back to text parsing when unsupported</p>
+          </div>
+
           {/* Models */}
           <div className="space-y-3">
             <div className="flex justify-between items-center">
@@ -940,6 +957,7 @@ function EditStrategyModal({ strategy, onClose, onSuccess }: { strategy: Strateg
   const [headlineCount, setHeadlineCount] = useState(strategy.headline_count);
   const [iterations, setIterations] = useState(strategy.iterations);
   const [forecastHistoryCount, setForecastHistoryCount] = useState(strategy.forecast_history_count || 1);
+  const [structuredOutput, setStructuredOutput] = useState(strategy.structured_output || false);
   const [forecastIds, setForecastIds] = useState<string[]>(strategy.forecast_ids || []);
   const [models, setModels] = useState<StrategyModel[]>(
     strategy.models && strategy.models.length > 0
@@ -1057,6 +1075,7 @@ function EditStrategyModal({ strategy, onClose, onSuccess }: { strategy: Strateg
           headline_count: headlineCount,
           iterations,
           forecast_history_count: forecastHistoryCount,
+          structured_output: structuredOutput,
           forecast_ids: forecastIds,
           models,
         }),
@@ -1271,6 +1290,20 @@ function EditStrategyModal({ strategy, onClose, onSuccess }: { strategy: Strateg
             <p className="text-xs font-mono text-fog">Number of past forecast runs to include in strategy context</p>
           </div>
 
+          {/* Structured Output */}
+          <div className="space-y-2">
+            <label className="flex items-center gap-2 cursor-pointer">
+              <input
+                type="checkbox"
+                checked={structuredOutput}
+                onChange={(e) => setStructuredOutput(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <span className="text-sm font-mono text-chalk font-bold">STRUCTURED OUTPUT</span>
+            </label>
+            <p className="text-xs font-mono text-fog">Request JSON-schema output from models; falls back to text parsing when unsupported</p>
+          </div>
+
           {/* Models */}
           <div className="space-y-3">
             <div className="flex justify-between items-center">
@@ -1408,6 +1441,7 @@ function DuplicateStrategyModal({ strategy, onClose, onSuccess }: { strategy: St
                   body: JSON.stringify({
                     ...fullStrategy,
                     name: fullStrategy.name + ' (Copy)',
+                    duplicate_of: strategy.id,
                     id: undefined,
                     created_at: undefined,
                     updated_at: undefined,
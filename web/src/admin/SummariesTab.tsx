@@ -18,6 +18,11 @@ interface Summary {
   schedule_interval: number;
   auto_post_to_twitter: boolean;
   include_forecasts: boolean;
+  mode: 'narrative' | 'digest';
+  digest_per_category: number;
+  email_enabled: boolean;
+  email_recipients: string[];
+  format: 'text' | 'markdown';
   last_run_at?: string;
   next_run_at?: string;
   created_at: string;
@@ -41,6 +46,9 @@ interface SummaryRun {
   status: string;
   error_message?: string;
   completed_at?: string;
+  email_status?: 'sent' | 'failed';
+  email_error?: string;
+  email_sent_at?: string;
 }
 
 interface SummaryResult {
@@ -49,12 +57,48 @@ interface SummaryResult {
   summary_text: string;
   model_provider: string;
   model_name: string;
+  format: 'text' | 'markdown';
+  html?: string;
   created_at: string;
 }
 
+// Classes for server-rendered markdown results
+const markdownClasses = 'font-sans text-sm text-chalk mb-3 space-y-2 [&_h1]:font-bold [&_h2]:font-bold [&_h3]:font-bold [&_h1]:text-terminal [&_h2]:text-terminal [&_h3]:text-terminal [&_ul]:list-disc [&_ul]:pl-5 [&_ol]:list-decimal [&_ol]:pl-5 [&_a]:text-terminal [&_a]:underline [&_code]:font-mono';
+
+interface SummaryCitation {
+  event_id: string;
+  title: string;
+  category: string;
+  magnitude: number;
+  timestamp: string;
+  url?: string;
+}
+
 interface SummaryRunDetail {
   run: SummaryRun;
   results: SummaryResult[];
+  citations?: SummaryCitation[];
+}
+
+// Citations lists the events a run was built from, linking back to each event
+function Citations({ citations }: { citations?: SummaryCitation[] }) {
+  if (!citations || citations.length === 0) return null;
+
+  return (
+    <div className="bg-concrete border border-steel p-4">
+      <h5 className="font-mono font-bold text-xs text-terminal mb-2">SOURCES CITED ({citations.length})</h5>
+      <ol className="list-decimal pl-5 space-y-1 font-mono text-xs text-chalk">
+        {citations.map((c) => (
+          <li key={c.event_id}>
+            <a href={c.url || `/events/${c.event_id}`} target="_blank" rel="noopener noreferrer" className="hover:text-terminal underline">
+              {c.title}
+            </a>
+            <span className="text-fog"> ({c.category}, magnitude {c.magnitude.toFixed(1)})</span>
+          </li>
+        ))}
+      </ol>
+    </div>
+  );
 }
 
 export function SummariesTab() {
@@ -317,9 +361,15 @@ function SummaryCard({ summary, onEdit, onRefresh }: { summary: Summary; onEdit:
               <span className="text-smoke">
                 Lookback: <span className="text-terminal font-bold">{summary.lookback_hours}h</span>
               </span>
-              <span className="text-smoke">
-                Headlines: <span className="text-terminal font-bold">{summary.headline_count}</span>
-              </span>
+              {summary.mode === 'digest' ? (
+                <span className="text-smoke">
+                  Digest: <span className="text-terminal font-bold">top {summary.digest_per_category}/category</span>
+                </span>
+              ) : (
+                <span className="text-smoke">
+                  Headlines: <span className="text-terminal font-bold">{summary.headline_count}</span>
+                </span>
+              )}
               {(summary.categories || []).length > 0 && (
                 <span className="text-smoke">
                   Categories: <span className="text-terminal font-bold">{(summary.categories || []).join(', ')}</span>
@@ -381,9 +431,13 @@ function SummaryCard({ summary, onEdit, onRefresh }: { summary: Summary; onEdit:
                     {formatDateTime(result.created_at)}
                   </span>
                 </div>
-                <div className="font-mono text-sm text-chalk whitespace-pre-wrap mb-3">
-                  {result.summary_text}
-                </div>
+                {result.html ? (
+                  <div className={markdownClasses} dangerouslySetInnerHTML={{ __html: result.html }} />
+                ) : (
+                  <div className="font-mono text-sm text-chalk whitespace-pre-wrap mb-3">
+                    {result.summary_text}
+                  </div>
+                )}
                 <button
                   onClick={() => postToTwitter(latestResult.run.id, result.id)}
                   disabled={postingToTwitter.has(result.id)}
@@ -403,6 +457,7 @@ function SummaryCard({ summary, onEdit, onRefresh }: { summary: Summary; onEdit:
                 </button>
               </div>
             ))}
+            <Citations citations={latestResult.citations} />
           </div>
         </div>
       )}
@@ -434,6 +489,16 @@ function SummaryCard({ summary, onEdit, onRefresh }: { summary: Summary; onEdit:
                       }`}>
                         {run.status.toUpperCase()}
                       </span>
+                      {run.email_status && (
+                        <span
+                          className={`ml-2 px-2 py-1 ${
+                            run.email_status === 'sent' ? 'bg-terminal/20 text-terminal' : 'bg-red-500/20 text-red-400'
+                          }`}
+                          title={run.email_error || (run.email_sent_at ? `Sent ${formatDateTime(run.email_sent_at)}` : undefined)}
+                        >
+                          EMAIL {run.email_status.toUpperCase()}
+                        </span>
+                      )}
                     </div>
                     {run.completed_at && (
                       <span className="font-mono text-xs text-fog">
@@ -462,9 +527,13 @@ function SummaryCard({ summary, onEdit, onRefresh }: { summary: Summary; onEdit:
                                   {formatDateTime(result.created_at)}
                                 </span>
                               </div>
-                              <div className="font-mono text-sm text-chalk whitespace-pre-wrap mb-3">
-                                {result.summary_text}
-                              </div>
+                              {result.html ? (
+                                <div className={markdownClasses} dangerouslySetInnerHTML={{ __html: result.html }} />
+                              ) : (
+                                <div className="font-mono text-sm text-chalk whitespace-pre-wrap mb-3">
+                                  {result.summary_text}
+                                </div>
+                              )}
                               <button
                                 onClick={() => postToTwitter(selectedRunDetail.run.id, result.id)}
                                 disabled={postingToTwitter.has(result.id)}
@@ -484,6 +553,7 @@ function SummaryCard({ summary, onEdit, onRefresh }: { summary: Summary; onEdit:
                               </button>
                             </div>
                           ))}
+                          <Citations citations={selectedRunDetail.citations} />
                         </div>
                       ) : selectedRunDetail?.run.status === 'failed' ? (
                         <div className="text-center py-4">
@@ -521,6 +591,11 @@ function CreateSummaryModal({ onClose, onSuccess }: { onClose: () => void; onSuc
   ]);
   const [autoPostToTwitter, setAutoPostToTwitter] = useState(false);
   const [includeForecasts, setIncludeForecasts] = useState(false);
+  const [mode, setMode] = useState<'narrative' | 'digest'>('narrative');
+  const [digestPerCategory, setDigestPerCategory] = useState(3);
+  const [emailEnabled, setEmailEnabled] = useState(false);
+  const [emailRecipients, setEmailRecipients] = useState('');
+  const [format, setFormat] = useState<'text' | 'markdown'>('text');
   const [creating, setCreating] = useState(false);
 
   const availableCategories = ['geopolitics', 'military', 'economic', 'cyber', 'disaster', 'terrorism', 'diplomacy', 'intelligence', 'humanitarian'];
@@ -577,6 +652,11 @@ function CreateSummaryModal({ onClose, onSuccess }: { onClose: () => void; onSuc
           active: true,
           auto_post_to_twitter: autoPostToTwitter,
           include_forecasts: includeForecasts,
+          mode,
+          digest_per_category: digestPerCategory,
+          email_enabled: emailEnabled,
+          email_recipients: emailRecipients.split(/[\s,;]+/).filter(Boolean),
+          format,
         }),
       });
 
@@ -687,6 +767,76 @@ function CreateSummaryModal({ onClose, onSuccess }: { onClose: () => void; onSuc
             </div>
           </div>
 
+          {/* Mode */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">MODE</label>
+            <div className="flex gap-2">
+              {(['narrative', 'digest'] as const).map(m => (
+                <button
+                  key={m}
+                  type="button"
+                  onClick={() => setMode(m)}
+                  className={`px-3 py-1 text-xs font-mono border-2 transition-colors ${
+                    mode === m
+                      ? 'border-terminal bg-terminal text-void'
+                      : 'border-steel bg-void text-fog hover:border-iron'
+                  }`}
+                >
+                  {m.toUpperCase()}
+                </button>
+              ))}
+            </div>
+            <p className="text-xs font-mono text-fog">
+              {mode === 'digest'
+                ? 'Per-category rollup: top events by magnitude in each category, one-line takeaway each'
+                : 'Single summary of the latest headlines'}
+            </p>
+          </div>
+
+          {/* Format */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">FORMAT</label>
+            <div className="flex gap-2">
+              {(['text', 'markdown'] as const).map(f => (
+                <button
+                  key={f}
+                  type="button"
+                  onClick={() => setFormat(f)}
+                  className={`px-3 py-1 text-xs font-mono border-2 transition-colors ${
+                    format === f
+                      ? 'border-terminal bg-terminal text-void'
+                      : 'border-steel bg-void text-fog hover:border-iron'
+                  }`}
+                >
+                  {f.toUpperCase()}
+                </button>
+              ))}
+            </div>
+            <p className="text-xs font-mono text-fog">
+              {format === 'markdown'
+                ? 'Markdown with event links and magnitudes; stripped to plain text for Twitter'
+                : 'Plain text, suitable for Twitter'}
+            </p>
+          </div>
+
+          {mode === 'digest' && (
+            <div className="space-y-2">
+              <label className="block text-sm font-mono text-chalk font-bold">
+                EVENTS PER CATEGORY: {digestPerCategory}
+              </label>
+              <input
+                type="range"
+                min="1"
+                max="20"
+                step="1"
+                value={digestPerCategory}
+                onChange={(e) => setDigestPerCategory(parseInt(e.target.value))}
+                className="w-full"
+              />
+              <p className="text-xs font-mono text-fog">Top events by magnitude to include from each category</p>
+            </div>
+          )}
+
           {/* Headline Count */}
           <div className="space-y-2">
             <label className="block text-sm font-mono text-chalk font-bold">
@@ -813,6 +963,32 @@ function CreateSummaryModal({ onClose, onSuccess }: { onClose: () => void; onSuc
               <p className="text-xs text-fog mt-1">Include current forecast probabilities in the summary prompt</p>
             </label>
           </div>
+
+          {/* Email Delivery */}
+          <div className="p-4 border-2 border-steel bg-void/20 space-y-3">
+            <div className="flex items-center gap-3">
+              <input
+                type="checkbox"
+                id="email-enabled"
+                checked={emailEnabled}
+                onChange={(e) => setEmailEnabled(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <label htmlFor="email-enabled" className="text-sm font-mono text-chalk cursor-pointer">
+                <span className="font-bold">Email Scheduled Runs</span>
+                <p className="text-xs text-fog mt-1">Email the first summary result to the recipients below when a scheduled run completes</p>
+              </label>
+            </div>
+            {emailEnabled && (
+              <input
+                type="text"
+                value={emailRecipients}
+                onChange={(e) => setEmailRecipients(e.target.value)}
+                placeholder="analyst@example.com, team@example.com"
+                className="w-full px-3 py-2 border border-steel bg-void text-chalk font-mono text-sm focus:border-terminal focus:outline-none"
+              />
+            )}
+          </div>
         </div>
 
         {/* Footer */}
@@ -857,6 +1033,11 @@ function EditSummaryModal({ summary, onClose, onSuccess }: { summary: Summary; o
   );
   const [autoPostToTwitter, setAutoPostToTwitter] = useState(summary.auto_post_to_twitter || false);
   const [includeForecasts, setIncludeForecasts] = useState(summary.include_forecasts || false);
+  const [mode, setMode] = useState<'narrative' | 'digest'>(summary.mode || 'narrative');
+  const [digestPerCategory, setDigestPerCategory] = useState(summary.digest_per_category || 3);
+  const [emailEnabled, setEmailEnabled] = useState(summary.email_enabled || false);
+  const [emailRecipients, setEmailRecipients] = useState((summary.email_recipients || []).join(', '));
+  const [format, setFormat] = useState<'text' | 'markdown'>(summary.format || 'text');
   const [updating, setUpdating] = useState(false);
 
   const availableCategories = ['geopolitics', 'military', 'economic', 'cyber', 'disaster', 'terrorism', 'diplomacy', 'intelligence', 'humanitarian'];
@@ -913,6 +1094,11 @@ function EditSummaryModal({ summary, onClose, onSuccess }: { summary: Summary; o
           active: true,
           auto_post_to_twitter: autoPostToTwitter,
           include_forecasts: includeForecasts,
+          mode,
+          digest_per_category: digestPerCategory,
+          email_enabled: emailEnabled,
+          email_recipients: emailRecipients.split(/[\s,;]+/).filter(Boolean),
+          format,
         }),
       });
 
@@ -1014,6 +1200,76 @@ function EditSummaryModal({ summary, onClose, onSuccess }: { summary: Summary; o
             </div>
           </div>
 
+          {/* Mode */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">MODE</label>
+            <div className="flex gap-2">
+              {(['narrative', 'digest'] as const).map(m => (
+                <button
+                  key={m}
+                  type="button"
+                  onClick={() => setMode(m)}
+                  className={`px-3 py-1 text-xs font-mono border-2 transition-colors ${
+                    mode === m
+                      ? 'border-terminal bg-terminal text-void'
+                      : 'border-steel bg-void text-fog hover:border-iron'
+                  }`}
+                >
+                  {m.toUpperCase()}
+                </button>
+              ))}
+            </div>
+            <p className="text-xs font-mono text-fog">
+              {mode === 'digest'
+                ? 'Per-category rollup: top events by magnitude in each category, one-line takeaway each'
+                : 'Single summary of the latest headlines'}
+            </p>
+          </div>
+
+          {/* Format */}
+          <div className="space-y-2">
+            <label className="block text-sm font-mono text-chalk font-bold">FORMAT</label>
+            <div className="flex gap-2">
+              {(['text', 'markdown'] as const).map(f => (
+                <button
+                  key={f}
+                  type="button"
+                  onClick={() => setFormat(f)}
+                  className={`px-3 py-1 text-xs font-mono border-2 transition-colors ${
+                    format === f
+                      ? 'border-terminal bg-terminal text-void'
+                      : 'border-steel bg-void text-fog hover:border-iron'
+                  }`}
+                >
+                  {f.toUpperCase()}
+                </button>
+              ))}
+            </div>
+            <p className="text-xs font-mono text-fog">
+              {format === 'markdown'
+                ? 'Markdown with event links and magnitudes; stripped to plain text for Twitter'
+                : 'Plain text, suitable for Twitter'}
+            </p>
+          </div>
+
+          {mode === 'digest' && (
+            <div className="space-y-2">
+              <label className="block text-sm font-mono text-chalk font-bold">
+                EVENTS PER CATEGORY: {digestPerCategory}
+              </label>
+              <input
+                type="range"
+                min="1"
+                max="20"
+                step="1"
+                value={digestPerCategory}
+                onChange={(e) => setDigestPerCategory(parseInt(e.target.value))}
+                className="w-full"
+              />
+              <p className="text-xs font-mono text-fog">Top events by magnitude to include from each category</p>
+            </div>
+          )}
+
           {/* Headline Count */}
           <div className="space-y-2">
             <label className="block text-sm font-mono text-chalk font-bold">
@@ -1119,6 +1375,32 @@ function EditSummaryModal({ summary, onClose, onSuccess }: { summary: Summary; o
               <p className="text-xs text-fog mt-1">Include current forecast probabilities in the summary prompt</p>
             </label>
           </div>
+
+          {/* Email Delivery */}
+          <div className="p-4 border-2 border-steel bg-void/20 space-y-3">
+            <div className="flex items-center gap-3">
+              <input
+                type="checkbox"
+                id="edit-email-enabled"
+                checked={emailEnabled}
+                onChange={(e) => setEmailEnabled(e.target.checked)}
+                className="w-4 h-4"
+              />
+              <label htmlFor="edit-email-enabled" className="text-sm font-mono text-chalk cursor-pointer">
+                <span className="font-bold">Email Scheduled Runs</span>
+                <p className="text-xs text-fog mt-1">Email the first summary result to the recipients below when a scheduled run completes</p>
+              </label>
+            </div>
+            {emailEnabled && (
+              <input
+                type="text"
+                value={emailRecipients}
+                onChange={(e) => setEmailRecipients(e.target.value)}
+                placeholder="analyst@example.com, team@example.com"
+                className="w-full px-3 py-2 border border-steel bg-void text-chalk font-mono text-sm focus:border-terminal focus:outline-none"
+              />
+            )}
+          </div>
         </div>
 
         {/* Footer */}
@@ -61,6 +61,12 @@ export function EventCard({ event, index }: EventCardProps) {
               [{event.category.toUpperCase()}]
             </Link>
 
+          {event.is_breaking && (
+            <span className="px-2 py-1 border-2 font-bold text-xs text-threat-critical border-threat-critical/30 bg-threat-critical/10 animate-pulse">
+              BREAKING
+            </span>
+          )}
+
           <div className="flex items-center gap-2">
             <span className="text-smoke">MAG:</span>
             <span className={`font-bold ${getMagnitudeColor(event.magnitude)}`}>
@@ -37,6 +37,13 @@ interface OHLCDataPoint {
   close: number;
 }
 
+interface ModelEstimate {
+  label: string;
+  percentile_predictions?: { p10: number; p25: number; p50: number; p75: number; p90: number };
+  point_estimate?: number;
+  probability?: number;
+}
+
 interface PublicForecastChartProps {
   forecastId: string;
   viewMode: 'hourly' | '4h' | 'daily';
@@ -112,6 +119,7 @@ export function PublicForecastChart({ forecastId, viewMode }: PublicForecastChar
   const [hourlyData, setHourlyData] = useState<ChartDataPoint[]>([]);
   const [fourHourData, setFourHourData] = useState<OHLCDataPoint[]>([]);
   const [dailyData, setDailyData] = useState<OHLCDataPoint[]>([]);
+  const [modelEstimates, setModelEstimates] = useState<ModelEstimate[]>([]);
   const [error, setError] = useState<string | null>(null);
 
   useEffect(() => {
@@ -124,7 +132,7 @@ export function PublicForecastChart({ forecastId, viewMode }: PublicForecastChar
       setError(null);
 
       // Fetch hourly data
-      const hourlyResponse = await fetch(`${API_BASE_URL}/api/forecasts/${forecastId}/history`);
+      const hourlyResponse = await fetch(`${API_BASE_URL}/api/forecasts/${forecastId}/history?include_models=true`);
 
       if (!hourlyResponse.ok) {
         throw new Error('Failed to fetch forecast history');
@@ -166,6 +174,10 @@ export function PublicForecastChart({ forecastId, viewMode }: PublicForecastChar
 
       setHourlyData(hourlyChartData);
 
+      // Per-model spread of the latest run, anonymized by the API
+      const latest = history.length > 0 ? history[history.length - 1] : null;
+      setModelEstimates(latest?.model_estimates || []);
+
       // Fetch 4-hour OHLC data
       const fourHourResponse = await fetch(`${API_BASE_URL}/api/forecasts/${forecastId}/history/4h`);
 
@@ -356,6 +368,27 @@ export function PublicForecastChart({ forecastId, viewMode }: PublicForecastChar
         <LightweightOHLCChart data={viewMode === '4h' ? fourHourData : dailyData} />
       )}
 
+      {modelEstimates.length > 1 && (
+        <div className="mt-4 border border-steel p-3 font-mono text-xs">
+          <p className="text-smoke mb-2">Latest run by model</p>
+          <div className="space-y-1">
+            {modelEstimates.map((estimate) => {
+              const p = estimate.percentile_predictions;
+              const value = p ? p.p50 : (estimate.probability ?? estimate.point_estimate);
+              return (
+                <p key={estimate.label} className="text-fog">
+                  <span className="text-chalk font-bold">{estimate.label}:</span>{' '}
+                  {value !== undefined ? `${value.toFixed(2)}%` : '—'}
+                  {p && (
+                    <span className="text-smoke"> (P10 {p.p10.toFixed(2)}% – P90 {p.p90.toFixed(2)}%)</span>
+                  )}
+                </p>
+              );
+            })}
+          </div>
+        </div>
+      )}
+
       <div className="mt-4 text-xs font-mono text-fog">
         {viewMode === 'hourly' ? (
           <>
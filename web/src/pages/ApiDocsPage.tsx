@@ -25,6 +25,7 @@ const endpoints: Endpoint[] = [
       { name: 'status', type: 'string', description: 'Filter by status: published, rejected, pending', required: false },
       { name: 'categories', type: 'string[]', description: 'Filter by categories (comma-separated)', required: false },
       { name: 'since', type: 'timestamp', description: 'Filter events after this timestamp', required: false },
+      { name: 'semantic_query', type: 'string', description: 'Find events by meaning, ranked by similarity (e.g. "unrest near energy infrastructure"); requires authentication', required: false },
     ],
     response: '{ events: Event[], count: int, query: EventQuery }',
     example: 'curl http://localhost:8080/api/events?limit=20&status=published',
@@ -53,6 +54,13 @@ const endpoints: Endpoint[] = [
     response: '{ uptime_seconds: int, total_events: int, total_sources: int, avg_confidence: float, enrichment_rate: float }',
     example: 'curl http://localhost:8080/api/stats',
   },
+  {
+    method: 'GET',
+    path: '/api/events/:id/related',
+    description: 'Recent published events sharing entities, tags or source URLs with an event, ranked by overlap score. Supports days (default 7, max 90), limit (default 10, max 50) and offset',
+    response: '{ query: RelatedEventsQuery, total: int, has_more: bool, related: RelatedEvent[] }',
+    example: 'curl http://localhost:8080/api/events/evt-123/related?limit=5',
+  },
   {
     method: 'GET',
     path: '/api/sources',
@@ -67,6 +75,13 @@ const endpoints: Endpoint[] = [
     response: 'PipelineMetrics',
     example: 'curl http://localhost:8080/api/pipeline/metrics',
   },
+  {
+    method: 'GET',
+    path: '/api/pipeline/status',
+    description: 'Pipeline health in one payload: sources by enrichment status, oldest pending source age, events by status, events/min, open ingestion errors by category, and whether the enricher is real or mock',
+    response: 'PipelineStatus',
+    example: 'curl http://localhost:8080/api/pipeline/status',
+  },
   {
     method: 'GET',
     path: '/api/thresholds',
@@ -237,7 +252,8 @@ export function ApiDocsPage() {
   confidence: {
     score: float,
     reasoning: string,
-    source_count: int
+    source_count: int,
+    breakdown: [{ name: string, score: float, weight: float, contribution: float }]
   },
   sources: Source[],
   entities: Entity[],
@@ -3,7 +3,7 @@ import { useParams, Link, useNavigate } from 'react-router-dom';
 import { Header } from '../components/Header';
 import { ShareButtons } from '../components/ShareButtons';
 import { MapPin, ArrowLeft, ExternalLink } from 'lucide-react';
-import type { Event } from '../types';
+import type { Event, RelatedEvent } from '../types';
 import { formatDateTime } from '../utils/dateFormat';
 
 import { API_BASE_URL } from '../utils/api';
@@ -14,6 +14,7 @@ export function EventDetailPage() {
   const [event, setEvent] = useState<Event | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
+  const [related, setRelated] = useState<RelatedEvent[]>([]);
 
   // Fetch event
   useEffect(() => {
@@ -41,6 +42,25 @@ export function EventDetailPage() {
     }
   }, [id]);
 
+  // Fetch related events
+  useEffect(() => {
+    const fetchRelated = async () => {
+      try {
+        const response = await fetch(`${API_BASE_URL}/api/events/${id}/related?limit=5`);
+        if (!response.ok) throw new Error('Failed to fetch related events');
+        const data = await response.json();
+        setRelated(data.related || []);
+      } catch (err) {
+        console.error('Error fetching related events:', err);
+        setRelated([]);
+      }
+    };
+
+    if (id) {
+      fetchRelated();
+    }
+  }, [id]);
+
   const getMagnitudeColor = (magnitude: number) => {
     if (magnitude >= 9.0) return 'text-threat-critical';
     if (magnitude >= 7.0) return 'text-threat-high';
@@ -188,6 +208,26 @@ export function EventDetailPage() {
                 </div>
               )}
 
+              {/* Confidence Breakdown */}
+              {event.confidence.breakdown && event.confidence.breakdown.length > 0 && (
+                <div className="space-y-2">
+                  <h3 className="text-sm font-mono font-bold text-chalk">CONFIDENCE BREAKDOWN</h3>
+                  <div className="p-4 bg-void border border-steel text-xs text-fog font-mono space-y-1">
+                    {event.confidence.breakdown.map((factor) => (
+                      <div key={factor.name} className="flex justify-between gap-4">
+                        <span>
+                          {factor.name.replace(/_/g, ' ')}
+                          {factor.weight !== undefined && ` (${(factor.score ?? 0).toFixed(2)} × ${factor.weight.toFixed(2)})`}
+                        </span>
+                        <span className={factor.contribution < 0 ? 'text-error' : 'text-chalk'}>
+                          {factor.contribution >= 0 ? '+' : ''}{factor.contribution.toFixed(3)}
+                        </span>
+                      </div>
+                    ))}
+                  </div>
+                </div>
+              )}
+
               {/* Entities */}
               {event.entities.length > 0 && (
                 <div className="space-y-3">
@@ -267,6 +307,34 @@ export function EventDetailPage() {
                   ))}
                 </div>
               </div>
+
+              {/* Related Events */}
+              {related.length > 0 && (
+                <div className="space-y-3">
+                  <h3 className="text-sm font-mono font-bold text-chalk">RELATED EVENTS ({related.length})</h3>
+                  <div className="space-y-2">
+                    {related.map((rel) => (
+                      <Link
+                        key={rel.id}
+                        to={`/events/${rel.id}`}
+                        className="block p-4 border border-steel bg-void/30 hover:border-terminal transition-colors"
+                      >
+                        <div className="flex items-start justify-between gap-4">
+                          <div className="font-mono text-sm text-chalk font-bold">{rel.title}</div>
+                          <span className={`font-mono text-sm font-bold ${getMagnitudeColor(rel.magnitude)}`}>
+                            {rel.magnitude.toFixed(1)}
+                          </span>
+                        </div>
+                        <div className="text-xs text-smoke font-mono mt-2">
+                          {formatDateTime(rel.timestamp)}
+                          {rel.shared_entities.length > 0 && ` · ${rel.shared_entities.join(', ')}`}
+                          {rel.shared_sources > 0 && ` · ${rel.shared_sources} shared source(s)`}
+                        </div>
+                      </Link>
+                    ))}
+                  </div>
+                </div>
+              )}
             </div>
           </article>
         </div>
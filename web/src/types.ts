@@ -13,6 +13,26 @@ export interface Event {
   tags: string[];
   location?: Location;
   status: EventStatus;
+  is_breaking?: boolean;
+  rejection_reason?: string;
+  rejection_thresholds?: RejectionThresholds;
+}
+
+// Publication thresholds in effect when an event was rejected
+export interface RejectionThresholds {
+  min_confidence: number;
+  min_magnitude: number;
+  max_source_age_hours: number;
+  min_sources: number;
+  min_source_types?: number;
+  min_source_domains?: number;
+}
+
+export interface ConfidenceFactor {
+  name: string;
+  score?: number;
+  weight?: number;
+  contribution: number;
 }
 
 export interface Confidence {
@@ -20,6 +40,7 @@ export interface Confidence {
   level: 'low' | 'medium' | 'high' | 'verified';
   reasoning: string;
   source_count: number;
+  breakdown?: ConfidenceFactor[];
 }
 
 export type Category =
@@ -66,6 +87,8 @@ export interface Source {
   published_at: string;
   retrieved_at: string;
   credibility: number;
+  media?: string[];
+  external_url?: string;
 }
 
 export type SourceType =
@@ -85,6 +108,18 @@ export interface Location {
   region?: string;
 }
 
+export interface RelatedEvent {
+  id: string;
+  title: string;
+  category: Category;
+  magnitude: number;
+  timestamp: string;
+  shared_entities: string[];
+  shared_tags: string[];
+  shared_sources: number;
+  score: number;
+}
+
 export interface EventResponse {
   events: Event[];
   page: number;
@@ -29,3 +29,47 @@ export function clearAuthToken(): void {
 export function isAuthenticated(): boolean {
   return !!localStorage.getItem('admin_token');
 }
+
+export function getRefreshToken(): string | null {
+  return localStorage.getItem('admin_refresh_token');
+}
+
+export function setRefreshToken(token: string): void {
+  localStorage.setItem('admin_refresh_token', token);
+}
+
+export function clearRefreshToken(): void {
+  localStorage.removeItem('admin_refresh_token');
+}
+
+// Exchange the stored refresh token for a new access token. Refresh tokens are
+// single use, so the rotated one returned by the server replaces the old one.
+export async function refreshAuthToken(apiBaseUrl: string): Promise<boolean> {
+  const refreshToken = getRefreshToken();
+  if (!refreshToken) {
+    return false;
+  }
+
+  try {
+    const response = await fetch(`${apiBaseUrl}/api/auth/refresh`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ refresh_token: refreshToken }),
+    });
+
+    if (!response.ok) {
+      clearRefreshToken();
+      return false;
+    }
+
+    const data = await response.json();
+    setAuthToken(data.token);
+    setRefreshToken(data.refresh_token);
+    return true;
+  } catch (error) {
+    console.error('Token refresh failed:', error);
+    return false;
+  }
+}